@@ -0,0 +1,160 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// ActionHandler runs a custom admin action on the selected records
+type ActionHandler func(ctx context.Context, records []interface{}) error
+
+// AdminAction is a named operation on records, shown as a button above the
+// list (for the checked rows) and on the edit form (for that record):
+//
+//	Actions: []AdminAction{{Name: "Deactivate", Handler: deactivateUsers}}
+type AdminAction struct {
+	Name    string        // Button label, e.g., "Deactivate"
+	Confirm string        // Confirmation prompt (defaults to "Deactivate the selected users?")
+	Handler ActionHandler // Runs the action; its error is shown to the user
+	Sudo    bool          // Ask for the user's password first (see Handler.Sudo)
+}
+
+// Slug is the action's URL segment, e.g., "mark-as-read" for "Mark as read"
+func (a AdminAction) Slug() string {
+	return strings.Join(strings.Fields(strings.ToLower(a.Name)), "-")
+}
+
+// ConfirmMessage returns the prompt shown before running the action
+func (a AdminAction) ConfirmMessage(namePlural string) string {
+	if a.Confirm != "" {
+		return a.Confirm
+	}
+	return fmt.Sprintf("%s the selected %s?", a.Name, strings.ToLower(namePlural))
+}
+
+// Action returns the model's action with the given slug, or nil
+func (c *ModelConfig) Action(slug string) *AdminAction {
+	for i := range c.Actions {
+		if c.Actions[i].Slug() == slug {
+			return &c.Actions[i]
+		}
+	}
+	return nil
+}
+
+// RunAction runs a custom action on the records in the "ids" form values and
+// re-renders the list. Every record must be one the user may modify.
+func (h *Handler) RunAction(w http.ResponseWriter, r *http.Request) {
+	modelName := chi.URLParam(r, "model")
+
+	config, err := h.Registry.Get(modelName)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Model not found")
+		return
+	}
+	action := config.Action(chi.URLParam(r, "action"))
+	if action == nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Action not found")
+		return
+	}
+	if action.Sudo && !h.Sudo.Active(r) {
+		h.Sudo.Prompt(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+	if len(r.Form["ids"]) == 0 {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, fmt.Sprintf("Select the %s to %s", strings.ToLower(config.NamePlural), strings.ToLower(action.Name)))
+		return
+	}
+
+	records := make([]interface{}, 0, len(r.Form["ids"]))
+	for _, v := range r.Form["ids"] {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+		record, err := config.QueryByID(r.Context(), id)
+		if err != nil || h.undo.isPending(config.Name, id) {
+			h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
+			return
+		}
+		if !h.authorizeRecord(w, r, config, record) {
+			return
+		}
+		records = append(records, record)
+	}
+
+	if err := action.Handler(r.Context(), records); err != nil {
+		utils.Warnw("admin.action_failed", "model", config.Name, "action", action.Name, "count", len(records), "error", err)
+		h.Renderer.RenderError(w, r, http.StatusUnprocessableEntity, fmt.Sprintf("%s failed: %v", action.Name, err))
+		return
+	}
+	utils.Infow("admin.action_run", "model", config.Name, "action", action.Name, "count", len(records))
+	for _, record := range records {
+		h.recordActionNamed(r.Context(), adminaction.ActionRun, action.Name, config, getIDValue(record), recordLabel(config, record))
+	}
+
+	// Parse pagination params for the list response
+	page := 1
+	if v := r.URL.Query().Get("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			page = p
+		}
+	}
+	perPage := 20
+	if v := r.URL.Query().Get("per_page"); v != "" {
+		if pp, err := strconv.Atoi(v); err == nil && validPerPage(pp) {
+			perPage = pp
+		}
+	}
+	offset := (page - 1) * perPage
+
+	prefs := h.listPreferences(r, config)
+	totalCount, err := config.CountList(r.Context(), prefs.Filters)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
+		return
+	}
+
+	list, err := config.QueryList(r.Context(), prefs.listOptions(perPage, offset))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
+		return
+	}
+	list = h.withoutPending(config, list)
+
+	totalPages := (totalCount + perPage - 1) / perPage
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	// Actions run from the edit form close it
+	htmx.Trigger(w, "closeFormModal")
+
+	h.Renderer.Render(w, r, "model_list.partial.html", &TemplateData{
+		Flash: fmt.Sprintf("%s ran on %d %s", action.Name, len(records), plural(len(records), strings.ToLower(config.Name))),
+		Data: map[string]interface{}{
+			"Config":     config,
+			"Records":    list,
+			"Page":       page,
+			"PerPage":    perPage,
+			"TotalPages": totalPages,
+			"TotalCount": totalCount,
+			"Prefs":      prefs,
+		},
+	})
+}
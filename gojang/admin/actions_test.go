@@ -0,0 +1,133 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+)
+
+// actionRequest builds a request routed to /admin/{model}/actions/{action} as user
+func actionRequest(user *models.User, model, action string, ids ...string) *http.Request {
+	form := url.Values{"ids": ids}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("model", model)
+	rctx.URLParams.Add("action", action)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = middleware.WithUser(ctx, user)
+	return req.WithContext(ctx)
+}
+
+// TestRunAction_Deactivate tests running the User "Deactivate" action on selected records
+func TestRunAction_Deactivate(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, renderer, client)
+	ctx := context.Background()
+
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	alice := client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").SaveX(ctx)
+	bob := client.User.Create().SetEmail("bob@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	w := httptest.NewRecorder()
+	handler.RunAction(w, actionRequest(admin, "user", "deactivate", alice.ID.String(), bob.ID.String()))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "Deactivate ran on 2 users") {
+		t.Errorf("Expected a confirmation message in the list, got %s", w.Body.String())
+	}
+	for _, u := range []*models.User{alice, bob} {
+		if client.User.GetX(ctx, u.ID).IsActive {
+			t.Errorf("Expected %s to be deactivated", u.Email)
+		}
+	}
+
+	entries := client.AdminAction.Query().AllX(ctx)
+	if len(entries) != 2 {
+		t.Fatalf("Expected one activity entry per record, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.Action != adminaction.ActionRun || e.ActionName != "Deactivate" || e.UserID != admin.ID {
+			t.Errorf("Unexpected activity entry %+v", e)
+		}
+	}
+
+	// Staff can't lock themselves out
+	w = httptest.NewRecorder()
+	handler.RunAction(w, actionRequest(admin, "user", "deactivate", admin.ID.String()))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 when deactivating yourself, got %d", w.Code)
+	}
+	if !client.User.GetX(ctx, admin.ID).IsActive {
+		t.Error("Expected the current user to stay active")
+	}
+
+	for name, req := range map[string]*http.Request{
+		"unknown action": actionRequest(admin, "user", "archive", alice.ID.String()),
+		"no selection":   actionRequest(admin, "user", "activate"),
+		"invalid ID":     actionRequest(admin, "user", "activate", "not-a-uuid"),
+	} {
+		w = httptest.NewRecorder()
+		handler.RunAction(w, req)
+		if w.Code < 400 {
+			t.Errorf("%s: expected an error status, got %d", name, w.Code)
+		}
+	}
+}
+
+// TestRegisterModel_Actions tests that actions need a handler and a unique name
+func TestRegisterModel_Actions(t *testing.T) {
+	noop := func(context.Context, []interface{}) error { return nil }
+	tests := []struct {
+		name    string
+		actions []AdminAction
+		wantErr bool
+	}{
+		{"valid", []AdminAction{{Name: "Mark as read", Handler: noop}, {Name: "Archive", Handler: noop}}, false},
+		{"missing handler", []AdminAction{{Name: "Archive"}}, true},
+		{"empty name", []AdminAction{{Name: " ", Handler: noop}}, true},
+		{"duplicate slug", []AdminAction{{Name: "Mark as read", Handler: noop}, {Name: "mark as  Read", Handler: noop}}, true},
+	}
+	for _, tt := range tests {
+		registry := NewRegistry(nil)
+		err := registry.RegisterModel(ModelRegistration{ModelType: &models.Post{}, Actions: tt.actions})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: RegisterModel error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// TestAdminAction_Slug tests action URL segments and confirmation prompts
+func TestAdminAction_Slug(t *testing.T) {
+	action := AdminAction{Name: "Mark as  Read"}
+	if got := action.Slug(); got != "mark-as-read" {
+		t.Errorf("Slug() = %q, want %q", got, "mark-as-read")
+	}
+	if got := action.ConfirmMessage("Posts"); got != "Mark as  Read the selected posts?" {
+		t.Errorf("ConfirmMessage() = %q", got)
+	}
+	action.Confirm = "Really?"
+	if got := action.ConfirmMessage("Posts"); got != "Really?" {
+		t.Errorf("ConfirmMessage() = %q, want the custom prompt", got)
+	}
+}
@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// recentActionsLimit is how many actions each dashboard panel shows
+const recentActionsLimit = 10
+
+// RecentAction is an admin change shown in the dashboard's "Recent actions" panel
+type RecentAction struct {
+	*models.AdminAction
+	Icon string
+	URL  string // Opens the record's edit form; "" for deleted records
+}
+
+// Verb describes the action in the past tense
+func (a RecentAction) Verb() string {
+	switch a.Action {
+	case adminaction.ActionCreate:
+		return "Added"
+	case adminaction.ActionUpdate:
+		return "Changed"
+	case adminaction.ActionRun:
+		return a.ActionName
+	default:
+		return "Deleted"
+	}
+}
+
+// recordAction adds a create, update or delete by the request's staff user to the
+// activity log. Failures are logged but never fail the change itself.
+func (h *Handler) recordAction(ctx context.Context, action adminaction.Action, config *ModelConfig, recordID, label string) {
+	h.recordActionNamed(ctx, action, "", config, recordID, label)
+}
+
+// recordActionNamed is recordAction with the name of the custom action that ran
+// (for ActionRun entries)
+func (h *Handler) recordActionNamed(ctx context.Context, action adminaction.Action, name string, config *ModelConfig, recordID, label string) {
+	h.recordActionOn(ctx, action, name, config.Name, recordID, label)
+}
+
+// recordActionOn is recordActionNamed for actions that aren't on a registered
+// model, such as query console queries (model "SQL")
+func (h *Handler) recordActionOn(ctx context.Context, action adminaction.Action, name, model, recordID, label string) {
+	user := middleware.GetUser(ctx)
+	if h.DB == nil || user == nil {
+		return
+	}
+	err := h.DB.AdminAction.Create().
+		SetAction(action).
+		SetActionName(name).
+		SetModel(model).
+		SetRecordID(recordID).
+		SetRecordLabel(label).
+		SetUserID(user.ID).
+		SetUserEmail(user.Email).
+		Exec(ctx)
+	if err != nil {
+		utils.Warnw("admin.activity_record_failed", "model", model, "id", recordID, "action", action, "error", err)
+	}
+}
+
+// recentActions returns the latest admin actions, optionally only those by userID
+func (h *Handler) recentActions(ctx context.Context, userID uuid.UUID) ([]RecentAction, error) {
+	query := h.DB.AdminAction.Query().
+		Order(models.Desc(adminaction.FieldCreatedAt)).
+		Limit(recentActionsLimit)
+	if userID != uuid.Nil {
+		query = query.Where(adminaction.UserIDEQ(userID))
+	}
+	entries, err := query.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]RecentAction, len(entries))
+	for i, entry := range entries {
+		actions[i] = RecentAction{AdminAction: entry}
+		// Link back unless the record is gone or its model is no longer registered
+		if config, err := h.Registry.Get(entry.Model); err == nil {
+			actions[i].Icon = config.Icon
+			if entry.Action != adminaction.ActionDelete {
+				actions[i].URL = "/admin/" + strings.ToLower(config.Name) + "?edit=" + entry.RecordID
+			}
+		}
+	}
+	return actions, nil
+}
+
+// recordLabel describes a record by its label field, falling back to the model
+// name and a short ID
+func recordLabel(config *ModelConfig, record interface{}) string {
+	if config.LabelField != "" {
+		if label := fmt.Sprintf("%v", extractFieldValue(record, config.LabelField)); label != "" {
+			return label
+		}
+	}
+	id := getIDValue(record)
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	return config.Name + " " + id
+}
@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/google/uuid"
+)
+
+// TestRecentActions tests recording admin changes and listing them for the dashboard
+func TestRecentActions(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	post, _ := registry.Get("post")
+
+	alice := client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").SaveX(context.Background())
+	bob := client.User.Create().SetEmail("bob@example.com").SetPasswordHash("x").SaveX(context.Background())
+	asAlice := middleware.WithUser(context.Background(), alice)
+	asBob := middleware.WithUser(context.Background(), bob)
+
+	// Without a user (e.g., a background job) nothing is recorded
+	handler.recordAction(context.Background(), adminaction.ActionCreate, post, "x", "Ignored")
+
+	id := uuid.NewString()
+	handler.recordAction(asAlice, adminaction.ActionCreate, post, id, "Hello")
+	time.Sleep(time.Millisecond)
+	handler.recordAction(asBob, adminaction.ActionUpdate, post, id, "Hello again")
+	time.Sleep(time.Millisecond)
+	handler.recordAction(asAlice, adminaction.ActionDelete, post, id, "Hello again")
+
+	recent, err := handler.recentActions(context.Background(), uuid.Nil)
+	if err != nil {
+		t.Fatalf("recentActions: %v", err)
+	}
+	if len(recent) != 3 {
+		t.Fatalf("Expected 3 actions, got %d", len(recent))
+	}
+	if recent[0].Verb() != "Deleted" || recent[0].URL != "" || recent[0].UserEmail != "alice@example.com" {
+		t.Errorf("Expected the newest action to be alice's delete with no link, got %+v", recent[0])
+	}
+	if recent[1].Verb() != "Changed" || recent[1].URL != "/admin/post?edit="+id || recent[1].Icon != post.Icon {
+		t.Errorf("Expected bob's change to link to the post, got %+v", recent[1])
+	}
+
+	mine, _ := handler.recentActions(context.Background(), alice.ID)
+	if len(mine) != 2 || mine[0].Action != adminaction.ActionDelete || mine[1].Action != adminaction.ActionCreate {
+		t.Errorf("Expected alice's 2 actions, got %+v", mine)
+	}
+}
+
+// TestQueueDelete_Callback tests that a queued delete reports when the record is gone
+func TestQueueDelete_Callback(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	handler.UndoWindow = 10 * time.Millisecond
+	config, _ := registry.Get("user")
+
+	u := client.User.Create().SetEmail("gone@example.com").SetPasswordHash("x").SaveX(context.Background())
+	done := make(chan struct{})
+	handler.queueDelete(config, u.ID, func(ctx context.Context) { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the callback after the delete ran")
+	}
+	if n := client.User.Query().CountX(context.Background()); n != 0 {
+		t.Errorf("Expected the user to be deleted, %d left", n)
+	}
+}
+
+func TestRecordLabel(t *testing.T) {
+	id := uuid.MustParse("12345678-aaaa-bbbb-cccc-123456789abc")
+	record := &struct {
+		ID      uuid.UUID
+		Subject string
+	}{ID: id, Subject: "Hello"}
+
+	if got := recordLabel(&ModelConfig{Name: "Post", LabelField: "Subject"}, record); got != "Hello" {
+		t.Errorf("recordLabel = %q, want the label field", got)
+	}
+	if got := recordLabel(&ModelConfig{Name: "Post"}, record); got != "Post 12345678" {
+		t.Errorf("recordLabel = %q, want the model name and short ID", got)
+	}
+}
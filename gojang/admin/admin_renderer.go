@@ -5,17 +5,29 @@ import (
 	"html/template"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gojangframework/gojang/gojang/debugbar"
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/i18n"
+	"github.com/gojangframework/gojang/gojang/images"
+	"github.com/gojangframework/gojang/gojang/livereload"
+	"github.com/gojangframework/gojang/gojang/storage"
 	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/version"
+	"github.com/gojangframework/gojang/gojang/views/components"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
 
 	"github.com/gojangframework/gojang/gojang/http/middleware"
 	"github.com/gojangframework/gojang/gojang/models"
 
+	"github.com/google/uuid"
 	"github.com/justinas/nosurf"
 )
 
@@ -30,29 +42,69 @@ type TemplateData struct {
 	CurrentPath string
 	Flash       string
 	FlashType   string
+
+	// When the staff member's session ends, for the warning before it does (see UseSessionExpiry)
+	SessionExpiry *middleware.SessionExpiry
 }
 
 type AdminRenderer struct {
 	templates map[string]*template.Template
-	mu        sync.RWMutex // Protects templates map
+	blocks    *renderers.BlockIndex
+	mu        sync.RWMutex // Protects templates and blocks
 	debug     bool
+
+	// Optional source of the session expiry warning (see UseSessionExpiry)
+	sessionExpiry func(*http.Request) *middleware.SessionExpiry
+
+	// Optional development toolbar added to full pages (see UseDebugToolbar)
+	debugToolbar *debugbar.Toolbar
 }
 
 // NewAdminRenderer creates a new template renderer for admin panel
-// Admin templates are ALWAYS rendered as fragments (no base.html wrapper)
+// Admin templates are ALWAYS rendered as fragments (no base.html wrapper).
+// Like renderers.NewRenderer, it checks every template up front and returns
+// their problems as renderers.TemplateErrors.
 func NewAdminRenderer(debug bool) (*AdminRenderer, error) {
-	tmpl, err := parseAdminTemplates()
+	tmpl, blocks, err := loadAdminTemplates()
 	if err != nil {
 		return nil, err
 	}
 
 	return &AdminRenderer{
 		templates: tmpl,
+		blocks:    blocks,
 		debug:     debug,
 	}, nil
 }
 
-func parseAdminTemplates() (map[string]*template.Template, error) {
+// pagePartials maps pages to the partials they render inline, which handlers
+// also render alone to refresh the page over htmx
+var pagePartials = map[string][]string{
+	"model_index.html":      {"model_list.partial.html"},
+	"media_index.html":      {"media_list.partial.html"},
+	"logs_index.html":       {"logs_list.partial.html"},
+	"moderation_index.html": {"moderation_list.partial.html"},
+	"settings_index.html":   {"settings_retention.partial.html", "settings_audit_export.partial.html"},
+}
+
+// loadAdminTemplates parses the admin templates, indexes their blocks and
+// checks them with renderers.Validate
+func loadAdminTemplates() (map[string]*template.Template, *renderers.BlockIndex, error) {
+	tmpl, problems, err := parseAdminTemplates()
+	if err != nil {
+		return nil, nil, err
+	}
+	blocks := renderers.NewBlockIndex(tmpl)
+	problems = append(problems, renderers.Validate(tmpl, blocks)...)
+	if len(problems) > 0 {
+		return nil, nil, renderers.TemplateErrors(problems)
+	}
+	return tmpl, blocks, nil
+}
+
+// parseAdminTemplates parses every admin template, carrying on past those
+// that fail so their problems can be reported together
+func parseAdminTemplates() (map[string]*template.Template, []string, error) {
 	funcMap := template.FuncMap{
 		"add": func(a, b int) int { return a + b },
 		"sub": func(a, b int) int { return a - b },
@@ -88,9 +140,44 @@ func parseAdminTemplates() (map[string]*template.Template, error) {
 		"formatField":    formatFieldForDisplay,
 		"getID":          getIDValue,
 		"formatDateTime": formatDateTimeField,
+		"formatTime":     formatTimeField,
+		"timeago":        utils.TimeAgo,
+		"edgeID":         edgeIDValue,
+		"plainText":      utils.StripHTML,
+		"formatJSON":     formatJSONField,
+		"formatUUID":     formatUUIDField,
+		"formatMoney":    formatMoneyField,
+		"moneyInput":     moneyInputField,
+		"formatGeo":      formatGeoField,
+		"locales":        i18n.Locales,
+		"translation":    translationField,
+		"colorSwatch":    colorSwatchField,
+		"urlLink":        urlLinkField,
+		"phoneLink":      phoneLinkField,
+		"fileLink":       fileLinkField,
+		"imageThumb":     imageThumbField,
+		"mediaThumb":     func(name string) template.HTML { return template.HTML(images.Thumbnail(name)) },
+		"staticMap":      staticMapField,
+		"mapTileURL":     utils.MapTileURL,
+		"liveReload":     func() template.HTML { return template.HTML(livereload.Snippet()) },
+		"buildVersion":   func() string { return version.Get().String() },
+	}
+
+	// Shared partials from gojang/views/components
+	for name, fn := range components.Funcs() {
+		funcMap[name] = fn
 	}
 
 	templates := make(map[string]*template.Template)
+	var problems []string
+	seen := make(map[string]bool)
+	fail := func(what string, err error) {
+		// A broken admin_base.html or component fails every page; report it once
+		if !seen[err.Error()] {
+			seen[err.Error()] = true
+			problems = append(problems, fmt.Sprintf("%s: %v", what, err))
+		}
+	}
 	templateDir := "./gojang/admin/views"
 	basePath := filepath.Join(templateDir, "admin_base.html")
 
@@ -130,24 +217,32 @@ func parseAdminTemplates() (map[string]*template.Template, error) {
 				return fmt.Errorf("reading admin fragment %s: %w", relPath, err)
 			}
 			tmpl, err = template.New(relPath).Funcs(funcMap).Parse(string(content))
+			if err == nil {
+				err = components.Parse(tmpl)
+			}
 			if err != nil {
-				return fmt.Errorf("parsing admin fragment %s: %w", relPath, err)
+				fail("parsing admin fragment "+relPath, err)
+				return nil
 			}
 		} else {
 			// Parse with admin_base.html
 			files := []string{basePath, path}
 
-			// For model_index.html, also include the partial
-			if relPath == "model_index.html" {
-				partialPath := filepath.Join(templateDir, "model_list.partial.html")
+			// Index pages also include their list partials
+			for _, partial := range pagePartials[relPath] {
+				partialPath := filepath.Join(templateDir, partial)
 				if _, err := os.Stat(partialPath); err == nil {
 					files = append(files, partialPath)
 				}
 			}
 
 			tmpl, err = template.New(filepath.Base(basePath)).Funcs(funcMap).ParseFiles(files...)
+			if err == nil {
+				err = components.Parse(tmpl)
+			}
 			if err != nil {
-				return fmt.Errorf("parsing admin page %s: %w", relPath, err)
+				fail("parsing admin page "+relPath, err)
+				return nil
 			}
 		}
 
@@ -156,10 +251,10 @@ func parseAdminTemplates() (map[string]*template.Template, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("walking admin template directory: %w", err)
+		return nil, nil, fmt.Errorf("walking admin template directory: %w", err)
 	}
 
-	return templates, nil
+	return templates, problems, nil
 }
 
 // Render renders an admin template
@@ -175,81 +270,146 @@ func (r *AdminRenderer) Render(w http.ResponseWriter, req *http.Request, name st
 	data.User = middleware.GetUser(req.Context())
 
 	// Check if htmx request
-	data.IsHX = req.Header.Get("HX-Request") == "true"
+	data.IsHX = htmx.IsRequest(req)
 	data.CurrentPath = req.URL.Path
+	if r.sessionExpiry != nil && !data.IsHX {
+		data.SessionExpiry = r.sessionExpiry(req)
+	}
 
-	// Reload templates in debug mode
+	// Reload templates in debug mode, keeping the last good ones while an edit has problems
 	if r.debug {
-		tmpl, err := parseAdminTemplates()
-		if err == nil {
+		tmpl, blocks, err := loadAdminTemplates()
+		if err != nil {
+			utils.Warnw("templates.reload_failed", "error", err)
+		} else {
 			r.mu.Lock()
-			r.templates = tmpl
+			r.templates, r.blocks = tmpl, blocks
 			r.mu.Unlock()
 		}
 	}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-
 	// Get the template
 	r.mu.RLock()
 	tmpl, ok := r.templates[name]
+	hasContent := r.blocks.Defines(name, "content")
 	r.mu.RUnlock()
 	if !ok {
-		utils.Errorf("Admin template '%s' not found", name)
-		return fmt.Errorf("admin template %s not found", name)
+		err := fmt.Errorf("admin template %s not found", name)
+		r.renderFailed(w, req, name, data, err)
+		return err
 	}
 
-	// Fragment templates (partials) render directly
-	isFragment := strings.Contains(name, ".partial.html")
+	// Execute into a pooled buffer so a failing template never leaves a half-written response
+	buf := renderers.GetBuffer()
+	defer renderers.PutBuffer(buf)
 
-	if isFragment {
+	var err error
+	start := time.Now()
+	partial := strings.Contains(name, ".partial.html")
+	if partial && hasContent {
 		// Partials can define a "content" block or just render directly
-		// Try content block first, fallback to direct execution
-		err := tmpl.ExecuteTemplate(w, "content", data)
-		if err != nil {
-			// If content block doesn't exist, execute directly
-			err = tmpl.Execute(w, data)
-			if err != nil {
-				utils.Errorf("Partial template execution failed: %v", err)
+		err = tmpl.ExecuteTemplate(buf, "content", data)
+	} else if partial {
+		err = tmpl.Execute(buf, data)
+	} else {
+		// Full page templates render with admin_base.html
+		err = tmpl.ExecuteTemplate(buf, "admin_base.html", data)
+	}
+	if err != nil {
+		r.renderFailed(w, req, name, data, err)
+		return err
+	}
+	if r.debugToolbar != nil {
+		debugbar.FromContext(req.Context()).AddRender(name, time.Since(start))
+		if !partial && !data.IsHX {
+			if err := r.debugToolbar.Inject(buf, req); err != nil {
+				utils.Warnw("debugbar.inject_failed", "template", name, "error", err)
 			}
 		}
-		return err
 	}
 
-	// Full page templates render with admin_base.html
-	err := tmpl.ExecuteTemplate(w, "admin_base.html", data)
-	if err != nil {
-		utils.Errorf("Admin template execution failed: %v", err)
-	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, err = buf.WriteTo(w)
 	return err
 }
 
+// renderFailed logs why name couldn't be rendered and answers with the
+// RenderError fragment instead (see Renderer.UseErrorTemplates)
+func (r *AdminRenderer) renderFailed(w http.ResponseWriter, req *http.Request, name string, data *TemplateData, err error) {
+	keys := make([]string, 0, len(data.Data))
+	for key := range data.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	utils.Errorw("render.failed", "template", name, "data_keys", keys, "path", req.URL.Path, "error", err)
+
+	message := "Something went wrong showing this page."
+	if r.debug {
+		message = err.Error()
+	}
+	// Error responses are only swapped when they set HX-Reswap (see admin_base.html)
+	if data.IsHX {
+		htmx.Reswap(w, "innerHTML")
+	}
+	r.RenderError(w, req, http.StatusInternalServerError, message)
+}
+
+// UseSessionExpiry sets where full pages learn when the staff member's
+// session ends (see Renderer.UseSessionExpiry)
+func (r *AdminRenderer) UseSessionExpiry(fn func(*http.Request) *middleware.SessionExpiry) {
+	r.sessionExpiry = fn
+}
+
+// UseDebugToolbar adds tb to full admin pages (see Renderer.UseDebugToolbar)
+func (r *AdminRenderer) UseDebugToolbar(tb *debugbar.Toolbar) {
+	r.debugToolbar = tb
+}
+
+// Blocks returns the index of the blocks each admin template set defines
+func (r *AdminRenderer) Blocks() *renderers.BlockIndex {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.blocks
+}
+
+// Templates returns the parsed template sets by name, for tooling such as `gojang check`
+func (r *AdminRenderer) Templates() map[string]*template.Template {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sets := make(map[string]*template.Template, len(r.templates))
+	for name, t := range r.templates {
+		sets[name] = t
+	}
+	return sets
+}
+
 // RenderError renders an error message (as a simple fragment)
 func (r *AdminRenderer) RenderError(w http.ResponseWriter, req *http.Request, status int, message string) {
-	w.WriteHeader(status)
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
 	fmt.Fprintf(w, `<div class="error">
 		<h2>Error %d</h2>
 		<p>%s</p>
-	</div>`, status, message)
+	</div>`, status, template.HTMLEscapeString(message))
 }
 
-// extractFieldValue extracts a field value from a struct using reflection
+// extractFieldValue extracts a field value from a record using the cached field metadata
 func extractFieldValue(obj interface{}, fieldName string) interface{} {
-	v := reflect.ValueOf(obj)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
-	if v.Kind() != reflect.Struct {
+	field, acc, ok := lookupField(obj, fieldName)
+	if !ok {
 		return ""
 	}
 
-	field := v.FieldByName(fieldName)
-	if !field.IsValid() {
-		return ""
+	// Related records (e.g., Post.Author) are displayed by their label
+	if acc.isEdge {
+		return formatFieldValue(field.Interface())
 	}
 
-	// Handle different types
+	return displayValue(field)
+}
+
+// displayValue converts a reflected field into a template-friendly value
+func displayValue(field reflect.Value) interface{} {
 	switch field.Kind() {
 	case reflect.String:
 		return field.String()
@@ -261,8 +421,7 @@ func extractFieldValue(obj interface{}, fieldName string) interface{} {
 		return field.Bool()
 	case reflect.Struct:
 		// Handle time.Time
-		if field.Type().String() == "time.Time" {
-			t := field.Interface().(time.Time)
+		if t, ok := field.Interface().(time.Time); ok {
 			if t.IsZero() {
 				return "-"
 			}
@@ -273,7 +432,7 @@ func extractFieldValue(obj interface{}, fieldName string) interface{} {
 		if field.IsNil() {
 			return "-"
 		}
-		return extractFieldValue(field.Interface(), fieldName)
+		return displayValue(field.Elem())
 	default:
 		return field.Interface()
 	}
@@ -281,51 +440,177 @@ func extractFieldValue(obj interface{}, fieldName string) interface{} {
 
 // getIDValue extracts the ID field from a struct and returns it as a string
 func getIDValue(obj interface{}) string {
-	v := reflect.ValueOf(obj)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+	idField, _, ok := lookupField(obj, "ID")
+	if !ok {
+		return ""
 	}
-	if v.Kind() != reflect.Struct {
+
+	// Handle int IDs (legacy or other models)
+	if idField.Kind() == reflect.Int || idField.Kind() == reflect.Int64 {
+		return fmt.Sprintf("%d", idField.Int())
+	}
+
+	// UUID IDs and everything else implement fmt.Stringer or format sensibly with %v
+	return fmt.Sprintf("%v", idField.Interface())
+}
+
+// edgeIDValue returns the ID of a loaded edge record (e.g., a post's Author), or ""
+func edgeIDValue(obj interface{}, edgeName string) string {
+	field, acc, ok := lookupField(obj, edgeName)
+	if !ok || !acc.isEdge || (field.Kind() == reflect.Ptr && field.IsNil()) {
 		return ""
 	}
+	return getIDValue(field.Interface())
+}
 
-	idField := v.FieldByName("ID")
-	if !idField.IsValid() {
+// moneyField extracts a money field's minor units; ok is false when it's unset
+func moneyField(obj interface{}, fieldName string) (int64, bool) {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok || (field.Kind() == reflect.Ptr && field.IsNil()) {
+		return 0, false
+	}
+	field = reflect.Indirect(field)
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), true
+	}
+	return 0, false
+}
+
+// formatMoneyField formats a money field for display (e.g., "$1,234.50")
+func formatMoneyField(obj interface{}, fieldName string) string {
+	if amount, ok := moneyField(obj, fieldName); ok {
+		return utils.FormatMoney(amount)
+	}
+	return "-"
+}
+
+// moneyInputField formats a money field for a form input (e.g., "1234.50")
+func moneyInputField(obj interface{}, fieldName string) string {
+	if amount, ok := moneyField(obj, fieldName); ok {
+		return utils.MoneyInput(amount)
+	}
+	return ""
+}
+
+// stringField returns a string field's value ("" when unset)
+func stringField(obj interface{}, fieldName string) string {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok || (field.Kind() == reflect.Ptr && field.IsNil()) {
 		return ""
 	}
+	if field = reflect.Indirect(field); field.Kind() == reflect.String {
+		return field.String()
+	}
+	return ""
+}
 
-	// Handle int IDs (legacy or other models)
-	if idField.Kind() == reflect.Int || idField.Kind() == reflect.Int64 {
-		return fmt.Sprintf("%d", idField.Int())
+// colorSwatchField shows a color field as a swatch with its code
+func colorSwatchField(obj interface{}, fieldName string) template.HTML {
+	return template.HTML(utils.ColorSwatch(stringField(obj, fieldName)))
+}
+
+// urlLinkField shows a URL field as a link that opens in a new tab
+func urlLinkField(obj interface{}, fieldName string) template.HTML {
+	return template.HTML(utils.URLLink(stringField(obj, fieldName)))
+}
+
+// phoneLinkField shows a phone field as a tel: link
+func phoneLinkField(obj interface{}, fieldName string) template.HTML {
+	return template.HTML(utils.PhoneLink(stringField(obj, fieldName)))
+}
+
+// fileLinkField links to an uploaded file by its base name ("" when unset)
+func fileLinkField(obj interface{}, fieldName string) template.HTML {
+	name := stringField(obj, fieldName)
+	if name == "" {
+		return ""
 	}
+	return template.HTML(fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener">%s</a>`,
+		template.HTMLEscapeString(storage.URL(name)), template.HTMLEscapeString(path.Base(name))))
+}
 
-	// Handle UUID IDs
-	if idField.Type().String() == "uuid.UUID" {
-		return fmt.Sprintf("%v", idField.Interface())
+// imageThumbField shows an image field as a thumbnail linking to the full image
+func imageThumbField(obj interface{}, fieldName string) template.HTML {
+	name := stringField(obj, fieldName)
+	if name == "" {
+		return ""
 	}
+	return template.HTML(fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener" class="admin-thumbnail">%s</a>`,
+		template.HTMLEscapeString(storage.URL(name)), images.Thumbnail(name)))
+}
 
-	// Fallback: convert to string
-	return fmt.Sprintf("%v", idField.Interface())
+// geoField returns the location in a geo field, or nil when unset
+func geoField(obj interface{}, fieldName string) *utils.Point {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok || (field.Kind() == reflect.Ptr && field.IsNil()) {
+		return nil
+	}
+	if p, ok := reflect.Indirect(field).Interface().(utils.Point); ok {
+		return &p
+	}
+	return nil
 }
 
-// formatDateTimeField extracts a time field and formats it for datetime-local input
-func formatDateTimeField(obj interface{}, fieldName string) string {
-	v := reflect.ValueOf(obj)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+// formatGeoField formats a geo field for a form input (e.g., "37.7749,-122.4194")
+func formatGeoField(obj interface{}, fieldName string) string {
+	if p := geoField(obj, fieldName); p != nil {
+		return p.String()
+	}
+	return ""
+}
+
+// staticMapField renders a small map of a geo field ("-" when unset)
+func staticMapField(obj interface{}, fieldName string) template.HTML {
+	if p := geoField(obj, fieldName); p != nil {
+		return template.HTML(utils.StaticMap(p))
 	}
-	if v.Kind() != reflect.Struct {
+	return "-"
+}
+
+// formatUUIDField extracts a UUID field for form inputs ("" when unset)
+func formatUUIDField(obj interface{}, fieldName string) string {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok || (field.Kind() == reflect.Ptr && field.IsNil()) {
 		return ""
 	}
+	if id, ok := reflect.Indirect(field).Interface().(uuid.UUID); ok && id != uuid.Nil {
+		return id.String()
+	}
+	return ""
+}
 
-	field := v.FieldByName(fieldName)
-	if !field.IsValid() {
+// formatJSONField extracts a JSON field and pretty-prints it ("" when null)
+func formatJSONField(obj interface{}, fieldName string) string {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok {
 		return ""
 	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+	return utils.PrettyJSON(field.Interface())
+}
+
+// formatDateTimeField extracts a time field and formats it for datetime-local input
+func formatDateTimeField(obj interface{}, fieldName string) string {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok {
+		return ""
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
 
 	// Check if it's a time.Time field
-	if field.Type().String() == "time.Time" {
-		t := field.Interface().(time.Time)
+	if t, ok := field.Interface().(time.Time); ok {
 		if t.IsZero() {
 			return ""
 		}
@@ -335,10 +620,32 @@ func formatDateTimeField(obj interface{}, fieldName string) string {
 	return ""
 }
 
+// formatTimeField shows a time field in layout along with how long ago it was
+// (see utils.TimeTag); unset times are shown as "-"
+func formatTimeField(obj interface{}, fieldName, layout string) template.HTML {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok {
+		return "-"
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "-"
+		}
+		field = field.Elem()
+	}
+	if t, ok := field.Interface().(time.Time); ok {
+		return template.HTML(utils.TimeTag(t, layout))
+	}
+	return "-"
+}
+
 // formatFieldForDisplay formats a field value for display in tables
 func formatFieldForDisplay(obj interface{}, fieldName string) string {
-	val := extractFieldValue(obj, fieldName)
+	return formatDisplayValue(extractFieldValue(obj, fieldName))
+}
 
+// formatDisplayValue formats a value for display in tables and read-only fields
+func formatDisplayValue(val interface{}) string {
 	// Format boolean values
 	if b, ok := val.(bool); ok {
 		if b {
@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"net/http"
+
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/chi/v5"
 	"github.com/gojangframework/gojang/gojang/http/middleware"
@@ -18,19 +20,88 @@ func AdminRoutes(adminHandler *Handler, sm *scs.SessionManager, client *models.C
 	// Admin dashboard
 	r.Get("/", adminHandler.Dashboard)
 
-	// Admin settings
+	// Password confirmation for destructive actions (sudo mode)
+	sudo := adminHandler.Sudo.Require
+	r.Get("/sudo", adminHandler.SudoPrompt)
+	r.With(middleware.RateLimit(middleware.AuthRateLimiter())).Post("/sudo", adminHandler.SudoConfirm)
+
+	// Command palette entries (Ctrl+K)
+	r.Get("/palette", adminHandler.Palette)
+
+	// Media library (uploaded files)
+	r.Get("/media", adminHandler.MediaIndex)
+	r.Post("/media", adminHandler.MediaUpload)                   // Upload files (multipart "files")
+	r.With(sudo).Post("/media/delete", adminHandler.MediaDelete) // Bulk delete unused files (sudo mode)
+
+	// Recent application logs
+	r.Get("/logs", adminHandler.LogsIndex)
+
+	// Moderation queue (records flagged for review; rejecting with a delete needs sudo mode)
+	r.Get("/moderation", adminHandler.ModerationIndex)
+	r.Get("/moderation/badge", adminHandler.ModerationBadge) // Navbar link with the pending count
+	r.Post("/moderation/approve", adminHandler.ModerationApprove)
+	r.Post("/moderation/reject", adminHandler.ModerationReject)
+
+	// Read-only SQL console (superusers only; every query is audited)
+	r.With(middleware.RequireAdmin).Get("/query", adminHandler.QueryIndex)
+	r.With(middleware.RequireAdmin).Post("/query", adminHandler.QueryRun)
+
+	// Admin settings (changing retention deletes data, so it's for superusers in sudo
+	// mode; audit log exports are for superusers too)
+	r.Get("/settings", adminHandler.SettingsIndex)
 	r.Post("/settings/model-order", adminHandler.SaveModelOrderSetting)
+	r.With(middleware.RequireAdmin, sudo).Post("/settings/retention", adminHandler.SaveRetention)
+	r.With(middleware.RequireAdmin, sudo).Post("/settings/retention/run", adminHandler.RunRetention)
+	r.With(middleware.RequireAdmin).Get("/settings/audit-export", adminHandler.AuditExportDownload) // ?from=&to=&format=
+	r.With(middleware.RequireAdmin).Post("/settings/audit-export", adminHandler.AuditExportDeliver)
+
+	// JSON API for scripts and SPA clients (same checks as the HTML views)
+	r.Route("/api", func(api chi.Router) {
+		api.Get("/", adminHandler.APIModels)             // Models, their fields and the CSRF token
+		api.Get("/{model}", adminHandler.APIList)        // List records (page, per_page, sort, f_<Field>)
+		api.Post("/{model}", adminHandler.APICreate)     // Create record
+		api.Get("/{model}/{id}", adminHandler.APIGet)    // Get record
+		api.Put("/{model}/{id}", adminHandler.APIUpdate) // Update the fields sent
+		api.Patch("/{model}/{id}", adminHandler.APIUpdate)
+		api.Delete("/{model}/{id}", adminHandler.APIDelete) // Delete record (no undo window)
+	})
 
 	// Generic model routes
 	r.Route("/{model}", func(model chi.Router) {
-		model.Get("/", adminHandler.Index)                    // List records
-		model.Get("/new", adminHandler.New)                   // Show create form
-		model.Post("/", adminHandler.Create)                  // Create record
-		model.Get("/{id}/edit", adminHandler.Edit)            // Show edit form
-		model.Put("/{id}", adminHandler.Update)               // Update record
-		model.Get("/{id}/delete", adminHandler.DeleteConfirm) // Show delete confirmation
-		model.Delete("/{id}", adminHandler.Delete)            // Delete record
+		model.Get("/", adminHandler.Index)                       // List records
+		model.Get("/new", adminHandler.New)                      // Show create form
+		model.Get("/autocomplete", adminHandler.Autocomplete)    // JSON {id,label} suggestions
+		model.Post("/", adminHandler.Create)                     // Create record
+		model.Get("/{id}/edit", adminHandler.Edit)               // Show edit form
+		model.Put("/{id}", adminHandler.Update)                  // Update record
+		model.Post("/undo/{token}", adminHandler.UndoDelete)     // Cancel a queued delete
+		model.Post("/actions/{action}", adminHandler.RunAction)  // Run a custom action on the "ids" records
+		model.Post("/preferences", adminHandler.SavePreferences) // Save columns and filter sets
+
+		// Deletes need a recent password confirmation (sudo mode)
+		model.With(sudo).Get("/{id}/delete", adminHandler.DeleteConfirm) // Show delete confirmation
+		model.With(sudo).Delete("/{id}", adminHandler.Delete)            // Delete record (queued for undo)
+
+		// Inline child records on the edit form
+		model.Get("/{id}/inlines/{child}", adminHandler.InlineList)
+		model.Post("/{id}/inlines/{child}", adminHandler.InlineCreate)
+		model.Put("/{id}/inlines/{child}/{childID}", adminHandler.InlineUpdate)
+		model.With(sudo).Delete("/{id}/inlines/{child}/{childID}", adminHandler.InlineDelete)
+
+		// Checkboxes of a to-many field on the create and edit forms (?id=record)
+		model.Get("/relations/{field}", adminHandler.RelationChoices)
 	})
 
 	return r
 }
+
+// BatchHandler serves APIBatch behind the admin API's middleware (login, staff,
+// audit log, CSRF). The versioned API mounts it at /api/v1/{model}/batch.
+func BatchHandler(adminHandler *Handler, sm *scs.SessionManager, client *models.Client) http.Handler {
+	return chi.Chain(
+		nosurf.NewPure,
+		middleware.RequireAuth(sm, client),
+		middleware.RequireStaff,
+		middleware.AuditMiddleware,
+	).HandlerFunc(adminHandler.APIBatch)
+}
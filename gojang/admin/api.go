@@ -0,0 +1,463 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/i18n"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+	"github.com/justinas/nosurf"
+)
+
+// maxAPIBody limits JSON request bodies of the admin API
+const maxAPIBody = 1 << 20
+
+// APIModel describes a registered model in the admin API index. `gojang gen
+// client` generates its Go client from these, so they match the handlers.
+type APIModel struct {
+	Name       string     `json:"name"`
+	NamePlural string     `json:"name_plural"`
+	URL        string     `json:"url"`
+	Fields     []APIField `json:"fields"`
+}
+
+// APIField describes one field of an admin API model
+type APIField struct {
+	Name       string    `json:"name"`
+	Label      string    `json:"label"`
+	Type       FieldType `json:"type"`
+	Required   bool      `json:"required,omitempty"`
+	Readonly   bool      `json:"readonly,omitempty"`
+	WriteOnly  bool      `json:"write_only,omitempty"`  // Accepted on create/update, never returned (e.g., Password)
+	CreateOnly bool      `json:"create_only,omitempty"` // Only accepted on create
+	EditOnly   bool      `json:"edit_only,omitempty"`   // Only accepted on update
+}
+
+// apiListResponse is the body of GET /admin/api/{model}
+type apiListResponse struct {
+	Data    []map[string]interface{} `json:"data"`
+	Page    int                      `json:"page"`
+	PerPage int                      `json:"per_page"`
+	Total   int                      `json:"total"`
+}
+
+// apiValidationError is the body of 422 responses
+type apiValidationError struct {
+	Error  string            `json:"error"`
+	Fields map[string]string `json:"fields"`
+}
+
+// APIModels lists the models in navigation and their fields. It also returns the
+// CSRF token that create, update and delete requests send as X-CSRF-Token.
+func (h *Handler) APIModels(w http.ResponseWriter, r *http.Request) {
+	api.JSON(w, http.StatusOK, map[string]interface{}{
+		"models":     APISchema(h.Registry),
+		"csrf_token": nosurf.Token(r),
+	})
+}
+
+// APISchema describes the models the admin API serves: those in navigation,
+// without hidden fields and uploads
+func APISchema(registry *Registry) []APIModel {
+	var list []APIModel
+	for _, config := range registry.Nav() {
+		m := APIModel{
+			Name:       config.Name,
+			NamePlural: config.NamePlural,
+			URL:        "/admin/api/" + strings.ToLower(config.Name),
+		}
+		for _, f := range config.Fields {
+			if f.Hidden || f.IsUpload() {
+				continue
+			}
+			m.Fields = append(m.Fields, APIField{
+				Name:       f.Name,
+				Label:      f.Label,
+				Type:       f.Type,
+				Required:   f.Required && !f.Readonly,
+				Readonly:   f.Readonly,
+				WriteOnly:  f.Sensitive || f.Type == FieldTypePassword,
+				CreateOnly: !f.OnForm(true),
+				EditOnly:   !f.OnForm(false),
+			})
+		}
+		list = append(list, m)
+	}
+	return list
+}
+
+// APIList returns a page of records. It takes the list view's parameters:
+// page, per_page (1-100), sort ("Field" or "-Field") and f_<Field> filters.
+func (h *Handler) APIList(w http.ResponseWriter, r *http.Request) {
+	config, ok := h.apiModel(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	page, perPage := 1, 20
+	if v := q.Get("page"); v != "" {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 {
+			api.Error(w, http.StatusBadRequest, "page must be a positive number")
+			return
+		}
+		page = p
+	}
+	if v := q.Get("per_page"); v != "" {
+		pp, err := strconv.Atoi(v)
+		if err != nil || pp < 1 || pp > 100 {
+			api.Error(w, http.StatusBadRequest, "per_page must be between 1 and 100")
+			return
+		}
+		perPage = pp
+	}
+	sort := q.Get("sort")
+	if sort != "" && !config.Sortable(strings.TrimPrefix(sort, "-")) {
+		api.Error(w, http.StatusBadRequest, fmt.Sprintf("Cannot sort by %q", sort))
+		return
+	}
+	filters := make(map[string]string)
+	for _, f := range config.Fields {
+		if v := strings.TrimSpace(q.Get("f_" + f.Name)); v != "" && f.Filterable() {
+			filters[f.Name] = v
+		}
+	}
+
+	total, err := config.CountList(r.Context(), filters)
+	if err != nil {
+		utils.Errorw("admin.count_failed", "model", config.Name, "error", err)
+		api.Error(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load %s", config.NamePlural))
+		return
+	}
+	records, err := config.QueryList(r.Context(), ListOptions{
+		Limit:   perPage,
+		Offset:  (page - 1) * perPage,
+		Sort:    sort,
+		Filters: filters,
+	})
+	if err != nil {
+		utils.Errorw("admin.query_failed", "model", config.Name, "error", err)
+		api.Error(w, http.StatusInternalServerError, fmt.Sprintf("Failed to load %s", config.NamePlural))
+		return
+	}
+	records = h.withoutPending(config, records)
+
+	data := make([]map[string]interface{}, len(records))
+	for i, record := range records {
+		data[i] = apiRecord(config, record)
+	}
+	api.JSON(w, http.StatusOK, apiListResponse{Data: data, Page: page, PerPage: perPage, Total: total})
+}
+
+// APIGet returns one record
+func (h *Handler) APIGet(w http.ResponseWriter, r *http.Request) {
+	config, ok := h.apiModel(w, r)
+	if !ok {
+		return
+	}
+	_, record, ok := h.apiRecordByID(w, r, config)
+	if !ok {
+		return
+	}
+	api.JSON(w, http.StatusOK, map[string]interface{}{"data": apiRecord(config, record)})
+}
+
+// APICreate creates a record from a JSON object of field values
+func (h *Handler) APICreate(w http.ResponseWriter, r *http.Request) {
+	config, ok := h.apiModel(w, r)
+	if !ok {
+		return
+	}
+
+	data, errors, err := h.apiData(w, r, config, true)
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if len(errors) == 0 {
+		if errors, err = h.checkRecord(r.Context(), config, data, uuid.Nil); err != nil {
+			utils.Errorw("admin.check_unique_failed", "model", config.Name, "error", err)
+			api.Error(w, http.StatusInternalServerError, "Failed to validate "+config.Name)
+			return
+		}
+	}
+	if len(errors) > 0 {
+		api.JSON(w, http.StatusUnprocessableEntity, apiValidationError{Error: "Validation failed", Fields: errors})
+		return
+	}
+
+	if !h.Sudo.Active(r) && h.changesSudoFields(r.Context(), config, nil, data) {
+		h.apiSudoRequired(w)
+		return
+	}
+
+	created, err := config.CreateFunc(r.Context(), data)
+	if errors, ok := db.FormErrors(err, "_general"); ok {
+		api.JSON(w, http.StatusUnprocessableEntity, apiValidationError{Error: "Validation failed", Fields: errors})
+		return
+	}
+	if err != nil {
+		utils.Errorw("admin.create_failed", "model", config.Name, "error", err)
+		api.Error(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create %s", config.Name))
+		return
+	}
+	h.recordAction(r.Context(), adminaction.ActionCreate, config, getIDValue(created), recordLabel(config, created))
+
+	// Reload so eager-loaded edges (e.g., a post's author) are included
+	if id, err := uuid.Parse(getIDValue(created)); err == nil {
+		if record, err := config.QueryByID(r.Context(), id); err == nil {
+			created = record
+		}
+	}
+	api.JSON(w, http.StatusCreated, map[string]interface{}{"data": apiRecord(config, created)})
+}
+
+// APIUpdate changes the fields given in a JSON object and leaves the others as they are
+func (h *Handler) APIUpdate(w http.ResponseWriter, r *http.Request) {
+	config, ok := h.apiModel(w, r)
+	if !ok {
+		return
+	}
+	id, existing, ok := h.apiRecordByID(w, r, config)
+	if !ok || !h.apiAuthorize(w, r, config, existing) {
+		return
+	}
+
+	data, errors, err := h.apiData(w, r, config, false)
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if len(errors) == 0 {
+		if errors, err = h.checkRecord(r.Context(), config, data, id); err != nil {
+			utils.Errorw("admin.check_unique_failed", "model", config.Name, "error", err)
+			api.Error(w, http.StatusInternalServerError, "Failed to validate "+config.Name)
+			return
+		}
+	}
+	if len(errors) > 0 {
+		api.JSON(w, http.StatusUnprocessableEntity, apiValidationError{Error: "Validation failed", Fields: errors})
+		return
+	}
+
+	if !h.Sudo.Active(r) && h.changesSudoFields(r.Context(), config, existing, data) {
+		h.apiSudoRequired(w)
+		return
+	}
+
+	err = config.UpdateFunc(r.Context(), id, data)
+	if errors, ok := db.FormErrors(err, "_general"); ok {
+		api.JSON(w, http.StatusUnprocessableEntity, apiValidationError{Error: "Validation failed", Fields: errors})
+		return
+	}
+	if err != nil {
+		utils.Errorw("admin.update_failed", "model", config.Name, "error", err)
+		api.Error(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update %s", config.Name))
+		return
+	}
+	if updated, err := config.QueryByID(r.Context(), id); err == nil {
+		existing = updated
+	}
+	h.recordAction(r.Context(), adminaction.ActionUpdate, config, id.String(), recordLabel(config, existing))
+
+	api.JSON(w, http.StatusOK, map[string]interface{}{"data": apiRecord(config, existing)})
+}
+
+// APIDelete deletes a record right away; the undo window only applies to the admin UI
+func (h *Handler) APIDelete(w http.ResponseWriter, r *http.Request) {
+	config, ok := h.apiModel(w, r)
+	if !ok {
+		return
+	}
+	id, record, ok := h.apiRecordByID(w, r, config)
+	if !ok || !h.apiAuthorize(w, r, config, record) {
+		return
+	}
+	if !h.Sudo.Active(r) {
+		h.apiSudoRequired(w)
+		return
+	}
+
+	if config.DeletePreview != nil && config.OnDelete != DeleteCascade {
+		related, err := config.DeletePreview(r.Context(), id)
+		if err != nil {
+			utils.Errorw("admin.delete_preview_failed", "model", config.Name, "error", err)
+			api.Error(w, http.StatusInternalServerError, "Failed to load related records")
+			return
+		}
+		if len(related) > 0 {
+			api.Error(w, http.StatusConflict,
+				fmt.Sprintf("Cannot delete this %s: %s still reference it", strings.ToLower(config.Name), related[0].Label()))
+			return
+		}
+	}
+
+	if err := config.DeleteFunc(r.Context(), id); err != nil {
+		utils.Errorw("admin.delete_failed", "model", config.Name, "error", err)
+		api.Error(w, http.StatusInternalServerError, fmt.Sprintf("Failed to delete %s", config.Name))
+		return
+	}
+	h.recordAction(r.Context(), adminaction.ActionDelete, config, id.String(), recordLabel(config, record))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiModel looks up the {model} URL parameter, writing a 404 if it isn't registered
+func (h *Handler) apiModel(w http.ResponseWriter, r *http.Request) (*ModelConfig, bool) {
+	config, err := h.Registry.Get(chi.URLParam(r, "model"))
+	if err != nil {
+		api.Error(w, http.StatusNotFound, "Model not found")
+		return nil, false
+	}
+	return config, true
+}
+
+// apiRecordByID loads the record named by the {id} URL parameter. Records
+// queued for deletion in the admin UI are treated as gone.
+func (h *Handler) apiRecordByID(w http.ResponseWriter, r *http.Request, config *ModelConfig) (uuid.UUID, interface{}, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "Invalid ID")
+		return id, nil, false
+	}
+	record, err := config.QueryByID(r.Context(), id)
+	if err != nil || h.undo.isPending(config.Name, id) {
+		api.Error(w, http.StatusNotFound, config.Name+" not found")
+		return id, nil, false
+	}
+	return id, record, true
+}
+
+// apiAuthorize writes a JSON 403 and returns false if the current user may not modify record
+func (h *Handler) apiAuthorize(w http.ResponseWriter, r *http.Request, config *ModelConfig, record interface{}) bool {
+	if h.permitted(r, config, record) {
+		return true
+	}
+	api.Error(w, http.StatusForbidden, fmt.Sprintf("You can only change your own %s", strings.ToLower(config.NamePlural)))
+	return false
+}
+
+// apiSudoRequired writes a JSON 403 for changes that need sudo mode, which
+// is started from the admin UI (the session is shared)
+func (h *Handler) apiSudoRequired(w http.ResponseWriter) {
+	api.Error(w, http.StatusForbidden, "Confirm your password at "+h.Sudo.PromptURL+" first")
+}
+
+// apiData reads a JSON object of field values into the data map the form
+// handlers build, so both go through the same validation and hooks. Values use
+// the admin form's formats; times may also be RFC 3339. Missing fields are left
+// out, so updates only change the fields that are sent. Unknown and read-only
+// fields are reported as errors rather than silently ignored.
+func (h *Handler) apiData(w http.ResponseWriter, r *http.Request, config *ModelConfig, isCreate bool) (map[string]interface{}, map[string]string, error) {
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody)).Decode(&body); err != nil {
+		return nil, nil, err
+	}
+	data, errors := h.apiFields(config, body, isCreate)
+	return data, errors, nil
+}
+
+// apiFields converts the field values of a decoded JSON object; see apiData
+func (h *Handler) apiFields(config *ModelConfig, body map[string]json.RawMessage, isCreate bool) (map[string]interface{}, map[string]string) {
+	data := make(map[string]interface{})
+	errors := make(map[string]string)
+	for _, field := range config.Fields {
+		raw, sent := body[field.Name]
+		if !sent {
+			continue
+		}
+		delete(body, field.Name)
+		if field.Readonly || field.Hidden || field.IsUpload() || field.Type == FieldTypeRelations {
+			errors[field.Name] = field.Label + " cannot be set through the API"
+			continue
+		}
+		if !field.OnForm(!isCreate) {
+			if isCreate {
+				errors[field.Name] = field.Label + " can only be set on update"
+			} else {
+				errors[field.Name] = field.Label + " can only be set on create"
+			}
+			continue
+		}
+
+		switch {
+		case string(raw) == "null":
+			data[field.Name] = nil
+		case field.Type == FieldTypeJSON:
+			data[field.Name] = jsonValue(raw)
+		case field.Type == FieldTypeTranslated:
+			var text i18n.Text
+			if err := json.Unmarshal(raw, &text); err != nil {
+				errors[field.Name] = field.Label + ` must be an object of translations, like {"` + i18n.Default() + `": "..."}`
+				continue
+			}
+			data[field.Name] = text
+		default:
+			// Strings are unquoted; numbers and booleans are used as written
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				value = string(raw)
+			}
+			if field.Type == FieldTypeTime {
+				if t, err := time.Parse(time.RFC3339, value); err == nil {
+					data[field.Name] = t
+					continue
+				}
+			}
+			data[field.Name] = h.parseFieldValue(field, value)
+			if _, invalid := data[field.Name].(string); invalid && field.Type == FieldTypeTime {
+				errors[field.Name] = field.Label + " must be a time like 2006-01-02T15:04:05Z"
+			}
+		}
+	}
+	for name := range body {
+		errors[name] = "Unknown field"
+	}
+
+	for name, msg := range h.validateFields(config, data, isCreate) {
+		// Fields that aren't sent keep their value on update, and booleans
+		// that aren't sent get their schema default on create
+		_, sent := data[name]
+		if f := config.Field(name); sent || (isCreate && f != nil && f.Type != FieldTypeBool) {
+			errors[name] = msg
+		}
+	}
+	return data, errors
+}
+
+// apiRecord converts a record to a JSON object keyed by field name. Hidden and
+// sensitive fields are left out; relations are the related record's ID.
+func apiRecord(config *ModelConfig, record interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(config.Fields))
+	for _, f := range config.Fields {
+		if f.Hidden || f.Sensitive || f.Type == FieldTypePassword || f.Type == FieldTypeRelations {
+			continue
+		}
+		switch {
+		case f.Type == FieldTypeComputed:
+			if f.Compute != nil {
+				out[f.Name] = f.Compute(record)
+			}
+		case f.Edge != "":
+			out[f.Name] = nil
+			if id := edgeIDValue(record, f.Edge); id != "" {
+				out[f.Name] = id
+			}
+		default:
+			if v, _, ok := lookupField(record, f.Name); ok {
+				out[f.Name] = v.Interface()
+			}
+		}
+	}
+	return out
+}
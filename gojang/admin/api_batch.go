@@ -0,0 +1,189 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// maxBatchOps is the most operations a batch request may hold
+const maxBatchOps = 100
+
+// apiBatchOp is one operation of a batch request
+type apiBatchOp struct {
+	Op   string                     `json:"op"` // "create" or "update"
+	ID   string                     `json:"id,omitempty"`
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+// apiBatchResult is the outcome of one operation, in request order
+type apiBatchResult struct {
+	Status int                    `json:"status"` // What the single-record endpoint would have answered
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+	Fields map[string]string      `json:"fields,omitempty"`
+}
+
+// apiBatchResponse is the body of POST /admin/api/{model}/batch
+type apiBatchResponse struct {
+	Committed bool             `json:"committed"`
+	Results   []apiBatchResult `json:"results"`
+}
+
+// APIBatch creates and updates records of one model from a JSON array of
+// operations, in one transaction:
+//
+//	[{"op": "create", "data": {"Subject": "Hello"}}, {"op": "update", "id": "308b...", "data": {"Body": "..."}}]
+//
+// Each operation goes through the same checks as APICreate and APIUpdate, and
+// sees the changes of those before it. The batch stops at the first operation
+// that fails and nothing is saved: the response is a 422 whose results give
+// that operation's error, and a 424 for every other one.
+func (h *Handler) APIBatch(w http.ResponseWriter, r *http.Request) {
+	config, ok := h.apiModel(w, r)
+	if !ok {
+		return
+	}
+	var ops []apiBatchOp
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody)).Decode(&ops); err != nil {
+		api.Error(w, http.StatusBadRequest, "Invalid JSON body (expected an array of operations)")
+		return
+	}
+	if len(ops) == 0 || len(ops) > maxBatchOps {
+		api.Error(w, http.StatusBadRequest, fmt.Sprintf("A batch holds 1-%d operations", maxBatchOps))
+		return
+	}
+
+	// Join the request's transaction if middleware.Transaction opened one
+	ctx := r.Context()
+	tx := ctxutil.Tx(ctx)
+	ownTx := tx == nil
+	if ownTx {
+		var err error
+		if tx, err = h.Registry.client.Tx(ctx); err != nil {
+			utils.Errorw("admin.batch_failed", "model", config.Name, "error", err)
+			api.Error(w, http.StatusInternalServerError, "Failed to start the batch")
+			return
+		}
+		ctx = ctxutil.WithTx(ctx, tx)
+	}
+
+	// Stop at the first failure: its transaction may not take more statements
+	results := make([]apiBatchResult, len(ops))
+	records := make([]interface{}, len(ops))
+	for i, op := range ops {
+		records[i], results[i] = h.batchOp(ctx, r, config, op)
+		if results[i].Status < http.StatusBadRequest {
+			continue
+		}
+		if ownTx {
+			_ = tx.Rollback()
+		}
+		if results[i].Status == http.StatusInternalServerError {
+			api.Error(w, http.StatusInternalServerError, fmt.Sprintf("Operation %d: %s", i, results[i].Error))
+			return
+		}
+		for j := range results {
+			if j != i {
+				results[j] = apiBatchResult{Status: http.StatusFailedDependency, Error: fmt.Sprintf("Not saved because operation %d failed", i)}
+			}
+		}
+		api.JSON(w, http.StatusUnprocessableEntity, apiBatchResponse{Results: results})
+		return
+	}
+	if ownTx {
+		if err := tx.Commit(); err != nil {
+			utils.Errorw("admin.batch_failed", "model", config.Name, "error", err)
+			api.Error(w, http.StatusInternalServerError, "Failed to save the batch")
+			return
+		}
+	}
+
+	for i, op := range ops {
+		action := adminaction.ActionUpdate
+		if op.Op == "create" {
+			action = adminaction.ActionCreate
+		}
+		h.recordAction(r.Context(), action, config, getIDValue(records[i]), recordLabel(config, records[i]))
+	}
+	utils.Infow("admin.batch_saved", "model", config.Name, "count", len(ops))
+	api.JSON(w, http.StatusOK, apiBatchResponse{Committed: true, Results: results})
+}
+
+// batchOp runs one operation in ctx's transaction and returns the saved record
+func (h *Handler) batchOp(ctx context.Context, r *http.Request, config *ModelConfig, op apiBatchOp) (interface{}, apiBatchResult) {
+	var id uuid.UUID
+	var existing interface{}
+	switch op.Op {
+	case "create":
+		if op.ID != "" {
+			return nil, apiBatchResult{Status: http.StatusBadRequest, Error: "Creates can't set an id"}
+		}
+	case "update":
+		var err error
+		if id, err = uuid.Parse(op.ID); err != nil {
+			return nil, apiBatchResult{Status: http.StatusBadRequest, Error: "Invalid ID"}
+		}
+		existing, err = config.QueryByID(ctx, id)
+		if err != nil || h.undo.isPending(config.Name, id) {
+			return nil, apiBatchResult{Status: http.StatusNotFound, Error: config.Name + " not found"}
+		}
+		if !h.permitted(r, config, existing) {
+			return nil, apiBatchResult{Status: http.StatusForbidden, Error: fmt.Sprintf("You can only change your own %s", strings.ToLower(config.NamePlural))}
+		}
+	default:
+		return nil, apiBatchResult{Status: http.StatusBadRequest, Error: `op must be "create" or "update"`}
+	}
+	isCreate := op.Op == "create"
+
+	data, errors := h.apiFields(config, op.Data, isCreate)
+	if len(errors) == 0 {
+		var err error
+		if errors, err = h.checkRecord(ctx, config, data, id); err != nil {
+			utils.Errorw("admin.check_unique_failed", "model", config.Name, "error", err)
+			return nil, apiBatchResult{Status: http.StatusInternalServerError, Error: "Failed to validate " + config.Name}
+		}
+	}
+	if len(errors) > 0 {
+		return nil, apiBatchResult{Status: http.StatusUnprocessableEntity, Error: "Validation failed", Fields: errors}
+	}
+	if !h.Sudo.Active(r) && h.changesSudoFields(ctx, config, existing, data) {
+		return nil, apiBatchResult{Status: http.StatusForbidden, Error: "Confirm your password at " + h.Sudo.PromptURL + " first"}
+	}
+
+	status := http.StatusOK
+	var err error
+	if isCreate {
+		status = http.StatusCreated
+		var created interface{}
+		if created, err = config.CreateFunc(ctx, data); err == nil {
+			id, _ = uuid.Parse(getIDValue(created))
+		}
+	} else {
+		err = config.UpdateFunc(ctx, id, data)
+	}
+	if errors, ok := db.FormErrors(err, "_general"); ok {
+		return nil, apiBatchResult{Status: http.StatusUnprocessableEntity, Error: "Validation failed", Fields: errors}
+	}
+	if err != nil {
+		utils.Errorw("admin.batch_op_failed", "model", config.Name, "op", op.Op, "error", err)
+		return nil, apiBatchResult{Status: http.StatusInternalServerError, Error: "Failed to save " + config.Name}
+	}
+
+	// Reload so eager-loaded edges (e.g., a post's author) are included
+	record, err := config.QueryByID(ctx, id)
+	if err != nil {
+		utils.Errorw("admin.batch_op_failed", "model", config.Name, "op", op.Op, "error", err)
+		return nil, apiBatchResult{Status: http.StatusInternalServerError, Error: "Failed to save " + config.Name}
+	}
+	return record, apiBatchResult{Status: status, Data: apiRecord(config, record)}
+}
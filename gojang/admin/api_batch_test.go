@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models/post"
+)
+
+// TestAPIBatch tests that a batch is saved in one transaction and reports each operation
+func TestAPIBatch(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	ctx := context.Background()
+
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	existing := client.Post.Create().SetSubject("Old").SetBody("x").SetAuthor(admin).SaveX(ctx)
+	router := apiRouter(handler, admin)
+
+	// A failing operation rolls back the others
+	status, res := apiRequest(t, router, http.MethodPost, "/post/batch", `[
+		{"op": "create", "data": {"Subject": "One", "Body": "x"}},
+		{"op": "update", "id": "`+existing.ID.String()+`", "data": {"Subject": ""}}
+	]`)
+	if status != http.StatusUnprocessableEntity || res["committed"] != false {
+		t.Fatalf("Expected a 422, got %d: %v", status, res)
+	}
+	results := res["results"].([]interface{})
+	if first := results[0].(map[string]interface{}); first["status"] != float64(http.StatusFailedDependency) {
+		t.Errorf("Expected a 424 for the create, got %v", first)
+	}
+	if second := results[1].(map[string]interface{}); second["status"] != float64(http.StatusUnprocessableEntity) || second["fields"].(map[string]interface{})["Subject"] == nil {
+		t.Errorf("Expected a Subject error for the update, got %v", second)
+	}
+	if n := client.Post.Query().CountX(ctx); n != 1 {
+		t.Errorf("Expected the create to be rolled back, got %d posts", n)
+	}
+
+	status, res = apiRequest(t, router, http.MethodPost, "/post/batch", `[
+		{"op": "create", "data": {"Subject": "One", "Body": "x"}},
+		{"op": "create", "data": {"Subject": "Two", "Body": "x"}},
+		{"op": "update", "id": "`+existing.ID.String()+`", "data": {"Subject": "New"}}
+	]`)
+	if status != http.StatusOK || res["committed"] != true {
+		t.Fatalf("Expected the batch to be saved, got %d: %v", status, res)
+	}
+	results = res["results"].([]interface{})
+	if created := results[0].(map[string]interface{}); created["status"] != float64(http.StatusCreated) || created["data"].(map[string]interface{})["Subject"] != "One" {
+		t.Errorf("Unexpected create result %v", created)
+	}
+	if updated := results[2].(map[string]interface{}); updated["status"] != float64(http.StatusOK) {
+		t.Errorf("Unexpected update result %v", updated)
+	}
+	if n := client.Post.Query().CountX(ctx); n != 3 {
+		t.Errorf("Expected 3 posts, got %d", n)
+	}
+	if p := client.Post.Query().Where(post.ID(existing.ID)).OnlyX(ctx); p.Subject != "New" || p.Body != "x" {
+		t.Errorf("Expected only the subject to change, got %+v", p)
+	}
+	if n := client.AdminAction.Query().CountX(ctx); n != 3 {
+		t.Errorf("Expected one activity entry per operation, got %d", n)
+	}
+
+	for _, body := range []string{`{}`, `[]`, `[{"op": "delete", "id": "x"}]`} {
+		if status, _ := apiRequest(t, router, http.MethodPost, "/post/batch", body); status == http.StatusOK {
+			t.Errorf("Expected %s to be rejected", body)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// apiRouter serves the admin API routes as user, without the session and CSRF middleware
+func apiRouter(handler *Handler, user *models.User) http.Handler {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(middleware.WithUser(r.Context(), user)))
+		})
+	})
+	r.Get("/{model}", handler.APIList)
+	r.Post("/{model}", handler.APICreate)
+	r.Get("/{model}/{id}", handler.APIGet)
+	r.Patch("/{model}/{id}", handler.APIUpdate)
+	r.Delete("/{model}/{id}", handler.APIDelete)
+	r.Post("/{model}/batch", handler.APIBatch)
+	return r
+}
+
+// apiRequest sends a request to router and decodes the JSON response body, if any
+func apiRequest(t *testing.T, router http.Handler, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	var res map[string]interface{}
+	if w.Body.Len() > 0 {
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("%s %s: invalid JSON response %q", method, path, w.Body.String())
+		}
+	}
+	return w.Code, res
+}
+
+// TestAPI_CRUD tests creating, reading, listing, updating and deleting a post
+func TestAPI_CRUD(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	ctx := context.Background()
+
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	router := apiRouter(handler, admin)
+
+	status, res := apiRequest(t, router, http.MethodPost, "/post", `{"Subject": "Hello", "Body": "Two words"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("Create: expected 201, got %d: %v", status, res)
+	}
+	created := res["data"].(map[string]interface{})
+	id, _ := created["ID"].(string)
+	if created["Subject"] != "Hello" || created["AuthorID"] != admin.ID.String() || created["WordCount"] != float64(2) {
+		t.Errorf("Create: unexpected record %v", created)
+	}
+
+	if status, res = apiRequest(t, router, http.MethodGet, "/post/"+id, ""); status != http.StatusOK {
+		t.Errorf("Get: expected 200, got %d: %v", status, res)
+	}
+
+	status, res = apiRequest(t, router, http.MethodGet, "/post?f_Subject=hell&sort=-CreatedAt", "")
+	if status != http.StatusOK || res["total"] != float64(1) || len(res["data"].([]interface{})) != 1 {
+		t.Errorf("List: expected one matching post, got %d: %v", status, res)
+	}
+
+	status, res = apiRequest(t, router, http.MethodPatch, "/post/"+id, `{"Subject": "Changed"}`)
+	if status != http.StatusOK {
+		t.Fatalf("Update: expected 200, got %d: %v", status, res)
+	}
+	if p := client.Post.Query().OnlyX(ctx); p.Subject != "Changed" || p.Body != "Two words" {
+		t.Errorf("Update: expected only the subject to change, got %q / %q", p.Subject, p.Body)
+	}
+
+	if status, _ = apiRequest(t, router, http.MethodDelete, "/post/"+id, ""); status != http.StatusNoContent {
+		t.Errorf("Delete: expected 204, got %d", status)
+	}
+	if n := client.Post.Query().CountX(ctx); n != 0 {
+		t.Errorf("Delete: expected no posts, got %d", n)
+	}
+	if status, _ = apiRequest(t, router, http.MethodGet, "/post/"+id, ""); status != http.StatusNotFound {
+		t.Errorf("Get after delete: expected 404, got %d", status)
+	}
+}
+
+// TestAPI_Validation tests that invalid input is rejected with field errors
+func TestAPI_Validation(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(context.Background())
+	router := apiRouter(handler, admin)
+
+	tests := []struct {
+		name, body, field string
+	}{
+		{"missing required field", `{"Body": "b"}`, "Subject"},
+		{"read-only field", `{"Subject": "s", "Body": "b", "ID": "8d6e2c62-8f8a-4f3f-9f2a-3c1e6c0f1b2a"}`, "ID"},
+		{"unknown field", `{"Subject": "s", "Body": "b", "Title": "t"}`, "Title"},
+	}
+	for _, tt := range tests {
+		status, res := apiRequest(t, router, http.MethodPost, "/post", tt.body)
+		if status != http.StatusUnprocessableEntity {
+			t.Errorf("%s: expected 422, got %d: %v", tt.name, status, res)
+			continue
+		}
+		if fields, _ := res["fields"].(map[string]interface{}); fields[tt.field] == nil {
+			t.Errorf("%s: expected an error for %s, got %v", tt.name, tt.field, res)
+		}
+	}
+
+	if status, _ := apiRequest(t, router, http.MethodPost, "/post", `not json`); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid body, got %d", status)
+	}
+	if status, _ := apiRequest(t, router, http.MethodGet, "/widget", ""); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown model, got %d", status)
+	}
+	if status, _ := apiRequest(t, router, http.MethodGet, "/post?sort=Body", ""); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unsortable field, got %d", status)
+	}
+}
+
+// TestAPI_Permissions tests that staff can't change other authors' posts through the API
+func TestAPI_Permissions(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	ctx := context.Background()
+
+	author := seedUserWithPosts(t, client, 1)
+	post := client.User.QueryPosts(author).OnlyX(ctx)
+	staff := client.User.Create().SetEmail("staff@example.com").SetPasswordHash("x").SetIsStaff(true).SaveX(ctx)
+	router := apiRouter(handler, staff)
+
+	if status, _ := apiRequest(t, router, http.MethodPatch, "/post/"+post.ID.String(), `{"Subject": "changed"}`); status != http.StatusForbidden {
+		t.Errorf("Update: expected 403, got %d", status)
+	}
+	if status, _ := apiRequest(t, router, http.MethodDelete, "/post/"+post.ID.String(), ""); status != http.StatusForbidden {
+		t.Errorf("Delete: expected 403, got %d", status)
+	}
+	if got := client.Post.GetX(ctx, post.ID).Subject; got != "s" {
+		t.Errorf("Expected post to be unchanged, got subject %q", got)
+	}
+
+	// Reading is not restricted
+	if status, _ := apiRequest(t, router, http.MethodGet, "/post/"+post.ID.String(), ""); status != http.StatusOK {
+		t.Errorf("Get: expected 200, got %d", status)
+	}
+}
+
+// TestAPIRecord_HidesSensitiveFields tests that password hashes never leave the server
+func TestAPIRecord_HidesSensitiveFields(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	config, _ := registry.Get("user")
+
+	u := client.User.Create().SetEmail("a@example.com").SetPasswordHash("secret-hash").SaveX(context.Background())
+	record := apiRecord(config, u)
+	for _, name := range []string{"PasswordHash", "Password", "PasswordConfirmation"} {
+		if _, ok := record[name]; ok {
+			t.Errorf("Expected %s to be left out, got %v", name, record[name])
+		}
+	}
+	if record["Email"] != "a@example.com" {
+		t.Errorf("Expected Email in record, got %v", record)
+	}
+}
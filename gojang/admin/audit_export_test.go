@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/auditexport"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/mail"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+)
+
+// TestAuditExport tests downloading and delivering the audit log from the settings page
+func TestAuditExport(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	handler := NewHandler(NewRegistry(client), renderer, client)
+	ctx := context.Background()
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	client.AdminAction.Create().
+		SetAction("delete").SetModel("Post").SetRecordID("1").SetRecordLabel("Hello").
+		SetUserID(admin.ID).SetUserEmail(admin.Email).
+		SetCreatedAt(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)).
+		SaveX(ctx)
+
+	send := func(h http.HandlerFunc, method string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/?"+form.Encode(), nil)
+		if method == http.MethodPost {
+			req = httptest.NewRequest(method, "/", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		req.Header.Set("HX-Request", "true")
+		req = req.WithContext(middleware.WithUser(req.Context(), admin))
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w
+	}
+
+	rec := send(handler.AuditExportDownload, http.MethodGet, url.Values{"from": {"2026-10-01"}, "to": {"2026-10-01"}, "format": {"csv"}})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Disposition"), "admin-actions-2026-10-01-2026-10-01.csv") {
+		t.Fatalf("Expected a CSV download, got %d %v", rec.Code, rec.Header())
+	}
+	if lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[1], ",delete,,Post,1,Hello,") {
+		t.Errorf("Unexpected export %q", rec.Body.String())
+	}
+	if n := client.AdminAction.Query().Where(adminaction.ActionEQ(adminaction.ActionRun), adminaction.ModelEQ("AdminAction")).CountX(ctx); n != 1 {
+		t.Errorf("Expected the export to be recorded in the activity log, got %d entries", n)
+	}
+
+	if rec := send(handler.AuditExportDownload, http.MethodGet, url.Values{"from": {"2026-10-02"}, "to": {"2026-10-01"}, "format": {"csv"}}); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a reversed range, got %d", rec.Code)
+	}
+
+	// Delivery needs a destination
+	form := url.Values{"from": {"2026-10-01"}, "to": {"2026-10-01"}}
+	if body := send(handler.AuditExportDeliver, http.MethodPost, form).Body.String(); !strings.Contains(body, "No export storage or email recipients") {
+		t.Errorf("Expected an error without destinations, got %s", body)
+	}
+	outbox := &mail.Outbox{}
+	handler.AuditExport = &auditexport.Exporter{Client: client, Format: auditexport.NDJSON, Email: []string{"security@example.com"}, Mailer: outbox}
+	if body := send(handler.AuditExportDeliver, http.MethodPost, form).Body.String(); !strings.Contains(body, "Sent admin-actions-2026-10-01-2026-10-01.ndjson (actions: 1,") {
+		t.Errorf("Expected the export to be sent, got %s", body)
+	}
+	if msgs := outbox.Messages(); len(msgs) != 1 || !strings.Contains(string(msgs[0].Attachments[0].Data), `"record_label":"Hello"`) {
+		t.Errorf("Expected one email with the NDJSON attached, got %v", msgs)
+	}
+
+	if body := send(handler.SettingsIndex, http.MethodGet, nil).Body.String(); !strings.Contains(body, "Send to export destinations") {
+		t.Errorf("Expected the export form on the settings page, got %s", body)
+	}
+}
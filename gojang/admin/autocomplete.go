@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 50
+)
+
+// AutocompleteResult is one suggestion returned by the autocomplete endpoint
+type AutocompleteResult struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// Autocomplete returns JSON {id,label} pairs matching ?q= for relation fields
+func (h *Handler) Autocomplete(w http.ResponseWriter, r *http.Request) {
+	modelName := chi.URLParam(r, "model")
+
+	// Admin routes already require staff; check again since results may be embedded elsewhere
+	user := middleware.GetUser(r.Context())
+	if user == nil || !user.IsStaff {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	config, err := h.Registry.Get(modelName)
+	if err != nil || len(config.SearchFields) == 0 || config.Search == nil {
+		http.Error(w, "Autocomplete not available for this model", http.StatusNotFound)
+		return
+	}
+
+	limit := defaultAutocompleteLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 {
+			limit = l
+		}
+	}
+	if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	records, err := config.Search(r.Context(), q, limit)
+	if err != nil {
+		utils.Errorw("admin.autocomplete_failed", "model", config.Name, "error", err)
+		http.Error(w, "Search failed", http.StatusInternalServerError)
+		return
+	}
+
+	results := make([]AutocompleteResult, 0, len(records))
+	for _, rec := range records {
+		results = append(results, AutocompleteResult{
+			ID:    getIDValue(rec),
+			Label: fmt.Sprintf("%v", extractFieldValue(rec, config.LabelField)),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// search finds up to limit records whose search fields contain q (case-insensitive)
+func (r *Registry) search(ctx context.Context, modelName string, fields []string, q string, limit int) ([]interface{}, error) {
+	modelClient := reflect.ValueOf(r.client).Elem().FieldByName(modelName)
+	if !modelClient.IsValid() {
+		return nil, fmt.Errorf("model %s not found on client", modelName)
+	}
+
+	queryMethod := modelClient.MethodByName("Query")
+	if !queryMethod.IsValid() {
+		return nil, fmt.Errorf("query method not found for model %s", modelName)
+	}
+	query := queryMethod.Call(nil)[0]
+
+	if q != "" {
+		preds := make([]func(*sql.Selector), 0, len(fields))
+		for _, f := range fields {
+			preds = append(preds, sql.FieldContainsFold(columnName(f), q))
+		}
+		var err error
+		query, err = applyWhere(query, sql.OrPredicates(preds...))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query = query.MethodByName("Limit").Call([]reflect.Value{reflect.ValueOf(limit)})[0]
+
+	all, err := callWithErr(query, "All", ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, all.Len())
+	for i := range results {
+		results[i] = all.Index(i).Interface()
+	}
+	return results, nil
+}
+
+// columnName converts a Go field name to its Ent column name (e.g., "LastLogin" -> "last_login")
+func columnName(field string) string {
+	var b strings.Builder
+	runes := []rune(field)
+	for i, c := range runes {
+		if unicode.IsUpper(c) {
+			// Start a new word unless continuing an acronym (e.g., "ID")
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(c))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// TestColumnName tests Go field name to Ent column name conversion
+func TestColumnName(t *testing.T) {
+	tests := map[string]string{
+		"Email":       "email",
+		"LastLogin":   "last_login",
+		"ID":          "id",
+		"AuthorID":    "author_id",
+		"HTMLContent": "html_content",
+	}
+	for in, want := range tests {
+		if got := columnName(in); got != want {
+			t.Errorf("columnName(%q) = %q, expected %q", in, got, want)
+		}
+	}
+}
+
+// autocompleteRequest builds a GET /admin/{model}/autocomplete request for user
+func autocompleteRequest(model, query string, user *models.User) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/admin/"+model+"/autocomplete?"+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("model", model)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if user != nil {
+		ctx = middleware.WithUser(ctx, user)
+	}
+	return req.WithContext(ctx)
+}
+
+// TestAutocomplete tests matching, limits and permission checks
+func TestAutocomplete(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	ctx := context.Background()
+
+	for _, email := range []string{"alice@example.com", "alan@example.com", "bob@example.com"} {
+		client.User.Create().SetEmail(email).SetPasswordHash("x").SaveX(ctx)
+	}
+	staff := &models.User{Email: "staff@example.com", IsStaff: true}
+
+	w := httptest.NewRecorder()
+	handler.Autocomplete(w, autocompleteRequest("user", "q=AL", staff))
+	var results []AutocompleteResult
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", w.Body.String(), err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 case-insensitive matches, got %+v", results)
+	}
+	for _, r := range results {
+		if r.ID == "" || r.Label == "" {
+			t.Errorf("Expected id and label, got %+v", r)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	handler.Autocomplete(w, autocompleteRequest("user", "limit=1", staff))
+	results = nil
+	json.Unmarshal(w.Body.Bytes(), &results)
+	if len(results) != 1 {
+		t.Errorf("Expected limit to cap results at 1, got %d", len(results))
+	}
+
+	w = httptest.NewRecorder()
+	handler.Autocomplete(w, autocompleteRequest("user", "q=a", &models.User{Email: "user@example.com"}))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for non-staff user, got %d", w.Code)
+	}
+
+	registry.models["user"].SearchFields = nil
+	w = httptest.NewRecorder()
+	handler.Autocomplete(w, autocompleteRequest("user", "q=a", staff))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for model without search fields, got %d", w.Code)
+	}
+}
+
+// TestRegisterModel_SensitiveSearchField tests that sensitive fields can't be searched
+func TestRegisterModel_SensitiveSearchField(t *testing.T) {
+	registry := &Registry{models: make(map[string]*ModelConfig)}
+
+	err := registry.RegisterModel(ModelRegistration{
+		ModelType:    &models.User{},
+		HiddenFields: []string{"PasswordHash"},
+		SearchFields: []string{"PasswordHash"},
+	})
+	if err == nil {
+		t.Error("Expected error for sensitive search field")
+	}
+}
+
+// TestQueryByID_AppliesModifier tests that eager loading applies when loading one record
+func TestQueryByID_AppliesModifier(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	owner := seedUserWithPosts(t, client, 1)
+	p := client.Post.Query().FirstX(context.Background())
+
+	config, _ := registry.Get("post")
+	record, err := config.QueryByID(context.Background(), p.ID)
+	if err != nil {
+		t.Fatalf("QueryByID failed: %v", err)
+	}
+	if got := edgeIDValue(record, "Author"); got != owner.ID.String() {
+		t.Errorf("Expected Author edge to be loaded with %s, got %q", owner.ID, got)
+	}
+}
@@ -0,0 +1,219 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+// DeleteBehavior controls what happens to related records when a record is deleted
+type DeleteBehavior string
+
+const (
+	// DeleteRestrict refuses to delete a record while related records exist (default)
+	DeleteRestrict DeleteBehavior = "restrict"
+	// DeleteCascade deletes related records together with the record
+	DeleteCascade DeleteBehavior = "cascade"
+)
+
+// maxCascadeDepth guards against cycles in the edge graph
+const maxCascadeDepth = 5
+
+// RelatedCount describes how many records of one edge a delete affects
+type RelatedCount struct {
+	Edge  string // Edge name on the parent (e.g., "Posts")
+	Model string // Related model name (e.g., "Post")
+	Count int
+}
+
+// Label formats the count for display (e.g., "14 posts")
+func (rc RelatedCount) Label() string {
+	if rc.Count == 1 {
+		return "1 " + strings.ToLower(rc.Model)
+	}
+	return fmt.Sprintf("%d %s", rc.Count, strings.ToLower(pluralize(rc.Model)))
+}
+
+// ownedEdges returns the names of to-many edges on a model (e.g., User.Edges.Posts).
+// Unique edges point at parents (e.g., Post.Author), and many-to-many edges (e.g.,
+// User.Edges.Groups) link records that outlive each other; neither is affected by
+// a delete.
+func ownedEdges(modelType reflect.Type) []string {
+	meta := metaForType(modelType)
+	if meta == nil {
+		return nil
+	}
+
+	var edges []string
+	for name, acc := range meta.accessors {
+		if acc.isEdge && acc.typ.Kind() == reflect.Slice && !isManyToMany(meta.typ, acc.typ.Elem()) {
+			edges = append(edges, name)
+		}
+	}
+	return edges
+}
+
+// isManyToMany reports whether related (e.g., *models.Group) has a to-many edge
+// back to modelType, which makes the edge between them many-to-many
+func isManyToMany(modelType, related reflect.Type) bool {
+	relatedMeta := metaForType(related)
+	if relatedMeta == nil {
+		return false
+	}
+	for _, acc := range relatedMeta.accessors {
+		if acc.isEdge && acc.typ.Kind() == reflect.Slice && acc.typ.Elem() == reflect.PointerTo(modelType) {
+			return true
+		}
+	}
+	return false
+}
+
+// relatedQuery calls <Model>Client.Query<Edge>(record) on the given client
+func relatedQuery(client *models.Client, modelName, edge string, record interface{}) (reflect.Value, error) {
+	modelClient := reflect.ValueOf(client).Elem().FieldByName(modelName)
+	if !modelClient.IsValid() {
+		return reflect.Value{}, fmt.Errorf("model %s not found on client", modelName)
+	}
+
+	queryMethod := modelClient.MethodByName("Query" + edge)
+	if !queryMethod.IsValid() {
+		return reflect.Value{}, fmt.Errorf("Query%s method not found for model %s", edge, modelName)
+	}
+
+	out := queryMethod.Call([]reflect.Value{reflect.ValueOf(record)})
+	if len(out) == 0 {
+		return reflect.Value{}, fmt.Errorf("Query%s method returned no results for model %s", edge, modelName)
+	}
+	return out[0], nil
+}
+
+// callWithErr calls a (T, error)-returning method with ctx and returns T
+func callWithErr(query reflect.Value, method string, ctx context.Context) (reflect.Value, error) {
+	m := query.MethodByName(method)
+	if !m.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%s method not found on %s", method, query.Type())
+	}
+	out := m.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if len(out) != 2 {
+		return reflect.Value{}, fmt.Errorf("%s method returned unexpected number of values", method)
+	}
+	if !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+	return out[0], nil
+}
+
+// deletePreview counts the related records a delete of id would affect
+func (r *Registry) deletePreview(ctx context.Context, modelName string, modelType reflect.Type, id uuid.UUID) ([]RelatedCount, error) {
+	record, err := r.queryByID(ctx, modelName, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []RelatedCount
+	for _, edge := range ownedEdges(modelType) {
+		query, err := relatedQuery(r.client, modelName, edge, record)
+		if err != nil {
+			return nil, err
+		}
+		n, err := callWithErr(query, "Count", ctx)
+		if err != nil {
+			return nil, fmt.Errorf("counting %s: %w", edge, err)
+		}
+		if n.Int() > 0 {
+			counts = append(counts, RelatedCount{
+				Edge:  edge,
+				Model: relatedModelName(modelType, edge),
+				Count: int(n.Int()),
+			})
+		}
+	}
+	return counts, nil
+}
+
+// relatedModelName returns the element type name of a to-many edge (e.g., "Post")
+func relatedModelName(modelType reflect.Type, edge string) string {
+	acc := metaForType(modelType).accessors[edge]
+	elem := acc.typ.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Name()
+}
+
+// deleteWithRelated deletes a record according to behavior. Restrict fails if any
+// related records exist; cascade deletes them first. Everything runs in one transaction.
+func (r *Registry) deleteWithRelated(ctx context.Context, modelName string, modelType reflect.Type, id uuid.UUID, behavior DeleteBehavior) error {
+	if behavior != DeleteCascade {
+		counts, err := r.deletePreview(ctx, modelName, modelType, id)
+		if err != nil {
+			return err
+		}
+		if len(counts) > 0 {
+			return fmt.Errorf("cannot delete %s: %s still reference it", strings.ToLower(modelName), counts[0].Label())
+		}
+		return r.genericDelete(ctx, modelName, id)
+	}
+
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	record, err := queryByIDWith(ctx, tx.Client(), modelName, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := cascadeDelete(ctx, tx.Client(), modelName, modelType, record, 0); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// cascadeDelete deletes record's to-many children (recursively), then the record itself
+func cascadeDelete(ctx context.Context, client *models.Client, modelName string, modelType reflect.Type, record interface{}, depth int) error {
+	if depth > maxCascadeDepth {
+		return fmt.Errorf("cascade delete exceeded depth %d at %s", maxCascadeDepth, modelName)
+	}
+
+	for _, edge := range ownedEdges(modelType) {
+		query, err := relatedQuery(client, modelName, edge, record)
+		if err != nil {
+			return err
+		}
+		children, err := callWithErr(query, "All", ctx)
+		if err != nil {
+			return fmt.Errorf("loading %s: %w", edge, err)
+		}
+
+		childType := reflect.TypeOf(children.Interface()).Elem()
+		childName := relatedModelName(modelType, edge)
+		for i := 0; i < children.Len(); i++ {
+			if err := cascadeDelete(ctx, client, childName, childType, children.Index(i).Interface(), depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	id, err := uuid.Parse(getIDValue(record))
+	if err != nil {
+		return fmt.Errorf("reading %s ID: %w", modelName, err)
+	}
+	return deleteByIDWith(ctx, client, modelName, id)
+}
+
+// queryByIDWith is queryByID against an explicit client (e.g., a transaction's)
+func queryByIDWith(ctx context.Context, client *models.Client, modelName string, id uuid.UUID) (interface{}, error) {
+	return (&Registry{client: client}).queryByID(ctx, modelName, id, nil)
+}
+
+// deleteByIDWith is genericDelete against an explicit client (e.g., a transaction's)
+func deleteByIDWith(ctx context.Context, client *models.Client, modelName string, id uuid.UUID) error {
+	return (&Registry{client: client}).genericDelete(ctx, modelName, id)
+}
@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// seedUserWithPosts creates a user owning n posts
+func seedUserWithPosts(t *testing.T, client *models.Client, n int) *models.User {
+	t.Helper()
+	ctx := context.Background()
+	u := client.User.Create().SetEmail("owner@example.com").SetPasswordHash("x").SaveX(ctx)
+	for i := 0; i < n; i++ {
+		client.Post.Create().SetSubject("s").SetBody("b").SetAuthor(u).SaveX(ctx)
+	}
+	return u
+}
+
+// TestDeletePreview_CountsRelated tests that to-many edges are counted and parent edges ignored
+func TestDeletePreview_CountsRelated(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	u := seedUserWithPosts(t, client, 3)
+
+	userConfig, _ := registry.Get("user")
+	related, err := userConfig.DeletePreview(context.Background(), u.ID)
+	if err != nil {
+		t.Fatalf("DeletePreview failed: %v", err)
+	}
+	if len(related) != 1 || related[0].Count != 3 || related[0].Label() != "3 posts" {
+		t.Errorf("Expected [3 posts], got %+v", related)
+	}
+
+	p := client.Post.Query().FirstX(context.Background())
+	postConfig, _ := registry.Get("post")
+	related, err = postConfig.DeletePreview(context.Background(), p.ID)
+	if err != nil {
+		t.Fatalf("DeletePreview failed: %v", err)
+	}
+	if len(related) != 0 {
+		t.Errorf("Expected deleting a post to affect nothing else, got %+v", related)
+	}
+}
+
+// TestDeleteFunc_Cascade tests that cascade deletes related records with the parent
+func TestDeleteFunc_Cascade(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	u := seedUserWithPosts(t, client, 2)
+
+	userConfig, _ := registry.Get("user")
+	if err := userConfig.DeleteFunc(context.Background(), u.ID); err != nil {
+		t.Fatalf("DeleteFunc failed: %v", err)
+	}
+	if n := client.Post.Query().CountX(context.Background()); n != 0 {
+		t.Errorf("Expected posts to be deleted, %d remain", n)
+	}
+	if n := client.User.Query().CountX(context.Background()); n != 0 {
+		t.Errorf("Expected user to be deleted, %d remain", n)
+	}
+}
+
+// TestDeleteFunc_Restrict tests that restrict refuses to delete while related records exist
+func TestDeleteFunc_Restrict(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	if err := registry.RegisterModel(ModelRegistration{
+		ModelType:  &models.User{},
+		ListFields: []string{"Email"},
+	}); err != nil {
+		t.Fatalf("RegisterModel failed: %v", err)
+	}
+	u := seedUserWithPosts(t, client, 1)
+
+	userConfig, _ := registry.Get("user")
+	if userConfig.OnDelete != DeleteRestrict {
+		t.Errorf("Expected restrict by default, got %q", userConfig.OnDelete)
+	}
+	if err := userConfig.DeleteFunc(context.Background(), u.ID); err == nil {
+		t.Fatal("Expected restrict to refuse the delete")
+	}
+	if n := client.User.Query().CountX(context.Background()); n != 1 {
+		t.Errorf("Expected user to remain, got %d users", n)
+	}
+}
+
+// TestDeleteFunc_ManyToMany tests that many-to-many links never block or cascade a delete
+func TestDeleteFunc_ManyToMany(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	ctx := context.Background()
+
+	editors := client.Group.Create().SetName("Editors").SaveX(ctx)
+	u := client.User.Create().SetEmail("member@example.com").SetPasswordHash("x").AddGroups(editors).SaveX(ctx)
+
+	groupConfig, _ := registry.Get("group")
+	if related, err := groupConfig.DeletePreview(ctx, editors.ID); err != nil || len(related) != 0 {
+		t.Errorf("Expected deleting a group to affect no records, got %+v (%v)", related, err)
+	}
+
+	userConfig, _ := registry.Get("user")
+	if err := userConfig.DeleteFunc(ctx, u.ID); err != nil {
+		t.Fatalf("DeleteFunc failed: %v", err)
+	}
+	if n := client.Group.Query().CountX(ctx); n != 1 {
+		t.Errorf("Expected the group to remain, got %d groups", n)
+	}
+}
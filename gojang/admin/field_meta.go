@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldAccessor reads one field from a model struct using a precomputed index path
+type fieldAccessor struct {
+	index  []int        // Index path passed to reflect.Value.FieldByIndex
+	typ    reflect.Type // Declared type of the field
+	isEdge bool         // Field lives under the Ent "Edges" struct
+}
+
+// modelMeta holds the cached accessors for one model struct type
+type modelMeta struct {
+	typ       reflect.Type
+	accessors map[string]fieldAccessor
+}
+
+// metaCache maps a struct reflect.Type to its *modelMeta
+var metaCache sync.Map
+
+// metaForType returns the cached metadata for a struct type, building it on first use
+func metaForType(t reflect.Type) *modelMeta {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if cached, ok := metaCache.Load(t); ok {
+		return cached.(*modelMeta)
+	}
+
+	meta, _ := metaCache.LoadOrStore(t, buildModelMeta(t))
+	return meta.(*modelMeta)
+}
+
+// buildModelMeta walks the struct once and records an accessor for every exported
+// field, plus every exported field of the Ent "Edges" struct (e.g., Post.Edges.Author)
+func buildModelMeta(t reflect.Type) *modelMeta {
+	meta := &modelMeta{
+		typ:       t,
+		accessors: make(map[string]fieldAccessor),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Name == "selectValues" {
+			continue
+		}
+
+		if field.Name == "Edges" && field.Type.Kind() == reflect.Struct {
+			for j := 0; j < field.Type.NumField(); j++ {
+				edge := field.Type.Field(j)
+				if !edge.IsExported() {
+					continue
+				}
+				// Direct fields win over edges with the same name
+				if _, exists := meta.accessors[edge.Name]; exists {
+					continue
+				}
+				meta.accessors[edge.Name] = fieldAccessor{
+					index:  []int{i, j},
+					typ:    edge.Type,
+					isEdge: true,
+				}
+			}
+			continue
+		}
+
+		meta.accessors[field.Name] = fieldAccessor{
+			index: []int{i},
+			typ:   field.Type,
+		}
+	}
+
+	return meta
+}
+
+// has reports whether the model has a field or edge with the given name
+func (m *modelMeta) has(name string) bool {
+	_, ok := m.accessors[name]
+	return ok
+}
+
+// lookupField resolves a record and field name to the field's reflect.Value.
+// It returns false if the record is nil, not a struct, or has no such field.
+func lookupField(obj interface{}, fieldName string) (reflect.Value, fieldAccessor, bool) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, fieldAccessor{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fieldAccessor{}, false
+	}
+
+	meta := metaForType(v.Type())
+	acc, ok := meta.accessors[fieldName]
+	if !ok {
+		return reflect.Value{}, fieldAccessor{}, false
+	}
+
+	field := v.FieldByIndex(acc.index)
+	if !field.CanInterface() {
+		return reflect.Value{}, fieldAccessor{}, false
+	}
+	return field, acc, true
+}
@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+// TestMetaForType_CachesPerType tests that metadata is built once per struct type
+func TestMetaForType_CachesPerType(t *testing.T) {
+	first := metaForType(reflect.TypeOf(&models.Post{}))
+	second := metaForType(reflect.TypeOf(models.Post{}))
+
+	if first != second {
+		t.Error("Expected pointer and value types to share cached metadata")
+	}
+	if !first.has("Subject") {
+		t.Error("Expected direct field Subject to be indexed")
+	}
+	if !first.has("Author") {
+		t.Error("Expected edge field Author to be indexed")
+	}
+	if first.has("selectValues") {
+		t.Error("Expected unexported fields to be skipped")
+	}
+}
+
+// TestExtractFieldValue_EdgeAndPointer tests edge resolution and pointer dereferencing
+func TestExtractFieldValue_EdgeAndPointer(t *testing.T) {
+	lastLogin := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	author := &models.User{Email: "author@example.com", LastLogin: &lastLogin}
+	post := &models.Post{Subject: "Hello"}
+	post.Edges.Author = author
+
+	if got := extractFieldValue(post, "Subject"); got != "Hello" {
+		t.Errorf("Expected Subject 'Hello', got %v", got)
+	}
+	if got := extractFieldValue(post, "Author"); got != "author@example.com" {
+		t.Errorf("Expected Author to render as email, got %v", got)
+	}
+	if got := extractFieldValue(author, "LastLogin"); got != "2025-01-02 03:04:05" {
+		t.Errorf("Expected formatted LastLogin, got %v", got)
+	}
+	if got := extractFieldValue(&models.User{}, "LastLogin"); got != "-" {
+		t.Errorf("Expected '-' for nil LastLogin, got %v", got)
+	}
+	if got := extractFieldValue(post, "Missing"); got != "" {
+		t.Errorf("Expected empty string for unknown field, got %v", got)
+	}
+	if got := extractFieldValue((*models.Post)(nil), "Subject"); got != "" {
+		t.Errorf("Expected empty string for nil record, got %v", got)
+	}
+}
+
+// TestGetIDValue_UUID tests that UUID IDs are rendered as strings
+func TestGetIDValue_UUID(t *testing.T) {
+	id := uuid.New()
+	if got := getIDValue(&models.User{ID: id}); got != id.String() {
+		t.Errorf("Expected %s, got %s", id, got)
+	}
+}
+
+// TestRegisterModel_UnknownListField tests that misconfigured list columns fail registration
+func TestRegisterModel_UnknownListField(t *testing.T) {
+	registry := &Registry{
+		models: make(map[string]*ModelConfig),
+	}
+
+	err := registry.RegisterModel(ModelRegistration{
+		ModelType:  &models.Post{},
+		ListFields: []string{"ID", "Subjekt"},
+	})
+	if err == nil {
+		t.Fatal("Expected error for unknown list field")
+	}
+	if _, err := registry.Get("post"); err == nil {
+		t.Error("Expected model not to be registered after validation failure")
+	}
+
+	if err := registry.RegisterModel(ModelRegistration{
+		ModelType:  &models.Post{},
+		ListFields: []string{"ID", "Subject", "Author"},
+	}); err != nil {
+		t.Errorf("Expected valid registration to succeed, got %v", err)
+	}
+}
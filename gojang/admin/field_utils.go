@@ -1,12 +1,16 @@
 package admin
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 	"time"
 
+	"github.com/gojangframework/gojang/gojang/i18n"
 	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
 )
 
 // extractFields uses reflection to discover fields from a struct
@@ -81,6 +85,21 @@ func detectFieldType(t reflect.Type, fieldName string, overrides map[string]Fiel
 		t = t.Elem()
 	}
 
+	if t == reflect.TypeOf(uuid.UUID{}) {
+		return FieldTypeUUID
+	}
+	if t == reflect.TypeOf(utils.Point{}) {
+		return FieldTypeGeo
+	}
+	if t == reflect.TypeOf(i18n.Text{}) {
+		return FieldTypeTranslated
+	}
+
+	// Ent JSON fields (json.RawMessage, maps, slices, structs)
+	if t == reflect.TypeOf(json.RawMessage{}) {
+		return FieldTypeJSON
+	}
+
 	// Special cases
 	if fieldName == "PasswordHash" {
 		return FieldTypePassword
@@ -103,10 +122,17 @@ func detectFieldType(t reflect.Type, fieldName string, overrides map[string]Fiel
 		return FieldTypeFloat
 	case reflect.Bool:
 		return FieldTypeBool
+	case reflect.Map:
+		return FieldTypeJSON
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return FieldTypeJSON
+		}
 	case reflect.Struct:
 		if t.String() == "time.Time" {
 			return FieldTypeTime
 		}
+		return FieldTypeJSON
 	}
 
 	return FieldTypeString
@@ -114,10 +140,11 @@ func detectFieldType(t reflect.Type, fieldName string, overrides map[string]Fiel
 
 // formatLabel converts field name to display label
 func formatLabel(fieldName string) string {
-	// Insert spaces before capital letters
+	// Insert spaces before capital letters, keeping acronyms together (e.g., "Owner ID")
 	var result strings.Builder
-	for i, r := range fieldName {
-		if i > 0 && r >= 'A' && r <= 'Z' {
+	runes := []rune(fieldName)
+	for i, r := range runes {
+		if i > 0 && isUpper(r) && (!isUpper(runes[i-1]) || (i+1 < len(runes) && !isUpper(runes[i+1]))) {
 			result.WriteRune(' ')
 		}
 		result.WriteRune(r)
@@ -125,38 +152,18 @@ func formatLabel(fieldName string) string {
 	return result.String()
 }
 
-// ExtractFieldValue extracts a field value from a struct using reflection
-func ExtractFieldValue(obj interface{}, fieldName string) interface{} {
-	v := reflect.ValueOf(obj)
-
-	// Handle pointers
-	if v.Kind() == reflect.Ptr {
-		if v.IsNil() {
-			return nil
-		}
-		v = v.Elem()
-	}
+func isUpper(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
 
-	if v.Kind() != reflect.Struct {
+// ExtractFieldValue extracts a field (or Ent edge) value from a record using the
+// cached field metadata, formatted for display
+func ExtractFieldValue(obj interface{}, fieldName string) interface{} {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok {
 		return nil
 	}
-
-	// Try direct field access
-	field := v.FieldByName(fieldName)
-	if field.IsValid() && field.CanInterface() {
-		return formatFieldValue(field.Interface())
-	}
-
-	// Try Edges for related fields
-	edges := v.FieldByName("Edges")
-	if edges.IsValid() && edges.CanInterface() {
-		edgeField := edges.FieldByName(fieldName)
-		if edgeField.IsValid() && edgeField.CanInterface() {
-			return formatFieldValue(edgeField.Interface())
-		}
-	}
-
-	return nil
+	return formatFieldValue(field.Interface())
 }
 
 // formatFieldValue formats a field value for display
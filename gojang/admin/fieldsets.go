@@ -0,0 +1,86 @@
+package admin
+
+import "fmt"
+
+// Fieldset groups fields on the edit form under a heading, in the listed order:
+//
+//	Fieldsets: []Fieldset{
+//		{Name: "Account", Fields: []string{"Email", "Username", "Password"}},
+//		{Name: "Permissions", Fields: []string{"IsActive", "IsStaff", "IsSuperuser"}, Collapsed: true},
+//	}
+//
+// Fields not in any fieldset follow the fieldsets, in reflection order.
+type Fieldset struct {
+	Name        string   // Heading ("" for an ungrouped block)
+	Description string   // Optional text shown under the heading
+	Fields      []string // Field names in display order
+	Collapsed   bool     // Start collapsed, for advanced or rarely used fields
+}
+
+// FormSection is a fieldset with its fields resolved, as rendered by the form template
+type FormSection struct {
+	Name        string
+	Description string
+	Collapsed   bool
+	Fields      []FieldConfig
+}
+
+// HasError reports whether any of the section's fields failed validation,
+// so a collapsed section is opened to show the error
+func (s FormSection) HasError(errors map[string]string) bool {
+	for _, f := range s.Fields {
+		if errors[f.Name] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// FormSections returns the edit form's fields grouped by the model's fieldsets.
+// Without fieldsets, every field is in a single unnamed section.
+func (c *ModelConfig) FormSections() []FormSection {
+	sections := make([]FormSection, 0, len(c.Fieldsets)+1)
+	placed := make(map[string]bool)
+	for _, fs := range c.Fieldsets {
+		section := FormSection{Name: fs.Name, Description: fs.Description, Collapsed: fs.Collapsed}
+		for _, name := range fs.Fields {
+			if f := c.Field(name); f != nil {
+				section.Fields = append(section.Fields, *f)
+				placed[name] = true
+			}
+		}
+		sections = append(sections, section)
+	}
+
+	var rest []FieldConfig
+	for _, f := range c.Fields {
+		if !placed[f.Name] {
+			rest = append(rest, f)
+		}
+	}
+	if len(rest) > 0 {
+		sections = append(sections, FormSection{Fields: rest})
+	}
+	return sections
+}
+
+// validateFieldsets checks that every fieldset field exists and is listed only once
+func validateFieldsets(fieldsets []Fieldset, fields []FieldConfig) error {
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f.Name] = true
+	}
+	seen := make(map[string]bool)
+	for _, fs := range fieldsets {
+		for _, name := range fs.Fields {
+			if !known[name] {
+				return fmt.Errorf("fieldset %q: field %q not found", fs.Name, name)
+			}
+			if seen[name] {
+				return fmt.Errorf("fieldset %q: field %q is already in another fieldset", fs.Name, name)
+			}
+			seen[name] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// TestFormSections tests that fieldsets order fields and leftovers follow them
+func TestFormSections(t *testing.T) {
+	config := &ModelConfig{
+		Fields: []FieldConfig{{Name: "A"}, {Name: "B"}, {Name: "C"}, {Name: "D"}},
+		Fieldsets: []Fieldset{
+			{Name: "First", Fields: []string{"C", "A"}},
+			{Name: "Advanced", Fields: []string{"D"}, Collapsed: true},
+		},
+	}
+
+	sections := config.FormSections()
+	var got []string
+	for _, s := range sections {
+		names := make([]string, len(s.Fields))
+		for i, f := range s.Fields {
+			names[i] = f.Name
+		}
+		got = append(got, s.Name+":"+strings.Join(names, ","))
+	}
+	if want := "First:C,A Advanced:D :B"; strings.Join(got, " ") != want {
+		t.Errorf("FormSections() = %q, want %q", strings.Join(got, " "), want)
+	}
+	if !sections[1].Collapsed || sections[1].HasError(map[string]string{"A": "required"}) || !sections[1].HasError(map[string]string{"D": "required"}) {
+		t.Errorf("Unexpected Advanced section %+v", sections[1])
+	}
+
+	// Without fieldsets every field is in one unnamed section
+	config.Fieldsets = nil
+	if sections := config.FormSections(); len(sections) != 1 || sections[0].Name != "" || len(sections[0].Fields) != 4 {
+		t.Errorf("Expected a single section, got %+v", sections)
+	}
+}
+
+// TestRegisterModel_Fieldsets tests that fieldsets may only list existing fields, once
+func TestRegisterModel_Fieldsets(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldsets []Fieldset
+		wantErr   bool
+	}{
+		{"valid", []Fieldset{{Name: "Content", Fields: []string{"Subject", "Body"}}, {Name: "Meta", Fields: []string{"CreatedAt"}}}, false},
+		{"unknown field", []Fieldset{{Name: "Content", Fields: []string{"Title"}}}, true},
+		{"field in two fieldsets", []Fieldset{{Name: "Content", Fields: []string{"Subject"}}, {Name: "Meta", Fields: []string{"Subject"}}}, true},
+	}
+	for _, tt := range tests {
+		registry := NewRegistry(nil)
+		err := registry.RegisterModel(ModelRegistration{ModelType: &models.Post{}, Fieldsets: tt.fieldsets})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: RegisterModel error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// TestEdit_RendersFieldsets tests that the user edit form groups fields and collapses History
+func TestEdit_RendersFieldsets(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, renderer, client)
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(context.Background())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("HX-Request", "true")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("model", "user")
+	rctx.URLParams.Add("id", admin.ID.String())
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	req = req.WithContext(middleware.WithUser(ctx, admin))
+
+	w := httptest.NewRecorder()
+	handler.Edit(w, req)
+	body := w.Body.String()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, body)
+	}
+
+	account := strings.Index(body, "<summary>Account</summary>")
+	permissions := strings.Index(body, "<summary>Permissions</summary>")
+	if account < 0 || permissions < account {
+		t.Fatalf("Expected Account then Permissions fieldsets, got %s", body)
+	}
+	if email := strings.Index(body, `id="Email"`); email < account || email > permissions {
+		t.Error("Expected Email in the Account fieldset")
+	}
+	history := strings.Index(body, "<summary>History</summary>")
+	if history < 0 {
+		t.Fatal("Expected a History fieldset")
+	}
+	if tag := body[strings.LastIndex(body[:history], "<details"):history]; strings.Contains(tag, "open") {
+		t.Errorf("Expected the History fieldset to start collapsed, got %s", tag)
+	}
+}
@@ -1,44 +1,78 @@
 package admin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/i18n"
 	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/google/uuid"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/auditexport"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
 	"github.com/gojangframework/gojang/gojang/models"
-	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/retention"
 )
 
 // Handler handles all admin panel requests
 type Handler struct {
-	Registry *Registry
-	Renderer *AdminRenderer
-	DB       *models.Client
+	Registry   *Registry
+	Renderer   *AdminRenderer
+	DB         *models.Client
+	UndoWindow time.Duration    // How long deletes can be undone; 0 deletes immediately
+	Console    *QueryConsole    // SQL console for superusers; nil turns it off
+	Sudo       *middleware.Sudo // Password confirmation before deletes and SudoFields changes; nil turns it off
+
+	Retention *retention.Registry // Policies shown on the settings page (/admin/settings); nil shows none
+
+	AuditExport *auditexport.Exporter // Where exports from the settings page are delivered; nil only allows downloads
+
+	undo *undoQueue
 }
 
 // NewHandler creates a new admin handler
 func NewHandler(registry *Registry, renderer *AdminRenderer, db *models.Client) *Handler {
 	return &Handler{
-		Registry: registry,
-		Renderer: renderer,
-		DB:       db,
+		Registry:   registry,
+		Renderer:   renderer,
+		DB:         db,
+		UndoWindow: DefaultUndoWindow,
+		undo:       newUndoQueue(),
 	}
 }
 
-// Dashboard shows the admin dashboard with all registered models
+// Dashboard shows the admin dashboard with the models in navigation and the
+// "Recent actions" panel
 func (h *Handler) Dashboard(w http.ResponseWriter, r *http.Request) {
-	models := h.Registry.List()
+	models := h.Registry.Nav()
+
+	var recent, mine []RecentAction
+	if u := middleware.GetUser(r.Context()); h.DB != nil && u != nil {
+		var err error
+		if recent, err = h.recentActions(r.Context(), uuid.Nil); err != nil {
+			utils.Warnw("admin.recent_actions_failed", "error", err)
+		}
+		if mine, err = h.recentActions(r.Context(), u.ID); err != nil {
+			utils.Warnw("admin.recent_actions_failed", "user_id", u.ID, "error", err)
+		}
+	}
 
 	h.Renderer.Render(w, r, "admin_main.html", &TemplateData{
 		Title: "Admin Dashboard",
 		Data: map[string]interface{}{
-			"Models": models,
+			"Models":        models,
+			"RecentActions": recent,
+			"MyActions":     mine,
 		},
 	})
 }
@@ -66,27 +100,47 @@ func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
 			perPage = pp
 		}
 	}
+
+	// Remember per_page, sort and filters; fall back to the saved per_page
+	prefs := h.listPreferences(r, config)
+	if applyListParams(config, &prefs, r.URL.Query()) {
+		if err := h.saveListPreferences(r.Context(), config, prefs); err != nil {
+			utils.Warnw("admin.preferences_save_failed", "model", config.Name, "error", err)
+		}
+	}
+	if r.URL.Query().Get("per_page") == "" && prefs.PerPage != 0 {
+		perPage = prefs.PerPage
+	}
 	offset := (page - 1) * perPage
 
-	totalCount, err := config.CountAll(r.Context())
+	totalCount, err := config.CountList(r.Context(), prefs.Filters)
 	if err != nil {
 		utils.Errorw("admin.count_failed", "model", config.Name, "error", err)
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to load %s", config.NamePlural))
 		return
 	}
 
-	records, err := config.QueryAllPaginated(r.Context(), perPage, offset)
+	records, err := config.QueryList(r.Context(), prefs.listOptions(perPage, offset))
 	if err != nil {
 		utils.Errorw("admin.query_failed", "model", config.Name, "error", err)
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to load %s", config.NamePlural))
 		return
 	}
+	records = h.withoutPending(config, records)
 
 	totalPages := (totalCount + perPage - 1) / perPage
 	if totalPages < 1 {
 		totalPages = 1
 	}
 
+	// Links from the command palette can open the create or edit modal directly
+	openEdit := ""
+	if v := r.URL.Query().Get("edit"); v != "" {
+		if id, err := uuid.Parse(v); err == nil {
+			openEdit = id.String()
+		}
+	}
+
 	h.Renderer.Render(w, r, "model_index.html", &TemplateData{
 		Title: config.NamePlural,
 		Data: map[string]interface{}{
@@ -96,6 +150,9 @@ func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
 			"PerPage":    perPage,
 			"TotalPages": totalPages,
 			"TotalCount": totalCount,
+			"Prefs":      prefs,
+			"OpenNew":    r.URL.Query().Get("new") == "1",
+			"OpenEdit":   openEdit,
 		},
 	})
 }
@@ -111,7 +168,7 @@ func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/admin/"+modelName, http.StatusSeeOther)
 		return
 	}
@@ -151,15 +208,16 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := r.ParseForm(); err != nil {
+	if err := parseForm(w, r); err != nil {
 		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
 		return
 	}
 
 	// Extract form data into map
 	data := make(map[string]interface{})
+	uploads := make(map[string]*multipart.FileHeader)
 	for _, field := range config.Fields {
-		if field.Readonly || field.Hidden {
+		if field.Readonly || field.Hidden || !field.OnForm(false) {
 			continue
 		}
 
@@ -167,6 +225,21 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		if field.Type == FieldTypeBool {
 			_, exists := r.Form[field.Name]
 			data[field.Name] = exists
+		} else if field.Type == FieldTypeRelations {
+			// The checkboxes load after the form; if they never did, keep the current links
+			if values, loaded := r.Form[field.Name]; loaded {
+				data[field.Name] = parseEdgeIDs(values)
+			}
+		} else if field.IsUpload() {
+			// Stored once the form validates; until then the file name stands in
+			// for required checks, and no file keeps the current one
+			data[field.Name] = ""
+			if fh := uploadedFile(r, field.Name); fh != nil {
+				uploads[field.Name] = fh
+				data[field.Name] = fh.Filename
+			}
+		} else if field.Type == FieldTypeTranslated {
+			data[field.Name] = parseTranslated(r.Form, field.Name)
 		} else {
 			value := r.Form.Get(field.Name)
 			data[field.Name] = h.parseFieldValue(field, value)
@@ -175,9 +248,10 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Validate required fields
 	errors := h.validateFields(config, data, true) // true = creating new record
+	validateUploads(config, uploads, errors)
 	if len(errors) > 0 {
-		w.Header().Set("HX-Retarget", "#form-modal")
-		w.Header().Set("HX-Reswap", "innerHTML")
+		htmx.Retarget(w, "#form-modal")
+		htmx.Reswap(w, "innerHTML")
 		h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
 			Title:  "New " + config.Name,
 			Errors: errors,
@@ -190,39 +264,67 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check for duplicate email when creating a User
-	if config.Name == "User" {
-		if email, ok := data["Email"].(string); ok && email != "" {
-			exists, err := h.DB.User.Query().Where(user.EmailEQ(email)).Exist(r.Context())
-			if err != nil {
-				utils.Errorw("admin.check_email_failed", "error", err)
-				h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to check email")
-				return
-			}
-			if exists {
-				w.Header().Set("HX-Retarget", "#form-modal")
-				w.Header().Set("HX-Reswap", "innerHTML")
-				h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
-					Title:  "New " + config.Name,
-					Errors: map[string]string{"Email": "This email address is already registered"},
-					Data: map[string]interface{}{
-						"Config":   config,
-						"Action":   "create",
-						"FormData": data,
-					},
-				})
-				return
-			}
+	// Run the model's Validate hook and check unique fields (e.g., a duplicate email)
+	errors, err = h.checkRecord(r.Context(), config, data, uuid.Nil)
+	if err != nil {
+		utils.Errorw("admin.check_unique_failed", "model", config.Name, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to validate "+config.Name)
+		return
+	}
+	if len(errors) > 0 {
+		htmx.Retarget(w, "#form-modal")
+		htmx.Reswap(w, "innerHTML")
+		h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
+			Title:  "New " + config.Name,
+			Errors: errors,
+			Data: map[string]interface{}{
+				"Config":   config,
+				"Action":   "create",
+				"FormData": data,
+			},
+		})
+		return
+	}
+
+	// Permission changes need a recent password confirmation
+	if !h.Sudo.Active(r) && h.changesSudoFields(r.Context(), config, nil, data) {
+		h.Sudo.Prompt(w, r)
+		return
+	}
+
+	if err := saveUploads(r, config, uploads, data); err != nil {
+		if reason, ok := uploadRejected(err); ok {
+			h.Renderer.RenderError(w, r, http.StatusUnprocessableEntity, "Upload rejected: the file "+reason)
+			return
 		}
+		utils.Errorw("admin.upload_failed", "model", config.Name, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to save uploaded file")
+		return
 	}
 
 	// Create the record
-	_, err = config.CreateFunc(r.Context(), data)
+	created, err := config.CreateFunc(r.Context(), data)
+	if errors, ok := db.FormErrors(err, "_general"); ok {
+		utils.Debugw("admin.create_rejected", "model", config.Name, "error", err)
+		htmx.Retarget(w, "#form-modal")
+		htmx.Reswap(w, "innerHTML")
+		h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
+			Title:  "New " + config.Name,
+			Errors: errors,
+			Data: map[string]interface{}{
+				"Config":   config,
+				"Action":   "create",
+				"FormData": data,
+			},
+		})
+		return
+	}
 	if err != nil {
 		utils.Errorw("admin.create_failed", "model", config.Name, "error", err)
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to create %s", config.Name))
 		return
 	}
+	h.recordAction(r.Context(), adminaction.ActionCreate, config, getIDValue(created), recordLabel(config, created))
 
 	// Parse pagination params for the list response
 	page := 1
@@ -239,24 +341,26 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 	offset := (page - 1) * perPage
 
-	totalCount, err := config.CountAll(r.Context())
+	prefs := h.listPreferences(r, config)
+	totalCount, err := config.CountList(r.Context(), prefs.Filters)
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
 		return
 	}
 
-	records, err := config.QueryAllPaginated(r.Context(), perPage, offset)
+	records, err := config.QueryList(r.Context(), prefs.listOptions(perPage, offset))
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
 		return
 	}
+	records = h.withoutPending(config, records)
 
 	totalPages := (totalCount + perPage - 1) / perPage
 	if totalPages < 1 {
 		totalPages = 1
 	}
 
-	w.Header().Set("HX-Trigger", "closeFormModal")
+	htmx.Trigger(w, "closeFormModal")
 
 	h.Renderer.Render(w, r, "model_list.partial.html", &TemplateData{
 		Data: map[string]interface{}{
@@ -266,6 +370,7 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 			"PerPage":    perPage,
 			"TotalPages": totalPages,
 			"TotalCount": totalCount,
+			"Prefs":      prefs,
 		},
 	})
 }
@@ -282,7 +387,7 @@ func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/admin/"+modelName, http.StatusSeeOther)
 		return
 	}
@@ -298,6 +403,9 @@ func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
 		h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
 		return
 	}
+	if !h.authorizeRecord(w, r, config, record) {
+		return
+	}
 
 	// Get pagination params to pass to template for form submission
 	page := 1
@@ -341,15 +449,29 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := r.ParseForm(); err != nil {
+	existing, err := config.QueryByID(r.Context(), id)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
+		return
+	}
+	if !h.authorizeRecord(w, r, config, existing) {
+		return
+	}
+
+	if err := parseForm(w, r); err != nil {
 		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
 		return
 	}
 
 	// Extract form data
 	data := make(map[string]interface{})
+	uploads := make(map[string]*multipart.FileHeader)
 	for _, field := range config.Fields {
-		if field.Readonly || field.Hidden {
+		if field.Readonly || field.Hidden || !field.OnForm(true) {
+			continue
+		}
+		// Left blank, fields that are optional on edit keep their current value
+		if field.OptionalOnEdit && !field.IsUpload() && field.Type != FieldTypeBool && r.Form.Get(field.Name) == "" {
 			continue
 		}
 
@@ -357,6 +479,21 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		if field.Type == FieldTypeBool {
 			_, exists := r.Form[field.Name]
 			data[field.Name] = exists
+		} else if field.Type == FieldTypeRelations {
+			// The checkboxes load after the form; if they never did, keep the current links
+			if values, loaded := r.Form[field.Name]; loaded {
+				data[field.Name] = parseEdgeIDs(values)
+			}
+		} else if field.IsUpload() {
+			// Stored once the form validates; until then the file name stands in
+			// for required checks, and no file keeps the current one
+			data[field.Name] = ""
+			if fh := uploadedFile(r, field.Name); fh != nil {
+				uploads[field.Name] = fh
+				data[field.Name] = fh.Filename
+			}
+		} else if field.Type == FieldTypeTranslated {
+			data[field.Name] = parseTranslated(r.Form, field.Name)
 		} else {
 			value := r.Form.Get(field.Name)
 			data[field.Name] = h.parseFieldValue(field, value)
@@ -365,14 +502,15 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 
 	// Validate required fields
 	errors := h.validateFields(config, data, false) // false = not creating, it's an update
+	validateUploads(config, uploads, errors)
 	if len(errors) > 0 {
 		record, err := config.QueryByID(r.Context(), id)
 		if err != nil {
 			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load record")
 			return
 		}
-		w.Header().Set("HX-Retarget", "#form-modal")
-		w.Header().Set("HX-Reswap", "innerHTML")
+		htmx.Retarget(w, "#form-modal")
+		htmx.Reswap(w, "innerHTML")
 		h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
 			Title:  "Edit " + config.Name,
 			Errors: errors,
@@ -387,49 +525,80 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check for duplicate email when updating a User (excluding the current user)
-	if config.Name == "User" {
-		if email, ok := data["Email"].(string); ok && email != "" {
-			exists, err := h.DB.User.Query().
-				Where(user.EmailEQ(email)).
-				Where(user.IDNEQ(id)).
-				Exist(r.Context())
-			if err != nil {
-				utils.Errorw("admin.check_email_failed", "error", err)
-				h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to check email")
-				return
-			}
-			if exists {
-				record, err := config.QueryByID(r.Context(), id)
-				if err != nil {
-					h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load record")
-					return
-				}
-				w.Header().Set("HX-Retarget", "#form-modal")
-				w.Header().Set("HX-Reswap", "innerHTML")
-				h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
-					Title:  "Edit " + config.Name,
-					Errors: map[string]string{"Email": "This email address is already registered"},
-					Data: map[string]interface{}{
-						"Config":   config,
-						"Action":   "edit",
-						"Record":   record,
-						"ID":       id,
-						"FormData": data,
-					},
-				})
-				return
-			}
+	// Run the model's Validate hook and check unique fields, excluding this record
+	errors, err = h.checkRecord(r.Context(), config, data, id)
+	if err != nil {
+		utils.Errorw("admin.check_unique_failed", "model", config.Name, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to validate "+config.Name)
+		return
+	}
+	if len(errors) > 0 {
+		record, err := config.QueryByID(r.Context(), id)
+		if err != nil {
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load record")
+			return
+		}
+		htmx.Retarget(w, "#form-modal")
+		htmx.Reswap(w, "innerHTML")
+		h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
+			Title:  "Edit " + config.Name,
+			Errors: errors,
+			Data: map[string]interface{}{
+				"Config":   config,
+				"Action":   "edit",
+				"Record":   record,
+				"ID":       id,
+				"FormData": data,
+			},
+		})
+		return
+	}
+
+	// Permission changes need a recent password confirmation
+	if !h.Sudo.Active(r) && h.changesSudoFields(r.Context(), config, existing, data) {
+		h.Sudo.Prompt(w, r)
+		return
+	}
+
+	if err := saveUploads(r, config, uploads, data); err != nil {
+		if reason, ok := uploadRejected(err); ok {
+			h.Renderer.RenderError(w, r, http.StatusUnprocessableEntity, "Upload rejected: the file "+reason)
+			return
 		}
+		utils.Errorw("admin.upload_failed", "model", config.Name, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to save uploaded file")
+		return
 	}
 
 	// Update
 	err = config.UpdateFunc(r.Context(), id, data)
+	if errors, ok := db.FormErrors(err, "_general"); ok {
+		utils.Debugw("admin.update_rejected", "model", config.Name, "error", err)
+		htmx.Retarget(w, "#form-modal")
+		htmx.Reswap(w, "innerHTML")
+		h.Renderer.Render(w, r, "model_form.partial.html", &TemplateData{
+			Title:  "Edit " + config.Name,
+			Errors: errors,
+			Data: map[string]interface{}{
+				"Config":   config,
+				"Action":   "edit",
+				"Record":   existing,
+				"ID":       id,
+				"FormData": data,
+			},
+		})
+		return
+	}
 	if err != nil {
 		utils.Errorw("admin.update_failed", "model", config.Name, "error", err)
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to update %s", config.Name))
 		return
 	}
+	// Log the record's new label, falling back to the one it had
+	if updated, err := config.QueryByID(r.Context(), id); err == nil {
+		existing = updated
+	}
+	h.recordAction(r.Context(), adminaction.ActionUpdate, config, id.String(), recordLabel(config, existing))
 
 	// Parse pagination params for the list response
 	page := 1
@@ -446,24 +615,26 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 	offset := (page - 1) * perPage
 
-	totalCount, err := config.CountAll(r.Context())
+	prefs := h.listPreferences(r, config)
+	totalCount, err := config.CountList(r.Context(), prefs.Filters)
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
 		return
 	}
 
-	records, err := config.QueryAllPaginated(r.Context(), perPage, offset)
+	records, err := config.QueryList(r.Context(), prefs.listOptions(perPage, offset))
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
 		return
 	}
+	records = h.withoutPending(config, records)
 
 	totalPages := (totalCount + perPage - 1) / perPage
 	if totalPages < 1 {
 		totalPages = 1
 	}
 
-	w.Header().Set("HX-Trigger", "closeFormModal")
+	htmx.Trigger(w, "closeFormModal")
 
 	h.Renderer.Render(w, r, "model_list.partial.html", &TemplateData{
 		Data: map[string]interface{}{
@@ -473,6 +644,7 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 			"PerPage":    perPage,
 			"TotalPages": totalPages,
 			"TotalCount": totalCount,
+			"Prefs":      prefs,
 		},
 	})
 }
@@ -489,7 +661,7 @@ func (h *Handler) DeleteConfirm(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/admin/"+modelName, http.StatusSeeOther)
 		return
 	}
@@ -505,6 +677,9 @@ func (h *Handler) DeleteConfirm(w http.ResponseWriter, r *http.Request) {
 		h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
 		return
 	}
+	if !h.authorizeRecord(w, r, config, record) {
+		return
+	}
 
 	// Get pagination params to pass to template for form submission
 	page := 1
@@ -520,14 +695,27 @@ func (h *Handler) DeleteConfirm(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Show what else the delete affects (e.g., "Deleting this user removes 14 posts")
+	var related []RelatedCount
+	if config.DeletePreview != nil {
+		related, err = config.DeletePreview(r.Context(), id)
+		if err != nil {
+			utils.Errorw("admin.delete_preview_failed", "model", config.Name, "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load related records")
+			return
+		}
+	}
+
 	h.Renderer.Render(w, r, "model_delete.partial.html", &TemplateData{
 		Title: "Delete " + config.Name,
 		Data: map[string]interface{}{
-			"Config":  config,
-			"Record":  record,
-			"ID":      id,
-			"Page":    page,
-			"PerPage": perPage,
+			"Config":     config,
+			"Record":     record,
+			"ID":         id,
+			"Page":       page,
+			"PerPage":    perPage,
+			"Related":    related,
+			"Restricted": len(related) > 0 && config.OnDelete != DeleteCascade,
 		},
 	})
 }
@@ -549,12 +737,48 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = config.DeleteFunc(r.Context(), id)
+	record, err := config.QueryByID(r.Context(), id)
 	if err != nil {
-		utils.Errorw("admin.delete_failed", "model", config.Name, "error", err)
-		h.Renderer.RenderError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to delete %s", config.Name))
+		h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
 		return
 	}
+	if !h.authorizeRecord(w, r, config, record) {
+		return
+	}
+
+	// Refuse up front when related records block the delete, rather than after the undo window
+	if config.DeletePreview != nil && config.OnDelete != DeleteCascade {
+		related, err := config.DeletePreview(r.Context(), id)
+		if err != nil {
+			h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
+			return
+		}
+		if len(related) > 0 {
+			h.Renderer.RenderError(w, r, http.StatusConflict,
+				fmt.Sprintf("Cannot delete this %s: %s still reference it", strings.ToLower(config.Name), related[0].Label()))
+			return
+		}
+	}
+
+	// Queue the delete so it can be undone; fall back to deleting immediately
+	// The delete is logged once it actually runs, so undone deletes leave no entry
+	user, label := middleware.GetUser(r.Context()), recordLabel(config, record)
+	logDelete := func(ctx context.Context) {
+		h.recordAction(middleware.WithUser(ctx, user), adminaction.ActionDelete, config, id.String(), label)
+	}
+	var undoToken string
+	if h.UndoWindow > 0 {
+		undoToken = h.queueDelete(config, id, logDelete)
+		utils.Infow("admin.delete_queued", "model", config.Name, "id", id, "window", h.UndoWindow.String())
+	} else {
+		err = config.DeleteFunc(r.Context(), id)
+		if err != nil {
+			utils.Errorw("admin.delete_failed", "model", config.Name, "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to delete %s", config.Name))
+			return
+		}
+		logDelete(r.Context())
+	}
 
 	// Parse pagination params for the list response
 	page := 1
@@ -571,25 +795,39 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	offset := (page - 1) * perPage
 
-	totalCount, err := config.CountAll(r.Context())
+	prefs := h.listPreferences(r, config)
+	totalCount, err := config.CountList(r.Context(), prefs.Filters)
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
 		return
 	}
 
-	records, err := config.QueryAllPaginated(r.Context(), perPage, offset)
+	records, err := config.QueryList(r.Context(), prefs.listOptions(perPage, offset))
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
 		return
 	}
+	records = h.withoutPending(config, records)
 
 	totalPages := (totalCount + perPage - 1) / perPage
 	if totalPages < 1 {
 		totalPages = 1
 	}
 
-	// Trigger modal close via HTMX event
-	w.Header().Set("HX-Trigger", "closeDeleteModal")
+	// Trigger modal close (and the undo toast) via HTMX events
+	if undoToken != "" {
+		htmx.TriggerWith(w, map[string]interface{}{
+			"closeDeleteModal": true,
+			"showUndo": map[string]interface{}{
+				"url":     fmt.Sprintf("/admin/%s/undo/%s?page=%d&per_page=%d", strings.ToLower(config.Name), undoToken, page, perPage),
+				"target":  "#" + strings.ToLower(config.Name) + "-list",
+				"message": config.Name + " deleted",
+				"seconds": int(h.UndoWindow.Seconds()),
+			},
+		})
+	} else {
+		htmx.Trigger(w, "closeDeleteModal")
+	}
 
 	h.Renderer.Render(w, r, "model_list.partial.html", &TemplateData{
 		Data: map[string]interface{}{
@@ -599,6 +837,74 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 			"PerPage":    perPage,
 			"TotalPages": totalPages,
 			"TotalCount": totalCount,
+			"Prefs":      prefs,
+		},
+	})
+}
+
+// UndoDelete cancels a queued delete and re-renders the list with the record restored
+func (h *Handler) UndoDelete(w http.ResponseWriter, r *http.Request) {
+	modelName := chi.URLParam(r, "model")
+	token := chi.URLParam(r, "token")
+
+	config, err := h.Registry.Get(modelName)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	pending, ok := h.undo.cancel(config.Name, token)
+	if !ok {
+		h.Renderer.RenderError(w, r, http.StatusGone, "Too late to undo, the record was already deleted")
+		return
+	}
+	utils.Infow("admin.delete_undone", "model", config.Name, "id", pending.id)
+
+	// Parse pagination params for the list response
+	page := 1
+	if v := r.URL.Query().Get("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			page = p
+		}
+	}
+	perPage := 20
+	if v := r.URL.Query().Get("per_page"); v != "" {
+		if pp, err := strconv.Atoi(v); err == nil && (pp == 20 || pp == 50 || pp == 100) {
+			perPage = pp
+		}
+	}
+	offset := (page - 1) * perPage
+
+	prefs := h.listPreferences(r, config)
+	totalCount, err := config.CountList(r.Context(), prefs.Filters)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
+		return
+	}
+
+	records, err := config.QueryList(r.Context(), prefs.listOptions(perPage, offset))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load records")
+		return
+	}
+	records = h.withoutPending(config, records)
+
+	totalPages := (totalCount + perPage - 1) / perPage
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	htmx.Trigger(w, "hideUndo")
+
+	h.Renderer.Render(w, r, "model_list.partial.html", &TemplateData{
+		Data: map[string]interface{}{
+			"Config":     config,
+			"Records":    records,
+			"Page":       page,
+			"PerPage":    perPage,
+			"TotalPages": totalPages,
+			"TotalCount": totalCount,
+			"Prefs":      prefs,
 		},
 	})
 }
@@ -620,6 +926,61 @@ func (h *Handler) parseFieldValue(field FieldConfig, value string) interface{} {
 		}
 		f, _ := strconv.ParseFloat(value, 64)
 		return f
+	case FieldTypeRichText:
+		return utils.SanitizeHTML(value)
+	case FieldTypeColor:
+		return strings.ToLower(strings.TrimSpace(value))
+	case FieldTypeURL:
+		return strings.TrimSpace(value)
+	case FieldTypePhone:
+		// Saved in E.164; invalid input is kept for validateFields to report
+		if phone, err := utils.NormalizePhone(value); err == nil {
+			return phone
+		}
+		return strings.TrimSpace(value)
+	case FieldTypeJSON:
+		// Checked by validateFields and decoded into the field's type by setFieldsOnBuilder
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		return jsonValue(value)
+	case FieldTypeMoney:
+		// Invalid input is kept as text so validateFields can report it
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		if amount, err := utils.ParseMoney(value); err == nil {
+			return amount
+		}
+		return value
+	case FieldTypeGeo:
+		// Invalid input is kept as text so validateFields can report it
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		if p, err := utils.ParsePoint(value); err == nil {
+			return &p
+		}
+		return value
+	case FieldTypeUUID:
+		// Invalid input is kept as text so validateFields can report it
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		if id, err := uuid.Parse(strings.TrimSpace(value)); err == nil {
+			return id
+		}
+		return value
+	case FieldTypeRelation:
+		// Hidden input carries the selected record's UUID
+		if value == "" {
+			return nil
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil
+		}
+		return id
 	case FieldTypeTime:
 		// Expect value from <input type="datetime-local"> with layout 2006-01-02T15:04
 		if value == "" {
@@ -645,18 +1006,53 @@ func (h *Handler) validateFields(config *ModelConfig, data map[string]interface{
 	errors := make(map[string]string)
 
 	for _, field := range config.Fields {
-		if !field.Required || field.Readonly || field.Hidden {
+		if v, ok := data[field.Name].(jsonValue); ok && !json.Valid([]byte(v)) {
+			errors[field.Name] = field.Label + " must be valid JSON"
+			continue
+		}
+		if v, ok := data[field.Name].(string); ok {
+			switch field.Type {
+			case FieldTypeUUID:
+				errors[field.Name] = field.Label + " must be a valid UUID"
+				continue
+			case FieldTypeMoney:
+				errors[field.Name] = field.Label + " must be an amount like " + utils.MoneyInput(1250)
+				continue
+			case FieldTypeGeo:
+				errors[field.Name] = field.Label + " must be a location like 37.7749,-122.4194"
+				continue
+			case FieldTypeColor:
+				if v != "" && !utils.IsHexColor(v) {
+					errors[field.Name] = field.Label + " must be a color like #1a2b3c"
+					continue
+				}
+			case FieldTypeURL:
+				if v != "" && !utils.IsHTTPURL(v) {
+					errors[field.Name] = field.Label + " must be a URL starting with http:// or https://"
+					continue
+				}
+			case FieldTypePhone:
+				if _, err := utils.NormalizePhone(v); v != "" && err != nil {
+					errors[field.Name] = field.Label + " must be a phone number with country code, like +1 415 555 2671"
+					continue
+				}
+			}
+		}
+		if !field.RequiredOnForm(!isCreate) || field.Readonly || field.Hidden {
 			continue
 		}
 
-		// For password fields on update, skip validation if empty (optional on edit)
-		if !isCreate && field.Type == FieldTypePassword {
+		// File fields keep their current file on update unless a new one is uploaded
+		if !isCreate && field.IsUpload() {
 			continue
 		}
 
 		value, ok := data[field.Name]
 		if !ok || value == "" || value == nil {
 			errors[field.Name] = field.Label + " is required"
+		} else if text, isText := value.(i18n.Text); isText && text[i18n.Default()] == "" {
+			// Other locales fall back to the default one, so it must be filled in
+			errors[field.Name] = field.Label + " is required in " + i18n.Default()
 		}
 	}
 
@@ -1,12 +1,21 @@
 package admin
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/google/uuid"
 )
 
@@ -283,3 +292,535 @@ func TestDeleteConfirm_WithHTMXRequest(t *testing.T) {
 		t.Error("HTMX request should not redirect")
 	}
 }
+
+// TestCheckRecord_User tests that emails and usernames are normalized and must be unique
+func TestCheckRecord_User(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	config, _ := registry.Get("user")
+	ctx := context.Background()
+	alice := client.User.Create().SetEmail("alice@example.com").SetUsername("alice").SetPasswordHash("x").SaveX(ctx)
+
+	if !config.Field("Email").Unique || !config.Field("Username").Unique || config.Field("Avatar").Unique {
+		t.Fatal("Expected Email and Username to be detected as unique from the Ent schema")
+	}
+
+	data := map[string]interface{}{"Email": " Bob@Example.com", "Username": "Bob"}
+	errors, err := handler.checkRecord(ctx, config, data, uuid.Nil)
+	if err != nil || len(errors) > 0 {
+		t.Fatalf("Expected no errors, got %v (%v)", errors, err)
+	}
+	if data["Email"] != "bob@example.com" || data["Username"] != "bob" {
+		t.Errorf("Expected normalized email and username, got %v", data)
+	}
+
+	data = map[string]interface{}{"Email": "ALICE@example.com", "Username": "Alice"}
+	errors, _ = handler.checkRecord(ctx, config, data, uuid.Nil)
+	if errors["Email"] != "A user with this email already exists" || errors["Username"] == "" {
+		t.Errorf("Expected duplicate email and username errors, got %v", errors)
+	}
+
+	// A user keeps their own email and username on update
+	errors, _ = handler.checkRecord(ctx, config, data, alice.ID)
+	if len(errors) > 0 {
+		t.Errorf("Expected no errors when updating the same user, got %v", errors)
+	}
+
+	errors, _ = handler.checkRecord(ctx, config, map[string]interface{}{"Username": "a@b"}, uuid.Nil)
+	if errors["Username"] == "" {
+		t.Error("Expected an error for an invalid username")
+	}
+}
+
+// TestCheckRecord_UniqueFields tests uniqueness checks for fields added at registration
+func TestCheckRecord_UniqueFields(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	if err := registry.RegisterModel(ModelRegistration{ModelType: &models.Post{}, UniqueFields: []string{"Subject"}}); err != nil {
+		t.Fatalf("RegisterModel failed: %v", err)
+	}
+	handler := NewHandler(registry, nil, client)
+	config, _ := registry.Get("post")
+
+	author := seedUserWithPosts(t, client, 1) // Subject "s"
+	post := client.User.QueryPosts(author).OnlyX(context.Background())
+
+	errors, err := handler.checkRecord(context.Background(), config, map[string]interface{}{"Subject": "S", "Body": "b"}, uuid.Nil)
+	if err != nil || errors["Subject"] != "A post with this subject already exists" {
+		t.Errorf("Expected a duplicate subject error, got %v (%v)", errors, err)
+	}
+	if errors, _ := handler.checkRecord(context.Background(), config, map[string]interface{}{"Subject": "s"}, post.ID); len(errors) > 0 {
+		t.Errorf("Expected no errors for the same post, got %v", errors)
+	}
+
+	if err := NewRegistry(client).RegisterModel(ModelRegistration{ModelType: &models.Post{}, UniqueFields: []string{"Title"}}); err == nil {
+		t.Error("Expected an error for an unknown unique field")
+	}
+}
+
+// TestParseFieldValue_RichText tests that rich text is sanitized before it is saved
+func TestParseFieldValue_RichText(t *testing.T) {
+	handler := &Handler{}
+	field := FieldConfig{Name: "Body", Type: FieldTypeRichText}
+
+	got := handler.parseFieldValue(field, `<p onclick="x()">Hi <script>alert(1)</script><em>there</em></p>`)
+	if got != "<p>Hi <em>there</em></p>" {
+		t.Errorf("Expected sanitized HTML, got %q", got)
+	}
+}
+
+// jsonBuilder stands in for an Ent builder with a JSON field setter
+type jsonBuilder struct{ meta map[string]interface{} }
+
+func (b *jsonBuilder) SetMeta(m map[string]interface{}) *jsonBuilder {
+	b.meta = m
+	return b
+}
+
+// TestJSONFields tests that JSON fields are detected, validated and decoded into the setter's type
+func TestJSONFields(t *testing.T) {
+	type record struct {
+		Meta  map[string]interface{}
+		Raw   json.RawMessage
+		Tags  []string
+		Bytes []byte
+	}
+	fields := extractFields(record{}, AdminOverrides{})
+	for _, f := range fields {
+		want := FieldTypeJSON
+		if f.Name == "Bytes" {
+			want = FieldTypeString
+		}
+		if f.Type != want {
+			t.Errorf("Expected %s to be %s, got %s", f.Name, want, f.Type)
+		}
+	}
+
+	handler := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{{Name: "Meta", Label: "Meta", Type: FieldTypeJSON}}}
+	data := map[string]interface{}{"Meta": handler.parseFieldValue(config.Fields[0], `{"a": 1`)}
+	if errors := handler.validateFields(config, data, true); errors["Meta"] == "" {
+		t.Error("Expected an invalid JSON error")
+	}
+
+	data["Meta"] = handler.parseFieldValue(config.Fields[0], `{"a": 1}`)
+	builder := &jsonBuilder{}
+	if err := setFieldsOnBuilder(builder, data); err != nil {
+		t.Fatalf("setFieldsOnBuilder failed: %v", err)
+	}
+	if builder.meta["a"] != float64(1) {
+		t.Errorf("Expected decoded map, got %v", builder.meta)
+	}
+}
+
+// TestUUIDFields tests that UUID fields are detected and labelled, and that malformed IDs are reported
+func TestUUIDFields(t *testing.T) {
+	type record struct {
+		OwnerID  uuid.UUID
+		ParentID *uuid.UUID
+	}
+	for _, f := range extractFields(record{}, AdminOverrides{}) {
+		if f.Type != FieldTypeUUID {
+			t.Errorf("Expected %s to be %s, got %s", f.Name, FieldTypeUUID, f.Type)
+		}
+	}
+	for name, label := range map[string]string{"OwnerID": "Owner ID", "HTMLBody": "HTML Body", "LastLogin": "Last Login"} {
+		if got := formatLabel(name); got != label {
+			t.Errorf("formatLabel(%q) = %q, expected %q", name, got, label)
+		}
+	}
+
+	handler := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{{Name: "OwnerID", Label: "Owner ID", Type: FieldTypeUUID}}}
+	data := map[string]interface{}{"OwnerID": handler.parseFieldValue(config.Fields[0], "not-an-id")}
+	if errors := handler.validateFields(config, data, true); errors["OwnerID"] != "Owner ID must be a valid UUID" {
+		t.Errorf("Expected an invalid UUID error, got %v", errors)
+	}
+
+	id := uuid.New()
+	if got := handler.parseFieldValue(config.Fields[0], " "+id.String()+" "); got != id {
+		t.Errorf("Expected %s, got %v", id, got)
+	}
+	if got := formatUUIDField(record{OwnerID: id}, "OwnerID"); got != id.String() {
+		t.Errorf("Expected %s, got %q", id, got)
+	}
+	if got := formatUUIDField(record{}, "ParentID"); got != "" {
+		t.Errorf("Expected an unset UUID to format as empty, got %q", got)
+	}
+}
+
+// TestMoneyFields tests that money fields parse amounts to minor units and format them back
+func TestMoneyFields(t *testing.T) {
+	type record struct {
+		Price    int64
+		Discount *int64
+	}
+
+	handler := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{{Name: "Price", Label: "Price", Type: FieldTypeMoney}}}
+	if got := handler.parseFieldValue(config.Fields[0], "$1,234.50"); got != int64(123450) {
+		t.Errorf("Expected 123450, got %v", got)
+	}
+	if got := handler.parseFieldValue(config.Fields[0], ""); got != nil {
+		t.Errorf("Expected a blank amount to be nil, got %v", got)
+	}
+
+	data := map[string]interface{}{"Price": handler.parseFieldValue(config.Fields[0], "12.345")}
+	if errors := handler.validateFields(config, data, true); errors["Price"] != "Price must be an amount like 12.50" {
+		t.Errorf("Expected an invalid amount error, got %v", errors)
+	}
+
+	if got := formatMoneyField(record{Price: 123450}, "Price"); got != "$1,234.50" {
+		t.Errorf("Expected $1,234.50, got %q", got)
+	}
+	if got := moneyInputField(record{Price: 5}, "Price"); got != "0.05" {
+		t.Errorf("Expected 0.05, got %q", got)
+	}
+	if got := formatMoneyField(record{}, "Discount"); got != "-" {
+		t.Errorf("Expected an unset amount to format as -, got %q", got)
+	}
+}
+
+// TestGeoFields tests that geo fields are detected, parsed and shown on a static map
+func TestGeoFields(t *testing.T) {
+	type record struct {
+		Location *utils.Point
+	}
+	fields := extractFields(record{}, AdminOverrides{})
+	if fields[0].Type != FieldTypeGeo || fields[0].Sortable() {
+		t.Errorf("Expected an unsortable %s field, got %+v", FieldTypeGeo, fields[0])
+	}
+
+	handler := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{{Name: "Location", Label: "Location", Type: FieldTypeGeo}}}
+	if got, ok := handler.parseFieldValue(config.Fields[0], "51.5074, -0.1278").(*utils.Point); !ok || *got != (utils.Point{Lat: 51.5074, Lng: -0.1278}) {
+		t.Errorf("Expected a parsed point, got %v", got)
+	}
+	data := map[string]interface{}{"Location": handler.parseFieldValue(config.Fields[0], "London")}
+	if errors := handler.validateFields(config, data, true); errors["Location"] != "Location must be a location like 37.7749,-122.4194" {
+		t.Errorf("Expected an invalid location error, got %v", errors)
+	}
+
+	london := record{Location: &utils.Point{Lat: 51.5074, Lng: -0.1278}}
+	if got := formatGeoField(london, "Location"); got != "51.5074,-0.1278" {
+		t.Errorf("Expected 51.5074,-0.1278, got %q", got)
+	}
+	if got := staticMapField(london, "Location"); !strings.Contains(string(got), `class="static-map"`) {
+		t.Errorf("Expected a static map, got %s", got)
+	}
+	if got := staticMapField(record{}, "Location"); got != "-" {
+		t.Errorf("Expected an unset location to render as -, got %s", got)
+	}
+}
+
+// TestColorURLPhoneFields tests that color, URL and phone fields are normalized, validated and linked
+func TestColorURLPhoneFields(t *testing.T) {
+	type record struct {
+		Color   string
+		Website string
+		Phone   string
+	}
+
+	handler := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{
+		{Name: "Color", Label: "Color", Type: FieldTypeColor},
+		{Name: "Website", Label: "Website", Type: FieldTypeURL},
+		{Name: "Phone", Label: "Phone", Type: FieldTypePhone},
+	}}
+
+	valid := map[string]interface{}{
+		"Color":   handler.parseFieldValue(config.Fields[0], "#1A2B3C"),
+		"Website": handler.parseFieldValue(config.Fields[1], " https://example.com "),
+		"Phone":   handler.parseFieldValue(config.Fields[2], "+1 (415) 555-2671"),
+	}
+	if valid["Color"] != "#1a2b3c" || valid["Website"] != "https://example.com" || valid["Phone"] != "+14155552671" {
+		t.Errorf("Expected normalized values, got %v", valid)
+	}
+	if errors := handler.validateFields(config, valid, true); len(errors) > 0 {
+		t.Errorf("Expected valid values to pass, got %v", errors)
+	}
+	if errors := handler.validateFields(config, map[string]interface{}{"Color": "", "Website": "", "Phone": ""}, true); len(errors) > 0 {
+		t.Errorf("Expected empty optional values to pass, got %v", errors)
+	}
+
+	invalid := map[string]interface{}{"Color": "red", "Website": "javascript:alert(1)", "Phone": "555-2671"}
+	errors := handler.validateFields(config, invalid, true)
+	for _, name := range []string{"Color", "Website", "Phone"} {
+		if errors[name] == "" {
+			t.Errorf("Expected an error for %s, got %v", name, errors)
+		}
+	}
+
+	r := record{Color: "#1a2b3c", Website: "https://example.com", Phone: "+14155552671"}
+	if got := colorSwatchField(r, "Color"); !strings.Contains(string(got), "background-color: #1a2b3c") {
+		t.Errorf("Expected a color swatch, got %s", got)
+	}
+	if got := urlLinkField(r, "Website"); !strings.Contains(string(got), `href="https://example.com"`) {
+		t.Errorf("Expected a link, got %s", got)
+	}
+	if got := phoneLinkField(r, "Phone"); !strings.Contains(string(got), `href="tel:+14155552671"`) {
+		t.Errorf("Expected a tel: link, got %s", got)
+	}
+}
+
+// TestComputedFields tests that computed fields are listed and read-only but never sorted, filtered or required
+func TestComputedFields(t *testing.T) {
+	registry := &Registry{
+		models: make(map[string]*ModelConfig),
+	}
+	wordCount := func(record interface{}) interface{} {
+		return len(strings.Fields(record.(*models.Post).Body))
+	}
+
+	err := registry.RegisterModel(ModelRegistration{
+		ModelType:      &models.Post{},
+		ListFields:     []string{"Subject", "WordCount", "Status"},
+		ComputedFields: []ComputedField{{Name: "WordCount", Compute: wordCount}, {Name: "Status", Compute: postStatus}},
+	})
+	if err != nil {
+		t.Fatalf("Expected registration to succeed, got %v", err)
+	}
+	config, _ := registry.Get("post")
+	field := config.Field("WordCount")
+	if field == nil || field.Type != FieldTypeComputed || !field.Readonly || field.Label != "Word Count" {
+		t.Fatalf("Expected a read-only computed field, got %+v", field)
+	}
+	if field.Sortable() || field.Filterable() || field.Required {
+		t.Error("Expected computed fields not to be sortable, filterable or required")
+	}
+	if got := field.ComputedValue(&models.Post{Body: "one two three"}); got != "3" {
+		t.Errorf("Expected 3, got %q", got)
+	}
+	if got := field.ComputedValue(nil); got != "-" {
+		t.Errorf("Expected - without a record, got %q", got)
+	}
+	status := config.Field("Status")
+	if got := status.ComputedValue(&models.Post{}); got != "Published" {
+		t.Errorf("Expected Published, got %q", got)
+	}
+	future := time.Now().Add(time.Hour)
+	if got := status.ComputedValue(&models.Post{PublishAt: &future}); !strings.HasPrefix(string(got), `<span class="admin-badge"`) {
+		t.Errorf("Expected the badge's HTML to be kept, got %q", got)
+	}
+	if errors := (&Handler{}).validateFields(config, map[string]interface{}{"Subject": "Hi", "Body": "Hello"}, true); errors["WordCount"] != "" {
+		t.Errorf("Expected computed fields to be skipped by validation, got %v", errors)
+	}
+
+	for _, computed := range []ComputedField{
+		{Name: "Subject", Compute: wordCount},
+		{Name: "Missing"},
+	} {
+		err := (&Registry{models: make(map[string]*ModelConfig)}).RegisterModel(ModelRegistration{
+			ModelType:      &models.Post{},
+			ComputedFields: []ComputedField{computed},
+		})
+		if err == nil {
+			t.Errorf("Expected computed field %q to fail registration", computed.Name)
+		}
+	}
+}
+
+// TestFileFields tests that uploads are stored, required only on create, and linked
+func TestFileFields(t *testing.T) {
+	fs, err := storage.NewLocal(t.TempDir(), "/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.SetDefault(fs)
+	t.Cleanup(func() { storage.SetDefault(nil) })
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("Title", "Report")
+	part, _ := mw.CreateFormFile("Attachment", "report.pdf")
+	part.Write([]byte("%PDF"))
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/document", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if err := parseForm(httptest.NewRecorder(), req); err != nil {
+		t.Fatalf("parseForm: %v", err)
+	}
+	fh := uploadedFile(req, "Attachment")
+	if fh == nil || fh.Filename != "report.pdf" {
+		t.Fatalf("Expected the uploaded file, got %v", fh)
+	}
+	if uploadedFile(req, "Missing") != nil {
+		t.Error("Expected no file for a field that wasn't uploaded")
+	}
+
+	data := map[string]interface{}{"Attachment": fh.Filename}
+	files := &ModelConfig{Fields: []FieldConfig{{Name: "Attachment", Type: FieldTypeFile}}}
+	if err := saveUploads(req, files, map[string]*multipart.FileHeader{"Attachment": fh}, data); err != nil {
+		t.Fatalf("saveUploads: %v", err)
+	}
+	name, _ := data["Attachment"].(string)
+	if !strings.HasPrefix(name, "uploads/") || !strings.HasSuffix(name, ".pdf") {
+		t.Fatalf("Expected a storage name, got %q", name)
+	}
+	if _, err := fs.Open(context.Background(), name); err != nil {
+		t.Errorf("Expected the upload to be stored: %v", err)
+	}
+
+	handler := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{{Name: "Attachment", Label: "Attachment", Type: FieldTypeFile, Required: true}}}
+	if !config.HasFileFields() {
+		t.Error("Expected HasFileFields to be true")
+	}
+	empty := map[string]interface{}{"Attachment": ""}
+	if errors := handler.validateFields(config, empty, true); errors["Attachment"] == "" {
+		t.Error("Expected a required file to be needed on create")
+	}
+	if errors := handler.validateFields(config, empty, false); len(errors) > 0 {
+		t.Errorf("Expected the current file to be kept on update, got %v", errors)
+	}
+
+	r := struct{ Attachment string }{Attachment: name}
+	if got := fileLinkField(r, "Attachment"); !strings.Contains(string(got), `href="/media/`+name+`"`) {
+		t.Errorf("Expected a link to the file, got %s", got)
+	}
+}
+
+// TestImageFields tests that image uploads are sniffed by content and shown as thumbnails
+func TestImageFields(t *testing.T) {
+	upload := func(filename string, content []byte) *multipart.FileHeader {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, _ := mw.CreateFormFile("Avatar", filename)
+		part.Write(content)
+		mw.Close()
+		req := httptest.NewRequest(http.MethodPost, "/", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		if err := parseForm(httptest.NewRecorder(), req); err != nil {
+			t.Fatal(err)
+		}
+		return uploadedFile(req, "Avatar")
+	}
+
+	config := &ModelConfig{Fields: []FieldConfig{{Name: "Avatar", Label: "Avatar", Type: FieldTypeImage}}}
+	if !config.HasFileFields() {
+		t.Error("Expected image fields to need a multipart form")
+	}
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	errors := map[string]string{}
+	validateUploads(config, map[string]*multipart.FileHeader{"Avatar": upload("me.png", png)}, errors)
+	if len(errors) > 0 {
+		t.Errorf("Expected a PNG to pass, got %v", errors)
+	}
+
+	// The extension doesn't matter, only the content
+	validateUploads(config, map[string]*multipart.FileHeader{"Avatar": upload("me.jpg", []byte("<html><script>"))}, errors)
+	if errors["Avatar"] != "Avatar must be image/jpeg, image/png, image/gif, not text/html" {
+		t.Errorf("Expected HTML named .jpg to be rejected, got %q", errors["Avatar"])
+	}
+
+	// Registered rules replace the defaults
+	limited := &ModelConfig{Fields: []FieldConfig{{Name: "Avatar", Label: "Avatar", Type: FieldTypeImage, Upload: storage.Rules{MaxSize: 8}}}}
+	errors = map[string]string{}
+	validateUploads(limited, map[string]*multipart.FileHeader{"Avatar": upload("me.png", png)}, errors)
+	if errors["Avatar"] != "Avatar must be at most 8 bytes" {
+		t.Errorf("Expected a size error, got %q", errors["Avatar"])
+	}
+	err := (&Registry{models: make(map[string]*ModelConfig)}).RegisterModel(ModelRegistration{
+		ModelType: &models.User{},
+		Uploads:   map[string]storage.Rules{"Email": {MaxSize: 1}},
+	})
+	if err == nil {
+		t.Error("Expected upload rules on a non-upload field to fail registration")
+	}
+
+	r := struct{ Avatar string }{Avatar: "uploads/me.png"}
+	if got := imageThumbField(r, "Avatar"); !strings.Contains(string(got), `src="/media/resize/96x96c/uploads/me.png?sig=`) {
+		t.Errorf("Expected a resized thumbnail, got %s", got)
+	}
+	if got := imageThumbField(struct{ Avatar string }{}, "Avatar"); got != "" {
+		t.Errorf("Expected nothing for an unset image, got %s", got)
+	}
+}
+
+// TestTimeFields tests date range filter values and time display in the list view
+func TestTimeFields(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tests := []struct {
+		value    string
+		from, to time.Time
+		ok       bool
+	}{
+		{"2026-01-01..2026-01-31", day("2026-01-01"), day("2026-01-31"), true},
+		{"2026-01-01..", day("2026-01-01"), time.Time{}, true},
+		{"..2026-01-31", time.Time{}, day("2026-01-31"), true},
+		{"2026-01-15", day("2026-01-15"), day("2026-01-15"), true},
+		{"..", time.Time{}, time.Time{}, false},
+		{"last week", time.Time{}, time.Time{}, false},
+		{"2026-01-01..soon", time.Time{}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		from, to, ok := parseDateRange(tt.value)
+		if ok != tt.ok || !from.Equal(tt.from) || !to.Equal(tt.to) {
+			t.Errorf("parseDateRange(%q) = %v, %v, %v", tt.value, from, to, ok)
+		}
+	}
+
+	prefs := ListPreferences{Filters: map[string]string{"CreatedAt": "2026-01-01.."}}
+	if prefs.RangeStart("CreatedAt") != "2026-01-01" || prefs.RangeEnd("CreatedAt") != "" {
+		t.Errorf("Unexpected range %q - %q", prefs.RangeStart("CreatedAt"), prefs.RangeEnd("CreatedAt"))
+	}
+
+	createdAt := time.Now().Add(-3 * time.Hour)
+	r := struct {
+		CreatedAt time.Time
+		DeletedAt *time.Time
+	}{CreatedAt: createdAt}
+	got := string(formatTimeField(r, "CreatedAt", "Jan 2, 2006"))
+	if !strings.Contains(got, createdAt.Format("Jan 2, 2006")) || !strings.Contains(got, "3 hours ago") {
+		t.Errorf("Expected absolute and relative times, got %s", got)
+	}
+	if got := formatTimeField(r, "DeletedAt", DefaultTimeFormat); got != "-" {
+		t.Errorf("Expected - for an unset time, got %s", got)
+	}
+}
+
+// TestFormVisibility tests create-only, edit-only and optional-on-edit fields
+func TestFormVisibility(t *testing.T) {
+	both := FieldConfig{Name: "Title", Label: "Title", Required: true}
+	createOnly := FieldConfig{Name: "Slug", Label: "Slug", Required: true, ShowOnCreate: true}
+	editOnly := FieldConfig{Name: "Reason", Label: "Reason", Required: true, ShowOnEdit: true}
+	password := FieldConfig{Name: "Password", Label: "Password", Type: FieldTypePassword, Required: true, OptionalOnEdit: true}
+
+	tests := []struct {
+		field                FieldConfig
+		onCreate, onEdit     bool
+		reqCreate, reqUpdate bool
+	}{
+		{both, true, true, true, true},
+		{createOnly, true, false, true, false},
+		{editOnly, false, true, false, true},
+		{password, true, true, true, false},
+	}
+	for _, tt := range tests {
+		f := tt.field
+		if f.OnForm(false) != tt.onCreate || f.OnForm(true) != tt.onEdit {
+			t.Errorf("%s: OnForm = %v/%v, want %v/%v", f.Name, f.OnForm(false), f.OnForm(true), tt.onCreate, tt.onEdit)
+		}
+		if f.RequiredOnForm(false) != tt.reqCreate || f.RequiredOnForm(true) != tt.reqUpdate {
+			t.Errorf("%s: unexpected required flags", f.Name)
+		}
+	}
+
+	handler := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{both, createOnly, editOnly, password}}
+	errors := handler.validateFields(config, map[string]interface{}{}, true)
+	if errors["Title"] == "" || errors["Slug"] == "" || errors["Password"] == "" || errors["Reason"] != "" {
+		t.Errorf("Create: unexpected errors %v", errors)
+	}
+	errors = handler.validateFields(config, map[string]interface{}{}, false)
+	if errors["Title"] == "" || errors["Reason"] == "" || errors["Slug"] != "" || errors["Password"] != "" {
+		t.Errorf("Update: unexpected errors %v", errors)
+	}
+}
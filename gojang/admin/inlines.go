@@ -0,0 +1,293 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// inlineContext bundles everything an inline request needs
+type inlineContext struct {
+	parent   *ModelConfig
+	parentID uuid.UUID
+	record   interface{}
+	inline   InlineConfig
+	child    *ModelConfig
+	columns  []FieldConfig
+}
+
+// loadInline resolves /{model}/{id}/inlines/{child} and renders an error if anything is missing
+func (h *Handler) loadInline(w http.ResponseWriter, r *http.Request) (*inlineContext, bool) {
+	parent, err := h.Registry.Get(chi.URLParam(r, "model"))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Model not found")
+		return nil, false
+	}
+
+	parentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid ID")
+		return nil, false
+	}
+
+	childName := chi.URLParam(r, "child")
+	var inline InlineConfig
+	found := false
+	for _, in := range parent.Inlines {
+		if strings.EqualFold(in.Model, childName) {
+			inline, found = in, true
+			break
+		}
+	}
+	if !found {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Inline not found")
+		return nil, false
+	}
+
+	child, err := h.Registry.Get(inline.Model)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Inline model not registered")
+		return nil, false
+	}
+
+	record, err := parent.QueryByID(r.Context(), parentID)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, parent.Name+" not found")
+		return nil, false
+	}
+	if !h.authorizeRecord(w, r, parent, record) {
+		return nil, false
+	}
+
+	return &inlineContext{
+		parent:   parent,
+		parentID: parentID,
+		record:   record,
+		inline:   inline,
+		child:    child,
+		columns:  inlineColumns(inline, child),
+	}, true
+}
+
+// inlineColumns returns the child's FieldConfigs for the inline's columns, in order
+func inlineColumns(inline InlineConfig, child *ModelConfig) []FieldConfig {
+	names := inline.Fields
+	if len(names) == 0 {
+		names = child.ListFields
+	}
+
+	var columns []FieldConfig
+	for _, name := range names {
+		for _, f := range child.Fields {
+			if f.Name == name {
+				columns = append(columns, f)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// children loads the parent's related records through the inline edge
+func (h *Handler) children(r *http.Request, ic *inlineContext) ([]interface{}, error) {
+	query, err := relatedQuery(h.Registry.client, ic.parent.Name, ic.inline.Edge, ic.record)
+	if err != nil {
+		return nil, err
+	}
+	all, err := callWithErr(query, "All", r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]interface{}, all.Len())
+	for i := range records {
+		records[i] = all.Index(i).Interface()
+	}
+	return records, nil
+}
+
+// inlineFormData reads the editable inline columns from the request form
+func (h *Handler) inlineFormData(r *http.Request, ic *inlineContext) (map[string]interface{}, map[string]string) {
+	data := make(map[string]interface{})
+	errors := make(map[string]string)
+
+	for _, field := range ic.columns {
+		// Translated fields are only edited on the record's own form
+		if field.Readonly || field.Hidden || field.Type == FieldTypeTranslated {
+			continue
+		}
+		if field.Type == FieldTypeBool {
+			_, exists := r.Form[field.Name]
+			data[field.Name] = exists
+			continue
+		}
+		value := r.Form.Get(field.Name)
+		if field.Required && value == "" {
+			errors[field.Name] = field.Label + " is required"
+		}
+		data[field.Name] = h.parseFieldValue(field, value)
+	}
+
+	// Link the child to this parent
+	data[ic.inline.FKField] = ic.parentID
+	return data, errors
+}
+
+// renderInline renders the inline table with the parent's current children
+func (h *Handler) renderInline(w http.ResponseWriter, r *http.Request, ic *inlineContext, formErrors map[string]string, formData map[string]interface{}) {
+	records, err := h.children(r, ic)
+	if err != nil {
+		utils.Errorw("admin.inline_load_failed", "model", ic.parent.Name, "inline", ic.child.Name, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load related records")
+		return
+	}
+
+	label := ic.inline.Label
+	if label == "" {
+		label = ic.child.NamePlural
+	}
+
+	h.Renderer.Render(w, r, "model_inline.partial.html", &TemplateData{
+		Errors: formErrors,
+		Data: map[string]interface{}{
+			"Parent":   ic.parent,
+			"ParentID": ic.parentID,
+			"Child":    ic.child,
+			"Label":    label,
+			"Columns":  ic.columns,
+			"Records":  records,
+			"FormData": formData,
+		},
+	})
+}
+
+// belongsToParent reports whether childID is one of the parent's related records
+func (h *Handler) belongsToParent(r *http.Request, ic *inlineContext, childID uuid.UUID) bool {
+	records, err := h.children(r, ic)
+	if err != nil {
+		return false
+	}
+	for _, rec := range records {
+		if getIDValue(rec) == childID.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// InlineList renders the inline table for a parent record
+func (h *Handler) InlineList(w http.ResponseWriter, r *http.Request) {
+	ic, ok := h.loadInline(w, r)
+	if !ok {
+		return
+	}
+	h.renderInline(w, r, ic, nil, nil)
+}
+
+// InlineCreate adds a child record linked to the parent
+func (h *Handler) InlineCreate(w http.ResponseWriter, r *http.Request) {
+	ic, ok := h.loadInline(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	data, errors := h.inlineFormData(r, ic)
+	if len(errors) > 0 {
+		h.renderInline(w, r, ic, errors, data)
+		return
+	}
+
+	created, err := ic.child.CreateFunc(r.Context(), data)
+	if errors, ok := db.FormErrors(err, "_general"); ok {
+		h.renderInline(w, r, ic, errors, data)
+		return
+	}
+	if err != nil {
+		utils.Errorw("admin.inline_create_failed", "model", ic.child.Name, "error", err)
+		h.renderInline(w, r, ic, map[string]string{"_general": fmt.Sprintf("Failed to add %s", strings.ToLower(ic.child.Name))}, data)
+		return
+	}
+	h.recordAction(r.Context(), adminaction.ActionCreate, ic.child, getIDValue(created), recordLabel(ic.child, created))
+
+	h.renderInline(w, r, ic, nil, nil)
+}
+
+// InlineUpdate saves one row of the inline table
+func (h *Handler) InlineUpdate(w http.ResponseWriter, r *http.Request) {
+	ic, ok := h.loadInline(w, r)
+	if !ok {
+		return
+	}
+
+	childID, err := uuid.Parse(chi.URLParam(r, "childID"))
+	if err != nil || !h.belongsToParent(r, ic, childID) {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, ic.child.Name+" not found")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	data, errors := h.inlineFormData(r, ic)
+	if len(errors) > 0 {
+		h.renderInline(w, r, ic, errors, nil)
+		return
+	}
+
+	if err := ic.child.UpdateFunc(r.Context(), childID, data); err != nil {
+		if errors, ok := db.FormErrors(err, "_general"); ok {
+			h.renderInline(w, r, ic, errors, nil)
+			return
+		}
+		utils.Errorw("admin.inline_update_failed", "model", ic.child.Name, "id", childID, "error", err)
+		h.renderInline(w, r, ic, map[string]string{"_general": fmt.Sprintf("Failed to update %s", strings.ToLower(ic.child.Name))}, nil)
+		return
+	}
+	if updated, err := ic.child.QueryByID(r.Context(), childID); err == nil {
+		h.recordAction(r.Context(), adminaction.ActionUpdate, ic.child, childID.String(), recordLabel(ic.child, updated))
+	}
+
+	h.renderInline(w, r, ic, nil, nil)
+}
+
+// InlineDelete removes one row of the inline table
+func (h *Handler) InlineDelete(w http.ResponseWriter, r *http.Request) {
+	ic, ok := h.loadInline(w, r)
+	if !ok {
+		return
+	}
+
+	childID, err := uuid.Parse(chi.URLParam(r, "childID"))
+	if err != nil || !h.belongsToParent(r, ic, childID) {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, ic.child.Name+" not found")
+		return
+	}
+
+	// Read the label before the record is gone
+	label := ic.child.Name
+	if record, err := ic.child.QueryByID(r.Context(), childID); err == nil {
+		label = recordLabel(ic.child, record)
+	}
+
+	if err := ic.child.DeleteFunc(r.Context(), childID); err != nil {
+		utils.Errorw("admin.inline_delete_failed", "model", ic.child.Name, "id", childID, "error", err)
+		h.renderInline(w, r, ic, map[string]string{"_general": fmt.Sprintf("Failed to remove %s", strings.ToLower(ic.child.Name))}, nil)
+		return
+	}
+	h.recordAction(r.Context(), adminaction.ActionDelete, ic.child, childID.String(), label)
+
+	h.renderInline(w, r, ic, nil, nil)
+}
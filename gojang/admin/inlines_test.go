@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// inlineRequest builds a request routed to /admin/user/{id}/inlines/post[/{childID}]
+func inlineRequest(method string, owner *models.User, childID string, form url.Values) *http.Request {
+	req := httptest.NewRequest(method, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("model", "user")
+	rctx.URLParams.Add("id", owner.ID.String())
+	rctx.URLParams.Add("child", "post")
+	if childID != "" {
+		rctx.URLParams.Add("childID", childID)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = middleware.WithUser(ctx, &models.User{Email: "admin@example.com"})
+	return req.WithContext(ctx)
+}
+
+// TestInlines_CreateUpdateDelete tests managing a user's posts through the inline endpoints
+func TestInlines_CreateUpdateDelete(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, renderer, client)
+	ctx := context.Background()
+
+	owner := seedUserWithPosts(t, client, 1)
+	other := client.User.Create().SetEmail("other@example.com").SetPasswordHash("x").SaveX(ctx)
+	foreign := client.Post.Create().SetSubject("theirs").SetBody("b").SetAuthor(other).SaveX(ctx)
+
+	// List
+	w := httptest.NewRecorder()
+	handler.InlineList(w, inlineRequest(http.MethodGet, owner, "", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `hx-post="/admin/user/`+owner.ID.String()+`/inlines/post"`) {
+		t.Fatalf("Expected inline table, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Create links the new post to the owner, not the current admin
+	w = httptest.NewRecorder()
+	handler.InlineCreate(w, inlineRequest(http.MethodPost, owner, "", url.Values{"Subject": {"inline"}, "Body": {"text"}}))
+	if n := client.User.QueryPosts(owner).CountX(ctx); n != 2 {
+		t.Fatalf("Expected owner to have 2 posts, got %d", n)
+	}
+
+	// Missing required column re-renders with an error
+	w = httptest.NewRecorder()
+	handler.InlineCreate(w, inlineRequest(http.MethodPost, owner, "", url.Values{"Body": {"text"}}))
+	if !strings.Contains(w.Body.String(), "Subject is required") {
+		t.Error("Expected required field error")
+	}
+
+	// Update
+	p := client.User.QueryPosts(owner).FirstX(ctx)
+	w = httptest.NewRecorder()
+	handler.InlineUpdate(w, inlineRequest(http.MethodPut, owner, p.ID.String(), url.Values{"Subject": {"renamed"}, "Body": {"b"}}))
+	if got := client.Post.GetX(ctx, p.ID).Subject; got != "renamed" {
+		t.Errorf("Expected subject 'renamed', got %q", got)
+	}
+
+	// Rows of another parent can't be touched
+	w = httptest.NewRecorder()
+	handler.InlineDelete(w, inlineRequest(http.MethodDelete, owner, foreign.ID.String(), nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for foreign child, got %d", w.Code)
+	}
+
+	// Delete
+	w = httptest.NewRecorder()
+	handler.InlineDelete(w, inlineRequest(http.MethodDelete, owner, p.ID.String(), nil))
+	if n := client.User.QueryPosts(owner).CountX(ctx); n != 1 {
+		t.Errorf("Expected owner to have 1 post after delete, got %d", n)
+	}
+}
+
+// TestRegisterModel_InvalidInlineEdge tests that inlines must reference a to-many edge
+func TestRegisterModel_InvalidInlineEdge(t *testing.T) {
+	registry := &Registry{models: make(map[string]*ModelConfig)}
+
+	err := registry.RegisterModel(ModelRegistration{
+		ModelType: &models.Post{},
+		Inlines:   []InlineConfig{{Model: "User", Edge: "Author", FKField: "AuthorID"}},
+	})
+	if err == nil {
+		t.Error("Expected error for unique edge used as inline")
+	}
+}
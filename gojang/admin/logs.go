@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+	"go.uber.org/zap/zapcore"
+)
+
+// logsShown is the most log entries the log viewer shows at once
+const logsShown = 200
+
+// logLevels are the levels the log viewer filters by, lowest first
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// LogsIndex shows the latest entries from utils.RecentLogs, newest first,
+// filtered by minimum level (?level=) and text (?q=). The page polls it
+// with htmx while "Live" is checked.
+func (h *Handler) LogsIndex(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	level := r.URL.Query().Get("level")
+	var minLevel zapcore.Level
+	if err := minLevel.UnmarshalText([]byte(level)); err != nil || level == "" {
+		level, minLevel = "debug", zapcore.DebugLevel
+	}
+
+	entries := utils.RecentLogs.Entries(utils.LogFilter{Level: minLevel, Query: q, Limit: logsShown})
+	h.Renderer.Render(w, r, "logs_index.html", &TemplateData{
+		Title: "Logs",
+		Data: map[string]interface{}{
+			"Entries": entries,
+			"Query":   q,
+			"Level":   level,
+			"Levels":  logLevels,
+			"Limit":   logsShown,
+			"Live":    r.URL.Query().Get("live") != "",
+		},
+	})
+}
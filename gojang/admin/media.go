@@ -0,0 +1,282 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/images"
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// mediaPerPage is how many files the media library shows per page
+const mediaPerPage = 48
+
+// MediaFile is an uploaded file in the media library
+type MediaFile struct {
+	storage.FileInfo
+	URL    string
+	Image  bool         // Whether a thumbnail can be generated
+	Usages []MediaUsage // Records whose upload fields link to the file
+}
+
+// MediaUsage is a record that links to a file through one of its upload fields
+type MediaUsage struct {
+	Icon  string
+	Model string
+	Field string
+	Label string
+	URL   string // Opens the record's edit form
+}
+
+// Base returns the file's name without its directory
+func (f MediaFile) Base() string {
+	return path.Base(f.Name)
+}
+
+// SizeLabel formats the file size for display (e.g., "1.2 MB")
+func (f MediaFile) SizeLabel() string {
+	size := float64(f.Size)
+	for _, unit := range []string{"B", "KB", "MB"} {
+		if size < 1024 {
+			if unit == "B" {
+				return fmt.Sprintf("%d B", f.Size)
+			}
+			return fmt.Sprintf("%.1f %s", size, unit)
+		}
+		size /= 1024
+	}
+	return fmt.Sprintf("%.1f GB", size)
+}
+
+// MediaIndex lists uploaded files, newest first, filtered by ?q= (matching the
+// file name or the records that use it)
+func (h *Handler) MediaIndex(w http.ResponseWriter, r *http.Request) {
+	h.renderMedia(w, r, "media_index.html", "", "")
+}
+
+// MediaUpload stores files dropped on or chosen in the media library
+func (h *Handler) MediaUpload(w http.ResponseWriter, r *http.Request) {
+	fs := storage.Default()
+	if fs == nil {
+		h.Renderer.RenderError(w, r, http.StatusServiceUnavailable, "No file storage configured")
+		return
+	}
+	if err := parseForm(w, r); err != nil || r.MultipartForm == nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid upload")
+		return
+	}
+
+	saved := 0
+	for _, fh := range r.MultipartForm.File["files"] {
+		if fh.Size == 0 {
+			continue
+		}
+		name, err := storage.SaveUpload(r, fs, fh, uploadDir, MediaUploadRules)
+		if reason, ok := uploadRejected(err); ok {
+			h.renderMedia(w, r, "media_list.partial.html", fmt.Sprintf("Rejected %s: the file %s", fh.Filename, reason), "error")
+			return
+		}
+		if err != nil {
+			utils.Errorw("admin.media_upload_failed", "file", fh.Filename, "error", err)
+			h.renderMedia(w, r, "media_list.partial.html", fmt.Sprintf("Failed to save %s", fh.Filename), "error")
+			return
+		}
+		utils.Infow("admin.media_uploaded", "name", name, "file", fh.Filename, "size", fh.Size)
+		saved++
+	}
+	h.renderMedia(w, r, "media_list.partial.html", fmt.Sprintf("Uploaded %d %s", saved, plural(saved, "file")), "success")
+}
+
+// MediaDelete deletes the selected files (form values "name") along with their
+// image variants. Files still linked from records are kept.
+func (h *Handler) MediaDelete(w http.ResponseWriter, r *http.Request) {
+	fs := storage.Default()
+	if fs == nil {
+		h.Renderer.RenderError(w, r, http.StatusServiceUnavailable, "No file storage configured")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	deleted, kept, err := h.deleteMedia(r.Context(), fs, r.Form["name"])
+	if err != nil {
+		utils.Errorw("admin.media_delete_failed", "error", err)
+		h.renderMedia(w, r, "media_list.partial.html", "Failed to delete files", "error")
+		return
+	}
+
+	flash, flashType := fmt.Sprintf("Deleted %d %s", deleted, plural(deleted, "file")), "success"
+	if kept > 0 {
+		flash += fmt.Sprintf("; kept %d still in use", kept)
+		flashType = "warning"
+	}
+	h.renderMedia(w, r, "media_list.partial.html", flash, flashType)
+}
+
+// renderMedia renders the media library page or its file list
+func (h *Handler) renderMedia(w http.ResponseWriter, r *http.Request, tmpl, flash, flashType string) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	page := 1
+	if v := r.URL.Query().Get("page"); v != "" {
+		if p, err := strconv.Atoi(v); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	var files []MediaFile
+	if fs := storage.Default(); fs != nil {
+		var err error
+		if files, err = h.mediaFiles(r.Context(), fs, q); err != nil {
+			utils.Errorw("admin.media_list_failed", "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load media")
+			return
+		}
+	}
+
+	totalCount := len(files)
+	totalPages := max((totalCount+mediaPerPage-1)/mediaPerPage, 1)
+	start := min((page-1)*mediaPerPage, totalCount)
+	end := min(start+mediaPerPage, totalCount)
+
+	h.Renderer.Render(w, r, tmpl, &TemplateData{
+		Title:     "Media",
+		Flash:     flash,
+		FlashType: flashType,
+		Data: map[string]interface{}{
+			"Files":      files[start:end],
+			"Query":      q,
+			"Page":       page,
+			"TotalPages": totalPages,
+			"TotalCount": totalCount,
+			"Configured": storage.Default() != nil,
+		},
+	})
+}
+
+// mediaFiles returns the uploaded files matching q, newest first
+func (h *Handler) mediaFiles(ctx context.Context, fs storage.Filesystem, q string) ([]MediaFile, error) {
+	stored, err := fs.List(ctx, uploadDir+"/")
+	if err != nil {
+		return nil, err
+	}
+	usages, err := h.mediaUsages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(q)
+	files := []MediaFile{}
+	for _, info := range stored {
+		file := MediaFile{
+			FileInfo: info,
+			URL:      fs.URL(info.Name),
+			Image:    isImageName(info.Name),
+			Usages:   usages[info.Name],
+		}
+		if needle == "" || file.matches(needle) {
+			files = append(files, file)
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].ModTime.After(files[j].ModTime) })
+	return files, nil
+}
+
+// matches reports whether the file name or a record using the file contains needle
+func (f MediaFile) matches(needle string) bool {
+	if strings.Contains(strings.ToLower(f.Name), needle) {
+		return true
+	}
+	for _, u := range f.Usages {
+		if strings.Contains(strings.ToLower(u.Label), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// mediaUsages maps stored file names to the records whose upload fields link to them
+func (h *Handler) mediaUsages(ctx context.Context) (map[string][]MediaUsage, error) {
+	usages := map[string][]MediaUsage{}
+	for _, config := range h.Registry.List() {
+		if !config.HasFileFields() || config.QueryAll == nil {
+			continue
+		}
+		records, err := config.QueryAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", config.NamePlural, err)
+		}
+		for _, rec := range h.withoutPending(config, records) {
+			for _, field := range config.Fields {
+				if !field.IsUpload() {
+					continue
+				}
+				name := stringField(rec, field.Name)
+				if name == "" {
+					continue
+				}
+				usages[name] = append(usages[name], MediaUsage{
+					Icon:  config.Icon,
+					Model: config.Name,
+					Field: field.Label,
+					Label: recordLabel(config, rec),
+					URL:   "/admin/" + strings.ToLower(config.Name) + "?edit=" + getIDValue(rec),
+				})
+			}
+		}
+	}
+	return usages, nil
+}
+
+// deleteMedia deletes uploaded files that no record links to, returning how many
+// were deleted and how many were kept because they're in use
+func (h *Handler) deleteMedia(ctx context.Context, fs storage.Filesystem, names []string) (deleted, kept int, err error) {
+	usages, err := h.mediaUsages(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, name := range names {
+		// Only uploads can be deleted here, never cached variants or other files
+		if !strings.HasPrefix(name, uploadDir+"/") {
+			continue
+		}
+		if len(usages[name]) > 0 {
+			kept++
+			continue
+		}
+		if err := fs.Delete(ctx, name); err != nil && !errors.Is(err, storage.ErrNotExist) {
+			return deleted, kept, err
+		}
+		if err := images.DeleteVariants(ctx, fs, name); err != nil {
+			utils.Warnw("admin.media_variants_delete_failed", "name", name, "error", err)
+		}
+		utils.Infow("admin.media_deleted", "name", name)
+		deleted++
+	}
+	return deleted, kept, nil
+}
+
+// isImageName reports whether a file name has an extension gojang/images can resize
+func isImageName(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jpg", ".jpeg", ".png", ".gif":
+		return true
+	}
+	return false
+}
+
+// plural returns word with an "s" unless n is 1
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
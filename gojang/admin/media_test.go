@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/storage"
+)
+
+// TestMediaLibrary tests listing, usage references, search and bulk delete of uploads
+func TestMediaLibrary(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	ctx := context.Background()
+
+	fs, _ := storage.NewLocal(t.TempDir(), "/media")
+	for _, name := range []string{
+		"uploads/2024/05/avatar.png",
+		"uploads/2024/05/unused.pdf",
+		"cache/resize/96x96c/uploads/2024/05/unused.pdf",
+		"other/notes.txt",
+	} {
+		fs.Save(ctx, name, strings.NewReader("data"))
+	}
+	client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").
+		SetAvatar("uploads/2024/05/avatar.png").SaveX(ctx)
+
+	files, err := handler.mediaFiles(ctx, fs, "")
+	if err != nil {
+		t.Fatalf("mediaFiles: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected only the 2 uploads, got %+v", files)
+	}
+	usages := map[string][]MediaUsage{}
+	for _, f := range files {
+		usages[f.Name] = f.Usages
+	}
+	avatar := usages["uploads/2024/05/avatar.png"]
+	if len(avatar) != 1 || avatar[0].Label != "alice@example.com" || avatar[0].Field != "Avatar" ||
+		!strings.HasPrefix(avatar[0].URL, "/admin/user?edit=") {
+		t.Errorf("Expected the avatar to be used by alice, got %+v", avatar)
+	}
+	if len(usages["uploads/2024/05/unused.pdf"]) != 0 {
+		t.Errorf("Expected the PDF to be unused, got %+v", usages["uploads/2024/05/unused.pdf"])
+	}
+
+	// Search matches file names and the records that use them
+	if files, _ := handler.mediaFiles(ctx, fs, "alice"); len(files) != 1 || files[0].Name != "uploads/2024/05/avatar.png" {
+		t.Errorf("Expected the search for alice to find alice's avatar, got %+v", files)
+	}
+	if files, _ := handler.mediaFiles(ctx, fs, ".PDF"); len(files) != 1 || files[0].Image {
+		t.Errorf("Expected the search for .PDF to find the PDF, got %+v", files)
+	}
+
+	deleted, kept, err := handler.deleteMedia(ctx, fs, []string{
+		"uploads/2024/05/avatar.png", "uploads/2024/05/unused.pdf", "other/notes.txt",
+	})
+	if err != nil {
+		t.Fatalf("deleteMedia: %v", err)
+	}
+	if deleted != 1 || kept != 1 {
+		t.Errorf("Expected 1 deleted and 1 kept, got %d and %d", deleted, kept)
+	}
+	remaining, _ := fs.List(ctx, "")
+	var names []string
+	for _, f := range remaining {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "other/notes.txt,uploads/2024/05/avatar.png" {
+		t.Errorf("Expected the used avatar and non-upload file to remain, got %s", got)
+	}
+}
+
+func TestMediaFile_SizeLabel(t *testing.T) {
+	tests := map[int64]string{512: "512 B", 1536: "1.5 KB", 5 << 20: "5.0 MB", 3 << 30: "3.0 GB"}
+	for size, want := range tests {
+		if got := (MediaFile{FileInfo: storage.FileInfo{Size: size}}).SizeLabel(); got != want {
+			t.Errorf("SizeLabel(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -2,16 +2,33 @@ package admin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/gojangframework/gojang/gojang/announcements"
 	"github.com/gojangframework/gojang/gojang/http/middleware"
 	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/contactmessage"
+	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/polls"
+	"github.com/gojangframework/gojang/gojang/redirects"
+	"github.com/gojangframework/gojang/gojang/storage"
 	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
 )
 
 // BeforeSaveHook is called before saving a record (create or update)
 type BeforeSaveHook func(ctx context.Context, data map[string]interface{}) error
 
+// ValidateHook normalizes submitted data in place and returns errors by field name.
+// It runs after the field checks and before unique fields are checked.
+type ValidateHook func(data map[string]interface{}) map[string]string
+
 // AfterLoadHook is called after loading records (for eager loading relations, etc.)
 type AfterLoadHook func(ctx context.Context, query interface{}) interface{}
 
@@ -24,9 +41,29 @@ type ModelRegistration struct {
 	HiddenFields   []string
 	ReadonlyFields []string
 	OptionalFields []string
-	CustomFields   []FieldConfig  // Additional fields not in the struct (e.g., Password for User)
-	BeforeSave     BeforeSaveHook // Hook to transform data before save
-	QueryModifier  AfterLoadHook  // Hook to modify query (e.g., eager load relations)
+	UniqueFields   []string             // Unique fields in addition to those declared Unique() in the Ent schema
+	CustomFields   []FieldConfig        // Additional fields not in the struct (e.g., Password for User)
+	ComputedFields []ComputedField      // Read-only values derived from the record (e.g., a word count)
+	Validate       ValidateHook         // Hook to normalize and check data before save (errors shown on the form)
+	BeforeSave     BeforeSaveHook       // Hook to transform data before save
+	QueryModifier  AfterLoadHook        // Hook to modify query (e.g., eager load relations)
+	OnDelete       DeleteBehavior       // Related records on delete: DeleteRestrict (default) or DeleteCascade
+	Inlines        []InlineConfig       // Child models edited inline on the edit form (e.g., a user's posts)
+	SearchFields   []string             // Fields matched by /admin/{model}/autocomplete (opt-in)
+	LabelField     string               // Autocomplete label (defaults to the first search field)
+	OwnsRecord     OwnershipRule        // Non-superuser staff may only edit/delete records this returns true for
+	Actions        []AdminAction        // Custom actions on selected records (e.g., "Deactivate")
+	TimeFormat     string               // Layout of time columns in the list view (defaults to DefaultTimeFormat)
+	Fieldsets      []Fieldset           // Group and order edit form fields under headings
+	SudoFields     []string             // Fields whose changes need a password confirmation (sudo mode), e.g., permissions
+	Moderation     *Moderation          // Review flagged records in the moderation queue (/admin/moderation)
+	FieldTypes     map[string]FieldType // Override detected field types (e.g., {"Body": FieldTypeRichText})
+	Hidden         bool                 // Leave out of navigation; still reachable at /admin/{model}
+	Enabled        func() bool          // Show in navigation only while this returns true (e.g., a feature flag)
+
+	// Size and type limits of upload fields, e.g., {"Avatar": {MaxSize: 2 << 20}}.
+	// Image fields accept JPEG, PNG and GIF unless their rules list Types.
+	Uploads map[string]storage.Rules
 }
 
 // RegisterModels registers all models with the admin registry
@@ -37,31 +74,78 @@ func RegisterModels(registry *Registry) {
 		ModelType:      &models.User{},
 		Icon:           "👤",
 		NamePlural:     "Users",
-		ListFields:     []string{"ID", "Email", "IsActive", "IsStaff", "CreatedAt"},
+		ListFields:     []string{"ID", "Avatar", "Email", "Username", "IsActive", "IsStaff", "CreatedAt"},
 		HiddenFields:   []string{"PasswordHash"},
+		FieldTypes:     map[string]FieldType{"Avatar": FieldTypeImage},
 		ReadonlyFields: []string{"ID", "CreatedAt", "UpdatedAt", "LastLogin"},
+		OnDelete:       DeleteCascade, // Deleting a user removes their posts
+		SearchFields:   []string{"Email"},
+		SudoFields:     []string{"IsStaff", "IsSuperuser", "Groups"}, // Granting access needs a password confirmation
+
+		// Bulk-deactivate accounts from the user list
+		Actions: []AdminAction{
+			{Name: "Activate", Handler: setUsersActive(registry, true)},
+			{Name: "Deactivate", Handler: setUsersActive(registry, false), Sudo: true},
+		},
+
+		// Group the edit form; timestamps are rarely needed, so they start collapsed
+		Fieldsets: []Fieldset{
+			{Name: "Account", Fields: []string{"Email", "Username", "Avatar", "Password", "PasswordConfirmation"}},
+			{Name: "Permissions", Fields: []string{"IsActive", "IsStaff", "IsSuperuser", "Groups"}},
+			{Name: "History", Fields: []string{"ID", "CreatedAt", "UpdatedAt", "LastLogin"}, Collapsed: true},
+		},
+
+		// Manage the user's posts from the user edit form
+		Inlines: []InlineConfig{
+			{Model: "Post", Edge: "Posts", FKField: "AuthorID", Fields: []string{"Subject", "Body"}},
+		},
 
 		// Add virtual Password fields for the form
 		CustomFields: []FieldConfig{
 			{
-				Name:      "Password",
-				Label:     "Password",
-				Type:      FieldTypePassword,
-				Required:  true, // Required for create, but we'll make it optional on edit in the template
-				Sensitive: true,
-				Help:      "Must be at least 10 characters with uppercase, lowercase, and special character",
+				Name:           "Password",
+				Label:          "Password",
+				Type:           FieldTypePassword,
+				Required:       true,
+				OptionalOnEdit: true, // Leave blank to keep the current password
+				Sensitive:      true,
+				Help:           "Must be at least 10 characters with uppercase, lowercase, and special character",
+			},
+			{
+				Name:           "PasswordConfirmation",
+				Label:          "Confirm Password",
+				Type:           FieldTypePassword,
+				Required:       true,
+				OptionalOnEdit: true, // Leave blank to keep the current password
+				Sensitive:      true,
+				Help:           "Re-enter password to confirm",
 			},
 			{
-				Name:      "PasswordConfirmation",
-				Label:     "Confirm Password",
-				Type:      FieldTypePassword,
-				Required:  true, // Required for create, but we'll make it optional on edit in the template
-				Sensitive: true,
-				Help:      "Re-enter password to confirm",
+				Name:         "Groups",
+				Label:        "Groups",
+				Type:         FieldTypeRelations,
+				RelatedModel: "Group",
+				Help:         "The user gets every permission of these groups",
 			},
 		},
 
-		// Only special logic: hash passwords before saving
+		// Emails and usernames are stored lowercased, so uniqueness checks see the saved value
+		Validate: func(data map[string]interface{}) map[string]string {
+			errors := make(map[string]string)
+			if email, ok := data["Email"].(string); ok {
+				data["Email"] = utils.NormalizeEmail(email)
+			}
+			if username, ok := data["Username"].(string); ok && username != "" {
+				username = utils.NormalizeUsername(username)
+				data["Username"] = username
+				if err := utils.ValidateUsername(username); err != nil {
+					errors["Username"] = err.Error()
+				}
+			}
+			return errors
+		},
+
+		// Hash passwords before saving
 		BeforeSave: func(ctx context.Context, data map[string]interface{}) error {
 			password, hasPassword := data["Password"].(string)
 			passwordConfirm, hasPasswordConfirm := data["PasswordConfirmation"].(string)
@@ -99,8 +183,43 @@ func RegisterModels(registry *Registry) {
 		ModelType:      &models.Post{},
 		Icon:           "📝",
 		NamePlural:     "Posts",
-		ListFields:     []string{"ID", "Subject", "Author", "CreatedAt"},
+		ListFields:     []string{"ID", "Subject", "Author", "WordCount", "Status", "PendingReview", "CreatedAt"},
 		ReadonlyFields: []string{"ID", "CreatedAt", "UpdatedAt"},
+		OptionalFields: []string{"PublishAt"},
+		SearchFields:   []string{"Subject"},
+
+		// Flagged posts wait in the moderation queue; rejecting one deletes it
+		Moderation: &Moderation{Field: "PendingReview"},
+
+		// Show a word count column computed from the body
+		ComputedFields: []ComputedField{
+			{Name: "WordCount", Compute: func(record interface{}) interface{} {
+				return len(strings.Fields(record.(*models.Post).Body))
+			}},
+			{Name: "Status", Compute: postStatus},
+		},
+
+		// Reschedule by changing Publish At; the publish task clears it once it's due
+		Actions: []AdminAction{
+			{Name: "Publish now", Handler: publishPosts(registry)},
+		},
+
+		// Pick the author with an autocomplete instead of a huge <select>
+		CustomFields: []FieldConfig{
+			{
+				Name:         "AuthorID",
+				Label:        "Author",
+				Type:         FieldTypeRelation,
+				RelatedModel: "User",
+				Edge:         "Author",
+				Help:         "Leave empty to use your own account",
+			},
+			{
+				Name: "PublishAt",
+				Type: FieldTypeTime,
+				Help: "Keeps the post hidden until then. Use Publish now to publish it early.",
+			},
+		},
 
 		// Set the author to the current user
 		BeforeSave: func(ctx context.Context, data map[string]interface{}) error {
@@ -109,11 +228,20 @@ func RegisterModels(registry *Registry) {
 			if user == nil {
 				return fmt.Errorf("no authenticated user found")
 			}
-			// Set the author ID
-			data["AuthorID"] = user.ID
+			// Default the author to the current user unless one was chosen
+			// (via the Author autocomplete or an inline on the User form)
+			if id, ok := data["AuthorID"].(uuid.UUID); !ok || id == uuid.Nil {
+				data["AuthorID"] = user.ID
+			}
 			return nil
 		},
 
+		// Staff can only edit and delete their own posts; superusers can edit any
+		OwnsRecord: func(user *models.User, record interface{}) bool {
+			post, ok := record.(*models.Post)
+			return ok && post.Edges.Author != nil && post.Edges.Author.ID == user.ID
+		},
+
 		// Eager load author relationship
 		QueryModifier: func(ctx context.Context, query interface{}) interface{} {
 			if q, ok := query.(*models.PostQuery); ok {
@@ -123,6 +251,179 @@ func RegisterModels(registry *Registry) {
 		},
 	})
 
+	// Register Group model - grant permissions to many users at once
+	registry.RegisterModel(ModelRegistration{
+		ModelType:      &models.Group{},
+		Icon:           "👥",
+		NamePlural:     "Groups",
+		ListFields:     []string{"Name", "Description", "Permissions", "CreatedAt"},
+		ReadonlyFields: []string{"ID", "CreatedAt"},
+		OptionalFields: []string{"Description"},
+		SearchFields:   []string{"Name"},
+		SudoFields:     []string{"Permissions"},
+		CustomFields: []FieldConfig{
+			{
+				Name:  "Permissions",
+				Label: "Permissions",
+				Type:  FieldTypeJSON,
+				Help:  `JSON list, e.g. ["manage_users"]. Known permissions: ` + strings.Join(middleware.Permissions, ", "),
+			},
+		},
+
+		// Catch typos, which would otherwise grant nothing
+		Validate: func(data map[string]interface{}) map[string]string {
+			errors := make(map[string]string)
+			raw, ok := data["Permissions"].(jsonValue)
+			if !ok {
+				return errors
+			}
+			var permissions []string
+			if err := json.Unmarshal([]byte(raw), &permissions); err != nil {
+				errors["Permissions"] = "Permissions must be a JSON list of strings"
+				return errors
+			}
+			for _, p := range permissions {
+				if !contains(middleware.Permissions, p) {
+					errors["Permissions"] = fmt.Sprintf("Unknown permission %q", p)
+					break
+				}
+			}
+			return errors
+		},
+	})
+
+	// Register Announcement model - banners shown above every page
+	registry.RegisterModel(ModelRegistration{
+		ModelType:      &models.Announcement{},
+		Icon:           "📢",
+		NamePlural:     "Announcements",
+		ListFields:     []string{"Message", "Level", "Audience", "StartsAt", "EndsAt", "CreatedAt"},
+		ReadonlyFields: []string{"ID", "CreatedAt"},
+		OptionalFields: []string{"StartsAt", "EndsAt"},
+		FieldTypes:     map[string]FieldType{"Message": FieldTypeMarkdown},
+		CustomFields: []FieldConfig{
+			{Name: "Level", Type: FieldTypeString, Required: true, Help: "One of: " + strings.Join(announcements.Levels, ", ")},
+			{Name: "Audience", Type: FieldTypeString, Required: true, Help: "One of: " + strings.Join(announcements.Audiences, ", ")},
+			{Name: "StartsAt", Type: FieldTypeTime, Help: "Leave empty to show it right away"},
+			{Name: "EndsAt", Type: FieldTypeTime, Help: "Leave empty to show it until it's deleted"},
+		},
+
+		// Level and audience are free text in the schema; only known values match anyone
+		Validate: func(data map[string]interface{}) map[string]string {
+			errors := make(map[string]string)
+			if level, ok := data["Level"].(string); ok && !contains(announcements.Levels, level) {
+				errors["Level"] = "Level must be one of: " + strings.Join(announcements.Levels, ", ")
+			}
+			if audience, ok := data["Audience"].(string); ok && !contains(announcements.Audiences, audience) {
+				errors["Audience"] = "Audience must be one of: " + strings.Join(announcements.Audiences, ", ")
+			}
+			return errors
+		},
+	})
+
+	// Register Redirect model - old URLs sent on to their new location
+	registry.RegisterModel(ModelRegistration{
+		ModelType:      &models.Redirect{},
+		Icon:           "↪️",
+		NamePlural:     "Redirects",
+		ListFields:     []string{"FromPath", "ToPath", "StatusCode", "CreatedAt"},
+		ReadonlyFields: []string{"ID", "CreatedAt"},
+		CustomFields: []FieldConfig{
+			{Name: "FromPath", Type: FieldTypeString, Required: true, Help: "Path to redirect, e.g. /blog/old-post"},
+			{Name: "ToPath", Type: FieldTypeString, Required: true, Help: "Path or full URL to send visitors to"},
+			{Name: "StatusCode", Type: FieldTypeInt, Required: true, Help: "301 or 308 for permanent moves, 302 or 307 for temporary ones"},
+		},
+
+		// Only paths no route serves are redirected, so these just keep the map sane
+		Validate: func(data map[string]interface{}) map[string]string {
+			errors := make(map[string]string)
+			from, _ := data["FromPath"].(string)
+			if from != "" && (!strings.HasPrefix(from, "/") || strings.ContainsAny(from, "?#")) {
+				errors["FromPath"] = "From path must start with / and have no query string"
+			}
+			if to, ok := data["ToPath"].(string); ok && to != "" {
+				if !strings.HasPrefix(to, "/") && !strings.HasPrefix(to, "https://") && !strings.HasPrefix(to, "http://") {
+					errors["ToPath"] = "To path must start with / or be a full http(s) URL"
+				} else if to == from {
+					errors["ToPath"] = "To path must differ from the from path"
+				}
+			}
+			if code, ok := data["StatusCode"].(int); ok && !slices.Contains(redirects.StatusCodes, code) {
+				errors["StatusCode"] = "Status code must be 301, 302, 307 or 308"
+			}
+			return errors
+		},
+	})
+
+	// Register ContactMessage model - the contact form's inbox
+	registry.RegisterModel(ModelRegistration{
+		ModelType:      &models.ContactMessage{},
+		Icon:           "✉️",
+		NamePlural:     "ContactMessages",
+		ListFields:     []string{"Name", "Email", "Subject", "Handled", "CreatedAt"},
+		ReadonlyFields: []string{"ID", "Name", "Email", "Subject", "Message", "IP", "CreatedAt"},
+		SearchFields:   []string{"Email", "Name"},
+
+		// Work through the inbox from the list
+		Actions: []AdminAction{
+			{Name: "Mark handled", Handler: setContactMessagesHandled(registry, true)},
+			{Name: "Mark unhandled", Handler: setContactMessagesHandled(registry, false)},
+		},
+	})
+
+	// Register Poll model - embeddable polls, with their results on the edit form
+	registry.RegisterModel(ModelRegistration{
+		ModelType:      &models.Poll{},
+		Icon:           "📊",
+		NamePlural:     "Polls",
+		ListFields:     []string{"Question", "VoteCount", "ClosesAt", "CreatedAt"},
+		ReadonlyFields: []string{"ID", "CreatedAt"},
+		OptionalFields: []string{"ClosesAt"},
+		OnDelete:       DeleteCascade, // Deleting a poll removes its votes
+		CustomFields: []FieldConfig{
+			{Name: "Options", Type: FieldTypeJSON, Required: true, Help: `A JSON list of answers, e.g. ["Yes", "No"]. Votes refer to answers by position, so only add new ones at the end.`},
+			{Name: "ClosesAt", Type: FieldTypeTime, Help: "Leave empty to keep it open until it's deleted"},
+		},
+		ComputedFields: []ComputedField{
+			{Name: "VoteCount", Label: "Votes", Compute: func(record interface{}) interface{} {
+				return polls.Count(record.(*models.Poll)).Total
+			}},
+			{Name: "Results", Compute: pollResults},
+		},
+		QueryModifier: func(ctx context.Context, query interface{}) interface{} {
+			if q, ok := query.(*models.PollQuery); ok {
+				return q.WithVotes()
+			}
+			return query
+		},
+
+		// Options must be a list of at least two distinct, non-empty answers
+		Validate: func(data map[string]interface{}) map[string]string {
+			errors := make(map[string]string)
+			raw, ok := data["Options"].(jsonValue)
+			if !ok || !json.Valid([]byte(raw)) {
+				return errors // Reported by the field checks
+			}
+			var options []string
+			if err := json.Unmarshal([]byte(raw), &options); err != nil {
+				errors["Options"] = "Options must be a list of answers"
+				return errors
+			}
+			seen := make(map[string]bool, len(options))
+			for _, option := range options {
+				if strings.TrimSpace(option) == "" || seen[option] {
+					errors["Options"] = "Answers must be distinct and not empty"
+					return errors
+				}
+				seen[option] = true
+			}
+			if len(options) < polls.MinOptions {
+				errors["Options"] = fmt.Sprintf("A poll needs at least %d answers", polls.MinOptions)
+			}
+			return errors
+		},
+	})
+
 	// Register SampleProduct model - example for demonstration
 	// Uncomment when SampleProduct model exists
 	// registry.RegisterSampleModel(ModelRegistration{
@@ -134,3 +435,75 @@ func RegisterModels(registry *Registry) {
 	// })
 
 }
+
+// setUsersActive returns an action that activates or deactivates the selected users.
+// Staff can't deactivate their own account, so they can't lock themselves out.
+func setUsersActive(registry *Registry, active bool) ActionHandler {
+	return func(ctx context.Context, records []interface{}) error {
+		current := middleware.GetUser(ctx)
+		ids := make([]uuid.UUID, 0, len(records))
+		for _, record := range records {
+			u := record.(*models.User)
+			if !active && current != nil && u.ID == current.ID {
+				return fmt.Errorf("you can't deactivate your own account")
+			}
+			ids = append(ids, u.ID)
+		}
+		return registry.client.User.Update().
+			Where(user.IDIn(ids...)).
+			SetIsActive(active).
+			Exec(ctx)
+	}
+}
+
+// setContactMessagesHandled returns an action that marks the selected contact
+// messages as handled or not
+func setContactMessagesHandled(registry *Registry, handled bool) ActionHandler {
+	return func(ctx context.Context, records []interface{}) error {
+		ids := make([]uuid.UUID, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, record.(*models.ContactMessage).ID)
+		}
+		return registry.client.ContactMessage.Update().
+			Where(contactmessage.IDIn(ids...)).
+			SetHandled(handled).
+			Exec(ctx)
+	}
+}
+
+// pollResults charts a poll's votes per answer as bars
+func pollResults(record interface{}) interface{} {
+	results := polls.Count(record.(*models.Poll))
+	var b strings.Builder
+	b.WriteString(`<div class="admin-poll-results">`)
+	for _, r := range results.Options {
+		fmt.Fprintf(&b, `<div class="admin-poll-row"><span class="admin-poll-label">%s</span><span class="admin-poll-bar"><span style="width: %d%%"></span></span><span class="admin-poll-count">%d (%d%%)</span></div>`,
+			template.HTMLEscapeString(r.Option), r.Percent, r.Votes, r.Percent)
+	}
+	fmt.Fprintf(&b, `<div class="admin-poll-total">%d votes</div></div>`, results.Total)
+	return template.HTML(b.String())
+}
+
+// postStatus shows whether a post is published or waiting for its publish time
+func postStatus(record interface{}) interface{} {
+	p := record.(*models.Post)
+	if p.PublishAt != nil && p.PublishAt.After(time.Now()) {
+		return template.HTML(`<span class="admin-badge" title="` + p.PublishAt.Format(DefaultTimeFormat) + `">Scheduled</span>`)
+	}
+	return "Published"
+}
+
+// publishPosts returns an action that publishes the selected scheduled posts
+// right away
+func publishPosts(registry *Registry) ActionHandler {
+	return func(ctx context.Context, records []interface{}) error {
+		ids := make([]uuid.UUID, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, record.(*models.Post).ID)
+		}
+		return registry.client.Post.Update().
+			Where(post.IDIn(ids...), post.PublishAtNotNil()).
+			ClearPublishAt().
+			Exec(ctx)
+	}
+}
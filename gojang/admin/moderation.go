@@ -0,0 +1,250 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// moderationShown is the most pending records the queue shows per model
+const moderationShown = 50
+
+// Moderation puts a model's records in the moderation queue (/admin/moderation)
+// while its bool Field is true. Code flags records for review by setting the
+// field (e.g., when a reader reports a post); staff approve or reject them:
+//
+//	Moderation: &Moderation{Field: "PendingReview"}
+type Moderation struct {
+	Field  string        // Bool field that is true while the record awaits review
+	Reject ActionHandler // Runs on rejected records and must clear Field; nil deletes them (sudo mode)
+}
+
+// ModerationQueue is one model's records pending review
+type ModerationQueue struct {
+	Config  *ModelConfig
+	Records []ModerationRecord
+	Count   int // All pending records; Records holds at most moderationShown
+}
+
+// ModerationRecord is a record listed in the moderation queue
+type ModerationRecord struct {
+	Item  string // Form value selecting it, e.g., "post:<id>"
+	Label string
+	URL   string // Opens its edit form
+}
+
+// moderationItem is a record selected in the queue
+type moderationItem struct {
+	config *ModelConfig
+	record interface{}
+}
+
+// ModerationIndex shows the records pending review, grouped by model
+func (h *Handler) ModerationIndex(w http.ResponseWriter, r *http.Request) {
+	h.renderModeration(w, r, "moderation_index.html", "", "")
+}
+
+// ModerationBadge renders the Moderation navbar link with the number of records
+// pending review. The header loads it with htmx and refreshes it every minute.
+func (h *Handler) ModerationBadge(w http.ResponseWriter, r *http.Request) {
+	enabled, total := false, 0
+	for _, config := range h.Registry.Nav() {
+		if config.Moderation == nil {
+			continue
+		}
+		enabled = true
+		count, err := config.CountList(r.Context(), moderationFilter(config))
+		if err != nil {
+			utils.Warnw("admin.moderation_count_failed", "model", config.Name, "error", err)
+			continue
+		}
+		total += count
+	}
+	h.Renderer.Render(w, r, "moderation_badge.partial.html", &TemplateData{
+		Data: map[string]interface{}{
+			"Enabled": enabled,
+			"Count":   total,
+		},
+	})
+}
+
+// ModerationApprove clears the flag of the "item" records ("post:<id>")
+func (h *Handler) ModerationApprove(w http.ResponseWriter, r *http.Request) {
+	items, ok := h.moderationItems(w, r)
+	if !ok {
+		return
+	}
+	for _, item := range items {
+		id, _ := uuid.Parse(getIDValue(item.record))
+		// Only the flag changes, so skip BeforeSave (it fills in defaults for edits)
+		err := h.Registry.genericUpdate(r.Context(), item.config.Name, id, map[string]interface{}{item.config.Moderation.Field: false})
+		if err != nil {
+			utils.Errorw("admin.moderation_failed", "model", item.config.Name, "id", id, "action", "approve", "error", err)
+			h.renderModeration(w, r, "moderation_list.partial.html", "Failed to approve "+recordLabel(item.config, item.record), "error")
+			return
+		}
+		h.recordActionNamed(r.Context(), adminaction.ActionRun, "Approve", item.config, id.String(), recordLabel(item.config, item.record))
+	}
+	utils.Infow("admin.moderation_approved", "count", len(items))
+	htmx.Trigger(w, "moderationChanged") // Refresh the navbar count
+	h.renderModeration(w, r, "moderation_list.partial.html", fmt.Sprintf("Approved %d %s", len(items), plural(len(items), "record")), "success")
+}
+
+// ModerationReject runs each model's Moderation.Reject on the "item" records,
+// deleting those without one. Deleting needs sudo mode, like other deletes.
+func (h *Handler) ModerationReject(w http.ResponseWriter, r *http.Request) {
+	items, ok := h.moderationItems(w, r)
+	if !ok {
+		return
+	}
+	for _, item := range items {
+		if item.config.Moderation.Reject == nil && !h.Sudo.Active(r) {
+			h.Sudo.Prompt(w, r)
+			return
+		}
+	}
+
+	// Custom handlers get each model's records at once, like actions
+	byModel := make(map[*ModelConfig][]interface{})
+	var order []*ModelConfig
+	for _, item := range items {
+		if _, ok := byModel[item.config]; !ok {
+			order = append(order, item.config)
+		}
+		byModel[item.config] = append(byModel[item.config], item.record)
+	}
+	for _, config := range order {
+		records := byModel[config]
+		if err := h.rejectRecords(r.Context(), config, records); err != nil {
+			utils.Warnw("admin.moderation_failed", "model", config.Name, "action", "reject", "count", len(records), "error", err)
+			h.renderModeration(w, r, "moderation_list.partial.html", fmt.Sprintf("Reject failed: %v", err), "error")
+			return
+		}
+	}
+	utils.Infow("admin.moderation_rejected", "count", len(items))
+	htmx.Trigger(w, "moderationChanged")
+	h.renderModeration(w, r, "moderation_list.partial.html", fmt.Sprintf("Rejected %d %s", len(items), plural(len(items), "record")), "success")
+}
+
+// rejectRecords rejects records of config and adds them to the activity log
+func (h *Handler) rejectRecords(ctx context.Context, config *ModelConfig, records []interface{}) error {
+	if reject := config.Moderation.Reject; reject != nil {
+		if err := reject(ctx, records); err != nil {
+			return err
+		}
+		for _, record := range records {
+			h.recordActionNamed(ctx, adminaction.ActionRun, "Reject", config, getIDValue(record), recordLabel(config, record))
+		}
+		return nil
+	}
+	for _, record := range records {
+		id, _ := uuid.Parse(getIDValue(record))
+		if err := config.DeleteFunc(ctx, id); err != nil {
+			return err
+		}
+		// Logged as a delete, so the activity log doesn't link to the record
+		h.recordActionNamed(ctx, adminaction.ActionDelete, "Reject", config, id.String(), recordLabel(config, record))
+	}
+	return nil
+}
+
+// moderationItems loads the records of the "item" form values. Each must be
+// pending review and one the user may modify.
+func (h *Handler) moderationItems(w http.ResponseWriter, r *http.Request) ([]moderationItem, bool) {
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return nil, false
+	}
+	if len(r.Form["item"]) == 0 {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Select the records to review")
+		return nil, false
+	}
+
+	items := make([]moderationItem, 0, len(r.Form["item"]))
+	for _, v := range r.Form["item"] {
+		modelName, idStr, _ := strings.Cut(v, ":")
+		config, err := h.Registry.Get(modelName)
+		if err != nil || config.Moderation == nil {
+			h.Renderer.RenderError(w, r, http.StatusNotFound, "Model not found")
+			return nil, false
+		}
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid ID")
+			return nil, false
+		}
+		record, err := config.QueryByID(r.Context(), id)
+		if err != nil || h.undo.isPending(config.Name, id) {
+			h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
+			return nil, false
+		}
+		if field, _, ok := lookupField(record, config.Moderation.Field); !ok || !field.Bool() {
+			h.Renderer.RenderError(w, r, http.StatusConflict, recordLabel(config, record)+" was already reviewed")
+			return nil, false
+		}
+		if !h.authorizeRecord(w, r, config, record) {
+			return nil, false
+		}
+		items = append(items, moderationItem{config: config, record: record})
+	}
+	return items, true
+}
+
+// renderModeration renders the moderation queue page or its list
+func (h *Handler) renderModeration(w http.ResponseWriter, r *http.Request, tmpl, flash, flashType string) {
+	var queues []ModerationQueue
+	for _, config := range h.Registry.Nav() {
+		if config.Moderation == nil {
+			continue
+		}
+		filters := moderationFilter(config)
+		count, err := config.CountList(r.Context(), filters)
+		if err == nil && count > 0 {
+			var records []interface{}
+			if records, err = config.QueryList(r.Context(), ListOptions{Limit: moderationShown, Filters: filters}); err == nil {
+				queues = append(queues, moderationQueue(config, h.withoutPending(config, records), count))
+			}
+		}
+		if err != nil {
+			utils.Errorw("admin.moderation_list_failed", "model", config.Name, "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load the moderation queue")
+			return
+		}
+	}
+
+	h.Renderer.Render(w, r, tmpl, &TemplateData{
+		Title:     "Moderation",
+		Flash:     flash,
+		FlashType: flashType,
+		Data: map[string]interface{}{
+			"Queues": queues,
+			"Limit":  moderationShown,
+		},
+	})
+}
+
+// moderationQueue lists records of config pending review
+func moderationQueue(config *ModelConfig, records []interface{}, count int) ModerationQueue {
+	queue := ModerationQueue{Config: config, Records: make([]ModerationRecord, len(records)), Count: count}
+	slug := strings.ToLower(config.Name)
+	for i, record := range records {
+		id := getIDValue(record)
+		queue.Records[i] = ModerationRecord{
+			Item:  slug + ":" + id,
+			Label: recordLabel(config, record),
+			URL:   "/admin/" + slug + "?edit=" + id,
+		}
+	}
+	return queue
+}
+
+// moderationFilter selects config's records pending review
+func moderationFilter(config *ModelConfig) map[string]string {
+	return map[string]string{config.Moderation.Field: "true"}
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/post"
+)
+
+// moderationRequest builds a request selecting the "item" records as user
+func moderationRequest(user *models.User, items ...string) *http.Request {
+	form := url.Values{"item": items}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	return req.WithContext(middleware.WithUser(req.Context(), user))
+}
+
+// TestModeration tests the queue, its navbar count and approving and rejecting flagged posts
+func TestModeration(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, renderer, client)
+	ctx := context.Background()
+
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	author := client.User.Create().SetEmail("author@example.com").SetPasswordHash("x").SaveX(ctx)
+	spam := client.Post.Create().SetSubject("Cheap watches").SetBody("x").SetAuthor(author).SetPendingReview(true).SaveX(ctx)
+	reported := client.Post.Create().SetSubject("Hot take").SetBody("x").SetAuthor(author).SetPendingReview(true).SaveX(ctx)
+	client.Post.Create().SetSubject("Hello").SetBody("x").SetAuthor(author).SaveX(ctx)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(middleware.WithUser(req.Context(), admin))
+	w := httptest.NewRecorder()
+	handler.ModerationBadge(w, req)
+	if !strings.Contains(w.Body.String(), `<span class="admin-nav-badge">2</span>`) {
+		t.Errorf("Expected a count of 2 in the navbar, got %s", w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	handler.ModerationIndex(w, req)
+	if body := w.Body.String(); !strings.Contains(body, "Cheap watches") || strings.Contains(body, "Hello") {
+		t.Errorf("Expected only flagged posts in the queue, got %s", body)
+	}
+
+	// Approving keeps the post and its author
+	w = httptest.NewRecorder()
+	handler.ModerationApprove(w, moderationRequest(admin, "post:"+reported.ID.String()))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Approved 1 record") {
+		t.Fatalf("Expected the approval to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	approved := client.Post.Query().Where(post.ID(reported.ID)).WithAuthor().OnlyX(ctx)
+	if approved.PendingReview || approved.Edges.Author.ID != author.ID {
+		t.Errorf("Expected the post to be approved unchanged, got %+v", approved)
+	}
+
+	// Approving twice is a conflict
+	w = httptest.NewRecorder()
+	handler.ModerationApprove(w, moderationRequest(admin, "post:"+reported.ID.String()))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a reviewed post, got %d", w.Code)
+	}
+
+	// Posts have no Reject handler, so rejecting deletes
+	w = httptest.NewRecorder()
+	handler.ModerationReject(w, moderationRequest(admin, "post:"+spam.ID.String()))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Nothing is waiting for review") {
+		t.Fatalf("Expected an empty queue after rejecting, got %d: %s", w.Code, w.Body.String())
+	}
+	if client.Post.Query().Where(post.ID(spam.ID)).ExistX(ctx) {
+		t.Error("Expected the rejected post to be deleted")
+	}
+
+	entries := client.AdminAction.Query().AllX(ctx)
+	if len(entries) != 2 {
+		t.Fatalf("Expected one activity entry per review, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.ActionName == "Approve" && e.Action != adminaction.ActionRun || e.ActionName == "Reject" && e.Action != adminaction.ActionDelete {
+			t.Errorf("Unexpected activity entry %+v", e)
+		}
+	}
+}
+
+// TestModerationReject_Sudo tests that rejecting by deleting needs sudo mode
+func TestModerationReject_Sudo(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	sessions := scs.New()
+	handler.Sudo = &middleware.Sudo{Sessions: sessions, Window: time.Minute, PromptURL: "/admin/sudo"}
+	ctx, err := sessions.Load(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	p := client.Post.Create().SetSubject("Spam").SetBody("x").SetAuthor(admin).SetPendingReview(true).SaveX(ctx)
+
+	req := moderationRequest(admin, "post:"+p.ID.String())
+	w := httptest.NewRecorder()
+	handler.ModerationReject(w, req.WithContext(middleware.WithUser(ctx, admin)))
+	if w.Code != http.StatusForbidden || w.Header().Get("HX-Redirect") == "" {
+		t.Errorf("Expected the sudo prompt, got %d", w.Code)
+	}
+	if !client.Post.Query().Where(post.ID(p.ID)).ExistX(ctx) {
+		t.Error("Expected the post to be kept outside sudo mode")
+	}
+}
+
+// TestRegisterModel_ModerationField tests that the moderation flag must be a bool field
+func TestRegisterModel_ModerationField(t *testing.T) {
+	registry := NewRegistry(testdb.Open(t))
+	err := registry.RegisterModel(ModelRegistration{ModelType: &models.Post{}, Moderation: &Moderation{Field: "Subject"}})
+	if err == nil {
+		t.Error("Expected an error for a non-bool moderation field")
+	}
+	if err := registry.RegisterModel(ModelRegistration{ModelType: &models.Post{}, Moderation: &Moderation{Field: "PendingReview"}}); err != nil {
+		t.Errorf("RegisterModel failed: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// paletteRecordsPerModel caps how many matching records each model contributes
+const paletteRecordsPerModel = 3
+
+// PaletteItem is one entry in the admin command palette
+type PaletteItem struct {
+	Group string `json:"group"` // "Models", "Actions" or "Records"
+	Icon  string `json:"icon"`
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Palette serves command palette entries (Ctrl+K) matching ?q= as JSON.
+// It searches registered models, their actions, and records of searchable models.
+func (h *Handler) Palette(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	needle := strings.ToLower(q)
+	matches := func(s string) bool {
+		return needle == "" || strings.Contains(strings.ToLower(s), needle)
+	}
+
+	items := []PaletteItem{}
+	if matches("Dashboard") {
+		items = append(items, PaletteItem{Group: "Actions", Icon: "🏠", Label: "Dashboard", URL: "/admin"})
+	}
+	if matches("Media library") {
+		items = append(items, PaletteItem{Group: "Actions", Icon: "🖼️", Label: "Media library", URL: "/admin/media"})
+	}
+
+	for _, config := range h.Registry.Nav() {
+		modelLower := strings.ToLower(config.Name)
+
+		if matches(config.NamePlural) || matches(config.Name) {
+			items = append(items, PaletteItem{
+				Group: "Models",
+				Icon:  config.Icon,
+				Label: config.NamePlural,
+				URL:   "/admin/" + modelLower,
+			})
+		}
+		if matches("New " + config.Name) {
+			items = append(items, PaletteItem{
+				Group: "Actions",
+				Icon:  "➕",
+				Label: "New " + config.Name,
+				URL:   "/admin/" + modelLower + "?new=1",
+			})
+		}
+
+		// Records are only searched once the user has typed something
+		if q == "" || config.Search == nil || len(config.SearchFields) == 0 {
+			continue
+		}
+		records, err := config.Search(r.Context(), q, paletteRecordsPerModel)
+		if err != nil {
+			utils.Errorw("admin.palette_search_failed", "model", config.Name, "error", err)
+			continue
+		}
+		for _, rec := range h.withoutPending(config, records) {
+			items = append(items, PaletteItem{
+				Group: "Records",
+				Icon:  config.Icon,
+				Label: fmt.Sprintf("%v", extractFieldValue(rec, config.LabelField)),
+				URL:   "/admin/" + modelLower + "?edit=" + getIDValue(rec),
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// paletteItems calls the palette endpoint and decodes its entries
+func paletteItems(t *testing.T, handler *Handler, q string) []PaletteItem {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler.Palette(w, httptest.NewRequest(http.MethodGet, "/admin/palette?q="+q, nil))
+
+	var items []PaletteItem
+	if err := json.Unmarshal(w.Body.Bytes(), &items); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", w.Body.String(), err)
+	}
+	return items
+}
+
+// TestPalette tests that models, actions and matching records are returned
+func TestPalette(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, nil, client)
+	u := client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").SaveX(context.Background())
+
+	items := paletteItems(t, handler, "")
+	groups := map[string]int{}
+	for _, item := range items {
+		groups[item.Group]++
+	}
+	if groups["Models"] != 7 || groups["Records"] != 0 {
+		t.Errorf("Expected 7 models and no records for empty query, got %v", groups)
+	}
+
+	items = paletteItems(t, handler, "alice")
+	found := false
+	for _, item := range items {
+		if item.Group == "Records" && item.Label == "alice@example.com" && item.URL == "/admin/user?edit="+u.ID.String() {
+			found = true
+		}
+		if item.Group == "Models" {
+			t.Errorf("Expected no model match for 'alice', got %+v", item)
+		}
+	}
+	if !found {
+		t.Errorf("Expected matching user record, got %+v", items)
+	}
+
+	items = paletteItems(t, handler, "new%20post")
+	if len(items) != 1 || items[0].URL != "/admin/post?new=1" {
+		t.Errorf("Expected only the 'New Post' action, got %+v", items)
+	}
+}
+
+// TestNav_HiddenModels tests that hidden and disabled models are left out of
+// navigation but stay registered
+func TestNav_HiddenModels(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	launched := false
+	registry.RegisterModel(ModelRegistration{ModelType: &models.User{}, Hidden: true})
+	registry.RegisterModel(ModelRegistration{ModelType: &models.Post{}, Enabled: func() bool { return launched }})
+	handler := NewHandler(registry, nil, client)
+
+	if nav := registry.Nav(); len(nav) != 0 {
+		t.Errorf("Expected no models in navigation, got %d", len(nav))
+	}
+	if items := paletteItems(t, handler, "post"); len(items) != 0 {
+		t.Errorf("Expected no palette items for a disabled model, got %+v", items)
+	}
+	if _, err := registry.Get("post"); err != nil {
+		t.Errorf("Expected the disabled model to stay registered: %v", err)
+	}
+
+	launched = true
+	if nav := registry.Nav(); len(nav) != 1 || nav[0].Name != "Post" {
+		t.Errorf("Expected only Post once enabled, got %v", nav)
+	}
+
+	// Reordering the dashboard only sends visible models; hidden ones must not be dropped
+	if err := registry.SaveModelOrder([]string{"Post"}); err != nil {
+		t.Fatalf("SaveModelOrder failed: %v", err)
+	}
+	if list := registry.List(); len(list) != 2 || list[0].Name != "Post" || list[1].Name != "User" {
+		t.Errorf("Expected Post then User, got %v", list)
+	}
+}
@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// OwnershipRule reports whether user owns record (e.g., is the author of a post).
+// Non-superuser staff may only edit and delete records they own.
+type OwnershipRule func(user *models.User, record interface{}) bool
+
+// CanModify reports whether user may edit or delete record.
+// Superusers and models without an OwnsRecord rule are unrestricted.
+func (c *ModelConfig) CanModify(user *models.User, record interface{}) bool {
+	if c.OwnsRecord == nil {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	return user.IsSuperuser || c.OwnsRecord(user, record)
+}
+
+// authorizeRecord renders 403 and returns false if the current user may not modify record
+func (h *Handler) authorizeRecord(w http.ResponseWriter, r *http.Request, config *ModelConfig, record interface{}) bool {
+	if h.permitted(r, config, record) {
+		return true
+	}
+	h.Renderer.RenderError(w, r, http.StatusForbidden,
+		fmt.Sprintf("You can only change your own %s", strings.ToLower(config.NamePlural)))
+	return false
+}
+
+// permitted reports whether the current user may modify record, logging denials
+func (h *Handler) permitted(r *http.Request, config *ModelConfig, record interface{}) bool {
+	user := middleware.GetUser(r.Context())
+	if config.CanModify(user, record) {
+		return true
+	}
+
+	var userID interface{}
+	if user != nil {
+		userID = user.ID
+	}
+	utils.Warnw("admin.permission_denied",
+		"model", config.Name,
+		"record_id", getIDValue(record),
+		"user_id", userID,
+	)
+	return false
+}
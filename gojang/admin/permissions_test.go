@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// recordRequest builds a request routed to /admin/post/{id} as user
+func recordRequest(method string, user *models.User, post *models.Post, form url.Values) *http.Request {
+	req := httptest.NewRequest(method, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("model", "post")
+	rctx.URLParams.Add("id", post.ID.String())
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = middleware.WithUser(ctx, user)
+	return req.WithContext(ctx)
+}
+
+// TestOwnsRecord_RestrictsStaffToOwnPosts tests that staff can't edit or delete other authors' posts
+func TestOwnsRecord_RestrictsStaffToOwnPosts(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, renderer, client)
+	handler.UndoWindow = 0
+	ctx := context.Background()
+
+	author := seedUserWithPosts(t, client, 1)
+	post := client.User.QueryPosts(author).OnlyX(ctx)
+	staff := client.User.Create().SetEmail("staff@example.com").SetPasswordHash("x").SetIsStaff(true).SaveX(ctx)
+	superuser := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	form := url.Values{"Subject": {"changed"}, "Body": {"b"}, "AuthorID": {author.ID.String()}}
+
+	for name, call := range map[string]func(http.ResponseWriter, *http.Request){
+		"Edit":          handler.Edit,
+		"Update":        handler.Update,
+		"DeleteConfirm": handler.DeleteConfirm,
+		"Delete":        handler.Delete,
+	} {
+		w := httptest.NewRecorder()
+		call(w, recordRequest(http.MethodPost, staff, post, form))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403 for another author's post, got %d", name, w.Code)
+		}
+	}
+	if got := client.Post.GetX(ctx, post.ID).Subject; got != "s" {
+		t.Errorf("Expected post to be unchanged, got subject %q", got)
+	}
+
+	// The author and superusers may edit it
+	for _, user := range []*models.User{author, superuser} {
+		w := httptest.NewRecorder()
+		handler.Update(w, recordRequest(http.MethodPut, user, post, form))
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected %s to update the post, got %d: %s", user.Email, w.Code, w.Body.String())
+		}
+	}
+	if got := client.Post.GetX(ctx, post.ID).Subject; got != "changed" {
+		t.Errorf("Expected subject 'changed', got %q", got)
+	}
+}
+
+// TestCanModify_NoRule tests that models without an OwnsRecord rule are unrestricted
+func TestCanModify_NoRule(t *testing.T) {
+	config := &ModelConfig{Name: "User"}
+	if !config.CanModify(&models.User{IsStaff: true}, &models.User{}) {
+		t.Error("Expected records to be modifiable without an OwnsRecord rule")
+	}
+
+	config.OwnsRecord = func(*models.User, interface{}) bool { return false }
+	if config.CanModify(nil, &models.User{}) {
+		t.Error("Expected anonymous users to be denied when a rule is set")
+	}
+}
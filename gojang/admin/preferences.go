@@ -0,0 +1,289 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/models/userpreference"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// listPreferencesPrefix namespaces list preferences per model (e.g., "admin.list.post")
+const listPreferencesPrefix = "admin.list."
+
+// ListPreferences are a user's remembered list view settings for one model
+type ListPreferences struct {
+	PerPage       int                          `json:"per_page,omitempty"`
+	Sort          string                       `json:"sort,omitempty"` // Field name, "-" prefix for descending
+	HiddenColumns []string                     `json:"hidden_columns,omitempty"`
+	Filters       map[string]string            `json:"filters,omitempty"`       // Active filter values by field
+	SavedFilters  map[string]map[string]string `json:"saved_filters,omitempty"` // Named filter sets
+}
+
+// VisibleColumns returns listFields minus the hidden columns
+func (p ListPreferences) VisibleColumns(listFields []string) []string {
+	var columns []string
+	for _, name := range listFields {
+		if !p.IsHidden(name) {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// IsHidden reports whether the user hid a list column
+func (p ListPreferences) IsHidden(column string) bool {
+	for _, hidden := range p.HiddenColumns {
+		if hidden == column {
+			return true
+		}
+	}
+	return false
+}
+
+// SortLink returns the sort value a column header should link to (toggling direction)
+func (p ListPreferences) SortLink(column string) string {
+	if p.Sort == column {
+		return "-" + column
+	}
+	return column
+}
+
+// RangeStart returns the start date of a time field's range filter ("" if open)
+func (p ListPreferences) RangeStart(field string) string {
+	from, _, _ := strings.Cut(p.Filters[field], dateRangeSep)
+	return from
+}
+
+// RangeEnd returns the end date of a time field's range filter ("" if open)
+func (p ListPreferences) RangeEnd(field string) string {
+	_, to, _ := strings.Cut(p.Filters[field], dateRangeSep)
+	return to
+}
+
+// listOptions builds query options for one page
+func (p ListPreferences) listOptions(limit, offset int) ListOptions {
+	return ListOptions{Limit: limit, Offset: offset, Sort: p.Sort, Filters: p.Filters}
+}
+
+// validPerPage reports whether n is one of the per-page sizes offered in the list view
+func validPerPage(n int) bool {
+	return n == 20 || n == 50 || n == 100
+}
+
+// sanitize drops settings that no longer match the model (e.g., after a field was removed)
+func (p *ListPreferences) sanitize(config *ModelConfig) {
+	if !validPerPage(p.PerPage) {
+		p.PerPage = 0
+	}
+	if p.Sort != "" && !config.Sortable(strings.TrimPrefix(p.Sort, "-")) {
+		p.Sort = ""
+	}
+	for field := range p.Filters {
+		if f := config.Field(field); f == nil || !f.Filterable() {
+			delete(p.Filters, field)
+		}
+	}
+	hidden := p.HiddenColumns[:0:0]
+	for _, column := range p.HiddenColumns {
+		for _, name := range config.ListFields {
+			if name == column {
+				hidden = append(hidden, column)
+			}
+		}
+	}
+	p.HiddenColumns = hidden
+}
+
+// listPreferences loads the current user's list preferences for a model.
+// Missing or unreadable preferences yield the defaults.
+func (h *Handler) listPreferences(r *http.Request, config *ModelConfig) ListPreferences {
+	var prefs ListPreferences
+	u := middleware.GetUser(r.Context())
+	if h.DB == nil || u == nil {
+		return prefs
+	}
+
+	pref, err := h.DB.UserPreference.Query().
+		Where(
+			userpreference.HasUserWith(user.IDEQ(u.ID)),
+			userpreference.KeyEQ(listPreferencesPrefix+strings.ToLower(config.Name)),
+		).
+		Only(r.Context())
+	if err != nil {
+		return prefs
+	}
+
+	if err := json.Unmarshal([]byte(pref.Value), &prefs); err != nil {
+		utils.Warnw("admin.preferences_invalid", "model", config.Name, "user_id", u.ID, "error", err)
+		return ListPreferences{}
+	}
+	prefs.sanitize(config)
+	return prefs
+}
+
+// saveListPreferences stores the current user's list preferences for a model
+func (h *Handler) saveListPreferences(ctx context.Context, config *ModelConfig, prefs ListPreferences) error {
+	u := middleware.GetUser(ctx)
+	if h.DB == nil || u == nil {
+		return nil
+	}
+	return h.saveUserPreference(ctx, u.ID, listPreferencesPrefix+strings.ToLower(config.Name), prefs)
+}
+
+// saveUserPreference upserts one JSON-encoded preference for a user
+func (h *Handler) saveUserPreference(ctx context.Context, userID uuid.UUID, key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	n, err := h.DB.UserPreference.Update().
+		Where(
+			userpreference.HasUserWith(user.IDEQ(userID)),
+			userpreference.KeyEQ(key),
+		).
+		SetValue(string(encoded)).
+		Save(ctx)
+	if err != nil || n > 0 {
+		return err
+	}
+
+	return h.DB.UserPreference.Create().
+		SetUserID(userID).
+		SetKey(key).
+		SetValue(string(encoded)).
+		Exec(ctx)
+}
+
+// applyListParams updates prefs from Index query params (per_page, sort, filters,
+// saved filter sets) and reports whether anything changed.
+func applyListParams(config *ModelConfig, prefs *ListPreferences, query map[string][]string) bool {
+	get := func(key string) (string, bool) {
+		v, ok := query[key]
+		if !ok || len(v) == 0 {
+			return "", ok
+		}
+		return v[0], true
+	}
+	changed := false
+
+	if v, ok := get("per_page"); ok {
+		if pp, err := strconv.Atoi(v); err == nil && validPerPage(pp) && pp != prefs.PerPage {
+			prefs.PerPage = pp
+			changed = true
+		}
+	}
+
+	if v, ok := get("sort"); ok && v != prefs.Sort {
+		if v == "" || config.Sortable(strings.TrimPrefix(v, "-")) {
+			prefs.Sort = v
+			changed = true
+		}
+	}
+
+	// The filter form always sends apply_filters so clearing every input clears the filters
+	if _, ok := get("apply_filters"); ok {
+		filters := make(map[string]string)
+		for _, field := range config.FilterFields() {
+			v, _ := get("f_" + field.Name)
+			// Time fields are filtered by the date range picker's two inputs
+			if field.Type == FieldTypeTime {
+				from, _ := get("f_" + field.Name + "_from")
+				to, _ := get("f_" + field.Name + "_to")
+				if from, to = strings.TrimSpace(from), strings.TrimSpace(to); from != "" || to != "" {
+					v = from + dateRangeSep + to
+				}
+			}
+			if strings.TrimSpace(v) != "" {
+				filters[field.Name] = strings.TrimSpace(v)
+			}
+		}
+		prefs.Filters = filters
+		changed = true
+	}
+
+	if name, ok := get("saved"); ok {
+		if saved, exists := prefs.SavedFilters[name]; exists {
+			prefs.Filters = make(map[string]string, len(saved))
+			for k, v := range saved {
+				prefs.Filters[k] = v
+			}
+			prefs.sanitize(config)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// SavePreferences updates column visibility and saved filter sets, then re-renders the list.
+// Form field "action" is one of: columns, save_filter, delete_filter, reset.
+func (h *Handler) SavePreferences(w http.ResponseWriter, r *http.Request) {
+	modelName := chi.URLParam(r, "model")
+
+	config, err := h.Registry.Get(modelName)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	prefs := h.listPreferences(r, config)
+	switch r.PostForm.Get("action") {
+	case "columns":
+		shown := make(map[string]bool)
+		for _, column := range r.PostForm["columns"] {
+			shown[column] = true
+		}
+		var hidden []string
+		for _, name := range config.ListFields {
+			if !shown[name] {
+				hidden = append(hidden, name)
+			}
+		}
+		if len(hidden) == len(config.ListFields) {
+			h.Renderer.RenderError(w, r, http.StatusBadRequest, "At least one column must stay visible")
+			return
+		}
+		prefs.HiddenColumns = hidden
+	case "save_filter":
+		name := strings.TrimSpace(r.PostForm.Get("name"))
+		if name == "" || len(prefs.Filters) == 0 {
+			h.Renderer.RenderError(w, r, http.StatusBadRequest, "Apply a filter and give it a name to save it")
+			return
+		}
+		if prefs.SavedFilters == nil {
+			prefs.SavedFilters = make(map[string]map[string]string)
+		}
+		prefs.SavedFilters[name] = prefs.Filters
+	case "delete_filter":
+		delete(prefs.SavedFilters, r.PostForm.Get("name"))
+	case "reset":
+		prefs = ListPreferences{}
+	default:
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Unknown preference action")
+		return
+	}
+
+	if err := h.saveListPreferences(r.Context(), config, prefs); err != nil {
+		utils.Errorw("admin.preferences_save_failed", "model", config.Name, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to save preferences")
+		return
+	}
+
+	// Index re-reads the saved preferences; list links use hx-select to pick the table
+	r.URL.RawQuery = ""
+	h.Index(w, r)
+}
@@ -0,0 +1,158 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// preferencesRequest builds a request for /admin/post as the given user
+func preferencesRequest(method, target string, user *models.User, form url.Values) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("model", "post")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = middleware.WithUser(ctx, user)
+	return req.WithContext(ctx)
+}
+
+// newPreferencesHandler returns a handler with a real renderer and posts "alpha", "beta", "gamma", "zed"
+func newPreferencesHandler(t *testing.T) (*Handler, *models.Client, *models.User) {
+	t.Helper()
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+
+	admin := seedUserWithPosts(t, client, 0)
+	for _, subject := range []string{"beta", "zed", "alpha", "gamma"} {
+		client.Post.Create().SetSubject(subject).SetBody("b").SetAuthor(admin).SaveX(context.Background())
+	}
+	return NewHandler(registry, renderer, client), client, admin
+}
+
+// TestIndex_RemembersListPreferences tests that sort, filters and per_page persist across visits
+func TestIndex_RemembersListPreferences(t *testing.T) {
+	handler, client, admin := newPreferencesHandler(t)
+
+	w := httptest.NewRecorder()
+	handler.Index(w, preferencesRequest(http.MethodGet, "/admin/post?sort=-Subject&apply_filters=1&f_Subject=A&per_page=50", admin, nil))
+	assertSubjectOrder(t, w.Body.String(), "gamma", "beta", "alpha")
+
+	// A later visit without params applies the saved preferences
+	w = httptest.NewRecorder()
+	handler.Index(w, preferencesRequest(http.MethodGet, "/admin/post", admin, nil))
+	body := w.Body.String()
+	assertSubjectOrder(t, body, "gamma", "beta", "alpha")
+	if strings.Contains(body, ">zed<") {
+		t.Error("Expected filtered-out post to stay hidden")
+	}
+	if !strings.Contains(body, `<option value="50" selected>`) {
+		t.Error("Expected saved per_page of 50 to be selected")
+	}
+
+	// Preferences are per user
+	other := client.User.Create().SetEmail("other@example.com").SetPasswordHash("x").SaveX(context.Background())
+	w = httptest.NewRecorder()
+	handler.Index(w, preferencesRequest(http.MethodGet, "/admin/post", other, nil))
+	if !strings.Contains(w.Body.String(), ">zed<") {
+		t.Error("Expected another user's list to be unfiltered")
+	}
+}
+
+// TestSavePreferences_ColumnsAndSavedFilters tests column visibility and named filter sets
+func TestSavePreferences_ColumnsAndSavedFilters(t *testing.T) {
+	handler, _, admin := newPreferencesHandler(t)
+
+	w := httptest.NewRecorder()
+	handler.SavePreferences(w, preferencesRequest(http.MethodPost, "/admin/post/preferences", admin, url.Values{
+		"action":  {"columns"},
+		"columns": {"Subject"},
+	}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "<th>Author</th>") {
+		t.Error("Expected hidden Author column to be omitted")
+	}
+
+	// Every column can't be hidden
+	w = httptest.NewRecorder()
+	handler.SavePreferences(w, preferencesRequest(http.MethodPost, "/admin/post/preferences", admin, url.Values{"action": {"columns"}}))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 when hiding every column, got %d", w.Code)
+	}
+
+	// Save the active filter under a name, clear it, then re-apply it by name
+	handler.Index(httptest.NewRecorder(), preferencesRequest(http.MethodGet, "/admin/post?apply_filters=1&f_Subject=zed", admin, nil))
+	w = httptest.NewRecorder()
+	handler.SavePreferences(w, preferencesRequest(http.MethodPost, "/admin/post/preferences", admin, url.Values{
+		"action": {"save_filter"},
+		"name":   {"Only zed"},
+	}))
+	if !strings.Contains(w.Body.String(), "Only zed") {
+		t.Error("Expected saved filter to be listed")
+	}
+
+	handler.Index(httptest.NewRecorder(), preferencesRequest(http.MethodGet, "/admin/post?apply_filters=1", admin, nil))
+	w = httptest.NewRecorder()
+	handler.Index(w, preferencesRequest(http.MethodGet, "/admin/post?saved=Only+zed", admin, nil))
+	if body := w.Body.String(); !strings.Contains(body, ">zed<") || strings.Contains(body, ">alpha<") {
+		t.Error("Expected saved filter to show only zed")
+	}
+}
+
+// TestIndex_DateRangeFilter tests filtering a time field with the date range picker
+func TestIndex_DateRangeFilter(t *testing.T) {
+	handler, _, admin := newPreferencesHandler(t)
+	today := time.Now().UTC().Format("2006-01-02")
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+
+	w := httptest.NewRecorder()
+	handler.Index(w, preferencesRequest(http.MethodGet, "/admin/post?apply_filters=1&f_CreatedAt_from="+today+"&f_CreatedAt_to="+today, admin, nil))
+	body := w.Body.String()
+	if !strings.Contains(body, ">zed<") {
+		t.Error("Expected posts created today to be listed")
+	}
+	if !strings.Contains(body, `name="f_CreatedAt_from" value="`+today+`"`) {
+		t.Error("Expected the date range picker to keep the start date")
+	}
+
+	w = httptest.NewRecorder()
+	handler.Index(w, preferencesRequest(http.MethodGet, "/admin/post?apply_filters=1&f_CreatedAt_from="+tomorrow, admin, nil))
+	if strings.Contains(w.Body.String(), ">zed<") {
+		t.Error("Expected no posts from tomorrow on")
+	}
+}
+
+// assertSubjectOrder checks that subjects appear in body in the given order
+func assertSubjectOrder(t *testing.T, body string, subjects ...string) {
+	t.Helper()
+	last := -1
+	for _, s := range subjects {
+		i := strings.Index(body, ">"+s+"<")
+		if i < 0 {
+			t.Fatalf("Expected %q in list", s)
+		}
+		if i < last {
+			t.Errorf("Expected %q after the previous subject", s)
+		}
+		last = i
+	}
+}
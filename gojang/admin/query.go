@@ -0,0 +1,281 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/migrate"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// Query console defaults
+const (
+	DefaultQueryRowLimit = 500
+	DefaultQueryTimeout  = 10 * time.Second
+)
+
+// readOnlyKeywords are the statements the query console accepts. The
+// transaction is read-only as well, so a data-modifying WITH still fails.
+// Neither is a security boundary: a SELECT can call functions with side
+// effects (pg_terminate_backend, set_config, pg_sleep...), which only the
+// database's own permissions can stop (see QueryConsole.Role).
+var readOnlyKeywords = map[string]bool{
+	"select":  true,
+	"with":    true,
+	"explain": true,
+	"values":  true,
+	"show":    true,
+	"table":   true,
+}
+
+// QueryConsole runs the read-only SQL superusers type into /admin/query, so
+// support questions can be answered without a shell on the server
+type QueryConsole struct {
+	Driver   func() *entsql.Driver // The pool to query, e.g. db.Monitor.Driver
+	RowLimit int                   // Rows shown per query (default DefaultQueryRowLimit)
+	Timeout  time.Duration         // Per query (default DefaultQueryTimeout)
+
+	// Role, on Postgres, is the role queries run as (SET LOCAL ROLE), such as
+	// one only granted SELECT. The app's database user must be a member of it.
+	Role string
+}
+
+// QueryResult is a query's columns and rows, formatted for display
+type QueryResult struct {
+	Columns   []string
+	Rows      [][]QueryCell
+	Truncated bool // There were more than RowLimit rows
+	Duration  time.Duration
+}
+
+// QueryCell is a value in a QueryResult
+type QueryCell struct {
+	Value string
+	Null  bool
+}
+
+// QueryTable is a table listed beside the console, for writing queries
+type QueryTable struct {
+	Name    string
+	Columns []string
+}
+
+// Run executes query in a read-only transaction that is always rolled back
+func (c *QueryConsole) Run(ctx context.Context, query string) (*QueryResult, error) {
+	query, err := checkReadOnly(query)
+	if err != nil {
+		return nil, err
+	}
+	limit, timeout := c.RowLimit, c.Timeout
+	if limit <= 0 {
+		limit = DefaultQueryRowLimit
+	}
+	if timeout <= 0 {
+		timeout = DefaultQueryTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	drv := c.Driver()
+	conn, err := drv.DB().Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// SQLite ignores read-only transactions; query_only makes the connection
+	// refuse writes until it's turned off again
+	if drv.Dialect() == dialect.SQLite {
+		if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if _, err := conn.ExecContext(context.Background(), "PRAGMA query_only = OFF"); err != nil {
+				// Never hand a read-only connection back to the pool
+				conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+			}
+		}()
+	}
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if drv.Dialect() == dialect.Postgres {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())); err != nil {
+			return nil, err
+		}
+		if c.Role != "" {
+			if _, err := tx.ExecContext(ctx, "SET LOCAL ROLE "+quoteIdentifier(c.Role)); err != nil {
+				return nil, fmt.Errorf("switching to role %s: %w", c.Role, err)
+			}
+		}
+	}
+
+	start := time.Now()
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, queryError(ctx, err, timeout)
+	}
+	defer rows.Close()
+
+	result := &QueryResult{}
+	if result.Columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(result.Columns))
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if len(result.Rows) == limit {
+			result.Truncated = true
+			break
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := make([]QueryCell, len(values))
+		for i, v := range values {
+			row[i] = queryCell(v)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, err, timeout)
+	}
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// quoteIdentifier quotes a Postgres identifier, such as a role name
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// checkReadOnly returns query without its trailing semicolon if it's a
+// single statement the console accepts
+func checkReadOnly(query string) (string, error) {
+	query = strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")
+	if query == "" {
+		return "", errors.New("enter a query")
+	}
+	if strings.Contains(query, ";") {
+		return "", errors.New("run one statement at a time")
+	}
+
+	// Skip leading comments to find the statement's keyword
+	rest := query
+	for {
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, "--") {
+			_, rest, _ = strings.Cut(rest, "\n")
+		} else if strings.HasPrefix(rest, "/*") {
+			_, rest, _ = strings.Cut(rest, "*/")
+		} else {
+			break
+		}
+	}
+	words := strings.FieldsFunc(rest, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '('
+	})
+	if len(words) == 0 {
+		return "", errors.New("enter a query")
+	}
+	if keyword := strings.ToLower(words[0]); !readOnlyKeywords[keyword] {
+		return "", fmt.Errorf("only SELECT, WITH, EXPLAIN, VALUES, SHOW and TABLE statements can be run here, not %s", strings.ToUpper(keyword))
+	}
+	return query, nil
+}
+
+// queryError explains a query canceled by the console's timeout
+func queryError(ctx context.Context, err error, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("query canceled after %s", timeout)
+	}
+	return err
+}
+
+func queryCell(v interface{}) QueryCell {
+	switch v := v.(type) {
+	case nil:
+		return QueryCell{Value: "NULL", Null: true}
+	case []byte:
+		if utf8.Valid(v) {
+			return QueryCell{Value: string(v)}
+		}
+		return QueryCell{Value: fmt.Sprintf("<%d bytes>", len(v))}
+	case time.Time:
+		return QueryCell{Value: v.Format(time.RFC3339)}
+	}
+	return QueryCell{Value: fmt.Sprint(v)}
+}
+
+// queryTables lists the Ent schema's tables and columns
+func queryTables() []QueryTable {
+	tables := make([]QueryTable, len(migrate.Tables))
+	for i, t := range migrate.Tables {
+		tables[i].Name = t.Name
+		for _, c := range t.Columns {
+			tables[i].Columns = append(tables[i].Columns, c.Name)
+		}
+	}
+	return tables
+}
+
+// QueryIndex shows the query console (superusers only, see AdminRoutes)
+func (h *Handler) QueryIndex(w http.ResponseWriter, r *http.Request) {
+	if h.Console == nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "The query console is turned off")
+		return
+	}
+	h.Renderer.Render(w, r, "query_index.html", &TemplateData{
+		Title: "Query console",
+		Data: map[string]interface{}{
+			"Tables": queryTables(),
+		},
+	})
+}
+
+// QueryRun runs the form's query and renders the results. Every query is
+// logged and added to the activity log, whether or not it succeeds.
+func (h *Handler) QueryRun(w http.ResponseWriter, r *http.Request) {
+	if h.Console == nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "The query console is turned off")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+	query := strings.TrimSpace(r.FormValue("query"))
+
+	result, err := h.Console.Run(r.Context(), query)
+	user := middleware.GetUser(r.Context())
+	fields := []interface{}{"user_id", user.ID, "user", user.Email, "query", query}
+	if err != nil {
+		utils.Warnw("admin.query_failed", append(fields, "error", err)...)
+	} else {
+		utils.Infow("admin.query", append(fields, "rows", len(result.Rows), "truncated", result.Truncated, "duration_ms", result.Duration.Milliseconds())...)
+	}
+	h.recordActionOn(r.Context(), adminaction.ActionRun, "Query", "SQL", "", query)
+
+	data := &TemplateData{Data: map[string]interface{}{"Result": result}}
+	if err != nil {
+		data.Flash, data.FlashType = err.Error(), "error"
+	}
+	h.Renderer.Render(w, r, "query_results.partial.html", data)
+}
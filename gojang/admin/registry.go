@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/google/uuid"
 )
 
@@ -47,14 +48,166 @@ func (r *Registry) RegisterModel(reg ModelRegistration) error {
 		HiddenFields:   reg.HiddenFields,
 		ReadonlyFields: reg.ReadonlyFields,
 		OptionalFields: reg.OptionalFields,
+		FieldTypes:     reg.FieldTypes,
 	}
 
 	// Use reflection to discover fields
 	fields := extractFields(reg.ModelType, override)
 
-	// Append custom fields if provided
-	if reg.CustomFields != nil {
-		fields = append(fields, reg.CustomFields...)
+	// Append custom fields if provided. A custom field with the name of a detected
+	// field replaces it (e.g., an edge field like OwnerID shown as a relation).
+	for _, custom := range reg.CustomFields {
+		replaced := false
+		for i := range fields {
+			if fields[i].Name == custom.Name {
+				fields[i] = custom
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			fields = append(fields, custom)
+		}
+	}
+
+	// Build the cached field accessors once
+	meta := metaForType(modelType)
+
+	// Computed fields are read-only columns that must not shadow a real field
+	computed := make(map[string]bool, len(reg.ComputedFields))
+	for _, c := range reg.ComputedFields {
+		if c.Name == "" || c.Compute == nil || meta.has(c.Name) || computed[c.Name] {
+			err := fmt.Errorf("computed field %q of model %s needs a unique name and a Compute func", c.Name, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+		computed[c.Name] = true
+		label := c.Label
+		if label == "" {
+			label = formatLabel(c.Name)
+		}
+		fields = append(fields, FieldConfig{
+			Name:       c.Name,
+			Label:      label,
+			Type:       FieldTypeComputed,
+			Readonly:   true,
+			ShowOnEdit: true, // There's no record to compute from on create
+			Compute:    c.Compute,
+		})
+	}
+
+	// Make sure every list column resolves, so a typo in ListFields fails at
+	// startup instead of rendering empty cells
+	for _, name := range reg.ListFields {
+		if !meta.has(name) && !computed[name] {
+			err := fmt.Errorf("list field %q not found on model %s", name, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+	}
+
+	// Unique fields come from the Ent schema, plus any the registration adds
+	unique := uniqueColumns(modelName)
+	for _, name := range reg.UniqueFields {
+		if !meta.has(name) {
+			err := fmt.Errorf("unique field %q not found on model %s", name, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+		unique[columnName(name)] = true
+	}
+	for i := range fields {
+		if unique[columnName(fields[i].Name)] && fields[i].Type != FieldTypeComputed {
+			fields[i].Unique = true
+		}
+	}
+
+	// Upload rules only apply to upload fields
+	for name, rules := range reg.Uploads {
+		found := false
+		for i := range fields {
+			if fields[i].Name == name && fields[i].IsUpload() {
+				fields[i].Upload, found = rules, true
+			}
+		}
+		if !found {
+			err := fmt.Errorf("upload field %q not found on model %s", name, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+	}
+	// Sudo fields must be form fields, or changes to them would go unnoticed
+	for _, name := range reg.SudoFields {
+		found := false
+		for _, f := range fields {
+			found = found || (f.Name == name && f.Type != FieldTypeComputed)
+		}
+		if !found {
+			err := fmt.Errorf("sudo field %q not found on model %s", name, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+	}
+
+	// The moderation queue filters on the flag, so it must be a filterable bool
+	if m := reg.Moderation; m != nil {
+		found := false
+		for _, f := range fields {
+			found = found || (f.Name == m.Field && f.Type == FieldTypeBool && f.Filterable())
+		}
+		if !found {
+			err := fmt.Errorf("moderation field %q not found on model %s or not a bool", m.Field, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+	}
+
+	if err := validateFieldsets(reg.Fieldsets, fields); err != nil {
+		err = fmt.Errorf("model %s: %w", modelName, err)
+		utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+		return err
+	}
+
+	// Inline edges must be to-many edges of this model
+	for _, inline := range reg.Inlines {
+		acc, ok := meta.accessors[inline.Edge]
+		if !ok || !acc.isEdge || acc.typ.Kind() != reflect.Slice {
+			err := fmt.Errorf("inline edge %q is not a to-many edge of model %s", inline.Edge, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+	}
+
+	// Autocomplete may only match plain string fields, never hidden or sensitive ones
+	for _, name := range reg.SearchFields {
+		acc, ok := meta.accessors[name]
+		if !ok || acc.isEdge || acc.typ.Kind() != reflect.String || contains(reg.HiddenFields, name) || name == "PasswordHash" {
+			err := fmt.Errorf("search field %q is not a visible string field of model %s", name, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+	}
+	if reg.LabelField == "" && len(reg.SearchFields) > 0 {
+		reg.LabelField = reg.SearchFields[0]
+	}
+
+	// Actions are addressed by slug, so names must be distinct after slugifying
+	slugs := make(map[string]bool, len(reg.Actions))
+	for _, action := range reg.Actions {
+		slug := action.Slug()
+		if slug == "" || action.Handler == nil || slugs[slug] {
+			err := fmt.Errorf("action %q of model %s needs a unique name and a Handler", action.Name, modelName)
+			utils.Errorw("admin.register_failed", "model", modelName, "error", err)
+			return err
+		}
+		slugs[slug] = true
+	}
+
+	if reg.OnDelete == "" {
+		reg.OnDelete = DeleteRestrict
+	}
+	if reg.TimeFormat == "" {
+		reg.TimeFormat = DefaultTimeFormat
 	}
 
 	// Create config with generic CRUD operations
@@ -66,6 +219,19 @@ func (r *Registry) RegisterModel(reg ModelRegistration) error {
 		ListFields:     reg.ListFields,
 		HiddenFields:   reg.HiddenFields,
 		ReadonlyFields: reg.ReadonlyFields,
+		OnDelete:       reg.OnDelete,
+		Inlines:        reg.Inlines,
+		SearchFields:   reg.SearchFields,
+		LabelField:     reg.LabelField,
+		OwnsRecord:     reg.OwnsRecord,
+		Actions:        reg.Actions,
+		TimeFormat:     reg.TimeFormat,
+		Fieldsets:      reg.Fieldsets,
+		Validate:       reg.Validate,
+		SudoFields:     reg.SudoFields,
+		Moderation:     reg.Moderation,
+		Hidden:         reg.Hidden,
+		Enabled:        reg.Enabled,
 
 		QueryAll: func(ctx context.Context) ([]interface{}, error) {
 			return r.queryAll(ctx, modelName, reg.QueryModifier)
@@ -79,6 +245,14 @@ func (r *Registry) RegisterModel(reg ModelRegistration) error {
 			return r.countAll(ctx, modelName)
 		},
 
+		QueryList: func(ctx context.Context, opts ListOptions) ([]interface{}, error) {
+			return r.queryList(ctx, modelName, reg.QueryModifier, fields, opts)
+		},
+
+		CountList: func(ctx context.Context, filters map[string]string) (int, error) {
+			return r.countList(ctx, modelName, fields, filters)
+		},
+
 		QueryByID: func(ctx context.Context, id uuid.UUID) (interface{}, error) {
 			return r.queryByID(ctx, modelName, id, reg.QueryModifier)
 		},
@@ -102,7 +276,19 @@ func (r *Registry) RegisterModel(reg ModelRegistration) error {
 		},
 
 		DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
-			return r.genericDelete(ctx, modelName, id)
+			return r.deleteWithRelated(ctx, modelName, modelType, id, reg.OnDelete)
+		},
+
+		DeletePreview: func(ctx context.Context, id uuid.UUID) ([]RelatedCount, error) {
+			return r.deletePreview(ctx, modelName, modelType, id)
+		},
+
+		Search: func(ctx context.Context, q string, limit int) ([]interface{}, error) {
+			return r.search(ctx, modelName, reg.SearchFields, q, limit)
+		},
+
+		IsTaken: func(ctx context.Context, field string, value interface{}, exclude uuid.UUID) (bool, error) {
+			return r.isTaken(ctx, modelName, field, value, exclude)
 		},
 	}
 
@@ -134,6 +320,18 @@ func (r *Registry) List() []*ModelConfig {
 	return configs
 }
 
+// Nav returns the models shown in navigation (the dashboard, command palette
+// and API model list): List without hidden or disabled models
+func (r *Registry) Nav() []*ModelConfig {
+	var configs []*ModelConfig
+	for _, config := range r.List() {
+		if config.Visible() {
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
 // register adds a model to the registry
 func (r *Registry) register(config *ModelConfig) {
 	key := strings.ToLower(config.Name)
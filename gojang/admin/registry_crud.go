@@ -2,16 +2,30 @@ package admin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
-
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/google/uuid"
 )
 
+// clientFor returns the client for queries in ctx: its transaction's, if it
+// carries one (e.g., a batch API request), or the registry's
+func (r *Registry) clientFor(ctx context.Context) *models.Client {
+	return db.ClientFromContext(ctx, r.client)
+}
+
 // queryAll retrieves all records for a model using Ent client with reflection
 func (r *Registry) queryAll(ctx context.Context, modelName string, modifier AfterLoadHook) ([]interface{}, error) {
 	// Get the model client using reflection (e.g., r.client.User)
-	clientVal := reflect.ValueOf(r.client).Elem()
+	clientVal := reflect.ValueOf(r.clientFor(ctx)).Elem()
 	modelClient := clientVal.FieldByName(modelName)
 
 	if !modelClient.IsValid() {
@@ -65,8 +79,16 @@ func (r *Registry) queryAll(ctx context.Context, modelName string, modifier Afte
 
 // queryAllPaginated retrieves paginated records for a model using Ent client with reflection
 func (r *Registry) queryAllPaginated(ctx context.Context, modelName string, modifier AfterLoadHook, limit, offset int) ([]interface{}, error) {
+	return r.queryList(ctx, modelName, modifier, nil, ListOptions{Limit: limit, Offset: offset})
+}
+
+// queryList retrieves a filtered, sorted page of records. Filters and sort keys that
+// don't name a filterable field in fields are ignored.
+func (r *Registry) queryList(ctx context.Context, modelName string, modifier AfterLoadHook, fields []FieldConfig, opts ListOptions) ([]interface{}, error) {
+	limit, offset := opts.Limit, opts.Offset
+
 	// Get the model client using reflection (e.g., r.client.User)
-	clientVal := reflect.ValueOf(r.client).Elem()
+	clientVal := reflect.ValueOf(r.clientFor(ctx)).Elem()
 	modelClient := clientVal.FieldByName(modelName)
 
 	if !modelClient.IsValid() {
@@ -91,8 +113,23 @@ func (r *Registry) queryAllPaginated(ctx context.Context, modelName string, modi
 		query = modifier(ctx, query)
 	}
 
-	// Apply Limit and Offset
 	queryVal := reflect.ValueOf(query)
+
+	// Apply filters and sort order
+	for _, pred := range listFilters(fields, opts.Filters) {
+		var err error
+		if queryVal, err = applyWhere(queryVal, pred); err != nil {
+			return nil, err
+		}
+	}
+	if order := listOrder(fields, opts.Sort); order != nil {
+		var err error
+		if queryVal, err = applyOrder(queryVal, order); err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply Limit and Offset
 	if limit > 0 {
 		limitMethod := queryVal.MethodByName("Limit")
 		if !limitMethod.IsValid() {
@@ -144,7 +181,12 @@ func (r *Registry) queryAllPaginated(ctx context.Context, modelName string, modi
 
 // countAll returns total number of records for the model
 func (r *Registry) countAll(ctx context.Context, modelName string) (int, error) {
-	clientVal := reflect.ValueOf(r.client).Elem()
+	return r.countList(ctx, modelName, nil, nil)
+}
+
+// countList returns the number of records matching filters (see queryList)
+func (r *Registry) countList(ctx context.Context, modelName string, fields []FieldConfig, filters map[string]string) (int, error) {
+	clientVal := reflect.ValueOf(r.clientFor(ctx)).Elem()
 	modelClient := clientVal.FieldByName(modelName)
 	if !modelClient.IsValid() {
 		return 0, fmt.Errorf("model %s not found on client", modelName)
@@ -161,6 +203,13 @@ func (r *Registry) countAll(ctx context.Context, modelName string) (int, error)
 	query := queryResults[0].Interface()
 
 	queryVal := reflect.ValueOf(query)
+	for _, pred := range listFilters(fields, filters) {
+		var err error
+		if queryVal, err = applyWhere(queryVal, pred); err != nil {
+			return 0, err
+		}
+	}
+
 	countMethod := queryVal.MethodByName("Count")
 	if !countMethod.IsValid() {
 		return 0, fmt.Errorf("count method not found for model %s", modelName)
@@ -175,16 +224,35 @@ func (r *Registry) countAll(ctx context.Context, modelName string) (int, error)
 	return int(countResults[0].Int()), nil
 }
 
-// queryByID retrieves a single record by ID using reflection
-func (r *Registry) queryByID(ctx context.Context, modelName string, id uuid.UUID, _ AfterLoadHook) (interface{}, error) {
+// queryByID retrieves a single record by ID using reflection.
+// When a modifier is given (e.g., eager loading), the record is loaded through Query() so it applies.
+func (r *Registry) queryByID(ctx context.Context, modelName string, id uuid.UUID, modifier AfterLoadHook) (interface{}, error) {
 	// Get the model client using reflection (e.g., r.client.User)
-	clientVal := reflect.ValueOf(r.client).Elem()
+	clientVal := reflect.ValueOf(r.clientFor(ctx)).Elem()
 	modelClient := clientVal.FieldByName(modelName)
 
 	if !modelClient.IsValid() {
 		return nil, fmt.Errorf("model %s not found on client", modelName)
 	}
 
+	if modifier != nil {
+		queryMethod := modelClient.MethodByName("Query")
+		if !queryMethod.IsValid() {
+			return nil, fmt.Errorf("query method not found for model %s", modelName)
+		}
+		query := reflect.ValueOf(modifier(ctx, queryMethod.Call(nil)[0].Interface()))
+
+		query, err := applyWhere(query, sql.FieldEQ("id", id))
+		if err != nil {
+			return nil, err
+		}
+		record, err := callWithErr(query, "Only", ctx)
+		if err != nil {
+			return nil, err
+		}
+		return record.Interface(), nil
+	}
+
 	// Call Get(ctx, id) method
 	getMethod := modelClient.MethodByName("Get")
 	if !getMethod.IsValid() {
@@ -211,7 +279,7 @@ func (r *Registry) queryByID(ctx context.Context, modelName string, id uuid.UUID
 // genericCreate creates a new record using reflection
 func (r *Registry) genericCreate(ctx context.Context, modelName string, data map[string]interface{}) (interface{}, error) {
 	// Get the model client using reflection (e.g., r.client.User)
-	clientVal := reflect.ValueOf(r.client).Elem()
+	clientVal := reflect.ValueOf(r.clientFor(ctx)).Elem()
 	modelClient := clientVal.FieldByName(modelName)
 
 	if !modelClient.IsValid() {
@@ -259,7 +327,7 @@ func (r *Registry) genericCreate(ctx context.Context, modelName string, data map
 // genericUpdate updates a record using reflection
 func (r *Registry) genericUpdate(ctx context.Context, modelName string, id uuid.UUID, data map[string]interface{}) error {
 	// Get the model client using reflection (e.g., r.client.User)
-	clientVal := reflect.ValueOf(r.client).Elem()
+	clientVal := reflect.ValueOf(r.clientFor(ctx)).Elem()
 	modelClient := clientVal.FieldByName(modelName)
 
 	if !modelClient.IsValid() {
@@ -307,7 +375,7 @@ func (r *Registry) genericUpdate(ctx context.Context, modelName string, id uuid.
 // genericDelete deletes a record using reflection
 func (r *Registry) genericDelete(ctx context.Context, modelName string, id uuid.UUID) error {
 	// Get the model client using reflection (e.g., r.client.User)
-	clientVal := reflect.ValueOf(r.client).Elem()
+	clientVal := reflect.ValueOf(r.clientFor(ctx)).Elem()
 	modelClient := clientVal.FieldByName(modelName)
 
 	if !modelClient.IsValid() {
@@ -357,6 +425,13 @@ func setFieldsOnBuilder(builder interface{}, data map[string]interface{}) error
 			continue
 		}
 
+		if ids, ok := value.(edgeIDs); ok {
+			if err := setEdgeIDs(builderVal, fieldName, ids); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// Build the setter method name (e.g., "SetEmail" for "Email")
 		setterName := "Set" + fieldName
 		method := builderVal.MethodByName(setterName)
@@ -372,8 +447,150 @@ func setFieldsOnBuilder(builder interface{}, data map[string]interface{}) error
 
 		// Call the setter method
 		valueToSet := reflect.ValueOf(value)
+		if raw, ok := value.(jsonValue); ok {
+			// Decode JSON input into the field's type (e.g., map[string]interface{})
+			target := reflect.New(method.Type().In(0))
+			if err := json.Unmarshal([]byte(raw), target.Interface()); err != nil {
+				return fmt.Errorf("%s: %w", fieldName, err)
+			}
+			valueToSet = target.Elem()
+		}
+		if param := method.Type().In(0); valueToSet.Kind() == reflect.Ptr && !valueToSet.Type().AssignableTo(param) && valueToSet.Type().Elem().AssignableTo(param) {
+			// Pointer values (e.g., *utils.Point) also set non-pointer fields
+			valueToSet = valueToSet.Elem()
+		}
 		method.Call([]reflect.Value{valueToSet})
 	}
 
 	return nil
 }
+
+// applyWhere calls query.Where with a generic SQL predicate converted to the
+// model's predicate type (e.g., predicate.User)
+func applyWhere(query reflect.Value, pred func(*sql.Selector)) (reflect.Value, error) {
+	whereMethod := query.MethodByName("Where")
+	if !whereMethod.IsValid() {
+		return reflect.Value{}, fmt.Errorf("where method not found on %s", query.Type())
+	}
+
+	// Where(ps ...predicate.X): the variadic parameter is a slice of the predicate type
+	predType := whereMethod.Type().In(0).Elem()
+	predVal := reflect.ValueOf(pred)
+	if !predVal.Type().ConvertibleTo(predType) {
+		return reflect.Value{}, fmt.Errorf("cannot use SQL predicate as %s", predType)
+	}
+
+	out := whereMethod.Call([]reflect.Value{predVal.Convert(predType)})
+	if len(out) == 0 {
+		return reflect.Value{}, fmt.Errorf("where method returned no results on %s", query.Type())
+	}
+	return out[0], nil
+}
+
+// applyOrder calls query.Order with a raw SQL ordering converted to the model's OrderOption type
+func applyOrder(query reflect.Value, order func(*sql.Selector)) (reflect.Value, error) {
+	orderMethod := query.MethodByName("Order")
+	if !orderMethod.IsValid() {
+		return reflect.Value{}, fmt.Errorf("order method not found on %s", query.Type())
+	}
+
+	optType := orderMethod.Type().In(0).Elem()
+	optVal := reflect.ValueOf(order)
+	if !optVal.Type().ConvertibleTo(optType) {
+		return reflect.Value{}, fmt.Errorf("cannot use SQL ordering as %s", optType)
+	}
+
+	out := orderMethod.Call([]reflect.Value{optVal.Convert(optType)})
+	if len(out) == 0 {
+		return reflect.Value{}, fmt.Errorf("order method returned no results on %s", query.Type())
+	}
+	return out[0], nil
+}
+
+// listFilters builds a predicate per filter value. Strings match case-insensitive
+// substrings; bools, ints and relations match exactly; times match a date range
+// (see parseDateRange). Invalid values are skipped.
+func listFilters(fields []FieldConfig, filters map[string]string) []func(*sql.Selector) {
+	var preds []func(*sql.Selector)
+	for _, field := range fields {
+		value, ok := filters[field.Name]
+		if !ok || value == "" || !field.Filterable() {
+			continue
+		}
+		column := columnName(field.Name)
+		switch field.Type {
+		case FieldTypeBool:
+			if b, err := strconv.ParseBool(value); err == nil {
+				preds = append(preds, sql.FieldEQ(column, b))
+			}
+		case FieldTypeInt:
+			if i, err := strconv.Atoi(value); err == nil {
+				preds = append(preds, sql.FieldEQ(column, i))
+			}
+		case FieldTypeMoney:
+			if amount, err := utils.ParseMoney(value); err == nil {
+				preds = append(preds, sql.FieldEQ(column, amount))
+			}
+		case FieldTypeRelation, FieldTypeUUID:
+			if id, err := uuid.Parse(value); err == nil {
+				preds = append(preds, sql.FieldEQ(column, id))
+			}
+		case FieldTypeTime:
+			if from, to, ok := parseDateRange(value); ok {
+				if !from.IsZero() {
+					preds = append(preds, sql.FieldGTE(column, from))
+				}
+				if !to.IsZero() {
+					preds = append(preds, sql.FieldLT(column, to.AddDate(0, 0, 1)))
+				}
+			}
+		default:
+			preds = append(preds, sql.FieldContainsFold(column, value))
+		}
+	}
+	return preds
+}
+
+// dateRangeSep separates the ends of a time filter, e.g., "2026-01-01..2026-01-31"
+const dateRangeSep = ".."
+
+// parseDateRange parses a time filter value: "from..to" with either end
+// optional, or a single date matching that day. Dates are YYYY-MM-DD in UTC,
+// both ends inclusive; an open end is returned as the zero time.
+func parseDateRange(value string) (from, to time.Time, ok bool) {
+	start, end, isRange := strings.Cut(value, dateRangeSep)
+	if !isRange {
+		end = start
+	}
+	if start = strings.TrimSpace(start); start != "" {
+		if from, ok = parseDate(start); !ok {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	if end = strings.TrimSpace(end); end != "" {
+		if to, ok = parseDate(end); !ok {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	return from, to, !from.IsZero() || !to.IsZero()
+}
+
+func parseDate(s string) (time.Time, bool) {
+	t, err := time.Parse("2006-01-02", s)
+	return t, err == nil
+}
+
+// listOrder returns the ordering for sort ("Field" or "-Field"), or nil if the field isn't sortable
+func listOrder(fields []FieldConfig, sort string) func(*sql.Selector) {
+	name := strings.TrimPrefix(sort, "-")
+	for _, field := range fields {
+		if field.Name != name || !field.Sortable() {
+			continue
+		}
+		if strings.HasPrefix(sort, "-") {
+			return sql.OrderByField(columnName(name), sql.OrderDesc()).ToFunc()
+		}
+		return sql.OrderByField(columnName(name)).ToFunc()
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// BenchmarkRegistry measures the reflection the admin does per request: loading
+// a page of 50 posts and formatting their list columns, and creating a post
+// from form data
+func BenchmarkRegistry(b *testing.B) {
+	client := testdb.Open(b)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	config, err := registry.Get("post")
+	if err != nil {
+		b.Fatal(err)
+	}
+	ctx := context.Background()
+
+	author := client.User.Create().SetEmail("author@example.com").SetPasswordHash("x").SaveX(ctx)
+	builders := make([]*models.PostCreate, 50)
+	for i := range builders {
+		builders[i] = client.Post.Create().SetSubject(fmt.Sprintf("Post %d", i)).SetBody("Lorem ipsum dolor sit amet").SetAuthor(author)
+	}
+	client.Post.CreateBulk(builders...).SaveX(ctx)
+
+	b.Run("List", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			records, err := config.QueryList(ctx, ListOptions{Limit: 50, Sort: "-CreatedAt"})
+			if err != nil {
+				b.Fatalf("QueryList failed: %v", err)
+			}
+			for _, record := range records {
+				for _, name := range config.ListFields {
+					formatFieldForDisplay(record, name)
+				}
+			}
+		}
+	})
+
+	b.Run("Create", func(b *testing.B) {
+		asAuthor := middleware.WithUser(ctx, author) // The author of new posts
+		data := map[string]interface{}{"Subject": "Hello", "Body": "Lorem ipsum dolor sit amet"}
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := config.CreateFunc(asAuthor, data); err != nil {
+				b.Fatalf("CreateFunc failed: %v", err)
+			}
+		}
+	})
+}
@@ -95,11 +95,19 @@ func (r *Registry) SaveModelOrder(order []string) error {
 		return fmt.Errorf("failed to save model order: %w", err)
 	}
 
-	// Update in-memory order
-	r.modelKeys = make([]string, len(order))
-	for i, name := range order {
-		r.modelKeys[i] = strings.ToLower(name)
+	// Update in-memory order; models missing from order (e.g., hidden ones) keep their place at the end
+	keys := make([]string, 0, len(r.modelKeys))
+	listed := make(map[string]bool, len(order))
+	for _, name := range order {
+		keys = append(keys, strings.ToLower(name))
+		listed[strings.ToLower(name)] = true
 	}
+	for _, key := range r.modelKeys {
+		if !listed[key] {
+			keys = append(keys, key)
+		}
+	}
+	r.modelKeys = keys
 
 	return nil
 }
@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// relationChoice is one checkbox of a FieldTypeRelations field
+type relationChoice struct {
+	ID      string
+	Label   string
+	Checked bool
+}
+
+// RelationChoices renders the checkboxes of a FieldTypeRelations field: every
+// record of the related model, checked if it's linked to the ?id record (none
+// on the create form). The edit form loads them over htmx, like inlines.
+func (h *Handler) RelationChoices(w http.ResponseWriter, r *http.Request) {
+	config, err := h.Registry.Get(chi.URLParam(r, "model"))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Model not found")
+		return
+	}
+	field := config.Field(chi.URLParam(r, "field"))
+	if field == nil || field.Type != FieldTypeRelations {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Field not found")
+		return
+	}
+	related, err := h.Registry.Get(field.RelatedModel)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Related model not registered")
+		return
+	}
+
+	checked := make(map[string]bool)
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid ID")
+			return
+		}
+		record, err := config.QueryByID(r.Context(), id)
+		if err != nil {
+			h.Renderer.RenderError(w, r, http.StatusNotFound, config.Name+" not found")
+			return
+		}
+		if !h.authorizeRecord(w, r, config, record) {
+			return
+		}
+		query, err := relatedQuery(h.Registry.client, config.Name, field.Name, record)
+		if err == nil {
+			var ids reflect.Value
+			if ids, err = callWithErr(query, "IDs", r.Context()); err == nil {
+				for i := 0; i < ids.Len(); i++ {
+					checked[fmt.Sprint(ids.Index(i).Interface())] = true
+				}
+			}
+		}
+		if err != nil {
+			utils.Errorw("admin.relations_load_failed", "model", config.Name, "field", field.Name, "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load related records")
+			return
+		}
+	}
+
+	records, err := related.QueryAll(r.Context())
+	if err != nil {
+		utils.Errorw("admin.relations_load_failed", "model", config.Name, "field", field.Name, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load related records")
+		return
+	}
+	choices := make([]relationChoice, len(records))
+	for i, rec := range records {
+		id := getIDValue(rec)
+		choices[i] = relationChoice{ID: id, Label: recordLabel(related, rec), Checked: checked[id]}
+	}
+	sort.Slice(choices, func(i, j int) bool {
+		return strings.ToLower(choices[i].Label) < strings.ToLower(choices[j].Label)
+	})
+
+	h.Renderer.Render(w, r, "model_relations.partial.html", &TemplateData{
+		Data: map[string]interface{}{
+			"Field":   field,
+			"Related": related,
+			"Choices": choices,
+		},
+	})
+}
+
+// parseEdgeIDs reads the checked IDs of a FieldTypeRelations field, skipping
+// the blank marker input and anything that isn't a UUID
+func parseEdgeIDs(values []string) edgeIDs {
+	ids := edgeIDs{}
+	for _, v := range values {
+		if id, err := uuid.Parse(v); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// setEdgeIDs replaces the records linked through a to-many edge (e.g.,
+// "Groups") with ids: Clear<Edge> on updates, then Add<Edge singular>IDs
+func setEdgeIDs(builder reflect.Value, edge string, ids edgeIDs) error {
+	if clear := builder.MethodByName("Clear" + edge); clear.IsValid() {
+		clear.Call(nil)
+	}
+	// Ent names the adder after the singular edge (e.g., AddGroupIDs, AddCategoryIDs)
+	for _, singular := range []string{strings.TrimSuffix(edge, "ies") + "y", strings.TrimSuffix(edge, "es"), strings.TrimSuffix(edge, "s")} {
+		if add := builder.MethodByName("Add" + singular + "IDs"); add.IsValid() {
+			add.Call(idValues(ids))
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: no Add...IDs method for the edge on %s", edge, builder.Type())
+}
+
+// idValues converts ids into arguments for a variadic Add...IDs method
+func idValues(ids edgeIDs) []reflect.Value {
+	values := make([]reflect.Value, len(ids))
+	for i, id := range ids {
+		values[i] = reflect.ValueOf(id)
+	}
+	return values
+}
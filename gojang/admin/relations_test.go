@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// TestRelationChoices tests that every group is listed and the user's groups are checked
+func TestRelationChoices(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := NewHandler(registry, renderer, client)
+	ctx := context.Background()
+
+	editors := client.Group.Create().SetName("Editors").SaveX(ctx)
+	client.Group.Create().SetName("Moderators").SaveX(ctx)
+	u := client.User.Create().SetEmail("member@example.com").SetPasswordHash("x").AddGroups(editors).SaveX(ctx)
+
+	req := httptest.NewRequest(http.MethodGet, "/?id="+u.ID.String(), nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("model", "user")
+	rctx.URLParams.Add("field", "Groups")
+	req = req.WithContext(middleware.WithUser(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), &models.User{IsStaff: true}))
+
+	w := httptest.NewRecorder()
+	handler.RelationChoices(w, req)
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.Contains(body, "Moderators") {
+		t.Fatalf("Expected every group, got %d: %s", w.Code, body)
+	}
+	if !strings.Contains(body, `value="`+editors.ID.String()+`" checked`) {
+		t.Errorf("Expected Editors to be checked: %s", body)
+	}
+	if strings.Count(body, "checked") != 1 {
+		t.Errorf("Expected only Editors to be checked: %s", body)
+	}
+}
+
+// TestRelations_Save tests that saving a relations field replaces the edge's links
+func TestRelations_Save(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	config, _ := registry.Get("user")
+	ctx := middleware.WithUser(context.Background(), &models.User{IsStaff: true})
+
+	editors := client.Group.Create().SetName("Editors").SaveX(ctx)
+	moderators := client.Group.Create().SetName("Moderators").SaveX(ctx)
+
+	created, err := config.CreateFunc(ctx, map[string]interface{}{
+		"Email":        "member@example.com",
+		"PasswordHash": "x",
+		"Groups":       parseEdgeIDs([]string{"", editors.ID.String()}),
+	})
+	if err != nil {
+		t.Fatalf("CreateFunc failed: %v", err)
+	}
+	u := created.(*models.User)
+	if ids := client.User.QueryGroups(u).IDsX(ctx); len(ids) != 1 || ids[0] != editors.ID {
+		t.Fatalf("Expected the Editors group, got %v", ids)
+	}
+
+	if err := config.UpdateFunc(ctx, u.ID, map[string]interface{}{"Groups": parseEdgeIDs([]string{"", moderators.ID.String()})}); err != nil {
+		t.Fatalf("UpdateFunc failed: %v", err)
+	}
+	if ids := client.User.QueryGroups(u).IDsX(ctx); len(ids) != 1 || ids[0] != moderators.ID {
+		t.Errorf("Expected only the Moderators group, got %v", ids)
+	}
+
+	// Just the blank marker: every box unchecked
+	if err := config.UpdateFunc(ctx, u.ID, map[string]interface{}{"Groups": parseEdgeIDs([]string{""})}); err != nil {
+		t.Fatalf("UpdateFunc failed: %v", err)
+	}
+	if n := client.User.QueryGroups(u).CountX(ctx); n != 0 {
+		t.Errorf("Expected no groups, got %d", n)
+	}
+	if n := client.Group.Query().CountX(ctx); n != 2 {
+		t.Errorf("Expected the groups themselves to remain, got %d", n)
+	}
+}
+
+// TestGroupValidate tests that group permissions must be known permissions
+func TestGroupValidate(t *testing.T) {
+	registry := NewRegistry(nil)
+	RegisterModels(registry)
+	config, _ := registry.Get("group")
+
+	if errors := config.Validate(map[string]interface{}{"Permissions": jsonValue(`["manage_users"]`)}); len(errors) != 0 {
+		t.Errorf("Expected a known permission to pass, got %v", errors)
+	}
+	if errors := config.Validate(map[string]interface{}{"Permissions": jsonValue(`["manage_user"]`)}); errors["Permissions"] == "" {
+		t.Error("Expected an unknown permission to be rejected")
+	}
+}
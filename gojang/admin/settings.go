@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/retention"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// SettingsIndex shows the admin settings: how long each table keeps its rows
+// (see Handler.Retention) and the audit log export
+func (h *Handler) SettingsIndex(w http.ResponseWriter, r *http.Request) {
+	h.renderSettings(w, r, "settings_index.html", "", "")
+}
+
+// SaveRetention saves the "days.<policy>" form values as retention overrides
+func (h *Handler) SaveRetention(w http.ResponseWriter, r *http.Request) {
+	if h.Retention == nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "No retention policies are registered")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	policies, err := h.Retention.Policies(r.Context())
+	if err != nil {
+		utils.Errorw("admin.retention_list_failed", "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load retention policies")
+		return
+	}
+
+	// Check every value before saving any
+	changed := make(map[string]int)
+	for _, p := range policies {
+		value := strings.TrimSpace(r.PostForm.Get("days." + p.Name))
+		if value == "" {
+			continue
+		}
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 || days > retention.MaxDays {
+			h.renderSettings(w, r, "settings_retention.partial.html", fmt.Sprintf("%s: days must be a number from 0 to %d", p.Label, retention.MaxDays), "error")
+			return
+		}
+		if days != p.Days {
+			changed[p.Name] = days
+		}
+	}
+
+	user := middleware.GetUser(r.Context())
+	for name, days := range changed {
+		if err := h.Retention.SetDays(r.Context(), name, days); err != nil {
+			utils.Errorw("admin.retention_save_failed", "policy", name, "error", err)
+			h.renderSettings(w, r, "settings_retention.partial.html", "Failed to save retention settings", "error")
+			return
+		}
+		utils.Infow("admin.retention_changed", "policy", name, "days", days, "user_id", user.ID)
+	}
+	h.renderSettings(w, r, "settings_retention.partial.html", "Retention settings saved", "success")
+}
+
+// RunRetention prunes every policy now instead of at the next scheduled run
+func (h *Handler) RunRetention(w http.ResponseWriter, r *http.Request) {
+	if h.Retention == nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "No retention policies are registered")
+		return
+	}
+	if err := h.Retention.Run(r.Context()); err != nil {
+		utils.Errorw("admin.retention_run_failed", "error", err)
+		h.renderSettings(w, r, "settings_retention.partial.html", fmt.Sprintf("Pruning failed: %v", err), "error")
+		return
+	}
+	h.renderSettings(w, r, "settings_retention.partial.html", "Old rows pruned", "success")
+}
+
+// renderSettings renders the settings page or one of its sections
+func (h *Handler) renderSettings(w http.ResponseWriter, r *http.Request, tmpl, flash, flashType string) {
+	var policies []retention.Status
+	if h.Retention != nil {
+		var err error
+		if policies, err = h.Retention.Policies(r.Context()); err != nil {
+			utils.Errorw("admin.retention_list_failed", "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load retention policies")
+			return
+		}
+	}
+
+	user := middleware.GetUser(r.Context())
+	exportFrom, exportTo := auditExportDefaults()
+	h.Renderer.Render(w, r, tmpl, &TemplateData{
+		Title:     "Settings",
+		Flash:     flash,
+		FlashType: flashType,
+		Data: map[string]interface{}{
+			"Policies": policies,
+			"MaxDays":  retention.MaxDays,
+			"CanEdit":  user != nil && user.IsSuperuser,
+
+			"ExportEnabled": h.AuditExport.Enabled(),
+			"ExportFrom":    exportFrom,
+			"ExportTo":      exportTo,
+		},
+	})
+}
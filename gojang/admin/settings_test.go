@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/retention"
+)
+
+// TestSettings_Retention tests listing, changing and running retention policies
+func TestSettings_Retention(t *testing.T) {
+	t.Chdir("../..") // Admin templates are loaded relative to the repository root
+	renderer, err := NewAdminRenderer(false)
+	if err != nil {
+		t.Fatalf("NewAdminRenderer failed: %v", err)
+	}
+
+	client := testdb.Open(t)
+	handler := NewHandler(NewRegistry(client), renderer, client)
+	ctx := context.Background()
+	admin := client.User.Create().SetEmail("root@example.com").SetPasswordHash("x").SetIsStaff(true).SetIsSuperuser(true).SaveX(ctx)
+	staff := client.User.Create().SetEmail("staff@example.com").SetPasswordHash("x").SetIsStaff(true).SaveX(ctx)
+
+	send := func(h http.HandlerFunc, method string, form url.Values, superuser bool) string {
+		req := httptest.NewRequest(method, "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		user := staff
+		if superuser {
+			user = admin
+		}
+		req = req.WithContext(middleware.WithUser(req.Context(), user))
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w.Body.String()
+	}
+
+	if body := send(handler.SettingsIndex, http.MethodGet, nil, true); !strings.Contains(body, "No retention policies") {
+		t.Errorf("Expected no policies without a registry, got %s", body)
+	}
+
+	policies := retention.New(client)
+	policies.Register(retention.AdminActions(client, 30))
+	handler.Retention = policies
+
+	body := send(handler.SettingsIndex, http.MethodGet, nil, false)
+	if !strings.Contains(body, "Admin activity log") || strings.Contains(body, `name="days.admin_actions"`) {
+		t.Errorf("Expected staff to see the policy read-only, got %s", body)
+	}
+	if body := send(handler.SettingsIndex, http.MethodGet, nil, true); !strings.Contains(body, `name="days.admin_actions" value="30"`) {
+		t.Errorf("Expected superusers to get a days input, got %s", body)
+	}
+
+	body = send(handler.SaveRetention, http.MethodPost, url.Values{"days.admin_actions": {"-5"}}, true)
+	if !strings.Contains(body, "days must be a number") {
+		t.Errorf("Expected an error for negative days, got %s", body)
+	}
+	body = send(handler.SaveRetention, http.MethodPost, url.Values{"days.admin_actions": {"7"}}, true)
+	if !strings.Contains(body, "Retention settings saved") || !strings.Contains(body, `value="7"`) || !strings.Contains(body, "(default 30)") {
+		t.Errorf("Expected the days to be saved, got %s", body)
+	}
+
+	client.AdminAction.Create().
+		SetAction("update").SetModel("Post").SetRecordID("1").SetRecordLabel("Hello").
+		SetUserID(admin.ID).SetUserEmail(admin.Email).
+		SetCreatedAt(time.Now().AddDate(0, 0, -10)).
+		SaveX(ctx)
+	body = send(handler.RunRetention, http.MethodPost, nil, true)
+	if !strings.Contains(body, "Old rows pruned") || !strings.Contains(body, "1 deleted") {
+		t.Errorf("Expected the old action to be pruned, got %s", body)
+	}
+	if n := client.AdminAction.Query().CountX(ctx); n != 0 {
+		t.Errorf("Expected no admin actions left, got %d", n)
+	}
+}
@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// SudoPrompt asks for the user's password before a destructive action (see
+// middleware.Sudo). ?next= is the admin page to return to.
+func (h *Handler) SudoPrompt(w http.ResponseWriter, r *http.Request) {
+	next := sudoNext(r.URL.Query().Get("next"))
+	if h.Sudo == nil {
+		http.Redirect(w, r, next, http.StatusSeeOther)
+		return
+	}
+	h.renderSudo(w, r, next, "")
+}
+
+// SudoConfirm checks the password and starts sudo mode
+func (h *Handler) SudoConfirm(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+	next := sudoNext(r.Form.Get("next"))
+	if h.Sudo == nil {
+		http.Redirect(w, r, next, http.StatusSeeOther)
+		return
+	}
+
+	user := middleware.GetUser(r.Context())
+	ok, err := utils.CheckPassword(user.PasswordHash, r.Form.Get("password"))
+	if err != nil || !ok {
+		utils.Warnw("admin.sudo_failed", "user_id", user.ID)
+		middleware.LogPermissionDenied(r, "sudo", "admin")
+		w.WriteHeader(http.StatusUnauthorized)
+		h.renderSudo(w, r, next, "Incorrect password")
+		return
+	}
+
+	h.Sudo.Grant(r.Context(), user.ID)
+	utils.Infow("admin.sudo_granted", "user_id", user.ID, "window", h.Sudo.Window)
+	http.Redirect(w, r, next, http.StatusSeeOther)
+}
+
+func (h *Handler) renderSudo(w http.ResponseWriter, r *http.Request, next, errMsg string) {
+	h.Renderer.Render(w, r, "sudo.html", &TemplateData{
+		Title: "Confirm password",
+		Data: map[string]interface{}{
+			"Next":    next,
+			"Minutes": int(h.Sudo.Window.Minutes()),
+			"Error":   errMsg,
+		},
+	})
+}
+
+// sudoNext returns next if it's an admin page, so the prompt can't redirect elsewhere
+func sudoNext(next string) string {
+	if next == "/admin" || strings.HasPrefix(next, "/admin/") || strings.HasPrefix(next, "/admin?") {
+		return next
+	}
+	return "/admin"
+}
+
+// changesSudoFields reports whether data changes one of config's SudoFields
+// on existing (nil when creating, where any non-empty value counts)
+func (h *Handler) changesSudoFields(ctx context.Context, config *ModelConfig, existing interface{}, data map[string]interface{}) bool {
+	for _, name := range config.SudoFields {
+		value, ok := data[name]
+		if !ok {
+			continue
+		}
+		var current interface{}
+		switch v := value.(type) {
+		case edgeIDs:
+			ids := map[string]bool{}
+			if existing != nil {
+				query, err := relatedQuery(h.Registry.client, config.Name, name, existing)
+				if err != nil {
+					return true
+				}
+				linked, err := callWithErr(query, "IDs", ctx)
+				if err != nil {
+					return true
+				}
+				for i := 0; i < linked.Len(); i++ {
+					ids[fmt.Sprint(linked.Index(i).Interface())] = true
+				}
+			}
+			if len(ids) != len(v) {
+				return true
+			}
+			for _, id := range v {
+				if !ids[id.String()] {
+					return true
+				}
+			}
+			continue
+		case jsonValue:
+			// Compare the decoded documents, so formatting doesn't count
+			var submitted interface{}
+			if json.Unmarshal([]byte(v), &submitted) != nil {
+				return true
+			}
+			value = submitted
+			if existing != nil {
+				if field, _, ok := lookupField(existing, name); ok {
+					raw, _ := json.Marshal(field.Interface())
+					json.Unmarshal(raw, &current)
+				}
+			}
+			if isEmptyValue(value) && isEmptyValue(current) {
+				continue
+			}
+		default:
+			if existing == nil {
+				if !isEmptyValue(value) {
+					return true
+				}
+				continue
+			}
+			if field, _, ok := lookupField(existing, name); ok {
+				current = field.Interface()
+			}
+		}
+		if !reflect.DeepEqual(value, current) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue reports whether v is nil, false, zero or an empty list or object
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+)
+
+func TestSudoNext(t *testing.T) {
+	tests := map[string]string{
+		"/admin/user?page=2":   "/admin/user?page=2",
+		"/admin":               "/admin",
+		"/administrator":       "/admin",
+		"https://evil.example": "/admin",
+		"//evil.example/admin": "/admin",
+		"":                     "/admin",
+	}
+	for next, want := range tests {
+		if got := sudoNext(next); got != want {
+			t.Errorf("sudoNext(%q) = %q, want %q", next, got, want)
+		}
+	}
+}
+
+// TestChangesSudoFields tests that only real changes to SudoFields need sudo mode
+func TestChangesSudoFields(t *testing.T) {
+	client := testdb.Open(t)
+	registry := NewRegistry(client)
+	RegisterModels(registry)
+	handler := &Handler{Registry: registry}
+	ctx := context.Background()
+	users, _ := registry.Get("user")
+	groups, _ := registry.Get("group")
+
+	editors := client.Group.Create().SetName("Editors").SetPermissions([]string{"edit_any_post"}).SaveX(ctx)
+	member := client.User.Create().SetEmail("member@example.com").SetPasswordHash("x").AddGroups(editors).SaveX(ctx)
+
+	tests := []struct {
+		name     string
+		config   *ModelConfig
+		existing interface{}
+		data     map[string]interface{}
+		want     bool
+	}{
+		{"unrelated field", users, member, map[string]interface{}{"Email": "new@example.com"}, false},
+		{"same staff flag", users, member, map[string]interface{}{"IsStaff": false}, false},
+		{"made staff", users, member, map[string]interface{}{"IsStaff": true}, true},
+		{"same groups", users, member, map[string]interface{}{"Groups": edgeIDs{editors.ID}}, false},
+		{"removed from groups", users, member, map[string]interface{}{"Groups": edgeIDs{}}, true},
+		{"created without groups", users, nil, map[string]interface{}{"Groups": edgeIDs{}, "IsStaff": false}, false},
+		{"created as superuser", users, nil, map[string]interface{}{"IsSuperuser": true}, true},
+		{"reformatted permissions", groups, editors, map[string]interface{}{"Permissions": jsonValue(`[ "edit_any_post" ]`)}, false},
+		{"added a permission", groups, editors, map[string]interface{}{"Permissions": jsonValue(`["edit_any_post","manage_users"]`)}, true},
+		{"created without permissions", groups, nil, map[string]interface{}{"Permissions": jsonValue(`[]`)}, false},
+	}
+	for _, tt := range tests {
+		if got := handler.changesSudoFields(ctx, tt.config, tt.existing, tt.data); got != tt.want {
+			t.Errorf("%s: changesSudoFields = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/i18n"
+)
+
+// parseTranslated collects the inputs of a FieldTypeTranslated field, one per
+// locale (e.g., "Title.fr"). Blank translations are left out, so pages fall
+// back to another locale instead of showing nothing.
+func parseTranslated(form url.Values, name string) i18n.Text {
+	text := i18n.Text{}
+	for _, locale := range i18n.Locales() {
+		if v := strings.TrimSpace(form.Get(name + "." + locale)); v != "" {
+			text[locale] = v
+		}
+	}
+	return text
+}
+
+// translationField returns one locale's value of a translated field, without
+// fallbacks, for its input on the edit form
+func translationField(obj interface{}, fieldName, locale string) string {
+	field, _, ok := lookupField(obj, fieldName)
+	if !ok {
+		return ""
+	}
+	text, _ := field.Interface().(i18n.Text)
+	return text[locale]
+}
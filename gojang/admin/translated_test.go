@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/i18n"
+)
+
+// page is a model with a translatable title
+type page struct {
+	Title i18n.Text
+}
+
+func TestTranslatedFields(t *testing.T) {
+	if err := i18n.SetLocales([]string{"en", "fr", "de"}); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLocales([]string{"en"})
+
+	if got := detectFieldType(reflect.TypeOf(i18n.Text{}), "Title", nil); got != FieldTypeTranslated {
+		t.Errorf("detectFieldType = %q, want %q", got, FieldTypeTranslated)
+	}
+
+	// Blank translations are dropped, so they fall back to the default
+	form := url.Values{"Title.en": {" Hello "}, "Title.fr": {"Bonjour"}, "Title.de": {"  "}, "Title.es": {"Hola"}}
+	text := parseTranslated(form, "Title")
+	if !reflect.DeepEqual(text, i18n.Text{"en": "Hello", "fr": "Bonjour"}) {
+		t.Errorf("parseTranslated = %v", text)
+	}
+
+	p := &page{Title: text}
+	if got := translationField(p, "Title", "fr"); got != "Bonjour" {
+		t.Errorf("translationField(fr) = %q", got)
+	}
+	if got := translationField(p, "Title", "de"); got != "" {
+		t.Errorf("Expected no fallback on the edit form, got %q", got)
+	}
+	if got := formatFieldForDisplay(p, "Title"); got != "Hello" {
+		t.Errorf("Expected the list to show the default locale, got %q", got)
+	}
+}
+
+// TestValidateFields_Translated tests that the default locale is required
+func TestValidateFields_Translated(t *testing.T) {
+	h := &Handler{}
+	config := &ModelConfig{Fields: []FieldConfig{{Name: "Title", Label: "Title", Type: FieldTypeTranslated, Required: true}}}
+
+	errors := h.validateFields(config, map[string]interface{}{"Title": i18n.Text{"fr": "Bonjour"}}, true)
+	if errors["Title"] != "Title is required in en" {
+		t.Errorf("Expected the default locale to be required, got %v", errors)
+	}
+	errors = h.validateFields(config, map[string]interface{}{"Title": i18n.Text{"en": "Hello"}}, true)
+	if len(errors) != 0 {
+		t.Errorf("Unexpected errors %v", errors)
+	}
+}
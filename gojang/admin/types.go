@@ -2,28 +2,56 @@ package admin
 
 import (
 	"context"
+	"html/template"
 
+	"github.com/gojangframework/gojang/gojang/storage"
 	"github.com/google/uuid"
 )
 
 // ModelConfig defines how a model should be displayed and managed in the admin panel
 type ModelConfig struct {
-	Name           string        // Display name (e.g., "User", "Post")
-	NamePlural     string        // Plural name (e.g., "Users", "Posts")
-	Icon           string        // Icon for navigation
-	Fields         []FieldConfig // Auto-discovered fields
-	ListFields     []string      // Fields to show in list view
-	HiddenFields   []string      // Fields to hide
-	ReadonlyFields []string      // Fields that can't be edited
+	Name           string         // Display name (e.g., "User", "Post")
+	NamePlural     string         // Plural name (e.g., "Users", "Posts")
+	Icon           string         // Icon for navigation
+	Fields         []FieldConfig  // Auto-discovered fields
+	ListFields     []string       // Fields to show in list view
+	HiddenFields   []string       // Fields to hide
+	ReadonlyFields []string       // Fields that can't be edited
+	OnDelete       DeleteBehavior // What happens to related records on delete
+	Inlines        []InlineConfig // Child models edited on this model's edit form
+	SearchFields   []string       // String fields matched by the autocomplete endpoint
+	LabelField     string         // Field used as the autocomplete label
+	OwnsRecord     OwnershipRule  // Restricts non-superuser staff to their own records (nil = no restriction)
+	Actions        []AdminAction  // Custom actions run on selected records
+	TimeFormat     string         // Layout of time columns in the list view
+	Fieldsets      []Fieldset     // Edit form field groups (see FormSections)
+	Validate       ValidateHook   // Normalizes data and reports field errors before saving
+	SudoFields     []string       // Changing these (e.g., IsSuperuser) requires sudo mode
+	Moderation     *Moderation    // Puts records pending review in the moderation queue (nil = not moderated)
+	Hidden         bool           // Left out of navigation (see Visible)
+	Enabled        func() bool    // Shown in navigation only while this returns true (nil = always)
 
 	// CRUD operations
 	QueryAll          func(ctx context.Context) ([]interface{}, error)
 	QueryAllPaginated func(ctx context.Context, limit, offset int) ([]interface{}, error)
 	CountAll          func(ctx context.Context) (int, error)
+	QueryList         func(ctx context.Context, opts ListOptions) ([]interface{}, error)
+	CountList         func(ctx context.Context, filters map[string]string) (int, error)
 	QueryByID         func(ctx context.Context, id uuid.UUID) (interface{}, error)
 	CreateFunc        func(ctx context.Context, data map[string]interface{}) (interface{}, error)
 	UpdateFunc        func(ctx context.Context, id uuid.UUID, data map[string]interface{}) error
 	DeleteFunc        func(ctx context.Context, id uuid.UUID) error
+	DeletePreview     func(ctx context.Context, id uuid.UUID) ([]RelatedCount, error)
+	Search            func(ctx context.Context, q string, limit int) ([]interface{}, error)
+	IsTaken           func(ctx context.Context, field string, value interface{}, exclude uuid.UUID) (bool, error)
+}
+
+// ListOptions narrows and orders the records shown in a model's list view
+type ListOptions struct {
+	Limit   int
+	Offset  int
+	Sort    string            // Field name; a "-" prefix sorts descending (e.g., "-CreatedAt")
+	Filters map[string]string // Field name -> value (substring match for strings, exact otherwise)
 }
 
 // FieldConfig defines configuration for a single field
@@ -34,16 +62,178 @@ type FieldConfig struct {
 	Required  bool      // Is field required?
 	Readonly  bool      // Is field readonly?
 	Sensitive bool      // Is field sensitive (e.g., password)?
+	Unique    bool      // No two records may share a value (checked before saving)
 	Hidden    bool      // Hide from forms
 	Help      string    // Help text shown below field
+
+	// Form visibility: a field with neither flag set is on both forms
+	ShowOnCreate   bool // Only on the create form (e.g., an initial password)
+	ShowOnEdit     bool // Only on the edit form (e.g., a computed value)
+	OptionalOnEdit bool // Required applies to the create form only; blank on edit keeps the current value
+
+	// Relation fields (FieldTypeRelation) pick a record of another model via autocomplete.
+	// Relations fields (FieldTypeRelations) check any number of them; their Name is
+	// the to-many edge (e.g., "Groups") and Edge is left empty.
+	RelatedModel string // Registered model to search (e.g., "User")
+	Edge         string // Edge holding the current value (e.g., "Author")
+
+	// Computed fields (FieldTypeComputed) derive a read-only value from the loaded record
+	Compute func(record interface{}) interface{}
+
+	// Upload fields (FieldTypeFile, FieldTypeImage): size and sniffed type limits
+	Upload storage.Rules
+}
+
+// OnForm reports whether the create (isEdit false) or edit form includes the field
+func (f FieldConfig) OnForm(isEdit bool) bool {
+	if isEdit {
+		return f.ShowOnEdit || !f.ShowOnCreate
+	}
+	return f.ShowOnCreate || !f.ShowOnEdit
+}
+
+// RequiredOnForm reports whether the field must be filled in on the create or edit form
+func (f FieldConfig) RequiredOnForm(isEdit bool) bool {
+	return f.Required && f.OnForm(isEdit) && !(isEdit && f.OptionalOnEdit)
+}
+
+// Filterable reports whether list views can filter on the field
+func (f FieldConfig) Filterable() bool {
+	if f.Hidden || f.Sensitive {
+		return false
+	}
+	switch f.Type {
+	case FieldTypeString, FieldTypeText, FieldTypeRichText, FieldTypeMarkdown, FieldTypeEmail, FieldTypeColor, FieldTypeURL, FieldTypePhone, FieldTypeBool, FieldTypeInt, FieldTypeMoney, FieldTypeRelation, FieldTypeUUID, FieldTypeTime:
+		return true
+	}
+	return false
+}
+
+// Sortable reports whether list views can order by the field
+func (f FieldConfig) Sortable() bool {
+	return !f.Hidden && !f.Sensitive && f.Type != FieldTypePassword && f.Type != FieldTypeText && f.Type != FieldTypeRichText && f.Type != FieldTypeMarkdown && f.Type != FieldTypeJSON && f.Type != FieldTypeGeo && f.Type != FieldTypeComputed && f.Type != FieldTypeRelations && f.Type != FieldTypeTranslated
+}
+
+// ComputedValue returns a computed field's value for record, formatted for
+// display. A template.HTML value (e.g., a badge or a chart) is shown as is;
+// anything else is escaped.
+func (f FieldConfig) ComputedValue(record interface{}) template.HTML {
+	if f.Compute == nil || record == nil {
+		return "-"
+	}
+	val := f.Compute(record)
+	if val == nil {
+		return "-"
+	}
+	if h, ok := val.(template.HTML); ok {
+		return h
+	}
+	return template.HTML(template.HTMLEscapeString(formatDisplayValue(val)))
+}
+
+// IsUpload reports whether the field is filled in by uploading a file
+func (f FieldConfig) IsUpload() bool {
+	return f.Type == FieldTypeFile || f.Type == FieldTypeImage
+}
+
+// UploadRules returns the limits on an upload field. Image fields accept the
+// formats gojang/images can resize unless Upload lists Types.
+func (f FieldConfig) UploadRules() storage.Rules {
+	rules := f.Upload
+	if f.Type == FieldTypeImage && len(rules.Types) == 0 {
+		rules.Types = imageTypes
+	}
+	return rules
 }
 
+// Field returns the configuration of a named field, or nil if the model has no such field
+func (c *ModelConfig) Field(name string) *FieldConfig {
+	for i := range c.Fields {
+		if c.Fields[i].Name == name {
+			return &c.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Visible reports whether the model is listed in navigation. Hidden and
+// disabled models are still served at their URLs, so they can be tested
+// before launch.
+func (c *ModelConfig) Visible() bool {
+	return !c.Hidden && (c.Enabled == nil || c.Enabled())
+}
+
+// HasFileFields reports whether the edit form uploads files (and so must be sent as multipart)
+func (c *ModelConfig) HasFileFields() bool {
+	for _, f := range c.Fields {
+		if f.IsUpload() && !f.Readonly && !f.Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterFields returns the list columns that can be filtered on
+func (c *ModelConfig) FilterFields() []FieldConfig {
+	var fields []FieldConfig
+	for _, name := range c.ListFields {
+		if f := c.Field(name); f != nil && f.Filterable() {
+			fields = append(fields, *f)
+		}
+	}
+	return fields
+}
+
+// Sortable reports whether list views can order by the named field
+func (c *ModelConfig) Sortable(name string) bool {
+	f := c.Field(name)
+	return f != nil && f.Sortable()
+}
+
+// InlineConfig shows a child model as an editable table on the parent's edit form
+type InlineConfig struct {
+	Model   string   // Registered child model name (e.g., "Post")
+	Edge    string   // To-many edge on the parent (e.g., "Posts")
+	FKField string   // Child field that stores the parent ID (e.g., "AuthorID")
+	Fields  []string // Child fields shown as columns (defaults to the child's ListFields)
+	Label   string   // Section heading (defaults to the child's NamePlural)
+}
+
+// ComputedField is a virtual, read-only field derived from a loaded record (e.g., an
+// "Age" from a birthdate). It can be listed in ListFields and is shown on the edit
+// form, but is never submitted, saved, sorted or filtered on.
+type ComputedField struct {
+	Name    string                               // Field name used in ListFields (e.g., "WordCount")
+	Label   string                               // Display label (defaults to Name split into words)
+	Compute func(record interface{}) interface{} // Receives the model pointer (e.g., *models.Post)
+}
+
+// jsonValue is submitted JSON text for a FieldTypeJSON field, decoded when it is set
+// on the Ent builder. It prints as the text the user entered when a form is re-shown.
+type jsonValue string
+
+// edgeIDs are the records checked on a FieldTypeRelations field. They replace the
+// edge's current links when set on the Ent builder (see setEdgeIDs).
+type edgeIDs []uuid.UUID
+
 // FieldType represents the type of field
 type FieldType string
 
 const (
 	FieldTypeString   FieldType = "string"
 	FieldTypeText     FieldType = "text"
+	FieldTypeRichText FieldType = "richtext" // HTML from the rich text editor, sanitized on save
+	FieldTypeMarkdown FieldType = "markdown" // Markdown source, edited with a live preview
+	FieldTypeJSON     FieldType = "json"     // Ent JSON field, edited as a JSON document
+	FieldTypeUUID     FieldType = "uuid"     // UUID column that isn't an edge (e.g., an external reference)
+	FieldTypeMoney    FieldType = "money"    // Integer minor units (e.g., cents), shown in the CURRENCY setting
+	FieldTypeGeo      FieldType = "geo"      // utils.Point location, picked on a map
+	FieldTypeColor    FieldType = "color"    // "#rrggbb" string, shown as a swatch
+	FieldTypeURL      FieldType = "url"      // http(s) URL string, shown as a link
+	FieldTypePhone    FieldType = "phone"    // Phone number string, saved in E.164 (e.g., "+14155552671")
+	FieldTypeFile     FieldType = "file"     // Upload saved to storage.Default(); the string field holds its storage name
+	FieldTypeImage    FieldType = "image"    // JPEG, PNG or GIF upload (like FieldTypeFile), shown as a thumbnail
+	FieldTypeComputed FieldType = "computed" // Virtual read-only value from FieldConfig.Compute, never in the database
 	FieldTypeInt      FieldType = "int"
 	FieldTypeFloat    FieldType = "float"
 	FieldTypeBool     FieldType = "bool"
@@ -51,8 +241,18 @@ const (
 	FieldTypePassword FieldType = "password"
 	FieldTypeEmail    FieldType = "email"
 	FieldTypeSelect   FieldType = "select"
+	FieldTypeRelation FieldType = "relation"
+
+	// FieldTypeRelations is a to-many edge (e.g., a user's groups), picked with checkboxes
+	FieldTypeRelations FieldType = "relations"
+
+	// FieldTypeTranslated is an i18n.Text JSON field, edited with a tab per locale
+	FieldTypeTranslated FieldType = "translated"
 )
 
+// DefaultTimeFormat is the list view layout of time fields unless a model sets TimeFormat
+const DefaultTimeFormat = "2006-01-02 15:04:05"
+
 // AdminOverrides allows customizing auto-discovered models
 type AdminOverrides struct {
 	Icon           string
@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// DefaultUndoWindow is how long an admin delete stays pending before it runs
+const DefaultUndoWindow = 10 * time.Second
+
+// pendingDelete is a delete waiting for its undo window to expire
+type pendingDelete struct {
+	model   string
+	id      uuid.UUID
+	timer   *time.Timer
+	run     func()
+	started bool // Claimed by its timer, flush or cancel; guarded by undoQueue.mu
+}
+
+// undoQueue holds deletes that can still be canceled
+type undoQueue struct {
+	mu      sync.Mutex
+	pending map[string]*pendingDelete // token -> pending delete
+	running sync.WaitGroup            // Deletes claimed but not finished, for flush to wait on
+}
+
+func newUndoQueue() *undoQueue {
+	return &undoQueue{pending: make(map[string]*pendingDelete)}
+}
+
+// schedule queues run to execute after window and returns the undo token
+func (q *undoQueue) schedule(model string, id uuid.UUID, window time.Duration, run func()) string {
+	token := uuid.NewString()
+	p := &pendingDelete{model: model, id: id, run: run}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	p.timer = time.AfterFunc(window, func() {
+		if q.claim(token, p) {
+			defer q.running.Done()
+			run()
+		}
+	})
+	q.pending[token] = p
+	return token
+}
+
+// claim marks p as started unless its timer, flush or cancel already did, so
+// exactly one of them handles each delete. The caller must run it and call
+// q.running.Done.
+func (q *undoQueue) claim(token string, p *pendingDelete) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if p.started {
+		return false
+	}
+	p.started = true
+	delete(q.pending, token)
+	q.running.Add(1)
+	return true
+}
+
+// cancel stops a pending delete of model. It returns false if the delete already
+// started or the token belongs to a different model.
+func (q *undoQueue) cancel(model, token string) (*pendingDelete, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	p, ok := q.pending[token]
+	if !ok || p.started || p.model != model {
+		return nil, false
+	}
+	p.started = true
+	delete(q.pending, token)
+	p.timer.Stop()
+	return p, true
+}
+
+// isPending reports whether a record of model is waiting to be deleted
+func (q *undoQueue) isPending(model string, id uuid.UUID) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, p := range q.pending {
+		if p.model == model && p.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// flush runs every pending delete immediately and waits for those already
+// running (used on shutdown, before the database closes)
+func (q *undoQueue) flush() {
+	q.mu.Lock()
+	var claimed []*pendingDelete
+	for token, p := range q.pending {
+		if !p.started {
+			p.started = true
+			q.running.Add(1)
+			claimed = append(claimed, p)
+		}
+		delete(q.pending, token)
+	}
+	q.mu.Unlock()
+
+	for _, p := range claimed {
+		p.timer.Stop()
+		p.run()
+		q.running.Done()
+	}
+	q.running.Wait()
+}
+
+// queueDelete schedules config.DeleteFunc for id after the handler's undo window;
+// deleted (optional) runs once the record is gone
+func (h *Handler) queueDelete(config *ModelConfig, id uuid.UUID, deleted func(ctx context.Context)) string {
+	return h.undo.schedule(config.Name, id, h.UndoWindow, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := config.DeleteFunc(ctx, id); err != nil {
+			utils.Errorw("admin.delete_failed", "model", config.Name, "id", id, "error", err)
+			return
+		}
+		utils.Infow("admin.deleted", "model", config.Name, "id", id)
+		if deleted != nil {
+			deleted(ctx)
+		}
+	})
+}
+
+// withoutPending filters records that are queued for deletion out of a list
+func (h *Handler) withoutPending(config *ModelConfig, records []interface{}) []interface{} {
+	visible := records[:0:0]
+	for _, rec := range records {
+		id, err := uuid.Parse(getIDValue(rec))
+		if err == nil && h.undo.isPending(config.Name, id) {
+			continue
+		}
+		visible = append(visible, rec)
+	}
+	return visible
+}
+
+// FlushPendingDeletes executes all queued deletes without waiting for their undo window.
+// Call it during shutdown so queued deletes aren't lost.
+func (h *Handler) FlushPendingDeletes() {
+	h.undo.flush()
+}
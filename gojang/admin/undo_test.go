@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestUndoQueue_RunsAfterWindow tests that a queued delete executes once its window expires
+func TestUndoQueue_RunsAfterWindow(t *testing.T) {
+	q := newUndoQueue()
+	id := uuid.New()
+	ran := make(chan struct{})
+
+	q.schedule("Post", id, 10*time.Millisecond, func() { close(ran) })
+	if !q.isPending("Post", id) {
+		t.Error("Expected record to be pending")
+	}
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("Expected delete to run after undo window")
+	}
+	if q.isPending("Post", id) {
+		t.Error("Expected record to no longer be pending")
+	}
+}
+
+// TestUndoQueue_Cancel tests that canceling prevents the delete and checks the model
+func TestUndoQueue_Cancel(t *testing.T) {
+	q := newUndoQueue()
+	var runs int32
+
+	token := q.schedule("Post", uuid.New(), 20*time.Millisecond, func() { atomic.AddInt32(&runs, 1) })
+
+	if _, ok := q.cancel("User", token); ok {
+		t.Error("Expected cancel with wrong model to fail")
+	}
+	if _, ok := q.cancel("Post", token); !ok {
+		t.Fatal("Expected cancel to succeed")
+	}
+	if _, ok := q.cancel("Post", token); ok {
+		t.Error("Expected second cancel to fail")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if atomic.LoadInt32(&runs) != 0 {
+		t.Error("Expected canceled delete not to run")
+	}
+}
+
+// TestUndoQueue_Flush tests that flush runs pending deletes immediately, exactly once
+func TestUndoQueue_Flush(t *testing.T) {
+	q := newUndoQueue()
+	var runs int32
+
+	q.schedule("Post", uuid.New(), time.Hour, func() { atomic.AddInt32(&runs, 1) })
+	q.schedule("Post", uuid.New(), time.Hour, func() { atomic.AddInt32(&runs, 1) })
+	q.flush()
+	q.flush()
+
+	if got := atomic.LoadInt32(&runs); got != 2 {
+		t.Errorf("Expected 2 deletes to run, got %d", got)
+	}
+}
+
+// TestUndoQueue_FlushWhileFiring tests that a delete whose timer fires during
+// flush runs exactly once, and that flush waits for it to finish
+func TestUndoQueue_FlushWhileFiring(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		q := newUndoQueue()
+		var runs, done int32
+		q.schedule("Post", uuid.New(), 0, func() {
+			atomic.AddInt32(&runs, 1)
+			time.Sleep(5 * time.Millisecond)
+			atomic.StoreInt32(&done, 1)
+		})
+
+		// Hold the lock while the timer fires, so its callback and flush race for it
+		q.mu.Lock()
+		time.Sleep(5 * time.Millisecond)
+		flushed := make(chan struct{})
+		go func() {
+			q.flush()
+			close(flushed)
+		}()
+		time.Sleep(time.Millisecond)
+		q.mu.Unlock()
+		<-flushed
+
+		if atomic.LoadInt32(&done) != 1 {
+			t.Fatal("Expected the delete to have finished when flush returns")
+		}
+		time.Sleep(10 * time.Millisecond) // A second run would have started by now
+		if got := atomic.LoadInt32(&runs); got != 1 {
+			t.Fatalf("Expected the delete to run exactly once, got %d", got)
+		}
+	}
+}
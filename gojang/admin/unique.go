@@ -0,0 +1,106 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/migrate"
+	"github.com/google/uuid"
+)
+
+// uniqueColumns returns the columns Ent declares unique on modelName's table
+// (e.g., users.email). Tables use Ent's default naming: "AdminAction" -> "admin_actions".
+func uniqueColumns(modelName string) map[string]bool {
+	table := columnName(pluralize(modelName))
+	columns := make(map[string]bool)
+	for _, t := range migrate.Tables {
+		if t.Name != table {
+			continue
+		}
+		for _, c := range t.Columns {
+			if c.Unique {
+				columns[c.Name] = true
+			}
+		}
+	}
+	return columns
+}
+
+// isTaken reports whether a record other than exclude has value in field.
+// Strings are compared case-insensitively, so "Alice@example.com" and
+// "alice@example.com" count as the same email.
+func (r *Registry) isTaken(ctx context.Context, modelName, field string, value interface{}, exclude uuid.UUID) (bool, error) {
+	modelClient := reflect.ValueOf(r.clientFor(ctx)).Elem().FieldByName(modelName)
+	if !modelClient.IsValid() {
+		return false, fmt.Errorf("model %s not found on client", modelName)
+	}
+	queryMethod := modelClient.MethodByName("Query")
+	if !queryMethod.IsValid() {
+		return false, fmt.Errorf("query method not found for model %s", modelName)
+	}
+	query := queryMethod.Call(nil)[0]
+
+	column := columnName(field)
+	preds := []func(*sql.Selector){sql.FieldEQ(column, value)}
+	if s, ok := value.(string); ok {
+		preds[0] = sql.FieldEqualFold(column, s)
+	}
+	if exclude != uuid.Nil {
+		preds = append(preds, sql.FieldNEQ("id", exclude))
+	}
+	for _, pred := range preds {
+		var err error
+		if query, err = applyWhere(query, pred); err != nil {
+			return false, err
+		}
+	}
+
+	existMethod := query.MethodByName("Exist")
+	if !existMethod.IsValid() {
+		return false, fmt.Errorf("exist method not found for model %s", modelName)
+	}
+	out := existMethod.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if len(out) != 2 {
+		return false, fmt.Errorf("exist method returned unexpected number of values for model %s", modelName)
+	}
+	if !out[1].IsNil() {
+		return false, out[1].Interface().(error)
+	}
+	return out[0].Bool(), nil
+}
+
+// checkRecord runs the model's Validate hook, then checks that no other record
+// (excluding id, uuid.Nil on create) has the same value in a unique field.
+// It returns form errors by field name.
+func (h *Handler) checkRecord(ctx context.Context, config *ModelConfig, data map[string]interface{}, id uuid.UUID) (map[string]string, error) {
+	errors := make(map[string]string)
+	if config.Validate != nil {
+		for name, msg := range config.Validate(data) {
+			errors[name] = msg
+		}
+	}
+	if config.IsTaken == nil {
+		return errors, nil
+	}
+
+	for _, field := range config.Fields {
+		if !field.Unique || errors[field.Name] != "" {
+			continue
+		}
+		value, ok := data[field.Name]
+		if !ok || value == nil || value == "" {
+			continue
+		}
+		taken, err := config.IsTaken(ctx, field.Name, value, id)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			errors[field.Name] = fmt.Sprintf("A %s with this %s already exists", strings.ToLower(config.Name), strings.ToLower(field.Label))
+		}
+	}
+	return errors, nil
+}
@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/storage"
+)
+
+// MaxUploadSize caps the body of admin forms with file fields
+const MaxUploadSize = 32 << 20 // 32 MB
+
+// MediaUploadRules limits files uploaded to the media library (/admin/media),
+// which takes any type by default
+var MediaUploadRules = storage.Rules{}
+
+// uploadDir is where admin uploads are stored, under a year/month subdirectory
+const uploadDir = "uploads"
+
+// parseForm parses urlencoded forms and multipart forms with file uploads
+func parseForm(w http.ResponseWriter, r *http.Request) error {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+		return r.ParseMultipartForm(MaxUploadSize)
+	}
+	return r.ParseForm()
+}
+
+// uploadedFile returns the file submitted for a field, or nil if none was chosen
+func uploadedFile(r *http.Request, name string) *multipart.FileHeader {
+	if r.MultipartForm == nil {
+		return nil
+	}
+	files := r.MultipartForm.File[name]
+	if len(files) == 0 || files[0].Size == 0 {
+		return nil
+	}
+	return files[0]
+}
+
+// imageTypes are the sniffed content types FieldTypeImage fields accept by
+// default (the formats gojang/images can resize)
+var imageTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// validateUploads adds an error for uploads that break their field's rules
+func validateUploads(config *ModelConfig, uploads map[string]*multipart.FileHeader, errors map[string]string) {
+	for name, fh := range uploads {
+		field := config.Field(name)
+		if field == nil {
+			continue
+		}
+		if _, err := storage.CheckUpload(fh, field.UploadRules()); err != nil {
+			errors[name] = uploadError(field, err)
+		}
+	}
+}
+
+// uploadError describes a rejected upload for the form, e.g., "Avatar must be
+// image/jpeg, image/png, image/gif, not text/html"
+func uploadError(field *FieldConfig, err error) string {
+	if reason, ok := uploadRejected(err); ok {
+		return field.Label + " " + reason
+	}
+	return field.Label + " could not be read"
+}
+
+// uploadRejected returns why an upload was refused, if err is a rejection
+func uploadRejected(err error) (string, bool) {
+	var rejected *storage.RejectedError
+	if errors.As(err, &rejected) {
+		return rejected.Reason, true
+	}
+	return "", false
+}
+
+// saveUploads stores validated uploads and puts their storage names into data.
+// Uploads the scanner refuses get a *storage.RejectedError.
+func saveUploads(r *http.Request, config *ModelConfig, uploads map[string]*multipart.FileHeader, data map[string]interface{}) error {
+	if len(uploads) == 0 {
+		return nil
+	}
+	fs := storage.Default()
+	if fs == nil {
+		return errors.New("no file storage configured (see storage.SetDefault)")
+	}
+	for field, fh := range uploads {
+		var rules storage.Rules
+		if f := config.Field(field); f != nil {
+			rules = f.UploadRules()
+		}
+		name, err := storage.SaveUpload(r, fs, fh, uploadDir, rules)
+		if err != nil {
+			return err
+		}
+		data[field] = name
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Package announcements picks the site-wide banners (Announcement records,
+// managed in the admin) to show on a page, and remembers who dismissed them.
+package announcements
+
+import (
+	"context"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/announcement"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/google/uuid"
+)
+
+// Levels style banners (as .alert-<level>)
+var Levels = []string{"info", "success", "warning", "error"}
+
+// Audiences of an announcement
+const (
+	AudienceAll    = "all"
+	AudienceGuests = "guests" // Visitors who aren't signed in
+	AudienceUsers  = "users"  // Every signed-in user
+	AudienceStaff  = "staff"
+)
+
+// Audiences lists every audience, for validating admin input
+var Audiences = []string{AudienceAll, AudienceGuests, AudienceUsers, AudienceStaff}
+
+// MaxShown caps the banners on one page; the newest win
+const MaxShown = 3
+
+// Active returns the announcements to show u (nil for a guest) right now,
+// newest first. Those u dismissed are left out, as are the IDs in dismissed
+// (a guest's dismissals, which only last as long as their session).
+func Active(ctx context.Context, client *models.Client, u *models.User, dismissed []uuid.UUID) ([]*models.Announcement, error) {
+	now := time.Now()
+	where := []predicate.Announcement{
+		announcement.Or(announcement.StartsAtIsNil(), announcement.StartsAtLTE(now)),
+		announcement.Or(announcement.EndsAtIsNil(), announcement.EndsAtGT(now)),
+		announcement.AudienceIn(audiences(u)...),
+	}
+	if u != nil {
+		where = append(where, announcement.Not(announcement.HasDismissedByWith(user.ID(u.ID))))
+	}
+	if len(dismissed) > 0 {
+		where = append(where, announcement.IDNotIn(dismissed...))
+	}
+	return client.Announcement.Query().
+		Where(where...).
+		Order(models.Desc(announcement.FieldCreatedAt)).
+		Limit(MaxShown).
+		All(ctx)
+}
+
+// audiences returns the audiences u belongs to
+func audiences(u *models.User) []string {
+	switch {
+	case u == nil:
+		return []string{AudienceAll, AudienceGuests}
+	case u.IsStaff:
+		return []string{AudienceAll, AudienceUsers, AudienceStaff}
+	default:
+		return []string{AudienceAll, AudienceUsers}
+	}
+}
+
+// Dismiss hides an announcement from u for good. Dismissing it twice is fine.
+func Dismiss(ctx context.Context, client *models.Client, u *models.User, id uuid.UUID) error {
+	dismissed, err := client.Announcement.Query().
+		Where(announcement.ID(id), announcement.HasDismissedByWith(user.ID(u.ID))).
+		Exist(ctx)
+	if err != nil || dismissed {
+		return err
+	}
+	return client.User.UpdateOneID(u.ID).AddDismissedAnnouncementIDs(id).Exec(ctx)
+}
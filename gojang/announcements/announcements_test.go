@@ -0,0 +1,84 @@
+package announcements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+func messages(list []*models.Announcement) map[string]bool {
+	got := make(map[string]bool)
+	for _, a := range list {
+		got[a.Message] = true
+	}
+	return got
+}
+
+// TestActive tests that announcements are picked by audience and time window
+func TestActive(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	now := time.Now()
+	client.Announcement.Create().SetMessage("everyone").SaveX(ctx)
+	client.Announcement.Create().SetMessage("guests").SetAudience(AudienceGuests).SaveX(ctx)
+	client.Announcement.Create().SetMessage("users").SetAudience(AudienceUsers).SaveX(ctx)
+	client.Announcement.Create().SetMessage("staff").SetAudience(AudienceStaff).SaveX(ctx)
+	client.Announcement.Create().SetMessage("upcoming").SetStartsAt(now.Add(time.Hour)).SaveX(ctx)
+	client.Announcement.Create().SetMessage("over").SetEndsAt(now.Add(-time.Hour)).SaveX(ctx)
+
+	member := client.User.Create().SetEmail("member@example.com").SetPasswordHash("x").SaveX(ctx)
+	staff := client.User.Create().SetEmail("staff@example.com").SetPasswordHash("x").SetIsStaff(true).SaveX(ctx)
+
+	for name, tc := range map[string]struct {
+		user *models.User
+		want []string
+	}{
+		"guest":  {nil, []string{"everyone", "guests"}},
+		"member": {member, []string{"everyone", "users"}},
+		"staff":  {staff, []string{"everyone", "users", "staff"}},
+	} {
+		list, err := Active(ctx, client, tc.user, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got := messages(list)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+		}
+		for _, m := range tc.want {
+			if !got[m] {
+				t.Errorf("%s: missing %q in %v", name, m, got)
+			}
+		}
+	}
+}
+
+// TestDismiss tests that dismissals last for the user, and for guests per the IDs passed in
+func TestDismiss(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	a := client.Announcement.Create().SetMessage("maintenance tonight").SaveX(ctx)
+	u := client.User.Create().SetEmail("member@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	for i := 0; i < 2; i++ {
+		if err := Dismiss(ctx, client, u, a.ID); err != nil {
+			t.Fatalf("Dismiss #%d: %v", i+1, err)
+		}
+	}
+	if list, _ := Active(ctx, client, u, nil); len(list) != 0 {
+		t.Errorf("Expected no announcements after dismissing, got %d", len(list))
+	}
+
+	// Other users and guests still see it, unless the guest dismissed it
+	other := client.User.Create().SetEmail("other@example.com").SetPasswordHash("x").SaveX(ctx)
+	if list, _ := Active(ctx, client, other, nil); len(list) != 1 {
+		t.Errorf("Expected another user to see it, got %d", len(list))
+	}
+	if list, _ := Active(ctx, client, nil, []uuid.UUID{a.ID}); len(list) != 0 {
+		t.Errorf("Expected the guest's dismissal to hide it, got %d", len(list))
+	}
+}
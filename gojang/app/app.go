@@ -0,0 +1,528 @@
+// Package app wires the web application together: database, file storage,
+// search, renderers, handlers and the router. cmd/web serves it with an
+// http.Server; cmd/lambda runs the same handler on AWS Lambda through the
+// serverless package.
+package app
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/admin"
+	"github.com/gojangframework/gojang/gojang/auditexport"
+	"github.com/gojangframework/gojang/gojang/cache"
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/debugbar"
+	"github.com/gojangframework/gojang/gojang/http/authz"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/http/routes"
+	"github.com/gojangframework/gojang/gojang/http/static"
+	"github.com/gojangframework/gojang/gojang/i18n"
+	"github.com/gojangframework/gojang/gojang/images"
+	"github.com/gojangframework/gojang/gojang/jobs"
+	"github.com/gojangframework/gojang/gojang/livereload"
+	"github.com/gojangframework/gojang/gojang/mail"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/oidc"
+	"github.com/gojangframework/gojang/gojang/redirects"
+	"github.com/gojangframework/gojang/gojang/retention"
+	"github.com/gojangframework/gojang/gojang/search"
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/utils/signer"
+	"github.com/gojangframework/gojang/gojang/version"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+	"github.com/gojangframework/gojang/gojang/webauthn"
+
+	"entgo.io/ent/dialect"
+	"github.com/alexedwards/scs/v2"
+	"github.com/alexedwards/scs/v2/memstore"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/justinas/nosurf"
+)
+
+// Options changes how the app runs outside a long-lived server process
+type Options struct {
+	// Serverless prepares the app for function platforms, where an instance
+	// is frozen between requests and may be dropped at any time: no background
+	// goroutines (cache and rate limiter cleanup, search reindex, live reload),
+	// admin deletes run immediately instead of after an undo window, and the
+	// schema isn't migrated on every cold start (run `migrate auto` when you deploy).
+	Serverless bool
+
+	// SessionStore keeps sessions somewhere shared, e.g. scs's postgresstore.
+	// Defaults to memory, which only works with a single instance.
+	SessionStore scs.Store
+
+	// Middleware adds global middleware, run among the built-in ones by phase
+	// and priority (see middleware.Stack). A conflict makes New fail.
+	Middleware []middleware.Entry
+}
+
+// App is the configured web application
+type App struct {
+	Config    *config.Config
+	DB        *models.Client
+	Databases *db.Databases // DB plus the DATABASES connections, routed per model
+	Health    *db.Monitor   // Pings DB for /readyz and reconnects it after failures
+	Jobs      *jobs.Queue   // Background jobs; handlers return their ID for /api/jobs/{id}
+	Handler   http.Handler  // Serves every route
+	Router    chi.Routes    // The router behind Handler, for listing routes (`gojang routes`)
+
+	// LiveReload streams browser refreshes under `gojang dev`; nil otherwise.
+	// Close it before shutting down the server so open streams don't hold it up.
+	LiveReload *livereload.Server
+
+	admin       *admin.Handler
+	searchIndex search.Indexer
+	stop        context.CancelFunc // Ends background goroutines
+}
+
+// New connects to the database and builds every handler. Templates are parsed
+// here rather than on first use (except in debug mode), so a serverless cold
+// start pays for them once, before the first request.
+func New(cfg *config.Config, opts Options) (*App, error) {
+	// Initialize global logging
+	// Use LOG_LEVEL env var or infer from cfg.Debug/ENV
+	lvl := ""
+	if cfg.Debug {
+		lvl = "debug"
+	}
+	if err := utils.Init(lvl); err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	utils.SetLogRedaction(cfg.LogRedactKeys, cfg.LogRedactEmails)
+	if err := utils.SetDefaultCurrency(cfg.Currency); err != nil {
+		return nil, fmt.Errorf("failed to set currency: %w", err)
+	}
+	if err := i18n.SetLocales(cfg.Locales); err != nil {
+		return nil, fmt.Errorf("failed to set locales: %w", err)
+	}
+	if err := utils.SetMapTileURL(cfg.MapTileURL); err != nil {
+		return nil, fmt.Errorf("failed to set map tiles: %w", err)
+	}
+	db.UsePostGIS(cfg.PostGIS)
+	utils.SetSpamTrap(cfg.SessionKey, cfg.SpamMinDelay)
+	signer.SetDefault(signer.New(cfg.SigningKeys...))
+
+	// Setup database; in debug mode, queries are recorded for the toolbar
+	var wrap []func(dialect.Driver) dialect.Driver
+	if cfg.Debug && cfg.DebugToolbar {
+		wrap = append(wrap, debugbar.Driver)
+	}
+	client, health, err := db.NewMonitoredClient(cfg.DatabaseURL, wrap...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	health.Interval = cfg.DBHealthInterval
+
+	ctx, stop := context.WithCancel(context.Background())
+	a := &App{Config: cfg, DB: client, Databases: db.NewDatabases(client), Health: health, stop: stop}
+	if err := a.Databases.Configure(cfg.Databases, cfg.DatabaseRoutes); err != nil {
+		a.Close()
+		return nil, fmt.Errorf("failed to set up databases: %w", err)
+	}
+	if err := a.build(ctx, opts); err != nil {
+		a.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+// build sets up everything that needs the database
+func (a *App) build(ctx context.Context, opts Options) error {
+	cfg, client := a.Config, a.DB
+	background := !opts.Serverless
+
+	// Run auto-migrations
+	if background {
+		if err := db.AutoMigrate(ctx, client); err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+		if err := a.Databases.Migrate(ctx); err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+		go db.StartCheckpointRoutine(cfg.DatabaseURL, cfg.SQLiteCheckpointInterval, ctx.Done())
+		go a.Health.Run(ctx)
+	}
+
+	// File storage for upload fields
+	fileStorage, err := storage.Open(cfg.StorageURL, storage.Options{
+		PublicURL: cfg.StoragePublicURL,
+		AccessKey: cfg.StorageAccessKey,
+		SecretKey: cfg.StorageSecretKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open file storage: %w", err)
+	}
+	storage.SetDefault(fileStorage)
+
+	// Uploads are checked by ClamAV when UPLOAD_SCANNER is set; refused files go
+	// to the quarantine store, which isn't served
+	scanner, err := storage.OpenScanner(cfg.UploadScanner)
+	if err != nil {
+		return fmt.Errorf("failed to set up upload scanning: %w", err)
+	}
+	storage.SetScanner(scanner)
+	var quarantine storage.Filesystem
+	if cfg.UploadQuarantineURL != "" {
+		if quarantine, err = storage.Open(cfg.UploadQuarantineURL, storage.Options{AccessKey: cfg.StorageAccessKey, SecretKey: cfg.StorageSecretKey}); err != nil {
+			return fmt.Errorf("failed to open upload quarantine: %w", err)
+		}
+	}
+	storage.SetQuarantine(quarantine)
+
+	// Email goes through SMTP_HOST; without one, messages are only logged
+	if cfg.SMTPHost != "" {
+		mail.SetDefault(&mail.SMTP{Host: cfg.SMTPHost, Port: cfg.SMTPPort, Username: cfg.SMTPUser, Password: cfg.SMTPPass, From: cfg.SMTPFrom})
+	}
+
+	// The admin activity log is exported daily to AUDIT_EXPORT_URL and/or emailed
+	auditFormat, _ := auditexport.ParseFormat(cfg.AuditExportFormat)
+	auditExporter := &auditexport.Exporter{Client: client, Format: auditFormat, Email: cfg.AuditExportEmail}
+	if cfg.AuditExportURL != "" {
+		if auditExporter.Storage, err = storage.Open(cfg.AuditExportURL, storage.Options{AccessKey: cfg.StorageAccessKey, SecretKey: cfg.StorageSecretKey}); err != nil {
+			return fmt.Errorf("failed to open audit export storage: %w", err)
+		}
+	}
+
+	// OpenID Connect provider, so the apps in OIDC_CLIENTS can sign users in here
+	var oidcProvider *oidc.Provider
+	oidcCodes := cache.NewMemoryCache()
+	if len(cfg.OIDCClients) > 0 {
+		parties, err := oidc.ParseRelyingParties(cfg.OIDCClients)
+		if err != nil {
+			return err
+		}
+		var key *rsa.PrivateKey
+		if cfg.OIDCKeyFile != "" {
+			key, err = oidc.LoadKey(cfg.OIDCKeyFile)
+		} else {
+			key, err = oidc.GenerateKey()
+			utils.Warnw("oidc.ephemeral_key", "hint", "tokens stop verifying on restart; set OIDC_KEY_FILE")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load the OIDC signing key: %w", err)
+		}
+		oidcProvider = oidc.New(cfg.OIDCIssuer, key, client, parties...)
+		oidcProvider.Codes = oidcCodes
+	}
+
+	// Passkeys are bound to the site's domain, so SITE_URL is required with them
+	var passkeys *webauthn.RelyingParty
+	if cfg.Passkeys {
+		passkeys, err = webauthn.New(cfg.SiteURL, "Gojang")
+		if err != nil {
+			return fmt.Errorf("invalid SITE_URL for passkeys: %w", err)
+		}
+	}
+
+	// Redirects from old URLs, reloaded whenever one is saved
+	redirectMap := redirects.New(client)
+	client.Redirect.Use(redirectMap.Hook())
+
+	// Search index, kept in sync with posts through an Ent hook. The app still
+	// runs without it; /search just isn't mounted.
+	searchIndex, err := search.Open(cfg.SearchURL, cfg.SearchAPIKey)
+	if err != nil {
+		utils.Warnw("search.disabled", "error", err)
+	} else {
+		a.searchIndex = searchIndex
+		posts := search.Posts(client)
+		client.Post.Use(search.Hook(searchIndex, posts))
+		if background {
+			go func() {
+				n, err := search.Reindex(ctx, searchIndex, posts)
+				if err != nil {
+					utils.Errorw("search.reindex_failed", "error", err)
+					return
+				}
+				utils.Infow("search.reindexed", "type", posts.Type, "count", n)
+			}()
+		}
+	}
+
+	// Background jobs (exports, imports, emails). A frozen serverless instance
+	// can't run them between requests, so they run inside the request there.
+	a.Jobs = jobs.NewQueue(4, 100)
+	if background {
+		a.Jobs.Start(ctx)
+	} else {
+		a.Jobs.Sync = true
+	}
+
+	// Setup session manager
+	sessionManager := middleware.NewSessionManager(cfg)
+	switch {
+	case opts.SessionStore != nil:
+		sessionManager.Store = opts.SessionStore
+	case !background:
+		// The default store's cleanup goroutine can't run in a frozen instance
+		sessionManager.Store = memstore.NewWithCleanupInterval(0)
+		utils.Warnw("sessions.in_memory", "hint", "sessions are lost between instances; set app.Options.SessionStore")
+	}
+	guestSessions := middleware.NewGuestSessions(sessionManager) // Register migrators for guest data here
+
+	// Setup renderers
+	// Public renderer: Handles public site pages with base.html wrapper
+	publicRenderer, err := renderers.NewRenderer(cfg.Debug)
+	if err != nil {
+		return fmt.Errorf("failed to setup public renderer: %w", err)
+	}
+
+	// Fragment cache for hot partials (e.g., the posts list)
+	fragmentCache := cache.NewMemoryCache()
+	publicRenderer.UseCache(fragmentCache)
+
+	// Admin renderer: Handles admin panel (always fragments, no base.html)
+	adminRenderer, err := admin.NewAdminRenderer(cfg.Debug)
+	if err != nil {
+		return fmt.Errorf("failed to setup admin renderer: %w", err)
+	}
+
+	// Setup handlers
+	authHandler := handlers.NewAuthHandler(client, sessionManager, publicRenderer)
+	authHandler.Identifier = cfg.AuthIdentifier
+	authHandler.Guests = guestSessions
+	authHandler.MagicLink = cfg.MagicLink
+	authHandler.MagicLinkTTL = cfg.MagicLinkTTL
+	authHandler.SiteURL = strings.TrimSuffix(cfg.SiteURL, "/")
+	authHandler.Passkeys = passkeys
+	authHandler.MaxSessions = cfg.SessionLimit
+	userHandler := handlers.NewUserHandler(client, publicRenderer)
+	accountHandler := handlers.NewAccountHandler(client, publicRenderer)
+	accountHandler.Passkeys = passkeys
+	accountHandler.Sessions = sessionManager
+	postHandler := handlers.NewPostHandler(client, publicRenderer)
+	announcementHandler := handlers.NewAnnouncementHandler(client, sessionManager)
+	publicRenderer.UseAnnouncements(announcementHandler.Banners)
+	sessionExpiry := middleware.SessionExpiryFunc(sessionManager, cfg.SessionExpiryWarning)
+	publicRenderer.UseSessionExpiry(sessionExpiry)
+	adminRenderer.UseSessionExpiry(sessionExpiry)
+
+	// Development toolbar on full pages, recording from the debug_toolbar middleware
+	var debugToolbar *debugbar.Toolbar
+	if cfg.Debug && cfg.DebugToolbar {
+		debugToolbar = &debugbar.Toolbar{Sessions: sessionManager, QueryBudget: cfg.DebugQueryBudget}
+		publicRenderer.UseDebugToolbar(debugToolbar)
+		adminRenderer.UseDebugToolbar(debugToolbar)
+	}
+	contactHandler := handlers.NewContactHandler(client, publicRenderer, cfg.ContactEmail)
+	pollHandler := handlers.NewPollHandler(client, guestSessions, publicRenderer)
+	postAPIHandler := handlers.NewPostAPIHandler(client)
+	pageHandler := handlers.NewPageHandler(client, publicRenderer)
+	jobHandler := handlers.NewJobHandler(a.Jobs, publicRenderer)
+	jobHandler.MaxWait = cfg.RequestTimeout / 2 // Long polls end well before the timeout
+	var searchHandler *handlers.SearchHandler
+	if searchIndex != nil {
+		searchHandler = handlers.NewSearchHandler(searchIndex, publicRenderer)
+	}
+
+	// Setup admin registry and handler
+	adminRegistry := admin.NewRegistry(client)
+	// Register models with the admin system
+	admin.RegisterModels(adminRegistry)
+	adminHandler := admin.NewHandler(adminRegistry, adminRenderer, client)
+	if !background {
+		// A pending delete's timer may never fire in a frozen instance
+		adminHandler.UndoWindow = 0
+	}
+	if cfg.AdminQueryConsole {
+		adminHandler.Console = &admin.QueryConsole{Driver: a.Health.Driver, Role: cfg.AdminQueryRole}
+	}
+	if cfg.AdminSudoWindow > 0 {
+		adminHandler.Sudo = &middleware.Sudo{Sessions: sessionManager, Window: cfg.AdminSudoWindow, PromptURL: "/admin/sudo"}
+	}
+	// Tables pruned of old rows every hour; superusers can change the days on /admin/settings
+	retentionPolicies := retention.New(client)
+	retentionPolicies.Register(retention.AdminActions(client, 365))
+	retentionPolicies.Register(retention.LoginEvents(client, 90))
+	retentionPolicies.Register(retention.Sessions(sessionManager, 30))
+	adminHandler.Retention = retentionPolicies
+	adminHandler.AuditExport = auditExporter
+	a.admin = adminHandler
+
+	// Setup router
+	r := chi.NewRouter()
+
+	// Global middleware, in phase and priority order, plus any from opts.Middleware
+	stack := &middleware.Stack{}
+	stack.Add(
+		middleware.Entry{Name: "request_id", Phase: middleware.PhaseCore, Priority: 0, Handler: chimiddleware.RequestID}, // Read with ctxutil.RequestID
+		middleware.Entry{Name: "real_ip", Phase: middleware.PhaseCore, Priority: 10, Handler: chimiddleware.RealIP},
+		middleware.Entry{Name: "logger", Phase: middleware.PhaseCore, Priority: 20, Handler: chimiddleware.Logger},
+		middleware.Entry{Name: "recoverer", Phase: middleware.PhaseCore, Priority: 30, Handler: chimiddleware.Recoverer},
+		middleware.Entry{Name: "enforce_https", Phase: middleware.PhaseSecurity, Priority: 0, Handler: middleware.EnforceHTTPS(cfg)},
+		middleware.Entry{Name: "security_headers", Phase: middleware.PhaseSecurity, Priority: 10, Handler: middleware.SecurityHeaders(cfg)},
+		middleware.Entry{Name: "session", Phase: middleware.PhaseSession, Priority: 0, Handler: sessionManager.LoadAndSave},
+		middleware.Entry{Name: "load_user", Phase: middleware.PhaseAuth, Priority: 0, Handler: middleware.LoadUser(sessionManager, client), After: []string{"session"}}, // Load user from session on all pages
+		middleware.Entry{Name: "locale", Phase: middleware.PhaseApp, Priority: -10, Handler: middleware.Locale},                                                         // Ahead of apps' own app-phase middleware
+	)
+	if debugToolbar != nil {
+		stack.Add(middleware.Entry{Name: "debug_toolbar", Phase: middleware.PhaseCore, Priority: 40, Handler: debugToolbar.Middleware}) // Ahead of the session and user queries
+	}
+	stack.Add(opts.Middleware...)
+	global, err := stack.Build()
+	if err != nil {
+		return err
+	}
+	r.Use(global...)
+
+	// Static files (CSS and assets in views/static), without directory listings
+	r.Handle("/static/*", http.StripPrefix("/static", static.Dir("./gojang/views/static")))
+
+	// Admin stylesheets (only css/, so the admin templates next to it aren't served)
+	r.Handle("/admin/static/css/*", http.StripPrefix("/admin/static/css", static.Dir("./gojang/admin/views/css")))
+
+	// Uploaded files (only used when STORAGE_PUBLIC_URL doesn't point elsewhere),
+	// and resized image variants from signed URLs
+	r.Handle("/media/*", http.StripPrefix("/media", storage.Handler(fileStorage)))
+	r.Handle("/media/resize/*", http.StripPrefix("/media/resize", images.Handler(fileStorage)))
+
+	// Well-known files (security.txt, etc.), embedded from gojang/http/static/wellknown
+	r.Handle("/.well-known/*", http.StripPrefix("/.well-known", static.WellKnown()))
+
+	// Per-group request timeouts (admin pages get a longer budget)
+	timeoutPage := http.HandlerFunc(pageHandler.Timeout)
+	publicTimeout := middleware.Timeout(cfg.RequestTimeout, timeoutPage)
+	adminTimeout := middleware.Timeout(cfg.AdminRequestTimeout, nil)
+	apiTimeout := middleware.Timeout(cfg.RequestTimeout, nil)
+
+	// Expensive endpoints queue for a few slots instead of overloading the database
+	searchLimit := middleware.ConcurrencyLimit(middleware.NewConcurrencyLimiter(cfg.ExpensiveConcurrency, cfg.ExpensiveQueue, cfg.ExpensiveQueueWait), nil)
+
+	// Page for users refused by authz.Require and authz.RequireRole
+	authz.SetForbiddenHandler(http.HandlerFunc(pageHandler.Forbidden))
+
+	// Honeypot and time-trap checks for public forms that render {{spamTrap}}
+	spamTrap := middleware.SpamTrap(http.HandlerFunc(pageHandler.SpamRejected))
+
+	// Auth routes (must be mounted before "/" to avoid conflicts)
+	authLimiter := middleware.AuthRateLimiter()
+	apiLimiter := middleware.APIRateLimiter()
+	contactLimiter := middleware.ContactRateLimiter()
+
+	// Start cleanup routines (every 5 minutes), scheduled posts (every minute), and
+	// retention and audit log exports (hourly)
+	if background {
+		go authLimiter.StartCleanupRoutine(5*time.Minute, ctx.Done())
+		go apiLimiter.StartCleanupRoutine(5*time.Minute, ctx.Done())
+		go contactLimiter.StartCleanupRoutine(5*time.Minute, ctx.Done())
+		go fragmentCache.StartCleanupRoutine(5*time.Minute, ctx.Done())
+		go oidcCodes.StartCleanupRoutine(5*time.Minute, ctx.Done())
+		go jobs.Every(ctx, time.Minute, "publish_posts", postHandler.PublishScheduled)
+		go jobs.Every(ctx, time.Hour, "retention", retentionPolicies.Run)
+		go jobs.Every(ctx, time.Hour, "audit_export", auditExporter.Daily)
+	}
+
+	r.Group(func(auth chi.Router) {
+		auth.Use(publicTimeout)
+		auth.Use(nosurf.NewPure)
+		auth.Get("/login", authHandler.LoginGET)
+		auth.With(middleware.RateLimit(authLimiter)).Post("/login", authHandler.LoginPOST)
+		auth.Get("/register", authHandler.RegisterGET)
+		auth.With(middleware.RateLimit(authLimiter), spamTrap).Post("/register", authHandler.RegisterPOST)
+		auth.Post("/logout", authHandler.LogoutPOST)
+		auth.Post("/session/keepalive", authHandler.KeepAlive)
+		if cfg.MagicLink != config.MagicLinkOff {
+			auth.With(middleware.RateLimit(authLimiter)).Post("/login/link", authHandler.LoginLinkPOST)
+			auth.Get("/login/link/confirm", authHandler.LoginLinkGET)
+			auth.Post("/login/link/confirm", authHandler.LoginLinkConfirmPOST)
+		}
+		if passkeys != nil {
+			auth.Post("/login/passkey/options", authHandler.PasskeyLoginOptions)
+			auth.With(middleware.RateLimit(authLimiter)).Post("/login/passkey", authHandler.PasskeyLoginPOST)
+		}
+	})
+
+	// Mount routes (organized by resource)
+	r.With(publicTimeout).Mount("/", routes.PageRoutes(pageHandler, sessionManager, client))
+	r.With(publicTimeout, spamTrap).Mount("/posts", routes.PostRoutes(postHandler, sessionManager, client))
+	if searchHandler != nil {
+		r.With(publicTimeout, searchLimit).Mount("/search", routes.SearchRoutes(searchHandler))
+	}
+	r.With(publicTimeout).Mount("/users", routes.UserRoutes(userHandler, sessionManager, client))
+	r.With(publicTimeout).Mount("/account", routes.AccountRoutes(accountHandler, sessionManager, client))
+	r.With(publicTimeout).Mount("/jobs", routes.JobRoutes(jobHandler))
+	r.With(publicTimeout).Mount("/announcements", routes.AnnouncementRoutes(announcementHandler))
+	r.With(publicTimeout).Mount("/polls", routes.PollRoutes(pollHandler))
+	if len(cfg.ContactEmail) > 0 {
+		r.With(publicTimeout, spamTrap).Mount("/contact", routes.ContactRoutes(contactHandler, contactLimiter))
+	}
+	r.With(apiTimeout, middleware.APIRateLimit(apiLimiter, middleware.APIBudgets().Key())).Mount("/api", routes.APIRoutes(postAPIHandler, jobHandler, admin.BatchHandler(adminHandler, sessionManager, client)))
+	r.With(adminTimeout).Mount("/admin", admin.AdminRoutes(adminHandler, sessionManager, client))
+
+	// Single sign-on for other apps, with discovery at the issuer's well-known URL
+	if oidcProvider != nil {
+		r.Get("/.well-known/openid-configuration", oidcProvider.Discovery)
+		r.With(apiTimeout).Mount("/oidc", oidc.Routes(oidcProvider, sessionManager))
+	}
+
+	// Build version and commit, for checking what a deploy is running
+	switch cfg.VersionEndpoint {
+	case config.VersionEndpointStaff:
+		r.With(authz.RequireRole(authz.RoleStaff)).Get("/version", version.Handler)
+	case config.VersionEndpointPublic:
+		r.Get("/version", version.Handler)
+	}
+
+	// Profiling (net/http/pprof and expvar) for `gojang profile`, without a
+	// request timeout so CPU profiles and traces can run for their duration
+	switch {
+	case cfg.Profiling == config.ProfilingStaff:
+		r.With(authz.RequireRole(authz.RoleStaff)).Mount("/debug", chimiddleware.Profiler())
+	case cfg.Profiling == config.ProfilingDebug && cfg.Debug:
+		r.Mount("/debug", chimiddleware.Profiler())
+	}
+
+	// 404 handler for unmatched routes, after checking for a redirect
+	r.NotFound(redirectMap.NotFound(http.HandlerFunc(pageHandler.NotFound)).ServeHTTP)
+
+	// Readiness checks are served outside the router, so load balancers probing
+	// over plain HTTP aren't redirected to HTTPS or given a session
+	mux := http.NewServeMux()
+	mux.Handle("/readyz", db.ReadyHandler(a.Health))
+	mux.Handle("/", r)
+	a.Handler = mux
+	a.Router = r
+
+	// Browser auto-refresh under `gojang dev`. The event stream is served
+	// outside the router so session and timeout middleware don't buffer it.
+	if cfg.Debug && cfg.LiveReload && background {
+		a.LiveReload = livereload.New()
+		livereload.SetEnabled(true)
+		mux.Handle(livereload.Path, a.LiveReload)
+
+		// Templates are re-parsed per request in debug mode, so a refresh is enough
+		go a.LiveReload.Watch(ctx, livereload.Watcher{
+			Dirs: []string{"./gojang/views", "./gojang/admin/views"},
+			Exts: []string{".html", ".css", ".js"},
+		})
+	}
+	return nil
+}
+
+// Close stops background work, runs admin deletes still inside their undo
+// window, and closes the search index and database. Call it after the server
+// has stopped taking requests.
+func (a *App) Close() {
+	a.stop()
+	if a.LiveReload != nil {
+		a.LiveReload.Close()
+	}
+	if a.admin != nil {
+		a.admin.FlushPendingDeletes()
+	}
+	if a.searchIndex != nil {
+		a.searchIndex.Close()
+	}
+	a.Databases.Close()
+	a.DB.Close()
+}
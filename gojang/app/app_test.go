@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models/db"
+)
+
+// testConfig returns the settings config.Load would produce for a local SQLite app
+func testConfig(t *testing.T) *config.Config {
+	dir := t.TempDir()
+	return &config.Config{
+		DatabaseURL:         "sqlite://" + filepath.Join(dir, "app.db"),
+		SessionKey:          "abcdefghijklmnopqrstuvwxyz012345",
+		Port:                "8080",
+		RequestTimeout:      10 * time.Second,
+		AdminRequestTimeout: 30 * time.Second,
+		SessionLifetime:     time.Hour,
+		AuthIdentifier:      config.AuthIdentifierEmail,
+		Currency:            "USD",
+		Locales:             []string{"en"},
+		MapTileURL:          "https://tile.openstreetmap.org/{z}/{x}/{y}.png",
+		StorageURL:          "file://" + filepath.Join(dir, "media"),
+		SearchURL:           "bleve://" + filepath.Join(dir, "search.bleve"),
+	}
+}
+
+// TestNew_Serverless tests that a serverless app serves pages without background work
+func TestNew_Serverless(t *testing.T) {
+	// Templates and static files are read relative to the project root
+	t.Chdir("../..")
+	cfg := testConfig(t)
+
+	// Serverless apps expect the schema to be migrated at deploy time
+	client, err := db.NewClient(cfg.DatabaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	client.Close()
+
+	// An app middleware that runs once the user is loaded
+	tag := middleware.Entry{Name: "tag", Phase: middleware.PhaseApp, After: []string{"load_user"}, Handler: func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Test-Middleware", "yes")
+			next.ServeHTTP(w, r)
+		})
+	}}
+	a, err := New(cfg, Options{Serverless: true, Middleware: []middleware.Entry{tag}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer a.Close()
+
+	if a.admin.UndoWindow != 0 {
+		t.Errorf("Expected admin deletes to run immediately, got an undo window of %v", a.admin.UndoWindow)
+	}
+
+	var header http.Header
+	get := func(path string) int {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("X-Forwarded-Proto", "https") // As set by API Gateway
+		w := httptest.NewRecorder()
+		a.Handler.ServeHTTP(w, r)
+		header = w.Header()
+		return w.Code
+	}
+	if code := get("/"); code != http.StatusOK {
+		t.Errorf("GET / = %d, want 200", code)
+	}
+	if header.Get("X-Test-Middleware") != "yes" {
+		t.Error("Expected the app's middleware to run")
+	}
+	if code := get("/no-such-page"); code != http.StatusNotFound {
+		t.Errorf("GET /no-such-page = %d, want 404", code)
+	}
+	if code := get("/readyz"); code != http.StatusOK {
+		t.Errorf("GET /readyz = %d, want 200", code)
+	}
+
+	// Static files are served, but not templates, directories or the project root
+	for path, served := range map[string]bool{
+		"/static/css/style.css":         true,
+		"/admin/static/css/admin.css":   true,
+		"/.well-known/security.txt":     true,
+		"/admin/static/admin_base.html": false,
+		"/static/css/":                  false,
+		"/.well-known/go.mod":           false,
+	} {
+		if code := get(path); (code == http.StatusOK) != served {
+			t.Errorf("GET %s = %d, served = %v", path, code, served)
+		}
+	}
+}
+
+// TestNew_MiddlewareConflict tests that middleware clashing with a built-in one is refused
+func TestNew_MiddlewareConflict(t *testing.T) {
+	t.Chdir("../..")
+	cfg := testConfig(t)
+
+	noop := func(next http.Handler) http.Handler { return next }
+	early := middleware.Entry{Name: "early", Phase: middleware.PhaseCore, Priority: 5, Handler: noop, After: []string{"load_user"}}
+	if _, err := New(cfg, Options{Serverless: true, Middleware: []middleware.Entry{early}}); err == nil || !strings.Contains(err.Error(), `"early" must run after "load_user"`) {
+		t.Errorf("Expected an ordering error, got %v", err)
+	}
+}
+
+func TestNew_DatabaseError(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.DatabaseURL = "mysql://localhost/app"
+	if _, err := New(cfg, Options{}); err == nil {
+		t.Error("Expected an error for an unsupported database URL")
+	}
+}
@@ -0,0 +1,116 @@
+package auditexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/mail"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/google/uuid"
+)
+
+// addAction records an admin action at the given time
+func addAction(t *testing.T, client *models.Client, at time.Time, label string) {
+	t.Helper()
+	client.AdminAction.Create().
+		SetAction("update").SetModel("Post").SetRecordID("1").SetRecordLabel(label).
+		SetUserID(uuid.New()).SetUserEmail("root@example.com").
+		SetCreatedAt(at).
+		SaveX(context.Background())
+}
+
+func day(s string) time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return t
+}
+
+func TestWrite(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	addAction(t, client, day("2026-10-01").Add(time.Hour), `Hello, "world"`)
+	addAction(t, client, day("2026-10-02").Add(time.Hour), "Second")
+	addAction(t, client, day("2026-10-03").Add(time.Hour), "Outside the range")
+
+	var buf bytes.Buffer
+	n, err := Write(ctx, client, &buf, CSV, day("2026-10-01"), day("2026-10-03"))
+	if err != nil || n != 2 {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "id" || rows[1][6] != `Hello, "world"` || rows[1][1] != "2026-10-01T01:00:00Z" || rows[2][6] != "Second" {
+		t.Errorf("Unexpected CSV %q", rows)
+	}
+
+	buf.Reset()
+	if n, err := Write(ctx, client, &buf, NDJSON, day("2026-10-01"), day("2026-10-02")); err != nil || n != 1 {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	var rec Record
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil || rec.RecordLabel != `Hello, "world"` || rec.Action != "update" {
+		t.Errorf("Unexpected NDJSON %q (%v)", buf.String(), err)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	r, err := ParseRange("2026-10-01", "2026-10-31")
+	if err != nil || r.Days() != 31 || r.String() != "2026-10-01 to 2026-10-31" || r.FileName(CSV) != "admin-actions-2026-10-01-2026-10-31.csv" {
+		t.Errorf("Unexpected range %v (%v)", r, err)
+	}
+	for _, bad := range [][2]string{{"2026-10-31", "2026-10-01"}, {"10/01/2026", "2026-10-31"}, {"2024-01-01", "2026-01-01"}} {
+		if _, err := ParseRange(bad[0], bad[1]); err == nil {
+			t.Errorf("Expected an error for %v", bad)
+		}
+	}
+}
+
+func TestExporter_Daily(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	fs, _ := storage.NewLocal(t.TempDir(), "")
+	outbox := &mail.Outbox{}
+	addAction(t, client, day("2026-10-13").Add(time.Hour), "Monday")
+	addAction(t, client, day("2026-10-14").Add(time.Hour), "Tuesday")
+
+	now := day("2026-10-15").Add(9 * time.Hour)
+	e := &Exporter{Client: client, Format: CSV, Storage: fs, Email: []string{"security@example.com"}, Mailer: outbox, now: func() time.Time { return now }}
+
+	// The first run exports yesterday only
+	if err := e.Daily(ctx); err != nil {
+		t.Fatalf("Daily: %v", err)
+	}
+	f, err := fs.Open(ctx, "audit/admin-actions-2026-10-14-2026-10-14.csv")
+	if err != nil {
+		t.Fatalf("Expected the export in storage: %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	f.Close()
+	if !strings.Contains(string(data), "Tuesday") || strings.Contains(string(data), "Monday") {
+		t.Errorf("Unexpected export %q", data)
+	}
+	msgs := outbox.Messages()
+	if len(msgs) != 1 || string(msgs[0].Attachments[0].Data) != string(data) || !strings.Contains(msgs[0].Text, "SHA-256: ") {
+		t.Fatalf("Expected one email with the export attached, got %d", len(msgs))
+	}
+
+	// Later runs the same day do nothing; after missed days they catch up
+	e.Daily(ctx)
+	if len(outbox.Messages()) != 1 {
+		t.Errorf("Expected no second export of the same day")
+	}
+	now = now.AddDate(0, 0, 2)
+	e.Daily(ctx)
+	if files, _ := fs.List(ctx, "audit/"); len(files) != 3 {
+		t.Errorf("Expected an export for each missed day, got %v", files)
+	}
+}
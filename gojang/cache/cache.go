@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is a key/value store for rendered fragments and other short-lived data.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value for key and whether it was found (and not expired)
+	Get(key string) ([]byte, bool)
+	// Set stores value under key; a ttl <= 0 means the entry never expires
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes a single key
+	Delete(key string)
+	// Take removes key and returns the value it had, in one step, so of
+	// several callers taking the same key only one gets it (single-use values)
+	Take(key string) ([]byte, bool)
+	// DeletePrefix removes every key starting with prefix (used for group invalidation)
+	DeletePrefix(prefix string)
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // Zero means no expiry
+}
+
+// MemoryCache is an in-process Cache backed by a map
+type MemoryCache struct {
+	entries map[string]memoryEntry
+	mu      sync.RWMutex
+	now     func() time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns a cached value if present and not expired
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	if !entry.expiresAt.IsZero() && c.now().After(entry.expiresAt) {
+		c.Delete(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores a value with an optional time-to-live
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+}
+
+// Delete removes a key
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+// Take removes a key and returns its value if it was present and not expired
+func (c *MemoryCache) Take(key string) ([]byte, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	delete(c.entries, key)
+	c.mu.Unlock()
+	if !ok || (!entry.expiresAt.IsZero() && c.now().After(entry.expiresAt)) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// DeletePrefix removes all keys that start with prefix
+func (c *MemoryCache) DeletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Cleanup removes expired entries (call periodically)
+func (c *MemoryCache) Cleanup() {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// StartCleanupRoutine periodically removes expired entries until done is closed
+func (c *MemoryCache) StartCleanupRoutine(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Cleanup()
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_SetGet(t *testing.T) {
+	c := NewMemoryCache()
+	c.Set("posts:list", []byte("<ul></ul>"), time.Minute)
+
+	value, ok := c.Get("posts:list")
+	if !ok {
+		t.Fatal("Expected cached value to be found")
+	}
+	if string(value) != "<ul></ul>" {
+		t.Errorf("Expected cached value, got %q", value)
+	}
+
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Expected missing key not to be found")
+	}
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	now := time.Now()
+	c := NewMemoryCache()
+	c.now = func() time.Time { return now }
+
+	c.Set("short", []byte("a"), time.Second)
+	c.Set("forever", []byte("b"), 0)
+
+	now = now.Add(2 * time.Second)
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("Expected expired entry to be evicted")
+	}
+	if _, ok := c.Get("forever"); !ok {
+		t.Error("Expected entry without ttl to persist")
+	}
+}
+
+func TestMemoryCache_Take(t *testing.T) {
+	now := time.Now()
+	c := NewMemoryCache()
+	c.now = func() time.Time { return now }
+	c.Set("code", []byte("grant"), time.Minute)
+	c.Set("stale", []byte("grant"), time.Second)
+
+	if value, ok := c.Take("code"); !ok || string(value) != "grant" {
+		t.Fatalf("Expected to take the value, got %q, %v", value, ok)
+	}
+	if _, ok := c.Take("code"); ok {
+		t.Error("Expected a taken key to be gone")
+	}
+	now = now.Add(2 * time.Second)
+	if _, ok := c.Take("stale"); ok {
+		t.Error("Expected an expired entry not to be taken")
+	}
+}
+
+// TestMemoryCache_TakeConcurrent tests that only one of several callers taking a key gets it
+func TestMemoryCache_TakeConcurrent(t *testing.T) {
+	c := NewMemoryCache()
+	c.Set("code", []byte("grant"), 0)
+
+	var wg sync.WaitGroup
+	var taken atomic.Int32
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, ok := c.Take("code"); ok {
+				taken.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if taken.Load() != 1 {
+		t.Errorf("Expected one caller to take the key, got %d", taken.Load())
+	}
+}
+
+func TestMemoryCache_DeletePrefix(t *testing.T) {
+	c := NewMemoryCache()
+	c.Set("posts:list:anon", []byte("a"), 0)
+	c.Set("posts:list:user", []byte("b"), 0)
+	c.Set("users:list", []byte("c"), 0)
+
+	c.DeletePrefix("posts:")
+
+	if _, ok := c.Get("posts:list:anon"); ok {
+		t.Error("Expected posts:list:anon to be invalidated")
+	}
+	if _, ok := c.Get("posts:list:user"); ok {
+		t.Error("Expected posts:list:user to be invalidated")
+	}
+	if _, ok := c.Get("users:list"); !ok {
+		t.Error("Expected users:list to survive prefix invalidation")
+	}
+}
+
+func TestMemoryCache_Cleanup(t *testing.T) {
+	now := time.Now()
+	c := NewMemoryCache()
+	c.now = func() time.Time { return now }
+
+	c.Set("a", []byte("a"), time.Second)
+	now = now.Add(time.Minute)
+	c.Cleanup()
+
+	if len(c.entries) != 0 {
+		t.Errorf("Expected 0 entries after cleanup, got %d", len(c.entries))
+	}
+}
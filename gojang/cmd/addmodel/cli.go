@@ -47,7 +47,24 @@ func showExamples() {
 	fmt.Println("     --fields 'name:string:required' \\")
 	fmt.Println("     --timestamps=false")
 
-	fmt.Println(colorize(colorYellow, "\n5. Complex Example:"))
+	fmt.Println(colorize(colorYellow, "\n5. Multi-Step Create Form:"))
+	fmt.Println("   go run ./gojang/cmd/addmodel \\")
+	fmt.Println("     --model Listing \\")
+	fmt.Println("     --fields 'title:string:required,summary:text,price:float:required,stock:int,featured:bool' \\")
+	fmt.Println("     --steps 2")
+
+	fmt.Println(colorize(colorYellow, "\n6. Referencing Other Models:"))
+	fmt.Println("   go run ./gojang/cmd/addmodel \\")
+	fmt.Println("     --model Task \\")
+	fmt.Println("     --fields 'title:string:required,assignee:fk(User):required,external_id:uuid'")
+
+	fmt.Println(colorize(colorYellow, "\n7. Cross-Field Rules:"))
+	fmt.Println("   go run ./gojang/cmd/addmodel \\")
+	fmt.Println("     --model Event \\")
+	fmt.Println("     --fields 'name:string:required,starts_at:time:required,ends_at:time,discount_type:string,coupon:string' \\")
+	fmt.Println("     --rules 'ends_at>starts_at,coupon:required_if:discount_type=coupon'")
+
+	fmt.Println(colorize(colorYellow, "\n8. Complex Example:"))
 	fmt.Println("   go run ./gojang/cmd/addmodel \\")
 	fmt.Println("     --model Article \\")
 	fmt.Println("     --icon '📰' \\")
@@ -56,9 +73,14 @@ func showExamples() {
 	fmt.Println(colorize(colorGreen, "\n📝 Field Format:"))
 	fmt.Println("   name:type[:required]")
 	fmt.Println("   - name: lowercase, snake_case (e.g., 'user_name', 'created_by')")
-	fmt.Println("   - type: string, text, int, float, bool, time")
+	fmt.Println("   - type: string, text, richtext, markdown, json, int, float, money, geo, color, url, phone, bool, time, uuid, fk(Model)")
 	fmt.Println("   - required: optional suffix to make field required")
 
+	fmt.Println(colorize(colorGreen, "\n🔗 Rule Format (--rules):"))
+	fmt.Println("   a>b, a>=b, a<b, a<=b      compare two int, float or time fields")
+	fmt.Println("   a:required_if:b=value     a is required when b equals value")
+	fmt.Println("   a:required_with:b         a is required when b is set")
+
 	fmt.Println(colorize(colorRed, "\n⚠️  Restrictions:"))
 	fmt.Println("   - Cannot use Go reserved keywords (for, func, if, return, etc.)")
 	fmt.Println("   - Cannot use Go built-in types (String, Int, Int16, Error, etc.)")
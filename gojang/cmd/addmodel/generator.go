@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
@@ -27,11 +28,40 @@ func createSchema(path, modelName string, fields []Field, includeTimestamps bool
 		return fmt.Errorf("schema file already exists: %s", path)
 	}
 
+	// Foreign keys need the target schema (models/schema/<model>.go)
+	hasEdges := false
+	for _, field := range fields {
+		if field.Type != "fk" {
+			continue
+		}
+		hasEdges = true
+		target := filepath.Join(filepath.Dir(path), strings.ToLower(field.Ref)+".go")
+		if _, err := os.Stat(target); err != nil {
+			return fmt.Errorf("%s references unknown model %s (no %s)", field.Name, field.Ref, target)
+		}
+	}
+
+	entImports := `"entgo.io/ent"`
+	if hasEdges {
+		entImports += "\n\t\"entgo.io/ent/schema/edge\""
+	}
 	imports := `"time"
 	
-	"entgo.io/ent"
+	` + entImports + `
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"`
+	for _, field := range fields {
+		if field.Type == "json" {
+			imports = "\"encoding/json\"\n\t" + imports
+			break
+		}
+	}
+	for _, field := range fields {
+		if field.Type == "geo" {
+			imports += "\n\t\"github.com/gojangframework/gojang/gojang/utils\""
+			break
+		}
+	}
 
 	// Build fields code
 	var fieldsCode strings.Builder
@@ -40,12 +70,48 @@ func createSchema(path, modelName string, fields []Field, includeTimestamps bool
 	fieldsCode.WriteString("\t\tfield.UUID(\"id\", uuid.UUID{}).\n\t\t\tDefault(uuid.New),\n\t\t\n")
 	
 	for _, field := range fields {
+		if field.Type == "json" {
+			// JSON fields keep the submitted document as-is
+			fieldsCode.WriteString(fmt.Sprintf("\t\tfield.JSON(\"%s\", json.RawMessage{})", field.Name))
+			if !field.Required {
+				fieldsCode.WriteString(".\n\t\t\tOptional()")
+			}
+			fieldsCode.WriteString(",\n\t\t\n")
+			continue
+		}
+		if field.Type == "geo" {
+			// Locations are stored as {"lat": ..., "lng": ...}; see db.WithinDistance
+			fieldsCode.WriteString(fmt.Sprintf("\t\tfield.JSON(\"%s\", &utils.Point{})", field.Name))
+			if !field.Required {
+				fieldsCode.WriteString(".\n\t\t\tOptional()")
+			}
+			fieldsCode.WriteString(",\n\t\t\n")
+			continue
+		}
+		if field.Type == "uuid" || field.Type == "fk" {
+			// Optional IDs are nil (NULL) rather than the zero UUID
+			fieldsCode.WriteString(fmt.Sprintf("\t\tfield.UUID(\"%s\", uuid.UUID{})", field.Name))
+			if !field.Required {
+				fieldsCode.WriteString(".\n\t\t\tOptional().\n\t\t\tNillable()")
+			}
+			fieldsCode.WriteString(",\n\t\t\n")
+			continue
+		}
+		if field.Type == "money" {
+			// Money is stored in minor units so amounts never pick up float rounding
+			fieldsCode.WriteString(fmt.Sprintf("\t\tfield.Int64(\"%s\").\n\t\t\tComment(\"Amount in minor units (e.g., cents)\")", field.Name))
+			if !field.Required {
+				fieldsCode.WriteString(".\n\t\t\tDefault(0)")
+			}
+			fieldsCode.WriteString(",\n\t\t\n")
+			continue
+		}
 		fieldsCode.WriteString(fmt.Sprintf("\t\tfield.%s(\"%s\")", getEntFieldType(field.Type), field.Name))
 
 		// Add field modifiers based on type and requirements
 		if field.Required {
 			// Required field modifiers
-			if field.Type == "string" || field.Type == "text" {
+			if field.Type == "string" || field.Type == "text" || field.Type == "richtext" || field.Type == "markdown" || field.Type == "color" || field.Type == "url" || field.Type == "phone" {
 				fieldsCode.WriteString(".\n\t\t\tNotEmpty()")
 			}
 			if field.Type == "float" {
@@ -75,6 +141,28 @@ func createSchema(path, modelName string, fields []Field, includeTimestamps bool
 		fieldsCode.WriteString("\t\tfield.Time(\"created_at\").\n\t\t\tDefault(time.Now).\n\t\t\tImmutable(),\n")
 	}
 
+	// Foreign keys are many-to-one edges stored in the "<edge>_id" field
+	var edgesCode strings.Builder
+	for _, field := range fields {
+		if field.Type != "fk" {
+			continue
+		}
+		edgesCode.WriteString(fmt.Sprintf("\t\tedge.To(\"%s\", %s.Type).\n\t\t\tField(\"%s\").\n\t\t\tUnique()", edgeName(field), field.Ref, field.Name))
+		if field.Required {
+			edgesCode.WriteString(".\n\t\t\tRequired()")
+		}
+		edgesCode.WriteString(",\n")
+	}
+	edges := ""
+	if hasEdges {
+		edges = fmt.Sprintf(`
+func (%s) Edges() []ent.Edge {
+	return []ent.Edge{
+%s	}
+}
+`, modelName, edgesCode.String())
+	}
+
 	content := fmt.Sprintf(`package schema
 
 import (
@@ -89,7 +177,7 @@ func (%s) Fields() []ent.Field {
 	return []ent.Field{
 %s	}
 }
-`, imports, modelName, modelName, fieldsCode.String())
+%s`, imports, modelName, modelName, fieldsCode.String(), edges)
 
 	return writeFile(path, []byte(content), 0644)
 }
@@ -133,8 +221,9 @@ func addFormStruct(path, modelName string, fields []Field) error {
 	return writeFile(path, []byte(newContent), 0644)
 }
 
-// createHandler creates the handler file
-func createHandler(path, modelName string, fields []Field) error {
+// createHandler creates the handler file. With more than one step, New and
+// Create run a forms.Wizard instead of a single form.
+func createHandler(path, modelName string, fields []Field, steps int) error {
 	// Check if file already exists
 	if _, err := os.Stat(path); err == nil {
 		return fmt.Errorf("handler file already exists: %s", path)
@@ -148,11 +237,33 @@ func createHandler(path, modelName string, fields []Field) error {
 	// Build form field extraction
 	formFieldExtraction := buildFormFieldExtraction(fields)
 
-	// Build field setters for Create
+	// Build field setters for Create (rich text is sanitized before saving)
 	var createSetters strings.Builder
+	needsUtils := false
 	for _, field := range fields {
 		fieldName := toCamelCase(field.Name)
 		setter := fmt.Sprintf("\t\tSet%s(form.%s)", fieldName, fieldName)
+		switch field.Type {
+		case "richtext":
+			setter = fmt.Sprintf("\t\tSet%s(utils.SanitizeHTML(form.%s))", fieldName, fieldName)
+			needsUtils = true
+		case "json":
+			setter = fmt.Sprintf("\t\tSet%s(forms.RawJSON(form.%s))", fieldName, fieldName)
+		case "uuid", "fk":
+			// Validated with the "uuid" tag; a blank optional ID keeps the current value
+			setter = fmt.Sprintf("\t\tSetNillable%s(forms.OptionalUUID(form.%s))", fieldName, fieldName)
+			if field.Required {
+				setter = fmt.Sprintf("\t\tSet%s(uuid.MustParse(form.%s))", fieldName, fieldName)
+			}
+		case "geo":
+			setter = fmt.Sprintf("\t\tSet%s(forms.Point(form.%s))", fieldName, fieldName)
+		case "phone":
+			// Saved in E.164 (e.g., "+14155552671")
+			setter = fmt.Sprintf("\t\tSet%s(forms.Phone(form.%s))", fieldName, fieldName)
+		case "money":
+			// Validated with the "money" tag, so the amount always parses
+			setter = fmt.Sprintf("\t\tSet%s(forms.Money(form.%s))", fieldName, fieldName)
+		}
 		createSetters.WriteString(setter + ".\n")
 	}
 
@@ -176,22 +287,28 @@ func createHandler(path, modelName string, fields []Field) error {
 		importsBuilder.WriteString("\n\t" + `"strconv"`)
 	}
 	importsBuilder.WriteString("\n\t" + `"time"` + "\n\n\t")
+	if steps > 1 {
+		importsBuilder.WriteString(`"github.com/alexedwards/scs/v2"` + "\n\t")
+	}
 	importsBuilder.WriteString(`"github.com/go-chi/chi/v5"` + "\n\t")
 	importsBuilder.WriteString(`"github.com/google/uuid"` + "\n\t")
+	if steps > 1 {
+		importsBuilder.WriteString(`"github.com/gojangframework/gojang/gojang/htmx"` + "\n\t")
+	}
 	importsBuilder.WriteString(`"github.com/gojangframework/gojang/gojang/models"` + "\n\t")
+	if needsUtils {
+		importsBuilder.WriteString(`"github.com/gojangframework/gojang/gojang/utils"` + "\n\t")
+	}
 	importsBuilder.WriteString(`"github.com/gojangframework/gojang/gojang/views/forms"` + "\n\t")
 	importsBuilder.WriteString(`"github.com/gojangframework/gojang/gojang/views/renderers"`)
 	imports := importsBuilder.String()
 
-	content := fmt.Sprintf(`package handlers
-
-import (
-	%s
-)
-
-var _ time.Time // to avoid unused import
-
-type %s struct {
+	var handlerStruct, newCreate string
+	if steps > 1 {
+		handlerStruct = buildWizardHandlerStruct(modelName, fields, steps)
+		newCreate = buildWizardNewCreate(modelName, createSetters.String())
+	} else {
+		handlerStruct = fmt.Sprintf(`type %s struct {
 	Client   *models.Client
 	Renderer *renderers.Renderer
 }
@@ -201,27 +318,8 @@ func New%s(client *models.Client, renderer *renderers.Renderer) *%s {
 		Client:   client,
 		Renderer: renderer,
 	}
-}
-
-// Index lists all %s
-func (h *%s) Index(w http.ResponseWriter, r *http.Request) {
-	%s, err := h.Client.%s.Query().
-		Order(models.Desc("created_at")).
-		All(r.Context())
-	if err != nil {
-		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load %s")
-		return
-	}
-
-	h.Renderer.Render(w, r, "%s/index.html", &renderers.TemplateData{
-		Title: "%s",
-		Data: map[string]interface{}{
-			"%s": %s,
-		},
-	})
-}
-
-// New shows the create form
+}`, handlerName, handlerName, handlerName, handlerName)
+		newCreate = fmt.Sprintf(`// New shows the create form
 func (h *%s) New(w http.ResponseWriter, r *http.Request) {
 	h.Renderer.Render(w, r, "%s/new.partial.html", &renderers.TemplateData{
 		Title: "New %s",
@@ -261,7 +359,42 @@ func (h *%s) Create(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/%s", http.StatusSeeOther)
 }
 
-// Edit shows the edit form
+`,
+			// New
+			handlerName, modelPlural, modelName,
+			// Create
+			modelLower, handlerName, modelName, formFieldExtraction, modelPlural, modelName, modelName, createSetters.String(), modelLower, modelLower, modelPlural)
+	}
+
+	content := fmt.Sprintf(`package handlers
+
+import (
+	%s
+)
+
+var _ time.Time // to avoid unused import
+
+%s
+
+// Index lists all %s
+func (h *%s) Index(w http.ResponseWriter, r *http.Request) {
+	%s, err := h.Client.%s.Query().
+		Order(models.Desc("created_at")).
+		All(r.Context())
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load %s")
+		return
+	}
+
+	h.Renderer.Render(w, r, "%s/index.html", &renderers.TemplateData{
+		Title: "%s",
+		Data: map[string]interface{}{
+			"%s": %s,
+		},
+	})
+}
+
+%s// Edit shows the edit form
 func (h *%s) Edit(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
@@ -347,13 +480,11 @@ func (h *%s) Delete(w http.ResponseWriter, r *http.Request) {
 		// Imports
 		imports,
 		// Handler struct
-		handlerName, handlerName, handlerName, handlerName,
+		handlerStruct,
 		// Index
 		modelPlural, handlerName, modelPlural, modelName, modelPlural, modelPlural, modelName, modelCamelCase, modelPlural,
-		// New
-		handlerName, modelPlural, modelName,
-		// Create
-		modelLower, handlerName, modelName, formFieldExtraction, modelPlural, modelName, modelName, createSetters.String(), modelLower, modelLower, modelPlural,
+		// New and Create
+		newCreate,
 		// Edit
 		handlerName, modelLower, modelName, modelName, modelPlural, modelName, modelName, modelLower,
 		// Update
@@ -364,6 +495,118 @@ func (h *%s) Delete(w http.ResponseWriter, r *http.Request) {
 	return writeFile(path, []byte(content), 0644)
 }
 
+// buildWizardHandlerStruct returns the handler struct and a constructor that
+// sets up the create wizard (one step per field group)
+func buildWizardHandlerStruct(modelName string, fields []Field, steps int) string {
+	handlerName := modelName + "Handler"
+	modelPlural := strings.ToLower(modelName) + "s"
+
+	var stepsCode strings.Builder
+	for i, group := range splitSteps(fields, steps) {
+		names := make([]string, len(group))
+		for j, field := range group {
+			names[j] = `"` + field.Name + `"`
+		}
+		stepsCode.WriteString(fmt.Sprintf("\t\t\tforms.WizardStep{Title: \"Step %d\", Template: \"%s/new_step%d.html\", Fields: []string{%s}},\n",
+			i+1, modelPlural, i+1, strings.Join(names, ", ")))
+	}
+
+	return fmt.Sprintf(`type %s struct {
+	Client   *models.Client
+	Renderer *renderers.Renderer
+	Wizard   *forms.Wizard // Multi-step create form
+}
+
+func New%s(client *models.Client, sessions *scs.SessionManager, renderer *renderers.Renderer) *%s {
+	return &%s{
+		Client:   client,
+		Renderer: renderer,
+		Wizard: forms.NewWizard("%s_new", sessions, forms.%sForm{},
+%s		),
+	}
+}`, handlerName, handlerName, handlerName, handlerName, strings.ToLower(modelName), modelName, stepsCode.String())
+}
+
+// buildWizardNewCreate returns New and Create handlers that walk through the wizard
+func buildWizardNewCreate(modelName, createSetters string) string {
+	handlerName := modelName + "Handler"
+	modelLower := strings.ToLower(modelName)
+	modelPlural := modelLower + "s"
+
+	return fmt.Sprintf(`// New shows the current step of the create wizard (?restart=1 starts over)
+func (h *%s) New(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("restart") {
+		h.Wizard.Reset(r.Context())
+	}
+	h.Renderer.RenderWizardStep(w, r, h.Wizard, &renderers.TemplateData{
+		Title: "New %s",
+	})
+}
+
+// Create handles a wizard step and creates the %s after the last one
+func (h *%s) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form")
+		return
+	}
+
+	errors, done := h.Wizard.Handle(r.Context(), r.Form)
+	if !done {
+		h.Renderer.RenderWizardStep(w, r, h.Wizard, &renderers.TemplateData{
+			Title:  "New %s",
+			Errors: errors,
+		})
+		return
+	}
+
+	var form forms.%sForm
+	if err := h.Wizard.Decode(r.Context(), &form); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form")
+		return
+	}
+
+	_, err := h.Client.%s.Create().
+%s		Save(r.Context())
+
+	if err != nil {
+		log.Printf("Error creating %s: %%v", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to create %s")
+		return
+	}
+	h.Wizard.Reset(r.Context())
+
+	if htmx.IsRequest(r) {
+		htmx.Redirect(w, "/%s")
+		return
+	}
+	http.Redirect(w, r, "/%s", http.StatusSeeOther)
+}
+
+`, handlerName, modelName, modelLower, handlerName, modelName, modelName, modelName, createSetters, modelLower, modelLower, modelPlural, modelPlural)
+}
+
+// splitSteps splits fields into n groups of nearly equal size, in order
+func splitSteps(fields []Field, n int) [][]Field {
+	if n > len(fields) {
+		n = len(fields)
+	}
+	if n < 1 {
+		n = 1
+	}
+	groups := make([][]Field, 0, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		// Earlier steps take the remainder, so 5 fields in 2 steps is 3 + 2
+		size := len(fields) / n
+		if i < len(fields)%n {
+			size++
+		}
+		groups = append(groups, fields[start:start+size])
+		start += size
+	}
+	return groups
+}
+
 // createRoutes creates the routes file
 func createRoutes(path, modelName string) error {
 	// Check if file already exists
@@ -409,8 +652,8 @@ func %sRoutes(handler *handlers.%s, sm *scs.SessionManager, client *models.Clien
 	return writeFile(path, []byte(content), 0644)
 }
 
-// updateMainGo adds route registration to main.go
-func updateMainGo(path, modelName string) error {
+// updateAppGo adds route registration to the app wiring (gojang/app/app.go). Wizard handlers also get the session manager.
+func updateAppGo(path, modelName string, wizard bool) error {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return err
@@ -422,7 +665,7 @@ func updateMainGo(path, modelName string) error {
 
 	// Check if handler already registered
 	if strings.Contains(string(content), handlerName+" := handlers.New"+modelName+"Handler") {
-		return fmt.Errorf("handler %s already registered in main.go", handlerName)
+		return fmt.Errorf("handler %s already registered in app.go", handlerName)
 	}
 
 	// Find position to add handler initialization (after postHandler)
@@ -434,19 +677,25 @@ func updateMainGo(path, modelName string) error {
 	insertPos := postHandlerPos + endOfLine + 1
 
 	// Add handler initialization
-	handlerCode := fmt.Sprintf("\t%s := handlers.New%sHandler(client, publicRenderer)\n", handlerName, modelName)
+	handlerArgs := "client, publicRenderer"
+	if wizard {
+		handlerArgs = "client, sessionManager, publicRenderer"
+	}
+	handlerCode := fmt.Sprintf("\t%s := handlers.New%sHandler(%s)\n", handlerName, modelName, handlerArgs)
 	newContent := string(content[:insertPos]) + handlerCode + string(content[insertPos:])
 
 	// Find position to add route mounting (after posts routes)
-	postsRoutePos := strings.Index(newContent, `r.Mount("/posts", routes.PostRoutes`)
+	postsRoutePos := strings.Index(newContent, `Mount("/posts", routes.PostRoutes`)
 	if postsRoutePos == -1 {
 		return fmt.Errorf("could not find route mounting section")
 	}
+	startOfLine := strings.LastIndex(newContent[:postsRoutePos], "\n") + 1
 	endOfLine = strings.Index(newContent[postsRoutePos:], "\n")
 	insertPos = postsRoutePos + endOfLine + 1
 
-	// Add route mounting
-	routeCode := fmt.Sprintf("\tr.Mount(\"/%s\", routes.%sRoutes(%s, sessionManager, client))\n", modelPlural, modelName, handlerName)
+	// Add route mounting, reusing the posts line's prefix (e.g., "\tr.With(publicTimeout).")
+	mountPrefix := newContent[startOfLine:postsRoutePos]
+	routeCode := fmt.Sprintf("%sMount(\"/%s\", routes.%sRoutes(%s, sessionManager, client))\n", mountPrefix, modelPlural, modelName, handlerName)
 	newContent = newContent[:insertPos] + routeCode + newContent[insertPos:]
 
 	return writeFile(path, []byte(newContent), 0644)
@@ -464,11 +713,15 @@ func registerWithAdmin(path, modelName, modelIcon string, fields []Field) error
 		return fmt.Errorf("model %s already registered in admin", modelName)
 	}
 
-	// Build list fields
+	// Build list fields (foreign keys show the related record)
 	listFields := []string{"ID"}
 	for i, field := range fields {
 		if i < 4 { // Show first 4 fields
-			listFields = append(listFields, toCamelCase(field.Name))
+			if field.Type == "fk" {
+				listFields = append(listFields, toCamelCase(edgeName(field)))
+			} else {
+				listFields = append(listFields, toCamelCase(field.Name))
+			}
 		}
 	}
 
@@ -477,11 +730,44 @@ func registerWithAdmin(path, modelName, modelIcon string, fields []Field) error
 	// Build optional fields (non-required in generator input)
 	optionalFields := []string{}
 	for _, f := range fields {
-		if !f.Required {
+		if !f.Required && f.Type != "fk" {
 			optionalFields = append(optionalFields, toCamelCase(f.Name))
 		}
 	}
 
+	// Rich text and Markdown fields use the admin's editors instead of a plain textarea,
+	// money fields are entered and shown as amounts rather than minor units, and
+	// color, URL and phone fields get matching inputs, validation and display
+	fieldTypes := []string{}
+	for _, f := range fields {
+		switch f.Type {
+		case "richtext":
+			fieldTypes = append(fieldTypes, fmt.Sprintf("%q: FieldTypeRichText", toCamelCase(f.Name)))
+		case "markdown":
+			fieldTypes = append(fieldTypes, fmt.Sprintf("%q: FieldTypeMarkdown", toCamelCase(f.Name)))
+		case "money":
+			fieldTypes = append(fieldTypes, fmt.Sprintf("%q: FieldTypeMoney", toCamelCase(f.Name)))
+		case "color":
+			fieldTypes = append(fieldTypes, fmt.Sprintf("%q: FieldTypeColor", toCamelCase(f.Name)))
+		case "url":
+			fieldTypes = append(fieldTypes, fmt.Sprintf("%q: FieldTypeURL", toCamelCase(f.Name)))
+		case "phone":
+			fieldTypes = append(fieldTypes, fmt.Sprintf("%q: FieldTypePhone", toCamelCase(f.Name)))
+		}
+	}
+
+	// Foreign keys are picked with the relation autocomplete and their edges eager loaded
+	var relations, withEdges strings.Builder
+	for _, f := range fields {
+		if f.Type != "fk" {
+			continue
+		}
+		edge := toCamelCase(edgeName(f))
+		relations.WriteString(fmt.Sprintf("\t\t\t{Name: %q, Label: %q, Type: FieldTypeRelation, RelatedModel: %q, Edge: %q, Required: %t},\n",
+			toCamelCase(f.Name), edge, f.Ref, edge, f.Required))
+		withEdges.WriteString(".With" + edge + "()")
+	}
+
 	// Build registration code with OptionalFields if any
 	var b strings.Builder
 	b.WriteString("\n\t// Register ")
@@ -506,6 +792,20 @@ func registerWithAdmin(path, modelName, modelIcon string, fields []Field) error
 		b.WriteString(strings.Join(optionalFields, "\", \""))
 		b.WriteString("\"},\n")
 	}
+	if len(fieldTypes) > 0 {
+		b.WriteString("\t\tFieldTypes:     map[string]FieldType{")
+		b.WriteString(strings.Join(fieldTypes, ", "))
+		b.WriteString("},\n")
+	}
+	if relations.Len() > 0 {
+		b.WriteString("\t\tCustomFields: []FieldConfig{\n")
+		b.WriteString(relations.String())
+		b.WriteString("\t\t},\n")
+		b.WriteString("\t\tQueryModifier: func(ctx context.Context, query interface{}) interface{} {\n")
+		b.WriteString("\t\t\tif q, ok := query.(*models." + modelName + "Query); ok {\n")
+		b.WriteString("\t\t\t\treturn q" + withEdges.String() + "\n")
+		b.WriteString("\t\t\t}\n\t\t\treturn query\n\t\t},\n")
+	}
 	b.WriteString("\t})\n")
 
 	registrationCode := b.String()
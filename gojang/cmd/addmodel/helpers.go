@@ -5,12 +5,28 @@ import (
 	"strings"
 )
 
-// toPascalCase converts a string to PascalCase
+// entAcronyms are the initialisms Ent keeps upper-case in generated names
+// (e.g., owner_id -> OwnerID, image_url -> ImageURL)
+var entAcronyms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "AWS": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GB": true, "GUID": true, "HCL": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "KB": true, "LHS": true, "MAC": true, "MB": true,
+	"QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true, "SQL": true,
+	"SSH": true, "SSO": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true, "UI": true,
+	"UID": true, "URI": true, "URL": true, "UTF8": true, "UUID": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// toPascalCase converts a string to PascalCase, matching Ent's field names
 func toPascalCase(s string) string {
 	words := strings.FieldsFunc(s, func(r rune) bool {
 		return r == '_' || r == ' ' || r == '-'
 	})
 	for i, word := range words {
+		if upper := strings.ToUpper(word); entAcronyms[upper] {
+			words[i] = upper
+			continue
+		}
 		words[i] = strings.Title(strings.ToLower(word))
 	}
 	return strings.Join(words, "")
@@ -28,7 +44,7 @@ func toCamelCase(s string) string {
 // getGoType returns the Go type for a field type
 func getGoType(fieldType string) string {
 	switch fieldType {
-	case "string", "text":
+	case "string", "text", "richtext", "markdown", "json", "uuid", "fk", "money", "geo", "color", "url", "phone":
 		return "string"
 	case "int":
 		return "int"
@@ -46,10 +62,16 @@ func getGoType(fieldType string) string {
 // getEntFieldType returns the Ent field type for a given type
 func getEntFieldType(fieldType string) string {
 	switch fieldType {
-	case "string":
+	case "string", "color", "url", "phone":
 		return "String"
-	case "text":
+	case "text", "richtext", "markdown":
 		return "Text"
+	case "json", "geo":
+		return "JSON"
+	case "uuid", "fk":
+		return "UUID"
+	case "money":
+		return "Int64"
 	case "int":
 		return "Int"
 	case "float":
@@ -63,19 +85,63 @@ func getEntFieldType(fieldType string) string {
 	}
 }
 
-// getValidationTag returns the validation tag for a field
+// getValidationTag returns the validation tag for a field, including its --rules
 func getValidationTag(field Field) string {
+	tag := typeValidationTag(field)
+	for _, rule := range field.Rules {
+		if strings.HasPrefix(rule, "required_") {
+			// Conditional requirements must run before omitempty skips an empty value
+			tag = rule + "," + tag
+		} else {
+			tag += "," + rule
+		}
+	}
+	return tag
+}
+
+// typeValidationTag returns the validation tag for a field's type
+func typeValidationTag(field Field) string {
 	switch field.Type {
 	case "string":
 		if field.Required {
 			return "required,max=255"
 		}
 		return "omitempty,max=255"
-	case "text":
+	case "text", "richtext", "markdown":
 		if field.Required {
 			return "required"
 		}
 		return "omitempty"
+	case "json":
+		if field.Required {
+			return "required,json"
+		}
+		return "omitempty,json"
+	case "uuid", "fk":
+		if field.Required {
+			return "required,uuid"
+		}
+		return "omitempty,uuid"
+	case "money":
+		if field.Required {
+			return "required,money"
+		}
+		return "omitempty,money"
+	case "geo":
+		if field.Required {
+			return "required,geo"
+		}
+		return "omitempty,geo"
+	case "color", "phone":
+		if field.Required {
+			return "required," + field.Type
+		}
+		return "omitempty," + field.Type
+	case "url":
+		if field.Required {
+			return "required,http_url,max=2048"
+		}
+		return "omitempty,http_url,max=2048"
 	case "int":
 		return "gte=0"
 	case "float":
@@ -92,8 +158,14 @@ func getValidationTag(field Field) string {
 // getInputType returns the HTML input type for a field type
 func getInputType(fieldType string) string {
 	switch fieldType {
-	case "string", "text":
+	case "string", "text", "richtext", "markdown", "json", "uuid", "fk", "money", "geo":
 		return "text"
+	case "color":
+		return "color"
+	case "url":
+		return "url"
+	case "phone":
+		return "tel"
 	case "int":
 		return "number"
 	case "float":
@@ -128,3 +200,8 @@ func buildFormFieldExtraction(fields []Field) string {
 	}
 	return builder.String()
 }
+
+// edgeName returns the Ent edge name of an "fk" field (e.g., "owner" for owner_id)
+func edgeName(field Field) string {
+	return strings.TrimSuffix(field.Name, "_id")
+}
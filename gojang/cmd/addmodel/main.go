@@ -15,6 +15,8 @@ type Field struct {
 	Name     string
 	Type     string
 	Required bool
+	Ref      string   // Target model of an "fk" field (e.g., "User")
+	Rules    []string // Cross-field validator tags from --rules (e.g., "gtfield=StartsAt")
 }
 
 var dryRun bool
@@ -26,6 +28,8 @@ func main() {
 	fieldsFlag := flag.String("fields", "", "Comma-separated fields (e.g., 'name:string:required,price:float,stock:int')")
 	dryRunFlag := flag.Bool("dry-run", false, "Preview changes without writing files")
 	timestampsFlag := flag.Bool("timestamps", true, "Add created_at and updated_at fields (default: true)")
+	stepsFlag := flag.Int("steps", 1, "Split the create form into this many wizard steps (default: 1, a single form)")
+	rulesFlag := flag.String("rules", "", "Comma-separated cross-field rules (e.g., 'ends_at>starts_at,coupon:required_if:discount_type=coupon')")
 	helpExamples := flag.Bool("examples", false, "Show usage examples and exit")
 	flag.Parse()
 
@@ -67,8 +71,24 @@ func main() {
 		log.Fatal("❌ At least one field is required")
 	}
 
+	for _, field := range fields {
+		if field.Type == "fk" && field.Ref == modelName {
+			log.Fatalf("❌ %s can't reference its own model; add self-references to the schema by hand", field.Name)
+		}
+	}
+
+	if *rulesFlag != "" {
+		if err := applyRules(fields, *rulesFlag); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+	}
+
+	if *stepsFlag < 1 || *stepsFlag > len(fields) {
+		log.Fatalf("❌ --steps must be between 1 and the number of fields (%d)", len(fields))
+	}
+
 	// Print summary
-	printSummary(modelName, modelIcon, fields, *dryRunFlag)
+	printSummary(modelName, modelIcon, fields, *stepsFlag, *dryRunFlag)
 
 	// Skip confirmation in non-interactive mode
 	if *modelNameFlag == "" && !confirmCreation() {
@@ -86,7 +106,7 @@ func main() {
 	dryRun = *dryRunFlag
 
 	// Execute model creation steps
-	executeModelCreation(projectRoot, modelName, modelIcon, fields, *timestampsFlag, *dryRunFlag)
+	executeModelCreation(projectRoot, modelName, modelIcon, fields, *stepsFlag, *timestampsFlag, *dryRunFlag)
 }
 
 // parseFieldsFromString parses the fields flag string into Field structs
@@ -105,6 +125,9 @@ func parseFieldsFromString(fieldsStr string) []Field {
 			Type:     parts[1],
 			Required: len(parts) > 2 && parts[2] == "required",
 		}
+		if target, ok := parseFKType(field.Type); ok {
+			field = fkField(field.Name, target, field.Required)
+		}
 
 		// Validate field
 		if err := validateField(field); err != nil {
@@ -131,11 +154,16 @@ func validateField(field Field) error {
 
 	// Validate field type
 	validTypes := map[string]bool{
-		"string": true, "text": true, "int": true,
-		"float": true, "bool": true, "time": true,
+		"string": true, "text": true, "richtext": true, "markdown": true, "json": true, "int": true,
+		"float": true, "money": true, "geo": true, "color": true, "url": true, "phone": true, "bool": true, "time": true, "uuid": true, "fk": true,
 	}
 	if !validTypes[field.Type] {
-		return fmt.Errorf("invalid field type: %s (supported: string, text, int, float, bool, time)", field.Type)
+		return fmt.Errorf("invalid field type: %s (supported: string, text, richtext, markdown, json, int, float, money, geo, color, url, phone, bool, time, uuid, fk(Model))", field.Type)
+	}
+
+	// Foreign keys must name a model (e.g., fk(User))
+	if field.Type == "fk" && !isValidModelName(field.Ref) {
+		return fmt.Errorf("invalid foreign key target for %s: %s (use fk(Model), e.g., fk(User))", field.Name, field.Ref)
 	}
 
 	return nil
@@ -183,7 +211,7 @@ func runInteractiveMode() (string, string, []Field) {
 	fmt.Println()
 	fmt.Println("Enter fields for the model (press Enter without input to finish):")
 	fmt.Println("Format: name:type (e.g., 'name:string', 'price:float', 'stock:int', 'active:bool')")
-	fmt.Println("Supported types: string, text, int, float, bool, time")
+	fmt.Println("Supported types: string, text, richtext, markdown, json, int, float, money, geo, color, url, phone, bool, time, uuid, fk(Model)")
 
 	var fields []Field
 	for {
@@ -219,7 +247,7 @@ func runInteractiveMode() (string, string, []Field) {
 }
 
 // printSummary prints the model creation summary
-func printSummary(modelName, modelIcon string, fields []Field, isDryRun bool) {
+func printSummary(modelName, modelIcon string, fields []Field, steps int, isDryRun bool) {
 	fmt.Println()
 	if isDryRun {
 		fmt.Println("🔍 DRY RUN MODE - No files will be created")
@@ -234,7 +262,18 @@ func printSummary(modelName, modelIcon string, fields []Field, isDryRun bool) {
 		if field.Required {
 			req = " (required)"
 		}
-		fmt.Printf("    - %s: %s%s\n", field.Name, field.Type, req)
+		fieldType := field.Type
+		if field.Type == "fk" {
+			fieldType = "fk(" + field.Ref + ")"
+		}
+		rules := ""
+		if len(field.Rules) > 0 {
+			rules = " [" + strings.Join(field.Rules, ", ") + "]"
+		}
+		fmt.Printf("    - %s: %s%s%s\n", field.Name, fieldType, req, rules)
+	}
+	if steps > 1 {
+		fmt.Printf("  Create form: %d-step wizard\n", steps)
 	}
 	fmt.Println()
 }
@@ -249,7 +288,7 @@ func confirmCreation() bool {
 }
 
 // executeModelCreation runs all the steps to create a model
-func executeModelCreation(projectRoot, modelName, modelIcon string, fields []Field, includeTimestamps, isDryRun bool) {
+func executeModelCreation(projectRoot, modelName, modelIcon string, fields []Field, steps int, includeTimestamps, isDryRun bool) {
 	// Step 1: Create Ent schema
 	fmt.Println()
 	fmt.Println("📝 Step 1: Creating Ent schema...")
@@ -281,7 +320,7 @@ func executeModelCreation(projectRoot, modelName, modelIcon string, fields []Fie
 	fmt.Println()
 	fmt.Println("📝 Step 4: Creating handler...")
 	handlerPath := filepath.Join(projectRoot, "gojang", "http", "handlers", strings.ToLower(modelName)+"s.go")
-	if err := createHandler(handlerPath, modelName, fields); err != nil {
+	if err := createHandler(handlerPath, modelName, fields, steps); err != nil {
 		log.Fatalf("❌ Failed to create handler: %v", err)
 	}
 	fmt.Printf("✅ Created: %s\n", handlerPath)
@@ -295,12 +334,12 @@ func executeModelCreation(projectRoot, modelName, modelIcon string, fields []Fie
 	}
 	fmt.Printf("✅ Created: %s\n", routesPath)
 
-	// Step 6: Update main.go
+	// Step 6: Update app.go
 	fmt.Println()
-	fmt.Println("📝 Step 6: Registering routes in main.go...")
-	mainPath := filepath.Join(projectRoot, "gojang", "cmd", "web", "main.go")
-	if err := updateMainGo(mainPath, modelName); err != nil {
-		log.Fatalf("❌ Failed to update main.go: %v", err)
+	fmt.Println("📝 Step 6: Registering routes in app.go...")
+	appPath := filepath.Join(projectRoot, "gojang", "app", "app.go")
+	if err := updateAppGo(appPath, modelName, steps > 1); err != nil {
+		log.Fatalf("❌ Failed to update app.go: %v", err)
 	}
 	fmt.Println("✅ Routes registered")
 
@@ -308,7 +347,7 @@ func executeModelCreation(projectRoot, modelName, modelIcon string, fields []Fie
 	fmt.Println()
 	fmt.Println("📝 Step 7: Creating templates...")
 	templatePath := filepath.Join(projectRoot, "gojang", "views", "templates", strings.ToLower(modelName)+"s")
-	if err := createTemplates(templatePath, modelName, fields); err != nil {
+	if err := createTemplates(templatePath, modelName, fields, steps); err != nil {
 		log.Fatalf("❌ Failed to create templates: %v", err)
 	}
 	fmt.Printf("✅ Created templates in: %s\n", templatePath)
@@ -323,7 +362,7 @@ func executeModelCreation(projectRoot, modelName, modelIcon string, fields []Fie
 	fmt.Println("✅ Registered with admin panel")
 
 	// Success message
-	printSuccessMessage(modelName, isDryRun, schemaPath, modelsPath, formsPath, handlerPath, routesPath, mainPath, templatePath, adminModelsPath)
+	printSuccessMessage(modelName, isDryRun, schemaPath, modelsPath, formsPath, handlerPath, routesPath, appPath, templatePath, adminModelsPath)
 }
 
 // printSuccessMessage prints the final success message
@@ -336,7 +375,7 @@ func printSuccessMessage(modelName string, isDryRun bool, paths ...string) {
 		for _, path := range paths {
 			if strings.Contains(path, "models") && !strings.Contains(path, "schema") {
 				fmt.Printf("  - %s (generated)\n", path)
-			} else if strings.Contains(path, "forms") || strings.Contains(path, "main.go") || strings.Contains(path, "admin") {
+			} else if strings.Contains(path, "forms") || strings.Contains(path, "app.go") || strings.Contains(path, "admin") {
 				fmt.Printf("  - %s (modified)\n", path)
 			} else if strings.Contains(path, "templates") {
 				fmt.Printf("  - %s (directory with templates)\n", path)
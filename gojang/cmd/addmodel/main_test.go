@@ -1,12 +1,43 @@
 package main
 
 import (
+	"fmt"
+	"html/template"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/gojangframework/gojang/gojang/views/components"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
 )
 
+// renderTemplate renders a generated template's "content" with the shared
+// components, as the page renderer would
+func renderTemplate(t *testing.T, path string, data *renderers.TemplateData) string {
+	t.Helper()
+	funcs := components.Funcs()
+	funcs["add"] = func(a, b int) int { return a + b }
+	funcs["sub"] = func(a, b int) int { return a - b }
+	funcs["mapTileURL"] = func() string { return "https://tile.openstreetmap.org/{z}/{x}/{y}.png" }
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(funcs).ParseFiles(path)
+	if err != nil {
+		t.Fatalf("Parsing %s: %v", path, err)
+	}
+	if _, err := os.Stat(components.Dir); err != nil {
+		t.Chdir("../../..") // Components are loaded relative to the repository root
+	}
+	if err := components.Parse(tmpl); err != nil {
+		t.Fatalf("Parsing components: %v", err)
+	}
+	var out strings.Builder
+	if err := tmpl.ExecuteTemplate(&out, "content", data); err != nil {
+		t.Fatalf("Rendering %s: %v", path, err)
+	}
+	return out.String()
+}
+
 func TestIsValidModelName(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -87,6 +118,23 @@ func TestParseField(t *testing.T) {
 			wantField: Field{Name: "name", Type: "string", Required: false},
 			wantErr:   false,
 		},
+		{
+			name:      "valid uuid field",
+			input:     "owner_id:uuid",
+			wantField: Field{Name: "owner_id", Type: "uuid", Required: false},
+			wantErr:   false,
+		},
+		{
+			name:      "foreign key field",
+			input:     "author:fk(User)",
+			wantField: Field{Name: "author_id", Type: "fk", Ref: "User"},
+			wantErr:   false,
+		},
+		{
+			name:    "foreign key to invalid model",
+			input:   "author:fk(user)",
+			wantErr: true,
+		},
 		{
 			name:      "valid int field",
 			input:     "count:int",
@@ -199,6 +247,8 @@ func TestToCamelCase(t *testing.T) {
 		{"simple", "name", "Name"},
 		{"snake_case", "product_name", "ProductName"},
 		{"kebab-case", "product-name", "ProductName"},
+		{"acronym", "owner_id", "OwnerID"},
+		{"acronym in middle", "image_url_text", "ImageURLText"},
 	}
 
 	for _, tt := range tests {
@@ -218,6 +268,13 @@ func TestGetEntFieldType(t *testing.T) {
 	}{
 		{"string", "String"},
 		{"text", "Text"},
+		{"richtext", "Text"},
+		{"markdown", "Text"},
+		{"json", "JSON"},
+		{"uuid", "UUID"},
+		{"fk", "UUID"},
+		{"money", "Int64"},
+		{"geo", "JSON"},
 		{"int", "Int"},
 		{"float", "Float"},
 		{"bool", "Bool"},
@@ -242,6 +299,7 @@ func TestGetGoType(t *testing.T) {
 	}{
 		{"string", "string"},
 		{"text", "string"},
+		{"richtext", "string"},
 		{"int", "int"},
 		{"float", "float64"},
 		{"bool", "bool"},
@@ -300,6 +358,31 @@ func TestGetValidationTag(t *testing.T) {
 			field: Field{Name: "active", Type: "bool", Required: false},
 			want:  "omitempty",
 		},
+		{
+			name:  "required color",
+			field: Field{Name: "brand_color", Type: "color", Required: true},
+			want:  "required,color",
+		},
+		{
+			name:  "optional url",
+			field: Field{Name: "website", Type: "url", Required: false},
+			want:  "omitempty,http_url,max=2048",
+		},
+		{
+			name:  "optional phone",
+			field: Field{Name: "phone", Type: "phone", Required: false},
+			want:  "omitempty,phone",
+		},
+		{
+			name:  "time with comparison rule",
+			field: Field{Name: "ends_at", Type: "time", Rules: []string{"gtfield=StartsAt"}},
+			want:  "omitempty,gtfield=StartsAt",
+		},
+		{
+			name:  "string with conditional rule",
+			field: Field{Name: "coupon", Type: "string", Rules: []string{"required_if=DiscountType coupon"}},
+			want:  "required_if=DiscountType coupon,omitempty,max=255",
+		},
 	}
 
 	for _, tt := range tests {
@@ -323,6 +406,9 @@ func TestGetInputType(t *testing.T) {
 		{"float", "number"},
 		{"bool", "checkbox"},
 		{"time", "datetime-local"},
+		{"color", "color"},
+		{"url", "url"},
+		{"phone", "tel"},
 		{"unknown", "text"},
 	}
 
@@ -456,7 +542,7 @@ func TestCreateHandler(t *testing.T) {
 		{Name: "price", Type: "float", Required: false},
 	}
 
-	err := createHandler(handlerPath, "Product", fields)
+	err := createHandler(handlerPath, "Product", fields, 1)
 	if err != nil {
 		t.Fatalf("createHandler failed: %v", err)
 	}
@@ -491,6 +577,459 @@ func TestCreateHandler(t *testing.T) {
 	}
 }
 
+func TestCreateHandler_Wizard(t *testing.T) {
+	tmpDir := t.TempDir()
+	handlerPath := filepath.Join(tmpDir, "products.go")
+
+	fields := []Field{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "price", Type: "float", Required: true},
+		{Name: "stock", Type: "int"},
+	}
+
+	if err := createHandler(handlerPath, "Product", fields, 2); err != nil {
+		t.Fatalf("createHandler failed: %v", err)
+	}
+
+	content, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatalf("Failed to read handler file: %v", err)
+	}
+
+	contentStr := string(content)
+	expectedStrings := []string{
+		`"github.com/alexedwards/scs/v2"`,
+		`"github.com/gojangframework/gojang/gojang/htmx"`,
+		`htmx.Redirect(w, "/products")`,
+		"Wizard   *forms.Wizard",
+		"func NewProductHandler(client *models.Client, sessions *scs.SessionManager, renderer *renderers.Renderer)",
+		`forms.NewWizard("product_new", sessions, forms.ProductForm{},`,
+		`forms.WizardStep{Title: "Step 1", Template: "products/new_step1.html", Fields: []string{"name", "price"}},`,
+		`forms.WizardStep{Title: "Step 2", Template: "products/new_step2.html", Fields: []string{"stock"}},`,
+		"h.Wizard.Handle(r.Context(), r.Form)",
+		"h.Wizard.Decode(r.Context(), &form)",
+		"h.Renderer.RenderWizardStep(w, r, h.Wizard,",
+		`log.Printf("Error creating product: %v", err)`,
+		"SetStock(form.Stock)",
+		"func (h *ProductHandler) Update",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("Handler content missing expected string: %q", expected)
+		}
+	}
+	if strings.Contains(contentStr, "new.partial.html") {
+		t.Error("Wizard handler should not render the single-form template")
+	}
+}
+
+func TestCreateHandler_RichText(t *testing.T) {
+	tmpDir := t.TempDir()
+	handlerPath := filepath.Join(tmpDir, "articles.go")
+
+	fields := []Field{
+		{Name: "title", Type: "string", Required: true},
+		{Name: "body", Type: "richtext", Required: true},
+	}
+
+	if err := createHandler(handlerPath, "Article", fields, 1); err != nil {
+		t.Fatalf("createHandler failed: %v", err)
+	}
+
+	content, err := os.ReadFile(handlerPath)
+	if err != nil {
+		t.Fatalf("Failed to read handler file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, expected := range []string{
+		`"github.com/gojangframework/gojang/gojang/utils"`,
+		"SetBody(utils.SanitizeHTML(form.Body))",
+		"SetTitle(form.Title)",
+	} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("Handler content missing expected string: %q", expected)
+		}
+	}
+
+	formPath := filepath.Join(tmpDir, "edit.partial.html")
+	if err := createFormTemplate(formPath, "Article", "Article", "articles", fields, "edit"); err != nil {
+		t.Fatalf("createFormTemplate failed: %v", err)
+	}
+	form, _ := os.ReadFile(formPath)
+	if !strings.Contains(string(form), `"Type" "richtext"`) {
+		t.Error("Rich text field should use the editor textarea")
+	}
+
+	adminPath := filepath.Join(tmpDir, "models.go")
+	os.WriteFile(adminPath, []byte("package admin\n\nfunc RegisterModels(registry *Registry) {\n}\n"), 0644)
+	if err := registerWithAdmin(adminPath, "Article", "📝", fields); err != nil {
+		t.Fatalf("registerWithAdmin failed: %v", err)
+	}
+	admin, _ := os.ReadFile(adminPath)
+	if !strings.Contains(string(admin), `FieldTypes:     map[string]FieldType{"Body": FieldTypeRichText}`) {
+		t.Errorf("Admin registration should use the rich text editor, got:\n%s", admin)
+	}
+}
+
+func TestCreateFormTemplate_Markdown(t *testing.T) {
+	tmpDir := t.TempDir()
+	fields := []Field{{Name: "notes", Type: "markdown"}}
+
+	formPath := filepath.Join(tmpDir, "new.partial.html")
+	if err := createFormTemplate(formPath, "Recipe", "Recipe", "recipes", fields, "new"); err != nil {
+		t.Fatalf("createFormTemplate failed: %v", err)
+	}
+	content := renderTemplate(t, formPath, &renderers.TemplateData{Data: map[string]interface{}{}})
+	for _, expected := range []string{
+		`hx-post="/markdown/preview?field=notes"`,
+		`hx-target="#notes-preview"`,
+		`hx-swap="innerHTML"`,
+		`<div id="notes-preview" class="markdown-preview richtext-content"></div>`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Form template missing expected string: %q", expected)
+		}
+	}
+
+	adminPath := filepath.Join(tmpDir, "models.go")
+	os.WriteFile(adminPath, []byte("package admin\n\nfunc RegisterModels(registry *Registry) {\n}\n"), 0644)
+	if err := registerWithAdmin(adminPath, "Recipe", "🍲", fields); err != nil {
+		t.Fatalf("registerWithAdmin failed: %v", err)
+	}
+	admin, _ := os.ReadFile(adminPath)
+	if !strings.Contains(string(admin), `FieldTypes:     map[string]FieldType{"Notes": FieldTypeMarkdown}`) {
+		t.Errorf("Admin registration should use the Markdown editor, got:\n%s", admin)
+	}
+}
+
+func TestCreateModel_JSONField(t *testing.T) {
+	tmpDir := t.TempDir()
+	fields := []Field{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "options", Type: "json"},
+	}
+
+	schemaPath := filepath.Join(tmpDir, "widget.go")
+	if err := createSchema(schemaPath, "Widget", fields, true); err != nil {
+		t.Fatalf("createSchema failed: %v", err)
+	}
+	schema, _ := os.ReadFile(schemaPath)
+	for _, expected := range []string{`"encoding/json"`, "field.JSON(\"options\", json.RawMessage{}).\n\t\t\tOptional()"} {
+		if !strings.Contains(string(schema), expected) {
+			t.Errorf("Schema missing expected string: %q", expected)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "widgets.go")
+	if err := createHandler(handlerPath, "Widget", fields, 1); err != nil {
+		t.Fatalf("createHandler failed: %v", err)
+	}
+	handler, _ := os.ReadFile(handlerPath)
+	if !strings.Contains(string(handler), "SetOptions(forms.RawJSON(form.Options))") {
+		t.Error("Handler should convert the JSON form value with forms.RawJSON")
+	}
+
+	if tag := getValidationTag(fields[1]); tag != "omitempty,json" {
+		t.Errorf("Expected omitempty,json, got %q", tag)
+	}
+}
+
+func TestCreateModel_ForeignKeyField(t *testing.T) {
+	tmpDir := t.TempDir()
+	fields := parseFieldsFromString("title:string:required,owner:fk(User):required,reviewer_id:fk(User),external_id:uuid")
+	if fields[1].Name != "owner_id" || fields[2].Name != "reviewer_id" {
+		t.Fatalf("Expected fk fields to be stored as <edge>_id, got %+v", fields)
+	}
+
+	// The target schema must exist
+	schemaPath := filepath.Join(tmpDir, "task.go")
+	if err := createSchema(schemaPath, "Task", fields, true); err == nil {
+		t.Fatal("Expected an error for a missing User schema")
+	}
+	os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte("package schema\n"), 0644)
+	if err := createSchema(schemaPath, "Task", fields, true); err != nil {
+		t.Fatalf("createSchema failed: %v", err)
+	}
+	schema, _ := os.ReadFile(schemaPath)
+	for _, expected := range []string{
+		`"entgo.io/ent/schema/edge"`,
+		"field.UUID(\"owner_id\", uuid.UUID{}),",
+		"field.UUID(\"reviewer_id\", uuid.UUID{}).\n\t\t\tOptional().\n\t\t\tNillable(),",
+		"field.UUID(\"external_id\", uuid.UUID{}).\n\t\t\tOptional().\n\t\t\tNillable(),",
+		"edge.To(\"owner\", User.Type).\n\t\t\tField(\"owner_id\").\n\t\t\tUnique().\n\t\t\tRequired(),",
+		"edge.To(\"reviewer\", User.Type).\n\t\t\tField(\"reviewer_id\").\n\t\t\tUnique(),",
+	} {
+		if !strings.Contains(string(schema), expected) {
+			t.Errorf("Schema missing expected string: %q", expected)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "tasks.go")
+	if err := createHandler(handlerPath, "Task", fields, 1); err != nil {
+		t.Fatalf("createHandler failed: %v", err)
+	}
+	handler, _ := os.ReadFile(handlerPath)
+	for _, expected := range []string{
+		"SetOwnerID(uuid.MustParse(form.OwnerID))",
+		"SetNillableReviewerID(forms.OptionalUUID(form.ReviewerID))",
+		"SetNillableExternalID(forms.OptionalUUID(form.ExternalID))",
+	} {
+		if !strings.Contains(string(handler), expected) {
+			t.Errorf("Handler missing expected string: %q", expected)
+		}
+	}
+
+	adminPath := filepath.Join(tmpDir, "models.go")
+	os.WriteFile(adminPath, []byte("package admin\n\nfunc RegisterModels(registry *Registry) {\n}\n"), 0644)
+	if err := registerWithAdmin(adminPath, "Task", "📋", fields); err != nil {
+		t.Fatalf("registerWithAdmin failed: %v", err)
+	}
+	admin, _ := os.ReadFile(adminPath)
+	for _, expected := range []string{
+		`ListFields:     []string{"ID", "Title", "Owner", "Reviewer", "ExternalID"}`,
+		`OptionalFields: []string{"ExternalID"}`,
+		`{Name: "OwnerID", Label: "Owner", Type: FieldTypeRelation, RelatedModel: "User", Edge: "Owner", Required: true},`,
+		`{Name: "ReviewerID", Label: "Reviewer", Type: FieldTypeRelation, RelatedModel: "User", Edge: "Reviewer", Required: false},`,
+		"return q.WithOwner().WithReviewer()",
+	} {
+		if !strings.Contains(string(admin), expected) {
+			t.Errorf("Admin registration missing expected string: %q", expected)
+		}
+	}
+
+	if tag := getValidationTag(fields[1]); tag != "required,uuid" {
+		t.Errorf("Expected required,uuid, got %q", tag)
+	}
+}
+
+func TestCreateModel_MoneyField(t *testing.T) {
+	tmpDir := t.TempDir()
+	fields := parseFieldsFromString("name:string:required,price:money:required,discount:money")
+
+	schemaPath := filepath.Join(tmpDir, "product.go")
+	if err := createSchema(schemaPath, "Product", fields, true); err != nil {
+		t.Fatalf("createSchema failed: %v", err)
+	}
+	schema, _ := os.ReadFile(schemaPath)
+	for _, expected := range []string{
+		"field.Int64(\"price\").\n\t\t\tComment(\"Amount in minor units (e.g., cents)\"),",
+		"field.Int64(\"discount\").\n\t\t\tComment(\"Amount in minor units (e.g., cents)\").\n\t\t\tDefault(0),",
+	} {
+		if !strings.Contains(string(schema), expected) {
+			t.Errorf("Schema missing expected string: %q", expected)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "products.go")
+	if err := createHandler(handlerPath, "Product", fields, 1); err != nil {
+		t.Fatalf("createHandler failed: %v", err)
+	}
+	handler, _ := os.ReadFile(handlerPath)
+	if !strings.Contains(string(handler), "SetPrice(forms.Money(form.Price))") {
+		t.Error("Handler should convert the amount with forms.Money")
+	}
+
+	indexPath := filepath.Join(tmpDir, "index.html")
+	if err := createIndexTemplate(indexPath, "Product", "Product", "products", fields); err != nil {
+		t.Fatalf("createIndexTemplate failed: %v", err)
+	}
+	index, _ := os.ReadFile(indexPath)
+	if !strings.Contains(string(index), "{{money .Price}}") {
+		t.Errorf("Index should format amounts with money, got:\n%s", index)
+	}
+
+	adminPath := filepath.Join(tmpDir, "models.go")
+	os.WriteFile(adminPath, []byte("package admin\n\nfunc RegisterModels(registry *Registry) {\n}\n"), 0644)
+	if err := registerWithAdmin(adminPath, "Product", "🏷️", fields); err != nil {
+		t.Fatalf("registerWithAdmin failed: %v", err)
+	}
+	admin, _ := os.ReadFile(adminPath)
+	if !strings.Contains(string(admin), `FieldTypes:     map[string]FieldType{"Price": FieldTypeMoney, "Discount": FieldTypeMoney}`) {
+		t.Errorf("Admin registration should use money fields, got:\n%s", admin)
+	}
+
+	if tag := getValidationTag(fields[1]); tag != "required,money" {
+		t.Errorf("Expected required,money, got %q", tag)
+	}
+}
+
+func TestCreateModel_GeoField(t *testing.T) {
+	tmpDir := t.TempDir()
+	fields := parseFieldsFromString("name:string:required,location:geo")
+
+	schemaPath := filepath.Join(tmpDir, "store.go")
+	if err := createSchema(schemaPath, "Store", fields, true); err != nil {
+		t.Fatalf("createSchema failed: %v", err)
+	}
+	schema, _ := os.ReadFile(schemaPath)
+	for _, expected := range []string{
+		`"github.com/gojangframework/gojang/gojang/utils"`,
+		"field.JSON(\"location\", &utils.Point{}).\n\t\t\tOptional(),",
+	} {
+		if !strings.Contains(string(schema), expected) {
+			t.Errorf("Schema missing expected string: %q", expected)
+		}
+	}
+
+	handlerPath := filepath.Join(tmpDir, "stores.go")
+	if err := createHandler(handlerPath, "Store", fields, 1); err != nil {
+		t.Fatalf("createHandler failed: %v", err)
+	}
+	handler, _ := os.ReadFile(handlerPath)
+	if !strings.Contains(string(handler), "SetLocation(forms.Point(form.Location))") {
+		t.Error("Handler should convert the location with forms.Point")
+	}
+
+	formPath := filepath.Join(tmpDir, "new.partial.html")
+	if err := createFormTemplate(formPath, "Store", "Store", "stores", fields, "new"); err != nil {
+		t.Fatalf("createFormTemplate failed: %v", err)
+	}
+	form := renderTemplate(t, formPath, &renderers.TemplateData{Data: map[string]interface{}{}})
+	if !strings.Contains(form, `data-geo="https://tile.openstreetmap.org/{z}/{x}/{y}.png"`) {
+		t.Errorf("Form should use the map picker, got:\n%s", form)
+	}
+
+	indexPath := filepath.Join(tmpDir, "index.html")
+	if err := createIndexTemplate(indexPath, "Store", "Store", "stores", fields); err != nil {
+		t.Fatalf("createIndexTemplate failed: %v", err)
+	}
+	index, _ := os.ReadFile(indexPath)
+	if !strings.Contains(string(index), "{{staticMap .Location}}") {
+		t.Errorf("Index should show a static map, got:\n%s", index)
+	}
+
+	if tag := getValidationTag(fields[1]); tag != "omitempty,geo" {
+		t.Errorf("Expected omitempty,geo, got %q", tag)
+	}
+}
+
+func TestCreateModel_ColorURLPhoneFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	fields := parseFieldsFromString("name:string:required,brand_color:color,website:url,phone:phone:required")
+
+	handlerPath := filepath.Join(tmpDir, "vendors.go")
+	if err := createHandler(handlerPath, "Vendor", fields, 1); err != nil {
+		t.Fatalf("createHandler failed: %v", err)
+	}
+	handler, _ := os.ReadFile(handlerPath)
+	if !strings.Contains(string(handler), "SetPhone(forms.Phone(form.Phone))") {
+		t.Error("Handler should normalize the phone number with forms.Phone")
+	}
+
+	indexPath := filepath.Join(tmpDir, "index.html")
+	if err := createIndexTemplate(indexPath, "Vendor", "Vendor", "vendors", fields); err != nil {
+		t.Fatalf("createIndexTemplate failed: %v", err)
+	}
+	index, _ := os.ReadFile(indexPath)
+	for _, expected := range []string{"{{colorSwatch .BrandColor}}", "{{urlLink .Website}}", "{{phoneLink .Phone}}"} {
+		if !strings.Contains(string(index), expected) {
+			t.Errorf("Index missing expected string: %q", expected)
+		}
+	}
+
+	adminPath := filepath.Join(tmpDir, "models.go")
+	os.WriteFile(adminPath, []byte("package admin\n\nfunc RegisterModels(registry *Registry) {\n}\n"), 0644)
+	if err := registerWithAdmin(adminPath, "Vendor", "🏪", fields); err != nil {
+		t.Fatalf("registerWithAdmin failed: %v", err)
+	}
+	admin, _ := os.ReadFile(adminPath)
+	if !strings.Contains(string(admin), `FieldTypes:     map[string]FieldType{"BrandColor": FieldTypeColor, "Website": FieldTypeURL, "Phone": FieldTypePhone}`) {
+		t.Errorf("Admin registration should set the field types, got:\n%s", admin)
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	fields := parseFieldsFromString("starts_at:time:required,ends_at:time,min_guests:int,max_guests:int,discount_type:string,coupon:string,title:string:required")
+	err := applyRules(fields, "ends_at>starts_at, max_guests>=min_guests,coupon:required_if:discount_type=coupon,coupon:required_with:ends_at")
+	if err != nil {
+		t.Fatalf("applyRules failed: %v", err)
+	}
+	expected := map[string]string{
+		"ends_at":    "omitempty,gtfield=StartsAt",
+		"max_guests": "gte=0,gtefield=MinGuests",
+		"coupon":     "required_with=EndsAt,required_if=DiscountType coupon,omitempty,max=255",
+	}
+	for _, field := range fields {
+		if want, ok := expected[field.Name]; ok && getValidationTag(field) != want {
+			t.Errorf("%s tag = %q, want %q", field.Name, getValidationTag(field), want)
+		}
+	}
+
+	for _, rules := range []string{
+		"ends_at>missing",
+		"ends_at>ends_at",
+		"max_guests>starts_at",
+		"coupon>discount_type",
+		"title:required_if:coupon=x",
+		"coupon:required_if:discount_type",
+		"coupon:required_unless:discount_type=x",
+		"ends_at starts_at",
+	} {
+		if err := applyRules(parseFieldsFromString("starts_at:time,ends_at:time,max_guests:int,discount_type:string,coupon:string,title:string:required"), rules); err == nil {
+			t.Errorf("applyRules(%q) should fail", rules)
+		}
+	}
+}
+
+func TestSplitSteps(t *testing.T) {
+	fields := []Field{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+
+	tests := []struct {
+		steps    int
+		expected []int
+	}{
+		{1, []int{5}},
+		{2, []int{3, 2}},
+		{3, []int{2, 2, 1}},
+		{5, []int{1, 1, 1, 1, 1}},
+		{9, []int{1, 1, 1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		groups := splitSteps(fields, tt.steps)
+		var sizes []int
+		for _, group := range groups {
+			sizes = append(sizes, len(group))
+		}
+		if fmt.Sprint(sizes) != fmt.Sprint(tt.expected) {
+			t.Errorf("splitSteps(5 fields, %d) sizes = %v, expected %v", tt.steps, sizes, tt.expected)
+		}
+	}
+}
+
+func TestCreateTemplates_Wizard(t *testing.T) {
+	tmpDir := t.TempDir()
+	fields := []Field{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "featured", Type: "bool"},
+	}
+
+	if err := createTemplates(tmpDir, "Product", fields, 2); err != nil {
+		t.Fatalf("createTemplates failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "new.partial.html")); err == nil {
+		t.Error("Expected no single-form template for a wizard")
+	}
+
+	step2 := renderTemplate(t, filepath.Join(tmpDir, "new_step2.html"), &renderers.TemplateData{
+		Data:   map[string]interface{}{"Values": map[string]string{"featured": "on"}, "WizardNav": template.HTML("<nav></nav>")},
+		Errors: map[string]string{"Featured": "Featured is invalid"},
+	})
+	for _, expected := range []string{
+		`hx-post="/products"`,
+		`<nav></nav>`,
+		`<input type="checkbox" id="featured" name="featured" checked>`,
+		`<span class="error">Featured is invalid</span>`,
+	} {
+		if !strings.Contains(step2, expected) {
+			t.Errorf("Step template missing expected string: %q", expected)
+		}
+	}
+}
+
 func TestCreateRoutes(t *testing.T) {
 	tmpDir := t.TempDir()
 	routesPath := filepath.Join(tmpDir, "products.go")
@@ -526,7 +1065,7 @@ func TestCreateRoutes(t *testing.T) {
 	}
 }
 
-func TestUpdateMainGo(t *testing.T) {
+func TestUpdateAppGo(t *testing.T) {
 	tmpDir := t.TempDir()
 	mainPath := filepath.Join(tmpDir, "main.go")
 
@@ -542,9 +1081,9 @@ func main() {
 `
 	os.WriteFile(mainPath, []byte(initialContent), 0644)
 
-	err := updateMainGo(mainPath, "Product")
+	err := updateAppGo(mainPath, "Product", false)
 	if err != nil {
-		t.Fatalf("updateMainGo failed: %v", err)
+		t.Fatalf("updateAppGo failed: %v", err)
 	}
 
 	// Read and verify content
@@ -566,6 +1105,42 @@ func main() {
 	}
 }
 
+func TestUpdateAppGo_WizardWithTimeout(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainPath := filepath.Join(tmpDir, "main.go")
+
+	initialContent := `package main
+
+func main() {
+	postHandler := handlers.NewPostHandler(client, publicRenderer)
+
+	r.With(publicTimeout).Mount("/posts", routes.PostRoutes(postHandler, sessionManager, client))
+}
+`
+	os.WriteFile(mainPath, []byte(initialContent), 0644)
+
+	if err := updateAppGo(mainPath, "Product", true); err != nil {
+		t.Fatalf("updateAppGo failed: %v", err)
+	}
+
+	content, err := os.ReadFile(mainPath)
+	if err != nil {
+		t.Fatalf("Failed to read main file: %v", err)
+	}
+
+	contentStr := string(content)
+	expectedStrings := []string{
+		"productHandler := handlers.NewProductHandler(client, sessionManager, publicRenderer)",
+		`r.With(publicTimeout).Mount("/products", routes.ProductRoutes(productHandler, sessionManager, client))`,
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("Main.go content missing expected string: %q", expected)
+		}
+	}
+}
+
 func TestCreateIndexTemplate(t *testing.T) {
 	tmpDir := t.TempDir()
 	indexPath := filepath.Join(tmpDir, "index.html")
@@ -645,11 +1220,13 @@ func TestCreateFormTemplate(t *testing.T) {
 		t.Fatalf("Failed to read template file: %v", err)
 	}
 
-	contentStr := string(content)
+	if !strings.Contains(string(content), `{{define "title"}}New Product{{end}}`) {
+		t.Error("Form template missing its title")
+	}
+
+	contentStr := renderTemplate(t, newPath, &renderers.TemplateData{Data: map[string]interface{}{}})
 	expectedStrings := []string{
-		`{{define "title"}}New Product{{end}}`,
-		`{{define "content"}}`,
-		`<h2>New Product</h2>`,
+		`<h3>New Product</h3>`,
 		`action="/products"`,
 		`hx-post="/products"`,
 		`<label for="name">Name</label>`,
@@ -673,6 +1250,48 @@ func TestCreateFormTemplate(t *testing.T) {
 	}
 }
 
+// TestCreateFormTemplate_Edit tests that edit forms show the record, then the
+// submitted values after a validation error
+func TestCreateFormTemplate_Edit(t *testing.T) {
+	editPath := filepath.Join(t.TempDir(), "edit.partial.html")
+	fields := []Field{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "active", Type: "bool"},
+	}
+	if err := createFormTemplate(editPath, "Product", "Product", "products", fields, "edit"); err != nil {
+		t.Fatalf("createFormTemplate failed: %v", err)
+	}
+
+	type product struct {
+		ID     int
+		Name   string
+		Active bool
+	}
+	type productForm struct {
+		Name   string
+		Active bool
+	}
+	record := product{ID: 7, Name: "Lamp", Active: true}
+
+	opened := renderTemplate(t, editPath, &renderers.TemplateData{Data: map[string]interface{}{"Product": record}})
+	for _, expected := range []string{`hx-put="/products/7"`, `value="Lamp"`, `name="active" checked`, "Update Product"} {
+		if !strings.Contains(opened, expected) {
+			t.Errorf("Edit form missing %q", expected)
+		}
+	}
+
+	rejected := renderTemplate(t, editPath, &renderers.TemplateData{Data: map[string]interface{}{
+		"Product": record,
+		"Form":    productForm{Name: ""},
+		"Errors":  map[string]string{"Name": "Name is required"},
+	}})
+	for _, expected := range []string{`value=""`, `name="active">`, `<p>Name is required</p>`} {
+		if !strings.Contains(rejected, expected) {
+			t.Errorf("Rejected edit form missing %q", expected)
+		}
+	}
+}
+
 func TestRegisterWithAdmin(t *testing.T) {
 	tmpDir := t.TempDir()
 	adminPath := filepath.Join(tmpDir, "models.go")
@@ -6,8 +6,9 @@ import (
 	"strings"
 )
 
-// createTemplates creates the template directory and files
-func createTemplates(dir, modelName string, fields []Field) error {
+// createTemplates creates the template directory and files. With more than one
+// step, the create form is split into new_step{N}.html wizard pages.
+func createTemplates(dir, modelName string, fields []Field, steps int) error {
 	// Create directory
 	if err := mkdir(dir, 0755); err != nil {
 		return err
@@ -23,10 +24,20 @@ func createTemplates(dir, modelName string, fields []Field) error {
 		return err
 	}
 
-	// Create new.partial.html
-	newPath := filepath.Join(dir, "new.partial.html")
-	if err := createFormTemplate(newPath, modelName, modelTitle, modelPlural, fields, "new"); err != nil {
-		return err
+	if steps > 1 {
+		// Create new_step1.html, new_step2.html, ...
+		for i, group := range splitSteps(fields, steps) {
+			stepPath := filepath.Join(dir, fmt.Sprintf("new_step%d.html", i+1))
+			if err := createWizardStepTemplate(stepPath, modelTitle, modelPlural, group); err != nil {
+				return err
+			}
+		}
+	} else {
+		// Create new.partial.html
+		newPath := filepath.Join(dir, "new.partial.html")
+		if err := createFormTemplate(newPath, modelName, modelTitle, modelPlural, fields, "new"); err != nil {
+			return err
+		}
 	}
 
 	// Create edit.partial.html
@@ -57,6 +68,25 @@ func createIndexTemplate(path, modelName, modelTitle, modelPlural string, fields
 				cells.WriteString(fmt.Sprintf("                <td>${{printf \"%%.2f\" .%s}}</td>\n", fieldName))
 			} else if field.Type == "bool" {
 				cells.WriteString(fmt.Sprintf("                <td>{{if .%s}}Yes{{else}}No{{end}}</td>\n", fieldName))
+			} else if field.Type == "richtext" {
+				cells.WriteString(fmt.Sprintf("                <td class=\"richtext-content\">{{richtext .%s}}</td>\n", fieldName))
+			} else if field.Type == "markdown" {
+				cells.WriteString(fmt.Sprintf("                <td class=\"richtext-content\">{{markdown .%s}}</td>\n", fieldName))
+			} else if field.Type == "json" {
+				cells.WriteString(fmt.Sprintf("                <td><pre class=\"json-value\">{{prettyJSON .%s}}</pre></td>\n", fieldName))
+			} else if field.Type == "geo" {
+				cells.WriteString(fmt.Sprintf("                <td>{{staticMap .%s}}</td>\n", fieldName))
+			} else if field.Type == "color" {
+				cells.WriteString(fmt.Sprintf("                <td>{{colorSwatch .%s}}</td>\n", fieldName))
+			} else if field.Type == "url" {
+				cells.WriteString(fmt.Sprintf("                <td>{{urlLink .%s}}</td>\n", fieldName))
+			} else if field.Type == "phone" {
+				cells.WriteString(fmt.Sprintf("                <td>{{phoneLink .%s}}</td>\n", fieldName))
+			} else if field.Type == "money" {
+				cells.WriteString(fmt.Sprintf("                <td>{{money .%s}}</td>\n", fieldName))
+			} else if field.Type == "uuid" || field.Type == "fk" {
+				// Optional IDs are nil pointers when unset
+				cells.WriteString(fmt.Sprintf("                <td>{{with .%s}}{{.}}{{end}}</td>\n", fieldName))
 			} else {
 				cells.WriteString(fmt.Sprintf("                <td>{{.%s}}</td>\n", fieldName))
 			}
@@ -99,7 +129,8 @@ func createIndexTemplate(path, modelName, modelTitle, modelPlural string, fields
 	return writeFile(path, []byte(content), 0644)
 }
 
-// createFormTemplate creates new or edit form template
+// createFormTemplate creates new or edit form template. Fields, the modal and
+// the error alert use the shared components (gojang/views/components).
 func createFormTemplate(path, modelName, modelTitle, modelPlural string, fields []Field, formType string) error {
 	isEdit := formType == "edit"
 	title := "New " + modelTitle
@@ -114,107 +145,131 @@ func createFormTemplate(path, modelName, modelTitle, modelPlural string, fields
 		buttonText = "Update " + modelTitle
 	}
 
-	// Build form fields
+	// Build form fields, refilled from the submitted form after a validation
+	// error and, when editing, from the record otherwise
 	var formFields strings.Builder
 	for _, field := range fields {
-		fieldName := field.Name
 		fieldTitle := toCamelCase(field.Name)
-		inputType := getInputType(field.Type)
-
-		value := ""
+		value := fmt.Sprintf(`(formValue .Data.Form "%s")`, fieldTitle)
 		if isEdit {
-			if field.Type == "float" {
-				value = fmt.Sprintf(`value="{{if .Data.Form}}{{.Data.Form.%s}}{{else}}{{.Data.%s.%s}}{{end}}"`, fieldTitle, toCamelCase(modelName), fieldTitle)
-			} else if field.Type == "bool" {
-				formFields.WriteString(fmt.Sprintf(`
-    <div class="form-group">
-        <label>
-            <input type="checkbox" 
-                   id="%s" 
-                   name="%s"
-                   {{if .Data.Form}}{{if .Data.Form.%s}}checked{{end}}{{else}}{{if .Data.%s.%s}}checked{{end}}{{end}}>
-            %s
-        </label>
-    </div>
-`, fieldName, fieldName, fieldTitle, toCamelCase(modelName), fieldTitle, fieldTitle))
-				continue
-			} else {
-				value = fmt.Sprintf(`value="{{if .Data.Form}}{{.Data.Form.%s}}{{else}}{{.Data.%s.%s}}{{end}}"`, fieldTitle, toCamelCase(modelName), fieldTitle)
-			}
-		} else {
-			if field.Type == "bool" {
-				formFields.WriteString(fmt.Sprintf(`
-    <div class="form-group">
-        <label>
-            <input type="checkbox" 
-                   id="%s" 
-                   name="%s"
-                   {{if .Data.Form}}{{if .Data.Form.%s}}checked{{end}}{{end}}>
-            %s
-        </label>
-    </div>
-`, fieldName, fieldName, fieldTitle, fieldTitle))
-				continue
-			} else {
-				value = fmt.Sprintf(`value="{{if .Data.Form}}{{.Data.Form.%s}}{{end}}"`, fieldTitle)
-			}
-		}
-
-		if field.Type == "text" {
-			formFields.WriteString(fmt.Sprintf(`
-    <div class="form-group">
-        <label for="%s">%s</label>
-        <textarea id="%s" 
-                  name="%s" 
-                  rows="3"
-                  class="form-control">{{if .Data.Form}}{{.Data.Form.%s}}{{else}}{{if .Data.%s}}{{.Data.%s.%s}}{{end}}{{end}}</textarea>
-    </div>
-`, fieldName, fieldTitle, fieldName, fieldName, fieldTitle, toCamelCase(modelName), toCamelCase(modelName), fieldTitle))
-		} else if field.Type != "bool" {
-			required := ""
-			if field.Required {
-				required = "\n               required"
+			record := fmt.Sprintf(".Data.%s.%s", toCamelCase(modelName), fieldTitle)
+			switch field.Type {
+			case "money":
+				// Minor units are shown as a plain amount (e.g., 1234.50)
+				record = "(moneyInput " + record + ")"
+			case "json":
+				record = "(prettyJSON " + record + ")"
 			}
-			step := ""
-			if field.Type == "float" {
-				step = "\n               step=\"0.01\""
-			}
-			formFields.WriteString(fmt.Sprintf(`
-    <div class="form-group">
-        <label for="%s">%s</label>
-        <input type="%s" 
-               id="%s" 
-               name="%s" %s%s
-               %s
-               class="form-control">
-    </div>
-`, fieldName, fieldTitle, inputType, fieldName, fieldName, step, required, value))
+			value = fmt.Sprintf(`(formValue .Data.Form "%s" %s)`, fieldTitle, record)
 		}
+		formFields.WriteString("    " + fieldComponent(field, value, "") + "\n")
 	}
 
 	content := fmt.Sprintf(`{{define "title"}}%s{{end}}
 
 {{define "content"}}
-<div class="modal-header">
-    <h2>%s</h2>
-</div>
-
-<form method="POST" action="%s" %s hx-swap="none">
-    {{if .Data.Errors}}
-    <div class="alert alert-danger">
-        {{range .Data.Errors}}
-        <p>{{.}}</p>
-        {{end}}
-    </div>
-    {{end}}
+{{template "modal" (dict "Title" "%s")}}
+<form method="POST" action="%s" %s hx-swap="none" class="form">
+    {{template "alert" (dict "Type" "error" "Messages" .Data.Errors)}}
 %s
-    <div class="form-actions">
-        <button type="submit" class="btn btn-primary">%s</button>
-        <button type="button" onclick="closeModal()" class="btn btn-secondary">Cancel</button>
-    </div>
+    {{template "modal_footer" (dict "Submit" "%s")}}
 </form>
+{{template "modal_end"}}
 {{end}}
 `, title, title, action, htmxAttr, formFields.String(), buttonText)
 
 	return writeFile(path, []byte(content), 0644)
 }
+
+// createWizardStepTemplate creates one page of the create wizard. Inputs are
+// refilled from .Data.Values; the progress bar and Back/Next buttons come from
+// RenderWizardStep.
+func createWizardStepTemplate(path, modelTitle, modelPlural string, fields []Field) error {
+	var formFields strings.Builder
+	for _, field := range fields {
+		value := fmt.Sprintf(`(index .Data.Values "%s")`, field.Name)
+		errExpr := fmt.Sprintf(`(index .Errors "%s")`, toCamelCase(field.Name))
+		formFields.WriteString("        " + fieldComponent(field, value, errExpr) + "\n")
+	}
+
+	content := fmt.Sprintf(`{{define "title"}}New %s{{end}}
+
+{{define "content"}}
+<div class="container" id="%s-wizard" style="padding: 2rem 2rem;">
+    <h1>New %s</h1>
+    {{.Data.WizardProgress}}
+
+    <form method="POST" action="/%s" hx-post="/%s" hx-target="#%s-wizard" hx-swap="outerHTML" class="form">
+        <input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+        {{template "alert" (dict "Type" "error" "Message" (index .Errors "general"))}}
+%s
+        {{.Data.WizardNav}}
+    </form>
+</div>
+{{end}}
+`, modelTitle, modelPlural, modelTitle, modelPlural, modelPlural, modelPlural, formFields.String())
+
+	return writeFile(path, []byte(content), 0644)
+}
+
+// fieldComponent returns the {{template "field"}} call for field. value and
+// errExpr are template expressions for its value and error ("" for none).
+func fieldComponent(field Field, value, errExpr string) string {
+	params := []string{
+		fmt.Sprintf(`"Name" "%s"`, field.Name),
+		fmt.Sprintf(`"Label" "%s"`, toCamelCase(field.Name)),
+	}
+	switch field.Type {
+	case "bool":
+		params = append(params, `"Type" "checkbox"`)
+	case "text":
+		params = append(params, `"Type" "textarea"`)
+	case "richtext", "markdown", "json":
+		// Rich text uses the bundled editor (static/js/richtext.js); markdown is
+		// previewed as it's typed (POST /markdown/preview)
+		params = append(params, fmt.Sprintf(`"Type" "%s"`, field.Type))
+	default:
+		if inputType := getInputType(field.Type); inputType != "text" {
+			params = append(params, fmt.Sprintf(`"Type" "%s"`, inputType))
+		}
+		if field.Required {
+			params = append(params, `"Required" true`)
+		}
+		switch field.Type {
+		case "float":
+			params = append(params, `"Step" "0.01"`)
+		case "money":
+			params = append(params, `"InputMode" "decimal"`)
+		case "geo":
+			// Picked on a map by static/js/geo.js
+			params = append(params, `"Geo" true`)
+		}
+		if placeholder := inputPlaceholder(field); placeholder != "" {
+			params = append(params, fmt.Sprintf(`"Placeholder" "%s"`, placeholder))
+		}
+	}
+	if value != "" {
+		params = append(params, `"Value" `+value)
+	}
+	if errExpr != "" {
+		params = append(params, `"Error" `+errExpr)
+	}
+	return `{{template "field" (dict ` + strings.Join(params, " ") + `)}}`
+}
+
+// inputPlaceholder returns the input placeholder for uuid, fk, geo, url and phone fields ("" otherwise)
+func inputPlaceholder(field Field) string {
+	switch field.Type {
+	case "url":
+		return "https://"
+	case "phone":
+		return "+1 415 555 2671"
+	case "geo":
+		return "37.7749,-122.4194"
+	case "fk":
+		return field.Ref + " ID"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	}
+	return ""
+}
@@ -100,6 +100,7 @@ func parseField(input string) (Field, error) {
 
 	name := strings.TrimSpace(parts[0])
 	fieldType := strings.TrimSpace(strings.ToLower(parts[1]))
+	target, isFK := parseFKType(strings.TrimSpace(parts[1]))
 
 	// Validate field name
 	if !regexp.MustCompile(`^[a-z][a-z0-9_]*$`).MatchString(name) {
@@ -111,10 +112,17 @@ func parseField(input string) (Field, error) {
 		return Field{}, fmt.Errorf("field name '%s' is a Go reserved keyword or built-in type", name)
 	}
 
+	if isFK {
+		if !isValidModelName(target) {
+			return Field{}, fmt.Errorf("invalid foreign key target '%s' (use fk(Model), e.g., fk(User))", target)
+		}
+		return fkField(name, target, false), nil
+	}
+
 	// Validate field type
 	validTypes := map[string]bool{
-		"string": true, "text": true, "int": true,
-		"float": true, "bool": true, "time": true,
+		"string": true, "text": true, "richtext": true, "markdown": true, "json": true, "int": true,
+		"float": true, "money": true, "geo": true, "color": true, "url": true, "phone": true, "bool": true, "time": true, "uuid": true,
 	}
 	if !validTypes[fieldType] {
 		return Field{}, fmt.Errorf("unsupported type '%s'", fieldType)
@@ -126,3 +134,98 @@ func parseField(input string) (Field, error) {
 		Required: false,
 	}, nil
 }
+
+// fkTypePattern matches a foreign key field type such as "fk(User)"
+var fkTypePattern = regexp.MustCompile(`^fk\(([A-Za-z0-9_]*)\)$`)
+
+// parseFKType returns the target model of a foreign key type like "fk(User)"
+func parseFKType(fieldType string) (string, bool) {
+	m := fkTypePattern.FindStringSubmatch(fieldType)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// fkField returns a foreign key field. The column is always "<edge>_id", so
+// "owner:fk(User)" and "owner_id:fk(User)" both give an OwnerID field and an Owner edge.
+func fkField(name, target string, required bool) Field {
+	return Field{
+		Name:     strings.TrimSuffix(name, "_id") + "_id",
+		Type:     "fk",
+		Required: required,
+		Ref:      target,
+	}
+}
+
+// compareRules maps --rules comparison operators to validator tags; two-character
+// operators come first so "a>=b" isn't read as "a>" and "=b"
+var compareRules = []struct{ op, tag string }{
+	{">=", "gtefield"},
+	{"<=", "ltefield"},
+	{">", "gtfield"},
+	{"<", "ltfield"},
+}
+
+// applyRules parses --rules and adds a validator tag to the constrained field of each:
+//
+//	ends_at>starts_at                         ends_at must be after starts_at (also >=, <, <=)
+//	coupon:required_if:discount_type=coupon   coupon is required when discount_type is "coupon"
+//	coupon:required_with:discount_type        coupon is required when discount_type is set
+//
+// Comparisons need two int, float or time fields of the same type.
+func applyRules(fields []Field, rules string) error {
+	byName := make(map[string]*Field, len(fields))
+	for i := range fields {
+		byName[fields[i].Name] = &fields[i]
+	}
+
+	for _, spec := range strings.Split(rules, ",") {
+		spec = strings.TrimSpace(spec)
+		target, other, tag, err := parseRule(spec)
+		if err != nil {
+			return err
+		}
+		t, o := byName[target], byName[other]
+		if t == nil || o == nil || t == o {
+			return fmt.Errorf("rule %q must name two different fields of the model", spec)
+		}
+
+		switch {
+		case strings.HasPrefix(tag, "required_"):
+			if t.Required {
+				return fmt.Errorf("rule %q: %s is always required", spec, target)
+			}
+		case t.Type != o.Type || (t.Type != "int" && t.Type != "float" && t.Type != "time"):
+			return fmt.Errorf("rule %q: comparisons need two int, float or time fields of the same type", spec)
+		}
+		t.Rules = append(t.Rules, tag)
+	}
+	return nil
+}
+
+// parseRule splits one --rules entry into the constrained field, the field it
+// depends on and the validator tag (e.g., "gtfield=StartsAt")
+func parseRule(spec string) (target, other, tag string, err error) {
+	for _, c := range compareRules {
+		if a, b, ok := strings.Cut(spec, c.op); ok {
+			a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+			return a, b, c.tag + "=" + toCamelCase(b), nil
+		}
+	}
+
+	parts := strings.Split(spec, ":")
+	if len(parts) == 3 {
+		target, other = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[2])
+		switch strings.TrimSpace(parts[1]) {
+		case "required_if":
+			name, value, ok := strings.Cut(other, "=")
+			if ok && value != "" && !strings.ContainsAny(value, " ,") {
+				return target, name, "required_if=" + toCamelCase(name) + " " + value, nil
+			}
+		case "required_with":
+			return target, other, "required_with=" + toCamelCase(other), nil
+		}
+	}
+	return "", "", "", fmt.Errorf("invalid rule %q (use a>b, a>=b, a<b, a<=b, a:required_if:b=value or a:required_with:b)", spec)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/admin"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+)
+
+// renderMethods are the renderer methods that take a template name
+var renderMethods = map[string]bool{
+	"Render":         true,
+	"RenderStatus":   true,
+	"RenderPartial":  true,
+	"RenderFragment": true,
+}
+
+// runCheck implements `gojang check`
+func runCheck(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	dir := flags.String("dir", ".", "project root (where gojang/ is)")
+	blocks := flags.Bool("blocks", false, "also list the blocks each template defines and the file each comes from")
+	flags.Parse(args)
+
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	out := io.Discard
+	if *blocks {
+		out = os.Stdout
+	}
+	problems, err := checkTemplates(os.Stdout, out)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Println("  " + p)
+		}
+		return fmt.Errorf("%d template problem(s)", len(problems))
+	}
+	fmt.Println("✅ Templates OK")
+	return nil
+}
+
+// checkTemplates loads the site and admin templates with the renderers, which
+// report syntax errors, unknown functions, {{template}} calls to undefined
+// templates and pages without content (see renderers.Validate), then reports
+// Go code rendering templates that don't exist. The blocks each template
+// defines are listed to blocksOut.
+func checkTemplates(out, blocksOut io.Writer) ([]string, error) {
+	site, siteErr := renderers.NewRenderer(false)
+	adminRenderer, adminErr := admin.NewAdminRenderer(false)
+	var problems []string
+	for _, err := range []error{siteErr, adminErr} {
+		var templateErrs renderers.TemplateErrors
+		switch {
+		case errors.As(err, &templateErrs):
+			problems = append(problems, templateErrs...)
+		case err != nil:
+			return nil, err
+		}
+	}
+	if len(problems) > 0 {
+		return problems, nil // Checking render calls needs both renderers
+	}
+	siteSets, adminSets := site.Templates(), adminRenderer.Templates()
+	fmt.Fprintf(out, "Parsed %d site and %d admin template sets\n", len(siteSets), len(adminSets))
+	listBlocks(blocksOut, "", siteSets, site.Blocks())
+	listBlocks(blocksOut, "admin/", adminSets, adminRenderer.Blocks())
+
+	renders, err := renderCalls("gojang")
+	if err != nil {
+		return nil, err
+	}
+	for _, call := range renders {
+		sets := siteSets
+		if strings.HasPrefix(filepath.ToSlash(call.pos.Filename), "gojang/admin/") {
+			sets = adminSets
+		}
+		if _, ok := sets[call.name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: template %q not found", call.pos, call.name))
+		}
+	}
+	return problems, nil
+}
+
+// listBlocks writes the blocks each template set defines, with the file each
+// comes from. Pages are listed in their own layout only.
+func listBlocks(out io.Writer, prefix string, sets map[string]*template.Template, index *renderers.BlockIndex) {
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		if !strings.Contains(name, "@") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(out, prefix+name)
+		for _, block := range index.Blocks(name) {
+			fmt.Fprintf(out, "  %-24s %s\n", block, index.DefinedIn(name, block))
+		}
+	}
+}
+
+// renderCall is a Go call that renders a template named by a string literal
+type renderCall struct {
+	pos  token.Position
+	name string
+}
+
+// renderCalls finds the renderer calls under root whose template name is a
+// string literal, e.g. h.Renderer.Render(w, r, "posts/index.html", data)
+func renderCalls(root string) ([]renderCall, error) {
+	var calls []renderCall
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Generators hold templates of Go code, not calls
+			if path == filepath.Join(root, "cmd") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !renderMethods[sel.Sel.Name] {
+				return true
+			}
+			for _, arg := range call.Args {
+				lit, ok := arg.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				if name, err := strconv.Unquote(lit.Value); err == nil && strings.HasSuffix(name, ".html") {
+					calls = append(calls, renderCall{pos: fset.Position(lit.Pos()), name: name})
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	return calls, err
+}
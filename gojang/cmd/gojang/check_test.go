@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCheckTemplates tests that the project's own templates pass the check
+func TestCheckTemplates(t *testing.T) {
+	t.Chdir("../../..") // Templates are loaded relative to the repository root
+	problems, err := checkTemplates(io.Discard, io.Discard)
+	if err != nil {
+		t.Fatalf("checkTemplates failed: %v", err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+// TestRenderCalls tests finding the templates Go code renders
+func TestRenderCalls(t *testing.T) {
+	dir := t.TempDir()
+	src := `package handlers
+
+func (h *PostHandler) Index(w http.ResponseWriter, r *http.Request) {
+	h.Renderer.Render(w, r, "posts/index.html", nil)
+	h.Renderer.RenderStatus(w, r, http.StatusNotFound, "404.html", nil)
+	h.Renderer.Render(w, r, name, nil)
+	h.Logger.Render("not a template")
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "posts.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	calls, err := renderCalls(dir)
+	if err != nil {
+		t.Fatalf("renderCalls failed: %v", err)
+	}
+	var names []string
+	for _, call := range calls {
+		names = append(names, call.name)
+	}
+	if strings.Join(names, ",") != "posts/index.html,404.html" {
+		t.Errorf("renderCalls found %q", names)
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/joho/godotenv"
+)
+
+// runDeploy dispatches `gojang deploy <subcommand>`
+func runDeploy(args []string) error {
+	if len(args) == 0 || args[0] != "init" {
+		fmt.Println("Usage: gojang deploy init [flags]")
+		fmt.Println("  init - Generate a Dockerfile, docker-compose.yml and entrypoint for this project")
+		return nil
+	}
+
+	fs := flag.NewFlagSet("deploy init", flag.ExitOnError)
+	opts := deployOptions{}
+	fs.StringVar(&opts.Dir, "dir", ".", "project root (where go.mod is)")
+	fs.StringVar(&opts.Database, "db", "postgres", "database service: postgres or sqlite (a file in the data volume)")
+	fs.BoolVar(&opts.Redis, "redis", false, "add a Redis service (e.g., for a shared session store)")
+	fs.BoolVar(&opts.Force, "force", false, "overwrite existing files")
+	fs.Parse(args[1:])
+
+	return deployInit(opts, os.Stdout)
+}
+
+// deployOptions configures `gojang deploy init`
+type deployOptions struct {
+	Dir      string
+	Database string
+	Redis    bool
+	Force    bool
+}
+
+// deployProject is what the deployment files are generated from
+type deployProject struct {
+	GoVersion   string      // Builder image tag, from go.mod (e.g., "1.24")
+	CGO         bool        // go-sqlite3 needs a C toolchain
+	Tags        string      // Build tags for the web server (e.g., "bleve")
+	Assets      []string    // Directories the server reads at runtime
+	Migrations  bool        // Has SQL files for `migrate up`
+	Port        string      // Port the server listens on in the container
+	Database    string      // "postgres" or "sqlite"
+	PostGIS     bool        // Use a PostGIS image for the database
+	Redis       bool        // Add a Redis service
+	Environment []deployEnv // The app service's environment, one entry per config setting
+}
+
+// deployEnv is an environment variable of the app service
+type deployEnv struct {
+	Name    string
+	Value   string
+	Comment string
+}
+
+// deployAssets are the runtime directories copied into the image when they exist
+var deployAssets = []string{"gojang/views", "gojang/admin/views", "gojang/models/migrations"}
+
+// deployFiles are generated in this order; mode 0o755 marks scripts
+var deployFiles = []struct {
+	name string
+	tmpl *template.Template
+	mode os.FileMode
+}{
+	{"Dockerfile", dockerfileTemplate, 0o644},
+	{".dockerignore", dockerignoreTemplate, 0o644},
+	{"docker-compose.yml", composeTemplate, 0o644},
+	{"docker-entrypoint.sh", entrypointTemplate, 0o755},
+}
+
+// deployInit writes the deployment files for the project in opts.Dir, leaving
+// existing files alone unless opts.Force is set
+func deployInit(opts deployOptions, out io.Writer) error {
+	project, err := readProject(opts)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range deployFiles {
+		path := filepath.Join(opts.Dir, file.name)
+		if _, err := os.Stat(path); err == nil && !opts.Force {
+			fmt.Fprintf(out, "⏭️  %s exists, skipping (use -force to overwrite)\n", file.name)
+			continue
+		}
+		var buf bytes.Buffer
+		if err := file.tmpl.Execute(&buf, project); err != nil {
+			return fmt.Errorf("render %s: %w", file.name, err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), file.mode); err != nil {
+			return err
+		}
+		// WriteFile keeps the mode of an existing file
+		if err := os.Chmod(path, file.mode); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "✅ Wrote %s\n", file.name)
+	}
+
+	fmt.Fprintln(out)
+	if project.Database == "postgres" {
+		fmt.Fprintln(out, "Set SESSION_KEY and POSTGRES_PASSWORD in the environment or a .env file, then run:")
+	} else {
+		fmt.Fprintln(out, "Set SESSION_KEY in the environment or a .env file, then run:")
+	}
+	fmt.Fprintln(out, "  docker compose up -d --build")
+	return nil
+}
+
+// readProject collects the facts the deployment files depend on from go.mod,
+// the project's .env (or .env.example) and the settings in config.Config
+func readProject(opts deployOptions) (*deployProject, error) {
+	if opts.Database != "postgres" && opts.Database != "sqlite" {
+		return nil, fmt.Errorf("-db must be postgres or sqlite, got %q", opts.Database)
+	}
+	project := &deployProject{Database: opts.Database, Redis: opts.Redis}
+
+	if err := readGoMod(filepath.Join(opts.Dir, "go.mod"), project); err != nil {
+		return nil, err
+	}
+
+	for _, dir := range deployAssets {
+		if info, err := os.Stat(filepath.Join(opts.Dir, dir)); err == nil && info.IsDir() {
+			project.Assets = append(project.Assets, dir)
+		}
+	}
+	sqlFiles, _ := filepath.Glob(filepath.Join(opts.Dir, "gojang/models/migrations/*.up.sql"))
+	project.Migrations = len(sqlFiles) > 0
+
+	// Same lookup as config.Load: .env, falling back to .env.example
+	values, err := godotenv.Read(filepath.Join(opts.Dir, ".env"))
+	if err != nil {
+		values, _ = godotenv.Read(filepath.Join(opts.Dir, ".env.example"))
+	}
+	settings := configSettings()
+	value := func(name string) string {
+		if v, ok := values[name]; ok {
+			return v
+		}
+		for _, s := range settings {
+			if s.Name == name {
+				return s.Default
+			}
+		}
+		return ""
+	}
+	project.Port = value("PORT")
+	project.PostGIS = opts.Database == "postgres" && value("POSTGIS") == "true"
+
+	for _, s := range settings {
+		env, ok := composeEnv(project, s, value(s.Name))
+		if ok {
+			project.Environment = append(project.Environment, env)
+		}
+	}
+	if project.Redis {
+		project.Environment = append(project.Environment, deployEnv{Name: "REDIS_URL", Value: "redis://redis:6379/0"})
+	}
+	project.Environment = append(project.Environment,
+		deployEnv{Name: "GOJANG_SUPERUSER_EMAIL", Value: "${GOJANG_SUPERUSER_EMAIL:-}", Comment: "First admin, see docker-entrypoint.sh"},
+		deployEnv{Name: "GOJANG_SUPERUSER_PASSWORD", Value: "${GOJANG_SUPERUSER_PASSWORD:-}"},
+	)
+	return project, nil
+}
+
+// readGoMod reads the Go version and the dependencies that change the build
+func readGoMod(path string, project *deployProject) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("run deploy init from the project root: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "go "):
+			// "go 1.24.0" builds in golang:1.24-alpine
+			parts := strings.SplitN(strings.TrimPrefix(line, "go "), ".", 3)
+			if len(parts) >= 2 {
+				project.GoVersion = parts[0] + "." + parts[1]
+			}
+		case strings.Contains(line, "github.com/mattn/go-sqlite3 "):
+			project.CGO = true
+		case strings.Contains(line, "github.com/blevesearch/bleve"):
+			// The embedded search backend is behind a build tag (see search.Open)
+			project.Tags = "bleve"
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if project.GoVersion == "" {
+		return errors.New("go.mod has no go directive")
+	}
+	return nil
+}
+
+// configSetting is an environment variable read by config.Load
+type configSetting struct {
+	Name     string
+	Default  string
+	Required bool
+}
+
+// configSettings lists config.Config's environment variables in declaration order
+func configSettings() []configSetting {
+	var settings []configSetting
+	t := reflect.TypeOf(config.Config{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		settings = append(settings, configSetting{
+			Name:     name,
+			Default:  field.Tag.Get("envDefault"),
+			Required: strings.Contains(options, "required"),
+		})
+	}
+	return settings
+}
+
+// composeEnv returns a setting's value for the app service. Deployment-specific
+// settings are fixed and the rest come from the environment; config.Load
+// treats an empty value as unset, so its defaults still apply. ok is false for
+// development-only settings.
+func composeEnv(project *deployProject, s configSetting, current string) (env deployEnv, ok bool) {
+	env.Name = s.Name
+	switch s.Name {
+	case "DEBUG":
+		env.Value = "false"
+	case "LIVE_RELOAD", "PID_FILE":
+		return env, false
+	case "PORT":
+		env.Value = project.Port
+	case "DATABASE_URL":
+		if project.Database == "sqlite" {
+			env.Value = "sqlite://./data/gojang.db"
+		} else {
+			env.Value = "postgres://gojang:${POSTGRES_PASSWORD:-gojang}@db:5432/gojang?sslmode=disable"
+		}
+	case "POSTGIS":
+		env.Value = fmt.Sprint(project.PostGIS)
+	case "STORAGE_URL", "SEARCH_URL":
+		// Local files and indexes must live in the data volume to survive restarts
+		env.Value = escapeCompose(dataVolumeURL(current))
+	case "SQLITE_CHECKPOINT_INTERVAL", "REPLICA_URL", "REPLICA_INTERVAL", "REPLICA_RETAIN":
+		if project.Database != "sqlite" {
+			return env, false
+		}
+		fallthrough
+	default:
+		if s.Required {
+			env.Value = fmt.Sprintf("${%s:?%s must be set}", s.Name, s.Name)
+		} else {
+			env.Value = fmt.Sprintf("${%s:-}", s.Name)
+		}
+		if s.Default != "" {
+			env.Comment = "Default: " + s.Default
+		}
+	}
+	return env, true
+}
+
+// dataVolumeURL moves file:// and bleve:// paths outside ./data into it
+func dataVolumeURL(rawURL string) string {
+	scheme, path, ok := strings.Cut(rawURL, "://")
+	if !ok || (scheme != "file" && scheme != "bleve") {
+		return rawURL
+	}
+	clean := filepath.ToSlash(filepath.Clean(path))
+	if clean == "data" || strings.HasPrefix(clean, "data/") {
+		return scheme + "://./" + clean
+	}
+	return scheme + "://./data/" + filepath.Base(clean)
+}
+
+// escapeCompose keeps docker compose from interpolating a literal value
+func escapeCompose(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"strconv"
+	"text/template"
+)
+
+var deployFuncs = template.FuncMap{
+	// Double-quoted YAML strings accept Go's escapes for the characters config values use
+	"quote": strconv.Quote,
+}
+
+var dockerfileTemplate = template.Must(template.New("Dockerfile").Funcs(deployFuncs).Parse(`# Generated by ` + "`gojang deploy init`" + `; edit freely.
+
+# Stage 1: build the server and the migrate and seed commands{{if eq .Database "sqlite"}} (and replicate){{end}}
+FROM golang:{{.GoVersion}}-alpine AS builder
+{{- if .CGO}}
+
+# go-sqlite3 uses cgo
+RUN apk add --no-cache build-base
+ENV CGO_ENABLED=1
+{{- else}}
+
+ENV CGO_ENABLED=0
+{{- end}}
+
+WORKDIR /src
+COPY go.mod go.sum ./
+RUN go mod download
+
+# Build metadata shown at /version and in logs, e.g.
+# docker compose build --build-arg VERSION=$(git describe --tags) --build-arg COMMIT=$(git rev-parse HEAD)
+# (.git isn't copied into the image, so Go can't stamp it)
+ARG VERSION=dev
+ARG COMMIT=
+ARG BUILD_TIME=
+ENV LDFLAGS="-s -w -X github.com/gojangframework/gojang/gojang/version.Version=${VERSION} -X github.com/gojangframework/gojang/gojang/version.Commit=${COMMIT} -X github.com/gojangframework/gojang/gojang/version.BuildTime=${BUILD_TIME}"
+
+COPY . .
+RUN go build -ldflags="$LDFLAGS"{{if .Tags}} -tags {{.Tags}}{{end}} -o /out/web ./gojang/cmd/web && \
+    go build -ldflags="$LDFLAGS" -o /out/migrate ./gojang/cmd/migrate && \
+    go build -ldflags="$LDFLAGS" -o /out/seed ./gojang/cmd/seed
+{{- if eq .Database "sqlite"}} && \
+    go build -ldflags="$LDFLAGS" -o /out/replicate ./gojang/cmd/replicate
+{{- end}}
+
+# Stage 2: run as a non-root user
+FROM alpine:3.20
+
+RUN apk add --no-cache ca-certificates tzdata && \
+    addgroup -S gojang && adduser -S -G gojang gojang
+
+WORKDIR /app
+COPY --from=builder /out/ ./
+{{- range .Assets}}
+COPY {{.}} ./{{.}}
+{{- end}}
+COPY docker-entrypoint.sh ./
+
+# Uploads, search indexes and SQLite databases
+RUN mkdir -p data && chown gojang:gojang data
+VOLUME /app/data
+
+USER gojang
+EXPOSE {{.Port}}
+
+HEALTHCHECK --interval=30s --timeout=3s --start-period=10s --retries=3 \
+    CMD wget -q -O /dev/null http://127.0.0.1:{{.Port}}/readyz || exit 1
+
+ENTRYPOINT ["./docker-entrypoint.sh"]
+CMD ["./web"]
+`))
+
+var dockerignoreTemplate = template.Must(template.New(".dockerignore").Parse(`# Generated by ` + "`gojang deploy init`" + `
+.git
+.env
+.env.*
+tmp/
+bin/
+data/
+*.db
+*.log
+docker-compose.yml
+`))
+
+var composeTemplate = template.Must(template.New("docker-compose.yml").Funcs(deployFuncs).Parse(`# Generated by ` + "`gojang deploy init`" + ` from the settings in gojang/config.
+# ${VAR} values come from your shell or a .env file next to this file.
+# SESSION_KEY is required; set GOJANG_SUPERUSER_EMAIL and
+# GOJANG_SUPERUSER_PASSWORD to create an admin on first start.
+
+services:
+  app:
+    build: .
+    restart: unless-stopped
+    ports:
+      - "{{.Port}}:{{.Port}}"
+    environment:{{if eq .Database "sqlite"}} &app-env{{end}}
+{{- range .Environment}}
+      {{.Name}}: {{quote .Value}}{{if .Comment}}  # {{.Comment}}{{end}}
+{{- end}}
+    volumes:
+      - app-data:/app/data
+{{- if or (eq .Database "postgres") .Redis}}
+    depends_on:
+{{- if eq .Database "postgres"}}
+      db:
+        condition: service_healthy
+{{- end}}
+{{- if .Redis}}
+      redis:
+        condition: service_healthy
+{{- end}}
+{{- end}}
+{{- if eq .Database "sqlite"}}
+
+  # Copies database snapshots to REPLICA_URL; start it with
+  # docker compose --profile replication up -d
+  replicate:
+    build: .
+    restart: unless-stopped
+    profiles: ["replication"]
+    entrypoint: ["./replicate", "run"]
+    environment: *app-env
+    volumes:
+      - app-data:/app/data
+    healthcheck:
+      disable: true
+    depends_on:
+      - app
+{{- end}}
+{{- if eq .Database "postgres"}}
+
+  db:
+    image: {{if .PostGIS}}postgis/postgis:16-3.4-alpine{{else}}postgres:16-alpine{{end}}
+    restart: unless-stopped
+    environment:
+      POSTGRES_DB: "gojang"
+      POSTGRES_USER: "gojang"
+      POSTGRES_PASSWORD: "${POSTGRES_PASSWORD:-gojang}"
+    volumes:
+      - postgres-data:/var/lib/postgresql/data
+    healthcheck:
+      test: ["CMD-SHELL", "pg_isready -U gojang -d gojang"]
+      interval: 5s
+      timeout: 5s
+      retries: 10
+{{- end}}
+{{- if .Redis}}
+
+  redis:
+    image: redis:7-alpine
+    restart: unless-stopped
+    volumes:
+      - redis-data:/data
+    healthcheck:
+      test: ["CMD", "redis-cli", "ping"]
+      interval: 5s
+      timeout: 5s
+      retries: 10
+{{- end}}
+
+volumes:
+  app-data:
+{{- if eq .Database "postgres"}}
+  postgres-data:
+{{- end}}
+{{- if .Redis}}
+  redis-data:
+{{- end}}
+`))
+
+var entrypointTemplate = template.Must(template.New("docker-entrypoint.sh").Parse(`#!/bin/sh
+# Generated by ` + "`gojang deploy init`" + `. Prepares the database, then runs the
+# container command (the web server by default).
+set -e
+{{- if eq .Database "sqlite"}}
+
+# On a fresh volume, start from the latest snapshot in REPLICA_URL
+if [ -n "$REPLICA_URL" ]; then
+    ./replicate restore
+fi
+{{- end}}
+
+# Apply the Ent schema before any instance serves traffic
+./migrate auto
+{{- if .Migrations}}
+
+# SQL migrations in gojang/models/migrations, if you manage any by hand
+# ./migrate up
+{{- end}}
+
+# Create the first admin (does nothing once a superuser exists)
+if [ -n "$GOJANG_SUPERUSER_EMAIL" ]; then
+    ./seed -noinput
+fi
+
+exec "$@"
+`))
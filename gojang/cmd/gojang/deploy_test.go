@@ -0,0 +1,130 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newDeployProject creates a minimal project tree for deploy init
+func newDeployProject(t *testing.T, env string) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                           "module example.com/app\n\ngo 1.23.4\n\nrequire (\n\tgithub.com/mattn/go-sqlite3 v1.14.22\n\tgithub.com/blevesearch/bleve/v2 v2.4.0\n)\n",
+		".env":                             env,
+		"gojang/views/templates/base.html": "",
+		"gojang/models/migrations/000001_users.up.sql": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestDeployInit tests that the generated files follow the project's go.mod and settings
+func TestDeployInit(t *testing.T) {
+	dir := newDeployProject(t, "PORT=9000\nPOSTGIS=true\nSESSION_KEY=super-secret-value\nSTORAGE_URL=file:///var/uploads\n")
+
+	if err := deployInit(deployOptions{Dir: dir, Database: "postgres", Redis: true}, io.Discard); err != nil {
+		t.Fatalf("deployInit: %v", err)
+	}
+
+	dockerfile := readFile(t, filepath.Join(dir, "Dockerfile"))
+	for _, want := range []string{
+		"FROM golang:1.23-alpine AS builder",
+		"RUN apk add --no-cache build-base",
+		"-tags bleve -o /out/web",
+		"COPY gojang/views ./gojang/views",
+		"EXPOSE 9000",
+	} {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Expected the Dockerfile to contain %q:\n%s", want, dockerfile)
+		}
+	}
+	if strings.Contains(dockerfile, "gojang/admin/views") {
+		t.Error("Expected directories missing from the project to be left out")
+	}
+
+	compose := readFile(t, filepath.Join(dir, "docker-compose.yml"))
+	for _, want := range []string{
+		`"9000:9000"`,
+		`SESSION_KEY: "${SESSION_KEY:?SESSION_KEY must be set}"`,
+		`DEBUG: "false"`,
+		`STORAGE_URL: "file://./data/uploads"`,
+		`REQUEST_TIMEOUT: "${REQUEST_TIMEOUT:-}"  # Default: 10s`,
+		"image: postgis/postgis:",
+		"image: redis:7-alpine",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("Expected docker-compose.yml to contain %q:\n%s", want, compose)
+		}
+	}
+	for _, unwanted := range []string{"super-secret-value", "LIVE_RELOAD", "PID_FILE", "REPLICA_URL", "replicate"} {
+		if strings.Contains(compose, unwanted) {
+			t.Errorf("Expected docker-compose.yml not to contain %q", unwanted)
+		}
+	}
+
+	entrypoint := filepath.Join(dir, "docker-entrypoint.sh")
+	if info, _ := os.Stat(entrypoint); info.Mode().Perm() != 0o755 {
+		t.Errorf("Expected the entrypoint to be executable, got %v", info.Mode())
+	}
+	if script := readFile(t, entrypoint); !strings.Contains(script, "./migrate auto") || !strings.Contains(script, "./seed -noinput") {
+		t.Errorf("Expected the entrypoint to migrate and seed:\n%s", script)
+	}
+
+	// Existing files are kept unless forced
+	os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("custom"), 0o644)
+	deployInit(deployOptions{Dir: dir, Database: "sqlite"}, io.Discard)
+	if readFile(t, filepath.Join(dir, "Dockerfile")) != "custom" {
+		t.Error("Expected the existing Dockerfile to be kept")
+	}
+	deployInit(deployOptions{Dir: dir, Database: "sqlite", Force: true}, io.Discard)
+	compose = readFile(t, filepath.Join(dir, "docker-compose.yml"))
+	if !strings.Contains(compose, `DATABASE_URL: "sqlite://./data/gojang.db"`) || strings.Contains(compose, "db:") {
+		t.Errorf("Expected an SQLite setup without a database service:\n%s", compose)
+	}
+	if !strings.Contains(compose, "replicate:") || !strings.Contains(compose, "REPLICA_URL:") {
+		t.Errorf("Expected a replication sidecar for SQLite:\n%s", compose)
+	}
+	if script := readFile(t, entrypoint); !strings.Contains(script, "./replicate restore") {
+		t.Errorf("Expected the entrypoint to restore the SQLite database:\n%s", script)
+	}
+}
+
+func TestDeployInit_InvalidDatabase(t *testing.T) {
+	dir := newDeployProject(t, "")
+	if err := deployInit(deployOptions{Dir: dir, Database: "mysql"}, io.Discard); err == nil {
+		t.Error("Expected an error for an unsupported database")
+	}
+}
+
+func TestDataVolumeURL(t *testing.T) {
+	tests := map[string]string{
+		"file://./data/media":          "file://./data/media",
+		"file://data/media":            "file://./data/media",
+		"file:///srv/uploads":          "file://./data/uploads",
+		"bleve://./index.bleve":        "bleve://./data/index.bleve",
+		"s3://bucket?region=us-east-1": "s3://bucket?region=us-east-1",
+		"http://search:7700":           "http://search:7700",
+	}
+	for in, want := range tests {
+		if got := dataVolumeURL(in); got != want {
+			t.Errorf("dataVolumeURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/livereload"
+)
+
+// devStopTimeout is how long the web process gets to shut down before it's killed
+const devStopTimeout = 5 * time.Second
+
+// runDev builds and runs the web server, then rebuilds and restarts it
+// whenever a .go file changes. The server runs with LIVE_RELOAD=true, so in
+// debug mode open pages refresh after a restart or a template change.
+func runDev(args []string) error {
+	fs := flag.NewFlagSet("dev", flag.ExitOnError)
+	pkg := fs.String("pkg", "./gojang/cmd/web", "package to build and run")
+	bin := fs.String("bin", filepath.Join("tmp", "gojang-dev"+exeSuffix()), "where to write the binary")
+	interval := fs.Duration("interval", 500*time.Millisecond, "how often to check for changed files")
+	fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &devServer{pkg: *pkg, bin: *bin}
+	server.restart(ctx)
+
+	watcher := livereload.Watcher{
+		Dirs:         []string{"."},
+		Exts:         []string{".go"},
+		SkipDirs:     []string{".git", "tmp", "bin", "vendor", "node_modules", "testdata", "data"},
+		SkipSuffixes: []string{"_test.go"},
+		Interval:     *interval,
+	}
+	fmt.Println("👀 Watching for changes (Ctrl+C to stop)")
+	watcher.Run(ctx, func(changed []string) {
+		fmt.Printf("🔄 %s changed, rebuilding...\n", describeChanges(changed))
+		server.restart(ctx)
+	})
+
+	fmt.Println("🛑 Stopping...")
+	server.stop()
+	return nil
+}
+
+// devServer is the web process run by `gojang dev`
+type devServer struct {
+	pkg    string
+	bin    string
+	cmd    *exec.Cmd
+	exited chan struct{} // Closed once cmd exits
+}
+
+// restart rebuilds the binary and swaps in a new process. A failed build
+// leaves the running process alone so the app stays usable while it's fixed.
+func (s *devServer) restart(ctx context.Context) {
+	// Windows can't replace the binary of a running process
+	if runtime.GOOS == "windows" {
+		s.stop()
+	}
+
+	if err := s.build(ctx); err != nil {
+		if ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "❌ Build failed: %v\n", err)
+		}
+		return
+	}
+
+	s.stop()
+	if err := s.start(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to start %s: %v\n", s.bin, err)
+	}
+}
+
+func (s *devServer) build(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(s.bin), 0o755); err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", s.bin, s.pkg)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func (s *devServer) start() error {
+	// exec needs a path, not a bare name it would look up in PATH
+	path, err := filepath.Abs(s.bin)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(path)
+	cmd.Env = append(os.Environ(), "LIVE_RELOAD=true")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		// The web server exits cleanly on interrupt, so errors are crashes or kills
+		if err := cmd.Wait(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Server exited: %v\n", err)
+		}
+		close(exited)
+	}()
+	s.cmd, s.exited = cmd, exited
+	return nil
+}
+
+// stop asks the process to shut down gracefully, killing it after devStopTimeout
+func (s *devServer) stop() {
+	if s.cmd == nil {
+		return
+	}
+	cmd, exited := s.cmd, s.exited
+	s.cmd, s.exited = nil, nil
+
+	// Windows has no interrupt signal for other processes
+	if runtime.GOOS == "windows" || cmd.Process.Signal(os.Interrupt) != nil {
+		cmd.Process.Kill()
+	}
+	select {
+	case <-exited:
+	case <-time.After(devStopTimeout):
+		cmd.Process.Kill()
+		<-exited
+	}
+}
+
+// describeChanges names the first changed file and counts the rest
+func describeChanges(changed []string) string {
+	if len(changed) == 1 {
+		return changed[0]
+	}
+	return fmt.Sprintf("%s and %d more", changed[0], len(changed)-1)
+}
+
+func exeSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
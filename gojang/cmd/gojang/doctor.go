@@ -0,0 +1,451 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
+)
+
+// Paths the doctor checks, relative to the project root
+const (
+	entDir        = "gojang/models"
+	migrationsDir = "gojang/models/migrations"
+)
+
+// doctorTimeout bounds each network check (database ping, SMTP dial)
+const doctorTimeout = 5 * time.Second
+
+// Issue levels. Only warnings and errors are counted; info explains what a
+// check skipped.
+const (
+	levelInfo = iota
+	levelWarning
+	levelError
+)
+
+// doctorIssue is something a check found
+type doctorIssue struct {
+	level   int
+	message string
+}
+
+// doctorCheck is a system check run by `gojang doctor`
+type doctorCheck struct {
+	name string
+	run  func(env *doctorEnv) []doctorIssue
+}
+
+// doctorChecks are run in this order; add project-specific checks here.
+// Later checks can use what earlier ones set on the doctorEnv.
+var doctorChecks = []doctorCheck{
+	{"config", checkConfig},
+	{"database", checkDatabase},
+	{"migrations", checkMigrations},
+	{"ent", checkEntCode},
+	{"smtp", checkSMTP},
+	{"templates", checkTemplateSets},
+}
+
+// doctorEnv is shared by the checks of one run
+type doctorEnv struct {
+	Deploy bool           // Check the config as production settings
+	Config *config.Config // nil when it failed to load
+	DB     *sql.DB        // nil until the database check reaches it
+	Driver *entsql.Driver // The Ent driver behind DB
+}
+
+// runDoctor implements `gojang doctor`
+func runDoctor(args []string) error {
+	flags := flag.NewFlagSet("doctor", flag.ExitOnError)
+	dir := flags.String("dir", ".", "project root (where .env and gojang/ are)")
+	deploy := flags.Bool("deploy", false, "check the settings as production settings (DEBUG, SESSION_KEY, ...)")
+	strict := flags.Bool("strict", false, "exit with status 1 on warnings too")
+	flags.Parse(args)
+
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	env := &doctorEnv{Deploy: *deploy}
+	defer env.close()
+
+	errs, warnings := runChecks(os.Stdout, env, doctorChecks)
+	fmt.Printf("\n%d error(s), %d warning(s)\n", errs, warnings)
+	if errs > 0 || (*strict && warnings > 0) {
+		return fmt.Errorf("doctor found %d error(s) and %d warning(s)", errs, warnings)
+	}
+	return nil
+}
+
+// runChecks runs checks in order, printing what each finds, and returns the
+// number of errors and warnings
+func runChecks(out io.Writer, env *doctorEnv, checks []doctorCheck) (errs, warnings int) {
+	for _, check := range checks {
+		issues := check.run(env)
+		if len(issues) == 0 {
+			fmt.Fprintf(out, "✅ %s\n", check.name)
+			continue
+		}
+		fmt.Fprintf(out, "%s %s\n", levelIcon(worstLevel(issues)), check.name)
+		for _, issue := range issues {
+			fmt.Fprintf(out, "   %s %s\n", levelIcon(issue.level), issue.message)
+			switch issue.level {
+			case levelError:
+				errs++
+			case levelWarning:
+				warnings++
+			}
+		}
+	}
+	return errs, warnings
+}
+
+func worstLevel(issues []doctorIssue) int {
+	worst := levelInfo
+	for _, issue := range issues {
+		if issue.level > worst {
+			worst = issue.level
+		}
+	}
+	return worst
+}
+
+func levelIcon(level int) string {
+	switch level {
+	case levelError:
+		return "❌"
+	case levelWarning:
+		return "⚠️ "
+	}
+	return "ℹ️ "
+}
+
+func (env *doctorEnv) close() {
+	if env.DB != nil {
+		env.DB.Close()
+	}
+}
+
+// issuef builds a doctorIssue
+func issuef(level int, format string, args ...interface{}) doctorIssue {
+	return doctorIssue{level: level, message: fmt.Sprintf(format, args...)}
+}
+
+// checkConfig loads the config and flags insecure settings. With -deploy
+// they're errors; otherwise the ones that only matter in production are
+// warnings or skipped.
+func checkConfig(env *doctorEnv) []doctorIssue {
+	cfg, err := config.Load()
+	if err != nil {
+		return []doctorIssue{issuef(levelError, "config: %v", err)}
+	}
+	env.Config = cfg
+
+	prodLevel := levelWarning
+	if env.Deploy {
+		prodLevel = levelError
+	}
+	var issues []doctorIssue
+	if problem := weakSessionKey(cfg.SessionKey); problem != "" {
+		issues = append(issues, issuef(prodLevel, "SESSION_KEY %s (generate one with: openssl rand -base64 32)", problem))
+	}
+	if !env.Deploy {
+		return issues
+	}
+	if cfg.Debug {
+		issues = append(issues, issuef(levelError, "DEBUG is on: HTTPS isn't enforced, session cookies aren't Secure and templates are re-parsed per request"))
+	}
+	if cfg.LiveReload {
+		issues = append(issues, issuef(levelWarning, "LIVE_RELOAD is on (it's only for `gojang dev`)"))
+	}
+	if len(cfg.AllowedHosts) == 0 || onlyLocalHosts(cfg.AllowedHosts) {
+		issues = append(issues, issuef(levelWarning, "ALLOWED_HOSTS doesn't name the production host: %q", cfg.AllowedHosts))
+	}
+	if strings.HasSuffix(cfg.SMTPFrom, "@localhost") {
+		issues = append(issues, issuef(levelWarning, "SMTP_FROM is %s, which mail servers will reject", cfg.SMTPFrom))
+	}
+	return issues
+}
+
+// weakSessionKey describes what's wrong with a session key, or returns ""
+// when it's long and varied enough to sign sessions and URLs
+func weakSessionKey(key string) string {
+	if len(key) < 32 {
+		return fmt.Sprintf("is %d characters; use at least 32", len(key))
+	}
+	distinct := make(map[rune]bool)
+	for _, c := range key {
+		distinct[c] = true
+	}
+	if len(distinct) < 10 {
+		return "repeats too few characters to be random"
+	}
+	return ""
+}
+
+func onlyLocalHosts(hosts []string) bool {
+	for _, host := range hosts {
+		switch strings.TrimSpace(host) {
+		case "localhost", "127.0.0.1", "::1", "":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// checkDatabase pings DATABASE_URL and the DATABASES connections
+func checkDatabase(env *doctorEnv) []doctorIssue {
+	if env.Config == nil {
+		return []doctorIssue{issuef(levelInfo, "skipped: no config")}
+	}
+
+	var issues []doctorIssue
+	path, sqlite := db.SQLitePath(env.Config.DatabaseURL)
+	if _, err := os.Stat(path); sqlite && errors.Is(err, os.ErrNotExist) {
+		// Pinging would create it
+		issues = append(issues, issuef(levelWarning, "%s doesn't exist yet (the server creates it on startup)", path))
+	} else if drv, err := pingDatabase(env.Config.DatabaseURL); err != nil {
+		issues = append(issues, issuef(levelError, "DATABASE_URL: %v", err))
+	} else {
+		env.Driver, env.DB = drv, drv.DB()
+	}
+
+	for _, entry := range env.Config.Databases {
+		name, url, ok := strings.Cut(entry, "=")
+		if !ok {
+			issues = append(issues, issuef(levelError, "DATABASES entry %q isn't name=URL", entry))
+			continue
+		}
+		drv, err := pingDatabase(url)
+		if err != nil {
+			issues = append(issues, issuef(levelError, "DATABASES %s: %v", name, err))
+			continue
+		}
+		drv.Close()
+	}
+	return issues
+}
+
+func pingDatabase(databaseURL string) (*entsql.Driver, error) {
+	drv, err := db.OpenDriver(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	if err := drv.DB().PingContext(ctx); err != nil {
+		drv.Close()
+		return nil, fmt.Errorf("unreachable: %w", err)
+	}
+	return drv, nil
+}
+
+// checkMigrations reports Ent schema changes the database doesn't have yet
+// and SQL migrations that haven't been applied
+func checkMigrations(env *doctorEnv) []doctorIssue {
+	if env.DB == nil {
+		return []doctorIssue{issuef(levelInfo, "skipped: the database check didn't connect")}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	var issues []doctorIssue
+	changes, err := pendingSchemaChanges(ctx, models.NewClient(models.Driver(env.Driver)))
+	if err != nil {
+		issues = append(issues, issuef(levelError, "diffing the Ent schema: %v", err))
+	} else if changes > 0 {
+		issues = append(issues, issuef(levelWarning, "%d Ent schema change(s) not applied (the server applies them on startup, or run: go run ./gojang/cmd/migrate auto)", changes))
+	}
+
+	latest, err := latestMigration(migrationsDir)
+	if err != nil {
+		return append(issues, issuef(levelError, "reading %s: %v", migrationsDir, err))
+	}
+	if latest == 0 {
+		return issues
+	}
+	version, dirty, err := migrationVersion(ctx, env.DB)
+	switch {
+	case err != nil:
+		issues = append(issues, issuef(levelError, "reading schema_migrations: %v", err))
+	case dirty:
+		issues = append(issues, issuef(levelError, "migration %d failed part way; fix the database, then clear dirty in schema_migrations", version))
+	case version < latest:
+		issues = append(issues, issuef(levelWarning, "SQL migrations are at %d of %d (run: go run ./gojang/cmd/migrate up)", version, latest))
+	}
+	return issues
+}
+
+// pendingSchemaChanges counts the statements auto-migration would run
+func pendingSchemaChanges(ctx context.Context, client *models.Client) (int, error) {
+	var ddl strings.Builder
+	if err := client.Schema.WriteTo(ctx, &ddl); err != nil {
+		return 0, err
+	}
+	changes := 0
+	for _, line := range strings.Split(ddl.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasSuffix(line, ";") && !strings.HasPrefix(line, "PRAGMA") && line != "BEGIN;" && line != "COMMIT;" {
+			changes++
+		}
+	}
+	return changes, nil
+}
+
+// latestMigration returns the highest version among dir's *.up.sql files
+// (e.g., 2 for 000002_add_posts.up.sql), or 0 when there are none
+func latestMigration(dir string) (uint64, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return 0, err
+	}
+	var latest uint64
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(filepath.Base(file), "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s doesn't start with a version number", file)
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+// migrationVersion reads the version golang-migrate recorded (0 when no
+// migration has run, so there's no schema_migrations table or row)
+func migrationVersion(ctx context.Context, sqlDB *sql.DB) (version uint64, dirty bool, err error) {
+	var table int
+	if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&table); err != nil {
+		return 0, false, nil
+	}
+	err = sqlDB.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// checkEntCode reports Ent schemas whose code hasn't been generated, or was
+// generated before the schema last changed
+func checkEntCode(env *doctorEnv) []doctorIssue {
+	schemas, err := entSchemas(filepath.Join(entDir, "schema"))
+	if err != nil {
+		return []doctorIssue{issuef(levelError, "reading Ent schemas: %v", err)}
+	}
+	var issues []doctorIssue
+	for _, s := range schemas {
+		generated := filepath.Join(entDir, strings.ToLower(s.name)+".go")
+		info, err := os.Stat(generated)
+		if err != nil {
+			issues = append(issues, issuef(levelError, "%s has no generated code (run: cd gojang/models && go generate ./...)", s.name))
+			continue
+		}
+		if s.modTime.After(info.ModTime()) {
+			issues = append(issues, issuef(levelWarning, "%s changed after %s was generated (run: cd gojang/models && go generate ./...)", s.file, generated))
+		}
+	}
+	return issues
+}
+
+// entSchema is a type embedding ent.Schema
+type entSchema struct {
+	name    string
+	file    string
+	modTime time.Time
+}
+
+// entSchemas finds the Ent schema types declared in dir
+func entSchemas(dir string) ([]entSchema, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var schemas []entSchema
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if st, ok := spec.Type.(*ast.StructType); ok && embedsEntSchema(st) {
+				schemas = append(schemas, entSchema{name: spec.Name.Name, file: file, modTime: info.ModTime()})
+			}
+			return false
+		})
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].name < schemas[j].name })
+	return schemas, nil
+}
+
+func embedsEntSchema(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if sel, ok := field.Type.(*ast.SelectorExpr); ok && len(field.Names) == 0 && sel.Sel.Name == "Schema" {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "ent" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkSMTP dials SMTP_HOST. Mail is optional, so failures are warnings.
+func checkSMTP(env *doctorEnv) []doctorIssue {
+	if env.Config == nil {
+		return []doctorIssue{issuef(levelInfo, "skipped: no config")}
+	}
+	if env.Config.SMTPHost == "" {
+		return []doctorIssue{issuef(levelInfo, "SMTP_HOST isn't set, so no mail is sent")}
+	}
+	addr := net.JoinHostPort(env.Config.SMTPHost, strconv.Itoa(env.Config.SMTPPort))
+	conn, err := net.DialTimeout("tcp", addr, doctorTimeout)
+	if err != nil {
+		return []doctorIssue{issuef(levelWarning, "SMTP server %s is unreachable: %v", addr, err)}
+	}
+	conn.Close()
+	return nil
+}
+
+// checkTemplateSets parses the templates the way `gojang check` does
+func checkTemplateSets(env *doctorEnv) []doctorIssue {
+	problems, err := checkTemplates(io.Discard, io.Discard)
+	if err != nil {
+		return []doctorIssue{issuef(levelError, "%v", err)}
+	}
+	issues := make([]doctorIssue, len(problems))
+	for i, problem := range problems {
+		issues[i] = issuef(levelError, "%s", problem)
+	}
+	return issues
+}
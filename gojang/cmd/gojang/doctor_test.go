@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/models/db"
+)
+
+// TestCheckConfig tests flagging insecure settings, as errors with -deploy
+func TestCheckConfig(t *testing.T) {
+	t.Chdir(t.TempDir()) // No .env
+	t.Setenv("DATABASE_URL", "sqlite://./app.db")
+	t.Setenv("DEBUG", "true")
+	t.Setenv("ALLOWED_HOSTS", "localhost")
+	t.Setenv("SESSION_KEY", "short")
+
+	env := &doctorEnv{}
+	issues := checkConfig(env)
+	if env.Config == nil {
+		t.Fatal("Expected the config to load")
+	}
+	if len(issues) != 1 || issues[0].level != levelWarning || !strings.Contains(issues[0].message, "SESSION_KEY") {
+		t.Errorf("Expected a SESSION_KEY warning in development, got %+v", issues)
+	}
+
+	issues = checkConfig(&doctorEnv{Deploy: true})
+	if worstLevel(issues) != levelError || len(issues) != 4 {
+		t.Errorf("Expected SESSION_KEY and DEBUG errors and ALLOWED_HOSTS and SMTP_FROM warnings, got %+v", issues)
+	}
+
+	t.Setenv("SESSION_KEY", "Xk3p9QvL2mN8rT5wY7zB1cF4hJ6dG0sA")
+	t.Setenv("DEBUG", "false")
+	t.Setenv("ALLOWED_HOSTS", "example.com")
+	t.Setenv("SMTP_FROM", "noreply@example.com")
+	if issues := checkConfig(&doctorEnv{Deploy: true}); len(issues) != 0 {
+		t.Errorf("Expected production settings to pass, got %+v", issues)
+	}
+}
+
+// TestWeakSessionKey tests rejecting short and repetitive keys
+func TestWeakSessionKey(t *testing.T) {
+	if weakSessionKey("abc") == "" {
+		t.Error("Expected a short key to be weak")
+	}
+	if weakSessionKey(strings.Repeat("ab", 20)) == "" {
+		t.Error("Expected a repetitive key to be weak")
+	}
+	if problem := weakSessionKey("Xk3p9QvL2mN8rT5wY7zB1cF4hJ6dG0sA"); problem != "" {
+		t.Errorf("Expected a random key to pass, got %q", problem)
+	}
+}
+
+// TestCheckMigrations tests reporting unapplied Ent and SQL migrations
+func TestCheckMigrations(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll(migrationsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(migrationsDir, "000002_add_index.up.sql"), []byte("SELECT 1;"), 0o644)
+
+	drv, err := db.OpenDriver("sqlite://" + filepath.Join(dir, "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &doctorEnv{Driver: drv, DB: drv.DB()}
+	defer env.close()
+
+	issues := checkMigrations(env)
+	if len(issues) != 2 || !strings.Contains(issues[0].message, "Ent schema change") || !strings.Contains(issues[1].message, "at 0 of 2") {
+		t.Fatalf("Expected pending Ent and SQL migrations, got %+v", issues)
+	}
+
+	client, _ := db.NewClient("sqlite://" + filepath.Join(dir, "app.db"))
+	defer client.Close()
+	if err := db.AutoMigrate(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	env.DB.Exec("CREATE TABLE schema_migrations (version bigint, dirty boolean)")
+	env.DB.Exec("INSERT INTO schema_migrations VALUES (2, false)")
+	if issues := checkMigrations(env); len(issues) != 0 {
+		t.Errorf("Expected a migrated database to pass, got %+v", issues)
+	}
+
+	env.DB.Exec("UPDATE schema_migrations SET dirty = true")
+	if issues := checkMigrations(env); worstLevel(issues) != levelError {
+		t.Errorf("Expected a dirty migration to be an error, got %+v", issues)
+	}
+}
+
+// TestCheckEntCode tests reporting schemas without up-to-date generated code
+func TestCheckEntCode(t *testing.T) {
+	t.Chdir(t.TempDir())
+	schemaDir := filepath.Join(entDir, "schema")
+	if err := os.MkdirAll(schemaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	schema := func(name string) string {
+		return "package schema\n\nimport \"entgo.io/ent\"\n\ntype " + name + " struct {\n\tent.Schema\n}\n"
+	}
+	os.WriteFile(filepath.Join(schemaDir, "post.go"), []byte(schema("Post")), 0o644)
+	os.WriteFile(filepath.Join(schemaDir, "tag.go"), []byte(schema("Tag")), 0o644)
+	os.WriteFile(filepath.Join(entDir, "post.go"), []byte("package models\n"), 0o644)
+
+	// The generated code is older than the schema
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(entDir, "post.go"), old, old)
+
+	issues := checkEntCode(nil)
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %+v", issues)
+	}
+	if issues[0].level != levelWarning || !strings.Contains(issues[0].message, "changed after") {
+		t.Errorf("Expected Post's code to be stale, got %+v", issues[0])
+	}
+	if issues[1].level != levelError || !strings.Contains(issues[1].message, "Tag has no generated code") {
+		t.Errorf("Expected Tag to have no code, got %+v", issues[1])
+	}
+}
+
+// TestRunChecks tests counting issues and printing them per check
+func TestRunChecks(t *testing.T) {
+	checks := []doctorCheck{
+		{"ok", func(*doctorEnv) []doctorIssue { return nil }},
+		{"bad", func(*doctorEnv) []doctorIssue {
+			return []doctorIssue{issuef(levelWarning, "careful"), issuef(levelError, "broken"), issuef(levelInfo, "skipped")}
+		}},
+	}
+	var out strings.Builder
+	errs, warnings := runChecks(&out, &doctorEnv{}, checks)
+	if errs != 1 || warnings != 1 {
+		t.Errorf("Expected 1 error and 1 warning, got %d and %d", errs, warnings)
+	}
+	for _, want := range []string{"✅ ok", "❌ bad", "careful", "broken"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/admin"
+	"github.com/gojangframework/gojang/gojang/models"
+	_ "github.com/mattn/go-sqlite3" // For the in-memory registry database
+)
+
+// runGen dispatches `gojang gen <subcommand>`
+func runGen(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "client":
+			return runGenClient(args[1:])
+		case "ts":
+			return runGenTS(args[1:])
+		}
+	}
+	fmt.Println("Usage: gojang gen <subcommand> [flags]")
+	fmt.Println("  client - Generate a typed Go client package for the admin JSON API")
+	fmt.Println("  ts     - Generate TypeScript interfaces for forms and JSON API responses")
+	return nil
+}
+
+// runGenClient writes the admin API client package
+func runGenClient(args []string) error {
+	fs := flag.NewFlagSet("gen client", flag.ExitOnError)
+	dir := fs.String("dir", ".", "project root (where go.mod is)")
+	out := fs.String("out", "gojangclient", "directory to write the client to, relative to -dir")
+	pkg := fs.String("pkg", "", "package name (defaults to the last element of -out)")
+	fs.Parse(args)
+
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	name := *pkg
+	if name == "" {
+		name = filepath.Base(*out)
+	}
+	schema, err := apiSchema()
+	if err != nil {
+		return err
+	}
+	src, err := genClient(name, schema)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(*out, "client.go")
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Wrote %s (%d models)\n", path, len(schema))
+	return nil
+}
+
+// runGenTS writes forms.ts and api.ts
+func runGenTS(args []string) error {
+	fs := flag.NewFlagSet("gen ts", flag.ExitOnError)
+	dir := fs.String("dir", ".", "project root (where go.mod is)")
+	out := fs.String("out", "web/types", "directory to write the .ts files to, relative to -dir")
+	fs.Parse(args)
+
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	schema, err := apiSchema()
+	if err != nil {
+		return err
+	}
+	files, err := tsFiles(schema)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	for _, name := range []string{"forms.ts", "api.ts"} {
+		path := filepath.Join(*out, name)
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Wrote %s\n", path)
+	}
+	return nil
+}
+
+// apiSchema describes the models of admin.RegisterModels as the admin API
+// serves them. Registration doesn't query the database, so an empty in-memory
+// one will do.
+func apiSchema() ([]admin.APIModel, error) {
+	client, err := models.Open("sqlite3", "file:gen?mode=memory&_fk=1")
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	registry := admin.NewRegistry(client)
+	admin.RegisterModels(registry)
+	return admin.APISchema(registry), nil
+}
+
+// genClient renders the client package for schema, gofmt'ed
+func genClient(pkg string, schema []admin.APIModel) ([]byte, error) {
+	data := clientPackage{Package: pkg}
+	for _, m := range schema {
+		cm := clientModel{
+			Name:   m.Name,
+			Plural: strings.ReplaceAll(m.NamePlural, " ", ""),
+			Path:   m.URL,
+			Lower:  strings.ToLower(m.NamePlural),
+		}
+		for _, f := range m.Fields {
+			// Many-to-many fields are edited in the admin UI only
+			if f.Type == admin.FieldTypeRelations {
+				continue
+			}
+			recordType, inputType := clientGoTypes(f.Type)
+			data.Time = data.Time || f.Type == admin.FieldTypeTime
+			if !f.WriteOnly {
+				cm.Fields = append(cm.Fields, clientField{Name: f.Name, Type: recordType, Comment: clientFieldComment(f, false)})
+				data.Point = data.Point || f.Type == admin.FieldTypeGeo
+			}
+			if !f.Readonly && f.Type != admin.FieldTypeComputed {
+				cm.Inputs = append(cm.Inputs, clientField{Name: f.Name, Type: inputType, Comment: clientFieldComment(f, true)})
+			}
+		}
+		data.Models = append(data.Models, cm)
+	}
+
+	var buf bytes.Buffer
+	if err := clientTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated client doesn't parse: %w", err)
+	}
+	return src, nil
+}
+
+// clientGoTypes returns the Go type of a field in records and in the input
+// struct, which takes values in the admin form's formats
+func clientGoTypes(t admin.FieldType) (record, input string) {
+	switch t {
+	case admin.FieldTypeBool:
+		return "bool", "bool"
+	case admin.FieldTypeInt:
+		return "int64", "int64"
+	case admin.FieldTypeFloat:
+		return "float64", "float64"
+	case admin.FieldTypeTime:
+		return "time.Time", "time.Time"
+	case admin.FieldTypeMoney:
+		return "int64", "string"
+	case admin.FieldTypeGeo:
+		return "*Point", "string"
+	case admin.FieldTypeJSON, admin.FieldTypeComputed:
+		return "json.RawMessage", "json.RawMessage"
+	}
+	return "string", "string"
+}
+
+// clientFieldComment documents a generated field where its type doesn't say it all
+func clientFieldComment(f admin.APIField, input bool) string {
+	var notes []string
+	switch {
+	case f.Type == admin.FieldTypeRelation || f.Type == admin.FieldTypeUUID:
+		notes = append(notes, "UUID")
+	case f.Type == admin.FieldTypeMoney && input:
+		notes = append(notes, `Amount like "12.50"`)
+	case f.Type == admin.FieldTypeMoney:
+		notes = append(notes, "Minor units (e.g., cents)")
+	case f.Type == admin.FieldTypeGeo && input:
+		notes = append(notes, `"lat,lng"`)
+	case f.Type == admin.FieldTypeComputed:
+		notes = append(notes, "Computed")
+	}
+	if input {
+		switch {
+		case f.Required && f.EditOnly:
+			notes = append(notes, "required on update")
+		case f.Required:
+			notes = append(notes, "required on create")
+		case f.CreateOnly:
+			notes = append(notes, "create only")
+		case f.EditOnly:
+			notes = append(notes, "update only")
+		}
+	}
+	if len(notes) > 0 {
+		notes[0] = strings.ToUpper(notes[0][:1]) + notes[0][1:]
+	}
+	return strings.Join(notes, "; ")
+}
+
+// clientPackage is what the client package is generated from
+type clientPackage struct {
+	Package string
+	Models  []clientModel
+	Time    bool // Some field is a time.Time
+	Point   bool // Some record has a geo field
+}
+
+// clientModel is a model's record, input and client types
+type clientModel struct {
+	Name   string // e.g., "User"
+	Plural string // Client field, e.g., "Users"
+	Lower  string // For doc comments, e.g., "users"
+	Path   string // e.g., "/admin/api/user"
+	Fields []clientField
+	Inputs []clientField
+}
+
+// clientField is a field of a generated struct
+type clientField struct {
+	Name    string
+	Type    string
+	Comment string
+}
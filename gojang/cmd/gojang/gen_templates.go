@@ -0,0 +1,276 @@
+package main
+
+import "text/template"
+
+var clientTemplate = template.Must(template.New("client.go").Parse(`// Code generated by ` + "`gojang gen client`" + `. DO NOT EDIT.
+
+// Package {{.Package}} calls a Gojang app's admin JSON API (/admin/api) as a
+// staff user, with one client per model:
+//
+//	c := {{.Package}}.New("https://example.com", token)
+//	page, err := c.{{with index .Models 0}}{{.Plural}}{{end}}.List(ctx, {{.Package}}.ListOptions{Sort: "-CreatedAt"})
+//
+// Regenerate it with ` + "`gojang gen client`" + ` after changing models.
+package {{.Package}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+{{- if .Time}}
+	"time"
+{{- end}}
+)
+
+// SessionCookie carries Client.Token
+const SessionCookie = "session_id"
+
+// Client calls the admin API. Token is the session token of a signed-in staff
+// user (their session_id cookie); sessions expire after 30 idle minutes.
+// Deletes and permission changes also need the user to have confirmed their
+// password in the admin recently (sudo mode).
+type Client struct {
+	BaseURL    string       // e.g., "https://example.com"
+	Token      string       // Session token
+	HTTPClient *http.Client // nil uses http.DefaultClient
+{{range .Models}}
+	{{.Plural}} *{{.Name}}Client
+{{- end}}
+
+	mu      sync.Mutex
+	csrf    string         // X-CSRF-Token for writes, fetched on first use
+	cookies []*http.Cookie // The CSRF cookie it goes with
+}
+
+// New returns a client for the app at baseURL, signed in with token
+func New(baseURL, token string) *Client {
+	c := &Client{BaseURL: strings.TrimRight(baseURL, "/"), Token: token}
+{{- range .Models}}
+	c.{{.Plural}} = &{{.Name}}Client{c: c}
+{{- end}}
+	return c
+}
+
+// Error is an error response. Fields holds validation errors by field name.
+type Error struct {
+	StatusCode int               ` + "`json:\"-\"`" + `
+	Message    string            ` + "`json:\"error\"`" + `
+	Fields     map[string]string ` + "`json:\"fields,omitempty\"`" + `
+}
+
+func (e *Error) Error() string {
+	if len(e.Fields) > 0 {
+		return fmt.Sprintf("%d %s: %v", e.StatusCode, e.Message, e.Fields)
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+}
+
+// ListOptions selects a page of records, like the admin list view
+type ListOptions struct {
+	Page    int               // From 1; 0 is the first page
+	PerPage int               // 1-100; 0 uses the server's default (20)
+	Sort    string            // Field name; a "-" prefix sorts descending (e.g., "-CreatedAt")
+	Filters map[string]string // Field name -> value (substring match for strings, exact otherwise)
+}
+
+func (o ListOptions) query() string {
+	q := url.Values{}
+	if o.Page > 0 {
+		q.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	for name, value := range o.Filters {
+		q.Set("f_"+name, value)
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// Page is a page of records
+type Page[T any] struct {
+	Data    []T ` + "`json:\"data\"`" + `
+	Page    int ` + "`json:\"page\"`" + `
+	PerPage int ` + "`json:\"per_page\"`" + `
+	Total   int ` + "`json:\"total\"`" + `
+}
+{{- if .Point}}
+
+// Point is a location in decimal degrees
+type Point struct {
+	Lat float64 ` + "`json:\"lat\"`" + `
+	Lng float64 ` + "`json:\"lng\"`" + `
+}
+{{- end}}
+
+// do sends a request with in as its JSON body and decodes the response into out
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.AddCookie(&http.Cookie{Name: SessionCookie, Value: c.Token})
+	if method != http.MethodGet {
+		token, cookies, err := c.csrfToken(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-CSRF-Token", token)
+		req.Header.Set("Referer", c.BaseURL+"/admin/api/")
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+	}
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// Requests that aren't signed in are redirected to the login page
+	if res.Request.URL.Path != req.URL.Path {
+		return &Error{StatusCode: http.StatusUnauthorized, Message: "Not signed in (is Token a staff user's current session?)"}
+	}
+	if res.StatusCode >= 400 {
+		apiErr := &Error{StatusCode: res.StatusCode}
+		if json.NewDecoder(res.Body).Decode(apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(res.StatusCode)
+		}
+		return apiErr
+	}
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// csrfToken returns the CSRF token and cookie that writes send, fetching them
+// from the model index the first time
+func (c *Client) csrfToken(ctx context.Context) (string, []*http.Cookie, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.csrf != "" {
+		return c.csrf, c.cookies, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/admin/api/", nil)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.AddCookie(&http.Cookie{Name: SessionCookie, Value: c.Token})
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer res.Body.Close()
+
+	var index struct {
+		CSRFToken string ` + "`json:\"csrf_token\"`" + `
+	}
+	if res.Request.URL.Path != req.URL.Path || res.StatusCode != http.StatusOK || json.NewDecoder(res.Body).Decode(&index) != nil || index.CSRFToken == "" {
+		return "", nil, &Error{StatusCode: http.StatusUnauthorized, Message: "Failed to get a CSRF token (is Token a staff user's current session?)"}
+	}
+	c.csrf, c.cookies = index.CSRFToken, res.Cookies()
+	return c.csrf, c.cookies, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+{{range .Models}}
+// {{.Name}} is a {{.Name}} record
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`json:\"{{.Name}}\"`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+
+// {{.Name}}Input sets {{.Name}} fields on Create and Update; nil fields aren't sent
+type {{.Name}}Input struct {
+{{- range .Inputs}}
+	{{.Name}} *{{.Type}} ` + "`json:\"{{.Name}},omitempty\"`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+
+// {{.Name}}Client calls {{.Path}}
+type {{.Name}}Client struct {
+	c *Client
+}
+
+// List returns a page of {{.Lower}}
+func (m *{{.Name}}Client) List(ctx context.Context, opts ListOptions) (*Page[{{.Name}}], error) {
+	var page Page[{{.Name}}]
+	if err := m.c.do(ctx, http.MethodGet, "{{.Path}}"+opts.query(), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Get returns the {{.Name}} with the given ID
+func (m *{{.Name}}Client) Get(ctx context.Context, id string) (*{{.Name}}, error) {
+	var res struct {
+		Data {{.Name}} ` + "`json:\"data\"`" + `
+	}
+	if err := m.c.do(ctx, http.MethodGet, "{{.Path}}/"+url.PathEscape(id), nil, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// Create creates a {{.Name}}
+func (m *{{.Name}}Client) Create(ctx context.Context, in {{.Name}}Input) (*{{.Name}}, error) {
+	var res struct {
+		Data {{.Name}} ` + "`json:\"data\"`" + `
+	}
+	if err := m.c.do(ctx, http.MethodPost, "{{.Path}}", in, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// Update changes the fields set in the input and returns the updated {{.Name}}
+func (m *{{.Name}}Client) Update(ctx context.Context, id string, in {{.Name}}Input) (*{{.Name}}, error) {
+	var res struct {
+		Data {{.Name}} ` + "`json:\"data\"`" + `
+	}
+	if err := m.c.do(ctx, http.MethodPatch, "{{.Path}}/"+url.PathEscape(id), in, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// Delete deletes the {{.Name}} with the given ID
+func (m *{{.Name}}Client) Delete(ctx context.Context, id string) error {
+	return m.c.do(ctx, http.MethodDelete, "{{.Path}}/"+url.PathEscape(id), nil, nil)
+}
+{{end}}`))
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+// TestGenClient tests that the generated client type-checks and has a client per model
+func TestGenClient(t *testing.T) {
+	schema, err := apiSchema()
+	if err != nil {
+		t.Fatalf("apiSchema failed: %v", err)
+	}
+	src, err := genClient("testclient", schema)
+	if err != nil {
+		t.Fatalf("genClient failed: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Generated client doesn't parse: %v", err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("testclient", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("Generated client doesn't type-check: %v\n%s", err, src)
+	}
+
+	for _, name := range []string{"UserClient", "PostClient", "GroupClient"} {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			t.Fatalf("Expected a %s type", name)
+		}
+		methods := types.NewMethodSet(types.NewPointer(obj.Type()))
+		for _, method := range []string{"List", "Get", "Create", "Update", "Delete"} {
+			if methods.Lookup(pkg, method) == nil {
+				t.Errorf("Expected %s.%s", name, method)
+			}
+		}
+	}
+
+	// Write-only fields are only in inputs, and read-only ones only in records
+	code := string(src)
+	user := code[strings.Index(code, "type User struct"):strings.Index(code, "type UserInput struct")]
+	input := code[strings.Index(code, "type UserInput struct"):strings.Index(code, "type UserClient struct")]
+	if strings.Contains(user, "Password") || !strings.Contains(input, "Password") {
+		t.Error("Expected Password in UserInput only")
+	}
+	if !strings.Contains(user, "CreatedAt") || strings.Contains(input, "CreatedAt") {
+		t.Error("Expected CreatedAt in User only")
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/admin"
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+	"github.com/gojangframework/gojang/gojang/version"
+	"github.com/gojangframework/gojang/gojang/views/forms"
+	"github.com/google/uuid"
+)
+
+// tsHeader starts every file `gojang gen ts` writes
+const tsHeader = "// Code generated by `gojang gen ts`. DO NOT EDIT.\n"
+
+// tsType is a Go struct to emit as a TypeScript interface
+type tsType struct {
+	Value     interface{}       // A value of the struct
+	Name      string            // Interface name; defaults to the Go type's
+	Params    string            // Type parameters, e.g., "T = unknown"
+	Overrides map[string]string // Go field name -> TypeScript type
+}
+
+// tsForms are the form structs handlers bind, keyed by their form tags. Add
+// forms here as you create them.
+var tsForms = []tsType{
+	{Value: forms.LoginForm{}},
+	{Value: forms.RegisterForm{}},
+	{Value: forms.UserForm{}},
+	{Value: forms.PostForm{}},
+}
+
+// tsAPI are the bodies of JSON responses, keyed by their json tags. Structs
+// they contain are emitted too. Add DTOs here as you create them.
+var tsAPI = []tsType{
+	{Value: api.ErrorResponse{}},
+	{Value: api.Problem{}},
+	{Value: api.Page{}, Params: "T = unknown", Overrides: map[string]string{"Data": "T[]"}},
+	{Value: api.Envelope{}, Params: "T = unknown", Overrides: map[string]string{"Data": "T"}},
+	{Value: handlers.PostJSON{}},
+	{Value: version.Info{}, Name: "VersionInfo"},
+	{Value: admin.AutocompleteResult{}},
+	{Value: admin.PaletteItem{}},
+	{Value: admin.APIModel{}},
+}
+
+// tsFiles renders forms.ts and api.ts. api.ts also has the records and inputs
+// of the admin API's models (schema), prefixed with "Admin".
+func tsFiles(schema []admin.APIModel) (map[string][]byte, error) {
+	formsTS := &tsWriter{tag: "form"}
+	for _, t := range tsForms {
+		if err := formsTS.add(t); err != nil {
+			return nil, err
+		}
+	}
+
+	apiTS := &tsWriter{tag: "json"}
+	for _, t := range tsAPI {
+		if err := apiTS.add(t); err != nil {
+			return nil, err
+		}
+	}
+	apiTS.adminModels(schema)
+
+	return map[string][]byte{
+		"forms.ts": formsTS.bytes(),
+		"api.ts":   apiTS.bytes(),
+	}, nil
+}
+
+// tsWriter emits interfaces for structs, using tag for property names
+type tsWriter struct {
+	tag  string
+	buf  strings.Builder
+	done map[reflect.Type]bool
+	todo []tsType // Nested structs left to emit
+}
+
+func (w *tsWriter) bytes() []byte {
+	return []byte(tsHeader + w.buf.String())
+}
+
+// add emits t and the structs it refers to
+func (w *tsWriter) add(t tsType) error {
+	w.todo = append(w.todo, t)
+	for len(w.todo) > 0 {
+		next := w.todo[0]
+		w.todo = w.todo[1:]
+		if err := w.emit(next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *tsWriter) emit(t tsType) error {
+	typ := reflect.TypeOf(t.Value)
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("gen ts: %s is not a struct", typ)
+	}
+	if w.done == nil {
+		w.done = make(map[reflect.Type]bool)
+	}
+	if w.done[typ] {
+		return nil
+	}
+	w.done[typ] = true
+
+	name := t.Name
+	if name == "" {
+		name = typ.Name()
+	}
+	if t.Params != "" {
+		name += "<" + t.Params + ">"
+	}
+	fmt.Fprintf(&w.buf, "\n// %s is %s.%s\nexport interface %s {\n", strings.SplitN(name, "<", 2)[0], typ.PkgPath()[strings.LastIndex(typ.PkgPath(), "/")+1:], typ.Name(), name)
+	w.fields(typ, t.Overrides)
+	w.buf.WriteString("}\n")
+	return nil
+}
+
+// fields emits the properties of typ, flattening embedded structs like encoding/json
+func (w *tsWriter) fields(typ reflect.Type, overrides map[string]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get(w.tag)
+		if tag == "-" {
+			continue
+		}
+		key, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && key == "" && f.Type.Kind() == reflect.Struct {
+			w.fields(f.Type, overrides)
+			continue
+		}
+		if key == "" {
+			key = f.Name
+		}
+
+		tsType, ok := overrides[f.Name]
+		if !ok {
+			tsType = w.tsType(f.Type)
+		}
+		optional := strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero")
+		if w.tag == "form" {
+			// Forms post every field; those without "required" may be empty
+			optional = !hasRule(f.Tag.Get("validate"), "required")
+		}
+		if optional {
+			key += "?"
+		}
+		fmt.Fprintf(&w.buf, "  %s: %s;\n", tsKey(key), tsType)
+	}
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// tsType returns the TypeScript type of Go values of t as encoding/json writes them
+func (w *tsWriter) tsType(t reflect.Type) string {
+	switch t {
+	case timeType:
+		return "string" // RFC 3339
+	case uuidType:
+		return "string"
+	case rawType:
+		return "unknown"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Pointer:
+		return w.tsType(t.Elem()) + " | null"
+	case reflect.Slice, reflect.Array:
+		elem := w.tsType(t.Elem())
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Map:
+		return "Record<string, " + w.tsType(t.Elem()) + ">"
+	case reflect.Struct:
+		if t.Name() == "" {
+			return "unknown"
+		}
+		w.todo = append(w.todo, tsType{Value: reflect.Zero(t).Interface()})
+		return t.Name()
+	}
+	return "unknown"
+}
+
+// adminModels emits a record and an input interface per admin API model
+func (w *tsWriter) adminModels(schema []admin.APIModel) {
+	w.buf.WriteString(`
+// AdminPage is the body of GET /admin/api/{model}
+export interface AdminPage<T> {
+  data: T[];
+  page: number;
+  per_page: number;
+  total: number;
+}
+
+// AdminValidationError is the body of 422 admin API responses
+export interface AdminValidationError {
+  error: string;
+  fields: Record<string, string>;
+}
+`)
+	for _, m := range schema {
+		fmt.Fprintf(&w.buf, "\n// Admin%s is a %s record from %s\nexport interface Admin%s {\n", m.Name, m.Name, m.URL, m.Name)
+		for _, f := range m.Fields {
+			if f.Type == admin.FieldTypeRelations || f.WriteOnly {
+				continue
+			}
+			record, _ := adminTSTypes(f.Type)
+			fmt.Fprintf(&w.buf, "  %s: %s;\n", tsKey(f.Name), record)
+		}
+		w.buf.WriteString("}\n")
+
+		fmt.Fprintf(&w.buf, "\n// Admin%sInput is the body of %s creates and updates\nexport interface Admin%sInput {\n", m.Name, m.Name, m.Name)
+		for _, f := range m.Fields {
+			if f.Type == admin.FieldTypeRelations || f.Readonly || f.Type == admin.FieldTypeComputed {
+				continue
+			}
+			_, input := adminTSTypes(f.Type)
+			fmt.Fprintf(&w.buf, "  %s?: %s;\n", tsKey(f.Name), input)
+		}
+		w.buf.WriteString("}\n")
+	}
+}
+
+// adminTSTypes returns the TypeScript type of a field in admin API records and
+// inputs; like clientGoTypes, inputs take the admin form's formats
+func adminTSTypes(t admin.FieldType) (record, input string) {
+	switch t {
+	case admin.FieldTypeBool:
+		return "boolean", "boolean"
+	case admin.FieldTypeInt, admin.FieldTypeFloat:
+		return "number", "number"
+	case admin.FieldTypeMoney:
+		return "number", "string" // Minor units; an amount like "12.50"
+	case admin.FieldTypeGeo:
+		return "{ lat: number; lng: number } | null", "string" // "lat,lng"
+	case admin.FieldTypeJSON, admin.FieldTypeComputed:
+		return "unknown", "unknown"
+	}
+	return "string", "string"
+}
+
+// hasRule reports whether a validate tag has rule (not just one starting with it)
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// tsKey quotes property names that aren't identifiers
+func tsKey(key string) string {
+	name := strings.TrimSuffix(key, "?")
+	for i, c := range name {
+		if !(c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return fmt.Sprintf("%q", name) + key[len(name):]
+		}
+	}
+	return key
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTSFiles tests the interfaces generated for forms, API responses and admin models
+func TestTSFiles(t *testing.T) {
+	schema, err := apiSchema()
+	if err != nil {
+		t.Fatalf("apiSchema failed: %v", err)
+	}
+	files, err := tsFiles(schema)
+	if err != nil {
+		t.Fatalf("tsFiles failed: %v", err)
+	}
+
+	formsTS, apiTS := string(files["forms.ts"]), string(files["api.ts"])
+	for _, want := range []string{
+		"export interface LoginForm {\n  login: string;\n  password: string;\n  next?: string;\n}",
+		"password_confirm: string;",
+		"is_staff?: boolean;",
+	} {
+		if !strings.Contains(formsTS, want) {
+			t.Errorf("Expected forms.ts to contain %q, got:\n%s", want, formsTS)
+		}
+	}
+	for _, want := range []string{
+		"export interface Page<T = unknown> {\n  data: T[];\n  next_cursor?: string;\n}",
+		"author?: AuthorJSON | null;",
+		"export interface AuthorJSON {",
+		"created_at: string;",
+		"export interface VersionInfo {",
+		"fields: APIField[];",
+		"export interface AdminPost {",
+		"PendingReview: boolean;",
+	} {
+		if !strings.Contains(apiTS, want) {
+			t.Errorf("Expected api.ts to contain %q, got:\n%s", want, apiTS)
+		}
+	}
+
+	// Computed fields are only in records, write-only ones only in inputs
+	input := apiTS[strings.Index(apiTS, "export interface AdminPostInput"):]
+	input = input[:strings.Index(input, "}")]
+	if strings.Contains(input, "WordCount") || !strings.Contains(input, "Subject?: string;") {
+		t.Errorf("Unexpected AdminPostInput:\n%s", input)
+	}
+	record := apiTS[strings.Index(apiTS, "export interface AdminUser {"):]
+	record = record[:strings.Index(record, "}")]
+	if strings.Contains(record, "Password") {
+		t.Errorf("Expected no password in AdminUser:\n%s", record)
+	}
+}
+
+// TestTSKey tests that property names that aren't identifiers are quoted
+func TestTSKey(t *testing.T) {
+	tests := map[string]string{
+		"name":        "name",
+		"next_cursor": "next_cursor",
+		"is-active?":  `"is-active"?`,
+		"2fa":         `"2fa"`,
+	}
+	for key, want := range tests {
+		if got := tsKey(key); got != want {
+			t.Errorf("tsKey(%q) = %s, want %s", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// maxRefs is how many rows of a referenced table loadseed picks foreign keys from
+const maxRefs = 10000
+
+// loadWords make up the text loadseed writes
+var loadWords = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing elit sed do
+	eiusmod tempor incididunt ut labore et dolore magna aliqua enim ad minim veniam quis
+	nostrud exercitation ullamco laboris nisi aliquip ex ea commodo consequat duis aute irure
+	in reprehenderit voluptate velit esse cillum eu fugiat nulla pariatur excepteur sint
+	occaecat cupidatat non proident sunt culpa qui officia deserunt mollit anim id est laborum`)
+
+// modelCounts is the repeatable -model Name=count flag
+type modelCounts []modelCount
+
+type modelCount struct {
+	Model string
+	Count int
+}
+
+func (m *modelCounts) String() string {
+	var parts []string
+	for _, mc := range *m {
+		parts = append(parts, fmt.Sprintf("%s=%d", mc.Model, mc.Count))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (m *modelCounts) Set(value string) error {
+	model, count, ok := strings.Cut(value, "=")
+	n, err := strconv.Atoi(count)
+	if !ok || model == "" || err != nil || n < 0 {
+		return fmt.Errorf("want Model=count, e.g. Poll=100")
+	}
+	*m = append(*m, modelCount{Model: model, Count: n})
+	return nil
+}
+
+// loadOptions are the flags of `gojang loadseed`
+type loadOptions struct {
+	Users    int         // Users to create, all with Password
+	Posts    int         // Posts to create, by random users
+	Models   modelCounts // Rows of other models to create
+	Batch    int         // Rows per INSERT
+	Password string      // Password of the created users
+	Run      string      // Tags emails and unique values so runs don't collide
+}
+
+// runLoadSeed implements `gojang loadseed`
+func runLoadSeed(args []string) error {
+	flags := flag.NewFlagSet("loadseed", flag.ExitOnError)
+	dir := flags.String("dir", ".", "project root (where .env and gojang/ are)")
+	opts := loadOptions{Run: strconv.FormatInt(time.Now().Unix(), 36)}
+	flags.IntVar(&opts.Users, "users", 100, "users to create")
+	flags.IntVar(&opts.Posts, "posts", 1000, "posts to create, by random users")
+	flags.Var(&opts.Models, "model", "rows of another model to create, as Model=count (repeatable)")
+	flags.IntVar(&opts.Batch, "batch", 500, "rows per INSERT")
+	flags.StringVar(&opts.Password, "password", "Load-test-pass1", "password of the created users")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: gojang loadseed [flags]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if opts.Users < 0 || opts.Posts < 0 {
+		return fmt.Errorf("-users and -posts can't be negative")
+	}
+	if opts.Batch < 1 {
+		return fmt.Errorf("-batch must be at least 1, got %d", opts.Batch)
+	}
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	drv, err := db.OpenDriver(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	client := models.NewClient(models.Driver(drv))
+	defer client.Close()
+
+	return newLoadSeeder(client, drv, opts).seed(context.Background())
+}
+
+// loadSeeder fills a database with generated rows for load testing
+type loadSeeder struct {
+	client *models.Client
+	drv    dialect.Driver
+	opts   loadOptions
+	rand   *rand.Rand
+}
+
+func newLoadSeeder(client *models.Client, drv dialect.Driver, opts loadOptions) *loadSeeder {
+	return &loadSeeder{client: client, drv: drv, opts: opts, rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))}
+}
+
+// seed creates the users, then the posts, then the -model rows in the order given
+func (s *loadSeeder) seed(ctx context.Context) error {
+	if err := s.step(ctx, "users", s.opts.Users, s.users); err != nil {
+		return err
+	}
+	if err := s.step(ctx, "posts", s.opts.Posts, s.posts); err != nil {
+		return err
+	}
+	for _, mc := range s.opts.Models {
+		model := mc.Model
+		if err := s.step(ctx, model+" rows", mc.Count, func(ctx context.Context, n int) error {
+			return s.rows(ctx, model, n)
+		}); err != nil {
+			return err
+		}
+	}
+	if s.opts.Users > 0 {
+		fmt.Printf("Users sign in as load-%s-<n>@example.com with password %s\n", s.opts.Run, s.opts.Password)
+	}
+	return nil
+}
+
+// step runs one kind of seeding and reports how fast it went
+func (s *loadSeeder) step(ctx context.Context, what string, n int, seed func(ctx context.Context, n int) error) error {
+	if n == 0 {
+		return nil
+	}
+	fmt.Printf("⏳ Creating %d %s...\n", n, what)
+	start := time.Now()
+	if err := seed(ctx, n); err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	elapsed := time.Since(start)
+	fmt.Printf("✅ %d %s in %s (%.0f/s)\n", n, what, elapsed.Round(time.Millisecond), float64(n)/elapsed.Seconds())
+	return nil
+}
+
+// batches calls insert with consecutive ranges of [0, n), at most
+// opts.Batch long
+func (s *loadSeeder) batches(n int, insert func(from, to int) error) error {
+	for from := 0; from < n; from += s.opts.Batch {
+		if err := insert(from, min(from+s.opts.Batch, n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// users creates n active users sharing one password, so the hash is only
+// computed once
+func (s *loadSeeder) users(ctx context.Context, n int) error {
+	hash, err := utils.HashPassword(s.opts.Password)
+	if err != nil {
+		return err
+	}
+	return s.batches(n, func(from, to int) error {
+		builders := make([]*models.UserCreate, 0, to-from)
+		for i := from; i < to; i++ {
+			joined := s.pastTime()
+			builders = append(builders, s.client.User.Create().
+				SetEmail(fmt.Sprintf("load-%s-%d@example.com", s.opts.Run, i+1)).
+				SetPasswordHash(hash).
+				SetIsActive(true).
+				SetCreatedAt(joined).
+				SetUpdatedAt(joined))
+		}
+		return s.client.User.CreateBulk(builders...).Exec(ctx)
+	})
+}
+
+// posts creates n posts by users picked at random
+func (s *loadSeeder) posts(ctx context.Context, n int) error {
+	authors, err := s.client.User.Query().Limit(maxRefs).IDs(ctx)
+	if err != nil {
+		return err
+	}
+	if len(authors) == 0 {
+		return fmt.Errorf("there are no users to write them; create some with -users")
+	}
+	return s.batches(n, func(from, to int) error {
+		builders := make([]*models.PostCreate, 0, to-from)
+		for i := from; i < to; i++ {
+			written := s.pastTime()
+			builders = append(builders, s.client.Post.Create().
+				SetSubject(s.sentence(4+s.rand.IntN(6))).
+				SetBody(s.paragraphs(1+s.rand.IntN(4))).
+				SetAuthorID(authors[s.rand.IntN(len(authors))]).
+				SetCreatedAt(written).
+				SetUpdatedAt(written))
+		}
+		return s.client.Post.CreateBulk(builders...).Exec(ctx)
+	})
+}
+
+// rows inserts n rows of model with values generated from its columns. It
+// writes SQL directly, so Ent hooks and validators don't run; the rows only
+// satisfy the database schema. Columns with a default get it, nullable ones
+// are left NULL and foreign keys point at random existing rows.
+func (s *loadSeeder) rows(ctx context.Context, model string, n int) error {
+	table := db.TableOf(model)
+	if table == nil {
+		return fmt.Errorf("unknown model %q (use its Go name, e.g. UserPreference)", model)
+	}
+
+	refs := map[string][]interface{}{}
+	for _, fk := range table.ForeignKeys {
+		ids, err := s.ids(ctx, fk.RefTable)
+		if err != nil {
+			return err
+		}
+		for _, c := range fk.Columns {
+			if len(ids) == 0 && !c.Nullable {
+				return fmt.Errorf("%s needs rows in %s first", model, fk.RefTable.Name)
+			}
+			refs[c.Name] = ids
+		}
+	}
+	unique := map[string]bool{}
+	for _, idx := range table.Indexes {
+		for _, c := range idx.Columns {
+			unique[c.Name] = unique[c.Name] || idx.Unique
+		}
+	}
+	goTypes := s.goTypes(model)
+
+	var columns []*schema.Column
+	for _, c := range table.Columns {
+		if !c.Increment {
+			columns = append(columns, c)
+		}
+	}
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+
+	return s.batches(n, func(from, to int) error {
+		insert := entsql.Dialect(s.drv.Dialect()).Insert(table.Name).Columns(names...)
+		for i := from; i < to; i++ {
+			values := make([]interface{}, len(columns))
+			for j, c := range columns {
+				v, err := s.value(c, i+1, unique[c.Name] || c.Unique, refs[c.Name], goTypes[c.Name])
+				if err != nil {
+					return err
+				}
+				values[j] = v
+			}
+			insert.Values(values...)
+		}
+		query, args := insert.Query()
+		return s.drv.Exec(ctx, query, args, nil)
+	})
+}
+
+// ids returns the IDs of up to maxRefs rows of table
+func (s *loadSeeder) ids(ctx context.Context, table *schema.Table) ([]interface{}, error) {
+	query, args := entsql.Dialect(s.drv.Dialect()).
+		Select(table.PrimaryKey[0].Name).From(entsql.Table(table.Name)).Limit(maxRefs).Query()
+	var rows entsql.Rows
+	if err := s.drv.Query(ctx, query, args, &rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []interface{}
+	for rows.Next() {
+		var id interface{}
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// goTypes maps model's columns to the types of its entity's fields, found by
+// their JSON tags. Only JSON columns need them, to generate a document the
+// entity can decode.
+func (s *loadSeeder) goTypes(model string) map[string]reflect.Type {
+	types := map[string]reflect.Type{}
+	modelClient := reflect.ValueOf(s.client).Elem().FieldByName(model)
+	if !modelClient.IsValid() {
+		return types
+	}
+	get := modelClient.MethodByName("Get")
+	if !get.IsValid() || get.Type().NumOut() == 0 {
+		return types
+	}
+	entity := get.Type().Out(0)
+	if entity.Kind() == reflect.Pointer {
+		entity = entity.Elem()
+	}
+	for i := 0; i < entity.NumField(); i++ {
+		f := entity.Field(i)
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			types[name] = f.Type
+		}
+	}
+	return types
+}
+
+// value generates the value of column c for the nth row
+func (s *loadSeeder) value(c *schema.Column, n int, unique bool, refs []interface{}, goType reflect.Type) (interface{}, error) {
+	switch {
+	case len(refs) > 0:
+		return refs[s.rand.IntN(len(refs))], nil
+	case c.Default != nil:
+		return c.Default, nil
+	case c.Nullable:
+		return nil, nil
+	}
+
+	switch c.Type {
+	case field.TypeUUID:
+		return uuid.New(), nil
+	case field.TypeBool:
+		return s.rand.IntN(2) == 0, nil
+	case field.TypeInt, field.TypeInt8, field.TypeInt16, field.TypeInt32, field.TypeInt64,
+		field.TypeUint, field.TypeUint8, field.TypeUint16, field.TypeUint32, field.TypeUint64:
+		return s.rand.IntN(100), nil
+	case field.TypeFloat32, field.TypeFloat64:
+		return float64(1+s.rand.IntN(100000)) / 100, nil
+	case field.TypeEnum:
+		return c.Enums[s.rand.IntN(len(c.Enums))], nil
+	case field.TypeTime:
+		return s.pastTime(), nil
+	case field.TypeBytes:
+		b := make([]byte, 32)
+		for i := range b {
+			b[i] = byte(s.rand.IntN(256))
+		}
+		return b, nil
+	case field.TypeJSON:
+		return json.Marshal(s.document(goType))
+	case field.TypeString:
+		return s.text(c, n, unique), nil
+	}
+	return nil, fmt.Errorf("can't generate %s values (column %s)", c.Type, c.Name)
+}
+
+// text generates the string for column c of the nth row, guessing at its use
+// from the name: emails, URLs, phone numbers and colors look the part.
+// Unique values end in the run and row number.
+func (s *loadSeeder) text(c *schema.Column, n int, unique bool) string {
+	tag := fmt.Sprintf("%s-%d", s.opts.Run, n)
+	var v string
+	switch name := c.Name; {
+	case strings.Contains(name, "email"):
+		return fmt.Sprintf("load-%s@example.com", tag)
+	case strings.Contains(name, "url"):
+		return "https://example.com/load/" + tag
+	case strings.Contains(name, "path"):
+		return "/load/" + tag
+	case strings.Contains(name, "phone"):
+		return fmt.Sprintf("+1415555%04d", n%10000)
+	case strings.Contains(name, "color"):
+		return fmt.Sprintf("#%06x", s.rand.IntN(0x1000000))
+	case c.Size == 0 || c.Size > 1024:
+		v = s.paragraphs(1)
+	default:
+		v = s.sentence(2 + s.rand.IntN(4))
+	}
+	if unique {
+		v += " " + tag
+	}
+	if c.Size > 0 && int64(len(v)) > c.Size {
+		v = v[len(v)-int(c.Size):] // Keeps the unique tag
+	}
+	return v
+}
+
+// document generates a value of type t to store in a JSON column: a few
+// options for string lists, a random location for utils.Point and an empty
+// document otherwise
+func (s *loadSeeder) document(t reflect.Type) interface{} {
+	if t == nil || t == reflect.TypeOf(json.RawMessage{}) {
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.document(t.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return []string{"Option 1", "Option 2", "Option 3"}
+		}
+		return reflect.MakeSlice(t, 0, 0).Interface()
+	case reflect.Map:
+		return reflect.MakeMap(t).Interface()
+	}
+	if t == reflect.TypeOf(utils.Point{}) {
+		return utils.Point{Lat: s.rand.Float64()*120 - 60, Lng: s.rand.Float64()*360 - 180}
+	}
+	return reflect.Zero(t).Interface()
+}
+
+// sentence returns n words, capitalized
+func (s *loadSeeder) sentence(n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = loadWords[s.rand.IntN(len(loadWords))]
+	}
+	return strings.ToUpper(words[0][:1]) + strings.Join(words, " ")[1:]
+}
+
+// paragraphs returns n paragraphs of a few sentences each
+func (s *loadSeeder) paragraphs(n int) string {
+	paras := make([]string, n)
+	for i := range paras {
+		sentences := make([]string, 3+s.rand.IntN(4))
+		for j := range sentences {
+			sentences[j] = s.sentence(6+s.rand.IntN(10)) + "."
+		}
+		paras[i] = strings.Join(sentences, " ")
+	}
+	return strings.Join(paras, "\n\n")
+}
+
+// pastTime returns a random time in the past year, so listings have a spread
+// of dates to page through
+func (s *loadSeeder) pastTime() time.Time {
+	return time.Now().Add(-time.Duration(s.rand.Int64N(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// newTestSeeder returns a loadSeeder for a migrated SQLite database
+func newTestSeeder(t *testing.T, opts loadOptions) *loadSeeder {
+	t.Helper()
+	drv, err := db.OpenDriver("sqlite://" + filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := models.NewClient(models.Driver(drv))
+	t.Cleanup(func() { client.Close() })
+	if err := db.AutoMigrate(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Batch == 0 {
+		opts.Batch = 7 // Several batches, the last one short
+	}
+	if opts.Run == "" {
+		opts.Run = "test"
+	}
+	return newLoadSeeder(client, drv, opts)
+}
+
+// TestLoadSeed tests creating users, posts and rows of other models
+func TestLoadSeed(t *testing.T) {
+	s := newTestSeeder(t, loadOptions{
+		Users:    20,
+		Posts:    30,
+		Models:   modelCounts{{"Poll", 5}, {"Vote", 25}, {"UserPreference", 10}},
+		Password: "Load-test-pass1",
+	})
+	ctx := context.Background()
+	if err := s.seed(ctx); err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+
+	counts := map[string]int{
+		"users":       s.client.User.Query().CountX(ctx),
+		"posts":       s.client.Post.Query().CountX(ctx),
+		"polls":       s.client.Poll.Query().CountX(ctx),
+		"votes":       s.client.Vote.Query().CountX(ctx),
+		"preferences": s.client.UserPreference.Query().CountX(ctx),
+	}
+	want := map[string]int{"users": 20, "posts": 30, "polls": 5, "votes": 25, "preferences": 10}
+	for name, n := range want {
+		if counts[name] != n {
+			t.Errorf("Expected %d %s, got %d", n, name, counts[name])
+		}
+	}
+
+	u := s.client.User.Query().Where(user.EmailEQ("load-test-20@example.com")).OnlyX(ctx)
+	if ok, _ := utils.CheckPassword(u.PasswordHash, "Load-test-pass1"); !u.IsActive || !ok {
+		t.Errorf("Expected an active user with the -password, got %+v", u)
+	}
+
+	// Ent can read back what was written directly, JSON included
+	polls, err := s.client.Poll.Query().All(ctx)
+	if err != nil {
+		t.Fatalf("Reading polls failed: %v", err)
+	}
+	if len(polls[0].Options) != 3 {
+		t.Errorf("Expected generated poll options, got %q", polls[0].Options)
+	}
+	votes, err := s.client.Vote.Query().WithPoll().All(ctx)
+	if err != nil {
+		t.Fatalf("Reading votes failed: %v", err)
+	}
+	if votes[0].Edges.Poll == nil || !strings.Contains(votes[0].Voter, "test-") {
+		t.Errorf("Expected a vote on a seeded poll with a unique voter, got %+v", votes[0])
+	}
+}
+
+// TestLoadSeed_Errors tests models that can't be seeded
+func TestLoadSeed_Errors(t *testing.T) {
+	s := newTestSeeder(t, loadOptions{})
+	ctx := context.Background()
+
+	if err := s.posts(ctx, 1); err == nil || !strings.Contains(err.Error(), "-users") {
+		t.Errorf("Expected posts without users to fail, got %v", err)
+	}
+	if err := s.rows(ctx, "Vote", 1); err == nil || !strings.Contains(err.Error(), "polls first") {
+		t.Errorf("Expected votes without polls to fail, got %v", err)
+	}
+	if err := s.rows(ctx, "Nope", 1); err == nil || !strings.Contains(err.Error(), "unknown model") {
+		t.Errorf("Expected an unknown model to fail, got %v", err)
+	}
+}
+
+// TestModelCounts tests parsing -model flags
+func TestModelCounts(t *testing.T) {
+	var m modelCounts
+	if err := m.Set("Poll=100"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	m.Set("Vote=0")
+	if m.String() != "Poll=100,Vote=0" {
+		t.Errorf("Expected both counts, got %q", m.String())
+	}
+	for _, bad := range []string{"Poll", "=3", "Poll=x", "Poll=-1"} {
+		if err := m.Set(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Command gojang bundles the framework's development tools.
+//
+//	go run ./gojang/cmd/gojang <command> [flags]
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a gojang subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+// commands are listed in this order by `gojang help`
+var commands = []command{
+	{"dev", "Run the web server, rebuilding and restarting it when code changes", runDev},
+	{"deploy", "Generate deployment files (deploy init: Dockerfile, docker-compose.yml)", runDeploy},
+	{"check", "Parse every template and report missing templates, blocks and functions", runCheck},
+	{"doctor", "Check config, database, migrations, generated code and templates for problems", runDoctor},
+	{"shell", "Run Go snippets against the database with the Ent client loaded", runShell},
+	{"routes", "List every route with its method, handler and middleware", runRoutes},
+	{"profile", "Capture CPU, heap and other profiles from a running instance", runProfile},
+	{"loadseed", "Fill the database with generated users, posts and other rows for load testing", runLoadSeed},
+	{"normalize-emails", "Lowercase and trim user emails, listing accounts whose emails only differ in case", runNormalizeEmails},
+	{"gen", "Generate code from the app (gen client: a Go client for the admin API)", runGen},
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "help" || os.Args[1] == "-h" || os.Args[1] == "--help" {
+		usage()
+		return
+	}
+
+	name := os.Args[1]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Println("Usage: gojang <command> [flags]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, cmd := range commands {
+		fmt.Printf("  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Println()
+	fmt.Println("Run 'gojang <command> -h' for a command's flags.")
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/models/db"
+)
+
+// runNormalizeEmails implements `gojang normalize-emails`
+func runNormalizeEmails(args []string) error {
+	flags := flag.NewFlagSet("normalize-emails", flag.ExitOnError)
+	dir := flags.String("dir", ".", "project root (where .env and gojang/ are)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: gojang normalize-emails [flags]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	client, err := db.NewClient(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	changed, err := db.NormalizeUserEmails(context.Background(), client)
+	var conflicts db.EmailConflicts
+	if errors.As(err, &conflicts) {
+		return fmt.Errorf("%w\nMerge or rename these accounts, then run normalize-emails again; nothing was changed", err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Normalized %d email(s); the server adds the unique index on lower(email) when it next starts\n", changed)
+	return nil
+}
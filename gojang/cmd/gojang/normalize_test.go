@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/models/db"
+)
+
+// TestNormalizeEmails tests normalizing emails, and refusing while some conflict
+func TestNormalizeEmails(t *testing.T) {
+	dir := t.TempDir()
+	databaseURL := "sqlite://" + filepath.Join(dir, "app.db")
+	t.Setenv("DATABASE_URL", databaseURL)
+	t.Setenv("SESSION_KEY", strings.Repeat("k", 32))
+	t.Chdir(dir)
+
+	client, err := db.NewClient(databaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	alice := client.User.Create().SetEmail("Alice@Example.com").SetPasswordHash("x").SaveX(ctx)
+	bob := client.User.Create().SetEmail("BOB@example.com").SetPasswordHash("x").SaveX(ctx)
+	client.User.Create().SetEmail("bob@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	if err := runNormalizeEmails(nil); err == nil || !strings.Contains(err.Error(), "bob@example.com") || !strings.Contains(err.Error(), "nothing was changed") {
+		t.Errorf("Expected the conflicting accounts to be listed, got %v", err)
+	}
+
+	client.User.UpdateOneID(bob.ID).SetEmail("robert@example.com").ExecX(ctx)
+	if err := runNormalizeEmails(nil); err != nil {
+		t.Fatalf("normalize-emails failed: %v", err)
+	}
+	if got := client.User.GetX(ctx, alice.ID).Email; got != "alice@example.com" {
+		t.Errorf("Expected a normalized email, got %q", got)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+)
+
+// profilePaths maps the profiles `gojang profile` captures to their pprof
+// endpoints. Profiles marked timed run for -seconds.
+var profilePaths = map[string]struct {
+	path  string
+	timed bool
+}{
+	"cpu":          {"profile", true},
+	"trace":        {"trace", true},
+	"heap":         {"heap", false},
+	"allocs":       {"allocs", false},
+	"goroutine":    {"goroutine", false},
+	"block":        {"block", false},
+	"mutex":        {"mutex", false},
+	"threadcreate": {"threadcreate", false},
+}
+
+// profileOptions are the flags of `gojang profile`
+type profileOptions struct {
+	URL     string // Base URL of the running instance
+	Session string // Session cookie of a staff user, for PROFILING=staff
+	Seconds int    // How long timed profiles run
+	Out     string // Directory the profiles are written to
+	Now     func() time.Time
+}
+
+// runProfile implements `gojang profile`
+func runProfile(args []string) error {
+	flags := flag.NewFlagSet("profile", flag.ExitOnError)
+	opts := profileOptions{Now: time.Now}
+	flags.StringVar(&opts.URL, "url", "http://localhost:8080", "base URL of the running instance")
+	flags.StringVar(&opts.Session, "session", os.Getenv("GOJANG_SESSION"), "session cookie of a staff user, needed with PROFILING=staff (default $GOJANG_SESSION)")
+	flags.IntVar(&opts.Seconds, "seconds", 10, "how long CPU profiles and traces run")
+	flags.StringVar(&opts.Out, "out", "profiles", "directory to write the profiles to")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: gojang profile [flags] [profile...]")
+		fmt.Fprintln(flags.Output(), "Profiles: cpu, heap (the default), trace, allocs, goroutine, block, mutex, threadcreate")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	profiles := flags.Args()
+	if len(profiles) == 0 {
+		profiles = []string{"cpu", "heap"}
+	}
+	for _, name := range profiles {
+		if _, ok := profilePaths[name]; !ok {
+			return fmt.Errorf("unknown profile %q (run 'gojang profile -h' for the list)", name)
+		}
+	}
+	if opts.Seconds < 1 {
+		return fmt.Errorf("-seconds must be at least 1, got %d", opts.Seconds)
+	}
+
+	if err := os.MkdirAll(opts.Out, 0o755); err != nil {
+		return err
+	}
+	for _, name := range profiles {
+		if profilePaths[name].timed {
+			fmt.Printf("⏳ Capturing %s for %ds...\n", name, opts.Seconds)
+		}
+		path, err := captureProfile(opts, name)
+		if err != nil {
+			return err
+		}
+		if name == "trace" {
+			fmt.Printf("✅ Wrote %s (go tool trace %s)\n", path, path)
+		} else {
+			fmt.Printf("✅ Wrote %s (go tool pprof -http=:0 %s)\n", path, path)
+		}
+	}
+	return nil
+}
+
+// captureProfile downloads one profile from the instance at opts.URL into
+// opts.Out and returns the file's path
+func captureProfile(opts profileOptions, name string) (string, error) {
+	endpoint := profilePaths[name]
+	target := strings.TrimSuffix(opts.URL, "/") + "/debug/pprof/" + endpoint.path
+	if endpoint.timed {
+		target += "?" + url.Values{"seconds": {fmt.Sprint(opts.Seconds)}}.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+	if opts.Session != "" {
+		req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: opts.Session})
+	}
+
+	// Redirects only lead to the login page
+	client := &http.Client{
+		Timeout:       time.Duration(opts.Seconds)*time.Second + time.Minute,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("%s: %s isn't serving profiles; set PROFILING=staff (or PROFILING=debug with DEBUG=true)", name, opts.URL)
+	case resp.StatusCode == http.StatusForbidden || (resp.StatusCode >= 300 && resp.StatusCode < 400):
+		return "", fmt.Errorf("%s: profiling is for staff; pass a staff user's session cookie with -session or GOJANG_SESSION", name)
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("%s: %s: %s", name, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	ext := ".pprof"
+	if name == "trace" {
+		ext = ".out"
+	}
+	path := filepath.Join(opts.Out, name+"-"+opts.Now().UTC().Format("20060102T150405")+ext)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return path, f.Close()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+)
+
+// TestCaptureProfile tests downloading a profile with the session cookie
+func TestCaptureProfile(t *testing.T) {
+	r := chi.NewRouter()
+	r.With(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if c, err := r.Cookie(middleware.SessionCookieName); err != nil || c.Value != "staff-token" {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}).Mount("/debug", chimiddleware.Profiler())
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	opts := profileOptions{
+		URL:     srv.URL + "/",
+		Session: "staff-token",
+		Seconds: 1,
+		Out:     t.TempDir(),
+		Now:     func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) },
+	}
+	path, err := captureProfile(opts, "heap")
+	if err != nil {
+		t.Fatalf("captureProfile failed: %v", err)
+	}
+	if want := filepath.Join(opts.Out, "heap-20250601T120000.pprof"); path != want {
+		t.Errorf("Expected %s, got %s", want, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Errorf("Expected a gzipped profile, got %d bytes, %v", len(data), err)
+	}
+
+	opts.Session = ""
+	if _, err := captureProfile(opts, "heap"); err == nil || !strings.Contains(err.Error(), "-session") {
+		t.Errorf("Expected an error asking for a staff session, got %v", err)
+	}
+
+	opts.URL = srv.URL + "/missing"
+	if _, err := captureProfile(opts, "goroutine"); err == nil || !strings.Contains(err.Error(), "PROFILING") {
+		t.Errorf("Expected an error about PROFILING, got %v", err)
+	}
+}
+
+// TestRunProfile_UnknownProfile tests that profile names are checked before anything is captured
+func TestRunProfile_UnknownProfile(t *testing.T) {
+	err := runProfile([]string{"-out", t.TempDir(), "cpu", "disk"})
+	if err == nil || !strings.Contains(err.Error(), `unknown profile "disk"`) {
+		t.Errorf("Expected an unknown profile error, got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/app"
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/http/authz"
+)
+
+// modulePrefix is trimmed from the framework's own function names
+const modulePrefix = "github.com/gojangframework/gojang/gojang/"
+
+// closureSuffix matches the ".func1" (or ".1" when inlined) Go appends to
+// closures, e.g. the handler returned by middleware.Timeout
+var closureSuffix = regexp.MustCompile(`(\.func\d+|\.\d+)+$`)
+
+// majorVersion matches a function in a major version's package, e.g.
+// "v5.(*Mux).Mount"
+var majorVersion = regexp.MustCompile(`^v\d+\.`)
+
+// authPolicies describes the auth middleware that predates authz
+var authPolicies = map[string]string{
+	"http/middleware.RequireAuth":         "role:" + authz.RoleAuthenticated,
+	"http/middleware.RequireStaff":        "role:" + authz.RoleStaff,
+	"http/middleware.RequireAdmin":        "role:" + authz.RoleSuperuser,
+	"http/middleware.RequireStaffOrAdmin": "role:" + authz.RoleStaff + "|" + authz.RoleSuperuser,
+}
+
+// routeInfo is a row of `gojang routes`
+type routeInfo struct {
+	Method     string // "*" for handlers that serve every method
+	Pattern    string
+	Handler    string
+	Middleware []string // Run in order, after the global middleware
+	Policy     []string // Who may use the route, from auth and authz middleware
+}
+
+// runRoutes implements `gojang routes`
+func runRoutes(args []string) error {
+	flags := flag.NewFlagSet("routes", flag.ExitOnError)
+	dir := flags.String("dir", ".", "project root (where .env and gojang/ are)")
+	prefix := flags.String("prefix", "", "only list routes starting with this path (e.g., /admin)")
+	flags.Parse(args)
+
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Build the app the way a serverless instance would: no migrations or
+	// background goroutines, just the handlers and router
+	a, err := app.New(cfg, app.Options{Serverless: true})
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	routes := listRoutes(a.Router)
+	if *prefix != "" {
+		filtered := routes[:0]
+		for _, route := range routes {
+			if strings.HasPrefix(route.Pattern, *prefix) {
+				filtered = append(filtered, route)
+			}
+		}
+		routes = filtered
+	}
+	printRoutes(os.Stdout, funcNames(a.Router.Middlewares()), routes)
+	return nil
+}
+
+// listRoutes returns every route registered on router and its mounted
+// subrouters, sorted by pattern. Unlike chi.Walk, it keeps the middleware
+// added with r.With(...).Mount(...). router's own middleware is left out of
+// each route's, since every route runs it.
+func listRoutes(router chi.Routes) []routeInfo {
+	var routes []routeInfo
+	walkRoutes(router, "", nil, &routes)
+	global := len(router.Middlewares())
+	for i := range routes {
+		routes[i].Middleware = routes[i].Middleware[global:]
+		if len(routes[i].Policy) == 0 {
+			routes[i].Policy = nil
+		}
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// walkRoutes appends router's routes to routes. mws is the middleware added
+// between the top-level router and this one.
+func walkRoutes(router chi.Routes, prefix string, mws []func(http.Handler) http.Handler, routes *[]routeInfo) {
+	for _, route := range router.Routes() {
+		if route.SubRoutes != nil {
+			// A mount's handler carries the middleware given to r.With
+			sub := append(append([]func(http.Handler) http.Handler{}, mws...), router.Middlewares()...)
+			if chain, ok := route.Handlers["*"].(*chi.ChainHandler); ok {
+				sub = append(sub, chain.Middlewares...)
+			}
+			walkRoutes(route.SubRoutes, prefix+strings.TrimSuffix(route.Pattern, "/*"), sub, routes)
+			continue
+		}
+
+		methods := make([]string, 0, len(route.Handlers))
+		if _, ok := route.Handlers["*"]; ok {
+			methods = append(methods, "*") // r.Handle: the same handler for every method
+		} else {
+			for method := range route.Handlers {
+				methods = append(methods, method)
+			}
+		}
+		for _, method := range methods {
+			handler := route.Handlers[method]
+			chain := append(append([]func(http.Handler) http.Handler{}, mws...), router.Middlewares()...)
+			if c, ok := handler.(*chi.ChainHandler); ok {
+				handler = c.Endpoint
+				chain = append(chain, c.Middlewares...)
+			}
+			name := funcName(handler)
+			if name == "chi.(*Mux).Mount" {
+				// r.Mount of an http.Handler rather than a router, which
+				// chi wraps without keeping the handler
+				name = "(mounted handler)"
+			}
+			*routes = append(*routes, routeInfo{
+				Method:     method,
+				Pattern:    prefix + route.Pattern,
+				Handler:    name,
+				Middleware: funcNames(chain),
+				Policy:     policies(chain),
+			})
+		}
+	}
+}
+
+// printRoutes writes routes as an aligned table
+func printRoutes(out io.Writer, global []string, routes []routeInfo) {
+	fmt.Fprintf(out, "Global middleware: %s\n\n", strings.Join(global, " → "))
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tROUTE\tHANDLER\tPOLICY\tMIDDLEWARE")
+	for _, route := range routes {
+		middleware := strings.Join(route.Middleware, " → ")
+		if middleware == "" {
+			middleware = "-"
+		}
+		policy := strings.Join(route.Policy, " + ")
+		if policy == "" {
+			policy = "public"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", route.Method, route.Pattern, route.Handler, policy, middleware)
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\n%d route(s)\n", len(routes))
+}
+
+// policies describes the access rules chain enforces, in order. Signing in
+// is left out when a later rule implies it.
+func policies(chain []func(http.Handler) http.Handler) []string {
+	var rules []string
+	for _, mw := range chain {
+		name := funcName(mw)
+		if rule, ok := authPolicies[name]; ok {
+			rules = append(rules, rule)
+		} else if strings.HasPrefix(name, "http/authz.") {
+			if policy, ok := authz.Describe(mw); ok {
+				rules = append(rules, policy.String())
+			}
+		}
+	}
+	if len(rules) > 1 {
+		implied := rules[:0]
+		for _, rule := range rules {
+			if rule != "role:"+authz.RoleAuthenticated {
+				implied = append(implied, rule)
+			}
+		}
+		rules = implied
+	}
+	return rules
+}
+
+// funcNames names each middleware with funcName
+func funcNames(mws []func(http.Handler) http.Handler) []string {
+	names := make([]string, len(mws))
+	for i, mw := range mws {
+		names[i] = funcName(mw)
+	}
+	return names
+}
+
+// funcName names a handler or middleware after the function that implements
+// it, e.g. "http/handlers.(*PostHandler).Index" or "middleware.RealIP".
+// Closures are named after the function that returned them.
+func funcName(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", v)
+	}
+	fn := runtime.FuncForPC(rv.Pointer())
+	if fn == nil {
+		return fmt.Sprintf("%T", v)
+	}
+	name := strings.TrimSuffix(fn.Name(), "-fm") // Method values
+	name = closureSuffix.ReplaceAllString(name, "")
+	if strings.HasPrefix(name, modulePrefix) {
+		return strings.TrimPrefix(name, modulePrefix)
+	}
+	// Other packages are named by their last path element, skipping a major
+	// version ("github.com/alexedwards/scs/v2" is scs)
+	pkg, rest := name, ""
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		pkg, rest = name[:i], name[i+1:]
+		if majorVersion.MatchString(rest) {
+			if j := strings.LastIndex(pkg, "/"); j >= 0 {
+				pkg = pkg[j+1:]
+			}
+			return pkg + rest[strings.Index(rest, "."):]
+		}
+		return rest
+	}
+	return name
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gojangframework/gojang/gojang/http/authz"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+)
+
+type testHandler struct{}
+
+func (testHandler) Index(w http.ResponseWriter, r *http.Request) {}
+
+func testMiddleware(next http.Handler) http.Handler { return next }
+
+func wrap() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler { return next }
+}
+
+// TestListRoutes tests listing routes, mounts and their middleware
+func TestListRoutes(t *testing.T) {
+	var h testHandler
+	posts := chi.NewRouter()
+	posts.Use(testMiddleware)
+	posts.Get("/", h.Index)
+	posts.With(chimiddleware.NoCache).Post("/{id}", h.Index)
+	posts.With(middleware.RequireStaff, authz.Require("edit_any_post")).Delete("/{id}", h.Index)
+
+	r := chi.NewRouter()
+	r.Use(chimiddleware.RealIP)
+	r.Handle("/static/*", http.NotFoundHandler())
+	r.Group(func(g chi.Router) {
+		g.Use(wrap())
+		g.Get("/login", h.Index)
+	})
+	r.With(wrap()).Mount("/posts", posts)
+
+	got := listRoutes(r)
+	want := []routeInfo{
+		{Method: "GET", Pattern: "/login", Handler: "cmd/gojang.testHandler.Index", Middleware: []string{"cmd/gojang.wrap"}},
+		{Method: "GET", Pattern: "/posts/", Handler: "cmd/gojang.testHandler.Index", Middleware: []string{"cmd/gojang.wrap", "cmd/gojang.testMiddleware"}},
+		{Method: "DELETE", Pattern: "/posts/{id}", Handler: "cmd/gojang.testHandler.Index", Middleware: []string{"cmd/gojang.wrap", "cmd/gojang.testMiddleware", "http/middleware.RequireStaff", "http/authz.Policy.Middleware"}, Policy: []string{"role:staff", "perm:edit_any_post"}},
+		{Method: "POST", Pattern: "/posts/{id}", Handler: "cmd/gojang.testHandler.Index", Middleware: []string{"cmd/gojang.wrap", "cmd/gojang.testMiddleware", "middleware.NoCache"}},
+		{Method: "*", Pattern: "/static/*", Handler: "http.NotFound", Middleware: []string{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listRoutes =\n%+v\nwant\n%+v", got, want)
+	}
+
+	var out strings.Builder
+	printRoutes(&out, funcNames(r.Middlewares()), got)
+	for _, line := range []string{"Global middleware: middleware.RealIP", "role:staff + perm:edit_any_post", "public", "5 route(s)"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected %q in:\n%s", line, out.String())
+		}
+	}
+}
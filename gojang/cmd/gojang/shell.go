@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// shellDir holds the program each snippet is compiled into. It's inside the
+// module so the program can import the project's packages; the leading
+// underscore keeps it out of ./... while it exists.
+var shellDir = filepath.Join("tmp", "_gojang-shell")
+
+// runShell implements `gojang shell`
+func runShell(args []string) error {
+	flags := flag.NewFlagSet("shell", flag.ExitOnError)
+	dir := flags.String("dir", ".", "project root (where .env and gojang/ are)")
+	code := flags.String("c", "", "run this snippet and exit instead of starting the shell")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: gojang shell [flags] [script.go]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if err := os.Chdir(*dir); err != nil {
+		return err
+	}
+	packages, err := modelPackages()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		os.RemoveAll(shellDir)
+		os.Remove(filepath.Dir(shellDir)) // Only if nothing else is in tmp
+	}()
+
+	switch {
+	case *code != "":
+		return runSnippet(*code, packages)
+	case flags.NArg() > 0:
+		script, err := os.ReadFile(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		return runSnippet(string(script), packages)
+	}
+
+	fmt.Println("Gojang shell. Go statements run with ctx, cfg and client (*models.Client) defined;")
+	fmt.Println("an expression's values are printed. Each snippet runs on its own. :help for more, :q to quit.")
+	in := bufio.NewScanner(os.Stdin)
+	var snippet strings.Builder
+	for {
+		if snippet.Len() == 0 {
+			fmt.Print(">>> ")
+		} else {
+			fmt.Print("... ")
+		}
+		if !in.Scan() {
+			fmt.Println()
+			return in.Err()
+		}
+		line := in.Text()
+		if snippet.Len() == 0 {
+			switch strings.TrimSpace(line) {
+			case "":
+				continue
+			case ":q", ":quit", "exit":
+				return nil
+			case ":help":
+				printShellHelp(packages)
+				continue
+			}
+		}
+		snippet.WriteString(line + "\n")
+		if !snippetComplete(snippet.String()) {
+			continue
+		}
+		if err := runSnippet(snippet.String(), packages); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		}
+		snippet.Reset()
+	}
+}
+
+func printShellHelp(packages []string) {
+	fmt.Println("Snippets are compiled into a program with these in scope:")
+	fmt.Println("  ctx     context.Background()")
+	fmt.Println("  cfg     *config.Config, loaded from .env")
+	fmt.Println("  client  *models.Client for DATABASE_URL")
+	fmt.Println("  pp(v)   prints values as JSON")
+	fmt.Printf("and these packages imported: models, %s, uuid, fmt, strings, time\n", strings.Join(packages, ", "))
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  client.User.Query().Where(user.IsStaff(true)).AllX(ctx)")
+	fmt.Println("  client.Post.Update().Where(post.SubjectContains(\"draft\")).SetSubject(\"Untitled\").SaveX(ctx)")
+	fmt.Println()
+	fmt.Println("A snippet runs when its brackets are balanced. Variables don't carry over between snippets.")
+}
+
+// modelPackages returns the Ent packages generated for the schemas (e.g.,
+// "user" for User), which snippets use for predicates
+func modelPackages() ([]string, error) {
+	schemas, err := entSchemas(filepath.Join(entDir, "schema"))
+	if err != nil {
+		return nil, err
+	}
+	var packages []string
+	for _, s := range schemas {
+		pkg := strings.ToLower(s.name)
+		if info, err := os.Stat(filepath.Join(entDir, pkg)); err == nil && info.IsDir() {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, nil
+}
+
+// snippetComplete reports whether src's brackets are balanced, so the shell
+// knows when a multi-line snippet has been typed in full
+func snippetComplete(src string) bool {
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, c := range src {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				escaped = true
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '{' || c == '(' || c == '[':
+			depth++
+		case c == '}' || c == ')' || c == ']':
+			depth--
+		}
+	}
+	return depth <= 0 && quote == 0
+}
+
+// runSnippet compiles src into a program and runs it. A single expression
+// has its values printed; anything else runs as statements.
+func runSnippet(src string, packages []string) error {
+	src = strings.TrimSpace(src)
+	if expr, err := parser.ParseExpr(src); err == nil && printable(expr) {
+		out, err := buildSnippet("pp("+src+")", packages)
+		if err == nil {
+			return runShellProgram(out)
+		}
+		// A call without a result can't be printed
+		if !strings.Contains(err.Error(), "used as value") {
+			return err
+		}
+	}
+	out, err := buildSnippet(src+useDeclared(src), packages)
+	if err != nil {
+		return err
+	}
+	return runShellProgram(out)
+}
+
+// printable reports whether an expression's values are worth printing:
+// not fmt.Println and friends, whose byte counts would be
+func printable(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return true
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return true
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "fmt" {
+		return true
+	}
+	return !strings.HasPrefix(sel.Sel.Name, "Print") && !strings.HasPrefix(sel.Sel.Name, "Fprint")
+}
+
+// useDeclared returns "_ = name" for each variable src declares at its top
+// level, so a snippet that only sets one up (u := ...) still compiles
+func useDeclared(src string) string {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p; func _() {\n"+src+"\n}", parser.SkipObjectResolution)
+	if err != nil {
+		return "" // Let the compiler report it
+	}
+	var names []string
+	add := func(ident *ast.Ident) {
+		if ident.Name != "_" {
+			names = append(names, ident.Name)
+		}
+	}
+	for _, stmt := range file.Decls[0].(*ast.FuncDecl).Body.List {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			if stmt.Tok == token.DEFINE {
+				for _, lhs := range stmt.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						add(ident)
+					}
+				}
+			}
+		case *ast.DeclStmt:
+			if gen, ok := stmt.Decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+				for _, spec := range gen.Specs {
+					for _, ident := range spec.(*ast.ValueSpec).Names {
+						add(ident)
+					}
+				}
+			}
+		}
+	}
+	var uses strings.Builder
+	for _, name := range names {
+		uses.WriteString("\n_ = " + name)
+	}
+	return uses.String()
+}
+
+// buildSnippet writes the program for src and builds it, returning the
+// binary's path. Compile errors point at lines of the snippet.
+func buildSnippet(src string, packages []string) (string, error) {
+	if err := os.MkdirAll(shellDir, 0o755); err != nil {
+		return "", err
+	}
+	var program bytes.Buffer
+	if err := shellProgram.Execute(&program, map[string]interface{}{"Packages": packages, "Snippet": src}); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(shellDir, "main.go"), program.Bytes(), 0o644); err != nil {
+		return "", err
+	}
+
+	bin := filepath.Join(shellDir, "shell"+exeSuffix())
+	var stderr bytes.Buffer
+	build := exec.Command("go", "build", "-o", bin, "./"+filepath.ToSlash(shellDir))
+	build.Stdout, build.Stderr = io.Discard, &stderr
+	if err := build.Run(); err != nil {
+		return "", fmt.Errorf("%s", compileErrors(stderr.String()))
+	}
+	return bin, nil
+}
+
+// compileErrors keeps the errors in the snippet, dropping the "# package"
+// header go build prints
+func compileErrors(output string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, strings.TrimPrefix(line, filepath.ToSlash(shellDir)+"/"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runShellProgram(bin string) error {
+	cmd := exec.Command(bin)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("snippet failed")
+		}
+		return err
+	}
+	return nil
+}
+
+// shellProgram wraps a snippet with the config, client and imports. The
+// //line directive makes compile errors refer to "snippet:<line>".
+var shellProgram = template.Must(template.New("shell").Parse(`package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	_ "github.com/gojangframework/gojang/gojang/models/runtime" // Schema defaults and hooks
+{{- range .Packages}}
+	"github.com/gojangframework/gojang/gojang/models/{{.}}"
+{{- end}}
+	"github.com/google/uuid"
+)
+
+var (
+	_ = fmt.Sprint
+	_ = strings.Contains
+	_ = time.Now
+	_ = uuid.Nil
+	_ = models.IsNotFound
+{{- range .Packages}}
+	_ = {{.}}.Label
+{{- end}}
+)
+
+// pp prints values as indented JSON, and errors as errors. Nil values
+// (e.g., the record when Get fails) are skipped.
+func pp(values ...interface{}) {
+	for _, v := range values {
+		if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+			continue
+		}
+		if err, ok := v.(error); ok {
+			fmt.Println("error:", err)
+			continue
+		}
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			fmt.Printf("%+v\n", v)
+			continue
+		}
+		fmt.Println(string(out))
+	}
+}
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		os.Exit(1)
+	}
+	client, err := db.NewClient(cfg.DatabaseURL)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "database:", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	_, _, _ = cfg, client, ctx
+
+//line snippet:1
+{{.Snippet}}
+}
+`))
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// TestSnippetComplete tests waiting for the rest of a multi-line snippet
+func TestSnippetComplete(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"client.User.Query().AllX(ctx)", true},
+		{"for _, u := range users {", false},
+		{"for _, u := range users {\n\tfmt.Println(u)\n}", true},
+		{"client.Post.Query().\n\tWhere(", false},
+		{`fmt.Println("{")`, true},
+		{"fmt.Println(`multi", false},
+		{`fmt.Println('(')`, true},
+	}
+	for _, tt := range tests {
+		if got := snippetComplete(tt.src); got != tt.want {
+			t.Errorf("snippetComplete(%q) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+// TestUseDeclared tests keeping a snippet's unused variables from failing the build
+func TestUseDeclared(t *testing.T) {
+	tests := []struct{ src, want string }{
+		{"u := client.User.GetX(ctx, id)", "\n_ = u"},
+		{"a, _ := 1, 2\nvar b, c int", "\n_ = a\n_ = b\n_ = c"},
+		{"for i := 0; i < 3; i++ {}", ""},
+		{"x = 1", ""},
+	}
+	for _, tt := range tests {
+		if got := useDeclared(tt.src); got != tt.want {
+			t.Errorf("useDeclared(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+// TestPrintable tests which expressions have their values printed
+func TestPrintable(t *testing.T) {
+	tests := map[string]bool{
+		"client.User.Query().CountX(ctx)": true,
+		`fmt.Sprintf("%d", 1)`:            true,
+		`fmt.Println("hi")`:               false,
+		`fmt.Fprintf(os.Stderr, "hi")`:    false,
+		"1 + 2":                           true,
+	}
+	for src, want := range tests {
+		expr, err := parser.ParseExpr(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := printable(expr); got != want {
+			t.Errorf("printable(%s) = %v, want %v", src, got, want)
+		}
+	}
+}
+
+// TestShellProgram tests that the generated program is valid Go
+func TestShellProgram(t *testing.T) {
+	var program bytes.Buffer
+	data := map[string]interface{}{"Packages": []string{"post", "user"}, "Snippet": "pp(client.User.Query().CountX(ctx))"}
+	if err := shellProgram.Execute(&program, data); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "main.go", program.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("Generated program doesn't parse: %v\n%s", err, program.String())
+	}
+	imports := make(map[string]bool)
+	for _, spec := range file.Imports {
+		imports[spec.Path.Value] = true
+	}
+	for _, want := range []string{`"github.com/gojangframework/gojang/gojang/models/post"`, `"github.com/gojangframework/gojang/gojang/models/user"`} {
+		if !imports[want] {
+			t.Errorf("Expected import %s", want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Command lambda runs the web app on AWS Lambda behind API Gateway or a
+// function URL. Build it as "bootstrap" for the provided.al2023 runtime and
+// ship the gojang/views and gojang/admin/views directories alongside it:
+//
+//	task build:lambda
+//
+// The schema isn't migrated on cold starts; run `migrate auto` when you deploy.
+package main
+
+import (
+	"log"
+
+	"github.com/gojangframework/gojang/gojang/app"
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/serverless"
+)
+
+func main() {
+	cfg := config.MustLoad()
+
+	// Built once per instance, before the first event
+	application, err := app.New(cfg, app.Options{Serverless: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer application.Close()
+
+	if err := serverless.Start(application.Handler); err != nil {
+		log.Fatal(err)
+	}
+}
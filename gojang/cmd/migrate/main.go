@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/gojangframework/gojang/gojang/config"
+	dbpkg "github.com/gojangframework/gojang/gojang/models/db"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
@@ -18,9 +20,10 @@ import (
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: migrate <up|down>")
+		fmt.Println("Usage: migrate <up|down|auto>")
 		fmt.Println("  up   - Apply all pending migrations")
 		fmt.Println("  down - Rollback the last migration")
+		fmt.Println("  auto - Apply the Ent schema (what the server does on startup)")
 		os.Exit(1)
 	}
 
@@ -29,6 +32,30 @@ func main() {
 	// Load config
 	cfg := config.MustLoad()
 
+	// Ent's auto-migration doesn't use the SQL migration files
+	if command == "auto" {
+		client, err := dbpkg.NewClient(cfg.DatabaseURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer client.Close()
+		if err := dbpkg.AutoMigrate(context.Background(), client); err != nil {
+			log.Fatalf("Failed to apply schema: %v", err)
+		}
+
+		// Tables of models routed to other databases (DATABASE_ROUTES)
+		databases := dbpkg.NewDatabases(client)
+		defer databases.Close()
+		if err := databases.Configure(cfg.Databases, cfg.DatabaseRoutes); err != nil {
+			log.Fatalf("Failed to set up databases: %v", err)
+		}
+		if err := databases.Migrate(context.Background()); err != nil {
+			log.Fatalf("Failed to apply schema: %v", err)
+		}
+		fmt.Println("✅ Schema is up to date")
+		return
+	}
+
 	// Parse database URL and connect
 	var db *sql.DB
 	var err error
@@ -36,8 +63,7 @@ func main() {
 	var databaseName string
 
 	if strings.HasPrefix(cfg.DatabaseURL, "sqlite://") {
-		dbPath := strings.TrimPrefix(cfg.DatabaseURL, "sqlite://")
-		db, err = sql.Open("sqlite3", dbPath+"?_fk=1")
+		db, err = sql.Open("sqlite3", dbpkg.SQLiteDSN(cfg.DatabaseURL))
 		driver = "sqlite3"
 		databaseName = "sqlite3"
 	} else if strings.HasPrefix(cfg.DatabaseURL, "postgres://") {
@@ -111,7 +137,7 @@ func main() {
 
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
-		fmt.Println("Usage: migrate <up|down>")
+		fmt.Println("Usage: migrate <up|down|auto>")
 		os.Exit(1)
 	}
 }
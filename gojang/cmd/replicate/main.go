@@ -0,0 +1,103 @@
+// Command replicate copies snapshots of a SQLite database to REPLICA_URL and
+// restores them. Run `replicate run` as a sidecar next to the server, and
+// `replicate restore` before the server starts on a fresh disk.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+func usage() {
+	fmt.Println("Usage: replicate <run|snapshot|restore> [flags]")
+	fmt.Println("  run      - Snapshot the database every REPLICA_INTERVAL while it changes")
+	fmt.Println("  snapshot - Upload one snapshot now")
+	fmt.Println("  restore  - Download the latest snapshot if the database doesn't exist (-force replaces it)")
+	os.Exit(1)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	force := flags.Bool("force", false, "restore: replace an existing database")
+	flags.Parse(os.Args[2:])
+
+	cfg := config.MustLoad()
+	if err := utils.Init(""); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	path, ok := db.SQLitePath(cfg.DatabaseURL)
+	if !ok {
+		log.Fatalf("Replication only works with SQLite; DATABASE_URL is %q", cfg.DatabaseURL)
+	}
+	if cfg.ReplicaURL == "" {
+		log.Fatal("REPLICA_URL is not set (e.g., s3://my-bucket/backups/app?region=us-east-1)")
+	}
+	fs, err := storage.Open(cfg.ReplicaURL, storage.Options{
+		AccessKey: cfg.StorageAccessKey,
+		SecretKey: cfg.StorageSecretKey,
+	})
+	if err != nil {
+		log.Fatalf("Failed to open replica: %v", err)
+	}
+	replica := &db.Replica{Path: path, FS: fs, Retain: cfg.ReplicaRetain}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch command {
+	case "run":
+		log.Printf("Replicating %s to %s every %s", path, cfg.ReplicaURL, cfg.ReplicaInterval)
+		if err := replica.Run(ctx, cfg.ReplicaInterval); err != nil {
+			log.Fatalf("Replication failed: %v", err)
+		}
+
+	case "snapshot":
+		sqlDB, err := sql.Open("sqlite3", db.SQLiteDSN(cfg.DatabaseURL))
+		if err != nil {
+			log.Fatalf("Failed to open database: %v", err)
+		}
+		defer sqlDB.Close()
+		name, err := replica.Snapshot(ctx, sqlDB)
+		if err != nil {
+			log.Fatalf("Snapshot failed: %v", err)
+		}
+		fmt.Printf("✅ Uploaded %s\n", name)
+
+	case "restore":
+		// Safe to run on every start (e.g., from a container entrypoint)
+		if _, err := os.Stat(path); err == nil && !*force {
+			fmt.Printf("✅ %s already exists, skipping restore\n", path)
+			return
+		}
+		name, err := replica.Restore(ctx)
+		if errors.Is(err, db.ErrNoSnapshot) {
+			fmt.Println("✅ No snapshots yet, starting with a new database")
+			return
+		}
+		if err != nil {
+			log.Fatalf("Restore failed: %v", err)
+		}
+		fmt.Printf("✅ Restored %s from %s\n", path, name)
+
+	default:
+		usage()
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"syscall"
 
 	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/models"
 	"github.com/gojangframework/gojang/gojang/models/db"
 	"github.com/gojangframework/gojang/gojang/models/user"
 	"github.com/gojangframework/gojang/gojang/utils"
@@ -18,6 +20,9 @@ import (
 )
 
 func main() {
+	noInput := flag.Bool("noinput", false, "Create the superuser from GOJANG_SUPERUSER_EMAIL and GOJANG_SUPERUSER_PASSWORD without prompting (skipped if one exists)")
+	flag.Parse()
+
 	cfg := config.MustLoad()
 
 	client, err := db.NewClient(cfg.DatabaseURL)
@@ -34,6 +39,16 @@ func main() {
 		log.Fatalf("Failed to query database: %v", err)
 	}
 
+	if *noInput {
+		// Safe to run on every deploy (e.g., from a container entrypoint)
+		if exists {
+			log.Println("✅ A superuser already exists, skipping")
+			return
+		}
+		createSuperuser(ctx, client, os.Getenv("GOJANG_SUPERUSER_EMAIL"), os.Getenv("GOJANG_SUPERUSER_PASSWORD"))
+		return
+	}
+
 	if exists {
 		log.Println("⚠️  A superuser already exists. Do you want to create another? (y/N)")
 		reader := bufio.NewReader(os.Stdin)
@@ -56,14 +71,27 @@ func main() {
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Print("Email: ")
 	email, _ := reader.ReadString('\n')
-	email = strings.TrimSpace(email)
 
+	// Prompt for password
+	fmt.Print("Password: ")
+	passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		log.Fatalf("Failed to read password: %v", err)
+	}
+	fmt.Println()
+
+	createSuperuser(ctx, client, email, string(passwordBytes))
+}
+
+// createSuperuser validates the credentials and creates an active staff superuser
+func createSuperuser(ctx context.Context, client *models.Client, email, password string) {
+	email = utils.NormalizeEmail(email)
 	if email == "" {
 		log.Fatal("Email is required")
 	}
 
 	// Check if email exists
-	exists, err = client.User.Query().Where(user.EmailEQ(email)).Exist(ctx)
+	exists, err := client.User.Query().Where(user.EmailEqualFold(email)).Exist(ctx)
 	if err != nil {
 		log.Fatalf("Failed to query database: %v", err)
 	}
@@ -71,16 +99,6 @@ func main() {
 		log.Fatalf("User with email %s already exists", email)
 	}
 
-	// Prompt for password
-	fmt.Print("Password: ")
-	passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
-	if err != nil {
-		log.Fatalf("Failed to read password: %v", err)
-	}
-	fmt.Println()
-
-	password := string(passwordBytes)
-
 	// Validate password complexity
 	if err := utils.ValidatePasswordComplexity(password); err != nil {
 		log.Fatalf("Password does not meet complexity requirements: %v", err)
@@ -105,5 +123,5 @@ func main() {
 		log.Fatalf("Failed to create superuser: %v", err)
 	}
 
-	log.Printf("✅ Superuser created successfully: %s (ID: %d)", u.Email, u.ID)
+	log.Printf("✅ Superuser created successfully: %s (ID: %s)", u.Email, u.ID)
 }
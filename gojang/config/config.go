@@ -1,22 +1,161 @@
 package config
 
 import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v9"
+	"github.com/gojangframework/gojang/gojang/i18n"
 	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/joho/godotenv"
 )
 
+// Login identifiers accepted by AUTH_IDENTIFIER
+const (
+	AuthIdentifierEmail    = "email"
+	AuthIdentifierUsername = "username"
+	AuthIdentifierBoth     = "both"
+)
+
+// Passwordless login by emailed link, per MAGIC_LINK
+const (
+	MagicLinkOff  = "off"
+	MagicLinkOn   = "on"   // Alongside passwords
+	MagicLinkOnly = "only" // Instead of passwords
+)
+
+// Who can see the build at /version, per VERSION_ENDPOINT
+const (
+	VersionEndpointStaff  = "staff"
+	VersionEndpointPublic = "public"
+	VersionEndpointOff    = "off"
+)
+
+// Who can use the profiling endpoints under /debug, per PROFILING
+const (
+	ProfilingStaff = "staff"
+	ProfilingDebug = "debug"
+	ProfilingOff   = "off"
+)
+
 type Config struct {
 	DatabaseURL  string   `env:"DATABASE_URL,required"`
 	SessionKey   string   `env:"SESSION_KEY,required"`
 	Debug        bool     `env:"DEBUG" envDefault:"false"`
 	Port         string   `env:"PORT" envDefault:"8080"`
 	AllowedHosts []string `env:"ALLOWED_HOSTS" envSeparator:","`
+	PIDFile      string   `env:"PID_FILE"`                       // Written on startup so supervisors can follow graceful upgrades
+	LiveReload   bool     `env:"LIVE_RELOAD" envDefault:"false"` // Set by `gojang dev`; only honored with DEBUG
+
+	// Development toolbar on full pages (queries, templates, session, logs); only
+	// honored with DEBUG. Requests making more than DEBUG_QUERY_BUDGET queries
+	// are flagged (0 for no budget).
+	DebugToolbar     bool `env:"DEBUG_TOOLBAR" envDefault:"true"`
+	DebugQueryBudget int  `env:"DEBUG_QUERY_BUDGET" envDefault:"20"`
+
+	// Keys signing URLs (see utils/signer), newest first: the first signs new
+	// links and the rest still verify old ones, for rotation. Defaults to SESSION_KEY.
+	SigningKeys []string `env:"SIGNING_KEYS" envSeparator:","`
+
+	// Extra log field names to redact (passwords, tokens and secrets always are),
+	// and whether to mask email addresses in log values
+	LogRedactKeys   []string `env:"LOG_REDACT_KEYS" envSeparator:","`
+	LogRedactEmails bool     `env:"LOG_REDACT_EMAILS" envDefault:"false"`
+
+	// Who can see the build version and commit at /version: staff, public or off
+	VersionEndpoint string `env:"VERSION_ENDPOINT" envDefault:"staff"`
+
+	// Who can use net/http/pprof and expvar under /debug (see `gojang profile`):
+	// staff, debug (anyone, only when DEBUG is on) or off
+	Profiling string `env:"PROFILING" envDefault:"debug"`
+
+	// Extra databases as name=URL, and models routed to them as Model=name
+	// (Model:read=name for reads only); see db.Databases
+	Databases      []string `env:"DATABASES" envSeparator:","`
+	DatabaseRoutes []string `env:"DATABASE_ROUTES" envSeparator:","`
+
+	// How often the database is pinged for /readyz (reconnecting after repeated failures)
+	DBHealthInterval time.Duration `env:"DB_HEALTH_INTERVAL" envDefault:"10s"`
+
+	// SQLite WAL checkpoints (0 leaves them to SQLite, or to Litestream) and
+	// where the `replicate` sidecar uploads snapshots (a storage URL; see storage.Open)
+	SQLiteCheckpointInterval time.Duration `env:"SQLITE_CHECKPOINT_INTERVAL" envDefault:"5m"`
+	ReplicaURL               string        `env:"REPLICA_URL"`
+	ReplicaInterval          time.Duration `env:"REPLICA_INTERVAL" envDefault:"1m"`
+	ReplicaRetain            int           `env:"REPLICA_RETAIN" envDefault:"24"`
+
+	// Request timeouts (cancel the request context, including DB queries)
+	RequestTimeout      time.Duration `env:"REQUEST_TIMEOUT" envDefault:"10s"`
+	AdminRequestTimeout time.Duration `env:"ADMIN_REQUEST_TIMEOUT" envDefault:"30s"`
+
+	// Expensive endpoints (search): requests running at once, how many more may
+	// queue, and how long each waits before a 503
+	ExpensiveConcurrency int           `env:"EXPENSIVE_CONCURRENCY" envDefault:"4"`
+	ExpensiveQueue       int           `env:"EXPENSIVE_QUEUE" envDefault:"32"`
+	ExpensiveQueueWait   time.Duration `env:"EXPENSIVE_QUEUE_WAIT" envDefault:"5s"`
+
+	// Read-only SQL console at /admin/query (superusers only), off unless
+	// turned on. On Postgres, queries run as ADMIN_QUERY_ROLE when it's set.
+	AdminQueryConsole bool   `env:"ADMIN_QUERY_CONSOLE" envDefault:"false"`
+	AdminQueryRole    string `env:"ADMIN_QUERY_ROLE"`
+
+	// How long a password confirmation (sudo mode) lasts before destructive admin actions; 0 turns it off
+	AdminSudoWindow time.Duration `env:"ADMIN_SUDO_WINDOW" envDefault:"15m"`
 
-	// Session settings
-	SessionLifetime time.Duration `env:"SESSION_LIFETIME" envDefault:"12h"`
+	// Session settings: sessions end SESSION_LIFETIME after signing in however
+	// active they are, or after SESSION_IDLE_TIMEOUT without a request (0 turns
+	// that off). Pages warn SESSION_EXPIRY_WARNING before either (0 doesn't warn).
+	SessionLifetime      time.Duration `env:"SESSION_LIFETIME" envDefault:"12h"`
+	SessionIdleTimeout   time.Duration `env:"SESSION_IDLE_TIMEOUT" envDefault:"30m"`
+	SessionExpiryWarning time.Duration `env:"SESSION_EXPIRY_WARNING" envDefault:"2m"`
+
+	// Most sessions a user can be signed in with at once; signing in again
+	// signs out the oldest. 0 means no limit.
+	SessionLimit int `env:"SESSION_LIMIT" envDefault:"0"`
+
+	// What users sign in with: "email", "username" or "both"
+	AuthIdentifier string `env:"AUTH_IDENTIFIER" envDefault:"email"`
+
+	// Sign-in links by email: "off", "on" (alongside passwords) or "only", and
+	// how long a link works. Links point at SITE_URL, the site's public base URL.
+	MagicLink    string        `env:"MAGIC_LINK" envDefault:"off"`
+	MagicLinkTTL time.Duration `env:"MAGIC_LINK_TTL" envDefault:"15m"`
+	SiteURL      string        `env:"SITE_URL"`
+
+	// Passkeys (WebAuthn) for signing in without a password; registered for SITE_URL's domain
+	Passkeys bool `env:"PASSKEYS" envDefault:"false"`
+
+	// ISO 4217 code for money fields (e.g., "EUR"); see utils.ParseMoney
+	Currency string `env:"CURRENCY" envDefault:"USD"`
+
+	// Languages translatable fields are offered in, the default first (see i18n.Text)
+	Locales []string `env:"LOCALES" envSeparator:"," envDefault:"en"`
+
+	// Maps for geo fields: tile server ({z}/{x}/{y}) and PostGIS distance queries
+	MapTileURL string `env:"MAP_TILE_URL" envDefault:"https://tile.openstreetmap.org/{z}/{x}/{y}.png"`
+	PostGIS    bool   `env:"POSTGIS" envDefault:"false"`
+
+	// Spam protection for public forms: honeypot plus a minimum time between
+	// rendering and submitting a form (0 only checks the honeypot)
+	SpamMinDelay time.Duration `env:"SPAM_MIN_DELAY" envDefault:"2s"`
+
+	// Full-text search index: bleve://<dir> (embedded) or a Meilisearch URL; see search.Open
+	SearchURL    string `env:"SEARCH_URL" envDefault:"bleve://./data/search.bleve"`
+	SearchAPIKey string `env:"SEARCH_API_KEY"`
+
+	// File storage for uploads: file://<dir> or s3://bucket?region=...; see storage.Open
+	StorageURL       string `env:"STORAGE_URL" envDefault:"file://./data/media"`
+	StoragePublicURL string `env:"STORAGE_PUBLIC_URL"` // e.g., a CDN in front of the bucket
+	StorageAccessKey string `env:"STORAGE_ACCESS_KEY"`
+	StorageSecretKey string `env:"STORAGE_SECRET_KEY"`
+
+	// Upload scanning: a clamd address (clamav://host:port or clamav:///socket), and
+	// a private storage URL where refused uploads are kept (discarded when empty)
+	UploadScanner       string `env:"UPLOAD_SCANNER"`
+	UploadQuarantineURL string `env:"UPLOAD_QUARANTINE_URL"`
 
 	// SMTP
 	SMTPHost string `env:"SMTP_HOST"`
@@ -24,6 +163,23 @@ type Config struct {
 	SMTPUser string `env:"SMTP_USER"`
 	SMTPPass string `env:"SMTP_PASS"`
 	SMTPFrom string `env:"SMTP_FROM" envDefault:"noreply@localhost"`
+
+	// Daily admin activity log exports (see auditexport): a private storage URL
+	// and/or email recipients, and the format (csv or ndjson). Off when both are empty.
+	AuditExportURL    string   `env:"AUDIT_EXPORT_URL"`
+	AuditExportEmail  []string `env:"AUDIT_EXPORT_EMAIL" envSeparator:","`
+	AuditExportFormat string   `env:"AUDIT_EXPORT_FORMAT" envDefault:"csv"`
+
+	// Contact form at /contact: messages are kept for the admin inbox and
+	// emailed to these comma-separated addresses. Off when empty.
+	ContactEmail []string `env:"CONTACT_EMAIL" envSeparator:","`
+
+	// OpenID Connect provider for single sign-on in other apps (see oidc): the
+	// site's base URL, relying parties as id:secret:redirect_uri, and a PEM RSA
+	// key signing tokens (generated per process when empty). Off without clients.
+	OIDCIssuer  string   `env:"OIDC_ISSUER"`
+	OIDCClients []string `env:"OIDC_CLIENTS" envSeparator:","`
+	OIDCKeyFile string   `env:"OIDC_KEY_FILE"`
 }
 
 func Load() (*Config, error) {
@@ -40,6 +196,103 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	switch cfg.AuthIdentifier {
+	case AuthIdentifierEmail, AuthIdentifierUsername, AuthIdentifierBoth:
+	default:
+		return nil, fmt.Errorf("AUTH_IDENTIFIER must be email, username or both, got %q", cfg.AuthIdentifier)
+	}
+
+	switch cfg.MagicLink {
+	case MagicLinkOff, MagicLinkOn, MagicLinkOnly:
+	default:
+		return nil, fmt.Errorf("MAGIC_LINK must be off, on or only, got %q", cfg.MagicLink)
+	}
+	if cfg.MagicLink != MagicLinkOff {
+		// Links in emails can't be built from the request's Host header, which an attacker controls
+		if !isBaseURL(cfg.SiteURL) {
+			return nil, fmt.Errorf("SITE_URL must be the site's base URL (e.g. https://example.com) when MAGIC_LINK is on, got %q", cfg.SiteURL)
+		}
+		if cfg.MagicLinkTTL <= 0 {
+			return nil, fmt.Errorf("MAGIC_LINK_TTL must be positive, got %s", cfg.MagicLinkTTL)
+		}
+	}
+
+	// Passkeys are bound to the site's domain, so it must be known
+	if cfg.Passkeys && !isBaseURL(cfg.SiteURL) {
+		return nil, fmt.Errorf("SITE_URL must be the site's base URL (e.g. https://example.com) when PASSKEYS is on, got %q", cfg.SiteURL)
+	}
+
+	switch cfg.VersionEndpoint {
+	case VersionEndpointStaff, VersionEndpointPublic, VersionEndpointOff:
+	default:
+		return nil, fmt.Errorf("VERSION_ENDPOINT must be staff, public or off, got %q", cfg.VersionEndpoint)
+	}
+
+	switch cfg.Profiling {
+	case ProfilingStaff, ProfilingDebug, ProfilingOff:
+	default:
+		return nil, fmt.Errorf("PROFILING must be staff, debug or off, got %q", cfg.Profiling)
+	}
+
+	if len(cfg.SigningKeys) == 0 {
+		cfg.SigningKeys = []string{cfg.SessionKey}
+	}
+	for _, key := range cfg.SigningKeys {
+		if key == "" {
+			return nil, fmt.Errorf("SIGNING_KEYS must not contain empty keys")
+		}
+	}
+
+	if cfg.AuditExportFormat != "csv" && cfg.AuditExportFormat != "ndjson" {
+		return nil, fmt.Errorf("AUDIT_EXPORT_FORMAT must be csv or ndjson, got %q", cfg.AuditExportFormat)
+	}
+
+	for _, addr := range cfg.ContactEmail {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return nil, fmt.Errorf("CONTACT_EMAIL must be email addresses, got %q", addr)
+		}
+	}
+
+	if len(cfg.OIDCClients) > 0 && !isBaseURL(cfg.OIDCIssuer) {
+		return nil, fmt.Errorf("OIDC_ISSUER must be the site's base URL (e.g. https://accounts.example.com) when OIDC_CLIENTS is set, got %q", cfg.OIDCIssuer)
+	}
+
+	if _, ok := utils.LookupCurrency(cfg.Currency); !ok {
+		return nil, fmt.Errorf("CURRENCY %q is not supported", cfg.Currency)
+	}
+
+	for _, locale := range cfg.Locales {
+		if !i18n.ValidLocale(strings.TrimSpace(locale)) {
+			return nil, fmt.Errorf("LOCALES must be language tags like en or pt-BR, got %q", locale)
+		}
+	}
+
+	if cfg.SessionLifetime <= 0 {
+		return nil, fmt.Errorf("SESSION_LIFETIME must be positive, got %s", cfg.SessionLifetime)
+	}
+	if cfg.SessionIdleTimeout < 0 || cfg.SessionExpiryWarning < 0 {
+		return nil, fmt.Errorf("SESSION_IDLE_TIMEOUT and SESSION_EXPIRY_WARNING must not be negative")
+	}
+	if cfg.SessionExpiryWarning >= cfg.SessionLifetime || (cfg.SessionIdleTimeout > 0 && cfg.SessionExpiryWarning >= cfg.SessionIdleTimeout) {
+		return nil, fmt.Errorf("SESSION_EXPIRY_WARNING must be shorter than SESSION_LIFETIME and SESSION_IDLE_TIMEOUT, got %s", cfg.SessionExpiryWarning)
+	}
+
+	if cfg.DebugQueryBudget < 0 {
+		return nil, fmt.Errorf("DEBUG_QUERY_BUDGET must not be negative, got %d", cfg.DebugQueryBudget)
+	}
+
+	if cfg.SessionLimit < 0 {
+		return nil, fmt.Errorf("SESSION_LIMIT must not be negative, got %d", cfg.SessionLimit)
+	}
+
+	if cfg.AdminSudoWindow < 0 {
+		return nil, fmt.Errorf("ADMIN_SUDO_WINDOW must not be negative, got %s", cfg.AdminSudoWindow)
+	}
+
+	if cfg.ExpensiveConcurrency < 1 || cfg.ExpensiveQueue < 0 {
+		return nil, fmt.Errorf("EXPENSIVE_CONCURRENCY must be at least 1 and EXPENSIVE_QUEUE not negative, got %d and %d", cfg.ExpensiveConcurrency, cfg.ExpensiveQueue)
+	}
+
 	if cfg.Debug {
 		utils.Warnf("Running in DEBUG mode")
 	}
@@ -47,6 +300,12 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// isBaseURL reports whether s is an http(s) URL with a host and no path or query
+func isBaseURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != "" && strings.Trim(u.Path, "/") == "" && u.RawQuery == ""
+}
+
 func MustLoad() *Config {
 	cfg, err := Load()
 	if err != nil {
@@ -91,3 +91,351 @@ func TestConfig_AllowedHosts(t *testing.T) {
 		t.Errorf("Expected first allowed host localhost, got %s", cfg.AllowedHosts[0])
 	}
 }
+
+// TestLoad_AuthIdentifier tests that AUTH_IDENTIFIER defaults to email and rejects unknown values
+func TestLoad_AuthIdentifier(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.AuthIdentifier != AuthIdentifierEmail {
+		t.Errorf("Expected default AUTH_IDENTIFIER %q, got %q", AuthIdentifierEmail, cfg.AuthIdentifier)
+	}
+
+	t.Setenv("AUTH_IDENTIFIER", "phone")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for an unknown AUTH_IDENTIFIER")
+	}
+}
+
+// TestLoad_VersionEndpoint tests that VERSION_ENDPOINT defaults to staff and rejects unknown values
+func TestLoad_VersionEndpoint(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.VersionEndpoint != VersionEndpointStaff {
+		t.Errorf("Expected default VERSION_ENDPOINT %q, got %q", VersionEndpointStaff, cfg.VersionEndpoint)
+	}
+
+	t.Setenv("VERSION_ENDPOINT", "everyone")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for an unknown VERSION_ENDPOINT")
+	}
+}
+
+// TestLoad_Profiling tests that PROFILING defaults to debug and rejects unknown values
+func TestLoad_Profiling(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Profiling != ProfilingDebug {
+		t.Errorf("Expected default PROFILING %q, got %q", ProfilingDebug, cfg.Profiling)
+	}
+
+	t.Setenv("PROFILING", "public")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for an unknown PROFILING")
+	}
+}
+
+// TestLoad_Currency tests that CURRENCY defaults to USD and rejects unsupported codes
+func TestLoad_Currency(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Currency != "USD" {
+		t.Errorf("Expected default CURRENCY USD, got %q", cfg.Currency)
+	}
+
+	t.Setenv("CURRENCY", "XYZ")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for an unsupported CURRENCY")
+	}
+}
+
+// TestLoad_AdminSudoWindow tests that ADMIN_SUDO_WINDOW defaults to 15m and rejects negative values
+func TestLoad_AdminSudoWindow(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.AdminSudoWindow != 15*time.Minute {
+		t.Errorf("Expected default ADMIN_SUDO_WINDOW 15m, got %s", cfg.AdminSudoWindow)
+	}
+
+	t.Setenv("ADMIN_SUDO_WINDOW", "-1m")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for a negative ADMIN_SUDO_WINDOW")
+	}
+}
+
+// TestLoad_ExpensiveConcurrency tests the defaults for expensive endpoints and that a zero limit is rejected
+func TestLoad_ExpensiveConcurrency(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.ExpensiveConcurrency != 4 || cfg.ExpensiveQueue != 32 || cfg.ExpensiveQueueWait != 5*time.Second {
+		t.Errorf("Expected defaults 4, 32 and 5s, got %d, %d and %s", cfg.ExpensiveConcurrency, cfg.ExpensiveQueue, cfg.ExpensiveQueueWait)
+	}
+
+	t.Setenv("EXPENSIVE_CONCURRENCY", "0")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for EXPENSIVE_CONCURRENCY 0")
+	}
+}
+
+// TestLoad_SigningKeys tests that SIGNING_KEYS falls back to SESSION_KEY and rejects empty keys
+func TestLoad_SigningKeys(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.SigningKeys) != 1 || cfg.SigningKeys[0] != "test-session-key-32-chars-long!" {
+		t.Errorf("Expected SIGNING_KEYS to default to SESSION_KEY, got %v", cfg.SigningKeys)
+	}
+
+	t.Setenv("SIGNING_KEYS", "new-key,old-key")
+	if cfg, _ := Load(); len(cfg.SigningKeys) != 2 || cfg.SigningKeys[0] != "new-key" {
+		t.Errorf("Expected both keys, newest first, got %v", cfg.SigningKeys)
+	}
+
+	t.Setenv("SIGNING_KEYS", "new-key,,old-key")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for an empty key in SIGNING_KEYS")
+	}
+}
+
+// TestLoad_LogRedaction tests that LOG_REDACT_KEYS is split on commas and email masking is off by default
+func TestLoad_LogRedaction(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+	t.Setenv("LOG_REDACT_KEYS", "ssn,date_of_birth")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.LogRedactKeys) != 2 || cfg.LogRedactKeys[1] != "date_of_birth" {
+		t.Errorf("Expected two keys, got %v", cfg.LogRedactKeys)
+	}
+	if cfg.LogRedactEmails {
+		t.Error("Expected LOG_REDACT_EMAILS to default to false")
+	}
+}
+
+// TestLoad_AuditExportFormat tests that AUDIT_EXPORT_FORMAT defaults to csv and rejects unknown formats
+func TestLoad_AuditExportFormat(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.AuditExportFormat != "csv" || cfg.AuditExportURL != "" || len(cfg.AuditExportEmail) != 0 {
+		t.Errorf("Expected CSV exports with no destination by default, got %+v", cfg)
+	}
+
+	t.Setenv("AUDIT_EXPORT_FORMAT", "xlsx")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for AUDIT_EXPORT_FORMAT xlsx")
+	}
+}
+
+// TestLoad_SessionTimeouts tests the session timeout defaults and that the warning must come before either timeout
+func TestLoad_SessionTimeouts(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.SessionLifetime != 12*time.Hour || cfg.SessionIdleTimeout != 30*time.Minute || cfg.SessionExpiryWarning != 2*time.Minute {
+		t.Errorf("Unexpected defaults %s, %s, %s", cfg.SessionLifetime, cfg.SessionIdleTimeout, cfg.SessionExpiryWarning)
+	}
+
+	t.Setenv("SESSION_IDLE_TIMEOUT", "0")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected the idle timeout to be optional, got %v", err)
+	}
+	t.Setenv("SESSION_IDLE_TIMEOUT", "1m")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for a warning longer than the idle timeout")
+	}
+	t.Setenv("SESSION_EXPIRY_WARNING", "0")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected no warning to be fine, got %v", err)
+	}
+	t.Setenv("SESSION_LIFETIME", "0")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for a zero SESSION_LIFETIME")
+	}
+}
+
+// TestLoad_DebugToolbar tests the toolbar's defaults and that DEBUG_QUERY_BUDGET can't be negative
+func TestLoad_DebugToolbar(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.DebugToolbar || cfg.DebugQueryBudget != 20 {
+		t.Errorf("Expected the toolbar on with a budget of 20, got %v and %d", cfg.DebugToolbar, cfg.DebugQueryBudget)
+	}
+
+	t.Setenv("DEBUG_QUERY_BUDGET", "-1")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for a negative DEBUG_QUERY_BUDGET")
+	}
+}
+
+// TestLoad_SessionLimit tests that sessions are unlimited by default and SESSION_LIMIT can't be negative
+func TestLoad_SessionLimit(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	if cfg, err := Load(); err != nil || cfg.SessionLimit != 0 {
+		t.Fatalf("Expected no session limit by default, got %v, %v", cfg, err)
+	}
+
+	t.Setenv("SESSION_LIMIT", "3")
+	if cfg, err := Load(); err != nil || cfg.SessionLimit != 3 {
+		t.Errorf("Expected a limit of 3, got %v, %v", cfg, err)
+	}
+
+	t.Setenv("SESSION_LIMIT", "-1")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for a negative SESSION_LIMIT")
+	}
+}
+
+// TestLoad_ContactEmail tests that the contact form is off by default and CONTACT_EMAIL must hold addresses
+func TestLoad_ContactEmail(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	if cfg, err := Load(); err != nil || len(cfg.ContactEmail) != 0 {
+		t.Fatalf("Expected the contact form to be off by default, got %v, %v", cfg, err)
+	}
+
+	t.Setenv("CONTACT_EMAIL", "hello@example.com,support@example.com")
+	if cfg, err := Load(); err != nil || len(cfg.ContactEmail) != 2 {
+		t.Errorf("Expected two addresses, got %v, %v", cfg, err)
+	}
+	t.Setenv("CONTACT_EMAIL", "hello@example.com,support")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for a CONTACT_EMAIL that isn't an address")
+	}
+}
+
+// TestLoad_OIDCIssuer tests that OIDC_CLIENTS requires OIDC_ISSUER to be a base URL
+func TestLoad_OIDCIssuer(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	if cfg, err := Load(); err != nil || len(cfg.OIDCClients) != 0 {
+		t.Fatalf("Expected the provider to be off by default, got %v, %v", cfg, err)
+	}
+
+	t.Setenv("OIDC_CLIENTS", "wiki:secret:https://wiki.example.com/callback")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for OIDC_CLIENTS without OIDC_ISSUER")
+	}
+	t.Setenv("OIDC_ISSUER", "https://accounts.example.com/sso")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for an OIDC_ISSUER with a path")
+	}
+	t.Setenv("OIDC_ISSUER", "https://accounts.example.com/")
+	if _, err := Load(); err != nil {
+		t.Errorf("Load failed: %v", err)
+	}
+}
+
+// TestLoad_MagicLink tests that MAGIC_LINK is off by default and needs SITE_URL when on
+func TestLoad_MagicLink(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.MagicLink != MagicLinkOff || cfg.MagicLinkTTL != 15*time.Minute {
+		t.Errorf("Expected MAGIC_LINK off with a 15m TTL, got %q and %s", cfg.MagicLink, cfg.MagicLinkTTL)
+	}
+
+	t.Setenv("MAGIC_LINK", "only")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for MAGIC_LINK without SITE_URL")
+	}
+	t.Setenv("SITE_URL", "https://example.com")
+	if _, err := Load(); err != nil {
+		t.Errorf("Load failed: %v", err)
+	}
+	t.Setenv("MAGIC_LINK", "always")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for an unknown MAGIC_LINK")
+	}
+}
+
+// TestLoad_Passkeys tests that PASSKEYS is off by default and needs SITE_URL
+func TestLoad_Passkeys(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+	t.Setenv("PASSKEYS", "true")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for PASSKEYS without SITE_URL")
+	}
+	t.Setenv("SITE_URL", "http://localhost:8080")
+	if cfg, err := Load(); err != nil || !cfg.Passkeys {
+		t.Errorf("Load = %v, %v", cfg, err)
+	}
+}
+
+// TestLoad_Locales tests the LOCALES default and that malformed tags are refused
+func TestLoad_Locales(t *testing.T) {
+	t.Setenv("DATABASE_URL", "sqlite://test.db")
+	t.Setenv("SESSION_KEY", "test-session-key-32-chars-long!")
+
+	cfg, err := Load()
+	if err != nil || len(cfg.Locales) != 1 || cfg.Locales[0] != "en" {
+		t.Fatalf("Load = %v, %v", cfg, err)
+	}
+	t.Setenv("LOCALES", "en,fr,pt-BR")
+	if cfg, err := Load(); err != nil || len(cfg.Locales) != 3 {
+		t.Errorf("Load = %v, %v", cfg, err)
+	}
+	t.Setenv("LOCALES", "en,French")
+	if _, err := Load(); err == nil {
+		t.Error("Expected an error for a malformed locale")
+	}
+}
@@ -0,0 +1,103 @@
+// Package ctxutil holds the typed accessors for request-scoped values, so
+// middleware and handlers share one set of context keys instead of each
+// package defining its own:
+//
+//	user := ctxutil.User(r.Context())        // Set by middleware.LoadUser
+//	id := ctxutil.RequestID(r.Context())     // Set by chi's RequestID middleware
+//	tx := ctxutil.Tx(r.Context())            // Set by middleware.Transaction
+//
+// Each value has a With function that returns a copy of ctx carrying it, for
+// middleware and tests. Getters return the zero value when nothing set one.
+package ctxutil
+
+import (
+	"context"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// key is the type of this package's context keys, so they can't collide with
+// keys defined elsewhere
+type key int
+
+const (
+	userKey key = iota
+	tenantKey
+	localeKey
+)
+
+// User returns the signed-in user, or nil for anonymous requests
+func User(ctx context.Context) *models.User {
+	user, _ := ctx.Value(userKey).(*models.User)
+	return user
+}
+
+// WithUser returns a copy of ctx carrying user
+func WithUser(ctx context.Context, user *models.User) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
+
+// RequestID returns the request's ID, which chi's Logger also prints
+func RequestID(ctx context.Context) string {
+	return chimiddleware.GetReqID(ctx)
+}
+
+// WithRequestID returns a copy of ctx carrying id, e.g., to keep a request's
+// ID on a background job it enqueued
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, chimiddleware.RequestIDKey, id)
+}
+
+// Tenant returns the ID of the tenant the request is for, in multi-tenant
+// apps whose middleware resolves one (e.g., from the subdomain)
+func Tenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey).(string)
+	return tenant
+}
+
+// WithTenant returns a copy of ctx carrying the tenant ID
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// Locale returns the request's language as a BCP 47 tag (e.g., "en-GB"), for
+// apps whose middleware picks one
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey).(string)
+	return locale
+}
+
+// WithLocale returns a copy of ctx carrying the locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// Tx returns the request's transaction, or nil outside one. Use
+// db.ClientFromContext to run queries in it when there is one.
+func Tx(ctx context.Context) *models.Tx {
+	return models.TxFromContext(ctx)
+}
+
+// WithTx returns a copy of ctx carrying tx
+func WithTx(ctx context.Context, tx *models.Tx) context.Context {
+	return models.NewTxContext(ctx, tx)
+}
+
+// LogFields returns the request ID, user ID and tenant set on ctx as key-value
+// pairs for utils.Infow and friends:
+//
+//	utils.Errorw("posts.save_failed", append(ctxutil.LogFields(ctx), "error", err)...)
+func LogFields(ctx context.Context) []interface{} {
+	var fields []interface{}
+	if id := RequestID(ctx); id != "" {
+		fields = append(fields, "request_id", id)
+	}
+	if user := User(ctx); user != nil {
+		fields = append(fields, "user_id", user.ID)
+	}
+	if tenant := Tenant(ctx); tenant != "" {
+		fields = append(fields, "tenant", tenant)
+	}
+	return fields
+}
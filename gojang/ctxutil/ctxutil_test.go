@@ -0,0 +1,55 @@
+package ctxutil
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+// TestAccessors tests that each value round-trips and defaults to its zero value
+func TestAccessors(t *testing.T) {
+	ctx := context.Background()
+	if User(ctx) != nil || RequestID(ctx) != "" || Tenant(ctx) != "" || Locale(ctx) != "" || Tx(ctx) != nil {
+		t.Fatal("Expected zero values from an empty context")
+	}
+	if fields := LogFields(ctx); len(fields) != 0 {
+		t.Errorf("Expected no log fields, got %v", fields)
+	}
+
+	user := &models.User{ID: uuid.New(), Email: "test@example.com"}
+	tx := &models.Tx{}
+	ctx = WithUser(ctx, user)
+	ctx = WithRequestID(ctx, "host/abc-000001")
+	ctx = WithTenant(ctx, "acme")
+	ctx = WithLocale(ctx, "en-GB")
+	ctx = WithTx(ctx, tx)
+
+	if User(ctx) != user {
+		t.Errorf("Expected the user, got %v", User(ctx))
+	}
+	if RequestID(ctx) != "host/abc-000001" {
+		t.Errorf("Expected the request ID, got %q", RequestID(ctx))
+	}
+	if Tenant(ctx) != "acme" || Locale(ctx) != "en-GB" {
+		t.Errorf("Expected tenant acme and locale en-GB, got %q and %q", Tenant(ctx), Locale(ctx))
+	}
+	if Tx(ctx) != tx || models.TxFromContext(ctx) != tx {
+		t.Error("Expected the transaction, shared with Ent's own context key")
+	}
+
+	want := []interface{}{"request_id", "host/abc-000001", "user_id", user.ID, "tenant", "acme"}
+	if fields := LogFields(ctx); !reflect.DeepEqual(fields, want) {
+		t.Errorf("Expected log fields %v, got %v", want, fields)
+	}
+}
+
+// TestUser_WrongType tests that a value of another type under the key isn't returned
+func TestUser_WrongType(t *testing.T) {
+	ctx := context.WithValue(context.Background(), userKey, "not a user")
+	if User(ctx) != nil {
+		t.Error("Expected nil user when context contains wrong type")
+	}
+}
@@ -0,0 +1,104 @@
+// Package debugbar is the development toolbar: an overlay at the bottom of
+// full pages in debug mode showing what the request did — its database queries
+// and their time, template rendering, the session's contents and the messages
+// logged meanwhile, like Django's debug toolbar.
+//
+// Driver records queries made through the Ent client, Toolbar.Middleware
+// starts a Recording for each request, and the renderers time their templates
+// (AddRender) and call Toolbar.Inject on the finished page.
+package debugbar
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxQueries is how many queries a Recording keeps; later ones are still
+// counted and timed
+const maxQueries = 500
+
+// Query is a database query made during a request
+type Query struct {
+	SQL      string
+	Args     string // Formatted, and shortened if long
+	Duration time.Duration
+	Err      string
+}
+
+// Render is a template executed during a request
+type Render struct {
+	Template string
+	Duration time.Duration
+}
+
+// Recording is what one request did, as shown on the toolbar. It's safe for
+// concurrent use and a nil Recording ignores everything.
+type Recording struct {
+	Start time.Time
+
+	mu        sync.Mutex
+	queries   []Query
+	count     int
+	queryTime time.Duration
+	renders   []Render
+}
+
+// recordingKey is the context key holding a request's Recording
+type recordingKey struct{}
+
+// NewContext returns a copy of ctx carrying rec, as Toolbar.Middleware does
+func NewContext(ctx context.Context, rec *Recording) context.Context {
+	return context.WithValue(ctx, recordingKey{}, rec)
+}
+
+// FromContext returns the Recording ctx carries, or nil outside a recorded request
+func FromContext(ctx context.Context) *Recording {
+	rec, _ := ctx.Value(recordingKey{}).(*Recording)
+	return rec
+}
+
+// addQuery records q
+func (r *Recording) addQuery(q Query) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.count++
+	r.queryTime += q.Duration
+	if len(r.queries) < maxQueries {
+		r.queries = append(r.queries, q)
+	}
+}
+
+// AddRender records that template name took d to execute
+func (r *Recording) AddRender(name string, d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renders = append(r.renders, Render{Template: name, Duration: d})
+}
+
+// Queries returns the queries made so far (at most the first 500), how many
+// there were in all and how long they took together
+func (r *Recording) Queries() ([]Query, int, time.Duration) {
+	if r == nil {
+		return nil, 0, 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Query(nil), r.queries...), r.count, r.queryTime
+}
+
+// Renders returns the templates executed so far
+func (r *Recording) Renders() []Render {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Render(nil), r.renders...)
+}
@@ -0,0 +1,45 @@
+package debugbar
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRecording tests that queries past maxQueries are still counted and timed
+func TestRecording(t *testing.T) {
+	rec := &Recording{Start: time.Now()}
+	ctx := NewContext(context.Background(), rec)
+	if FromContext(ctx) != rec {
+		t.Fatal("Expected the recording back from the context")
+	}
+
+	for i := 0; i < maxQueries+10; i++ {
+		FromContext(ctx).addQuery(Query{SQL: "SELECT 1", Duration: time.Millisecond})
+	}
+	FromContext(ctx).AddRender("home.html", 2*time.Millisecond)
+
+	queries, count, total := rec.Queries()
+	if len(queries) != maxQueries || count != maxQueries+10 {
+		t.Errorf("Expected %d queries kept of %d, got %d of %d", maxQueries, maxQueries+10, len(queries), count)
+	}
+	if total != time.Duration(maxQueries+10)*time.Millisecond {
+		t.Errorf("Expected every query's time to count, got %s", total)
+	}
+	if renders := rec.Renders(); len(renders) != 1 || renders[0].Template != "home.html" {
+		t.Errorf("Expected the home.html render, got %+v", renders)
+	}
+}
+
+// TestRecording_Nil tests that requests without a recording ignore everything
+func TestRecording_Nil(t *testing.T) {
+	rec := FromContext(context.Background())
+	if rec != nil {
+		t.Fatal("Expected no recording outside a recorded request")
+	}
+	rec.addQuery(Query{SQL: "SELECT 1"})
+	rec.AddRender("home.html", time.Millisecond)
+	if _, count, _ := rec.Queries(); count != 0 || rec.Renders() != nil {
+		t.Error("Expected a nil recording to stay empty")
+	}
+}
@@ -0,0 +1,92 @@
+package debugbar
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// maxArgsLen is how much of a query's formatted arguments is kept
+const maxArgsLen = 200
+
+// Driver wraps drv so queries made with a recorded request's context, inside
+// transactions too, are added to its Recording. Other queries go straight
+// through. See db.NewMonitoredClient.
+func Driver(drv dialect.Driver) dialect.Driver {
+	return &driver{Driver: drv}
+}
+
+// driver is the dialect.Driver returned by Driver
+type driver struct {
+	dialect.Driver
+}
+
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	return record(ctx, query, args, func() error { return d.Driver.Exec(ctx, query, args, v) })
+}
+
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	return record(ctx, query, args, func() error { return d.Driver.Query(ctx, query, args, v) })
+}
+
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	t, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tx{Tx: t}, nil
+}
+
+// BeginTx is used by models.Client.BeginTx
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	b, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, errors.New("debugbar: driver does not support BeginTx")
+	}
+	t, err := b.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &tx{Tx: t}, nil
+}
+
+// tx records the queries of a transaction started through driver
+type tx struct {
+	dialect.Tx
+}
+
+func (t *tx) Exec(ctx context.Context, query string, args, v any) error {
+	return record(ctx, query, args, func() error { return t.Tx.Exec(ctx, query, args, v) })
+}
+
+func (t *tx) Query(ctx context.Context, query string, args, v any) error {
+	return record(ctx, query, args, func() error { return t.Tx.Query(ctx, query, args, v) })
+}
+
+// record runs a query, adding it to ctx's Recording if it has one
+func record(ctx context.Context, query string, args any, run func() error) error {
+	rec := FromContext(ctx)
+	if rec == nil {
+		return run()
+	}
+	start := time.Now()
+	err := run()
+	q := Query{SQL: query, Duration: time.Since(start)}
+	if args != nil {
+		q.Args = fmt.Sprint(args)
+		if len(q.Args) > maxArgsLen {
+			q.Args = q.Args[:maxArgsLen] + "…"
+		}
+	}
+	if err != nil {
+		q.Err = err.Error()
+	}
+	rec.addQuery(q)
+	return err
+}
@@ -0,0 +1,51 @@
+package debugbar
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// TestDriver tests that queries made for a recorded request, in transactions
+// too, are recorded and others aren't
+func TestDriver(t *testing.T) {
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	client := models.NewClient(models.Driver(Driver(drv)))
+	defer client.Close()
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("Schema.Create failed: %v", err)
+	}
+
+	rec := &Recording{Start: time.Now()}
+	ctx := NewContext(context.Background(), rec)
+	client.User.Create().SetEmail("a@example.com").SetPasswordHash("x").SaveX(ctx)
+	client.User.Query().CountX(ctx)
+	client.User.Query().CountX(context.Background()) // Not recorded
+
+	tx, err := client.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx failed: %v", err)
+	}
+	tx.User.Query().CountX(ctx)
+	tx.Commit()
+
+	queries, count, _ := rec.Queries()
+	if count != 3 {
+		t.Fatalf("Expected 3 recorded queries, got %d: %+v", count, queries)
+	}
+	if !strings.HasPrefix(queries[0].SQL, "INSERT INTO `users`") || !strings.Contains(queries[0].Args, "a@example.com") {
+		t.Errorf("Expected the insert with its arguments first, got %+v", queries[0])
+	}
+	if !strings.HasPrefix(queries[2].SQL, "SELECT COUNT") {
+		t.Errorf("Expected the count inside the transaction last, got %+v", queries[2])
+	}
+}
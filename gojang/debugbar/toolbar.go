@@ -0,0 +1,258 @@
+package debugbar
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxValueLen is how much of a session value the toolbar shows
+const maxValueLen = 200
+
+// Toolbar adds the development toolbar to pages. Only use it in debug mode:
+// it shows query arguments and session contents to whoever loads the page.
+type Toolbar struct {
+	// Sessions, if set, is the session manager whose contents are shown
+	Sessions *scs.SessionManager
+
+	// QueryBudget, if positive, is how many queries a request may make before
+	// the toolbar flags it and a warning is logged
+	QueryBudget int
+}
+
+// Middleware records each request for the toolbar. Add it ahead of anything
+// that queries the database, so those queries count.
+func (t *Toolbar) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &Recording{Start: time.Now()}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), rec)))
+
+		// Also covers htmx and API requests, which don't show the toolbar
+		if _, count, queryTime := rec.Queries(); t.QueryBudget > 0 && count > t.QueryBudget {
+			utils.Warnw("debugbar.query_budget_exceeded",
+				"method", r.Method, "path", r.URL.Path,
+				"queries", count, "budget", t.QueryBudget, "query_ms", queryTime.Milliseconds())
+		}
+	})
+}
+
+// Inject adds the toolbar to the page in buf, just before </body>, showing what
+// req's Recording holds so far. Pages without a Recording or a </body> (e.g.,
+// htmx partials) are left alone.
+func (t *Toolbar) Inject(buf *bytes.Buffer, req *http.Request) error {
+	rec := FromContext(req.Context())
+	if rec == nil {
+		return nil
+	}
+	at := bytes.LastIndex(buf.Bytes(), []byte("</body>"))
+	if at < 0 {
+		return nil
+	}
+
+	var toolbar bytes.Buffer
+	if err := toolbarTemplate.Execute(&toolbar, t.view(req, rec)); err != nil {
+		return err
+	}
+	rest := append([]byte(nil), buf.Bytes()[at:]...)
+	buf.Truncate(at)
+	buf.Write(toolbar.Bytes())
+	buf.Write(rest)
+	return nil
+}
+
+// view is what toolbarTemplate shows
+type view struct {
+	Method, Path string
+	Total        time.Duration
+
+	Queries    []queryView
+	QueryCount int
+	QueryTime  time.Duration
+	Duplicates int // Queries that ran more than once with the same SQL, beyond the first
+	Budget     int
+	OverBudget bool
+
+	Renders    []Render
+	RenderTime time.Duration
+
+	Session []sessionValue
+	Logs    []utils.LogEntry
+}
+
+// queryView is a query with how often its SQL ran during the request
+type queryView struct {
+	Query
+	Repeats int
+}
+
+// sessionValue is a session key and its formatted value
+type sessionValue struct {
+	Key, Value string
+}
+
+// view gathers what the toolbar shows for req
+func (t *Toolbar) view(req *http.Request, rec *Recording) *view {
+	queries, count, queryTime := rec.Queries()
+	v := &view{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Total:      time.Since(rec.Start),
+		QueryCount: count,
+		QueryTime:  queryTime,
+		Budget:     t.QueryBudget,
+		OverBudget: t.QueryBudget > 0 && count > t.QueryBudget,
+		Renders:    rec.Renders(),
+		Session:    t.session(req),
+		Logs:       requestLogs(req, rec.Start),
+	}
+
+	// Repeated SQL is usually a query in a loop (N+1)
+	seen := map[string]int{}
+	for _, q := range queries {
+		seen[q.SQL]++
+	}
+	for _, q := range queries {
+		v.Queries = append(v.Queries, queryView{Query: q, Repeats: seen[q.SQL]})
+	}
+	for _, n := range seen {
+		v.Duplicates += n - 1
+	}
+	for _, r := range v.Renders {
+		v.RenderTime += r.Duration
+	}
+	return v
+}
+
+// session returns the contents of req's session, by key. Requests served
+// outside the session middleware have none.
+func (t *Toolbar) session(req *http.Request) (values []sessionValue) {
+	if t.Sessions == nil {
+		return nil
+	}
+	defer func() {
+		if recover() != nil { // scs panics without session data in the context
+			values = nil
+		}
+	}()
+	ctx := req.Context()
+	keys := t.Sessions.Keys(ctx)
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := fmt.Sprint(t.Sessions.Get(ctx, key))
+		if len(value) > maxValueLen {
+			value = value[:maxValueLen] + "…"
+		}
+		values = append(values, sessionValue{Key: key, Value: value})
+	}
+	return values
+}
+
+// requestLogs returns the entries logged since start, oldest first, leaving
+// out those tagged with another request's ID. Untagged entries from requests
+// running at the same time can't be told apart and are included.
+func requestLogs(req *http.Request, start time.Time) []utils.LogEntry {
+	id := ctxutil.RequestID(req.Context())
+	var logs []utils.LogEntry
+	for _, e := range utils.RecentLogs.Entries(utils.LogFilter{Level: zapcore.DebugLevel, Limit: 200}) {
+		if e.Time.Before(start) {
+			break // Newest first
+		}
+		if other := logField(e, "request_id"); other != "" && other != id {
+			continue
+		}
+		logs = append(logs, e)
+	}
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+	return logs
+}
+
+// logField returns the value of e's field key, or ""
+func logField(e utils.LogEntry, key string) string {
+	for _, f := range e.Fields {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// ms formats d in milliseconds, e.g. "3.2 ms"
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1f ms", float64(d.Microseconds())/1000)
+}
+
+// toolbarTemplate is the toolbar, styled inline so it looks the same on every
+// layout. Panels are <details>, so it works without JavaScript.
+var toolbarTemplate = template.Must(template.New("debugbar").Funcs(template.FuncMap{"ms": ms}).Parse(`
+<div id="debugbar">
+<style>
+#debugbar { position: fixed; right: 0.75rem; bottom: 0.75rem; z-index: 2147483000; max-width: min(64rem, calc(100vw - 1.5rem)); font: 12px/1.4 ui-monospace, SFMono-Regular, Menlo, monospace; color: #e2e8f0; }
+#debugbar > details { background: #0f172a; border: 1px solid #334155; border-radius: 0.5rem; box-shadow: 0 10px 25px rgba(0,0,0,0.3); }
+#debugbar summary { cursor: pointer; padding: 0.375rem 0.75rem; list-style: none; white-space: nowrap; }
+#debugbar summary::-webkit-details-marker { display: none; }
+#debugbar .debugbar-panels { max-height: 60vh; overflow: auto; padding: 0 0.75rem 0.75rem; }
+#debugbar .debugbar-panels details { border-top: 1px solid #334155; }
+#debugbar .debugbar-panels summary { padding: 0.375rem 0; font-weight: 600; }
+#debugbar table { width: 100%; border-collapse: collapse; }
+#debugbar td { padding: 0.125rem 0.5rem 0.125rem 0; vertical-align: top; border-bottom: 1px solid #1e293b; }
+#debugbar td.debugbar-num { text-align: right; white-space: nowrap; color: #94a3b8; }
+#debugbar code { white-space: pre-wrap; word-break: break-word; color: inherit; background: none; }
+#debugbar .debugbar-muted { color: #94a3b8; }
+#debugbar .debugbar-warn { color: #fbbf24; }
+#debugbar .debugbar-error { color: #f87171; }
+</style>
+<details>
+<summary>
+<strong>{{.Method}} {{.Path}}</strong> · {{ms .Total}}
+· <span{{if .OverBudget}} class="debugbar-warn" title="Over the budget of {{.Budget}} queries"{{end}}>SQL {{.QueryCount}}{{if .Budget}}/{{.Budget}}{{end}} in {{ms .QueryTime}}</span>{{if .Duplicates}} <span class="debugbar-warn">({{.Duplicates}} repeated)</span>{{end}}
+· Templates {{ms .RenderTime}}
+· Session {{len .Session}}
+· Logs {{len .Logs}}
+</summary>
+<div class="debugbar-panels">
+<details open>
+<summary>Queries ({{.QueryCount}}, {{ms .QueryTime}}){{if lt (len .Queries) .QueryCount}} <span class="debugbar-muted">first {{len .Queries}} shown</span>{{end}}</summary>
+<table>
+{{range .Queries}}<tr>
+<td class="debugbar-num">{{ms .Duration}}</td>
+<td><code>{{.SQL}}</code>{{if .Args}}<br><span class="debugbar-muted">{{.Args}}</span>{{end}}{{if .Err}}<br><span class="debugbar-error">{{.Err}}</span>{{end}}</td>
+<td class="debugbar-num">{{if gt .Repeats 1}}<span class="debugbar-warn">×{{.Repeats}}</span>{{end}}</td>
+</tr>{{else}}<tr><td class="debugbar-muted">No queries</td></tr>{{end}}
+</table>
+</details>
+<details>
+<summary>Templates ({{ms .RenderTime}})</summary>
+<table>
+{{range .Renders}}<tr><td class="debugbar-num">{{ms .Duration}}</td><td>{{.Template}}</td></tr>{{end}}
+</table>
+</details>
+<details>
+<summary>Session ({{len .Session}})</summary>
+<table>
+{{range .Session}}<tr><td>{{.Key}}</td><td><code>{{.Value}}</code></td></tr>{{else}}<tr><td class="debugbar-muted">Empty</td></tr>{{end}}
+</table>
+</details>
+<details>
+<summary>Logs ({{len .Logs}})</summary>
+<table>
+{{range .Logs}}<tr>
+<td class="debugbar-num">{{.Time.Format "15:04:05.000"}}</td>
+<td class="{{if ge .Level 2}}debugbar-error{{else if eq .Level 1}}debugbar-warn{{end}}">{{.Level.CapitalString}}</td>
+<td>{{.Message}}{{range .Fields}} <span class="debugbar-muted">{{.Key}}={{.Value}}</span>{{end}}</td>
+</tr>{{else}}<tr><td class="debugbar-muted">Nothing logged</td></tr>{{end}}
+</table>
+</details>
+</div>
+</details>
+</div>
+`))
@@ -0,0 +1,77 @@
+package debugbar
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+// TestToolbar_Inject tests that the toolbar goes before </body> with the request's queries and session
+func TestToolbar_Inject(t *testing.T) {
+	sm := scs.New()
+	tb := &Toolbar{Sessions: sm, QueryBudget: 2}
+
+	var page bytes.Buffer
+	handler := sm.LoadAndSave(tb.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sm.Put(r.Context(), "user_id", "42")
+		rec := FromContext(r.Context())
+		for i := 0; i < 3; i++ {
+			rec.addQuery(Query{SQL: "SELECT * FROM `posts` WHERE `id` = ?", Args: "[7]", Duration: time.Millisecond})
+		}
+		rec.AddRender("posts/show.html", time.Millisecond)
+
+		page.WriteString("<html><body><main>Post</main></body></html>")
+		if err := tb.Inject(&page, r); err != nil {
+			t.Errorf("Inject failed: %v", err)
+		}
+	})))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/posts/7", nil))
+
+	html := page.String()
+	toolbar := strings.Index(html, `<div id="debugbar">`)
+	if toolbar < strings.Index(html, "</main>") || toolbar > strings.Index(html, "</body>") {
+		t.Fatalf("Expected the toolbar just before </body>, got %s", html)
+	}
+	for _, want := range []string{
+		"GET /posts/7",
+		`class="debugbar-warn" title="Over the budget of 2 queries"`,
+		"SQL 3/2",
+		"(2 repeated)",
+		"×3",
+		"posts/show.html",
+		"<td>user_id</td><td><code>42</code></td>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected the toolbar to contain %q", want)
+		}
+	}
+}
+
+// TestToolbar_InjectWithoutBody tests that fragments and unrecorded requests are left alone
+func TestToolbar_InjectWithoutBody(t *testing.T) {
+	tb := &Toolbar{}
+	r := httptest.NewRequest("GET", "/", nil)
+
+	page := bytes.NewBufferString("<html><body></body></html>")
+	if err := tb.Inject(page, r); err != nil || strings.Contains(page.String(), "debugbar") {
+		t.Errorf("Expected no toolbar without a recording, got %q, %v", page, err)
+	}
+
+	r = r.WithContext(NewContext(r.Context(), &Recording{Start: time.Now()}))
+	page = bytes.NewBufferString("<li>Fragment</li>")
+	if err := tb.Inject(page, r); err != nil || page.String() != "<li>Fragment</li>" {
+		t.Errorf("Expected fragments to be left alone, got %q, %v", page, err)
+	}
+
+	// Without a session manager or session data there's just no session panel
+	page = bytes.NewBufferString("<html><body></body></html>")
+	tb.Sessions = scs.New()
+	if err := tb.Inject(page, r); err != nil || !strings.Contains(page.String(), "Session 0") {
+		t.Errorf("Expected an empty session panel, got %v", err)
+	}
+}
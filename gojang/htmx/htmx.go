@@ -0,0 +1,149 @@
+// Package htmx reads the headers htmx sends with its requests and sets the
+// response headers it acts on, so handlers don't spell out "HX-*" strings:
+//
+//	if !htmx.IsRequest(r) {
+//		http.Redirect(w, r, "/posts", http.StatusSeeOther)
+//		return
+//	}
+//	htmx.Retarget(w, "#modal")
+//	htmx.Reswap(w, "innerHTML")
+//
+// See https://htmx.org/reference/#headers
+package htmx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Request headers
+const (
+	HeaderRequest        = "HX-Request"
+	HeaderBoosted        = "HX-Boosted"
+	HeaderCurrentURL     = "HX-Current-URL"
+	HeaderHistoryRestore = "HX-History-Restore-Request"
+	HeaderPrompt         = "HX-Prompt"
+	HeaderTarget         = "HX-Target"
+	HeaderTriggerName    = "HX-Trigger-Name"
+)
+
+// Response headers (HeaderTrigger is also sent on requests, with the
+// triggering element's id)
+const (
+	HeaderTrigger            = "HX-Trigger"
+	HeaderTriggerAfterSwap   = "HX-Trigger-After-Swap"
+	HeaderTriggerAfterSettle = "HX-Trigger-After-Settle"
+	HeaderRetarget           = "HX-Retarget"
+	HeaderReswap             = "HX-Reswap"
+	HeaderRedirect           = "HX-Redirect"
+	HeaderRefresh            = "HX-Refresh"
+	HeaderPushURL            = "HX-Push-Url"
+	HeaderReplaceURL         = "HX-Replace-Url"
+)
+
+// IsRequest reports whether htmx made the request
+func IsRequest(r *http.Request) bool {
+	return r.Header.Get(HeaderRequest) == "true"
+}
+
+// IsBoosted reports whether the request came from an hx-boost link or form
+func IsBoosted(r *http.Request) bool {
+	return r.Header.Get(HeaderBoosted) == "true"
+}
+
+// IsHistoryRestore reports whether htmx is restoring a page missing from its
+// history cache, in which case it needs the full page
+func IsHistoryRestore(r *http.Request) bool {
+	return r.Header.Get(HeaderHistoryRestore) == "true"
+}
+
+// CurrentURL returns the browser's URL when the request was made
+func CurrentURL(r *http.Request) string {
+	return r.Header.Get(HeaderCurrentURL)
+}
+
+// Prompt returns the user's answer to hx-prompt
+func Prompt(r *http.Request) string {
+	return r.Header.Get(HeaderPrompt)
+}
+
+// TargetID returns the id of the target element, if it has one
+func TargetID(r *http.Request) string {
+	return r.Header.Get(HeaderTarget)
+}
+
+// TriggerID returns the id of the element that made the request, if it has one
+func TriggerID(r *http.Request) string {
+	return r.Header.Get(HeaderTrigger)
+}
+
+// TriggerName returns the name of the element that made the request, if it has one
+func TriggerName(r *http.Request) string {
+	return r.Header.Get(HeaderTriggerName)
+}
+
+// Trigger fires client-side events once the response is received, e.g.,
+// htmx.Trigger(w, "closeModal")
+func Trigger(w http.ResponseWriter, events ...string) {
+	w.Header().Set(HeaderTrigger, strings.Join(events, ", "))
+}
+
+// TriggerWith fires events with details, which listeners read from
+// event.detail:
+//
+//	htmx.TriggerWith(w, map[string]interface{}{"showMessage": map[string]interface{}{"level": "info", "text": "Saved"}})
+//
+// Events without details can be given a true value.
+func TriggerWith(w http.ResponseWriter, events map[string]interface{}) error {
+	return setJSON(w, HeaderTrigger, events)
+}
+
+// TriggerAfterSwap is TriggerWith, fired after the response is swapped in
+func TriggerAfterSwap(w http.ResponseWriter, events map[string]interface{}) error {
+	return setJSON(w, HeaderTriggerAfterSwap, events)
+}
+
+// TriggerAfterSettle is TriggerWith, fired after the swapped content settles
+func TriggerAfterSettle(w http.ResponseWriter, events map[string]interface{}) error {
+	return setJSON(w, HeaderTriggerAfterSettle, events)
+}
+
+// Retarget swaps the response into selector instead of the request's target
+func Retarget(w http.ResponseWriter, selector string) {
+	w.Header().Set(HeaderRetarget, selector)
+}
+
+// Reswap overrides the request's hx-swap (e.g., "innerHTML", "outerHTML", "none")
+func Reswap(w http.ResponseWriter, swap string) {
+	w.Header().Set(HeaderReswap, swap)
+}
+
+// Redirect makes the browser load url with a full page load
+func Redirect(w http.ResponseWriter, url string) {
+	w.Header().Set(HeaderRedirect, url)
+}
+
+// Refresh makes the browser reload the current page
+func Refresh(w http.ResponseWriter) {
+	w.Header().Set(HeaderRefresh, "true")
+}
+
+// PushURL adds url to the browser's history
+func PushURL(w http.ResponseWriter, url string) {
+	w.Header().Set(HeaderPushURL, url)
+}
+
+// ReplaceURL replaces the browser's current URL with url
+func ReplaceURL(w http.ResponseWriter, url string) {
+	w.Header().Set(HeaderReplaceURL, url)
+}
+
+func setJSON(w http.ResponseWriter, header string, events map[string]interface{}) error {
+	payload, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(header, string(payload))
+	return nil
+}
@@ -0,0 +1,87 @@
+package htmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRequestHeaders tests reading the headers htmx sends
+func TestRequestHeaders(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/posts", nil)
+	if IsRequest(r) || IsBoosted(r) || IsHistoryRestore(r) {
+		t.Error("Plain requests should not look like htmx requests")
+	}
+
+	r.Header.Set("HX-Request", "true")
+	r.Header.Set("HX-Boosted", "true")
+	r.Header.Set("HX-Target", "posts-list")
+	r.Header.Set("HX-Trigger", "load-more")
+	r.Header.Set("HX-Trigger-Name", "page")
+	r.Header.Set("HX-Current-URL", "https://example.com/posts")
+	r.Header.Set("HX-Prompt", "yes")
+	if !IsRequest(r) || !IsBoosted(r) {
+		t.Error("Expected an htmx boosted request")
+	}
+	for name, tt := range map[string][2]string{
+		"TargetID":    {TargetID(r), "posts-list"},
+		"TriggerID":   {TriggerID(r), "load-more"},
+		"TriggerName": {TriggerName(r), "page"},
+		"CurrentURL":  {CurrentURL(r), "https://example.com/posts"},
+		"Prompt":      {Prompt(r), "yes"},
+	} {
+		if tt[0] != tt[1] {
+			t.Errorf("%s = %q, want %q", name, tt[0], tt[1])
+		}
+	}
+}
+
+// TestResponseHeaders tests the headers the response helpers set
+func TestResponseHeaders(t *testing.T) {
+	w := httptest.NewRecorder()
+	Trigger(w, "closeModal", "refreshList")
+	Retarget(w, "#modal")
+	Reswap(w, "innerHTML")
+	Redirect(w, "/login")
+	Refresh(w)
+	PushURL(w, "/posts?page=2")
+	ReplaceURL(w, "/posts")
+	if err := TriggerAfterSettle(w, map[string]interface{}{"focus": "#subject"}); err != nil {
+		t.Fatalf("TriggerAfterSettle failed: %v", err)
+	}
+
+	for header, want := range map[string]string{
+		"HX-Trigger":              "closeModal, refreshList",
+		"HX-Retarget":             "#modal",
+		"HX-Reswap":               "innerHTML",
+		"HX-Redirect":             "/login",
+		"HX-Refresh":              "true",
+		"HX-Push-Url":             "/posts?page=2",
+		"HX-Replace-Url":          "/posts",
+		"HX-Trigger-After-Settle": `{"focus":"#subject"}`,
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+}
+
+// TestTriggerWith tests event details are sent as JSON
+func TestTriggerWith(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := TriggerWith(w, map[string]interface{}{
+		"closeDeleteModal": true,
+		"showUndo":         map[string]interface{}{"url": "/admin/post/undo/abc", "seconds": 10},
+	})
+	if err != nil {
+		t.Fatalf("TriggerWith failed: %v", err)
+	}
+	want := `{"closeDeleteModal":true,"showUndo":{"seconds":10,"url":"/admin/post/undo/abc"}}`
+	if got := w.Header().Get("HX-Trigger"); got != want {
+		t.Errorf("HX-Trigger = %q, want %q", got, want)
+	}
+
+	if err := TriggerWith(w, map[string]interface{}{"bad": make(chan int)}); err == nil {
+		t.Error("Expected an error for details that can't be encoded")
+	}
+}
@@ -0,0 +1,143 @@
+// Package api serves versioned JSON APIs. Each Version registers its own
+// routes, so v2 can change responses while v1 clients keep working:
+//
+//	r.Mount("/api", api.Router(
+//		api.Version{Name: "v1", Routes: v1Routes, Deprecated: v1Deprecated, Sunset: v1Sunset},
+//		api.Version{Name: "v2", Routes: v2Routes},
+//	))
+//
+// Clients pick a version with the path (/api/v1/posts) or, on unprefixed
+// paths (/api/posts), with an API-Version header or an Accept media type
+// like application/vnd.gojang.v2+json. Unprefixed requests without either
+// get the latest version.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// VersionHeader names the version a request asks for and a response was served by
+const VersionHeader = "API-Version"
+
+// Version is one version of an API
+type Version struct {
+	Name   string             // Path segment and header value, e.g. "v1"
+	Routes func(r chi.Router) // Registers the version's endpoints
+
+	// Deprecated versions keep working but tell clients to move on with
+	// Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers
+	Deprecated time.Time // When the version was deprecated (zero if it isn't)
+	Sunset     time.Time // When it will be removed (optional)
+	Link       string    // Migration guide URL (optional)
+}
+
+// mediaTypeVersion matches the version in application/vnd.<vendor>.<version>+json
+var mediaTypeVersion = regexp.MustCompile(`application/vnd\.[a-z0-9-]+\.(v[0-9]+)\+json`)
+
+// Router serves each version under its own path prefix and negotiates the
+// version of unprefixed requests. The last version is the latest.
+func Router(versions ...Version) chi.Router {
+	if len(versions) == 0 {
+		panic("api: Router needs at least one version")
+	}
+
+	r := chi.NewRouter()
+	byName := make(map[string]http.Handler, len(versions))
+	for _, v := range versions {
+		vr := chi.NewRouter()
+		vr.Use(v.headers)
+		vr.NotFound(func(w http.ResponseWriter, r *http.Request) {
+			Error(w, http.StatusNotFound, "Not found")
+		})
+		vr.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+			Error(w, http.StatusMethodNotAllowed, "Method not allowed")
+		})
+		v.Routes(vr)
+
+		r.Mount("/"+v.Name, vr)
+		byName[v.Name] = vr
+	}
+
+	latest := versions[len(versions)-1].Name
+	r.Mount("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", VersionHeader+", Accept")
+		name := requestedVersion(r)
+		if name == "" {
+			name = latest
+		}
+		h, ok := byName[name]
+		if !ok {
+			Error(w, http.StatusBadRequest, fmt.Sprintf("Unknown API version %q", name))
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+	return r
+}
+
+// requestedVersion returns the version named by the request's headers, if any.
+// Both "v2" and "2" are accepted in API-Version.
+func requestedVersion(r *http.Request) string {
+	if v := strings.TrimSpace(r.Header.Get(VersionHeader)); v != "" {
+		if !strings.HasPrefix(v, "v") {
+			v = "v" + v
+		}
+		return v
+	}
+	if m := mediaTypeVersion.FindStringSubmatch(r.Header.Get("Accept")); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// headers marks responses with the version that served them
+func (v Version) headers(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set(VersionHeader, v.Name)
+		if !v.Deprecated.IsZero() {
+			h.Set("Deprecation", fmt.Sprintf("@%d", v.Deprecated.Unix()))
+			if !v.Sunset.IsZero() {
+				h.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if v.Link != "" {
+				h.Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", v.Link))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// JSON writes v as a JSON response
+func JSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		utils.Warnw("api.encode_failed", "error", err)
+	}
+}
+
+// Page is the body of list responses. NextCursor is empty on the last page;
+// otherwise clients pass it back as ?cursor= (see db.EncodeCursor).
+type Page struct {
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// ErrorResponse is the body of every API error
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Error writes a JSON error response
+func Error(w http.ResponseWriter, status int, message string) {
+	JSON(w, status, ErrorResponse{Error: message})
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// testRouter serves GET /hello from a deprecated v1 and a current v2
+func testRouter() chi.Router {
+	hello := func(version string) func(chi.Router) {
+		return func(r chi.Router) {
+			r.Get("/hello", func(w http.ResponseWriter, r *http.Request) {
+				JSON(w, http.StatusOK, map[string]string{"version": version})
+			})
+		}
+	}
+	return Router(
+		Version{
+			Name:       "v1",
+			Routes:     hello("one"),
+			Deprecated: time.Unix(1767225600, 0),
+			Sunset:     time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			Link:       "https://example.com/api/migrating-to-v2",
+		},
+		Version{Name: "v2", Routes: hello("two")},
+	)
+}
+
+// TestRouter tests choosing a version by path and by header
+func TestRouter(t *testing.T) {
+	router := testRouter()
+	tests := []struct {
+		name, path string
+		header     http.Header
+		want       string
+	}{
+		{"path v1", "/v1/hello", nil, "one"},
+		{"path v2", "/v2/hello", nil, "two"},
+		{"latest by default", "/hello", nil, "two"},
+		{"API-Version header", "/hello", http.Header{"Api-Version": {"v1"}}, "one"},
+		{"API-Version number", "/hello", http.Header{"Api-Version": {"1"}}, "one"},
+		{"Accept media type", "/hello", http.Header{"Accept": {"application/vnd.gojang.v1+json"}}, "one"},
+		{"path wins over header", "/v2/hello", http.Header{"Api-Version": {"v1"}}, "two"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"`+tt.want+`"`) {
+				t.Errorf("Expected version %s, got %d %s", tt.want, rec.Code, rec.Body.String())
+			}
+			served := map[string]string{"one": "v1", "two": "v2"}[tt.want]
+			if got := rec.Header().Get(VersionHeader); got != served {
+				t.Errorf("%s = %q, want %q", VersionHeader, got, served)
+			}
+		})
+	}
+}
+
+// TestRouter_Deprecation tests the headers sent by deprecated versions
+func TestRouter_Deprecation(t *testing.T) {
+	router := testRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/hello", nil))
+	if got := rec.Header().Get("Deprecation"); got != "@1767225600" {
+		t.Errorf("Deprecation = %q", got)
+	}
+	if got := rec.Header().Get("Sunset"); got != "Fri, 01 Jan 2027 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", got)
+	}
+	if got := rec.Header().Get("Link"); got != `<https://example.com/api/migrating-to-v2>; rel="deprecation"` {
+		t.Errorf("Link = %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/hello", nil))
+	if rec.Header().Get("Deprecation") != "" || rec.Header().Get("Sunset") != "" {
+		t.Errorf("Expected no deprecation headers on v2, got %v", rec.Header())
+	}
+}
+
+// TestRouter_Errors tests that errors are JSON
+func TestRouter_Errors(t *testing.T) {
+	router := testRouter()
+	tests := []struct {
+		method, path, version string
+		status                int
+	}{
+		{http.MethodGet, "/hello", "v9", http.StatusBadRequest},
+		{http.MethodGet, "/v1/missing", "", http.StatusNotFound},
+		{http.MethodPost, "/v2/hello", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.version != "" {
+			req.Header.Set(VersionHeader, tt.version)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, rec.Code, tt.status)
+		}
+		if !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") || !strings.Contains(rec.Body.String(), `"error"`) {
+			t.Errorf("%s %s: expected a JSON error, got %s", tt.method, tt.path, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/views/forms"
+)
+
+// ProblemContentType is the media type of Problem responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Errors holds the invalid fields
+// of a request body by their JSON name.
+type Problem struct {
+	Type   string            `json:"type"` // "about:blank" unless set
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail,omitempty"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// WriteProblem writes p as an application/problem+json response. Title
+// defaults to the status text.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		utils.Warnw("api.encode_failed", "error", err)
+	}
+}
+
+// Binder decodes JSON request bodies into DTO structs
+type Binder struct {
+	MaxBytes int64 // Largest body accepted (413 beyond it)
+	MaxDepth int   // Deepest nesting of objects and arrays accepted
+}
+
+// DefaultBinder is the Binder behind Bind
+var DefaultBinder = Binder{MaxBytes: 1 << 20, MaxDepth: 32}
+
+// Bind decodes the JSON body of r into dst (a pointer to a struct) and checks
+// its validate tags, with the same rules and messages as forms.Validate. On
+// failure it writes a problem+json response and returns false:
+//
+//	var in CreatePostRequest
+//	if !api.Bind(w, r, &in) {
+//		return
+//	}
+func Bind(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	return DefaultBinder.Bind(w, r, dst)
+}
+
+// Bind is the package-level Bind with b's limits
+func (b Binder) Bind(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		WriteProblem(w, Problem{Status: http.StatusUnsupportedMediaType, Detail: "Content-Type must be application/json"})
+		return false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, b.MaxBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			WriteProblem(w, Problem{Status: http.StatusRequestEntityTooLarge, Detail: fmt.Sprintf("Request body is larger than %d bytes", b.MaxBytes)})
+			return false
+		}
+		WriteProblem(w, Problem{Status: http.StatusBadRequest, Detail: "Failed to read the request body"})
+		return false
+	}
+	if jsonDepth(body) > b.MaxDepth {
+		WriteProblem(w, Problem{Status: http.StatusBadRequest, Detail: fmt.Sprintf("Request body nests deeper than %d levels", b.MaxDepth)})
+		return false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		writeDecodeProblem(w, err)
+		return false
+	}
+	if dec.More() {
+		WriteProblem(w, Problem{Status: http.StatusBadRequest, Detail: "Request body must be a single JSON value"})
+		return false
+	}
+
+	if errs := forms.Validate(dst); len(errs) > 0 {
+		names := jsonNames(reflect.TypeOf(dst))
+		fields := make(map[string]string, len(errs))
+		for field, message := range errs {
+			if name, ok := names[field]; ok {
+				field = name
+			}
+			fields[field] = message
+		}
+		WriteProblem(w, Problem{Status: http.StatusUnprocessableEntity, Detail: "Validation failed", Errors: fields})
+		return false
+	}
+	return true
+}
+
+// writeDecodeProblem describes a json.Decoder error
+func writeDecodeProblem(w http.ResponseWriter, err error) {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		WriteProblem(w, Problem{Status: http.StatusBadRequest, Detail: "Request body is empty"})
+	case errors.As(err, &syntaxErr):
+		WriteProblem(w, Problem{Status: http.StatusBadRequest, Detail: fmt.Sprintf("Malformed JSON at byte %d", syntaxErr.Offset)})
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		WriteProblem(w, Problem{Status: http.StatusBadRequest, Detail: "Malformed JSON"})
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		WriteProblem(w, Problem{Status: http.StatusUnprocessableEntity, Detail: "Validation failed", Errors: map[string]string{
+			typeErr.Field: "Must be " + jsonKind(typeErr.Type),
+		}})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		WriteProblem(w, Problem{Status: http.StatusUnprocessableEntity, Detail: "Validation failed", Errors: map[string]string{
+			field: "Unknown field",
+		}})
+	default:
+		WriteProblem(w, Problem{Status: http.StatusBadRequest, Detail: "Request body must be a JSON object"})
+	}
+}
+
+// jsonKind names the JSON value a Go type decodes from
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	}
+	return "a " + t.String()
+}
+
+// jsonNames maps the struct field names of t (a struct or a pointer to one) to
+// their JSON names, so validation errors use the names clients send
+func jsonNames(t reflect.Type) map[string]string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	names := make(map[string]string)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			for field, name := range jsonNames(f.Type) {
+				names[field] = name
+			}
+			continue
+		}
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			names[f.Name] = name
+		}
+	}
+	return names
+}
+
+// jsonDepth returns how deeply the objects and arrays in data nest
+func jsonDepth(data []byte) int {
+	depth, deepest := 0, 0
+	inString, escaped := false, false
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			if depth > deepest {
+				deepest = depth
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return deepest
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// bindRequest is a DTO with nested and validated fields
+type bindRequest struct {
+	Subject string   `json:"subject" validate:"required,max=10"`
+	Email   string   `json:"email" validate:"omitempty,email"`
+	Count   int      `json:"count"`
+	Tags    []string `json:"tags"`
+}
+
+// TestBind tests decoding, limits and the problem+json errors
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantErrors  map[string]string
+	}{
+		{"valid", "application/json", `{"subject": "Hello", "count": 2, "tags": ["a"]}`, http.StatusOK, nil},
+		{"charset", "application/json; charset=utf-8", `{"subject": "Hello"}`, http.StatusOK, nil},
+		{"form content type", "application/x-www-form-urlencoded", `subject=Hello`, http.StatusUnsupportedMediaType, nil},
+		{"empty", "application/json", ``, http.StatusBadRequest, nil},
+		{"malformed", "application/json", `{"subject": }`, http.StatusBadRequest, nil},
+		{"truncated", "application/json", `{"subject": "Hello"`, http.StatusBadRequest, nil},
+		{"two values", "application/json", `{"subject": "a"} {"subject": "b"}`, http.StatusBadRequest, nil},
+		{"too large", "application/json", `{"subject": "` + strings.Repeat("a", 100) + `"}`, http.StatusRequestEntityTooLarge, nil},
+		{"too deep", "application/json", `{"tags": [[[[["a"]]]]]}`, http.StatusBadRequest, nil},
+		{"brackets in strings", "application/json", `{"subject": "[[[[[[", "tags": ["{{{{"]}`, http.StatusOK, nil},
+		{"wrong type", "application/json", `{"subject": "Hello", "count": "two"}`, http.StatusUnprocessableEntity, map[string]string{"count": "Must be a whole number"}},
+		{"unknown field", "application/json", `{"subject": "Hello", "author": "x"}`, http.StatusUnprocessableEntity, map[string]string{"author": "Unknown field"}},
+		{"invalid", "application/json", `{"email": "nope"}`, http.StatusUnprocessableEntity, map[string]string{"subject": "This field is required", "email": "Invalid email address"}},
+	}
+	binder := Binder{MaxBytes: 64, MaxDepth: 4}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+
+			var in bindRequest
+			if ok := binder.Bind(rec, req, &in); ok != (tt.wantStatus == http.StatusOK) {
+				t.Fatalf("Bind = %v, want status %d: %s", ok, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+
+			if rec.Code != tt.wantStatus || rec.Header().Get("Content-Type") != ProblemContentType {
+				t.Fatalf("Expected a %d problem, got %d %s", tt.wantStatus, rec.Code, rec.Header().Get("Content-Type"))
+			}
+			var p Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatalf("Invalid problem body: %v", err)
+			}
+			if p.Type != "about:blank" || p.Status != tt.wantStatus || p.Title != http.StatusText(tt.wantStatus) || p.Detail == "" {
+				t.Errorf("Unexpected problem %+v", p)
+			}
+			for field, message := range tt.wantErrors {
+				if p.Errors[field] != message {
+					t.Errorf("Expected %s error %q, got %v", field, message, p.Errors)
+				}
+			}
+			if len(p.Errors) != len(tt.wantErrors) {
+				t.Errorf("Expected errors %v, got %v", tt.wantErrors, p.Errors)
+			}
+		})
+	}
+}
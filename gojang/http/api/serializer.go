@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Envelope is the body of responses from serialized endpoints: the record or
+// records in Data, and for lists, Meta.Pagination
+type Envelope struct {
+	Data interface{} `json:"data"`
+	Meta *Meta       `json:"meta,omitempty"`
+}
+
+// Meta describes a response beyond its data
+type Meta struct {
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes a page of a list. NextCursor is empty on the last page;
+// otherwise clients pass it back as ?cursor=.
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Field is a property of serialized records
+type Field[T any] struct {
+	Name  string
+	Value func(T) interface{}
+}
+
+// Serializer renders records as JSON objects. Clients choose Fields with
+// ?fields=id,subject (all by default) and embed Includes, such as relations,
+// with ?include=author:
+//
+//	var postSerializer = api.Serializer[*models.Post]{
+//		Fields:   []api.Field[*models.Post]{{Name: "id", Value: func(p *models.Post) interface{} { return p.ID }}},
+//		Includes: []api.Field[*models.Post]{{Name: "author", Value: postAuthor}},
+//	}
+type Serializer[T any] struct {
+	Fields   []Field[T]
+	Includes []Field[T]
+}
+
+// Selection is the fields and includes a request asked a Serializer for
+type Selection struct {
+	fields   map[string]bool // nil selects every field
+	includes map[string]bool
+}
+
+// Included reports whether the request asked to embed name, so handlers can
+// load only the relations they'll render
+func (s Selection) Included(name string) bool {
+	return s.includes[name]
+}
+
+// Select reads ?fields= and ?include= from r. Unknown names are an error whose
+// message can go to the client.
+func (s Serializer[T]) Select(r *http.Request) (Selection, error) {
+	var sel Selection
+	q := r.URL.Query()
+	if v := q.Get("fields"); v != "" {
+		sel.fields = make(map[string]bool)
+		for _, name := range splitList(v) {
+			if !hasField(s.Fields, name) {
+				return Selection{}, fmt.Errorf("Unknown field %q in fields (valid: %s)", name, fieldNames(s.Fields))
+			}
+			sel.fields[name] = true
+		}
+	}
+	if v := q.Get("include"); v != "" {
+		sel.includes = make(map[string]bool)
+		for _, name := range splitList(v) {
+			if !hasField(s.Includes, name) {
+				return Selection{}, fmt.Errorf("Unknown relation %q in include (valid: %s)", name, fieldNames(s.Includes))
+			}
+			sel.includes[name] = true
+		}
+	}
+	return sel, nil
+}
+
+// One renders record with the selected fields and includes
+func (s Serializer[T]) One(sel Selection, record T) map[string]interface{} {
+	obj := make(map[string]interface{}, len(s.Fields))
+	for _, f := range s.Fields {
+		if sel.fields == nil || sel.fields[f.Name] {
+			obj[f.Name] = f.Value(record)
+		}
+	}
+	for _, f := range s.Includes {
+		if sel.includes[f.Name] {
+			obj[f.Name] = f.Value(record)
+		}
+	}
+	return obj
+}
+
+// Many renders records with the selected fields and includes
+func (s Serializer[T]) Many(sel Selection, records []T) []map[string]interface{} {
+	objs := make([]map[string]interface{}, len(records))
+	for i, record := range records {
+		objs[i] = s.One(sel, record)
+	}
+	return objs
+}
+
+// splitList splits a comma-separated query value, ignoring blanks
+func splitList(v string) []string {
+	var names []string
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func hasField[T any](fields []Field[T], name string) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldNames[T any](fields []Field[T]) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// testArticle is a record with a relation
+type testArticle struct {
+	ID     int
+	Title  string
+	Author string
+}
+
+var testSerializer = Serializer[testArticle]{
+	Fields: []Field[testArticle]{
+		{Name: "id", Value: func(a testArticle) interface{} { return a.ID }},
+		{Name: "title", Value: func(a testArticle) interface{} { return a.Title }},
+	},
+	Includes: []Field[testArticle]{
+		{Name: "author", Value: func(a testArticle) interface{} { return map[string]string{"name": a.Author} }},
+	},
+}
+
+// TestSerializer tests field selection and includes
+func TestSerializer(t *testing.T) {
+	article := testArticle{ID: 1, Title: "Hello", Author: "alice"}
+	tests := []struct {
+		name  string
+		query string
+		want  map[string]interface{}
+	}{
+		{"all fields", "", map[string]interface{}{"id": 1, "title": "Hello"}},
+		{"selected fields", "?fields=title", map[string]interface{}{"title": "Hello"}},
+		{"spaces and blanks", "?fields=id,+title,", map[string]interface{}{"id": 1, "title": "Hello"}},
+		{"include", "?include=author", map[string]interface{}{"id": 1, "title": "Hello", "author": map[string]string{"name": "alice"}}},
+		{"fields and include", "?fields=id&include=author", map[string]interface{}{"id": 1, "author": map[string]string{"name": "alice"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := testSerializer.Select(httptest.NewRequest("GET", "/"+tt.query, nil))
+			if err != nil {
+				t.Fatalf("Select failed: %v", err)
+			}
+			if got := testSerializer.One(sel, article); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if got := testSerializer.Many(sel, []testArticle{article}); len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("Expected [%v], got %v", tt.want, got)
+			}
+		})
+	}
+
+	sel, _ := testSerializer.Select(httptest.NewRequest("GET", "/?include=author", nil))
+	if !sel.Included("author") {
+		t.Error("Expected author to be included")
+	}
+	for _, query := range []string{"?fields=body", "?include=comments", "?fields=author"} {
+		if _, err := testSerializer.Select(httptest.NewRequest("GET", "/"+query, nil)); err == nil {
+			t.Errorf("Expected an error for %s", query)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package authz declares who may use a route, next to the route itself:
+//
+//	r.With(authz.Require(middleware.PermissionManageUsers)).Get("/users", h.Index)
+//	r.With(authz.RequireRole(authz.RoleStaff)).Mount("/reports", reports)
+//
+// Every declaration is enforced by the same middleware (Policy.Middleware),
+// and `gojang routes` lists each route's policy. Permissions are checked with
+// middleware.CanUser. The user must already be loaded into the request
+// context (middleware.LoadUser or RequireAuth).
+package authz
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// Roles a Policy can require
+const (
+	RoleAuthenticated = "authenticated" // Any signed-in user
+	RoleStaff         = "staff"         // IsStaff (superusers count as staff)
+	RoleSuperuser     = "superuser"     // IsSuperuser
+)
+
+// Policy is who may use a route: a signed-in user with any of Roles (if
+// set) and all of Permissions. The zero Policy lets everyone in.
+type Policy struct {
+	Roles       []string
+	Permissions []string
+}
+
+// Require returns middleware that only lets in users with every permission
+func Require(permissions ...string) func(http.Handler) http.Handler {
+	return Policy{Permissions: permissions}.Middleware()
+}
+
+// RequireRole returns middleware that only lets in users with one of roles
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return Policy{Roles: roles}.Middleware()
+}
+
+// Allows reports whether the request's user satisfies p
+func (p Policy) Allows(r *http.Request) bool {
+	if len(p.Roles) == 0 && len(p.Permissions) == 0 {
+		return true
+	}
+	user := middleware.GetUser(r.Context())
+	if user == nil {
+		return false
+	}
+	if len(p.Roles) > 0 && !hasAnyRole(user, p.Roles) {
+		return false
+	}
+	for _, permission := range p.Permissions {
+		if !middleware.CanUser(r, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnyRole(user *models.User, roles []string) bool {
+	for _, role := range roles {
+		switch role {
+		case RoleAuthenticated:
+			return true
+		case RoleStaff:
+			if user.IsStaff || user.IsSuperuser {
+				return true
+			}
+		case RoleSuperuser:
+			if user.IsSuperuser {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String describes p for `gojang routes`, e.g. "role:staff perm:manage_users"
+func (p Policy) String() string {
+	var parts []string
+	if len(p.Roles) > 0 {
+		parts = append(parts, "role:"+strings.Join(p.Roles, "|"))
+	}
+	for _, permission := range p.Permissions {
+		parts = append(parts, "perm:"+permission)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Middleware returns the middleware enforcing p. Visitors who aren't signed
+// in are sent to the login page; signed-in users p refuses get the
+// forbidden handler (see SetForbiddenHandler) and an audit log entry.
+func (p Policy) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return &guard{policy: p, next: next}
+	}
+}
+
+// guard is the handler Policy.Middleware wraps routes in
+type guard struct {
+	policy Policy
+	next   http.Handler
+}
+
+func (g *guard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if g.policy.Allows(r) {
+		g.next.ServeHTTP(w, r)
+		return
+	}
+	if middleware.GetUser(r.Context()) == nil {
+		if htmx.IsRequest(r) {
+			htmx.Redirect(w, "/login")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		http.Redirect(w, r, "/login?next="+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
+		return
+	}
+	middleware.LogPermissionDenied(r, r.Method+" "+r.URL.Path, g.policy.String())
+	forbiddenHandler().ServeHTTP(w, r)
+}
+
+// Describe returns the Policy mw enforces, if it's authz middleware
+func Describe(mw func(http.Handler) http.Handler) (Policy, bool) {
+	g, ok := mw(http.NotFoundHandler()).(*guard)
+	if !ok {
+		return Policy{}, false
+	}
+	return g.policy, true
+}
+
+var (
+	forbiddenMu sync.RWMutex
+	forbidden   http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+)
+
+// SetForbiddenHandler sets the handler for signed-in users a policy refuses
+// (by default a plain 403). It should respond with 403 Forbidden.
+func SetForbiddenHandler(h http.Handler) {
+	forbiddenMu.Lock()
+	defer forbiddenMu.Unlock()
+	forbidden = h
+}
+
+func forbiddenHandler() http.Handler {
+	forbiddenMu.RLock()
+	defer forbiddenMu.RUnlock()
+	return forbidden
+}
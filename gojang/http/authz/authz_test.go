@@ -0,0 +1,75 @@
+package authz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+func TestPolicyMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	member := &models.User{Email: "member@example.com"}
+	staff := &models.User{Email: "staff@example.com", IsStaff: true}
+	superuser := &models.User{Email: "root@example.com", IsSuperuser: true}
+
+	tests := []struct {
+		name   string
+		mw     func(http.Handler) http.Handler
+		user   *models.User
+		status int
+	}{
+		{"anonymous is sent to login", RequireRole(RoleAuthenticated), nil, http.StatusSeeOther},
+		{"member signed in", RequireRole(RoleAuthenticated), member, http.StatusOK},
+		{"member isn't staff", RequireRole(RoleStaff), member, http.StatusForbidden},
+		{"superuser counts as staff", RequireRole(RoleStaff), superuser, http.StatusOK},
+		{"staff isn't superuser", RequireRole(RoleSuperuser), staff, http.StatusForbidden},
+		{"any of the roles", RequireRole(RoleSuperuser, RoleStaff), staff, http.StatusOK},
+		{"member lacks permission", Require(middleware.PermissionManageUsers), member, http.StatusForbidden},
+		{"staff has permission", Require(middleware.PermissionManageUsers), staff, http.StatusOK},
+		{"member has permission", Require(middleware.PermissionViewAnyPost), member, http.StatusOK},
+		{"zero policy", Policy{}.Middleware(), nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/users?page=2", nil)
+		if tt.user != nil {
+			r = r.WithContext(middleware.WithUser(r.Context(), tt.user))
+		}
+		w := httptest.NewRecorder()
+		tt.mw(ok).ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.status)
+		}
+		if tt.status == http.StatusSeeOther && w.Header().Get("Location") != "/login?next=%2Fusers%3Fpage%3D2" {
+			t.Errorf("%s: redirected to %q", tt.name, w.Header().Get("Location"))
+		}
+	}
+}
+
+func TestSetForbiddenHandler(t *testing.T) {
+	defer SetForbiddenHandler(forbiddenHandler())
+	SetForbiddenHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("custom"))
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(middleware.WithUser(r.Context(), &models.User{}))
+	w := httptest.NewRecorder()
+	RequireRole(RoleStaff)(http.NotFoundHandler()).ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden || w.Body.String() != "custom" {
+		t.Errorf("got %d %q, want the custom forbidden page", w.Code, w.Body.String())
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	policy, ok := Describe(Policy{Roles: []string{RoleStaff, RoleSuperuser}, Permissions: []string{"a", "b"}}.Middleware())
+	if !ok || policy.String() != "role:staff|superuser perm:a perm:b" {
+		t.Errorf("Describe = %q, %v", policy, ok)
+	}
+	if _, ok := Describe(middleware.RequireStaff); ok {
+		t.Error("Describe reported a policy for non-authz middleware")
+	}
+}
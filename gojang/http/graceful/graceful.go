@@ -0,0 +1,96 @@
+// Package graceful provides listener handoff for zero-downtime binary upgrades.
+//
+// On SIGHUP the running process starts a fresh copy of its executable and passes
+// the listening socket to it. Both processes accept connections on the same socket
+// until the child reports it is ready, then the parent drains in-flight requests
+// with http.Server.Shutdown and exits. No connection is refused during the swap.
+package graceful
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// listenFDEnv carries the inherited listener's file descriptor to the child
+	listenFDEnv = "GOJANG_LISTEN_FD"
+	// readyFDEnv carries the write end of the readiness pipe to the child
+	readyFDEnv = "GOJANG_READY_FD"
+)
+
+// ReadyTimeout is how long the parent waits for the child to report readiness
+var ReadyTimeout = 30 * time.Second
+
+// ErrUnsupported is returned by Upgrade on platforms without listener handoff
+var ErrUnsupported = errors.New("graceful upgrades are not supported on this platform")
+
+// Listen returns the listener inherited from a parent process during an upgrade,
+// or opens a new TCP listener on addr when started normally
+func Listen(addr string) (net.Listener, error) {
+	fd, ok, err := inheritedFD(listenFDEnv)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+
+	f := os.NewFile(fd, "listener")
+	defer f.Close() // FileListener dups the descriptor
+
+	ln, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("inheriting listener: %w", err)
+	}
+	return ln, nil
+}
+
+// Inherited reports whether this process was started by a graceful upgrade
+func Inherited() bool {
+	return os.Getenv(listenFDEnv) != ""
+}
+
+// Ready tells the parent process (if any) that this process is serving requests,
+// allowing the parent to shut down. It is a no-op when not started by an upgrade.
+func Ready() error {
+	fd, ok, err := inheritedFD(readyFDEnv)
+	if err != nil || !ok {
+		return err
+	}
+	os.Unsetenv(readyFDEnv)
+
+	f := os.NewFile(fd, "ready")
+	defer f.Close()
+	_, err = f.Write([]byte{1})
+	return err
+}
+
+// WritePIDFile atomically writes the current process ID to path so supervisors
+// (e.g., systemd with PIDFile=) can follow the process across upgrades
+func WritePIDFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// inheritedFD parses a file descriptor number from the named environment variable
+func inheritedFD(name string) (uintptr, bool, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, false, nil
+	}
+	fd, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s %q: %w", name, v, err)
+	}
+	return uintptr(fd), true, nil
+}
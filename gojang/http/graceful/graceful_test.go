@@ -0,0 +1,86 @@
+package graceful
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestListen_Fresh tests that Listen opens a new socket when nothing is inherited
+func TestListen_Fresh(t *testing.T) {
+	t.Setenv(listenFDEnv, "")
+
+	ln, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	if Inherited() {
+		t.Error("Expected Inherited() to be false")
+	}
+}
+
+// TestListen_Inherited tests that Listen reuses a listener passed by file descriptor
+func TestListen_Inherited(t *testing.T) {
+	orig, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer orig.Close()
+
+	f, err := orig.(*net.TCPListener).File()
+	if err != nil {
+		t.Skipf("listener files not supported: %v", err)
+	}
+	defer f.Close()
+
+	t.Setenv(listenFDEnv, strconv.Itoa(int(f.Fd())))
+
+	ln, err := Listen("ignored:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	if ln.Addr().String() != orig.Addr().String() {
+		t.Errorf("Expected inherited address %s, got %s", orig.Addr(), ln.Addr())
+	}
+}
+
+// TestListen_InvalidFD tests that a malformed descriptor is reported
+func TestListen_InvalidFD(t *testing.T) {
+	t.Setenv(listenFDEnv, "abc")
+
+	if _, err := Listen("127.0.0.1:0"); err == nil {
+		t.Error("Expected error for invalid descriptor")
+	}
+}
+
+// TestReady_NoParent tests that Ready is a no-op outside an upgrade
+func TestReady_NoParent(t *testing.T) {
+	t.Setenv(readyFDEnv, "")
+
+	if err := Ready(); err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+}
+
+// TestWritePIDFile tests that the current PID is written to the file
+func TestWritePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.pid")
+
+	if err := WritePIDFile(path); err != nil {
+		t.Fatalf("WritePIDFile failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading PID file: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != strconv.Itoa(os.Getpid()) {
+		t.Errorf("Expected PID %d, got %q", os.Getpid(), data)
+	}
+}
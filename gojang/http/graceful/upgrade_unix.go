@@ -0,0 +1,74 @@
+//go:build !windows
+
+package graceful
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Upgrade starts a new copy of the current executable, hands it the listener,
+// and waits until the child calls Ready. On success the caller should shut down
+// its http.Server; on error the caller keeps serving and the child is killed.
+func Upgrade(ln net.Listener) error {
+	tl, ok := ln.(*net.TCPListener)
+	if !ok {
+		return fmt.Errorf("cannot hand off listener of type %T", ln)
+	}
+
+	lnFile, err := tl.File()
+	if err != nil {
+		return fmt.Errorf("duplicating listener: %w", err)
+	}
+	defer lnFile.Close()
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("creating readiness pipe: %w", err)
+	}
+	defer readyR.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		readyW.Close()
+		return fmt.Errorf("locating executable: %w", err)
+	}
+
+	// ExtraFiles[i] becomes fd 3+i in the child
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{lnFile, readyW}
+	cmd.Env = append(os.Environ(), listenFDEnv+"=3", readyFDEnv+"=4")
+
+	if err := cmd.Start(); err != nil {
+		readyW.Close()
+		return fmt.Errorf("starting new process: %w", err)
+	}
+	// Close our copy so a crashing child produces EOF instead of blocking
+	readyW.Close()
+
+	ready := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		_, err := readyR.Read(buf)
+		ready <- err
+	}()
+
+	select {
+	case err := <-ready:
+		if err != nil {
+			_ = cmd.Wait()
+			return fmt.Errorf("new process exited before becoming ready: %w", err)
+		}
+		// The child outlives us; don't wait on it
+		return cmd.Process.Release()
+	case <-time.After(ReadyTimeout):
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return fmt.Errorf("new process not ready after %s", ReadyTimeout)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package graceful
+
+import "net"
+
+// Upgrade is not supported on Windows; restart the service instead
+func Upgrade(ln net.Listener) error {
+	return ErrUnsupported
+}
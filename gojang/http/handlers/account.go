@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/passkey"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+	"github.com/gojangframework/gojang/gojang/webauthn"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+// recentLoginsLimit is how many login attempts the security page lists
+const recentLoginsLimit = 20
+
+// AccountHandler serves the signed-in user's own account pages
+type AccountHandler struct {
+	Client   *models.Client
+	Renderer *renderers.Renderer
+
+	// Passkeys, if set, lets users add passkeys on the security page; their
+	// ceremonies keep a challenge in Sessions
+	Passkeys *webauthn.RelyingParty
+	Sessions *scs.SessionManager
+}
+
+func NewAccountHandler(client *models.Client, renderer *renderers.Renderer) *AccountHandler {
+	return &AccountHandler{
+		Client:   client,
+		Renderer: renderer,
+	}
+}
+
+// Security shows the user's recent login attempts, flagging failed ones and
+// logins from new devices, and their passkeys
+func (h *AccountHandler) Security(w http.ResponseWriter, r *http.Request) {
+	u := middleware.GetUser(r.Context())
+	logins, err := h.Client.LoginEvent.Query().
+		Where(loginevent.HasUserWith(user.ID(u.ID))).
+		Order(models.Desc(loginevent.FieldCreatedAt)).
+		Limit(recentLoginsLimit).
+		All(r.Context())
+	if err != nil {
+		utils.Errorw("account.login_history_failed", "user_id", u.ID, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load your sign-in history")
+		return
+	}
+
+	var passkeys []*models.Passkey
+	if h.Passkeys != nil {
+		passkeys, err = h.Client.Passkey.Query().
+			Where(passkey.HasUserWith(user.ID(u.ID))).
+			Order(models.Asc(passkey.FieldCreatedAt)).
+			All(r.Context())
+		if err != nil {
+			utils.Errorw("account.passkeys_failed", "user_id", u.ID, "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load your passkeys")
+			return
+		}
+	}
+
+	h.Renderer.Render(w, r, "account/security.html", &renderers.TemplateData{
+		Title: "Security",
+		Data: map[string]interface{}{
+			"Logins":          logins,
+			"CurrentDevice":   deviceID(r),
+			"PasskeysEnabled": h.Passkeys != nil,
+			"Passkeys":        passkeys,
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/announcements"
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/announcement"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+// dismissedAnnouncementsKey is the session key listing the announcements a
+// guest dismissed; signed-in users' dismissals are saved on their account
+const dismissedAnnouncementsKey = "announcements.dismissed"
+
+// AnnouncementHandler shows the announcements managed in the admin as
+// banners, and lets visitors dismiss them
+type AnnouncementHandler struct {
+	Client   *models.Client
+	Sessions *scs.SessionManager
+}
+
+func NewAnnouncementHandler(client *models.Client, sm *scs.SessionManager) *AnnouncementHandler {
+	return &AnnouncementHandler{
+		Client:   client,
+		Sessions: sm,
+	}
+}
+
+// Banners returns the announcements for the page being rendered (see
+// Renderer.UseAnnouncements). Errors are logged and show no banners.
+func (h *AnnouncementHandler) Banners(r *http.Request) []*models.Announcement {
+	list, err := announcements.Active(r.Context(), h.Client, middleware.GetUser(r.Context()), h.guestDismissed(r))
+	if err != nil {
+		utils.Errorw("announcements.load_failed", "error", err)
+		return nil
+	}
+	return list
+}
+
+// guestDismissed returns the announcements a guest dismissed this session
+func (h *AnnouncementHandler) guestDismissed(r *http.Request) []uuid.UUID {
+	if middleware.GetUser(r.Context()) != nil {
+		return nil
+	}
+	ids, _ := h.Sessions.Get(r.Context(), dismissedAnnouncementsKey).([]string)
+	dismissed := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if parsed, err := uuid.Parse(id); err == nil {
+			dismissed = append(dismissed, parsed)
+		}
+	}
+	return dismissed
+}
+
+// Dismiss hides an announcement from the visitor. htmx requests get an empty
+// body, which removes the banner; others go back to the "next" page.
+func (h *AnnouncementHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	exists, err := h.Client.Announcement.Query().Where(announcement.ID(id)).Exist(r.Context())
+	if err != nil {
+		utils.Errorw("announcements.dismiss_failed", "announcement_id", id, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		http.NotFound(w, r)
+		return
+	}
+
+	if u := middleware.GetUser(r.Context()); u != nil {
+		if err := announcements.Dismiss(r.Context(), h.Client, u, id); err != nil {
+			utils.Errorw("announcements.dismiss_failed", "announcement_id", id, "user_id", u.ID, "error", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		ids, _ := h.Sessions.Get(r.Context(), dismissedAnnouncementsKey).([]string)
+		h.Sessions.Put(r.Context(), dismissedAnnouncementsKey, append(ids, id.String()))
+	}
+
+	if htmx.IsRequest(r) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, nextURL(r, "/"), http.StatusSeeOther)
+}
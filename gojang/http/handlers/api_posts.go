@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
+	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+)
+
+// PostAPIHandler serves posts as JSON (see routes.APIRoutes)
+type PostAPIHandler struct {
+	Client *models.Client
+}
+
+func NewPostAPIHandler(client *models.Client) *PostAPIHandler {
+	return &PostAPIHandler{Client: client}
+}
+
+// Page sizes for post lists
+const (
+	apiDefaultLimit = 20
+	apiMaxLimit     = 100
+)
+
+// PostJSON is a post in API v1 responses (exported for `gojang gen ts`)
+type PostJSON struct {
+	ID        uuid.UUID   `json:"id"`
+	Subject   string      `json:"subject"`
+	Body      string      `json:"body"`
+	Author    *AuthorJSON `json:"author,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// AuthorJSON is the public part of a post's author (never the email)
+type AuthorJSON struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username,omitempty"`
+}
+
+func newPostJSON(p *models.Post) PostJSON {
+	res := PostJSON{
+		ID:        p.ID,
+		Subject:   p.Subject,
+		Body:      p.Body,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+	if a := p.Edges.Author; a != nil {
+		res.Author = &AuthorJSON{ID: a.ID}
+		if a.Username != nil {
+			res.Author.Username = *a.Username
+		}
+	}
+	return res
+}
+
+// postSerializer renders posts in API v2. The author is embedded with ?include=author.
+var postSerializer = api.Serializer[*models.Post]{
+	Fields: []api.Field[*models.Post]{
+		{Name: "id", Value: func(p *models.Post) interface{} { return p.ID }},
+		{Name: "subject", Value: func(p *models.Post) interface{} { return p.Subject }},
+		{Name: "body", Value: func(p *models.Post) interface{} { return p.Body }},
+		{Name: "author_id", Value: func(p *models.Post) interface{} {
+			if a := p.Edges.Author; a != nil {
+				return a.ID
+			}
+			return nil
+		}},
+		{Name: "created_at", Value: func(p *models.Post) interface{} { return p.CreatedAt }},
+		{Name: "updated_at", Value: func(p *models.Post) interface{} { return p.UpdatedAt }},
+	},
+	Includes: []api.Field[*models.Post]{
+		{Name: "author", Value: func(p *models.Post) interface{} { return newPostJSON(p).Author }},
+	},
+}
+
+// List returns posts, newest first. Clients page with ?cursor= set to the
+// previous page's next_cursor; ?offset= also works but slows down on deep pages.
+func (h *PostAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	posts, _, next, ok := h.postPage(w, r)
+	if !ok {
+		return
+	}
+	data := make([]PostJSON, len(posts))
+	for i, p := range posts {
+		data[i] = newPostJSON(p)
+	}
+	api.JSON(w, http.StatusOK, api.Page{Data: data, NextCursor: next})
+}
+
+// ListV2 is List in an api.Envelope, with ?fields= and ?include=
+func (h *PostAPIHandler) ListV2(w http.ResponseWriter, r *http.Request) {
+	sel, err := postSerializer.Select(r)
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	posts, limit, next, ok := h.postPage(w, r)
+	if !ok {
+		return
+	}
+	api.JSON(w, http.StatusOK, api.Envelope{
+		Data: postSerializer.Many(sel, posts),
+		Meta: &api.Meta{Pagination: &api.Pagination{Limit: limit, NextCursor: next, HasMore: next != ""}},
+	})
+}
+
+// Get returns one post
+func (h *PostAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.loadPost(w, r)
+	if !ok {
+		return
+	}
+	api.JSON(w, http.StatusOK, map[string]interface{}{"data": newPostJSON(p)})
+}
+
+// GetV2 is Get in an api.Envelope, with ?fields= and ?include=
+func (h *PostAPIHandler) GetV2(w http.ResponseWriter, r *http.Request) {
+	sel, err := postSerializer.Select(r)
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	p, ok := h.loadPost(w, r)
+	if !ok {
+		return
+	}
+	api.JSON(w, http.StatusOK, api.Envelope{Data: postSerializer.One(sel, p)})
+}
+
+// postPage loads the page of posts selected by ?limit=, ?offset= and ?cursor=,
+// with their authors. On a bad request it writes the error and returns false.
+func (h *PostAPIHandler) postPage(w http.ResponseWriter, r *http.Request) (posts []*models.Post, limit int, next string, ok bool) {
+	q := r.URL.Query()
+	limit, offset := apiDefaultLimit, 0
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > apiMaxLimit {
+			api.Error(w, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(apiMaxLimit))
+			return nil, 0, "", false
+		}
+		limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			api.Error(w, http.StatusBadRequest, "offset must be a non-negative number")
+			return nil, 0, "", false
+		}
+		offset = n
+	}
+
+	query := h.Client.Post.Query().
+		Where(publishedPosts()).
+		WithAuthor().
+		Order(post.OrderOption(db.CursorOrder(post.FieldCreatedAt, true)))
+	if v := q.Get("cursor"); v != "" {
+		if offset > 0 {
+			api.Error(w, http.StatusBadRequest, "Use either cursor or offset, not both")
+			return nil, 0, "", false
+		}
+		createdAt, id, err := db.DecodeCursor[time.Time](v)
+		if err != nil {
+			api.Error(w, http.StatusBadRequest, "Invalid cursor")
+			return nil, 0, "", false
+		}
+		query.Where(predicate.Post(db.CursorAfter(post.FieldCreatedAt, createdAt, id, true)))
+	}
+
+	// One extra row tells whether there's a next page
+	posts, err := query.Limit(limit + 1).Offset(offset).All(r.Context())
+	if err != nil {
+		api.Error(w, http.StatusInternalServerError, "Failed to load posts")
+		return nil, 0, "", false
+	}
+	if len(posts) > limit {
+		posts = posts[:limit]
+		last := posts[limit-1]
+		next = db.EncodeCursor(last.CreatedAt, last.ID)
+	}
+	return posts, limit, next, true
+}
+
+// loadPost loads the {id} post with its author. If it's missing it writes the
+// error and returns false.
+func (h *PostAPIHandler) loadPost(w http.ResponseWriter, r *http.Request) (*models.Post, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "Invalid post ID")
+		return nil, false
+	}
+
+	p, err := h.Client.Post.Query().
+		Where(post.ID(id), publishedPosts()).
+		WithAuthor().
+		Only(r.Context())
+	if models.IsNotFound(err) {
+		api.Error(w, http.StatusNotFound, "Post not found")
+		return nil, false
+	}
+	if err != nil {
+		api.Error(w, http.StatusInternalServerError, "Failed to load post")
+		return nil, false
+	}
+	return p, true
+}
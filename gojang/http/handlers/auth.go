@@ -1,14 +1,22 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
 	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
 	"github.com/gojangframework/gojang/gojang/models/user"
 	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/gojangframework/gojang/gojang/views/forms"
 	"github.com/gojangframework/gojang/gojang/views/renderers"
+	"github.com/gojangframework/gojang/gojang/webauthn"
 
 	"github.com/alexedwards/scs/v2"
 )
@@ -17,24 +25,90 @@ type AuthHandler struct {
 	Client   *models.Client
 	Sessions *scs.SessionManager
 	Renderer *renderers.Renderer
+
+	// Identifier is what users sign in with: config.AuthIdentifierEmail (default),
+	// config.AuthIdentifierUsername or config.AuthIdentifierBoth
+	Identifier string
+
+	// Guests, if set, merges anonymous session data into the account on login/registration
+	Guests *middleware.GuestSessions
+
+	// MagicLink offers sign-in links by email: config.MagicLinkOff (default),
+	// config.MagicLinkOn or config.MagicLinkOnly. Links point at SiteURL and
+	// work for MagicLinkTTL.
+	MagicLink    string
+	MagicLinkTTL time.Duration
+	SiteURL      string
+
+	// Passkeys, if set, offers signing in with a passkey
+	Passkeys *webauthn.RelyingParty
+
+	// MaxSessions, if positive, is how many sessions a user can be signed in
+	// with at once; signing in again signs out the oldest
+	MaxSessions int
 }
 
 func NewAuthHandler(client *models.Client, sessions *scs.SessionManager, renderer *renderers.Renderer) *AuthHandler {
 	return &AuthHandler{
-		Client:   client,
-		Sessions: sessions,
-		Renderer: renderer,
+		Client:     client,
+		Sessions:   sessions,
+		Renderer:   renderer,
+		Identifier: config.AuthIdentifierEmail,
+
+		MagicLink:    config.MagicLinkOff,
+		MagicLinkTTL: 15 * time.Minute,
+	}
+}
+
+// formData returns the template data shared by the login and register forms
+func (h *AuthHandler) formData(r *http.Request, values map[string]interface{}) map[string]interface{} {
+	data := map[string]interface{}{
+		"Next":            nextURL(r, ""), // Only if it's a safe local path
+		"Identifier":      h.Identifier,
+		"IdentifierLabel": h.identifierLabel(),
+		"MagicLink":       h.MagicLink,
+		"Passkeys":        h.Passkeys != nil,
 	}
+	for k, v := range values {
+		data[k] = v
+	}
+	return data
+}
+
+// identifierLabel names the login field for the configured identifier
+func (h *AuthHandler) identifierLabel() string {
+	switch h.Identifier {
+	case config.AuthIdentifierUsername:
+		return "Username"
+	case config.AuthIdentifierBoth:
+		return "Email or username"
+	default:
+		return "Email"
+	}
+}
+
+// findUser looks up the user signing in by email and/or username, per h.Identifier
+func (h *AuthHandler) findUser(ctx context.Context, login string) (*models.User, error) {
+	byEmail := user.EmailEqualFold(utils.NormalizeEmail(login))
+	byUsername := user.UsernameEqualFold(utils.NormalizeUsername(login))
+
+	query := h.Client.User.Query()
+	switch h.Identifier {
+	case config.AuthIdentifierUsername:
+		query = query.Where(byUsername)
+	case config.AuthIdentifierBoth:
+		// Usernames can't contain "@", so at most one of these matches
+		query = query.Where(user.Or(byEmail, byUsername))
+	default:
+		query = query.Where(byEmail)
+	}
+	return query.Only(ctx)
 }
 
 // LoginGET shows the login form
 func (h *AuthHandler) LoginGET(w http.ResponseWriter, r *http.Request) {
-	// Pass the "next" parameter to the template
-	nextURL := r.URL.Query().Get("next")
 	h.Renderer.Render(w, r, "auth/login.html", &renderers.TemplateData{
-		Data: map[string]interface{}{
-			"Next": nextURL,
-		},
+		Data: h.formData(r, map[string]interface{}{"Expired": r.URL.Query().Get("expired") != ""}),
 	})
 }
 
@@ -45,8 +119,12 @@ func (h *AuthHandler) LoginPOST(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	login := r.Form.Get("login")
+	if login == "" {
+		login = r.Form.Get("email") // Login forms from before AUTH_IDENTIFIER
+	}
 	form := forms.LoginForm{
-		Email:    r.Form.Get("email"),
+		Login:    login,
 		Password: r.Form.Get("password"),
 	}
 
@@ -55,15 +133,26 @@ func (h *AuthHandler) LoginPOST(w http.ResponseWriter, r *http.Request) {
 	if len(errors) > 0 {
 		h.Renderer.Render(w, r, "auth/login.html", &renderers.TemplateData{
 			Errors: errors,
+			Data:   h.formData(r, map[string]interface{}{"Login": form.Login}),
+		})
+		return
+	}
+
+	if h.passwordless() {
+		h.Renderer.Render(w, r, "auth/login.html", &renderers.TemplateData{
+			Errors: map[string]string{"general": "Sign in with an emailed link instead"},
+			Data:   h.formData(r, map[string]interface{}{"Login": form.Login}),
 		})
 		return
 	}
 
 	// Find user
-	u, err := h.Client.User.Query().Where(user.EmailEQ(form.Email)).Only(r.Context())
+	invalid := "Invalid " + strings.ToLower(h.identifierLabel()) + " or password"
+	u, err := h.findUser(r.Context(), form.Login)
 	if err != nil {
 		h.Renderer.Render(w, r, "auth/login.html", &renderers.TemplateData{
-			Errors: map[string]string{"general": "Invalid email or password"},
+			Errors: map[string]string{"general": invalid},
+			Data:   h.formData(r, map[string]interface{}{"Login": form.Login}),
 		})
 		return
 	}
@@ -71,53 +160,40 @@ func (h *AuthHandler) LoginPOST(w http.ResponseWriter, r *http.Request) {
 	// Check password
 	ok, err := utils.CheckPassword(u.PasswordHash, form.Password)
 	if err != nil || !ok {
+		h.recordLogin(w, r, u, loginFailedPassword)
 		h.Renderer.Render(w, r, "auth/login.html", &renderers.TemplateData{
-			Errors: map[string]string{"general": "Invalid email or password"},
+			Errors: map[string]string{"general": invalid},
+			Data:   h.formData(r, map[string]interface{}{"Login": form.Login}),
 		})
 		return
 	}
 
 	// Check if user is active
 	if !u.IsActive {
+		h.recordLogin(w, r, u, loginFailedInactive)
 		h.Renderer.Render(w, r, "auth/login.html", &renderers.TemplateData{
 			Errors: map[string]string{"general": "Your account is inactive"},
+			Data:   h.formData(r, map[string]interface{}{"Login": form.Login}),
 		})
 		return
 	}
 
 	// Update last login
-	if _, err := h.Client.User.UpdateOneID(u.ID).SetLastLogin(time.Now()).Save(r.Context()); err != nil {
+	if err := h.touchLastLogin(r.Context(), u); err != nil {
 		// Log error but don't fail login
 		utils.Warnw("user.update_last_login_failed", "user_id", u.ID, "error", err)
 	}
+	h.startSession(w, r, u)
 
-	// Create session
-	h.Sessions.Put(r.Context(), "user_id", u.ID.String())
-	h.Sessions.RenewToken(r.Context())
-
-	// Determine redirect URL (check for "next" parameter from form or query)
-	redirectURL := r.Form.Get("next")
-	if redirectURL == "" {
-		redirectURL = r.URL.Query().Get("next")
-	}
-	if redirectURL == "" {
-		redirectURL = "/dashboard"
-	}
-
-	// Handle htmx vs regular request
-	if r.Header.Get("HX-Request") == "true" {
-		// Use htmx redirect header for client-side redirect
-		w.Header().Set("HX-Redirect", redirectURL)
-		w.WriteHeader(http.StatusOK)
-		return
-	}
-
-	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+	// Redirect to the "next" page from the form or query, if it's a safe local path
+	redirect(w, r, nextURL(r, "/dashboard"))
 }
 
 // RegisterGET shows the registration form
 func (h *AuthHandler) RegisterGET(w http.ResponseWriter, r *http.Request) {
-	h.Renderer.Render(w, r, "auth/register.html", nil)
+	h.Renderer.Render(w, r, "auth/register.html", &renderers.TemplateData{
+		Data: h.formData(r, nil),
+	})
 }
 
 // RegisterPOST handles registration submission
@@ -128,25 +204,40 @@ func (h *AuthHandler) RegisterPOST(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form := forms.RegisterForm{
-		Email:           r.Form.Get("email"),
+		Email:           utils.NormalizeEmail(r.Form.Get("email")),
+		Username:        utils.NormalizeUsername(r.Form.Get("username")),
 		Password:        r.Form.Get("password"),
 		PasswordConfirm: r.Form.Get("password_confirm"),
 	}
 
+	// Without passwords the account gets one nobody knows
+	if h.passwordless() {
+		form.Password = unusablePassword()
+		form.PasswordConfirm = form.Password
+	}
+
+	// Usernames are only collected when users can sign in with one
+	if h.Identifier == config.AuthIdentifierEmail {
+		form.Username = ""
+	}
+	values := map[string]interface{}{"Email": form.Email, "Username": form.Username}
+
 	// Validate form
 	errors := forms.Validate(form)
+	if h.Identifier == config.AuthIdentifierUsername && form.Username == "" {
+		errors["Username"] = "This field is required"
+	}
 	if len(errors) > 0 {
 		h.Renderer.Render(w, r, "auth/register.html", &renderers.TemplateData{
 			Errors: errors,
-			Data: map[string]interface{}{
-				"Email": form.Email,
-			},
+			Data:   h.formData(r, values),
 		})
 		return
 	}
 
 	// Check if user already exists
-	exists, err := h.Client.User.Query().Where(user.EmailEQ(form.Email)).Exist(r.Context())
+	// Case-insensitive so accounts created before normalization still count
+	exists, err := h.Client.User.Query().Where(user.EmailEqualFold(form.Email)).Exist(r.Context())
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to check email availability")
 		return
@@ -154,13 +245,26 @@ func (h *AuthHandler) RegisterPOST(w http.ResponseWriter, r *http.Request) {
 	if exists {
 		h.Renderer.Render(w, r, "auth/register.html", &renderers.TemplateData{
 			Errors: map[string]string{"Email": "Email already registered"},
-			Data: map[string]interface{}{
-				"Email": form.Email,
-			},
+			Data:   h.formData(r, values),
 		})
 		return
 	}
 
+	if form.Username != "" {
+		taken, err := h.Client.User.Query().Where(user.UsernameEqualFold(form.Username)).Exist(r.Context())
+		if err != nil {
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to check username availability")
+			return
+		}
+		if taken {
+			h.Renderer.Render(w, r, "auth/register.html", &renderers.TemplateData{
+				Errors: map[string]string{"Username": "Username already taken"},
+				Data:   h.formData(r, values),
+			})
+			return
+		}
+	}
+
 	// Hash password
 	hash, err := utils.HashPassword(form.Password)
 	if err != nil {
@@ -169,38 +273,103 @@ func (h *AuthHandler) RegisterPOST(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create user
-	u, err := h.Client.User.Create().
+	create := h.Client.User.Create().
 		SetEmail(form.Email).
-		SetPasswordHash(hash).
-		Save(r.Context())
+		SetPasswordHash(hash)
+	if form.Username != "" {
+		create.SetUsername(form.Username)
+	}
+	u, err := create.Save(r.Context())
+	if errors, ok := db.FormErrors(err, "general"); ok {
+		h.Renderer.Render(w, r, "auth/register.html", &renderers.TemplateData{
+			Errors: errors,
+			Data:   h.formData(r, values),
+		})
+		return
+	}
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
 
 	// Auto-login
-	h.Sessions.Put(r.Context(), "user_id", u.ID.String())
-	h.Sessions.RenewToken(r.Context())
-
-	// Handle htmx vs regular request
-	if r.Header.Get("HX-Request") == "true" {
-		w.Header().Set("HX-Redirect", "/dashboard")
-		w.WriteHeader(http.StatusOK)
-		return
-	}
+	h.startSession(w, r, u)
 
-	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+	redirect(w, r, nextURL(r, "/dashboard"))
 }
 
 // LogoutPOST handles logout
 func (h *AuthHandler) LogoutPOST(w http.ResponseWriter, r *http.Request) {
+	h.endSession(r.Context(), h.Sessions.Token(r.Context()))
 	_ = h.Sessions.Destroy(r.Context())
 
-	if r.Header.Get("HX-Request") == "true" {
-		w.Header().Set("HX-Redirect", "/")
-		w.WriteHeader(http.StatusOK)
+	redirect(w, r, nextURL(r, "/"))
+}
+
+// KeepAlive keeps a signed-in user's session from timing out while they stay
+// on one page (any request does; see static/js/session.js), and says how long
+// the session has left
+func (h *AuthHandler) KeepAlive(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetUser(r.Context()) == nil {
+		api.Error(w, http.StatusUnauthorized, "Your session has expired")
 		return
 	}
+	api.JSON(w, http.StatusOK, map[string]int{
+		"idle":      int(h.Sessions.IdleTimeout.Seconds()),
+		"remaining": int(time.Until(h.Sessions.Deadline(r.Context())).Seconds()),
+	})
+}
 
-	http.Redirect(w, r, "/", http.StatusSeeOther)
+// touchLastLogin sets u's last login to now
+func (h *AuthHandler) touchLastLogin(ctx context.Context, u *models.User) error {
+	updated, err := h.Client.User.UpdateOneID(u.ID).SetLastLogin(time.Now()).Save(ctx)
+	if err == nil {
+		*u = *updated
+	}
+	return err
+}
+
+// startSession signs u in: it renews the session token, hands over guest data,
+// records the login and signs out the oldest sessions beyond MaxSessions
+func (h *AuthHandler) startSession(w http.ResponseWriter, r *http.Request, u *models.User) {
+	if err := middleware.SignIn(r.Context(), h.Sessions, u); err != nil {
+		utils.Warnw("auth.session_renew_failed", "user_id", u.ID, "error", err)
+	}
+	h.migrateGuest(r, u)
+	h.recordLogin(w, r, u, "")
+	h.limitSessions(r.Context(), u)
+}
+
+// migrateGuest hands anything the visitor did before signing in (e.g., a cart)
+// over to their account. Failures are logged by MigrateToUser and don't block
+// the login; the unmigrated data stays in the session.
+func (h *AuthHandler) migrateGuest(r *http.Request, u *models.User) {
+	if h.Guests != nil {
+		_ = h.Guests.MigrateToUser(r.Context(), u)
+	}
+}
+
+// nextURL returns the "next" form or query value if it is a safe local path,
+// otherwise fallback. Rejected values are logged since they usually mean an
+// open-redirect attempt.
+func nextURL(r *http.Request, fallback string) string {
+	next := r.FormValue("next")
+	if next == "" {
+		return fallback
+	}
+	if !utils.IsSafeRedirect(next) {
+		utils.Warnw("auth.unsafe_redirect_rejected", "next", next, "path", r.URL.Path)
+		return fallback
+	}
+	return next
+}
+
+// redirect sends the client to url, using HX-Redirect for htmx requests
+func redirect(w http.ResponseWriter, r *http.Request, url string) {
+	if htmx.IsRequest(r) {
+		htmx.Redirect(w, url)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, url, http.StatusSeeOther)
 }
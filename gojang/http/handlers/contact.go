@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/mail"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/views/forms"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+)
+
+// ContactHandler serves the contact form. Messages are kept for the admin
+// inbox and emailed to To, with replies going to the sender.
+type ContactHandler struct {
+	Client   *models.Client
+	Renderer *renderers.Renderer
+	To       []string
+}
+
+func NewContactHandler(client *models.Client, renderer *renderers.Renderer, to []string) *ContactHandler {
+	return &ContactHandler{
+		Client:   client,
+		Renderer: renderer,
+		To:       to,
+	}
+}
+
+// ContactGET renders the contact form, filled in for signed-in users
+func (h *ContactHandler) ContactGET(w http.ResponseWriter, r *http.Request) {
+	values := map[string]interface{}{}
+	if u := middleware.GetUser(r.Context()); u != nil {
+		values["Email"] = u.Email
+		values["Name"] = u.Username
+	}
+	h.Renderer.Render(w, r, "contact.html", &renderers.TemplateData{Data: values})
+}
+
+// ContactPOST saves a message from the contact form and emails it on
+func (h *ContactHandler) ContactPOST(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	form := forms.ContactForm{
+		Name:    strings.TrimSpace(r.Form.Get("name")),
+		Email:   utils.NormalizeEmail(r.Form.Get("email")),
+		Subject: strings.TrimSpace(r.Form.Get("subject")),
+		Message: strings.TrimSpace(r.Form.Get("message")),
+	}
+	values := map[string]interface{}{"Name": form.Name, "Email": form.Email, "Subject": form.Subject, "Message": form.Message}
+
+	if errors := forms.Validate(form); len(errors) > 0 {
+		h.Renderer.Render(w, r, "contact.html", &renderers.TemplateData{Errors: errors, Data: values})
+		return
+	}
+
+	msg, err := h.Client.ContactMessage.Create().
+		SetName(form.Name).
+		SetEmail(form.Email).
+		SetSubject(form.Subject).
+		SetMessage(form.Message).
+		SetIP(clientIP(r)).
+		Save(r.Context())
+	if err != nil {
+		utils.Errorw("contact.save_failed", "error", err)
+		h.Renderer.Render(w, r, "contact.html", &renderers.TemplateData{
+			Errors: map[string]string{"general": "Your message couldn't be sent. Please try again."},
+			Data:   values,
+		})
+		return
+	}
+	utils.Infow("contact.received", "message_id", msg.ID)
+
+	// The message is already in the admin inbox, so a failed email isn't the sender's problem
+	go h.notify(context.WithoutCancel(r.Context()), msg)
+
+	values["Sent"] = true
+	h.Renderer.Render(w, r, "contact.html", &renderers.TemplateData{Data: values})
+}
+
+// notify emails msg to the configured recipients
+func (h *ContactHandler) notify(ctx context.Context, msg *models.ContactMessage) {
+	subject := msg.Subject
+	if subject == "" {
+		subject = "Message from " + msg.Name
+	}
+	err := mail.Send(ctx, &mail.Message{
+		To:      h.To,
+		ReplyTo: msg.Email,
+		Subject: "[Contact] " + subject,
+		Text:    fmt.Sprintf("From: %s <%s>\n\n%s\n", msg.Name, msg.Email, msg.Message),
+	})
+	if err != nil {
+		utils.Errorw("contact.email_failed", "message_id", msg.ID, "error", err)
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+)
+
+// DashboardWidget is one card on the user dashboard
+type DashboardWidget struct {
+	Name     string        // Unique key (e.g., "recent_posts"); also used in the fragment cache key
+	Title    string        // Card heading
+	Template string        // Partial rendering the card body (e.g., "dashboard/recent_posts.partial.html")
+	Order    int           // Lower values are shown first
+	CacheTTL time.Duration // Cache the rendered card per user; 0 renders it on every request
+
+	// Visible hides the widget from some users (nil shows it to everyone)
+	Visible func(user *models.User) bool
+	// Load returns the template's .Data (nil loads nothing)
+	Load func(ctx context.Context, user *models.User) (map[string]interface{}, error)
+}
+
+// renderedWidget is a widget ready to be placed in dashboard.html
+type renderedWidget struct {
+	Name  string
+	Title string
+	HTML  template.HTML
+	Error bool
+}
+
+// RegisterWidget adds a widget to the dashboard, replacing any widget with the same name
+func (h *PageHandler) RegisterWidget(widget DashboardWidget) {
+	for i, existing := range h.widgets {
+		if existing.Name == widget.Name {
+			h.widgets[i] = widget
+			return
+		}
+	}
+	h.widgets = append(h.widgets, widget)
+	sort.SliceStable(h.widgets, func(i, j int) bool {
+		return h.widgets[i].Order < h.widgets[j].Order
+	})
+}
+
+// UnregisterWidget removes a widget (e.g., a default one the app doesn't want)
+func (h *PageHandler) UnregisterWidget(name string) {
+	for i, existing := range h.widgets {
+		if existing.Name == name {
+			h.widgets = append(h.widgets[:i], h.widgets[i+1:]...)
+			return
+		}
+	}
+}
+
+// renderWidgets renders the widgets visible to the current user. A widget that
+// fails is shown as unavailable instead of failing the whole dashboard.
+func (h *PageHandler) renderWidgets(r *http.Request) []renderedWidget {
+	u := middleware.GetUser(r.Context())
+
+	var rendered []renderedWidget
+	for _, widget := range h.widgets {
+		if widget.Visible != nil && !widget.Visible(u) {
+			continue
+		}
+
+		widget := widget
+		load := func() (*renderers.TemplateData, error) {
+			data := &renderers.TemplateData{}
+			if widget.Load != nil {
+				values, err := widget.Load(r.Context(), u)
+				if err != nil {
+					return nil, err
+				}
+				data.Data = values
+			}
+			return data, nil
+		}
+
+		var html template.HTML
+		var err error
+		if widget.CacheTTL > 0 {
+			key := renderers.FragmentKey(r, "dashboard:"+widget.Name)
+			html, err = h.Renderer.RenderFragment(r, key, widget.CacheTTL, widget.Template, load)
+		} else {
+			var data *renderers.TemplateData
+			if data, err = load(); err == nil {
+				html, err = h.Renderer.RenderPartial(r, widget.Template, data)
+			}
+		}
+		if err != nil {
+			utils.Errorw("dashboard.widget_failed", "widget", widget.Name, "error", err)
+		}
+
+		rendered = append(rendered, renderedWidget{
+			Name:  widget.Name,
+			Title: widget.Title,
+			HTML:  html,
+			Error: err != nil,
+		})
+	}
+	return rendered
+}
+
+// defaultWidgets are the widgets every new PageHandler starts with
+func defaultWidgets(client *models.Client) []DashboardWidget {
+	return []DashboardWidget{
+		{
+			Name:     "account",
+			Title:    "Your Profile",
+			Template: "dashboard/account.partial.html",
+			Order:    10,
+		},
+		{
+			Name:     "recent_posts",
+			Title:    "Your Recent Posts",
+			Template: "dashboard/recent_posts.partial.html",
+			Order:    20,
+			Visible:  func(u *models.User) bool { return client != nil && u != nil },
+			Load: func(ctx context.Context, u *models.User) (map[string]interface{}, error) {
+				posts, err := client.Post.Query().
+					Where(post.HasAuthorWith(user.IDEQ(u.ID))).
+					Order(models.Desc(post.FieldCreatedAt)).
+					Limit(5).
+					All(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{"Posts": posts}, nil
+			},
+		},
+		{
+			Name:     "admin_links",
+			Title:    "Admin Quick Links",
+			Template: "dashboard/admin_links.partial.html",
+			Order:    30,
+			Visible:  func(u *models.User) bool { return u != nil && u.IsStaff },
+		},
+		{
+			Name:     "getting_started",
+			Title:    "Getting Started",
+			Template: "dashboard/getting_started.partial.html",
+			Order:    100,
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/jobs"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+)
+
+// JobHandler reports the progress of background jobs, as JSON for API clients
+// (/api/jobs/{id}) and as a self-refreshing partial for pages (/jobs/{id})
+type JobHandler struct {
+	Queue    *jobs.Queue
+	Renderer *renderers.Renderer
+	MaxWait  time.Duration // Longest ?wait= for long polling; keep it under the request timeout
+}
+
+func NewJobHandler(queue *jobs.Queue, renderer *renderers.Renderer) *JobHandler {
+	return &JobHandler{
+		Queue:    queue,
+		Renderer: renderer,
+		MaxWait:  5 * time.Second,
+	}
+}
+
+// Accepted answers a request that enqueued job id: API clients get a 202 with
+// the job and a Location to poll, and HTMX requests the progress partial.
+//
+//	id, err := h.Jobs.Queue.Enqueue("Export posts", user.ID, exportPosts)
+//	...
+//	h.Jobs.Accepted(w, r, id)
+func (h *JobHandler) Accepted(w http.ResponseWriter, r *http.Request, id string) {
+	job, _ := h.Queue.Get(id)
+	if htmx.IsRequest(r) {
+		h.renderStatus(w, r, job)
+		return
+	}
+	w.Header().Set("Location", "/api/jobs/"+id)
+	api.JSON(w, http.StatusAccepted, map[string]interface{}{"data": job})
+}
+
+// Status returns a job as JSON. With ?wait=N (seconds, up to MaxWait) it
+// waits for the job to change before answering, so clients can long-poll
+// instead of polling on a timer.
+func (h *JobHandler) Status(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	job, changed, ok := h.watch(r, id)
+	if !ok {
+		api.Error(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	if v := r.URL.Query().Get("wait"); v != "" && !job.Finished() {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 {
+			api.Error(w, http.StatusBadRequest, "wait must be a number of seconds")
+			return
+		}
+		timer := time.NewTimer(min(time.Duration(seconds)*time.Second, h.MaxWait))
+		defer timer.Stop()
+		select {
+		case <-changed:
+			job, _ = h.Queue.Get(id)
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	api.JSON(w, http.StatusOK, map[string]interface{}{"data": job})
+}
+
+// Poll renders a job's progress. The partial re-requests itself every two
+// seconds until the job finishes.
+func (h *JobHandler) Poll(w http.ResponseWriter, r *http.Request) {
+	job, _, ok := h.watch(r, chi.URLParam(r, "id"))
+	if !ok {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+	if htmx.IsRequest(r) {
+		h.renderStatus(w, r, job)
+		return
+	}
+
+	status, err := h.Renderer.RenderPartial(r, "jobs/status.partial.html", jobData(job))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to render the job")
+		return
+	}
+	data := jobData(job)
+	data.Data["StatusHTML"] = status
+	h.Renderer.Render(w, r, "jobs/show.html", data)
+}
+
+// watch looks up a job the current user may see. Jobs with an owner are
+// hidden from everyone else, as if they didn't exist.
+func (h *JobHandler) watch(r *http.Request, id string) (jobs.Job, <-chan struct{}, bool) {
+	job, changed, ok := h.Queue.Watch(id)
+	if !ok {
+		return jobs.Job{}, nil, false
+	}
+	if job.Owner != uuid.Nil {
+		user := middleware.GetUser(r.Context())
+		if user == nil || user.ID != job.Owner {
+			return jobs.Job{}, nil, false
+		}
+	}
+	return job, changed, true
+}
+
+func (h *JobHandler) renderStatus(w http.ResponseWriter, r *http.Request, job jobs.Job) {
+	h.Renderer.Render(w, r, "jobs/status.partial.html", jobData(job))
+}
+
+func jobData(job jobs.Job) *renderers.TemplateData {
+	return &renderers.TemplateData{
+		Title: job.Name,
+		Data: map[string]interface{}{
+			"Job": job,
+		},
+	}
+}
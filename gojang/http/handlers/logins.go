@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/mail"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// deviceCookie holds a random ID for the browser, so a login from a browser
+// the user hasn't signed in from before can be flagged
+const deviceCookie = "device_id"
+
+// deviceCookieMaxAge keeps the device ID for about two years
+const deviceCookieMaxAge = 2 * 365 * 24 * 60 * 60
+
+// Why a login attempt on an existing account failed, as shown on the security page
+const (
+	loginFailedPassword = "wrong password"
+	loginFailedInactive = "account inactive"
+)
+
+// deviceID returns the browser's device ID, or "" if it has none yet
+func deviceID(r *http.Request) string {
+	if c, err := r.Cookie(deviceCookie); err == nil {
+		if _, err := uuid.Parse(c.Value); err == nil {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// ensureDeviceID returns the browser's device ID, giving it one first if
+// needed, and refreshes the cookie's expiry
+func ensureDeviceID(w http.ResponseWriter, r *http.Request) string {
+	device := deviceID(r)
+	if device == "" {
+		device = uuid.NewString()
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     deviceCookie,
+		Value:    device,
+		Path:     "/",
+		MaxAge:   deviceCookieMaxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return device
+}
+
+// clientIP returns the request's IP without the port (RemoteAddr has already
+// been set from proxy headers by the real_ip middleware)
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// recordLogin adds a login attempt on u's account to their login history.
+// failure is "" for a successful login, which also gives the browser a device
+// ID and, when it's a device the user hasn't signed in from before, emails
+// them. Failures are logged but never block the login.
+func (h *AuthHandler) recordLogin(w http.ResponseWriter, r *http.Request, u *models.User, failure string) {
+	ctx := r.Context()
+	device := deviceID(r)
+	newDevice := false
+	if failure == "" {
+		device = ensureDeviceID(w, r)
+
+		// The first login ever isn't from a "new" device
+		var err error
+		newDevice, err = h.isNewDevice(ctx, u, device)
+		if err != nil {
+			utils.Warnw("auth.login_history_failed", "user_id", u.ID, "error", err)
+		}
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+	create := h.Client.LoginEvent.Create().
+		SetUser(u).
+		SetSuccess(failure == "").
+		SetFailure(failure).
+		SetIP(clientIP(r)).
+		SetUserAgent(userAgent).
+		SetDevice(device).
+		SetNewDevice(newDevice)
+	if failure == "" && h.MaxSessions > 0 {
+		create.SetSession(h.Sessions.Token(ctx)) // For limitSessions
+	}
+	event, err := create.Save(ctx)
+	if err != nil {
+		utils.Warnw("auth.login_history_failed", "user_id", u.ID, "error", err)
+		return
+	}
+	if newDevice {
+		go h.alertNewDevice(context.WithoutCancel(ctx), u, event)
+	}
+}
+
+// isNewDevice reports whether u has signed in before, but never from device
+func (h *AuthHandler) isNewDevice(ctx context.Context, u *models.User, device string) (bool, error) {
+	logins := h.Client.LoginEvent.Query().Where(loginevent.HasUserWith(user.ID(u.ID)), loginevent.Success(true))
+	before, err := logins.Clone().Exist(ctx)
+	if err != nil || !before {
+		return false, err
+	}
+	seen, err := logins.Where(loginevent.Device(device)).Exist(ctx)
+	return !seen, err
+}
+
+// limitSessions signs u out of their oldest sessions so they have at most
+// MaxSessions, counting the request's. Logins whose sessions have already
+// ended (signed out or expired) stop being tracked.
+func (h *AuthHandler) limitSessions(ctx context.Context, u *models.User) {
+	if h.MaxSessions <= 0 {
+		return
+	}
+	logins, err := h.Client.LoginEvent.Query().
+		Where(loginevent.HasUserWith(user.ID(u.ID)), loginevent.SessionNEQ("")).
+		Order(models.Desc(loginevent.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		utils.Warnw("auth.session_limit_failed", "user_id", u.ID, "error", err)
+		return
+	}
+
+	current := h.Sessions.Token(ctx)
+	active := 0
+	for _, login := range logins {
+		// The request's session isn't saved to the store until it's over
+		if login.Session != current {
+			if _, found, err := h.Sessions.Store.Find(login.Session); err != nil {
+				utils.Warnw("auth.session_limit_failed", "user_id", u.ID, "error", err)
+				continue
+			} else if !found {
+				h.endSession(ctx, login.Session)
+				continue
+			}
+		}
+
+		active++
+		if active <= h.MaxSessions {
+			continue
+		}
+		if err := h.Sessions.Store.Delete(login.Session); err != nil {
+			utils.Warnw("auth.session_limit_failed", "user_id", u.ID, "error", err)
+			continue
+		}
+		h.endSession(ctx, login.Session)
+		utils.Infow("auth.session_evicted", "user_id", u.ID, "login_id", login.ID, "limit", h.MaxSessions)
+	}
+}
+
+// endSession stops tracking the login whose session has token, if any
+func (h *AuthHandler) endSession(ctx context.Context, token string) {
+	if token == "" {
+		return
+	}
+	if err := h.Client.LoginEvent.Update().Where(loginevent.Session(token)).ClearSession().Exec(ctx); err != nil {
+		utils.Warnw("auth.session_end_failed", "error", err)
+	}
+}
+
+// alertNewDevice emails u about a login from a new device
+func (h *AuthHandler) alertNewDevice(ctx context.Context, u *models.User, event *models.LoginEvent) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	err := mail.Send(ctx, &mail.Message{
+		To:      []string{u.Email},
+		Subject: "New sign-in to your account",
+		Text: fmt.Sprintf("Your account was signed in to from a new device.\n\n"+
+			"Time: %s\nIP address: %s\nBrowser: %s\n\n"+
+			"If this was you, there's nothing to do. If not, change your password now "+
+			"and review your recent sign-ins under Account > Security.\n",
+			event.CreatedAt.UTC().Format("Jan 2, 2006 15:04 MST"), event.IP, event.UserAgent),
+	})
+	if err != nil {
+		utils.Warnw("auth.new_device_alert_failed", "user_id", u.ID, "error", err)
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/mail"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/utils/signer"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+	"github.com/google/uuid"
+)
+
+// loginLinkPath is where emailed sign-in links point; GET asks the user to
+// confirm and POST signs them in
+const loginLinkPath = "/login/link/confirm"
+
+var errLoginLinkUsed = errors.New("sign-in link already used")
+
+// passwordless reports whether passwords are turned off in favor of sign-in links
+func (h *AuthHandler) passwordless() bool {
+	return h.MagicLink == config.MagicLinkOnly
+}
+
+// LoginLinkPOST emails a sign-in link to the account named in the form. The
+// page is the same whether or not the account exists, so it can't be used to
+// find out who has one.
+func (h *AuthHandler) LoginLinkPOST(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+	login := r.Form.Get("login")
+	if login == "" {
+		h.Renderer.Render(w, r, "auth/login.html", &renderers.TemplateData{
+			Errors: map[string]string{"Login": "This field is required"},
+			Data:   h.formData(r, nil),
+		})
+		return
+	}
+
+	// The link remembers the browser it was requested from
+	device := ensureDeviceID(w, r)
+	u, err := h.findUser(r.Context(), login)
+	switch {
+	case err == nil && u.IsActive:
+		link := h.SiteURL + h.loginLink(u, device, nextURL(r, ""))
+		go h.sendLoginLink(context.WithoutCancel(r.Context()), u, link)
+	case err != nil && !models.IsNotFound(err):
+		utils.Errorw("auth.login_link_failed", "error", err)
+	}
+
+	h.Renderer.Render(w, r, "auth/login_link_sent.html", &renderers.TemplateData{
+		Data: map[string]interface{}{"Login": login, "Expires": expiresIn(h.MagicLinkTTL)},
+	})
+}
+
+// LoginLinkGET asks the user to confirm signing in with an emailed link.
+// Following the link doesn't sign in by itself, so mail scanners that open
+// links don't use it up, and a link opened in another browser is pointed out.
+func (h *AuthHandler) LoginLinkGET(w http.ResponseWriter, r *http.Request) {
+	u, err := h.checkLoginLink(r)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusForbidden, "This sign-in link is invalid, has expired or was already used. Request a new one from the login page.")
+		return
+	}
+	h.Renderer.Render(w, r, "auth/login_link.html", &renderers.TemplateData{
+		Data: map[string]interface{}{
+			"Email":       u.Email,
+			"Action":      r.URL.RequestURI(),
+			"OtherDevice": r.URL.Query().Get("device") != deviceHash(deviceID(r)),
+		},
+	})
+}
+
+// LoginLinkConfirmPOST signs the user in with an emailed link
+func (h *AuthHandler) LoginLinkConfirmPOST(w http.ResponseWriter, r *http.Request) {
+	u, err := h.checkLoginLink(r)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusForbidden, "This sign-in link is invalid, has expired or was already used. Request a new one from the login page.")
+		return
+	}
+	// Updating the last login is what makes the link single use
+	if err := h.consumeLoginLink(r.Context(), u); errors.Is(err, errLoginLinkUsed) {
+		utils.Warnw("auth.login_link_replayed", "user_id", u.ID)
+		h.Renderer.RenderError(w, r, http.StatusForbidden, "This sign-in link is invalid, has expired or was already used. Request a new one from the login page.")
+		return
+	} else if err != nil {
+		utils.Errorw("auth.login_link_failed", "user_id", u.ID, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to sign in")
+		return
+	}
+	h.startSession(w, r, u)
+	utils.Infow("auth.login_link_used", "user_id", u.ID)
+	redirect(w, r, nextURL(r, "/dashboard"))
+}
+
+// loginLink returns a signed, expiring sign-in link for u, relative to the site.
+// It holds u's last login time, which signing in changes, so it works once.
+func (h *AuthHandler) loginLink(u *models.User, device, next string) string {
+	q := url.Values{
+		"user":   {u.ID.String()},
+		"since":  {lastLoginStamp(u)},
+		"device": {deviceHash(device)},
+	}
+	if next != "" {
+		q.Set("next", next)
+	}
+	return signer.Sign(loginLinkPath+"?"+q.Encode(), h.MagicLinkTTL)
+}
+
+// checkLoginLink returns the user a sign-in link in r's URL is for, if it is
+// correctly signed, unexpired and unused, and the user can still sign in
+func (h *AuthHandler) checkLoginLink(r *http.Request) (*models.User, error) {
+	if err := signer.Verify(r.URL); err != nil {
+		return nil, err
+	}
+	q := r.URL.Query()
+	id, err := uuid.Parse(q.Get("user"))
+	if err != nil {
+		return nil, err
+	}
+	u, err := h.Client.User.Get(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsActive || q.Get("since") != lastLoginStamp(u) {
+		return nil, errLoginLinkUsed
+	}
+	return u, nil
+}
+
+// consumeLoginLink uses up the link checkLoginLink returned u for by moving
+// their last login on. The update only applies while the last login is still
+// the one the link holds, so when requests race with one link only the first
+// signs in; the others get errLoginLinkUsed.
+func (h *AuthHandler) consumeLoginLink(ctx context.Context, u *models.User) error {
+	now := time.Now()
+	update := h.Client.User.Update().Where(user.ID(u.ID))
+	if u.LastLogin == nil {
+		update.Where(user.LastLoginIsNil())
+	} else {
+		update.Where(user.LastLoginEQ(*u.LastLogin))
+	}
+	n, err := update.SetLastLogin(now).Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errLoginLinkUsed
+	}
+	u.LastLogin = &now
+	return nil
+}
+
+// sendLoginLink emails u their sign-in link
+func (h *AuthHandler) sendLoginLink(ctx context.Context, u *models.User, link string) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	err := mail.Send(ctx, &mail.Message{
+		To:      []string{u.Email},
+		Subject: "Your sign-in link",
+		Text: fmt.Sprintf("Open this link to sign in. It works once and expires in %s.\n\n%s\n\n"+
+			"If you didn't ask to sign in, you can ignore this email; nobody can use the link without access to your inbox.\n",
+			expiresIn(h.MagicLinkTTL), link),
+	})
+	if err != nil {
+		utils.Warnw("auth.login_link_failed", "user_id", u.ID, "error", err)
+	}
+}
+
+// lastLoginStamp returns u's last login time as a string for sign-in links
+func lastLoginStamp(u *models.User) string {
+	if u.LastLogin == nil {
+		return "0"
+	}
+	return strconv.FormatInt(u.LastLogin.UnixNano(), 10)
+}
+
+// deviceHash returns a short digest of a device ID, so links don't carry the
+// cookie's value
+func deviceHash(device string) string {
+	if device == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(device))
+	return hex.EncodeToString(sum[:8])
+}
+
+// expiresIn describes a link lifetime for emails, e.g. "15 minutes"
+func expiresIn(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		if d == time.Hour {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	}
+	if d <= time.Minute {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", d/time.Minute)
+}
+
+// unusablePassword returns a random password that passes the complexity
+// rules, for accounts registered while passwords are turned off
+func unusablePassword() string {
+	return "Aa-" + rand.Text()
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// newLinkHandler returns an AuthHandler with sign-in links and a user to send them to
+func newLinkHandler(t *testing.T) (*AuthHandler, *models.User) {
+	t.Helper()
+	client := testdb.Open(t)
+	u := client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").SetIsActive(true).SaveX(t.Context())
+	return &AuthHandler{Client: client, MagicLinkTTL: time.Hour}, u
+}
+
+// TestLoginLink_Replay tests that a link stops working once it signed in
+func TestLoginLink_Replay(t *testing.T) {
+	h, u := newLinkHandler(t)
+	link := h.loginLink(u, "device", "")
+
+	for _, previous := range []bool{false, true} {
+		// Give the link a last login to hold, as well as none
+		if previous {
+			h.Client.User.UpdateOneID(u.ID).SetLastLogin(time.Now().Add(-time.Hour)).ExecX(t.Context())
+			link = h.loginLink(h.Client.User.GetX(t.Context(), u.ID), "device", "")
+		}
+		req := httptest.NewRequest(http.MethodPost, link, nil)
+		got, err := h.checkLoginLink(req)
+		if err != nil {
+			t.Fatalf("Expected a fresh link to be accepted, got %v", err)
+		}
+		if err := h.consumeLoginLink(t.Context(), got); err != nil {
+			t.Fatalf("Expected the link to sign in, got %v", err)
+		}
+
+		if _, err := h.checkLoginLink(httptest.NewRequest(http.MethodPost, link, nil)); !errors.Is(err, errLoginLinkUsed) {
+			t.Errorf("Expected a used link to be rejected, got %v", err)
+		}
+	}
+}
+
+// TestLoginLink_ConcurrentConfirms tests that of several requests confirming
+// the same link at once, only one signs in
+func TestLoginLink_ConcurrentConfirms(t *testing.T) {
+	h, u := newLinkHandler(t)
+	link := h.loginLink(u, "device", "")
+
+	// Every request passes the check before any consumes the link
+	const requests = 5
+	users := make([]*models.User, requests)
+	for i := range users {
+		got, err := h.checkLoginLink(httptest.NewRequest(http.MethodPost, link, nil))
+		if err != nil {
+			t.Fatalf("Expected the link to be accepted, got %v", err)
+		}
+		users[i] = got
+	}
+
+	errs := make([]error, requests)
+	var wg sync.WaitGroup
+	for i, got := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = h.consumeLoginLink(t.Context(), got)
+		}()
+	}
+	wg.Wait()
+
+	signedIn := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			signedIn++
+		case !errors.Is(err, errLoginLinkUsed):
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if signedIn != 1 {
+		t.Errorf("Expected exactly one request to sign in, got %d", signedIn)
+	}
+}
@@ -3,17 +3,26 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/gojangframework/gojang/gojang/models"
 	"github.com/gojangframework/gojang/gojang/views/renderers"
 )
 
 type PageHandler struct {
+	Client   *models.Client
 	Renderer *renderers.Renderer
+
+	widgets []DashboardWidget // Dashboard cards (see RegisterWidget)
 }
 
-func NewPageHandler(renderer *renderers.Renderer) *PageHandler {
-	return &PageHandler{
+func NewPageHandler(client *models.Client, renderer *renderers.Renderer) *PageHandler {
+	h := &PageHandler{
+		Client:   client,
 		Renderer: renderer,
 	}
+	for _, widget := range defaultWidgets(client) {
+		h.RegisterWidget(widget)
+	}
+	return h
 }
 
 // Home renders the home page
@@ -21,10 +30,32 @@ func (h *PageHandler) Home(w http.ResponseWriter, r *http.Request) {
 	h.Renderer.Render(w, r, "home.html", nil)
 }
 
-// Dashboard renders the user dashboard
+// Dashboard renders the user dashboard from the registered widgets
 func (h *PageHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 	h.Renderer.Render(w, r, "dashboard.html", &renderers.TemplateData{
 		Title: "Dashboard",
+		Data: map[string]interface{}{
+			"Widgets": h.renderWidgets(r),
+		},
+	})
+}
+
+// maxMarkdownPreviewBytes limits the form submitted to MarkdownPreview
+const maxMarkdownPreviewBytes = 1 << 20
+
+// MarkdownPreview renders a Markdown field for the live preview pane in forms.
+// The field to render is named by ?field= and read from the posted form.
+func (h *PageHandler) MarkdownPreview(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxMarkdownPreviewBytes)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	h.Renderer.Render(w, r, "markdown/preview.partial.html", &renderers.TemplateData{
+		Data: map[string]interface{}{
+			"Source": r.PostForm.Get(r.URL.Query().Get("field")),
+		},
 	})
 }
 
@@ -35,8 +66,22 @@ func (h *PageHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 
 // NotFound renders the 404 page
 func (h *PageHandler) NotFound(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotFound)
-	h.Renderer.Render(w, r, "404.html", &renderers.TemplateData{
+	h.Renderer.RenderStatus(w, r, http.StatusNotFound, "404.html", &renderers.TemplateData{
 		Title: "404 Not Found",
 	})
 }
+
+// Timeout renders the 503 page shown when a request exceeds its deadline
+func (h *PageHandler) Timeout(w http.ResponseWriter, r *http.Request) {
+	h.Renderer.RenderError(w, r, http.StatusServiceUnavailable, "The server took too long to respond. Please try again.")
+}
+
+// Forbidden is shown to signed-in users an authz policy turns away
+func (h *PageHandler) Forbidden(w http.ResponseWriter, r *http.Request) {
+	h.Renderer.RenderError(w, r, http.StatusForbidden, "You don't have permission to view this page.")
+}
+
+// SpamRejected is shown when middleware.SpamTrap turns down a form submission
+func (h *PageHandler) SpamRejected(w http.ResponseWriter, r *http.Request) {
+	h.Renderer.RenderError(w, r, http.StatusBadRequest, "Your submission could not be accepted. Please go back, wait a moment and try again.")
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/passkey"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/webauthn"
+	"github.com/google/uuid"
+)
+
+// Session keys holding the challenge of a passkey ceremony in progress
+const (
+	passkeyRegisterChallenge = "passkey.register_challenge"
+	passkeyLoginChallenge    = "passkey.login_challenge"
+)
+
+// maxPasskeyBody limits the JSON a browser sends back from a ceremony
+const maxPasskeyBody = 64 << 10
+
+// PasskeyOptions starts adding a passkey to the signed-in user's account and
+// returns the options for navigator.credentials.create
+func (h *AccountHandler) PasskeyOptions(w http.ResponseWriter, r *http.Request) {
+	u := middleware.GetUser(r.Context())
+	existing, err := h.Client.Passkey.Query().
+		Where(passkey.HasUserWith(user.ID(u.ID))).
+		All(r.Context())
+	if err != nil {
+		utils.Errorw("account.passkeys_failed", "user_id", u.ID, "error", err)
+		api.Error(w, http.StatusInternalServerError, "Failed to load your passkeys")
+		return
+	}
+	var exclude [][]byte
+	for _, pk := range existing {
+		exclude = append(exclude, pk.CredentialID)
+	}
+
+	challenge := webauthn.NewChallenge()
+	h.Sessions.Put(r.Context(), passkeyRegisterChallenge, challenge)
+	api.JSON(w, http.StatusOK, map[string]interface{}{
+		"publicKey": h.Passkeys.CreationOptions(challenge, u.ID[:], u.Email, u.Email, exclude),
+	})
+}
+
+// PasskeyCreate verifies and stores the credential the browser created
+func (h *AccountHandler) PasskeyCreate(w http.ResponseWriter, r *http.Request) {
+	u := middleware.GetUser(r.Context())
+	var body struct {
+		Name       string                    `json:"name"`
+		Credential webauthn.CreationResponse `json:"credential"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPasskeyBody)).Decode(&body); err != nil {
+		api.Error(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	// Each challenge is good for one attempt
+	challenge := h.Sessions.PopBytes(r.Context(), passkeyRegisterChallenge)
+	cred, err := h.Passkeys.VerifyRegistration(challenge, &body.Credential)
+	if err != nil {
+		utils.Warnw("account.passkey_rejected", "user_id", u.ID, "error", err)
+		api.Error(w, http.StatusBadRequest, "The passkey couldn't be verified. Please try again.")
+		return
+	}
+
+	name := strings.TrimSpace(body.Name)
+	if name == "" {
+		name = "Passkey"
+	}
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	_, err = h.Client.Passkey.Create().
+		SetUser(u).
+		SetCredentialID(cred.ID).
+		SetPublicKey(cred.PublicKey).
+		SetSignCount(cred.SignCount).
+		SetName(name).
+		Save(r.Context())
+	if models.IsConstraintError(err) {
+		api.Error(w, http.StatusConflict, "This passkey is already registered")
+		return
+	}
+	if err != nil {
+		utils.Errorw("account.passkey_save_failed", "user_id", u.ID, "error", err)
+		api.Error(w, http.StatusInternalServerError, "Failed to save the passkey")
+		return
+	}
+
+	utils.Infow("account.passkey_added", "user_id", u.ID, "name", name)
+	api.JSON(w, http.StatusCreated, map[string]string{"redirect": "/account/security"})
+}
+
+// PasskeyDelete removes one of the signed-in user's passkeys
+func (h *AccountHandler) PasskeyDelete(w http.ResponseWriter, r *http.Request) {
+	u := middleware.GetUser(r.Context())
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Passkey not found")
+		return
+	}
+	n, err := h.Client.Passkey.Delete().
+		Where(passkey.ID(id), passkey.HasUserWith(user.ID(u.ID))).
+		Exec(r.Context())
+	if err != nil {
+		utils.Errorw("account.passkey_delete_failed", "user_id", u.ID, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to remove the passkey")
+		return
+	}
+	if n == 0 {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Passkey not found")
+		return
+	}
+
+	utils.Infow("account.passkey_removed", "user_id", u.ID, "passkey_id", id)
+	redirect(w, r, "/account/security")
+}
+
+// PasskeyLoginOptions starts a passkey sign-in and returns the options for
+// navigator.credentials.get
+func (h *AuthHandler) PasskeyLoginOptions(w http.ResponseWriter, r *http.Request) {
+	challenge := webauthn.NewChallenge()
+	h.Sessions.Put(r.Context(), passkeyLoginChallenge, challenge)
+	api.JSON(w, http.StatusOK, map[string]interface{}{
+		"publicKey": h.Passkeys.RequestOptions(challenge),
+	})
+}
+
+// PasskeyLoginPOST signs in with the passkey the browser returned, and
+// answers with where to go next
+func (h *AuthHandler) PasskeyLoginPOST(w http.ResponseWriter, r *http.Request) {
+	const failed = "That passkey couldn't be used to sign in"
+	var res webauthn.AssertionResponse
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPasskeyBody)).Decode(&res); err != nil {
+		api.Error(w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	challenge := h.Sessions.PopBytes(r.Context(), passkeyLoginChallenge)
+
+	pk, err := h.Client.Passkey.Query().
+		Where(passkey.CredentialID(res.RawID)).
+		WithUser().
+		Only(r.Context())
+	if err != nil {
+		if !models.IsNotFound(err) {
+			utils.Errorw("auth.passkey_login_failed", "error", err)
+		}
+		api.Error(w, http.StatusUnauthorized, failed)
+		return
+	}
+	u := pk.Edges.User
+
+	// The user handle, when sent, is the ID the passkey was created for
+	if len(res.Response.UserHandle) > 0 && string(res.Response.UserHandle) != string(u.ID[:]) {
+		api.Error(w, http.StatusUnauthorized, failed)
+		return
+	}
+	count, err := h.Passkeys.VerifyLogin(challenge, &res, &webauthn.Credential{
+		ID:        pk.CredentialID,
+		PublicKey: pk.PublicKey,
+		SignCount: pk.SignCount,
+	})
+	if err != nil {
+		utils.Warnw("auth.passkey_rejected", "user_id", u.ID, "passkey_id", pk.ID, "error", err)
+		api.Error(w, http.StatusUnauthorized, failed)
+		return
+	}
+	if !u.IsActive {
+		h.recordLogin(w, r, u, loginFailedInactive)
+		api.Error(w, http.StatusForbidden, "Your account is inactive")
+		return
+	}
+
+	if err := pk.Update().SetSignCount(count).SetLastUsedAt(time.Now()).Exec(r.Context()); err != nil {
+		utils.Warnw("auth.passkey_update_failed", "passkey_id", pk.ID, "error", err)
+	}
+	if err := h.touchLastLogin(r.Context(), u); err != nil {
+		utils.Warnw("user.update_last_login_failed", "user_id", u.ID, "error", err)
+	}
+	h.startSession(w, r, u)
+	api.JSON(w, http.StatusOK, map[string]string{"redirect": nextURL(r, "/dashboard")})
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/polls"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+)
+
+// PollHandler shows polls and takes votes. Each poll is also a partial for
+// embedding in other pages, loaded with htmx:
+//
+//	<div hx-get="/polls/{id}" hx-trigger="load" hx-swap="outerHTML"></div>
+type PollHandler struct {
+	Client   *models.Client
+	Guests   *middleware.GuestSessions // Guests vote under their guest ID
+	Renderer *renderers.Renderer
+}
+
+func NewPollHandler(client *models.Client, guests *middleware.GuestSessions, renderer *renderers.Renderer) *PollHandler {
+	return &PollHandler{
+		Client:   client,
+		Guests:   guests,
+		Renderer: renderer,
+	}
+}
+
+// Show renders a poll: its options until the visitor votes, its results after
+func (h *PollHandler) Show(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.load(w, r)
+	if !ok {
+		return
+	}
+	h.render(w, r, p, "")
+}
+
+// Vote records the visitor's vote and shows the results
+func (h *PollHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.load(w, r)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	option, err := strconv.Atoi(r.Form.Get("option"))
+	if err != nil {
+		option = -1
+	}
+	voter := h.voter(r, true)
+	err = polls.Cast(r.Context(), h.Client, p, voter, option)
+	switch {
+	case errors.Is(err, polls.ErrInvalidOption):
+		h.render(w, r, p, "Please pick one of the options")
+		return
+	case errors.Is(err, polls.ErrAlreadyVoted), errors.Is(err, polls.ErrClosed):
+		// The results are shown either way
+	case err != nil:
+		utils.Errorw("polls.vote_failed", "poll_id", p.ID, "error", err)
+		h.render(w, r, p, "Your vote couldn't be saved. Please try again.")
+		return
+	default:
+		utils.Infow("polls.voted", "poll_id", p.ID, "option", option)
+	}
+
+	if !htmx.IsRequest(r) {
+		http.Redirect(w, r, "/polls/"+p.ID.String(), http.StatusSeeOther)
+		return
+	}
+	h.render(w, r, p, "")
+}
+
+// voter returns the key r's votes count under: the signed-in user, or else the
+// guest's ID (see GuestSessions.ID), which create gives them when they have none
+func (h *PollHandler) voter(r *http.Request, create bool) string {
+	ctx := r.Context()
+	if u := middleware.GetUser(ctx); u != nil {
+		return polls.Voter(u, "")
+	}
+	if !create && !h.Guests.HasID(ctx) {
+		return polls.Voter(nil, "")
+	}
+	return polls.Voter(nil, h.Guests.ID(ctx))
+}
+
+// load returns the poll named in the URL, rendering a 404 when there's none
+func (h *PollHandler) load(w http.ResponseWriter, r *http.Request) (*models.Poll, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Poll not found")
+		return nil, false
+	}
+	p, err := h.Client.Poll.Get(r.Context(), id)
+	if models.IsNotFound(err) {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Poll not found")
+		return nil, false
+	}
+	if err != nil {
+		utils.Errorw("polls.load_failed", "poll_id", id, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load the poll")
+		return nil, false
+	}
+	return p, true
+}
+
+// render shows p as a page, or as the embeddable partial to htmx requests
+func (h *PollHandler) render(w http.ResponseWriter, r *http.Request, p *models.Poll, formError string) {
+	voter := h.voter(r, false)
+	choice, err := polls.Choice(r.Context(), h.Client, p, voter)
+	if err != nil {
+		utils.Errorw("polls.load_failed", "poll_id", p.ID, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load the poll")
+		return
+	}
+	results, err := polls.Tally(r.Context(), h.Client, p)
+	if err != nil {
+		utils.Errorw("polls.tally_failed", "poll_id", p.ID, "error", err)
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load the poll")
+		return
+	}
+
+	name := "polls/show.html"
+	if htmx.IsRequest(r) {
+		name = "polls/poll.partial.html"
+	}
+	h.Renderer.Render(w, r, name, &renderers.TemplateData{
+		Data: map[string]interface{}{
+			"Poll":    p,
+			"Open":    polls.Open(p, time.Now()),
+			"Choice":  choice,
+			"Results": results,
+			"Error":   formError,
+		},
+	})
+}
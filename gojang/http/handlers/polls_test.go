@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+)
+
+// TestPollVote_GuestsBySession tests that a guest's votes are counted by
+// session, so changing proxy headers doesn't give them another vote
+func TestPollVote_GuestsBySession(t *testing.T) {
+	client := testdb.Open(t)
+	p := client.Poll.Create().SetQuestion("Tabs or spaces?").SetOptions([]string{"Tabs", "Spaces"}).SaveX(t.Context())
+	sm := scs.New()
+	h := NewPollHandler(client, middleware.NewGuestSessions(sm), nil)
+
+	// As mounted by the app, behind the real_ip middleware
+	router := chi.NewRouter()
+	router.Use(chimiddleware.RealIP, sm.LoadAndSave)
+	router.Post("/polls/{id}/vote", h.Vote)
+
+	vote := func(forwardedFor string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/polls/"+p.ID.String()+"/vote", strings.NewReader(url.Values{"option": {"1"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("Expected a redirect to the results, got %d", rec.Code)
+		}
+		return rec
+	}
+
+	cookies := vote("198.51.100.1", nil).Result().Cookies()
+	vote("198.51.100.2", cookies)
+	if n := p.QueryVotes().CountX(t.Context()); n != 1 {
+		t.Errorf("Expected one vote from one session whatever its X-Forwarded-For, got %d", n)
+	}
+
+	// Another browser is another guest
+	vote("198.51.100.2", nil)
+	if n := p.QueryVotes().CountX(t.Context()); n != 2 {
+		t.Errorf("Expected a second session to vote too, got %d votes", n)
+	}
+}
@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/gojangframework/gojang/gojang/htmx"
 	"github.com/gojangframework/gojang/gojang/http/middleware"
 	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/db"
 	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/gojangframework/gojang/gojang/views/forms"
 	"github.com/gojangframework/gojang/gojang/views/renderers"
 )
@@ -25,12 +32,28 @@ func NewPostHandler(client *models.Client, renderer *renderers.Renderer) *PostHa
 	}
 }
 
+// postsListCacheTTL controls how long the rendered posts list stays in the fragment cache
+const postsListCacheTTL = 30 * time.Second
+
 // Index lists all posts
 func (h *PostHandler) Index(w http.ResponseWriter, r *http.Request) {
-	posts, err := h.Client.Post.Query().
-		WithAuthor().
-		Order(models.Desc(post.FieldCreatedAt)).
-		All(r.Context())
+	// The list is cached per viewer; the query only runs on a cache miss
+	list, err := h.Renderer.RenderFragment(r, renderers.FragmentKey(r, "posts:list"), postsListCacheTTL, "posts/list.partial.html",
+		func() (*renderers.TemplateData, error) {
+			posts, err := h.Client.Post.Query().
+				Where(visiblePosts(r)).
+				WithAuthor().
+				Order(models.Desc(post.FieldCreatedAt)).
+				All(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			return &renderers.TemplateData{
+				Data: map[string]interface{}{
+					"Posts": posts,
+				},
+			}, nil
+		})
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load posts")
 		return
@@ -40,7 +63,7 @@ func (h *PostHandler) Index(w http.ResponseWriter, r *http.Request) {
 	h.Renderer.Render(w, r, "posts/index.html", &renderers.TemplateData{
 		Title: "Posts",
 		Data: map[string]interface{}{
-			"Posts": posts,
+			"PostsList": list,
 		},
 	})
 }
@@ -48,7 +71,7 @@ func (h *PostHandler) Index(w http.ResponseWriter, r *http.Request) {
 // New shows the create post form
 func (h *PostHandler) New(w http.ResponseWriter, r *http.Request) {
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/posts", http.StatusSeeOther)
 		return
 	}
@@ -64,8 +87,9 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form := forms.PostForm{
-		Subject: r.Form.Get("subject"),
-		Body:    r.Form.Get("body"),
+		Subject:   r.Form.Get("subject"),
+		Body:      r.Form.Get("body"),
+		PublishAt: r.Form.Get("publish_at"),
 	}
 
 	// Validate
@@ -88,18 +112,27 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 	_, err := h.Client.Post.Create().
 		SetSubject(form.Subject).
 		SetBody(form.Body).
+		SetNillablePublishAt(forms.OptionalTime(form.PublishAt)).
 		SetAuthor(user).
 		Save(r.Context())
+	if errors, ok := db.FormErrors(err, "general"); ok {
+		h.Renderer.Render(w, r, "posts/new.partial.html", &renderers.TemplateData{
+			Errors: errors,
+		})
+		return
+	}
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to create post")
 		return
 	}
+	h.Renderer.InvalidateFragments("posts:list")
 
 	// Close modal and return updated posts list
-	w.Header().Set("HX-Trigger", "closeModal")
+	htmx.Trigger(w, "closeModal")
 
 	// Query all posts to return updated list
 	posts, err := h.Client.Post.Query().
+		Where(visiblePosts(r)).
 		WithAuthor().
 		Order(models.Desc(post.FieldCreatedAt)).
 		All(r.Context())
@@ -109,8 +142,8 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the updated posts list (user site only)
-	w.Header().Set("HX-Retarget", "#posts-list")
-	w.Header().Set("HX-Reswap", "innerHTML")
+	htmx.Retarget(w, "#posts-list")
+	htmx.Reswap(w, "innerHTML")
 	h.Renderer.Render(w, r, "posts/list.partial.html", &renderers.TemplateData{
 		Data: map[string]interface{}{
 			"Posts": posts,
@@ -121,7 +154,7 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 // Edit shows the edit post form
 func (h *PostHandler) Edit(w http.ResponseWriter, r *http.Request) {
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/posts", http.StatusSeeOther)
 		return
 	}
@@ -156,7 +189,8 @@ func (h *PostHandler) Edit(w http.ResponseWriter, r *http.Request) {
 
 	h.Renderer.Render(w, r, "posts/edit.partial.html", &renderers.TemplateData{
 		Data: map[string]interface{}{
-			"Post": p,
+			"Post":      p,
+			"PublishAt": publishAtValue(p),
 		},
 	})
 }
@@ -176,8 +210,9 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form := forms.PostForm{
-		Subject: r.Form.Get("subject"),
-		Body:    r.Form.Get("body"),
+		Subject:   r.Form.Get("subject"),
+		Body:      r.Form.Get("body"),
+		PublishAt: r.Form.Get("publish_at"),
 	}
 
 	// Validate
@@ -191,6 +226,7 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 					"Subject": form.Subject,
 					"Body":    form.Body,
 				},
+				"PublishAt": form.PublishAt,
 			},
 		})
 		return
@@ -215,20 +251,41 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update post
-	_, err = h.Client.Post.UpdateOneID(id).
+	update := h.Client.Post.UpdateOneID(id).
 		SetSubject(form.Subject).
-		SetBody(form.Body).
-		Save(r.Context())
+		SetBody(form.Body)
+	if publishAt := forms.OptionalTime(form.PublishAt); publishAt != nil {
+		update.SetPublishAt(*publishAt)
+	} else {
+		update.ClearPublishAt()
+	}
+	_, err = update.Save(r.Context())
+	if errors, ok := db.FormErrors(err, "general"); ok {
+		h.Renderer.Render(w, r, "posts/edit.partial.html", &renderers.TemplateData{
+			Errors: errors,
+			Data: map[string]interface{}{
+				"Post": map[string]interface{}{
+					"ID":      id,
+					"Subject": form.Subject,
+					"Body":    form.Body,
+				},
+				"PublishAt": form.PublishAt,
+			},
+		})
+		return
+	}
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to update post")
 		return
 	}
+	h.Renderer.InvalidateFragments("posts:list")
 
 	// Close modal and return updated posts list (user site only)
-	w.Header().Set("HX-Trigger", "closeModal")
+	htmx.Trigger(w, "closeModal")
 
 	// Query all posts to return updated list
 	posts, err := h.Client.Post.Query().
+		Where(visiblePosts(r)).
 		WithAuthor().
 		Order(models.Desc(post.FieldCreatedAt)).
 		All(r.Context())
@@ -238,8 +295,8 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the updated posts list
-	w.Header().Set("HX-Retarget", "#posts-list")
-	w.Header().Set("HX-Reswap", "innerHTML")
+	htmx.Retarget(w, "#posts-list")
+	htmx.Reswap(w, "innerHTML")
 	h.Renderer.Render(w, r, "posts/list.partial.html", &renderers.TemplateData{
 		Data: map[string]interface{}{
 			"Posts": posts,
@@ -250,7 +307,7 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 // DeleteConfirm shows the delete confirmation modal
 func (h *PostHandler) DeleteConfirm(w http.ResponseWriter, r *http.Request) {
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/posts", http.StatusSeeOther)
 		return
 	}
@@ -322,12 +379,101 @@ func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to delete post")
 		return
 	}
+	h.Renderer.InvalidateFragments("posts:list")
 
 	// Close modal and return updated posts list (user site only)
-	w.Header().Set("HX-Trigger", "closeModal")
+	htmx.Trigger(w, "closeModal")
+
+	// Query all posts to return updated list
+	posts, err := h.Client.Post.Query().
+		Where(visiblePosts(r)).
+		WithAuthor().
+		Order(models.Desc(post.FieldCreatedAt)).
+		All(r.Context())
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to load posts")
+		return
+	}
+
+	// Return the updated posts list
+	htmx.Retarget(w, "#posts-list")
+	htmx.Reswap(w, "innerHTML")
+	h.Renderer.Render(w, r, "posts/list.partial.html", &renderers.TemplateData{
+		Data: map[string]interface{}{
+			"Posts": posts,
+		},
+	})
+}
+
+// Schedule shows the reschedule form for a post
+func (h *PostHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	// Prevent direct access - modal forms must be loaded via HTMX
+	if !htmx.IsRequest(r) {
+		http.Redirect(w, r, "/posts", http.StatusSeeOther)
+		return
+	}
+
+	p, ok := h.ownPost(w, r)
+	if !ok {
+		return
+	}
+
+	h.Renderer.Render(w, r, "posts/schedule.partial.html", &renderers.TemplateData{
+		Data: map[string]interface{}{
+			"Post":      p,
+			"PublishAt": publishAtValue(p),
+		},
+	})
+}
+
+// Reschedule moves a post's publication to the "publish_at" time, or publishes
+// it now when that's empty
+func (h *PostHandler) Reschedule(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.ownPost(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid form data")
+		return
+	}
+
+	form := forms.ScheduleForm{
+		PublishAt: r.Form.Get("publish_at"),
+	}
+
+	// Validate
+	errors := forms.Validate(form)
+	if len(errors) > 0 {
+		h.Renderer.Render(w, r, "posts/schedule.partial.html", &renderers.TemplateData{
+			Errors: errors,
+			Data: map[string]interface{}{
+				"Post":      p,
+				"PublishAt": form.PublishAt,
+			},
+		})
+		return
+	}
+
+	update := h.Client.Post.UpdateOneID(p.ID)
+	if publishAt := forms.OptionalTime(form.PublishAt); publishAt != nil {
+		update.SetPublishAt(*publishAt)
+	} else {
+		update.ClearPublishAt()
+	}
+	if err := update.Exec(r.Context()); err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to reschedule post")
+		return
+	}
+	h.Renderer.InvalidateFragments("posts:list")
+
+	// Close modal and return updated posts list
+	htmx.Trigger(w, "closeModal")
 
 	// Query all posts to return updated list
 	posts, err := h.Client.Post.Query().
+		Where(visiblePosts(r)).
 		WithAuthor().
 		Order(models.Desc(post.FieldCreatedAt)).
 		All(r.Context())
@@ -337,11 +483,74 @@ func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return the updated posts list
-	w.Header().Set("HX-Retarget", "#posts-list")
-	w.Header().Set("HX-Reswap", "innerHTML")
+	htmx.Retarget(w, "#posts-list")
+	htmx.Reswap(w, "innerHTML")
 	h.Renderer.Render(w, r, "posts/list.partial.html", &renderers.TemplateData{
 		Data: map[string]interface{}{
 			"Posts": posts,
 		},
 	})
 }
+
+// PublishScheduled publishes the scheduled posts whose time has come. The app
+// runs it every minute with jobs.Every.
+func (h *PostHandler) PublishScheduled(ctx context.Context) error {
+	n, err := h.Client.Post.Update().
+		Where(post.PublishAtLTE(time.Now())).
+		ClearPublishAt().
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		h.Renderer.InvalidateFragments("posts:list")
+		utils.Infow("posts.published", "count", n)
+	}
+	return nil
+}
+
+// ownPost loads the {id} post if the current user may change it; otherwise it
+// renders the error and returns false
+func (h *PostHandler) ownPost(w http.ResponseWriter, r *http.Request) (*models.Post, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusBadRequest, "Invalid post ID")
+		return nil, false
+	}
+
+	p, err := h.Client.Post.Query().Where(post.IDEQ(id)).WithAuthor().Only(r.Context())
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusNotFound, "Post not found")
+		return nil, false
+	}
+
+	if p.Edges.Author == nil || !middleware.OwnsResource(r, p.Edges.Author.ID) {
+		h.Renderer.RenderError(w, r, http.StatusForbidden, "You don't have permission to change this post")
+		return nil, false
+	}
+	return p, true
+}
+
+// publishedPosts matches posts that aren't scheduled, or whose time has come
+// even if the publish task hasn't run yet
+func publishedPosts() predicate.Post {
+	return post.Or(post.PublishAtIsNil(), post.PublishAtLTE(time.Now()))
+}
+
+// visiblePosts matches the posts listed for r's user: published ones, and
+// their own scheduled ones
+func visiblePosts(r *http.Request) predicate.Post {
+	u := middleware.GetUser(r.Context())
+	if u == nil {
+		return publishedPosts()
+	}
+	return post.Or(publishedPosts(), post.HasAuthorWith(user.IDEQ(u.ID)))
+}
+
+// publishAtValue formats a scheduled post's time for a datetime-local input
+func publishAtValue(p *models.Post) string {
+	if p.PublishAt == nil || !p.PublishAt.After(time.Now()) {
+		return ""
+	}
+	return p.PublishAt.In(time.Local).Format(forms.DateTimeLayout)
+}
@@ -8,7 +8,7 @@ All code is commented out. To use this handler:
 2. Run `go generate ./...` in the models directory
 3. Uncomment all the code in this file
 4. Rename this file to "sampleproducts.go" (remove "sample_" prefix)
-5. Register routes in main.go
+5. Register routes in gojang/app/app.go
 
 See SAMPLE_PRODUCTS_INTEGRATION.md for detailed instructions.
 */
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/search"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/views/renderers"
+)
+
+type SearchHandler struct {
+	Index    search.Indexer
+	Renderer *renderers.Renderer
+}
+
+func NewSearchHandler(index search.Indexer, renderer *renderers.Renderer) *SearchHandler {
+	return &SearchHandler{
+		Index:    index,
+		Renderer: renderer,
+	}
+}
+
+// Search shows the search page, or just the results for HTMX requests (?q=text&type=Post)
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	data := &renderers.TemplateData{
+		Title: "Search",
+		Data: map[string]interface{}{
+			"Query": query,
+		},
+	}
+
+	if query != "" {
+		results, err := h.Index.Search(r.Context(), search.Query{
+			Text:  query,
+			Types: r.URL.Query()["type"],
+		})
+		if err != nil {
+			utils.Errorw("search.failed", "query", query, "error", err)
+			h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Search is unavailable right now")
+			return
+		}
+		data.Data["Results"] = results
+	}
+
+	if htmx.IsRequest(r) {
+		h.Renderer.Render(w, r, "search/results.partial.html", data)
+		return
+	}
+
+	results, err := h.Renderer.RenderPartial(r, "search/results.partial.html", data)
+	if err != nil {
+		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to render results")
+		return
+	}
+	data.Data["ResultsHTML"] = results
+	h.Renderer.Render(w, r, "search/index.html", data)
+}
@@ -3,6 +3,8 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/models/db"
 	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/google/uuid"
 
@@ -45,7 +47,7 @@ func (h *UserHandler) Index(w http.ResponseWriter, r *http.Request) {
 // New shows the create user form
 func (h *UserHandler) New(w http.ResponseWriter, r *http.Request) {
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
 		return
 	}
@@ -61,7 +63,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form := forms.UserForm{
-		Email:       r.Form.Get("email"),
+		Email:       utils.NormalizeEmail(r.Form.Get("email")),
 		Password:    r.Form.Get("password"),
 		IsActive:    r.Form.Get("is_active") == "true",
 		IsStaff:     r.Form.Get("is_staff") == "true",
@@ -78,7 +80,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if user exists
-	exists, err := h.Client.User.Query().Where(user.EmailEQ(form.Email)).Exist(r.Context())
+	exists, err := h.Client.User.Query().Where(user.EmailEqualFold(form.Email)).Exist(r.Context())
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to check email availability")
 		return
@@ -105,6 +107,12 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 		SetIsStaff(form.IsStaff).
 		SetIsSuperuser(form.IsSuperuser).
 		Save(r.Context())
+	if errors, ok := db.FormErrors(err, "general"); ok {
+		h.Renderer.Render(w, r, "users/new.partial.html", &renderers.TemplateData{
+			Errors: errors,
+		})
+		return
+	}
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to create user")
 		return
@@ -121,7 +129,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 // Edit shows the edit user form
 func (h *UserHandler) Edit(w http.ResponseWriter, r *http.Request) {
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
 		return
 	}
@@ -161,7 +169,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form := forms.UserForm{
-		Email:       r.Form.Get("email"),
+		Email:       utils.NormalizeEmail(r.Form.Get("email")),
 		Password:    r.Form.Get("password"),
 		IsActive:    r.Form.Get("is_active") == "true",
 		IsStaff:     r.Form.Get("is_staff") == "true",
@@ -186,6 +194,24 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	u, err := updateQuery.Save(r.Context())
+	if errors, ok := db.FormErrors(err, "general"); ok {
+		// The edit form targets the table row, so swap the form back into the modal instead
+		htmx.Retarget(w, "#modal")
+		htmx.Reswap(w, "innerHTML")
+		h.Renderer.Render(w, r, "users/edit.partial.html", &renderers.TemplateData{
+			Errors: errors,
+			Data: map[string]interface{}{
+				"User": map[string]interface{}{
+					"ID":          id,
+					"Email":       form.Email,
+					"IsActive":    form.IsActive,
+					"IsStaff":     form.IsStaff,
+					"IsSuperuser": form.IsSuperuser,
+				},
+			},
+		})
+		return
+	}
 	if err != nil {
 		h.Renderer.RenderError(w, r, http.StatusInternalServerError, "Failed to update user")
 		return
@@ -202,7 +228,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 // DeleteConfirm shows the delete confirmation modal
 func (h *UserHandler) DeleteConfirm(w http.ResponseWriter, r *http.Request) {
 	// Prevent direct access - modal forms must be loaded via HTMX
-	if r.Header.Get("HX-Request") != "true" {
+	if !htmx.IsRequest(r) {
 		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
 		return
 	}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/gojangframework/gojang/gojang/utils"
 	"github.com/google/uuid"
 )
@@ -81,6 +82,10 @@ func AuditMiddleware(next http.Handler) http.Handler {
 
 		next.ServeHTTP(ww, r)
 
+		// Route params are only populated once the sub-router has matched,
+		// so model/action metadata is read after the handler runs
+		model, recordID, action := auditRouteMeta(r)
+
 		// Log completion
 		duration := time.Since(start)
 		utils.Infow("admin.complete",
@@ -88,12 +93,60 @@ func AuditMiddleware(next http.Handler) http.Handler {
 			"user_id", userID,
 			"method", r.Method,
 			"path", r.URL.Path,
+			"model", model,
+			"record_id", recordID,
+			"action", action,
 			"status", ww.statusCode,
 			"duration", duration,
 		)
 	})
 }
 
+// auditRouteMeta extracts the admin model, record ID and action from the matched chi route
+func auditRouteMeta(r *http.Request) (model, recordID, action string) {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return "", "", ""
+	}
+
+	model = rctx.URLParam("model")
+	recordID = rctx.URLParam("id")
+	pattern := rctx.RoutePattern()
+
+	switch {
+	case model == "":
+		action = ""
+	case r.Method == http.MethodGet && strings.HasSuffix(pattern, "/autocomplete"):
+		action = "autocomplete"
+	case r.Method == http.MethodGet && strings.HasSuffix(pattern, "/new"):
+		action = "new"
+	case r.Method == http.MethodGet && strings.HasSuffix(pattern, "/edit"):
+		action = "edit"
+	case r.Method == http.MethodGet && strings.HasSuffix(pattern, "/delete"):
+		action = "delete_confirm"
+	case r.Method == http.MethodGet && strings.HasSuffix(pattern, "/{id}"):
+		action = "view"
+	case r.Method == http.MethodGet:
+		action = "list"
+	case r.Method == http.MethodPost && strings.Contains(pattern, "/undo/"):
+		action = "undo"
+	case r.Method == http.MethodPost && strings.HasSuffix(pattern, "/preferences"):
+		action = "preferences"
+	case r.Method == http.MethodPost && strings.Contains(pattern, "/actions/"):
+		action = "action:" + rctx.URLParam("action")
+	case r.Method == http.MethodPost:
+		action = "create"
+	case r.Method == http.MethodPut, r.Method == http.MethodPatch:
+		action = "update"
+	case r.Method == http.MethodDelete:
+		action = "delete"
+	}
+	if action != "" && strings.Contains(pattern, "/inlines/") {
+		action = "inline_" + action
+	}
+	return model, recordID, action
+}
+
 // responseWriterWrapper wraps http.ResponseWriter to capture status code
 type responseWriterWrapper struct {
 	http.ResponseWriter
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestAuditRouteMeta tests that model, record ID and action are read from the matched admin route
+func TestAuditRouteMeta(t *testing.T) {
+	tests := []struct {
+		method, path            string
+		model, recordID, action string
+	}{
+		{http.MethodGet, "/", "", "", ""},
+		{http.MethodGet, "/post/", "post", "", "list"},
+		{http.MethodGet, "/post/new", "post", "", "new"},
+		{http.MethodPost, "/post/", "post", "", "create"},
+		{http.MethodGet, "/post/abc/edit", "post", "abc", "edit"},
+		{http.MethodPut, "/post/abc", "post", "abc", "update"},
+		{http.MethodGet, "/post/abc/delete", "post", "abc", "delete_confirm"},
+		{http.MethodDelete, "/post/abc", "post", "abc", "delete"},
+		{http.MethodPost, "/post/undo/tok", "post", "", "undo"},
+		{http.MethodPost, "/post/preferences", "post", "", "preferences"},
+		{http.MethodPost, "/user/actions/deactivate", "user", "", "action:deactivate"},
+		{http.MethodGet, "/api/post", "post", "", "list"},
+		{http.MethodGet, "/api/post/abc", "post", "abc", "view"},
+		{http.MethodPatch, "/api/post/abc", "post", "abc", "update"},
+		{http.MethodGet, "/post/abc/inlines/comment", "post", "abc", "inline_list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			var model, recordID, action string
+			capture := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					next.ServeHTTP(w, r)
+					model, recordID, action = auditRouteMeta(r)
+				})
+			}
+
+			noop := func(w http.ResponseWriter, r *http.Request) {}
+			r := chi.NewRouter()
+			r.Use(capture)
+			r.Get("/", noop)
+			r.Route("/api", func(api chi.Router) {
+				api.Get("/{model}", noop)
+				api.Get("/{model}/{id}", noop)
+				api.Patch("/{model}/{id}", noop)
+			})
+			r.Route("/{model}", func(m chi.Router) {
+				m.Get("/", noop)
+				m.Get("/new", noop)
+				m.Post("/", noop)
+				m.Get("/{id}/edit", noop)
+				m.Put("/{id}", noop)
+				m.Get("/{id}/delete", noop)
+				m.Delete("/{id}", noop)
+				m.Post("/undo/{token}", noop)
+				m.Post("/preferences", noop)
+				m.Post("/actions/{action}", noop)
+				m.Get("/{id}/inlines/{child}", noop)
+			})
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			if model != tt.model || recordID != tt.recordID || action != tt.action {
+				t.Errorf("Got (%q, %q, %q), expected (%q, %q, %q)",
+					model, recordID, action, tt.model, tt.recordID, tt.action)
+			}
+		})
+	}
+}
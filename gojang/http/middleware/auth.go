@@ -3,17 +3,17 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"net/url"
 
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/htmx"
 	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/user"
 	"github.com/google/uuid"
 
 	"github.com/alexedwards/scs/v2"
 )
 
-type contextKey string
-
-const userContextKey contextKey = "user"
-
 // RequireAuth middleware ensures user is authenticated
 func RequireAuth(sm *scs.SessionManager, client *models.Client) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -21,12 +21,12 @@ func RequireAuth(sm *scs.SessionManager, client *models.Client) func(http.Handle
 			userIDStr := sm.GetString(r.Context(), "user_id")
 			if userIDStr == "" {
 				// Check if htmx request
-				if r.Header.Get("HX-Request") == "true" {
-					w.Header().Set("HX-Redirect", "/login")
+				if htmx.IsRequest(r) {
+					htmx.Redirect(w, "/login")
 					w.WriteHeader(http.StatusUnauthorized)
 					return
 				}
-				http.Redirect(w, r, "/login?next="+r.URL.Path, http.StatusSeeOther)
+				http.Redirect(w, r, "/login?next="+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
 				return
 			}
 
@@ -39,14 +39,15 @@ func RequireAuth(sm *scs.SessionManager, client *models.Client) func(http.Handle
 			}
 
 			// Load user and add to context
-			user, err := client.User.Get(r.Context(), userID)
+			user, err := queryUser(r.Context(), client, userID)
 			if err != nil || !user.IsActive {
 				sm.Destroy(r.Context())
 				http.Redirect(w, r, "/login", http.StatusSeeOther)
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), userContextKey, user)
+			renewOnPrivilegeChange(r.Context(), sm, client, user)
+			ctx := ctxutil.WithUser(r.Context(), user)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -98,9 +99,10 @@ func LoadUser(sm *scs.SessionManager, client *models.Client) func(http.Handler)
 				userID, err := uuid.Parse(userIDStr)
 				if err == nil {
 					// Load user and add to context
-					user, err := client.User.Get(r.Context(), userID)
+					user, err := queryUser(r.Context(), client, userID)
 					if err == nil && user.IsActive {
-						ctx := context.WithValue(r.Context(), userContextKey, user)
+						renewOnPrivilegeChange(r.Context(), sm, client, user)
+						ctx := ctxutil.WithUser(r.Context(), user)
 						r = r.WithContext(ctx)
 					} else {
 						// Invalid session, destroy it
@@ -116,8 +118,18 @@ func LoadUser(sm *scs.SessionManager, client *models.Client) func(http.Handler)
 	}
 }
 
-// GetUser retrieves the authenticated user from context
+// queryUser loads a session's user along with their groups, which CanUser
+// checks for permissions
+func queryUser(ctx context.Context, client *models.Client, id uuid.UUID) (*models.User, error) {
+	return client.User.Query().Where(user.ID(id)).WithGroups().Only(ctx)
+}
+
+// GetUser retrieves the authenticated user from context (see ctxutil.User)
 func GetUser(ctx context.Context) *models.User {
-	user, _ := ctx.Value(userContextKey).(*models.User)
-	return user
+	return ctxutil.User(ctx)
+}
+
+// WithUser returns a copy of ctx carrying user, as LoadUser does for requests
+func WithUser(ctx context.Context, user *models.User) context.Context {
+	return ctxutil.WithUser(ctx, user)
 }
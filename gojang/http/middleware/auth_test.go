@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/gojangframework/gojang/gojang/ctxutil"
 	"github.com/gojangframework/gojang/gojang/models"
 )
 
@@ -14,8 +15,8 @@ func TestGetUser_WithUser(t *testing.T) {
 		IsStaff: true,
 	}
 
-	// Add user to context
-	ctx := context.WithValue(context.Background(), userContextKey, user)
+	// Add user to context the way other packages do
+	ctx := ctxutil.WithUser(context.Background(), user)
 
 	// Retrieve user
 	retrievedUser := GetUser(ctx)
@@ -42,14 +43,3 @@ func TestGetUser_WithoutUser(t *testing.T) {
 		t.Error("Expected nil user from empty context")
 	}
 }
-
-func TestGetUser_WithWrongType(t *testing.T) {
-	// Add wrong type to context
-	ctx := context.WithValue(context.Background(), userContextKey, "not a user")
-
-	retrievedUser := GetUser(ctx)
-
-	if retrievedUser != nil {
-		t.Error("Expected nil user when context contains wrong type")
-	}
-}
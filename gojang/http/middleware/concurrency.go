@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+var (
+	errQueueFull    = errors.New("queue full")
+	errQueueTimeout = errors.New("queue timeout")
+)
+
+// ConcurrencyLimiter caps how many requests to a route group run at once.
+// Requests over the limit wait in a queue instead of being rejected; only a full
+// queue, or a wait longer than the limiter's timeout, gets a 503.
+type ConcurrencyLimiter struct {
+	slots   chan struct{} // One token per running request
+	waiting chan struct{} // One token per queued request
+	wait    time.Duration
+}
+
+// NewConcurrencyLimiter creates a limiter that runs limit requests at once and
+// queues up to queue more for at most wait each
+func NewConcurrencyLimiter(limit, queue int, wait time.Duration) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		slots:   make(chan struct{}, max(limit, 1)),
+		waiting: make(chan struct{}, max(queue, 0)),
+		wait:    wait,
+	}
+}
+
+// Running returns how many requests hold a slot
+func (l *ConcurrencyLimiter) Running() int {
+	return len(l.slots)
+}
+
+// Queued returns how many requests are waiting for a slot
+func (l *ConcurrencyLimiter) Queued() int {
+	return len(l.waiting)
+}
+
+// acquire takes a slot, queueing until one frees up. Waiting requests get
+// slots in roughly the order they arrived.
+func (l *ConcurrencyLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	select {
+	case l.waiting <- struct{}{}:
+		defer func() { <-l.waiting }()
+	default:
+		return errQueueFull
+	}
+
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return errQueueTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire
+func (l *ConcurrencyLimiter) release() {
+	<-l.slots
+}
+
+// ConcurrencyLimit runs requests through limiter, so an expensive route group
+// (search, exports) can't take every database connection or CPU. Give each
+// group its own limiter. When a request can't get a slot, onBusy writes the
+// response (a 503 with an HTMX-friendly alert when nil), after Retry-After is
+// set. Requests whose context ends while queued, e.g., by the Timeout
+// middleware or a client going away, are dropped without a response.
+func ConcurrencyLimit(limiter *ConcurrencyLimiter, onBusy http.Handler) func(next http.Handler) http.Handler {
+	if onBusy == nil {
+		onBusy = http.HandlerFunc(defaultBusyHandler)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := limiter.acquire(r.Context())
+			if err == nil {
+				defer limiter.release()
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.Context().Err() != nil {
+				return
+			}
+
+			utils.Warnw("concurrency_limit_exceeded",
+				"reason", err.Error(),
+				"running", limiter.Running(),
+				"queued", limiter.Queued(),
+				"method", r.Method,
+				"path", r.URL.Path,
+				"ip", getRealIP(r),
+			)
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(math.Ceil(limiter.wait.Seconds())), 1)))
+			onBusy.ServeHTTP(w, r)
+		})
+	}
+}
+
+// defaultBusyHandler writes a 503, as an alert for HTMX requests
+func defaultBusyHandler(w http.ResponseWriter, r *http.Request) {
+	if htmx.IsRequest(r) {
+		htmx.Reswap(w, "innerHTML")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`<div class="alert alert-error">The server is busy. Please try again in a moment.</div>`))
+		return
+	}
+
+	http.Error(w, "The server is busy. Please try again in a moment.", http.StatusServiceUnavailable)
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blockingHandler holds every request until release is closed
+func blockingHandler(started chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write([]byte("done"))
+	})
+}
+
+// serveAsync serves a request in a goroutine and returns its recorder once done
+func serveAsync(h http.Handler, req *http.Request) <-chan *httptest.ResponseRecorder {
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		done <- rec
+	}()
+	return done
+}
+
+// waitFor polls cond until it's true or a second passes
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestConcurrencyLimit_QueuesRequests tests that a request over the limit waits for a slot instead of failing
+func TestConcurrencyLimit_QueuesRequests(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 1, time.Second)
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	handler := ConcurrencyLimit(limiter, nil)(blockingHandler(started, release))
+
+	first := serveAsync(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+	<-started
+	second := serveAsync(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+	waitFor(t, func() bool { return limiter.Queued() == 1 })
+
+	select {
+	case <-started:
+		t.Fatal("Expected the second request to wait for the first")
+	default:
+	}
+
+	close(release)
+	for _, done := range []<-chan *httptest.ResponseRecorder{first, second} {
+		if rec := <-done; rec.Code != http.StatusOK || rec.Body.String() != "done" {
+			t.Errorf("Expected 200 done, got %d %q", rec.Code, rec.Body.String())
+		}
+	}
+	if limiter.Running() != 0 || limiter.Queued() != 0 {
+		t.Errorf("Expected slots to be released, got %d running and %d queued", limiter.Running(), limiter.Queued())
+	}
+}
+
+// TestConcurrencyLimit_QueueFull tests that requests beyond the queue are rejected at once
+func TestConcurrencyLimit_QueueFull(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 0, time.Minute)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	handler := ConcurrencyLimit(limiter, nil)(blockingHandler(started, release))
+
+	serveAsync(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+	<-started
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "60" {
+		t.Errorf("Expected Retry-After 60, got %q", rec.Header().Get("Retry-After"))
+	}
+}
+
+// TestConcurrencyLimit_QueueTimeout tests that a queued request gives up after the wait
+func TestConcurrencyLimit_QueueTimeout(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 1, 20*time.Millisecond)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	handler := ConcurrencyLimit(limiter, nil)(blockingHandler(started, release))
+
+	serveAsync(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+	<-started
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "alert-error") {
+		t.Errorf("Expected an HTMX alert, got %q", rec.Body.String())
+	}
+	if rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("Expected Retry-After 1, got %q", rec.Header().Get("Retry-After"))
+	}
+	if limiter.Queued() != 0 {
+		t.Errorf("Expected the queue to be empty, got %d", limiter.Queued())
+	}
+}
+
+// TestConcurrencyLimit_CanceledWhileQueued tests that a request canceled in the queue gets no response
+func TestConcurrencyLimit_CanceledWhileQueued(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 1, time.Minute)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	busy := false
+	onBusy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { busy = true })
+	handler := ConcurrencyLimit(limiter, onBusy)(blockingHandler(started, release))
+
+	serveAsync(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := serveAsync(handler, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	waitFor(t, func() bool { return limiter.Queued() == 1 })
+	cancel()
+
+	if rec := <-done; rec.Body.Len() != 0 || busy {
+		t.Errorf("Expected no response, got %q (onBusy called: %v)", rec.Body.String(), busy)
+	}
+}
+
+// TestConcurrencyLimit_CustomBusyHandler tests that onBusy writes the rejection
+func TestConcurrencyLimit_CustomBusyHandler(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 0, time.Second)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	onBusy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"busy"}`))
+	})
+	handler := ConcurrencyLimit(limiter, onBusy)(blockingHandler(started, release))
+
+	serveAsync(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+	<-started
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusTooManyRequests || rec.Body.String() != `{"error":"busy"}` {
+		t.Errorf("Expected the custom response, got %d %q", rec.Code, rec.Body.String())
+	}
+}
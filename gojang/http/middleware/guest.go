@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+const (
+	guestIDKey     = "guest_id"
+	guestKeyPrefix = "guest:"
+)
+
+// GuestMigrator moves one piece of guest data (e.g., a cart) into a user's
+// account. data is the JSON stored with GuestSessions.Put.
+type GuestMigrator func(ctx context.Context, user *models.User, data json.RawMessage) error
+
+// GuestSessions stores data for anonymous visitors (carts, draft forms) in their
+// session and hands it over to their account when they log in or register.
+// Values are JSON-encoded, so any JSON-serializable type can be stored.
+type GuestSessions struct {
+	Sessions  *scs.SessionManager
+	migrators map[string]GuestMigrator
+}
+
+// NewGuestSessions creates guest session storage on top of the session manager
+func NewGuestSessions(sm *scs.SessionManager) *GuestSessions {
+	return &GuestSessions{
+		Sessions:  sm,
+		migrators: make(map[string]GuestMigrator),
+	}
+}
+
+// RegisterMigrator sets how data stored under key is merged into an account on
+// login. Keys without a migrator stay in the session after login.
+func (g *GuestSessions) RegisterMigrator(key string, migrate GuestMigrator) {
+	g.migrators[key] = migrate
+}
+
+// ID returns a stable identifier for the visitor, creating one on first use
+func (g *GuestSessions) ID(ctx context.Context) string {
+	id := g.Sessions.GetString(ctx, guestIDKey)
+	if id == "" {
+		id = uuid.NewString()
+		g.Sessions.Put(ctx, guestIDKey, id)
+	}
+	return id
+}
+
+// HasID reports whether the visitor was given an ID, without giving them one
+func (g *GuestSessions) HasID(ctx context.Context) bool {
+	return g.Sessions.Exists(ctx, guestIDKey)
+}
+
+// Put stores value under key in the visitor's session
+func (g *GuestSessions) Put(ctx context.Context, key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	g.ID(ctx) // Make sure the visitor has an ID before they have data
+	g.Sessions.Put(ctx, guestKeyPrefix+key, string(encoded))
+	return nil
+}
+
+// Get decodes the value stored under key into dst and reports whether it existed
+func (g *GuestSessions) Get(ctx context.Context, key string, dst interface{}) (bool, error) {
+	encoded := g.Sessions.GetString(ctx, guestKeyPrefix+key)
+	if encoded == "" {
+		return false, nil
+	}
+	return true, json.Unmarshal([]byte(encoded), dst)
+}
+
+// Remove deletes the value stored under key
+func (g *GuestSessions) Remove(ctx context.Context, key string) {
+	g.Sessions.Remove(ctx, guestKeyPrefix+key)
+}
+
+// Keys lists the keys the visitor has data for, sorted
+func (g *GuestSessions) Keys(ctx context.Context) []string {
+	var keys []string
+	for _, key := range g.Sessions.Keys(ctx) {
+		if strings.HasPrefix(key, guestKeyPrefix) {
+			keys = append(keys, strings.TrimPrefix(key, guestKeyPrefix))
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MigrateToUser runs the registered migrators for the visitor's data and removes
+// what was migrated. A failing migrator leaves its data in the session so it can
+// be retried; the first error is returned after every key has been tried.
+func (g *GuestSessions) MigrateToUser(ctx context.Context, user *models.User) error {
+	guestID := g.Sessions.GetString(ctx, guestIDKey)
+	if guestID == "" {
+		return nil
+	}
+
+	var firstErr error
+	for _, key := range g.Keys(ctx) {
+		migrate, ok := g.migrators[key]
+		if !ok {
+			continue
+		}
+		encoded := g.Sessions.GetString(ctx, guestKeyPrefix+key)
+		if err := migrate(ctx, user, json.RawMessage(encoded)); err != nil {
+			utils.Errorw("guest.migrate_failed", "key", key, "guest_id", guestID, "user_id", user.ID, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		g.Remove(ctx, key)
+		utils.Infow("guest.migrated", "key", key, "guest_id", guestID, "user_id", user.ID)
+	}
+
+	// The visitor is now a user; keep the ID only while un-migrated data remains
+	if firstErr == nil && len(g.Keys(ctx)) == 0 {
+		g.Sessions.Remove(ctx, guestIDKey)
+	}
+	return firstErr
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+// guestContext returns guest storage and a context carrying a fresh session
+func guestContext(t *testing.T) (*GuestSessions, context.Context) {
+	t.Helper()
+	sm := scs.New()
+	ctx, err := sm.Load(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return NewGuestSessions(sm), ctx
+}
+
+// TestGuestSessions_PutGet tests that guest data round-trips and the guest ID is stable
+func TestGuestSessions_PutGet(t *testing.T) {
+	guests, ctx := guestContext(t)
+
+	if guests.HasID(ctx) {
+		t.Error("Expected a fresh session to have no guest ID")
+	}
+	id := guests.ID(ctx)
+	if id == "" || guests.ID(ctx) != id || !guests.HasID(ctx) {
+		t.Errorf("Expected a stable guest ID, got %q", id)
+	}
+
+	if err := guests.Put(ctx, "cart", []string{"apple", "pear"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	var cart []string
+	found, err := guests.Get(ctx, "cart", &cart)
+	if err != nil || !found || len(cart) != 2 {
+		t.Errorf("Expected cart to round-trip, got %v (found=%v, err=%v)", cart, found, err)
+	}
+
+	if found, _ := guests.Get(ctx, "draft", &cart); found {
+		t.Error("Expected missing key to be reported as not found")
+	}
+	if keys := guests.Keys(ctx); len(keys) != 1 || keys[0] != "cart" {
+		t.Errorf("Expected keys [cart], got %v", keys)
+	}
+}
+
+// TestGuestSessions_MigrateToUser tests that migrated data is removed and failed or unregistered data is kept
+func TestGuestSessions_MigrateToUser(t *testing.T) {
+	guests, ctx := guestContext(t)
+	user := &models.User{ID: uuid.New()}
+
+	var migrated []string
+	guests.RegisterMigrator("cart", func(ctx context.Context, u *models.User, data json.RawMessage) error {
+		if u.ID != user.ID {
+			t.Errorf("Expected migrator to receive the user")
+		}
+		return json.Unmarshal(data, &migrated)
+	})
+	guests.RegisterMigrator("draft", func(context.Context, *models.User, json.RawMessage) error {
+		return errors.New("storage down")
+	})
+
+	guests.Put(ctx, "cart", []string{"apple"})
+	guests.Put(ctx, "draft", "hello")
+	guests.Put(ctx, "theme", "dark")
+
+	if err := guests.MigrateToUser(ctx, user); err == nil {
+		t.Error("Expected the failing migrator's error")
+	}
+	if len(migrated) != 1 || migrated[0] != "apple" {
+		t.Errorf("Expected cart to be migrated, got %v", migrated)
+	}
+	if keys := guests.Keys(ctx); len(keys) != 2 || keys[0] != "draft" || keys[1] != "theme" {
+		t.Errorf("Expected draft and theme to stay in the session, got %v", keys)
+	}
+}
+
+// TestGuestSessions_MigrateWithoutGuest tests that users without guest data are left alone
+func TestGuestSessions_MigrateWithoutGuest(t *testing.T) {
+	guests, ctx := guestContext(t)
+	if err := guests.MigrateToUser(ctx, &models.User{}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if guests.Sessions.Exists(ctx, guestIDKey) {
+		t.Error("Expected no guest ID to be created")
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/i18n"
+)
+
+// LocaleCookie remembers the language picked with ?lang=
+const LocaleCookie = "lang"
+
+// localeCookieMaxAge keeps the picked language for a year
+const localeCookieMaxAge = 365 * 24 * 60 * 60
+
+// Locale picks the request's language from the supported locales (see
+// i18n.SetLocales): a ?lang= parameter, which is remembered in a cookie, then
+// that cookie, then the browser's Accept-Language, then the default. Read it
+// with ctxutil.Locale; templates get it as .Locale.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := ""
+		if lang := r.URL.Query().Get("lang"); lang != "" {
+			if locale = i18n.Supported(lang); locale != "" {
+				http.SetCookie(w, &http.Cookie{
+					Name:     LocaleCookie,
+					Value:    locale,
+					Path:     "/",
+					MaxAge:   localeCookieMaxAge,
+					HttpOnly: true,
+					Secure:   r.TLS != nil,
+					SameSite: http.SameSiteLaxMode,
+				})
+			}
+		}
+		if c, err := r.Cookie(LocaleCookie); locale == "" && err == nil {
+			locale = i18n.Supported(c.Value)
+		}
+		if locale == "" {
+			locale = i18n.Match(r.Header.Get("Accept-Language"))
+		}
+		if locale == "" {
+			locale = i18n.Default()
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(ctxutil.WithLocale(r.Context(), locale)))
+	})
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/i18n"
+)
+
+func TestLocale(t *testing.T) {
+	if err := i18n.SetLocales([]string{"en", "fr", "de"}); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLocales([]string{"en"})
+
+	var got string
+	handler := Locale(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ctxutil.Locale(r.Context())
+	}))
+	tests := map[string]struct {
+		url      string
+		cookie   string
+		accept   string
+		want     string
+		remember bool
+	}{
+		"default":             {"/", "", "", "en", false},
+		"accept-language":     {"/", "", "de-AT,fr;q=0.5", "de", false},
+		"cookie over browser": {"/", "fr", "de", "fr", false},
+		"query over cookie":   {"/?lang=de", "fr", "", "de", true},
+		"unsupported query":   {"/?lang=es", "", "fr", "fr", false},
+		"unsupported cookie":  {"/", "es", "", "en", false},
+	}
+	for name, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+		if tt.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: LocaleCookie, Value: tt.cookie})
+		}
+		if tt.accept != "" {
+			req.Header.Set("Accept-Language", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if got != tt.want {
+			t.Errorf("%s: locale = %q, want %q", name, got, tt.want)
+		}
+		if remembered := len(rec.Result().Cookies()) > 0; remembered != tt.remember {
+			t.Errorf("%s: cookie set = %v, want %v", name, remembered, tt.remember)
+		}
+	}
+}
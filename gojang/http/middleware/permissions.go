@@ -15,6 +15,15 @@ const (
 	PermissionManageUsers   = "manage_users"
 )
 
+// Permissions lists the permission constants above, e.g. for choosing a
+// group's permissions in the admin
+var Permissions = []string{
+	PermissionViewAnyPost,
+	PermissionEditAnyPost,
+	PermissionDeleteAnyPost,
+	PermissionManageUsers,
+}
+
 // CanUser checks if the authenticated user can perform an action
 func CanUser(r *http.Request, permission string) bool {
 	user := GetUser(r.Context())
@@ -22,21 +31,31 @@ func CanUser(r *http.Request, permission string) bool {
 		return false
 	}
 
-	// Staff can do everything
-	if user.IsStaff {
+	// Staff and superusers can do everything
+	if user.IsStaff || user.IsSuperuser {
 		return true
 	}
 
-	// Add more granular permission logic here
-	// For now, regular users have limited permissions
-	switch permission {
-	case PermissionViewAnyPost:
-		return true // Anyone can view posts
-	case PermissionEditAnyPost, PermissionDeleteAnyPost, PermissionManageUsers:
-		return false // Only staff
-	default:
-		return false
+	// Anyone can view posts
+	if permission == PermissionViewAnyPost {
+		return true
+	}
+
+	// Other users need a group granting the permission
+	return groupsGrant(user, permission)
+}
+
+// groupsGrant reports whether any of user's groups grants permission. The
+// groups must be loaded with the user (LoadUser and RequireAuth do this).
+func groupsGrant(user *models.User, permission string) bool {
+	for _, group := range user.Edges.Groups {
+		for _, p := range group.Permissions {
+			if p == permission {
+				return true
+			}
+		}
 	}
+	return false
 }
 
 // OwnsResource checks if user owns a resource (e.g., their own post)
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// TestCanUser_Groups tests that users get the permissions of their groups
+func TestCanUser_Groups(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := t.Context()
+
+	editors := client.Group.Create().SetName("Editors").
+		SetPermissions([]string{PermissionEditAnyPost}).SaveX(ctx)
+	client.Group.Create().SetName("Empty").SaveX(ctx)
+	member := client.User.Create().SetEmail("editor@example.com").SetPasswordHash("x").
+		AddGroups(editors).SaveX(ctx)
+
+	// Loaded the way LoadUser and RequireAuth load it
+	user, err := queryUser(ctx, client, member.ID)
+	if err != nil {
+		t.Fatalf("queryUser failed: %v", err)
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(WithUser(r.Context(), user))
+
+	if !CanUser(r, PermissionEditAnyPost) {
+		t.Error("Expected the Editors group to grant edit_any_post")
+	}
+	if CanUser(r, PermissionManageUsers) {
+		t.Error("Expected no group to grant manage_users")
+	}
+
+	// Without groups, regular users only view posts
+	r = r.WithContext(WithUser(r.Context(), &models.User{}))
+	if CanUser(r, PermissionEditAnyPost) || !CanUser(r, PermissionViewAnyPost) {
+		t.Error("Expected a user without groups to only view posts")
+	}
+}
@@ -1,12 +1,16 @@
 package middleware
 
 import (
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/http/api"
 	"github.com/gojangframework/gojang/gojang/utils"
 	"golang.org/x/time/rate"
 )
@@ -32,13 +36,81 @@ func NewIPRateLimiter(r rate.Limit, b int) *IPRateLimiter {
 
 // GetLimiter returns the rate limiter for a given IP address
 func (i *IPRateLimiter) GetLimiter(ip string) *rate.Limiter {
+	return i.getLimiter(ip, nil)
+}
+
+// Quota overrides a limiter's rate and burst for one client, e.g., from the
+// plan of an API token
+type Quota struct {
+	Rate  rate.Limit
+	Burst int
+}
+
+// PerMinute is a quota of n requests a minute, with bursts of up to n
+func PerMinute(n int) Quota {
+	return Quota{Rate: rate.Limit(float64(n) / 60), Burst: n}
+}
+
+// Budgets declares rate limits by who is making the request. Anonymous
+// requests share a bucket per client IP; each signed-in user (loaded by
+// LoadUser) and each API token gets its own. A zero Quota uses the limiter's
+// defaults.
+type Budgets struct {
+	Anonymous     Quota
+	Authenticated Quota
+	Staff         Quota // Staff and superusers
+
+	// Token returns the ID and quota of a verified API token on the request, or
+	// an empty ID if there isn't one. A nil quota uses Authenticated.
+	Token func(r *http.Request) (id string, quota *Quota)
+}
+
+// Key returns the RateLimitKey for APIRateLimit that applies the budgets
+func (b Budgets) Key() RateLimitKey {
+	return func(r *http.Request) (string, *Quota) {
+		if b.Token != nil {
+			if id, quota := b.Token(r); id != "" {
+				if quota == nil {
+					quota = b.Authenticated.orNil()
+				}
+				return "token:" + id, quota
+			}
+		}
+		if user := GetUser(r.Context()); user != nil {
+			if user.IsStaff || user.IsSuperuser {
+				return "user:" + user.ID.String(), b.Staff.orNil()
+			}
+			return "user:" + user.ID.String(), b.Authenticated.orNil()
+		}
+		return "ip:" + getRealIP(r), b.Anonymous.orNil()
+	}
+}
+
+// orNil returns nil for a zero quota, so the limiter's defaults apply
+func (q Quota) orNil() *Quota {
+	if q == (Quota{}) {
+		return nil
+	}
+	return &q
+}
+
+// getLimiter returns the limiter for key, applying quota when it's set
+func (i *IPRateLimiter) getLimiter(key string, quota *Quota) *rate.Limiter {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	limiter, exists := i.limiters[ip]
+	r, b := i.rate, i.burst
+	if quota != nil {
+		r, b = quota.Rate, quota.Burst
+	}
+	limiter, exists := i.limiters[key]
 	if !exists {
-		limiter = rate.NewLimiter(i.rate, i.burst)
-		i.limiters[ip] = limiter
+		limiter = rate.NewLimiter(r, b)
+		i.limiters[key] = limiter
+	} else if limiter.Limit() != r || limiter.Burst() != b {
+		// The client's quota changed (e.g., a plan upgrade)
+		limiter.SetLimit(r)
+		limiter.SetBurst(b)
 	}
 
 	return limiter
@@ -104,16 +176,17 @@ func RateLimit(limiter *IPRateLimiter) func(next http.Handler) http.Handler {
 			ip := getRealIP(r)
 
 			limiterForIP := limiter.GetLimiter(ip)
-			if !limiterForIP.Allow() {
+			allowed := limiterForIP.Allow()
+			retryAfter := setRateLimitHeaders(w, limiterForIP)
+			if !allowed {
 				// Log rate limit violation
 				logRateLimitViolation(r, ip)
 
-				// Set retry-after header (suggest waiting 60 seconds)
-				w.Header().Set("Retry-After", "60")
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
 
 				// Check if it's an HTMX request
-				if r.Header.Get("HX-Request") == "true" {
-					w.Header().Set("HX-Reswap", "innerHTML")
+				if htmx.IsRequest(r) {
+					htmx.Reswap(w, "innerHTML")
 					w.WriteHeader(http.StatusTooManyRequests)
 					w.Write([]byte(`<div class="alert alert-error">Too many requests. Please wait a moment and try again.</div>`))
 					return
@@ -128,6 +201,66 @@ func RateLimit(limiter *IPRateLimiter) func(next http.Handler) http.Handler {
 	}
 }
 
+// RateLimitKey returns who a request counts against and, optionally, their own
+// quota. An empty key counts the request against the client IP.
+type RateLimitKey func(r *http.Request) (key string, quota *Quota)
+
+// APIRateLimit limits JSON API requests like RateLimit, but answers 429s with
+// a JSON error. key picks the bucket; nil (or an empty key) uses the client IP.
+// Only return keys for credentials that were verified, such as an API token
+// loaded by an earlier middleware; otherwise clients could pick a fresh bucket
+// for every request.
+func APIRateLimit(limiter *IPRateLimiter, key RateLimitKey) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := getRealIP(r)
+			bucket, quota := "", (*Quota)(nil)
+			if key != nil {
+				bucket, quota = key(r)
+			}
+			if bucket == "" {
+				bucket, quota = "ip:"+ip, nil
+			}
+
+			l := limiter.getLimiter(bucket, quota)
+			allowed := l.Allow()
+			retryAfter := setRateLimitHeaders(w, l)
+			if !allowed {
+				logRateLimitViolation(r, ip)
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				api.Error(w, http.StatusTooManyRequests, "Too many requests. Please try again later.")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setRateLimitHeaders describes l's bucket after a request: X-RateLimit-Limit
+// is the burst, X-RateLimit-Remaining the requests left right now, and
+// X-RateLimit-Reset the seconds until the bucket is full again. It returns the
+// seconds until the next request is allowed.
+func setRateLimitHeaders(w http.ResponseWriter, l *rate.Limiter) (retryAfter int) {
+	tokens := l.Tokens()
+	remaining, reset := 0, 0
+	if tokens > 0 {
+		remaining = int(math.Floor(tokens))
+	}
+	if l.Limit() != rate.Inf && l.Limit() > 0 {
+		reset = int(math.Ceil((float64(l.Burst()) - tokens) / float64(l.Limit())))
+		if tokens < 1 {
+			retryAfter = int(math.Ceil((1 - tokens) / float64(l.Limit())))
+		}
+	}
+
+	h := w.Header()
+	h.Set("X-RateLimit-Limit", strconv.Itoa(l.Burst()))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Reset", strconv.Itoa(max(reset, 0)))
+	return max(retryAfter, 1)
+}
+
 // StartCleanupRoutine starts a background goroutine to cleanup old limiters
 func (i *IPRateLimiter) StartCleanupRoutine(interval time.Duration, done <-chan struct{}) {
 	ticker := time.NewTicker(interval)
@@ -148,3 +281,25 @@ func (i *IPRateLimiter) StartCleanupRoutine(interval time.Duration, done <-chan
 func AuthRateLimiter() *IPRateLimiter {
 	return NewIPRateLimiter(rate.Every(12*time.Second), 10) // 5 req/min average, 10 burst
 }
+
+// ContactRateLimiter creates a rate limiter for the contact form
+// Allows 1 message per minute with burst of 3
+func ContactRateLimiter() *IPRateLimiter {
+	return NewIPRateLimiter(rate.Every(time.Minute), 3)
+}
+
+// APIRateLimiter creates the default rate limiter for the JSON API
+// Allows 60 requests per minute with burst of 60
+func APIRateLimiter() *IPRateLimiter {
+	return NewIPRateLimiter(rate.Every(time.Second), 60)
+}
+
+// APIBudgets are the default budgets for the JSON API: 60 requests per minute
+// per IP for anonymous clients, 120 per signed-in user and 600 for staff
+func APIBudgets() Budgets {
+	return Budgets{
+		Anonymous:     PerMinute(60),
+		Authenticated: PerMinute(120),
+		Staff:         PerMinute(600),
+	}
+}
@@ -3,9 +3,13 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
 	"golang.org/x/time/rate"
 )
 
@@ -247,6 +251,191 @@ func TestRateLimit_HTMXRequest(t *testing.T) {
 	}
 }
 
+func TestRateLimit_Headers(t *testing.T) {
+	// 1 request per 10 seconds, burst of 2
+	limiter := NewIPRateLimiter(rate.Every(10*time.Second), 2)
+
+	handler := RateLimit(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		status                       int
+		remaining, reset, retryAfter string
+	}{
+		{http.StatusOK, "1", "10", ""},
+		{http.StatusOK, "0", "20", ""},
+		{http.StatusTooManyRequests, "0", "20", "10"},
+	}
+	for i, tt := range tests {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != tt.status {
+			t.Errorf("Request %d: Expected status %d, got %d", i+1, tt.status, w.Code)
+		}
+		if got := w.Header().Get("X-RateLimit-Limit"); got != "2" {
+			t.Errorf("Request %d: Expected X-RateLimit-Limit 2, got %q", i+1, got)
+		}
+		if got := w.Header().Get("X-RateLimit-Remaining"); got != tt.remaining {
+			t.Errorf("Request %d: Expected X-RateLimit-Remaining %s, got %q", i+1, tt.remaining, got)
+		}
+		if got := w.Header().Get("X-RateLimit-Reset"); got != tt.reset {
+			t.Errorf("Request %d: Expected X-RateLimit-Reset %s, got %q", i+1, tt.reset, got)
+		}
+		if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+			t.Errorf("Request %d: Expected Retry-After %q, got %q", i+1, tt.retryAfter, got)
+		}
+	}
+}
+
+func TestAPIRateLimit_JSONResponse(t *testing.T) {
+	limiter := NewIPRateLimiter(rate.Every(10*time.Second), 1)
+
+	handler := APIRateLimit(limiter, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req := httptest.NewRequest("GET", "/api/v1/posts", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != want {
+			t.Fatalf("Request %d: Expected status %d, got %d", i+1, want, w.Code)
+		}
+		if want != http.StatusTooManyRequests {
+			continue
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			t.Errorf("Expected JSON content type, got %q", ct)
+		}
+		if !strings.Contains(w.Body.String(), `"error"`) {
+			t.Errorf("Expected JSON error body, got %s", w.Body.String())
+		}
+		if w.Header().Get("Retry-After") != "10" {
+			t.Errorf("Expected Retry-After 10, got %q", w.Header().Get("Retry-After"))
+		}
+	}
+}
+
+func TestAPIRateLimit_PerKeyQuota(t *testing.T) {
+	limiter := NewIPRateLimiter(rate.Every(10*time.Second), 1)
+
+	// Requests with a token get their own bucket with a larger burst
+	key := func(r *http.Request) (string, *Quota) {
+		if token := r.Header.Get("X-Test-Token"); token != "" {
+			return "token:" + token, &Quota{Rate: rate.Every(time.Second), Burst: 3}
+		}
+		return "", nil
+	}
+	handler := APIRateLimit(limiter, key)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/posts", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		if token != "" {
+			req.Header.Set("X-Test-Token", token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 3; i++ {
+		if w := serve("abc"); w.Code != http.StatusOK {
+			t.Errorf("Token request %d: Expected status 200, got %d", i+1, w.Code)
+		} else if w.Header().Get("X-RateLimit-Limit") != "3" {
+			t.Errorf("Expected X-RateLimit-Limit 3, got %q", w.Header().Get("X-RateLimit-Limit"))
+		}
+	}
+	if w := serve("abc"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected token quota to be exhausted, got %d", w.Code)
+	}
+
+	// The same IP without a token still has its own allowance
+	if w := serve(""); w.Code != http.StatusOK {
+		t.Errorf("Expected IP bucket to be independent of token bucket, got %d", w.Code)
+	}
+}
+
+func TestBudgets_Key(t *testing.T) {
+	budgets := Budgets{
+		Anonymous:     PerMinute(1),
+		Authenticated: PerMinute(2),
+		Staff:         PerMinute(3),
+		Token: func(r *http.Request) (string, *Quota) {
+			return r.Header.Get("X-Test-Token"), nil
+		},
+	}
+	limiter := NewIPRateLimiter(rate.Every(time.Minute), 10)
+	handler := APIRateLimit(limiter, budgets.Key())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	member := &models.User{ID: uuid.New()}
+	staff := &models.User{ID: uuid.New(), IsStaff: true}
+	tests := []struct {
+		name  string
+		user  *models.User
+		token string
+		burst int
+	}{
+		{"anonymous", nil, "", 1},
+		{"member", member, "", 2},
+		{"staff", staff, "", 3},
+		{"token", member, "abc", 2}, // Tokens without a quota get the authenticated budget
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serve := func() *httptest.ResponseRecorder {
+				req := httptest.NewRequest("GET", "/api/v1/posts", nil)
+				req.RemoteAddr = "192.168.1.1:12345"
+				req.Header.Set("X-Test-Token", tt.token)
+				if tt.user != nil {
+					req = req.WithContext(WithUser(req.Context(), tt.user))
+				}
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+				return w
+			}
+
+			for i := 0; i < tt.burst; i++ {
+				if w := serve(); w.Code != http.StatusOK {
+					t.Fatalf("Request %d: Expected status 200, got %d", i+1, w.Code)
+				} else if w.Header().Get("X-RateLimit-Limit") != strconv.Itoa(tt.burst) {
+					t.Errorf("Expected X-RateLimit-Limit %d, got %q", tt.burst, w.Header().Get("X-RateLimit-Limit"))
+				}
+			}
+			if w := serve(); w.Code != http.StatusTooManyRequests {
+				t.Errorf("Expected the %s budget to be exhausted, got %d", tt.name, w.Code)
+			}
+		})
+	}
+
+	// A zero quota falls back to the limiter's defaults
+	_, quota := Budgets{}.Key()(httptest.NewRequest("GET", "/", nil))
+	if quota != nil {
+		t.Errorf("Expected no quota for an empty budget, got %+v", quota)
+	}
+}
+
+func TestGetLimiter_QuotaChange(t *testing.T) {
+	limiter := NewIPRateLimiter(rate.Every(time.Second), 5)
+
+	l := limiter.getLimiter("token:abc", &Quota{Rate: rate.Every(time.Second), Burst: 5})
+	limiter.getLimiter("token:abc", &Quota{Rate: rate.Limit(10), Burst: 50})
+
+	if l.Burst() != 50 || l.Limit() != rate.Limit(10) {
+		t.Errorf("Expected updated quota 10/s burst 50, got %v/s burst %d", l.Limit(), l.Burst())
+	}
+}
+
 func TestAuthRateLimiter(t *testing.T) {
 	limiter := AuthRateLimiter()
 
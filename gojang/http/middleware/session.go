@@ -1,23 +1,122 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
 	"time"
 
 	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/utils"
 
 	"github.com/alexedwards/scs/v2"
 )
 
-// NewSessionManager creates a configured session manager
+// SessionCookieName is the session cookie. API clients sign in as a user by
+// sending its value (see `gojang gen client`).
+const SessionCookieName = "session_id"
+
+// NewSessionManager creates a configured session manager. Sessions end
+// SESSION_LIFETIME after signing in (signing in renews the token, which
+// restarts the lifetime) or after SESSION_IDLE_TIMEOUT without a request.
 func NewSessionManager(cfg *config.Config) *scs.SessionManager {
 	sessionManager := scs.New()
 	sessionManager.Lifetime = cfg.SessionLifetime
-	sessionManager.Cookie.Name = "session_id"
+	sessionManager.Cookie.Name = SessionCookieName
 	sessionManager.Cookie.HttpOnly = true
 	sessionManager.Cookie.Secure = !cfg.Debug // true in production
 	sessionManager.Cookie.SameSite = 2        // Lax mode (allows navigation)
 	sessionManager.Cookie.Path = "/"          // Cookie available for entire site
-	sessionManager.IdleTimeout = 30 * time.Minute
+	sessionManager.IdleTimeout = cfg.SessionIdleTimeout
 
 	return sessionManager
 }
+
+// privilegesKey holds the signed-in user's privileges when the session token
+// was last issued, so LoadUser can issue a new one when they change
+const privilegesKey = "user_privileges"
+
+// SignIn signs u in to the request's session. The token is renewed so one set
+// before signing in (session fixation) can't be used to act as u.
+func SignIn(ctx context.Context, sm *scs.SessionManager, u *models.User) error {
+	sm.Put(ctx, "user_id", u.ID.String())
+	sm.Put(ctx, privilegesKey, privileges(u))
+	return sm.RenewToken(ctx)
+}
+
+// privileges sums up what u may do beyond a regular user
+func privileges(u *models.User) string {
+	switch {
+	case u.IsSuperuser && u.IsStaff:
+		return "staff,superuser"
+	case u.IsSuperuser:
+		return "superuser"
+	case u.IsStaff:
+		return "staff"
+	}
+	return ""
+}
+
+// renewOnPrivilegeChange gives the session a new token when the signed-in
+// user's privileges have changed since it was issued (e.g., an admin made them
+// staff), as signing in does. A login tracked for SESSION_LIMIT follows it to
+// the new token. The session keeps its deadline, so renewing never extends
+// SESSION_LIFETIME.
+func renewOnPrivilegeChange(ctx context.Context, sm *scs.SessionManager, client *models.Client, u *models.User) {
+	current := privileges(u)
+	if !sm.Exists(ctx, privilegesKey) {
+		// Signed in before privileges were tracked
+		sm.Put(ctx, privilegesKey, current)
+		return
+	}
+	if sm.GetString(ctx, privilegesKey) == current {
+		return
+	}
+
+	// RenewToken resets the deadline to Lifetime from now
+	old, deadline := sm.Token(ctx), sm.Deadline(ctx)
+	if err := sm.RenewToken(ctx); err != nil {
+		utils.Warnw("session.renew_failed", "user_id", u.ID, "error", err)
+		return
+	}
+	sm.SetDeadline(ctx, deadline)
+	sm.Put(ctx, privilegesKey, current)
+	if old != "" {
+		err := client.LoginEvent.Update().Where(loginevent.Session(old)).SetSession(sm.Token(ctx)).Exec(ctx)
+		if err != nil {
+			utils.Warnw("session.login_update_failed", "user_id", u.ID, "error", err)
+		}
+	}
+	utils.Infow("session.renewed", "user_id", u.ID, "privileges", current)
+}
+
+// SessionExpiry is when a signed-in user's session ends, for the warning
+// pages show before it does (the "session_expiry" component)
+type SessionExpiry struct {
+	Idle      time.Duration // Ends after this long without a request; 0 if it doesn't
+	Remaining time.Duration // Ends after this long however active the user is
+	Warn      time.Duration // How long before the end to warn
+}
+
+// IdleSeconds, RemainingSeconds and WarnSeconds are the durations in whole
+// seconds, for data attributes
+func (e *SessionExpiry) IdleSeconds() int      { return int(e.Idle.Seconds()) }
+func (e *SessionExpiry) RemainingSeconds() int { return int(e.Remaining.Seconds()) }
+func (e *SessionExpiry) WarnSeconds() int      { return int(e.Warn.Seconds()) }
+
+// SessionExpiryFunc returns the session expiry of the signed-in user making a
+// request, for the renderers (see Renderer.UseSessionExpiry). It's nil for
+// guests, and always nil when warn is 0.
+func SessionExpiryFunc(sm *scs.SessionManager, warn time.Duration) func(*http.Request) *SessionExpiry {
+	return func(r *http.Request) *SessionExpiry {
+		if warn <= 0 || GetUser(r.Context()) == nil {
+			return nil
+		}
+		return &SessionExpiry{
+			Idle:      sm.IdleTimeout,
+			Remaining: time.Until(sm.Deadline(r.Context())),
+			Warn:      warn,
+		}
+	}
+}
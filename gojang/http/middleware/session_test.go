@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+// TestSessionExpiryFunc tests that signed-in users get their session's timeouts and guests nothing
+func TestSessionExpiryFunc(t *testing.T) {
+	sm := scs.New()
+	sm.Lifetime = 12 * time.Hour
+	sm.IdleTimeout = 30 * time.Minute
+	ctx, err := sm.Load(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	guest := httptest.NewRequest("GET", "/", nil).WithContext(ctx)
+	user := guest.WithContext(WithUser(ctx, &models.User{ID: uuid.New()}))
+
+	expiry := SessionExpiryFunc(sm, 2*time.Minute)
+	if e := expiry(guest); e != nil {
+		t.Errorf("Expected no expiry for guests, got %+v", e)
+	}
+
+	e := expiry(user)
+	if e == nil {
+		t.Fatal("Expected an expiry for a signed-in user")
+	}
+	if e.IdleSeconds() != 1800 || e.WarnSeconds() != 120 {
+		t.Errorf("Expected 1800s idle and 120s warning, got %d and %d", e.IdleSeconds(), e.WarnSeconds())
+	}
+	if r := e.RemainingSeconds(); r <= 12*3600-5 || r > 12*3600 {
+		t.Errorf("Expected about 12h remaining, got %ds", r)
+	}
+
+	if e := SessionExpiryFunc(sm, 0)(user); e != nil {
+		t.Errorf("Expected no expiry without a warning, got %+v", e)
+	}
+}
+
+// TestLoadUser_RenewsOnPrivilegeChange tests that a session gets a new token
+// once its user is made staff, keeping its deadline, and its tracked login
+// follows it
+func TestLoadUser_RenewsOnPrivilegeChange(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := t.Context()
+	u := client.User.Create().SetEmail("member@example.com").SetPasswordHash("x").SetIsActive(true).SaveX(ctx)
+	sm := scs.New()
+
+	// serve runs h with the session cookie token, returning the token it ends with
+	serve := func(h http.Handler, token string) string {
+		t.Helper()
+		r := httptest.NewRequest("GET", "/", nil)
+		if token != "" {
+			r.AddCookie(&http.Cookie{Name: sm.Cookie.Name, Value: token})
+		}
+		w := httptest.NewRecorder()
+		sm.LoadAndSave(h).ServeHTTP(w, r)
+		for _, c := range w.Result().Cookies() {
+			if c.Name == sm.Cookie.Name {
+				return c.Value
+			}
+		}
+		return token
+	}
+	signIn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := SignIn(r.Context(), sm, u); err != nil {
+			t.Fatalf("SignIn failed: %v", err)
+		}
+	})
+	page := LoadUser(sm, client)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetUser(r.Context()) == nil {
+			t.Error("Expected the user to stay signed in")
+		}
+	}))
+
+	guest := serve(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { sm.Put(r.Context(), "cart", "1") }), "")
+	token := serve(signIn, guest)
+	if token == guest {
+		t.Fatal("Expected signing in to renew the token")
+	}
+	login := client.LoginEvent.Create().SetUser(u).SetSuccess(true).SetIP("127.0.0.1").
+		SetUserAgent("test").SetSession(token).SaveX(ctx)
+
+	if got := serve(page, token); got != token {
+		t.Error("Expected the token to stay the same while privileges do")
+	}
+
+	// As if the session began most of its lifetime ago
+	deadline := time.Now().Add(time.Hour).Round(0)
+	serve(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { sm.SetDeadline(r.Context(), deadline) }), token)
+
+	client.User.UpdateOne(u).SetIsStaff(true).ExecX(ctx)
+	renewed := serve(page, token)
+	if renewed == token {
+		t.Fatal("Expected a new token after the user was made staff")
+	}
+	b, _, _ := sm.Store.Find(renewed)
+	if got, _, err := sm.Codec.Decode(b); err != nil || !got.Equal(deadline) {
+		t.Errorf("Expected the renewed session to keep its deadline %v, got %v (%v)", deadline, got, err)
+	}
+	if _, found, _ := sm.Store.Find(token); found {
+		t.Error("Expected the old token to stop working")
+	}
+	if got := client.LoginEvent.GetX(ctx, login.ID).Session; got != renewed {
+		t.Error("Expected the tracked login to follow the new token")
+	}
+	if got := serve(page, renewed); got != renewed {
+		t.Error("Expected the token to stay the same after renewing")
+	}
+}
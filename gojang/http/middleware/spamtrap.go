@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// SpamTrap rejects form submissions that fill in the honeypot field or arrive
+// without a valid time-trap token, or sooner than the configured delay after the
+// form was rendered (see utils.SetSpamTrap). The form must include {{spamTrap}}.
+// GET and DELETE requests pass through. Rejections get onReject (a plain 400
+// when nil), so people who were just quick can go back and submit again.
+func SpamTrap(onReject http.Handler) func(http.Handler) http.Handler {
+	if onReject == nil {
+		onReject = http.HandlerFunc(defaultSpamTrapHandler)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost && r.Method != http.MethodPut && r.Method != http.MethodPatch {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// PostFormValue parses both urlencoded and multipart bodies; handlers
+			// calling ParseForm afterwards get the already parsed values
+			r.PostFormValue(utils.TimeTrapField)
+			if err := utils.CheckSpamTrap(r.PostForm); err != nil {
+				utils.Warnw("spam.rejected",
+					"path", r.URL.Path,
+					"ip", getRealIP(r),
+					"reason", err.Error(),
+				)
+				onReject.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func defaultSpamTrapHandler(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "Your submission could not be accepted. Please go back, wait a moment and try again.", http.StatusBadRequest)
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+func TestSpamTrap(t *testing.T) {
+	defer utils.SetSpamTrap("gojang-spam-trap", 2*time.Second)
+	utils.SetSpamTrap("test-key", 0)
+
+	handler := SpamTrap(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	token := regexp.MustCompile(`name="hp_issued" value="([^"]+)"`).FindStringSubmatch(utils.SpamTrapFields())[1]
+
+	tests := map[string]struct {
+		method string
+		form   url.Values
+		want   int
+	}{
+		"valid post":     {http.MethodPost, url.Values{"hp_issued": {token}, "hp_website": {""}}, http.StatusNoContent},
+		"valid put":      {http.MethodPut, url.Values{"hp_issued": {token}}, http.StatusNoContent},
+		"honeypot":       {http.MethodPost, url.Values{"hp_issued": {token}, "hp_website": {"spam"}}, http.StatusBadRequest},
+		"missing token":  {http.MethodPost, url.Values{"subject": {"hi"}}, http.StatusBadRequest},
+		"get ignored":    {http.MethodGet, nil, http.StatusNoContent},
+		"delete ignored": {http.MethodDelete, nil, http.StatusNoContent},
+	}
+	for name, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/posts", strings.NewReader(tt.form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected status %d, got %d", name, tt.want, rec.Code)
+		}
+	}
+}
+
+func TestSpamTrap_TooFast(t *testing.T) {
+	defer utils.SetSpamTrap("gojang-spam-trap", 2*time.Second)
+	utils.SetSpamTrap("test-key", time.Minute)
+
+	rejected := false
+	handler := SpamTrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rejected = true
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected a form submitted right away not to reach the handler")
+	}))
+
+	token := regexp.MustCompile(`name="hp_issued" value="([^"]+)"`).FindStringSubmatch(utils.SpamTrapFields())[1]
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(url.Values{"hp_issued": {token}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !rejected {
+		t.Error("Expected the onReject handler to run")
+	}
+}
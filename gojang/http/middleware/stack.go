@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Phase is the stage of a request a global middleware belongs to. Phases run
+// in this order, so, e.g., anything in PhaseApp can rely on the user being
+// loaded.
+type Phase int
+
+const (
+	PhaseCore     Phase = iota // Request IDs, client IPs, logging, panic recovery
+	PhaseSecurity              // HTTPS redirects and security headers
+	PhaseSession               // Loading and saving the session
+	PhaseAuth                  // Loading the user from the session
+	PhaseApp                   // Everything else (tenants, locales, feature flags)
+)
+
+var phaseNames = [...]string{"core", "security", "session", "auth", "app"}
+
+func (p Phase) String() string {
+	if p < 0 || int(p) >= len(phaseNames) {
+		return fmt.Sprintf("Phase(%d)", int(p))
+	}
+	return phaseNames[p]
+}
+
+// Entry is a middleware registered in a Stack
+type Entry struct {
+	Name     string // Unique, e.g., "session"
+	Phase    Phase
+	Priority int                             // Lower runs first within the phase
+	Handler  func(http.Handler) http.Handler // The middleware
+	After    []string                        // Entries that must run before this one
+}
+
+// Stack orders global middleware by phase and priority, so apps and plugins
+// can add their own between the built-in ones instead of editing the list in
+// app.go:
+//
+//	stack.Add(middleware.Entry{Name: "tenant", Phase: middleware.PhaseApp, Handler: resolveTenant, After: []string{"load_user"}})
+//
+// The built-in entries use priorities 0, 10, 20 and so on within each phase,
+// leaving room in between.
+type Stack struct {
+	entries []Entry
+}
+
+// Add registers entries. Conflicts are reported by Build, so every problem
+// shows up at once.
+func (s *Stack) Add(entries ...Entry) {
+	s.entries = append(s.entries, entries...)
+}
+
+// Build returns the middleware in the order they run. It fails when two
+// entries share a name, two share a phase and priority (their order would be
+// arbitrary), or an entry runs before one listed in its After.
+func (s *Stack) Build() ([]func(http.Handler) http.Handler, error) {
+	entries, err := s.sorted()
+	if err != nil {
+		return nil, err
+	}
+	handlers := make([]func(http.Handler) http.Handler, len(entries))
+	for i, e := range entries {
+		handlers[i] = e.Handler
+	}
+	return handlers, nil
+}
+
+// sorted validates the entries and returns them in the order they run
+func (s *Stack) sorted() ([]Entry, error) {
+	entries := append([]Entry(nil), s.entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Phase != entries[j].Phase {
+			return entries[i].Phase < entries[j].Phase
+		}
+		return entries[i].Priority < entries[j].Priority
+	})
+
+	var problems []string
+	position := make(map[string]int, len(entries))
+	for i, e := range entries {
+		switch {
+		case e.Name == "":
+			problems = append(problems, fmt.Sprintf("entry %d in phase %s has no name", i, e.Phase))
+		case e.Handler == nil:
+			problems = append(problems, fmt.Sprintf("%q has no handler", e.Name))
+		}
+		if _, ok := position[e.Name]; ok && e.Name != "" {
+			problems = append(problems, fmt.Sprintf("%q is registered twice", e.Name))
+		}
+		position[e.Name] = i
+		if i > 0 {
+			prev := entries[i-1]
+			if prev.Phase == e.Phase && prev.Priority == e.Priority {
+				problems = append(problems, fmt.Sprintf("%q and %q share phase %s and priority %d", prev.Name, e.Name, e.Phase, e.Priority))
+			}
+		}
+	}
+	for _, e := range entries {
+		for _, name := range e.After {
+			before, ok := position[name]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("%q runs after %q, which isn't registered", e.Name, name))
+			case before > position[e.Name]:
+				problems = append(problems, fmt.Sprintf("%q must run after %q; raise its phase or priority", e.Name, name))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("middleware stack: %s", strings.Join(problems, "; "))
+	}
+	return entries, nil
+}
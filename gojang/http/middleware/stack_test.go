@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gojangframework/gojang/gojang/config"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+)
+
+// tagger returns a middleware that appends name to the X-Order header
+func tagger(name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Order", name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TestStack_Order tests that entries run by phase, then priority, whatever order they're added in
+func TestStack_Order(t *testing.T) {
+	s := &Stack{}
+	s.Add(
+		Entry{Name: "tenant", Phase: PhaseApp, Handler: tagger("tenant"), After: []string{"user"}},
+		Entry{Name: "user", Phase: PhaseAuth, Handler: tagger("user"), After: []string{"session"}},
+		Entry{Name: "logger", Phase: PhaseCore, Priority: 10, Handler: tagger("logger")},
+		Entry{Name: "session", Phase: PhaseSession, Handler: tagger("session")},
+		Entry{Name: "request_id", Phase: PhaseCore, Handler: tagger("request_id")},
+		Entry{Name: "headers", Phase: PhaseSecurity, Handler: tagger("headers")},
+	)
+	mws, err := s.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := "request_id,logger,headers,session,user,tenant"
+	if got := strings.Join(w.Header().Values("X-Order"), ","); got != want {
+		t.Errorf("Expected order %s, got %s", want, got)
+	}
+}
+
+// TestStack_Conflicts tests that Build reports every conflict
+func TestStack_Conflicts(t *testing.T) {
+	noop := func(next http.Handler) http.Handler { return next }
+	tests := []struct {
+		name    string
+		entries []Entry
+		want    string
+	}{
+		{"duplicate name", []Entry{{Name: "a", Handler: noop}, {Name: "a", Priority: 1, Handler: noop}}, `"a" is registered twice`},
+		{"same priority", []Entry{{Name: "a", Handler: noop}, {Name: "b", Handler: noop}}, `"a" and "b" share phase core and priority 0`},
+		{"wrong order", []Entry{{Name: "a", Handler: noop, After: []string{"b"}}, {Name: "b", Phase: PhaseApp, Handler: noop}}, `"a" must run after "b"`},
+		{"unknown after", []Entry{{Name: "a", Handler: noop, After: []string{"missing"}}}, `"a" runs after "missing", which isn't registered`},
+		{"no handler", []Entry{{Name: "a"}}, `"a" has no handler`},
+		{"no name", []Entry{{Phase: PhaseAuth, Handler: noop}}, "entry 0 in phase auth has no name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{}
+			s.Add(tt.entries...)
+			if _, err := s.Build(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// BenchmarkStack measures the global middleware the app runs on every request
+// (less request logging), for a guest and for a signed-in user, whose session
+// and user are loaded
+func BenchmarkStack(b *testing.B) {
+	client := testdb.Open(b)
+	u := client.User.Create().SetEmail("member@example.com").SetPasswordHash("x").SetIsActive(true).SaveX(b.Context())
+	sm := scs.New()
+	cfg := &config.Config{}
+
+	s := &Stack{}
+	s.Add(
+		Entry{Name: "request_id", Phase: PhaseCore, Priority: 0, Handler: chimiddleware.RequestID},
+		Entry{Name: "real_ip", Phase: PhaseCore, Priority: 10, Handler: chimiddleware.RealIP},
+		Entry{Name: "recoverer", Phase: PhaseCore, Priority: 30, Handler: chimiddleware.Recoverer},
+		Entry{Name: "enforce_https", Phase: PhaseSecurity, Priority: 0, Handler: EnforceHTTPS(cfg)},
+		Entry{Name: "security_headers", Phase: PhaseSecurity, Priority: 10, Handler: SecurityHeaders(cfg)},
+		Entry{Name: "session", Phase: PhaseSession, Priority: 0, Handler: sm.LoadAndSave},
+		Entry{Name: "load_user", Phase: PhaseAuth, Priority: 0, Handler: LoadUser(sm, client), After: []string{"session"}},
+		Entry{Name: "locale", Phase: PhaseApp, Priority: -10, Handler: Locale},
+	)
+	mws, err := s.Build()
+	if err != nil {
+		b.Fatalf("Build: %v", err)
+	}
+	chain := func(h http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			h = mws[i](h)
+		}
+		return h
+	}
+
+	// Sign in to get a session cookie
+	w := httptest.NewRecorder()
+	sm.LoadAndSave(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := SignIn(r.Context(), sm, u); err != nil {
+			b.Fatalf("SignIn failed: %v", err)
+		}
+	})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := w.Result().Cookies()
+
+	for _, bm := range []struct {
+		name    string
+		cookies []*http.Cookie
+	}{
+		{"Guest", nil},
+		{"SignedIn", cookies},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if (GetUser(r.Context()) != nil) != (bm.cookies != nil) {
+					b.Fatal("Expected only the signed-in request to have a user")
+				}
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Forwarded-Proto", "https")
+			for _, c := range bm.cookies {
+				req.AddCookie(c)
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				h.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		})
+	}
+}
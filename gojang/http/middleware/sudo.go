@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// Session keys of sudo mode: who confirmed their password, and when (Unix seconds)
+const (
+	sudoUserKey = "sudo_user"
+	sudoAtKey   = "sudo_at"
+)
+
+// Sudo is "sudo mode": staff confirm their password before destructive
+// actions (e.g., deleting a user or changing permissions), and aren't asked
+// again until Window has passed. The confirmation is kept in the session.
+// A nil *Sudo turns sudo mode off.
+type Sudo struct {
+	Sessions  *scs.SessionManager
+	Window    time.Duration // How long a confirmation lasts
+	PromptURL string        // Page asking for the password; it gets ?next= to return to
+}
+
+// Active reports whether the signed-in user confirmed their password within Window
+func (s *Sudo) Active(r *http.Request) bool {
+	if s == nil {
+		return true
+	}
+	user := GetUser(r.Context())
+	if user == nil || s.Sessions.GetString(r.Context(), sudoUserKey) != user.ID.String() {
+		return false
+	}
+	at := s.Sessions.GetInt64(r.Context(), sudoAtKey)
+	return at != 0 && time.Since(time.Unix(at, 0)) < s.Window
+}
+
+// Expires returns when the request's sudo mode ends (zero if it isn't active)
+func (s *Sudo) Expires(r *http.Request) time.Time {
+	if s == nil || !s.Active(r) {
+		return time.Time{}
+	}
+	return time.Unix(s.Sessions.GetInt64(r.Context(), sudoAtKey), 0).Add(s.Window)
+}
+
+// Grant starts sudo mode for userID, who just confirmed their password
+func (s *Sudo) Grant(ctx context.Context, userID uuid.UUID) {
+	s.Sessions.Put(ctx, sudoUserKey, userID.String())
+	s.Sessions.Put(ctx, sudoAtKey, time.Now().Unix())
+}
+
+// Require is middleware that sends requests outside sudo mode to Prompt
+func (s *Sudo) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.Active(r) {
+			s.Prompt(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Prompt sends the user to PromptURL to confirm their password. Afterwards
+// they return to the page they were on and repeat the action.
+func (s *Sudo) Prompt(w http.ResponseWriter, r *http.Request) {
+	next := r.URL.RequestURI()
+	if htmx.IsRequest(r) || r.Method != http.MethodGet {
+		// Return to the page the request came from, not the action itself
+		next = localPath(htmx.CurrentURL(r), r.Referer())
+	}
+	target := s.PromptURL + "?next=" + url.QueryEscape(next)
+
+	utils.Infow("sudo.required", "method", r.Method, "path", r.URL.Path)
+	if htmx.IsRequest(r) {
+		htmx.Redirect(w, target)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Confirm your password at "+s.PromptURL+" to continue", http.StatusForbidden)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// localPath returns the path and query of the first URL given, or "/"
+func localPath(urls ...string) string {
+	for _, raw := range urls {
+		if u, err := url.Parse(raw); err == nil && raw != "" {
+			return u.RequestURI()
+		}
+	}
+	return "/"
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+// sudoRequest returns sudo mode and a request from user carrying a fresh session
+func sudoRequest(t *testing.T, method, target string, user *models.User) (*Sudo, *http.Request) {
+	t.Helper()
+	sm := scs.New()
+	ctx, err := sm.Load(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	r := httptest.NewRequest(method, target, nil)
+	r = r.WithContext(WithUser(ctx, user))
+	return &Sudo{Sessions: sm, Window: 15 * time.Minute, PromptURL: "/admin/sudo"}, r
+}
+
+// TestSudo_Active tests that sudo mode starts with Grant, is bound to the user and expires
+func TestSudo_Active(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	sudo, r := sudoRequest(t, http.MethodGet, "/admin", user)
+
+	if sudo.Active(r) {
+		t.Fatal("Expected sudo mode to be off before Grant")
+	}
+	sudo.Grant(r.Context(), user.ID)
+	if !sudo.Active(r) || sudo.Expires(r).IsZero() {
+		t.Fatal("Expected sudo mode after Grant")
+	}
+
+	// Another user signing in with the same session isn't in sudo mode
+	other := r.WithContext(WithUser(r.Context(), &models.User{ID: uuid.New()}))
+	if sudo.Active(other) {
+		t.Error("Expected sudo mode to be bound to the user who confirmed")
+	}
+
+	sudo.Sessions.Put(r.Context(), sudoAtKey, time.Now().Add(-time.Hour).Unix())
+	if sudo.Active(r) {
+		t.Error("Expected sudo mode to expire after Window")
+	}
+
+	var off *Sudo
+	if !off.Active(r) {
+		t.Error("Expected a nil Sudo to allow everything")
+	}
+}
+
+// TestSudo_Require tests that requests outside sudo mode are sent to the prompt
+func TestSudo_Require(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	user := &models.User{ID: uuid.New()}
+
+	tests := []struct {
+		name     string
+		method   string
+		htmx     bool
+		status   int
+		location string
+	}{
+		{"page", http.MethodGet, false, http.StatusSeeOther, "/admin/sudo?next=%2Fadmin%2Fuser%2F1%2Fdelete"},
+		{"form post", http.MethodPost, false, http.StatusForbidden, ""},
+		{"htmx", http.MethodPost, true, http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		sudo, r := sudoRequest(t, tt.method, "/admin/user/1/delete", user)
+		if tt.htmx {
+			r.Header.Set("HX-Request", "true")
+			r.Header.Set("HX-Current-URL", "http://example.com/admin/user?page=2")
+		}
+		w := httptest.NewRecorder()
+		sudo.Require(ok).ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.status)
+		}
+		if tt.location != "" && w.Header().Get("Location") != tt.location {
+			t.Errorf("%s: redirected to %q", tt.name, w.Header().Get("Location"))
+		}
+		if tt.htmx && w.Header().Get("HX-Redirect") != "/admin/sudo?next=%2Fadmin%2Fuser%3Fpage%3D2" {
+			t.Errorf("%s: HX-Redirect %q", tt.name, w.Header().Get("HX-Redirect"))
+		}
+
+		sudo.Grant(r.Context(), user.ID)
+		w = httptest.NewRecorder()
+		sudo.Require(ok).ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status %d in sudo mode", tt.name, w.Code)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// Timeout cancels the request context after d. Because handlers pass r.Context()
+// to Ent, in-flight queries are aborted too. Responses are buffered until the
+// handler returns; if the deadline passes first, onTimeout writes the response
+// instead (a plain 503 page when nil). Requests abandoned by the client are logged
+// separately and get no response.
+func Timeout(d time.Duration, onTimeout http.Handler) func(http.Handler) http.Handler {
+	if onTimeout == nil {
+		onTimeout = http.HandlerFunc(defaultTimeoutHandler)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			r = r.WithContext(ctx)
+
+			tw := &timeoutWriter{header: make(http.Header)}
+			done := make(chan struct{})
+			panicChan := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicChan <- p
+					}
+				}()
+				next.ServeHTTP(tw, r)
+				close(done)
+			}()
+
+			select {
+			case p := <-panicChan:
+				// Re-panic on the request goroutine so Recoverer sees it
+				panic(p)
+
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				for k, v := range tw.header {
+					w.Header()[k] = v
+				}
+				if tw.status == 0 {
+					tw.status = http.StatusOK
+				}
+				w.WriteHeader(tw.status)
+				_, _ = w.Write(tw.buf.Bytes())
+
+			case <-ctx.Done():
+				tw.mu.Lock()
+				tw.timedOut = true
+				tw.mu.Unlock()
+
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					utils.Warnw("request_timeout",
+						"method", r.Method,
+						"path", r.URL.Path,
+						"timeout", d.String(),
+						"ip", getRealIP(r),
+					)
+					onTimeout.ServeHTTP(w, r)
+					return
+				}
+
+				// Parent context was canceled: the client went away
+				utils.Infow("client_disconnected",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"ip", getRealIP(r),
+				)
+			}
+		})
+	}
+}
+
+// defaultTimeoutHandler writes a minimal 503 page
+func defaultTimeoutHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write([]byte(`<div class="error"><h2>Request timed out</h2><p>The server took too long to respond. Please try again.</p></div>`))
+}
+
+// timeoutWriter buffers a handler's response so it can be discarded on timeout
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	status   int
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.buf.Write(p)
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.status != 0 {
+		return
+	}
+	tw.status = status
+}
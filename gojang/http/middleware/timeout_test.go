@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTimeout_FastHandler tests that responses within the deadline pass through unchanged
+func TestTimeout_FastHandler(t *testing.T) {
+	handler := Timeout(time.Second, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "yes")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", rec.Code)
+	}
+	if rec.Body.String() != "created" {
+		t.Errorf("Expected body 'created', got %q", rec.Body.String())
+	}
+	if rec.Header().Get("X-Test") != "yes" {
+		t.Error("Expected handler headers to be copied")
+	}
+}
+
+// TestTimeout_SlowHandler tests that a slow handler gets its context canceled and a 503 is returned
+func TestTimeout_SlowHandler(t *testing.T) {
+	canceled := make(chan struct{})
+	handler := Timeout(20*time.Millisecond, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(canceled)
+		w.Write([]byte("too late"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "too late") {
+		t.Error("Expected late handler output to be discarded")
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("Expected handler context to be canceled")
+	}
+}
+
+// TestTimeout_CustomPage tests that the onTimeout handler renders the timeout response
+func TestTimeout_CustomPage(t *testing.T) {
+	page := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("custom"))
+	})
+	handler := Timeout(10*time.Millisecond, page)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Body.String() != "custom" {
+		t.Errorf("Expected custom timeout page, got %q", rec.Body.String())
+	}
+}
+
+// TestTimeout_ClientDisconnect tests that a canceled client request gets no timeout page
+func TestTimeout_ClientDisconnect(t *testing.T) {
+	handler := Timeout(time.Second, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	cancel()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Body.Len() != 0 {
+		t.Errorf("Expected empty body for disconnected client, got %q", rec.Body.String())
+	}
+}
+
+// TestTimeout_PanicPropagates tests that handler panics reach the caller's goroutine
+func TestTimeout_PanicPropagates(t *testing.T) {
+	handler := Timeout(time.Second, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic to propagate")
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
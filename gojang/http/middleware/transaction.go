@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// Transaction runs each POST, PUT, PATCH and DELETE request in one Ent
+// transaction, so a handler's writes are saved together or not at all.
+// Handlers get it with db.TxFromContext or db.ClientFromContext.
+//
+// The response is buffered until the handler returns. The transaction commits
+// when the status is below 400 and rolls back on an error status or a panic.
+// If the commit fails, the buffered response is dropped and a 500 is sent.
+// Other methods pass through without a transaction.
+func Transaction(client *models.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+			// Nested uses share the outer transaction
+			if ctxutil.Tx(r.Context()) != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tx, err := client.Tx(r.Context())
+			if err != nil {
+				utils.Errorw("db.tx_begin_failed", "path", r.URL.Path, "error", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			tw := &txWriter{header: make(http.Header)}
+			committed := false
+			defer func() {
+				// Covers panics and error statuses; a no-op after Commit
+				if !committed {
+					if err := tx.Rollback(); err != nil {
+						utils.Errorw("db.tx_rollback_failed", "path", r.URL.Path, "error", err)
+					}
+				}
+			}()
+
+			next.ServeHTTP(tw, r.WithContext(ctxutil.WithTx(r.Context(), tx)))
+
+			if tw.status == 0 {
+				tw.status = http.StatusOK
+			}
+			if tw.status < http.StatusBadRequest {
+				// Commit ends the transaction even when it fails
+				committed = true
+				if err := tx.Commit(); err != nil {
+					utils.Errorw("db.tx_commit_failed", "path", r.URL.Path, "error", err)
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+			}
+
+			for k, v := range tw.header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(tw.status)
+			_, _ = w.Write(tw.buf.Bytes())
+		})
+	}
+}
+
+// txWriter buffers a response until its transaction has ended
+type txWriter struct {
+	header http.Header
+	buf    bytes.Buffer
+	status int
+}
+
+func (tw *txWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *txWriter) WriteHeader(status int) {
+	if tw.status == 0 {
+		tw.status = status
+	}
+}
+
+func (tw *txWriter) Write(b []byte) (int, error) {
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.buf.Write(b)
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models/db"
+)
+
+// TestTransaction tests that writes commit or roll back with the response status
+func TestTransaction(t *testing.T) {
+	client := testdb.Open(t)
+
+	// Creates a user, then responds with the status in the query string
+	handler := Transaction(client)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (db.TxFromContext(r.Context()) != nil) != (r.Method == http.MethodPost) {
+			t.Errorf("Expected a transaction only for POST, got one for %s", r.Method)
+		}
+		email := r.URL.Query().Get("email")
+		if email != "" {
+			db.ClientFromContext(r.Context(), client).User.Create().
+				SetEmail(email).SetPasswordHash("x").SaveX(r.Context())
+		}
+		if r.URL.Query().Get("panic") != "" {
+			panic("boom")
+		}
+		w.Header().Set("Location", "/done")
+		status, _ := strconv.Atoi(r.URL.Query().Get("status"))
+		w.WriteHeader(status)
+	}))
+
+	serve := func(method, query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/?"+query, nil))
+		return rec
+	}
+
+	if rec := serve(http.MethodPost, "email=a@example.com&status=303"); rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/done" {
+		t.Errorf("Expected the handler's redirect, got %d %v", rec.Code, rec.Header())
+	}
+	if serve(http.MethodPost, "email=b@example.com&status=422").Code != http.StatusUnprocessableEntity {
+		t.Error("Expected the handler's error status")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the panic to propagate")
+			}
+		}()
+		serve(http.MethodPost, "email=c@example.com&panic=1")
+	}()
+	serve(http.MethodGet, "status=200")
+
+	var emails []string
+	for _, u := range client.User.Query().AllX(t.Context()) {
+		emails = append(emails, u.Email)
+	}
+	if len(emails) != 1 || emails[0] != "a@example.com" {
+		t.Errorf("Expected only the successful request's write, got %v", emails)
+	}
+}
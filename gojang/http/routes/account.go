@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/justinas/nosurf"
+)
+
+func AccountRoutes(handler *handlers.AccountHandler, sm *scs.SessionManager, client *models.Client) chi.Router {
+	r := chi.NewRouter()
+	r.Use(nosurf.NewPure)
+	r.Use(middleware.RequireAuth(sm, client))
+
+	// Recent sign-ins, with failed attempts and new devices flagged
+	r.Get("/security", handler.Security)
+
+	// Passkeys, when enabled with PASSKEYS=true
+	if handler.Passkeys != nil {
+		r.Post("/passkeys/options", handler.PasskeyOptions)
+		r.Post("/passkeys", handler.PasskeyCreate)
+		r.Post("/passkeys/{id}/delete", handler.PasskeyDelete)
+	}
+
+	return r
+}
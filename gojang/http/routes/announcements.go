@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+	"github.com/justinas/nosurf"
+)
+
+// AnnouncementRoutes lets guests and users dismiss announcement banners
+func AnnouncementRoutes(handler *handlers.AnnouncementHandler) chi.Router {
+	r := chi.NewRouter()
+	r.Use(nosurf.NewPure)
+
+	r.Post("/{id}/dismiss", handler.Dismiss)
+
+	return r
+}
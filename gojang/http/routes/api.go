@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/api"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+)
+
+// APIRoutes serves the JSON API (mounted at /api). Add a Version here when a
+// change would break existing clients, and set Deprecated on the old one.
+// batch is admin.BatchHandler, which brings its own login and CSRF checks.
+func APIRoutes(posts *handlers.PostAPIHandler, jobs *handlers.JobHandler, batch http.Handler) chi.Router {
+	v1 := api.Version{
+		Name: "v1",
+		Routes: func(r chi.Router) {
+			r.Get("/posts", posts.List)
+			r.Get("/posts/{id}", posts.Get)
+			r.Get("/jobs/{id}", jobs.Status)
+			r.Method(http.MethodPost, "/{model}/batch", batch)
+		},
+		Deprecated: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), // v2 wraps responses in an envelope
+	}
+	v2 := api.Version{
+		Name: "v2",
+		Routes: func(r chi.Router) {
+			r.Get("/posts", posts.ListV2)
+			r.Get("/posts/{id}", posts.GetV2)
+			r.Get("/jobs/{id}", jobs.Status) // Unchanged from v1
+			r.Method(http.MethodPost, "/{model}/batch", batch)
+		},
+	}
+	return api.Router(v1, v2)
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestAPIRoutes_Batch tests that the versioned API serves the batch endpoint
+func TestAPIRoutes_Batch(t *testing.T) {
+	var model string
+	batch := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		model = chi.URLParam(r, "model")
+		w.WriteHeader(http.StatusOK)
+	})
+	r := chi.NewRouter()
+	r.Mount("/api", APIRoutes(nil, nil, batch))
+
+	for _, path := range []string{"/api/v1/post/batch", "/api/v2/post/batch", "/api/post/batch"} {
+		model = ""
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		if w.Code != http.StatusOK || model != "post" {
+			t.Errorf("POST %s: expected the batch handler for post, got %d for %q", path, w.Code, model)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/post/batch", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected 405, got %d", w.Code)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/justinas/nosurf"
+)
+
+// ContactRoutes serves the contact form. Mount it behind middleware.SpamTrap;
+// limiter caps how often one address can send a message.
+func ContactRoutes(handler *handlers.ContactHandler, limiter *middleware.IPRateLimiter) chi.Router {
+	r := chi.NewRouter()
+	r.Use(nosurf.NewPure)
+
+	r.Get("/", handler.ContactGET)
+	r.With(middleware.RateLimit(limiter)).Post("/", handler.ContactPOST)
+
+	return r
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+)
+
+// JobRoutes serves the progress partial of background jobs (see JobHandler.Poll)
+func JobRoutes(handler *handlers.JobHandler) chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{id}", handler.Poll)
+
+	return r
+}
@@ -14,6 +14,7 @@ func PageRoutes(handler *handlers.PageHandler, sm *scs.SessionManager, client *m
 	r.Use(nosurf.NewPure)
 
 	r.Get("/", handler.Home)
+	r.Post("/markdown/preview", handler.MarkdownPreview)
 
 	// Example of a public page route
 	// r.Get("/sample", handler.Sample)
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+	"github.com/justinas/nosurf"
+)
+
+// PollRoutes shows polls, as pages or embeddable partials, and takes votes
+func PollRoutes(handler *handlers.PollHandler) chi.Router {
+	r := chi.NewRouter()
+	r.Use(nosurf.NewPure)
+
+	r.Get("/{id}", handler.Show)
+	r.Post("/{id}/vote", handler.Vote)
+
+	return r
+}
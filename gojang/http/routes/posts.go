@@ -26,6 +26,8 @@ func PostRoutes(handler *handlers.PostHandler, sm *scs.SessionManager, client *m
 		auth.Get("/{id}/delete", handler.DeleteConfirm) // Handler checks ownership
 		auth.Put("/{id}", handler.Update)               // Handler checks ownership
 		auth.Delete("/{id}", handler.Delete)            // Handler checks ownership
+		auth.Get("/{id}/schedule", handler.Schedule)    // Handler checks ownership
+		auth.Put("/{id}/schedule", handler.Reschedule)  // Handler checks ownership
 	})
 
 	return r
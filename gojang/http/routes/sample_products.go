@@ -7,7 +7,7 @@ All code is commented out. To use these routes:
 1. Ensure the SampleProduct handler is set up (see sample_products.go in handlers)
 2. Uncomment all the code in this file
 3. Rename this file to "sampleproducts.go" (remove "sample_" prefix)
-4. Register these routes in main.go (see comments at the bottom of this file)
+4. Register these routes in gojang/app/app.go (see comments at the bottom of this file)
 
 See SAMPLE_PRODUCTS_INTEGRATION.md for detailed instructions.
 */
@@ -47,6 +47,6 @@ package routes
 // 	return r
 // }
 //
-// // To register these routes in main.go, add:
+// // To register these routes in gojang/app/app.go, add:
 // // sampleProductHandler := handlers.NewSampleProductHandler(client, publicRenderer)
 // // r.Mount("/sampleproducts", routes.SampleProductRoutes(sampleProductHandler, sessionManager, client))
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/handlers"
+)
+
+func SearchRoutes(handler *handlers.SearchHandler) chi.Router {
+	r := chi.NewRouter()
+
+	// Public routes
+	r.Get("/", handler.Search)
+
+	return r
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/authz"
 	"github.com/gojangframework/gojang/gojang/http/handlers"
 	"github.com/gojangframework/gojang/gojang/http/middleware"
 	"github.com/gojangframework/gojang/gojang/models"
@@ -13,9 +14,8 @@ func UserRoutes(handler *handlers.UserHandler, sm *scs.SessionManager, client *m
 	r := chi.NewRouter()
 	r.Use(nosurf.NewPure)
 	r.Use(middleware.RequireAuth(sm, client))
-	r.Use(middleware.RequireStaffOrAdmin)
+	r.Use(authz.Require(middleware.PermissionManageUsers))
 
-	// All user management routes check permissions in handler
 	r.Get("/", handler.Index)
 	r.Get("/new", handler.New)
 	r.Post("/", handler.Create)
@@ -0,0 +1,91 @@
+// Package static serves files that ship with the app: CSS, JavaScript and
+// images from a directory, and the /.well-known files embedded in the binary.
+// Unlike a bare http.FileServer, it never lists directories or serves
+// dotfiles, and it sets content types from storage.ContentType.
+package static
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/storage"
+)
+
+// wellKnown holds the app's /.well-known files. Edit or add files in
+// wellknown/ (e.g., security.txt, apple-app-site-association) and rebuild.
+//
+//go:embed wellknown
+var wellKnown embed.FS
+
+// Dir serves the files under root:
+//
+//	r.Handle("/static/*", http.StripPrefix("/static", static.Dir("./gojang/views/static")))
+func Dir(root string) http.Handler {
+	return Files(http.Dir(root))
+}
+
+// Files serves the files in fsys without directory listings or dotfiles
+func Files(fsys http.FileSystem) http.Handler {
+	server := http.FileServer(filesOnly{fsys})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		// FileServer keeps a Content-Type that's already set instead of guessing
+		if w.Header().Get("Content-Type") == "" && !strings.HasSuffix(r.URL.Path, "/") {
+			w.Header().Set("Content-Type", storage.ContentType(r.URL.Path))
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		server.ServeHTTP(w, r)
+	})
+}
+
+// WellKnown serves the embedded /.well-known files, and nothing else:
+//
+//	r.Handle("/.well-known/*", http.StripPrefix("/.well-known", static.WellKnown()))
+func WellKnown() http.Handler {
+	sub, err := fs.Sub(wellKnown, "wellknown")
+	if err != nil {
+		panic(err) // The directory is embedded, so this can't happen
+	}
+	files := Files(http.FS(sub))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if t, ok := wellKnownTypes[strings.TrimPrefix(r.URL.Path, "/")]; ok {
+			w.Header().Set("Content-Type", t)
+		}
+		files.ServeHTTP(w, r)
+	})
+}
+
+// wellKnownTypes are the content types of well-known files without an extension
+var wellKnownTypes = map[string]string{
+	"apple-app-site-association": "application/json",
+}
+
+// filesOnly hides directories and dotfiles, so FileServer answers 404
+// instead of listing a directory or serving, e.g., .env
+type filesOnly struct {
+	fs http.FileSystem
+}
+
+func (f filesOnly) Open(name string) (http.File, error) {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return nil, fs.ErrNotExist
+		}
+	}
+	file, err := f.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		file.Close()
+		return nil, fs.ErrNotExist
+	}
+	return file, nil
+}
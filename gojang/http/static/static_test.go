@@ -0,0 +1,78 @@
+package static
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "css"), 0o755)
+	os.MkdirAll(filepath.Join(root, ".git"), 0o755)
+	os.WriteFile(filepath.Join(root, "css", "site.css"), []byte("body{}"), 0o644)
+	os.WriteFile(filepath.Join(root, "font.woff2"), []byte("wOF2"), 0o644)
+	os.WriteFile(filepath.Join(root, ".env"), []byte("SECRET=1"), 0o644)
+	os.WriteFile(filepath.Join(root, ".git", "config"), []byte("[core]"), 0o644)
+	h := http.StripPrefix("/static", Dir(root))
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+	}{
+		{"/static/css/site.css", http.StatusOK, "text/css; charset=utf-8"},
+		{"/static/font.woff2", http.StatusOK, "font/woff2"},
+		{"/static/css/", http.StatusNotFound, ""},
+		{"/static/css", http.StatusNotFound, ""},
+		{"/static/", http.StatusNotFound, ""},
+		{"/static/.env", http.StatusNotFound, ""},
+		{"/static/.git/config", http.StatusNotFound, ""},
+		{"/static/missing.css", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.path, rec.Code, tt.status)
+			continue
+		}
+		if tt.status == http.StatusOK && rec.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("%s: Content-Type = %q, want %q", tt.path, rec.Header().Get("Content-Type"), tt.contentType)
+		}
+		if strings.Contains(rec.Body.String(), "SECRET") || strings.Contains(rec.Body.String(), "<a href") {
+			t.Errorf("%s: leaked a dotfile or directory listing: %q", tt.path, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/static/css/site.css", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: status = %d, want 405", rec.Code)
+	}
+}
+
+func TestWellKnown(t *testing.T) {
+	h := http.StripPrefix("/.well-known", WellKnown())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/security.txt", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Contact:") {
+		t.Fatalf("security.txt: got %d %q", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("security.txt: Content-Type = %q", ct)
+	}
+
+	// Only the embedded files are served, never the working directory
+	for _, path := range []string{"/.well-known/", "/.well-known/../go.mod", "/.well-known/static.go", "/.well-known/missing"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code == http.StatusOK {
+			t.Errorf("%s: expected an error, got 200 %q", path, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Package i18n holds the languages a site is offered in (LOCALES) and Text,
+// a content field with a value per language. middleware.Locale picks the
+// request's language, which templates pass to Text.Get:
+//
+//	{{.Data.Page.Title.Get .Locale}}
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// locales are the supported BCP 47 tags, the default first
+var locales = []string{"en"}
+
+// tagPattern matches BCP 47 tags like "en", "pt-BR" or "zh-Hant"
+var tagPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// ValidLocale reports whether tag looks like a BCP 47 language tag
+func ValidLocale(tag string) bool {
+	return tagPattern.MatchString(tag)
+}
+
+// SetLocales sets the languages content is offered in; the first is the
+// default, used when a translation is missing
+func SetLocales(tags []string) error {
+	if len(tags) == 0 {
+		return fmt.Errorf("no locales given")
+	}
+	list := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if !ValidLocale(tag) {
+			return fmt.Errorf("invalid locale %q", tag)
+		}
+		list = append(list, tag)
+	}
+	locales = list
+	return nil
+}
+
+// Locales returns the supported locales, the default first
+func Locales() []string {
+	return append([]string(nil), locales...)
+}
+
+// Default returns the default locale
+func Default() string {
+	return locales[0]
+}
+
+// base returns the language of a tag (e.g., "fr" for "fr-CA")
+func base(tag string) string {
+	lang, _, _ := strings.Cut(tag, "-")
+	return strings.ToLower(lang)
+}
+
+// Supported returns the supported locale for tag: the same tag, else one of
+// the same language (e.g., "fr" for "fr-CA"). It returns "" if there's none.
+func Supported(tag string) string {
+	for _, l := range locales {
+		if strings.EqualFold(l, tag) {
+			return l
+		}
+	}
+	for _, l := range locales {
+		if base(l) == base(tag) {
+			return l
+		}
+	}
+	return ""
+}
+
+// Match returns the supported locale the browser prefers most, from an
+// Accept-Language header (e.g., "fr-CA,fr;q=0.9,en;q=0.5"), or "" if it
+// accepts none of them
+func Match(header string) string {
+	type choice struct {
+		tag string
+		q   float64
+	}
+	var choices []choice
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if tag != "" && tag != "*" && q > 0 {
+			choices = append(choices, choice{tag, q})
+		}
+	}
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	for _, c := range choices {
+		if l := Supported(c.tag); l != "" {
+			return l
+		}
+	}
+	return ""
+}
+
+// Text is a translatable content field: its values by locale. Declare it
+// as a JSON field in the Ent schema:
+//
+//	field.JSON("title", i18n.Text{})
+//
+// The admin edits it with a tab per locale.
+type Text map[string]string
+
+// Get returns the value for locale, falling back to another locale of the
+// same language (e.g., "fr" for "fr-CA"), then the default locale, then the
+// first supported locale with a value
+func (t Text) Get(locale string) string {
+	if v := t[locale]; v != "" {
+		return v
+	}
+	if v := t[Supported(locale)]; v != "" {
+		return v
+	}
+	for _, l := range locales {
+		if v := t[l]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// String returns the value in the default locale (with Get's fallbacks), so
+// {{.Title}} in a template shows something sensible
+func (t Text) String() string {
+	return t.Get(Default())
+}
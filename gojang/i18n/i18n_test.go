@@ -0,0 +1,67 @@
+package i18n
+
+import "testing"
+
+// useLocales sets the supported locales for one test
+func useLocales(t *testing.T, tags ...string) {
+	t.Helper()
+	saved := locales
+	t.Cleanup(func() { locales = saved })
+	if err := SetLocales(tags); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetLocales(t *testing.T) {
+	useLocales(t, "en", "fr", "pt-BR")
+	if Default() != "en" || len(Locales()) != 3 {
+		t.Errorf("Locales = %v", Locales())
+	}
+	for _, bad := range [][]string{nil, {"en", "english!"}, {""}} {
+		if err := SetLocales(bad); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+	if Default() != "en" {
+		t.Errorf("A failed SetLocales changed the default to %q", Default())
+	}
+}
+
+func TestMatch(t *testing.T) {
+	useLocales(t, "en", "fr", "pt-BR")
+	for header, want := range map[string]string{
+		"fr-CA,fr;q=0.9,en;q=0.5": "fr",
+		"de,en;q=0.8":             "en",
+		"en;q=0.2,pt-br;q=0.9":    "pt-BR",
+		"pt-PT":                   "pt-BR",
+		"fr;q=0,de":               "",
+		"":                        "",
+		"*":                       "",
+	} {
+		if got := Match(header); got != want {
+			t.Errorf("Match(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+// TestTextGet tests the fallbacks from the requested locale to the default
+func TestTextGet(t *testing.T) {
+	useLocales(t, "en", "fr", "de")
+	text := Text{"en": "Hello", "fr": "Bonjour"}
+	for locale, want := range map[string]string{
+		"fr":    "Bonjour",
+		"fr-CA": "Bonjour",
+		"de":    "Hello",
+		"":      "Hello",
+	} {
+		if got := text.Get(locale); got != want {
+			t.Errorf("Get(%q) = %q, want %q", locale, got, want)
+		}
+	}
+	if got := (Text{"de": "Hallo"}).String(); got != "Hallo" {
+		t.Errorf("Expected the only translation without a default one, got %q", got)
+	}
+	if got := Text(nil).Get("en"); got != "" {
+		t.Errorf("Expected nothing from a nil Text, got %q", got)
+	}
+}
@@ -0,0 +1,208 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // GIFs are resized (first frame only) to PNG
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/utils/signer"
+)
+
+// maxSourcePixels rejects huge (or decompression-bomb) originals before decoding
+const maxSourcePixels = 50_000_000
+
+// generating limits how many variants are generated at once, since resizing is CPU-heavy
+var generating = make(chan struct{}, 4)
+
+// contentTypes are the formats variants are written in, by extension
+var contentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+}
+
+// Handler serves resized variants of images stored in fs:
+//
+//	r.Handle("/media/resize/*", http.StripPrefix("/media/resize", images.Handler(fs)))
+//
+// It must be mounted at /media/resize, which its signed URLs include. URLs
+// come from URL or Picture; unsigned or tampered URLs get a 403.
+func Handler(fs storage.Filesystem) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, name, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		spec, err := ParseSpec(size)
+		if !ok || err != nil || name == "" {
+			http.NotFound(w, r)
+			return
+		}
+		webp := r.URL.Query().Get("fm") == "webp"
+		signed := &url.URL{Path: prefix + strings.TrimPrefix(r.URL.Path, "/"), RawQuery: r.URL.RawQuery}
+		if signer.Verify(signed) != nil {
+			http.Error(w, "Invalid image signature", http.StatusForbidden)
+			return
+		}
+
+		ext := outputExt(name)
+		if ext == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if webp && WebPAvailable() {
+			ext = ".webp"
+		}
+
+		data, err := variant(r.Context(), fs, spec, name, ext)
+		if errors.Is(err, storage.ErrNotExist) || errors.Is(err, storage.ErrInvalidName) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			utils.Errorw("images.resize_failed", "name", name, "size", spec.String(), "error", err)
+			http.Error(w, "Failed to resize image", http.StatusUnprocessableEntity)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentTypes[ext])
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Write(data)
+	})
+}
+
+// outputExt returns the extension a variant of the named image is written with
+// ("" for formats that can't be resized)
+func outputExt(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jpg", ".jpeg":
+		return ".jpg"
+	case ".png", ".gif":
+		return ".png"
+	}
+	return ""
+}
+
+// variant returns a cached variant, generating and caching it on first use
+func variant(ctx context.Context, fs storage.Filesystem, spec Spec, name, ext string) ([]byte, error) {
+	cached := variantName(spec, name, ext)
+	if f, err := fs.Open(ctx, cached); err == nil {
+		defer f.Close()
+		return io.ReadAll(f)
+	}
+
+	generating <- struct{}{}
+	defer func() { <-generating }()
+
+	f, err := fs.Open(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	original, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := Resize(original, spec, ext)
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.Save(ctx, cached, bytes.NewReader(data)); err != nil {
+		// Still serve the variant; it's generated again next time
+		utils.Warnw("images.cache_failed", "name", cached, "error", err)
+	}
+	return data, nil
+}
+
+// DeleteVariants removes the cached variants of a stored image, e.g. after the
+// original is deleted
+func DeleteVariants(ctx context.Context, fs storage.Filesystem, name string) error {
+	cached, err := fs.List(ctx, "cache/resize/")
+	if err != nil {
+		return err
+	}
+	for _, f := range cached {
+		// Variants are cached as cache/resize/<spec>/<name>[<ext>]
+		_, rest, _ := strings.Cut(strings.TrimPrefix(f.Name, "cache/resize/"), "/")
+		suffix, ok := strings.CutPrefix(rest, name)
+		if !ok || suffix != "" && contentTypes[suffix] == "" {
+			continue
+		}
+		if err := fs.Delete(ctx, f.Name); err != nil && !errors.Is(err, storage.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resize decodes a JPEG, PNG or GIF image, transforms it to spec and encodes it
+// in the format of ext (".jpg", ".png" or ".webp")
+func Resize(original []byte, spec Spec, ext string) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, fmt.Errorf("image is too large (%dx%d)", cfg.Width, cfg.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(original))
+	if err != nil {
+		return nil, err
+	}
+	img = Transform(img, spec)
+
+	var buf bytes.Buffer
+	switch ext {
+	case ".jpg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	case ".png":
+		err = png.Encode(&buf, img)
+	case ".webp":
+		return encodeWebP(img)
+	default:
+		err = fmt.Errorf("unsupported output format %q", ext)
+	}
+	return buf.Bytes(), err
+}
+
+// encodeWebP converts an image to WebP with cwebp (see WebPAvailable)
+func encodeWebP(img image.Image) ([]byte, error) {
+	if !WebPAvailable() {
+		return nil, errors.New("cwebp not found")
+	}
+	dir, err := os.MkdirTemp("", "gojang-webp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in, out := filepath.Join(dir, "in.png"), filepath.Join(dir, "out.webp")
+	f, err := os.Create(in)
+	if err != nil {
+		return nil, err
+	}
+	err = png.Encode(f, img)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if output, err := exec.Command(cwebpPath, "-quiet", "-q", "80", in, "-o", out).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("cwebp: %w: %s", err, bytes.TrimSpace(output))
+	}
+	return os.ReadFile(out)
+}
@@ -0,0 +1,134 @@
+// Package images serves resized variants of uploaded images. Variants are
+// generated on the first request to a signed URL such as
+//
+//	/media/resize/300x300c/uploads/2024/05/photo.jpg?sig=...
+//
+// and cached in storage, so later requests are served straight from the cache.
+package images
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils/signer"
+)
+
+// MaxDimension caps the width and height of generated variants
+const MaxDimension = 4000
+
+// ErrInvalidSpec is returned for size specs that don't parse
+var ErrInvalidSpec = errors.New("invalid image size")
+
+// Spec is a target size: "300x200" fits the image inside 300×200, "300x200c"
+// crops it to fill exactly 300×200, and "300x0" only limits the width
+type Spec struct {
+	Width  int
+	Height int
+	Crop   bool
+}
+
+// ParseSpec parses a size like "300x300", "300x300c" or "640x0"
+func ParseSpec(s string) (Spec, error) {
+	var spec Spec
+	if strings.HasSuffix(s, "c") {
+		spec.Crop = true
+		s = strings.TrimSuffix(s, "c")
+	}
+	w, h, ok := strings.Cut(s, "x")
+	if !ok {
+		return Spec{}, ErrInvalidSpec
+	}
+	var err error
+	if spec.Width, err = strconv.Atoi(w); err != nil || spec.Width < 0 || spec.Width > MaxDimension {
+		return Spec{}, ErrInvalidSpec
+	}
+	if spec.Height, err = strconv.Atoi(h); err != nil || spec.Height < 0 || spec.Height > MaxDimension {
+		return Spec{}, ErrInvalidSpec
+	}
+	if spec.Width == 0 && spec.Height == 0 || spec.Crop && (spec.Width == 0 || spec.Height == 0) {
+		return Spec{}, ErrInvalidSpec
+	}
+	return spec, nil
+}
+
+func (s Spec) String() string {
+	out := strconv.Itoa(s.Width) + "x" + strconv.Itoa(s.Height)
+	if s.Crop {
+		out += "c"
+	}
+	return out
+}
+
+// prefix is where Handler is mounted; variant URLs are signed with it
+const prefix = "/media/resize/"
+
+// URL returns the signed URL of a stored image resized to size (e.g., "300x300c");
+// webp asks for a WebP variant, served in the original format when WebP isn't
+// available. URLs are signed with signer.Default, so clients can't request
+// arbitrary sizes, and don't expire. It returns "" for an empty name or
+// invalid size.
+func URL(name, size string, webp bool) string {
+	spec, err := ParseSpec(size)
+	if name == "" || err != nil {
+		return ""
+	}
+	u := prefix + spec.String() + "/" + storage.EscapePath(name)
+	if webp {
+		u += "?fm=webp"
+	}
+	return signer.Sign(u, 0)
+}
+
+// Picture renders an <img> of a stored image resized to size, wrapped in a
+// <picture> with a WebP source when WebP variants can be generated
+func Picture(name, size, alt, class string) string {
+	src := URL(name, size, false)
+	if src == "" {
+		return ""
+	}
+	img := fmt.Sprintf(`<img src="%s" alt="%s" loading="lazy"`, html.EscapeString(src), html.EscapeString(alt))
+	if class != "" {
+		img += fmt.Sprintf(` class="%s"`, html.EscapeString(class))
+	}
+	img += ">"
+	if !WebPAvailable() {
+		return img
+	}
+	return fmt.Sprintf(`<picture><source type="image/webp" srcset="%s">%s</picture>`,
+		html.EscapeString(URL(name, size, true)), img)
+}
+
+// Thumbnail renders a stored image as a small square thumbnail ("" when unset)
+func Thumbnail(name string) string {
+	return Picture(name, "96x96c", path.Base(name), "thumbnail")
+}
+
+var (
+	cwebpOnce sync.Once
+	cwebpPath string
+)
+
+// WebPAvailable reports whether WebP variants can be encoded, which needs the
+// cwebp tool (from libwebp) on the PATH
+func WebPAvailable() bool {
+	cwebpOnce.Do(func() {
+		cwebpPath, _ = exec.LookPath("cwebp")
+	})
+	return cwebpPath != ""
+}
+
+// variantName is where a generated variant is cached in storage
+// (e.g., "cache/resize/300x300c/uploads/photo.jpg.webp")
+func variantName(spec Spec, name, ext string) string {
+	if strings.ToLower(path.Ext(name)) != ext {
+		name += ext
+	}
+	return path.Join("cache/resize", spec.String(), name)
+}
@@ -0,0 +1,149 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils/signer"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Spec
+		ok   bool
+	}{
+		{"300x200", Spec{Width: 300, Height: 200}, true},
+		{"300x300c", Spec{Width: 300, Height: 300, Crop: true}, true},
+		{"640x0", Spec{Width: 640}, true},
+		{"0x0", Spec{}, false},
+		{"300x0c", Spec{}, false},
+		{"300", Spec{}, false},
+		{"-1x10", Spec{}, false},
+		{"5000x10", Spec{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseSpec(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseSpec(%q) = %+v, %v", tt.in, got, err)
+		}
+		if tt.ok && got.String() != tt.in {
+			t.Errorf("Spec.String() = %q, want %q", got.String(), tt.in)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	tests := []struct {
+		spec Spec
+		w, h int
+	}{
+		{Spec{Width: 100, Height: 100}, 100, 50},
+		{Spec{Width: 100, Height: 100, Crop: true}, 100, 100},
+		{Spec{Width: 0, Height: 50}, 100, 50},
+		{Spec{Width: 800, Height: 800}, 400, 200},             // Never enlarged
+		{Spec{Width: 800, Height: 400, Crop: true}, 400, 200}, // Crop box larger than the image
+	}
+	for _, tt := range tests {
+		b := Transform(img, tt.spec).Bounds()
+		if b.Dx() != tt.w || b.Dy() != tt.h {
+			t.Errorf("Transform(%s) = %dx%d, want %dx%d", tt.spec, b.Dx(), b.Dy(), tt.w, tt.h)
+		}
+	}
+}
+
+// TestTransform_Averages tests that downscaling averages pixels rather than sampling one
+func TestTransform_Averages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{0, 0, 0, 255})
+	img.Set(1, 0, color.RGBA{200, 200, 200, 255})
+
+	out := Transform(img, Spec{Width: 1, Height: 1}).(*image.RGBA)
+	if got := out.RGBAAt(0, 0); got.R != 100 {
+		t.Errorf("Expected the average gray 100, got %v", got)
+	}
+}
+
+// TestHandler tests signed URLs, resizing and caching of variants
+func TestHandler(t *testing.T) {
+	signer.SetDefault(signer.New("test-key"))
+	fs, _ := storage.NewLocal(t.TempDir(), "")
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 640, 480)))
+	fs.Save(ctx, "uploads/photo.png", &buf)
+	h := http.StripPrefix("/media/resize", Handler(fs))
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	url := URL("uploads/photo.png", "100x100c", false)
+	if !strings.HasPrefix(url, "/media/resize/100x100c/uploads/photo.png?sig=") {
+		t.Fatalf("unexpected URL %q", url)
+	}
+	rec := get(url)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("status = %d, Content-Type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	cfg, err := png.DecodeConfig(rec.Body)
+	if err != nil || cfg.Width != 100 || cfg.Height != 100 {
+		t.Errorf("variant is %dx%d (%v), want 100x100", cfg.Width, cfg.Height, err)
+	}
+	if _, err := fs.Open(ctx, "cache/resize/100x100c/uploads/photo.png"); err != nil {
+		t.Errorf("variant was not cached: %v", err)
+	}
+
+	// Changing the size invalidates the signature
+	if rec := get(strings.Replace(url, "100x100c", "2000x2000c", 1)); rec.Code != http.StatusForbidden {
+		t.Errorf("tampered URL: status = %d, want 403", rec.Code)
+	}
+	if rec := get(URL("uploads/missing.png", "100x100", false)); rec.Code != http.StatusNotFound {
+		t.Errorf("missing image: status = %d, want 404", rec.Code)
+	}
+}
+
+// TestDeleteVariants tests that only the variants of the given image are removed
+func TestDeleteVariants(t *testing.T) {
+	fs, _ := storage.NewLocal(t.TempDir(), "")
+	ctx := context.Background()
+	for _, name := range []string{
+		"cache/resize/96x96c/uploads/a.jpg",
+		"cache/resize/300x0/uploads/a.jpg.webp",
+		"cache/resize/96x96c/uploads/a.jpg2.jpg",
+		"cache/resize/96x96c/uploads/b.png",
+	} {
+		fs.Save(ctx, name, strings.NewReader("x"))
+	}
+
+	if err := DeleteVariants(ctx, fs, "uploads/a.jpg"); err != nil {
+		t.Fatalf("DeleteVariants: %v", err)
+	}
+	files, _ := fs.List(ctx, "")
+	if len(files) != 2 || files[0].Name != "cache/resize/96x96c/uploads/a.jpg2.jpg" || files[1].Name != "cache/resize/96x96c/uploads/b.png" {
+		t.Errorf("remaining files = %+v", files)
+	}
+}
+
+func TestPicture(t *testing.T) {
+	if got := Picture("", "100x100", "", ""); got != "" {
+		t.Errorf("Expected no markup for an empty name, got %q", got)
+	}
+	got := Picture("uploads/a.jpg", "100x100c", `Jo's "photo"`, "avatar")
+	if !strings.Contains(got, `<img src="/media/resize/100x100c/uploads/a.jpg?sig=`) ||
+		!strings.Contains(got, `alt="Jo&#39;s &#34;photo&#34;"`) || !strings.Contains(got, `class="avatar"`) {
+		t.Errorf("unexpected markup %q", got)
+	}
+}
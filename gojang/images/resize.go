@@ -0,0 +1,82 @@
+package images
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Transform resizes img to the spec: Crop fills the whole box, cutting off the
+// edges of the longer side; otherwise the image is scaled to fit inside the box.
+// Images are never enlarged.
+func Transform(img image.Image, spec Spec) image.Image {
+	src := img.Bounds()
+	sw, sh := src.Dx(), src.Dy()
+	if sw == 0 || sh == 0 {
+		return img
+	}
+
+	if spec.Crop && spec.Width > 0 && spec.Height > 0 {
+		// Largest centered region with the box's aspect ratio
+		cw, ch := sw, sw*spec.Height/spec.Width
+		if ch > sh {
+			cw, ch = sh*spec.Width/spec.Height, sh
+		}
+		x0 := src.Min.X + (sw-cw)/2
+		y0 := src.Min.Y + (sh-ch)/2
+		dw, dh := spec.Width, spec.Height
+		if dw > cw {
+			dw, dh = cw, ch
+		}
+		return resize(img, image.Rect(x0, y0, x0+cw, y0+ch), max(dw, 1), max(dh, 1))
+	}
+
+	dw, dh := fitSize(sw, sh, spec.Width, spec.Height)
+	if dw == sw && dh == sh {
+		return img
+	}
+	return resize(img, src, dw, dh)
+}
+
+// fitSize scales sw×sh down to fit inside w×h (0 = unbounded), keeping the aspect ratio
+func fitSize(sw, sh, w, h int) (int, int) {
+	dw, dh := sw, sh
+	if w > 0 && dw > w {
+		dw, dh = w, sh*w/sw
+	}
+	if h > 0 && dh > h {
+		dw, dh = sw*h/sh, h
+	}
+	return max(dw, 1), max(dh, 1)
+}
+
+// resize averages the source pixels under each destination pixel (a box filter),
+// which keeps downscaled photos smooth without an external library
+func resize(img image.Image, rect image.Rectangle, dw, dh int) *image.RGBA {
+	src := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(src, src.Bounds(), img, rect.Min, draw.Src)
+	sw, sh := rect.Dx(), rect.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					b += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+			d := dst.Pix[y*dst.Stride+x*4:]
+			d[0], d[1], d[2], d[3] = uint8(r/n), uint8(g/n), uint8(b/n), uint8(a/n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,21 @@
+// Package testdb opens throwaway databases for tests.
+package testdb
+
+import (
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/enttest"
+
+	_ "github.com/mattn/go-sqlite3" // SQLite driver
+)
+
+// Open returns a client for a migrated in-memory SQLite database named after
+// the test, closed when it ends
+func Open(t testing.TB) *models.Client {
+	t.Helper()
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	return client
+}
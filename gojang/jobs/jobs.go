@@ -0,0 +1,234 @@
+// Package jobs runs slow work (exports, imports, sending email) in the
+// background of the server process and tracks its progress, so a handler can
+// answer right away with a job ID that clients poll:
+//
+//	id, err := queue.Enqueue("Export posts", user.ID, func(ctx context.Context, p *jobs.Progress) error {
+//		p.Set(50, "Writing rows")
+//		...
+//		p.SetResult("/media/exports/posts.csv")
+//		return nil
+//	})
+//
+// Jobs live in memory: they're lost on restart and each instance tracks its
+// own, so clients must poll the instance that took the request (or run a
+// single instance).
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// Status is where a job is in its life
+type Status string
+
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// ErrFull is returned by Enqueue when every worker is busy and the backlog is full
+var ErrFull = errors.New("jobs: queue is full")
+
+// Job is a snapshot of a job's state
+type Job struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Status     Status    `json:"status"`
+	Progress   int       `json:"progress"` // 0-100
+	Message    string    `json:"message,omitempty"`
+	ResultURL  string    `json:"result_url,omitempty"` // e.g., the file an export wrote
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	FinishedAt time.Time `json:"finished_at,omitzero"`
+
+	Owner uuid.UUID `json:"-"` // User who may see it; uuid.Nil if anyone with the ID may
+}
+
+// Finished reports whether the job is done or failed
+func (j Job) Finished() bool {
+	return j.Status == StatusDone || j.Status == StatusFailed
+}
+
+// Func is a job's work. It should stop when ctx is canceled (on shutdown).
+type Func func(ctx context.Context, p *Progress) error
+
+// Progress lets a running job report how far it got
+type Progress struct {
+	q  *Queue
+	id string
+}
+
+// Set records the percentage done (0-100) and what the job is doing
+func (p *Progress) Set(percent int, message string) {
+	p.q.update(p.id, func(j *Job) {
+		j.Progress = max(0, min(percent, 100))
+		j.Message = message
+	})
+}
+
+// SetResult records where clients find the job's output once it's done
+func (p *Progress) SetResult(url string) {
+	p.q.update(p.id, func(j *Job) { j.ResultURL = url })
+}
+
+// entry is a tracked job and the channel closed on its next change
+type entry struct {
+	job     Job
+	changed chan struct{}
+}
+
+// task is a job waiting for a worker
+type task struct {
+	id string
+	fn Func
+}
+
+// Queue runs jobs on a fixed number of workers and remembers them for
+// Retention after they finish
+type Queue struct {
+	Retention time.Duration // How long finished jobs can be looked up
+	Sync      bool          // Run jobs inside Enqueue, for instances that can't run background work (serverless)
+
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	pending chan task
+	workers int
+	now     func() time.Time
+}
+
+// NewQueue creates a queue with workers workers and room for backlog jobs
+// waiting for one. Call Start to run them.
+func NewQueue(workers, backlog int) *Queue {
+	return &Queue{
+		Retention: time.Hour,
+		jobs:      make(map[string]*entry),
+		pending:   make(chan task, backlog),
+		workers:   workers,
+		now:       time.Now,
+	}
+}
+
+// Start runs the workers until ctx is canceled, which also cancels running jobs
+func (q *Queue) Start(ctx context.Context) {
+	for i := 0; i < q.workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case t := <-q.pending:
+					q.run(ctx, t)
+				}
+			}
+		}()
+	}
+}
+
+// Enqueue queues fn as a job named name that owner may look up, and returns its ID
+func (q *Queue) Enqueue(name string, owner uuid.UUID, fn Func) (string, error) {
+	id := uuid.NewString()
+	q.mu.Lock()
+	q.prune()
+	q.jobs[id] = &entry{
+		job:     Job{ID: id, Name: name, Status: StatusQueued, Owner: owner, CreatedAt: q.now()},
+		changed: make(chan struct{}),
+	}
+	q.mu.Unlock()
+
+	if q.Sync {
+		q.run(context.Background(), task{id: id, fn: fn})
+		return id, nil
+	}
+	select {
+	case q.pending <- task{id: id, fn: fn}:
+		utils.Infow("jobs.enqueued", "id", id, "name", name)
+		return id, nil
+	default:
+		q.mu.Lock()
+		delete(q.jobs, id)
+		q.mu.Unlock()
+		return "", ErrFull
+	}
+}
+
+// Get returns the job with id, if it's still tracked
+func (q *Queue) Get(id string) (Job, bool) {
+	job, _, ok := q.Watch(id)
+	return job, ok
+}
+
+// Watch returns the job with id and a channel that is closed when it next
+// changes, for long polling
+func (q *Queue) Watch(id string) (Job, <-chan struct{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	e, ok := q.jobs[id]
+	if !ok {
+		return Job{}, nil, false
+	}
+	return e.job, e.changed, true
+}
+
+// run runs one job, recovering from panics so a worker survives them
+func (q *Queue) run(ctx context.Context, t task) {
+	q.update(t.id, func(j *Job) { j.Status = StatusRunning })
+	start := q.now()
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return t.fn(ctx, &Progress{q: q, id: t.id})
+	}()
+
+	q.update(t.id, func(j *Job) {
+		j.FinishedAt = q.now()
+		if err != nil {
+			j.Status = StatusFailed
+			j.Error = err.Error()
+			return
+		}
+		j.Status = StatusDone
+		j.Progress = 100
+	})
+	if err != nil {
+		utils.Errorw("jobs.failed", "id", t.id, "duration", q.now().Sub(start), "error", err)
+		return
+	}
+	utils.Infow("jobs.done", "id", t.id, "duration", q.now().Sub(start))
+}
+
+// update changes a job and wakes its watchers
+func (q *Queue) update(id string, change func(*Job)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	e, ok := q.jobs[id]
+	if !ok {
+		return
+	}
+	change(&e.job)
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// prune forgets jobs that finished more than Retention ago. Callers hold mu.
+func (q *Queue) prune() {
+	cutoff := q.now().Add(-q.Retention)
+	for id, e := range q.jobs {
+		if e.job.Finished() && e.job.FinishedAt.Before(cutoff) {
+			delete(q.jobs, id)
+		}
+	}
+}
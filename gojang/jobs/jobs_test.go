@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// waitFinished long-polls until the job with id finishes
+func waitFinished(t *testing.T, q *Queue, id string) Job {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		job, changed, ok := q.Watch(id)
+		if !ok {
+			t.Fatalf("Job %s not found", id)
+		}
+		if job.Finished() {
+			return job
+		}
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("Job %s didn't finish, last state %+v", id, job)
+		}
+	}
+}
+
+// TestQueue tests running jobs, progress, results and failures
+func TestQueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := NewQueue(2, 10)
+	q.Start(ctx)
+	owner := uuid.New()
+
+	release := make(chan struct{})
+	id, err := q.Enqueue("Export", owner, func(ctx context.Context, p *Progress) error {
+		p.Set(40, "Writing rows")
+		<-release
+		p.SetResult("/media/export.csv")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	// Watchers see progress as it's reported
+	for {
+		job, changed, _ := q.Watch(id)
+		if job.Progress == 40 {
+			if job.Status != StatusRunning || job.Message != "Writing rows" || job.Owner != owner {
+				t.Errorf("Unexpected running job %+v", job)
+			}
+			break
+		}
+		<-changed
+	}
+	close(release)
+	if job := waitFinished(t, q, id); job.Status != StatusDone || job.Progress != 100 || job.ResultURL != "/media/export.csv" || job.FinishedAt.IsZero() {
+		t.Errorf("Unexpected finished job %+v", job)
+	}
+
+	failing, _ := q.Enqueue("Import", owner, func(ctx context.Context, p *Progress) error {
+		return errors.New("bad row 3")
+	})
+	if job := waitFinished(t, q, failing); job.Status != StatusFailed || job.Error != "bad row 3" {
+		t.Errorf("Expected a failed job, got %+v", job)
+	}
+	panicking, _ := q.Enqueue("Email", owner, func(ctx context.Context, p *Progress) error {
+		panic("boom")
+	})
+	if job := waitFinished(t, q, panicking); job.Status != StatusFailed || job.Error != "panic: boom" {
+		t.Errorf("Expected the panic to fail the job, got %+v", job)
+	}
+
+	if _, ok := q.Get("missing"); ok {
+		t.Error("Expected an unknown job not to be found")
+	}
+}
+
+// TestQueue_Full tests that Enqueue refuses jobs beyond the backlog
+func TestQueue_Full(t *testing.T) {
+	q := NewQueue(1, 1) // Not started, so jobs stay queued
+	noop := func(ctx context.Context, p *Progress) error { return nil }
+	if _, err := q.Enqueue("One", uuid.Nil, noop); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if _, err := q.Enqueue("Two", uuid.Nil, noop); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+}
+
+// TestQueue_Sync tests running jobs inside Enqueue
+func TestQueue_Sync(t *testing.T) {
+	q := NewQueue(0, 0)
+	q.Sync = true
+	id, err := q.Enqueue("Export", uuid.Nil, func(ctx context.Context, p *Progress) error { return nil })
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if job, _ := q.Get(id); job.Status != StatusDone {
+		t.Errorf("Expected the job to be done, got %+v", job)
+	}
+}
+
+// TestQueue_Retention tests that finished jobs are forgotten after Retention
+func TestQueue_Retention(t *testing.T) {
+	q := NewQueue(0, 0)
+	q.Sync = true
+	now := time.Now()
+	q.now = func() time.Time { return now }
+	id, _ := q.Enqueue("Export", uuid.Nil, func(ctx context.Context, p *Progress) error { return nil })
+
+	now = now.Add(q.Retention - time.Second)
+	if _, ok := q.Get(id); !ok {
+		t.Error("Expected the job inside its retention")
+	}
+	now = now.Add(2 * time.Second)
+	if _, ok := q.Get(id); ok {
+		t.Error("Expected the job to be forgotten")
+	}
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// Task is periodic work run by Every
+type Task func(ctx context.Context) error
+
+// Every runs task every interval until ctx is canceled, like a cron entry for
+// housekeeping that nobody polls (publishing scheduled posts, pruning old
+// rows). Start it in a goroutine:
+//
+//	go jobs.Every(ctx, time.Minute, "publish_posts", publishDuePosts)
+//
+// Errors are logged and the task runs again on the next tick. Serverless
+// instances are frozen between requests, so run tasks from a scheduled
+// invocation there instead.
+func Every(ctx context.Context, interval time.Duration, name string, task Task) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := task(ctx); err != nil && ctx.Err() == nil {
+				utils.Errorw("jobs.task_failed", "task", name, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestEvery tests that a task keeps running after errors until ctx is canceled
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		Every(ctx, time.Millisecond, "test", func(ctx context.Context) error {
+			runs <- struct{}{}
+			return errors.New("try again")
+		})
+		close(done)
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected run %d", i+1)
+		}
+	}
+	cancel()
+	for {
+		select {
+		case <-runs: // A tick that raced with cancel
+		case <-done:
+			return
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected Every to return after cancel")
+		}
+	}
+}
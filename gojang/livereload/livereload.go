@@ -0,0 +1,130 @@
+// Package livereload refreshes open browser tabs during development. Pages
+// rendered in debug mode include a small script (see Snippet) that listens to
+// Server over Server-Sent Events and reloads the page when templates or static
+// files change, or when the app restarts with a new build (`gojang dev`).
+package livereload
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Path is where Server is mounted and the snippet connects
+const Path = "/__livereload"
+
+// snippet reloads the page on a "reload" event, or when it reconnects to a
+// server that booted since the page loaded
+const snippet = `<script>
+(function() {
+    var boot = null;
+    var events = new EventSource("` + Path + `");
+    events.addEventListener("boot", function(e) {
+        if (boot !== null && boot !== e.data) { location.reload(); }
+        boot = e.data;
+    });
+    events.addEventListener("reload", function() { location.reload(); });
+})();
+</script>`
+
+// enabled turns the snippet on; see SetEnabled
+var enabled atomic.Bool
+
+// SetEnabled makes Snippet return the reload script. Only enable it in debug
+// mode with a Server mounted at Path.
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Snippet returns the script that connects pages to Server ("" unless enabled)
+func Snippet() string {
+	if !enabled.Load() {
+		return ""
+	}
+	return snippet
+}
+
+// Server pushes reload events to connected pages
+type Server struct {
+	boot    string
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+	done    chan struct{}
+	closing sync.Once
+}
+
+// New returns a Server identified by its start time, so pages reload when they
+// reconnect to a restarted app
+func New() *Server {
+	return &Server{
+		boot:    strconv.FormatInt(time.Now().UnixNano(), 36),
+		clients: map[chan struct{}]struct{}{},
+		done:    make(chan struct{}),
+	}
+}
+
+// ServeHTTP streams events to one page until it disconnects or the server closes
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	// The stream outlives the server's write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	reload := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[reload] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, reload)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Reconnect quickly while the app restarts
+	fmt.Fprintf(w, "retry: 500\nevent: boot\ndata: %s\n\n", s.boot)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-reload:
+			fmt.Fprint(w, "event: reload\ndata: \n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Reload tells every connected page to reload
+func (s *Server) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for client := range s.clients {
+		select {
+		case client <- struct{}{}:
+		default: // A reload is already pending
+		}
+	}
+}
+
+// Watch reloads pages whenever w reports a change, until ctx is canceled
+func (s *Server) Watch(ctx context.Context, w Watcher) {
+	w.Run(ctx, func([]string) { s.Reload() })
+}
+
+// Close ends all streams so http.Server.Shutdown doesn't wait on them
+// (register it with RegisterOnShutdown)
+func (s *Server) Close() {
+	s.closing.Do(func() { close(s.done) })
+}
@@ -0,0 +1,126 @@
+package livereload
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestServer tests that pages get the boot ID on connect and a reload on change
+func TestServer(t *testing.T) {
+	s := New()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	defer s.Close()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	readEvent := func() string {
+		var event []string
+		for lines.Scan() && lines.Text() != "" {
+			event = append(event, lines.Text())
+		}
+		return strings.Join(event, "\n")
+	}
+	if got, want := readEvent(), "retry: 500\nevent: boot\ndata: "+s.boot; got != want {
+		t.Errorf("First event = %q, want %q", got, want)
+	}
+
+	// The client registers before the boot event is flushed
+	s.Reload()
+	if got := readEvent(); got != "event: reload\ndata: " {
+		t.Errorf("Expected a reload event, got %q", got)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	defer SetEnabled(false)
+
+	if Snippet() != "" {
+		t.Error("Expected no snippet while disabled")
+	}
+	SetEnabled(true)
+	if !strings.Contains(Snippet(), `new EventSource("/__livereload")`) {
+		t.Errorf("Expected the snippet to connect to %s, got %s", Path, Snippet())
+	}
+}
+
+// TestWatcher tests that added, modified and removed files are reported
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("main.go", "package main")
+	write("main_test.go", "package main")
+	write("tmp/build.go", "package tmp")
+
+	changes := make(chan []string, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := Watcher{
+		Dirs:         []string{dir},
+		Exts:         []string{".go"},
+		SkipDirs:     []string{"tmp"},
+		SkipSuffixes: []string{"_test.go"},
+		Interval:     10 * time.Millisecond,
+	}
+	go w.Run(ctx, func(changed []string) { changes <- changed })
+	time.Sleep(30 * time.Millisecond)
+
+	// collect gathers the changes reported over a few polls
+	collect := func() string {
+		seen := map[string]bool{}
+		timeout := time.After(100 * time.Millisecond)
+		for {
+			select {
+			case changed := <-changes:
+				for _, path := range changed {
+					seen[strings.TrimPrefix(path, dir+string(filepath.Separator))] = true
+				}
+			case <-timeout:
+				var paths []string
+				for path := range seen {
+					paths = append(paths, filepath.ToSlash(path))
+				}
+				sort.Strings(paths)
+				return strings.Join(paths, ",")
+			}
+		}
+	}
+
+	// Ignored files don't trigger a change
+	write("main_test.go", "package main // changed")
+	write("tmp/build.go", "package tmp // changed")
+	write("notes.txt", "hello")
+	// Sizes differ, so the change is seen even with coarse mtimes
+	write("main.go", "package main // changed")
+	write("util.go", "package main")
+	if got := collect(); got != "main.go,util.go" {
+		t.Errorf("Changed = %s, want main.go,util.go", got)
+	}
+
+	os.Remove(filepath.Join(dir, "util.go"))
+	if got := collect(); got != "util.go" {
+		t.Errorf("Expected the removed file, got %s", got)
+	}
+}
@@ -0,0 +1,107 @@
+package livereload
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Watcher polls directory trees for changed files. Polling needs no
+// platform-specific APIs and is quick enough for a project-sized tree.
+type Watcher struct {
+	Dirs         []string      // Trees to watch
+	Exts         []string      // File extensions to watch (e.g., ".go"); empty watches everything
+	SkipDirs     []string      // Directory names never descended into (e.g., ".git", "tmp")
+	SkipSuffixes []string      // File name endings to ignore (e.g., "_test.go")
+	Interval     time.Duration // Time between polls (default 500ms)
+}
+
+// fileState is what a poll compares to notice a change
+type fileState struct {
+	mod  time.Time
+	size int64
+}
+
+// Run calls onChange with the files added, modified or removed since the last
+// poll, until ctx is canceled. Changes made while onChange runs are reported
+// on the next poll.
+func (w Watcher) Run(ctx context.Context, onChange func(changed []string)) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := w.snapshot()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current := w.snapshot()
+		if changed := diff(last, current); len(changed) > 0 {
+			onChange(changed)
+		}
+		last = current
+	}
+}
+
+// snapshot records the state of every watched file
+func (w Watcher) snapshot() map[string]fileState {
+	files := map[string]fileState{}
+	for _, dir := range w.Dirs {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// Files can vanish mid-walk (e.g., editor swap files); skip them
+				return nil
+			}
+			if d.IsDir() {
+				if path != dir && slices.Contains(w.SkipDirs, d.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !w.watches(d.Name()) {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				files[path] = fileState{mod: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+// watches reports whether a file name has a watched extension and no skipped suffix
+func (w Watcher) watches(name string) bool {
+	for _, suffix := range w.SkipSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return false
+		}
+	}
+	return len(w.Exts) == 0 || slices.Contains(w.Exts, filepath.Ext(name))
+}
+
+// diff returns the sorted paths that differ between two snapshots
+func diff(before, after map[string]fileState) []string {
+	var changed []string
+	for path, state := range after {
+		if prev, ok := before[path]; !ok || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
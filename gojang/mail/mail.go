@@ -0,0 +1,288 @@
+// Package mail sends email through SMTP, with attachments:
+//
+//	err := mail.Send(ctx, &mail.Message{
+//		To:      []string{"security@example.com"},
+//		Subject: "Audit log export",
+//		Text:    "Attached.",
+//		Attachments: []mail.Attachment{{Name: "audit.csv", ContentType: "text/csv", Data: data}},
+//	})
+//
+// The app sets the default Sender from the SMTP_* settings, or a Log sender
+// that only logs messages when SMTP_HOST is unset.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// Errors for messages that can't be sent
+var (
+	ErrNoRecipients     = errors.New("mail: message has no recipients")
+	ErrInvalidRecipient = errors.New("mail: recipient contains a line break")
+)
+
+// Message is an email. Text is the plain-text body; HTML, when set, is sent
+// as an alternative for clients that show it. ReplyTo, when set, is where
+// replies go instead of the sender's address.
+type Message struct {
+	To          []string
+	ReplyTo     string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Attachment is a file sent with a Message
+type Attachment struct {
+	Name        string // File name shown to the recipient
+	ContentType string // e.g., "text/csv"; defaults to application/octet-stream
+	Data        []byte
+}
+
+// Sender delivers messages
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// SMTP sends messages through an SMTP server, upgrading to TLS with STARTTLS
+// when the server offers it
+type SMTP struct {
+	Host     string
+	Port     int // Defaults to 587
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration // For the whole delivery; 0 means 30 seconds
+}
+
+// Send delivers msg to every recipient
+func (s *SMTP) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+	body, err := msg.build(s.From, time.Now())
+	if err != nil {
+		return err
+	}
+
+	port, timeout := s.Port, s.Timeout
+	if port == 0 {
+		port = 587
+	}
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(s.Host, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("mail: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("mail: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return fmt.Errorf("mail: starting TLS: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("mail: %w", err)
+		}
+	}
+	if err := c.Mail(s.From); err != nil {
+		return fmt.Errorf("mail: %w", err)
+	}
+	for _, to := range msg.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("mail: recipient %s: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("mail: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("mail: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("mail: %w", err)
+	}
+	return c.Quit()
+}
+
+// build renders msg as a MIME message from the given address
+func (msg *Message) build(from string, date time.Time) ([]byte, error) {
+	for _, to := range append([]string{msg.ReplyTo}, msg.To...) {
+		if strings.ContainsAny(to, "\r\n") {
+			return nil, ErrInvalidRecipient
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	if msg.ReplyTo != "" {
+		fmt.Fprintf(&buf, "Reply-To: %s\r\n", msg.ReplyTo)
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	mixed := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mixed.Boundary())
+
+	if err := msg.writeBody(mixed); err != nil {
+		return nil, err
+	}
+	for _, a := range msg.Attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Name})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64(part, a.Data)
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBody adds the text body, with the HTML alternative when there is one
+func (msg *Message) writeBody(mixed *multipart.Writer) error {
+	text := textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}, "Content-Transfer-Encoding": {"base64"}}
+	if msg.HTML == "" {
+		part, err := mixed.CreatePart(text)
+		if err != nil {
+			return err
+		}
+		writeBase64(part, []byte(msg.Text))
+		return nil
+	}
+
+	var alt bytes.Buffer
+	w := multipart.NewWriter(&alt)
+	part, err := w.CreatePart(text)
+	if err != nil {
+		return err
+	}
+	writeBase64(part, []byte(msg.Text))
+	if part, err = w.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}, "Content-Transfer-Encoding": {"base64"}}); err != nil {
+		return err
+	}
+	writeBase64(part, []byte(msg.HTML))
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	outer, err := mixed.CreatePart(textproto.MIMEHeader{"Content-Type": {"multipart/alternative; boundary=" + w.Boundary()}})
+	if err != nil {
+		return err
+	}
+	_, err = outer.Write(alt.Bytes())
+	return err
+}
+
+// writeBase64 writes data base64-encoded in 76-character lines
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
+
+// Log is a Sender for development that logs messages instead of sending them
+type Log struct{}
+
+// Send logs the message's recipients, subject and attachments
+func (Log) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+	names := make([]string, len(msg.Attachments))
+	for i, a := range msg.Attachments {
+		names[i] = a.Name
+	}
+	utils.Infow("mail.logged", "to", strings.Join(msg.To, ", "), "subject", msg.Subject, "attachments", names)
+	return nil
+}
+
+// Outbox is a Sender that keeps messages in memory, for tests
+type Outbox struct {
+	mu       sync.Mutex
+	messages []*Message
+}
+
+// Send records msg
+func (o *Outbox) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append(o.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far
+func (o *Outbox) Messages() []*Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]*Message(nil), o.messages...)
+}
+
+// defaultSender is used by Send; see SetDefault
+var defaultSender Sender = Log{}
+
+// SetDefault sets the Sender used by Send
+func SetDefault(s Sender) {
+	defaultSender = s
+}
+
+// Default returns the Sender set with SetDefault (a Log sender until then)
+func Default() Sender {
+	return defaultSender
+}
+
+// Send delivers msg with the default Sender
+func Send(ctx context.Context, msg *Message) error {
+	return defaultSender.Send(ctx, msg)
+}
@@ -0,0 +1,142 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessage_Build(t *testing.T) {
+	msg := &Message{
+		To:          []string{"a@example.com", "b@example.com"},
+		ReplyTo:     "c@example.com",
+		Subject:     "Audit export – October",
+		Text:        "See attached.",
+		Attachments: []Attachment{{Name: "audit.csv", ContentType: "text/csv", Data: []byte("id,action\n1,create\n")}},
+	}
+	raw, err := msg.build("noreply@example.com", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject")); subject != msg.Subject {
+		t.Errorf("Subject = %q", subject)
+	}
+	if to := parsed.Header.Get("To"); to != "a@example.com, b@example.com" {
+		t.Errorf("To = %q", to)
+	}
+	if replyTo := parsed.Header.Get("Reply-To"); replyTo != "c@example.com" {
+		t.Errorf("Reply-To = %q", replyTo)
+	}
+
+	_, params, _ := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+		parts = append(parts, part.FileName()+":"+string(data))
+	}
+	if len(parts) != 2 || parts[0] != ":See attached." || parts[1] != "audit.csv:id,action\n1,create\n" {
+		t.Errorf("Unexpected parts %q", parts)
+	}
+
+	if _, err := (&Message{To: []string{"a@example.com\r\nBcc: x@example.com"}}).build("noreply@example.com", time.Now()); !errors.Is(err, ErrInvalidRecipient) {
+		t.Errorf("Expected a header injection to be refused, got %v", err)
+	}
+	if _, err := (&Message{To: []string{"a@example.com"}, ReplyTo: "c@example.com\nBcc: x@example.com"}).build("noreply@example.com", time.Now()); !errors.Is(err, ErrInvalidRecipient) {
+		t.Errorf("Expected a Reply-To header injection to be refused, got %v", err)
+	}
+}
+
+// fakeSMTP accepts one message and sends the DATA it received on the channel
+func fakeSMTP(t *testing.T) (host string, port int, received <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ESMTP")
+		var body strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					l, _ := r.ReadString('\n')
+					if l == ".\r\n" {
+						break
+					}
+					body.WriteString(l)
+				}
+				data <- body.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, data
+}
+
+func TestSMTP_Send(t *testing.T) {
+	host, port, received := fakeSMTP(t)
+	s := &SMTP{Host: host, Port: port, From: "noreply@example.com"}
+	if err := s.Send(context.Background(), &Message{To: []string{"a@example.com"}, Subject: "Hi", Text: "Hello"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if body := <-received; !strings.Contains(body, "Subject: Hi\r\n") || !strings.Contains(body, "From: noreply@example.com") {
+		t.Errorf("Unexpected message %q", body)
+	}
+
+	if err := s.Send(context.Background(), &Message{Subject: "Hi"}); !errors.Is(err, ErrNoRecipients) {
+		t.Errorf("Expected ErrNoRecipients, got %v", err)
+	}
+}
+
+func TestOutbox(t *testing.T) {
+	var outbox Outbox
+	outbox.Send(context.Background(), &Message{To: []string{"a@example.com"}, Subject: "First"})
+	if got := outbox.Messages(); len(got) != 1 || got[0].Subject != "First" {
+		t.Errorf("Unexpected messages %v", got)
+	}
+}
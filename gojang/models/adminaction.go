@@ -0,0 +1,184 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/google/uuid"
+)
+
+// AdminAction is the model entity for the AdminAction schema.
+type AdminAction struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Action holds the value of the "action" field.
+	Action adminaction.Action `json:"action,omitempty"`
+	// Custom admin action run on the record (e.g., 'Deactivate')
+	ActionName string `json:"action_name,omitempty"`
+	// Admin model name (e.g., 'Post')
+	Model string `json:"model,omitempty"`
+	// RecordID holds the value of the "record_id" field.
+	RecordID string `json:"record_id,omitempty"`
+	// Record label when the action ran, kept after deletes
+	RecordLabel string `json:"record_label,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// UserEmail holds the value of the "user_email" field.
+	UserEmail string `json:"user_email,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AdminAction) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case adminaction.FieldAction, adminaction.FieldActionName, adminaction.FieldModel, adminaction.FieldRecordID, adminaction.FieldRecordLabel, adminaction.FieldUserEmail:
+			values[i] = new(sql.NullString)
+		case adminaction.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case adminaction.FieldID, adminaction.FieldUserID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AdminAction fields.
+func (_m *AdminAction) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case adminaction.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case adminaction.FieldAction:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field action", values[i])
+			} else if value.Valid {
+				_m.Action = adminaction.Action(value.String)
+			}
+		case adminaction.FieldActionName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field action_name", values[i])
+			} else if value.Valid {
+				_m.ActionName = value.String
+			}
+		case adminaction.FieldModel:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field model", values[i])
+			} else if value.Valid {
+				_m.Model = value.String
+			}
+		case adminaction.FieldRecordID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field record_id", values[i])
+			} else if value.Valid {
+				_m.RecordID = value.String
+			}
+		case adminaction.FieldRecordLabel:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field record_label", values[i])
+			} else if value.Valid {
+				_m.RecordLabel = value.String
+			}
+		case adminaction.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				_m.UserID = *value
+			}
+		case adminaction.FieldUserEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_email", values[i])
+			} else if value.Valid {
+				_m.UserEmail = value.String
+			}
+		case adminaction.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AdminAction.
+// This includes values selected through modifiers, order, etc.
+func (_m *AdminAction) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this AdminAction.
+// Note that you need to call AdminAction.Unwrap() before calling this method if this AdminAction
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *AdminAction) Update() *AdminActionUpdateOne {
+	return NewAdminActionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the AdminAction entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *AdminAction) Unwrap() *AdminAction {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("models: AdminAction is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *AdminAction) String() string {
+	var builder strings.Builder
+	builder.WriteString("AdminAction(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("action=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Action))
+	builder.WriteString(", ")
+	builder.WriteString("action_name=")
+	builder.WriteString(_m.ActionName)
+	builder.WriteString(", ")
+	builder.WriteString("model=")
+	builder.WriteString(_m.Model)
+	builder.WriteString(", ")
+	builder.WriteString("record_id=")
+	builder.WriteString(_m.RecordID)
+	builder.WriteString(", ")
+	builder.WriteString("record_label=")
+	builder.WriteString(_m.RecordLabel)
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("user_email=")
+	builder.WriteString(_m.UserEmail)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AdminActions is a parsable slice of AdminAction.
+type AdminActions []*AdminAction
@@ -0,0 +1,541 @@
+// Code generated by ent, DO NOT EDIT.
+
+package adminaction
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldID, id))
+}
+
+// ActionName applies equality check predicate on the "action_name" field. It's identical to ActionNameEQ.
+func ActionName(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldActionName, v))
+}
+
+// Model applies equality check predicate on the "model" field. It's identical to ModelEQ.
+func Model(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldModel, v))
+}
+
+// RecordID applies equality check predicate on the "record_id" field. It's identical to RecordIDEQ.
+func RecordID(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldRecordID, v))
+}
+
+// RecordLabel applies equality check predicate on the "record_label" field. It's identical to RecordLabelEQ.
+func RecordLabel(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldRecordLabel, v))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserEmail applies equality check predicate on the "user_email" field. It's identical to UserEmailEQ.
+func UserEmail(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldUserEmail, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// ActionEQ applies the EQ predicate on the "action" field.
+func ActionEQ(v Action) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldAction, v))
+}
+
+// ActionNEQ applies the NEQ predicate on the "action" field.
+func ActionNEQ(v Action) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldAction, v))
+}
+
+// ActionIn applies the In predicate on the "action" field.
+func ActionIn(vs ...Action) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldAction, vs...))
+}
+
+// ActionNotIn applies the NotIn predicate on the "action" field.
+func ActionNotIn(vs ...Action) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldAction, vs...))
+}
+
+// ActionNameEQ applies the EQ predicate on the "action_name" field.
+func ActionNameEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldActionName, v))
+}
+
+// ActionNameNEQ applies the NEQ predicate on the "action_name" field.
+func ActionNameNEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldActionName, v))
+}
+
+// ActionNameIn applies the In predicate on the "action_name" field.
+func ActionNameIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldActionName, vs...))
+}
+
+// ActionNameNotIn applies the NotIn predicate on the "action_name" field.
+func ActionNameNotIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldActionName, vs...))
+}
+
+// ActionNameGT applies the GT predicate on the "action_name" field.
+func ActionNameGT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldActionName, v))
+}
+
+// ActionNameGTE applies the GTE predicate on the "action_name" field.
+func ActionNameGTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldActionName, v))
+}
+
+// ActionNameLT applies the LT predicate on the "action_name" field.
+func ActionNameLT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldActionName, v))
+}
+
+// ActionNameLTE applies the LTE predicate on the "action_name" field.
+func ActionNameLTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldActionName, v))
+}
+
+// ActionNameContains applies the Contains predicate on the "action_name" field.
+func ActionNameContains(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContains(FieldActionName, v))
+}
+
+// ActionNameHasPrefix applies the HasPrefix predicate on the "action_name" field.
+func ActionNameHasPrefix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasPrefix(FieldActionName, v))
+}
+
+// ActionNameHasSuffix applies the HasSuffix predicate on the "action_name" field.
+func ActionNameHasSuffix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasSuffix(FieldActionName, v))
+}
+
+// ActionNameIsNil applies the IsNil predicate on the "action_name" field.
+func ActionNameIsNil() predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIsNull(FieldActionName))
+}
+
+// ActionNameNotNil applies the NotNil predicate on the "action_name" field.
+func ActionNameNotNil() predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotNull(FieldActionName))
+}
+
+// ActionNameEqualFold applies the EqualFold predicate on the "action_name" field.
+func ActionNameEqualFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEqualFold(FieldActionName, v))
+}
+
+// ActionNameContainsFold applies the ContainsFold predicate on the "action_name" field.
+func ActionNameContainsFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContainsFold(FieldActionName, v))
+}
+
+// ModelEQ applies the EQ predicate on the "model" field.
+func ModelEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldModel, v))
+}
+
+// ModelNEQ applies the NEQ predicate on the "model" field.
+func ModelNEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldModel, v))
+}
+
+// ModelIn applies the In predicate on the "model" field.
+func ModelIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldModel, vs...))
+}
+
+// ModelNotIn applies the NotIn predicate on the "model" field.
+func ModelNotIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldModel, vs...))
+}
+
+// ModelGT applies the GT predicate on the "model" field.
+func ModelGT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldModel, v))
+}
+
+// ModelGTE applies the GTE predicate on the "model" field.
+func ModelGTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldModel, v))
+}
+
+// ModelLT applies the LT predicate on the "model" field.
+func ModelLT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldModel, v))
+}
+
+// ModelLTE applies the LTE predicate on the "model" field.
+func ModelLTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldModel, v))
+}
+
+// ModelContains applies the Contains predicate on the "model" field.
+func ModelContains(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContains(FieldModel, v))
+}
+
+// ModelHasPrefix applies the HasPrefix predicate on the "model" field.
+func ModelHasPrefix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasPrefix(FieldModel, v))
+}
+
+// ModelHasSuffix applies the HasSuffix predicate on the "model" field.
+func ModelHasSuffix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasSuffix(FieldModel, v))
+}
+
+// ModelEqualFold applies the EqualFold predicate on the "model" field.
+func ModelEqualFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEqualFold(FieldModel, v))
+}
+
+// ModelContainsFold applies the ContainsFold predicate on the "model" field.
+func ModelContainsFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContainsFold(FieldModel, v))
+}
+
+// RecordIDEQ applies the EQ predicate on the "record_id" field.
+func RecordIDEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldRecordID, v))
+}
+
+// RecordIDNEQ applies the NEQ predicate on the "record_id" field.
+func RecordIDNEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldRecordID, v))
+}
+
+// RecordIDIn applies the In predicate on the "record_id" field.
+func RecordIDIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldRecordID, vs...))
+}
+
+// RecordIDNotIn applies the NotIn predicate on the "record_id" field.
+func RecordIDNotIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldRecordID, vs...))
+}
+
+// RecordIDGT applies the GT predicate on the "record_id" field.
+func RecordIDGT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldRecordID, v))
+}
+
+// RecordIDGTE applies the GTE predicate on the "record_id" field.
+func RecordIDGTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldRecordID, v))
+}
+
+// RecordIDLT applies the LT predicate on the "record_id" field.
+func RecordIDLT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldRecordID, v))
+}
+
+// RecordIDLTE applies the LTE predicate on the "record_id" field.
+func RecordIDLTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldRecordID, v))
+}
+
+// RecordIDContains applies the Contains predicate on the "record_id" field.
+func RecordIDContains(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContains(FieldRecordID, v))
+}
+
+// RecordIDHasPrefix applies the HasPrefix predicate on the "record_id" field.
+func RecordIDHasPrefix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasPrefix(FieldRecordID, v))
+}
+
+// RecordIDHasSuffix applies the HasSuffix predicate on the "record_id" field.
+func RecordIDHasSuffix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasSuffix(FieldRecordID, v))
+}
+
+// RecordIDEqualFold applies the EqualFold predicate on the "record_id" field.
+func RecordIDEqualFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEqualFold(FieldRecordID, v))
+}
+
+// RecordIDContainsFold applies the ContainsFold predicate on the "record_id" field.
+func RecordIDContainsFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContainsFold(FieldRecordID, v))
+}
+
+// RecordLabelEQ applies the EQ predicate on the "record_label" field.
+func RecordLabelEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldRecordLabel, v))
+}
+
+// RecordLabelNEQ applies the NEQ predicate on the "record_label" field.
+func RecordLabelNEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldRecordLabel, v))
+}
+
+// RecordLabelIn applies the In predicate on the "record_label" field.
+func RecordLabelIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldRecordLabel, vs...))
+}
+
+// RecordLabelNotIn applies the NotIn predicate on the "record_label" field.
+func RecordLabelNotIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldRecordLabel, vs...))
+}
+
+// RecordLabelGT applies the GT predicate on the "record_label" field.
+func RecordLabelGT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldRecordLabel, v))
+}
+
+// RecordLabelGTE applies the GTE predicate on the "record_label" field.
+func RecordLabelGTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldRecordLabel, v))
+}
+
+// RecordLabelLT applies the LT predicate on the "record_label" field.
+func RecordLabelLT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldRecordLabel, v))
+}
+
+// RecordLabelLTE applies the LTE predicate on the "record_label" field.
+func RecordLabelLTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldRecordLabel, v))
+}
+
+// RecordLabelContains applies the Contains predicate on the "record_label" field.
+func RecordLabelContains(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContains(FieldRecordLabel, v))
+}
+
+// RecordLabelHasPrefix applies the HasPrefix predicate on the "record_label" field.
+func RecordLabelHasPrefix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasPrefix(FieldRecordLabel, v))
+}
+
+// RecordLabelHasSuffix applies the HasSuffix predicate on the "record_label" field.
+func RecordLabelHasSuffix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasSuffix(FieldRecordLabel, v))
+}
+
+// RecordLabelEqualFold applies the EqualFold predicate on the "record_label" field.
+func RecordLabelEqualFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEqualFold(FieldRecordLabel, v))
+}
+
+// RecordLabelContainsFold applies the ContainsFold predicate on the "record_label" field.
+func RecordLabelContainsFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContainsFold(FieldRecordLabel, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v uuid.UUID) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldUserID, v))
+}
+
+// UserEmailEQ applies the EQ predicate on the "user_email" field.
+func UserEmailEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldUserEmail, v))
+}
+
+// UserEmailNEQ applies the NEQ predicate on the "user_email" field.
+func UserEmailNEQ(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldUserEmail, v))
+}
+
+// UserEmailIn applies the In predicate on the "user_email" field.
+func UserEmailIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldUserEmail, vs...))
+}
+
+// UserEmailNotIn applies the NotIn predicate on the "user_email" field.
+func UserEmailNotIn(vs ...string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldUserEmail, vs...))
+}
+
+// UserEmailGT applies the GT predicate on the "user_email" field.
+func UserEmailGT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldUserEmail, v))
+}
+
+// UserEmailGTE applies the GTE predicate on the "user_email" field.
+func UserEmailGTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldUserEmail, v))
+}
+
+// UserEmailLT applies the LT predicate on the "user_email" field.
+func UserEmailLT(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldUserEmail, v))
+}
+
+// UserEmailLTE applies the LTE predicate on the "user_email" field.
+func UserEmailLTE(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldUserEmail, v))
+}
+
+// UserEmailContains applies the Contains predicate on the "user_email" field.
+func UserEmailContains(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContains(FieldUserEmail, v))
+}
+
+// UserEmailHasPrefix applies the HasPrefix predicate on the "user_email" field.
+func UserEmailHasPrefix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasPrefix(FieldUserEmail, v))
+}
+
+// UserEmailHasSuffix applies the HasSuffix predicate on the "user_email" field.
+func UserEmailHasSuffix(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldHasSuffix(FieldUserEmail, v))
+}
+
+// UserEmailEqualFold applies the EqualFold predicate on the "user_email" field.
+func UserEmailEqualFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEqualFold(FieldUserEmail, v))
+}
+
+// UserEmailContainsFold applies the ContainsFold predicate on the "user_email" field.
+func UserEmailContainsFold(v string) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldContainsFold(FieldUserEmail, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.AdminAction {
+	return predicate.AdminAction(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.AdminAction) predicate.AdminAction {
+	return predicate.AdminAction(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.AdminAction) predicate.AdminAction {
+	return predicate.AdminAction(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.AdminAction) predicate.AdminAction {
+	return predicate.AdminAction(sql.NotPredicates(p))
+}
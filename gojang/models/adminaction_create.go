@@ -0,0 +1,332 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/google/uuid"
+)
+
+// AdminActionCreate is the builder for creating a AdminAction entity.
+type AdminActionCreate struct {
+	config
+	mutation *AdminActionMutation
+	hooks    []Hook
+}
+
+// SetAction sets the "action" field.
+func (_c *AdminActionCreate) SetAction(v adminaction.Action) *AdminActionCreate {
+	_c.mutation.SetAction(v)
+	return _c
+}
+
+// SetActionName sets the "action_name" field.
+func (_c *AdminActionCreate) SetActionName(v string) *AdminActionCreate {
+	_c.mutation.SetActionName(v)
+	return _c
+}
+
+// SetNillableActionName sets the "action_name" field if the given value is not nil.
+func (_c *AdminActionCreate) SetNillableActionName(v *string) *AdminActionCreate {
+	if v != nil {
+		_c.SetActionName(*v)
+	}
+	return _c
+}
+
+// SetModel sets the "model" field.
+func (_c *AdminActionCreate) SetModel(v string) *AdminActionCreate {
+	_c.mutation.SetModel(v)
+	return _c
+}
+
+// SetRecordID sets the "record_id" field.
+func (_c *AdminActionCreate) SetRecordID(v string) *AdminActionCreate {
+	_c.mutation.SetRecordID(v)
+	return _c
+}
+
+// SetRecordLabel sets the "record_label" field.
+func (_c *AdminActionCreate) SetRecordLabel(v string) *AdminActionCreate {
+	_c.mutation.SetRecordLabel(v)
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *AdminActionCreate) SetUserID(v uuid.UUID) *AdminActionCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetUserEmail sets the "user_email" field.
+func (_c *AdminActionCreate) SetUserEmail(v string) *AdminActionCreate {
+	_c.mutation.SetUserEmail(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *AdminActionCreate) SetCreatedAt(v time.Time) *AdminActionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *AdminActionCreate) SetNillableCreatedAt(v *time.Time) *AdminActionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *AdminActionCreate) SetID(v uuid.UUID) *AdminActionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *AdminActionCreate) SetNillableID(v *uuid.UUID) *AdminActionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the AdminActionMutation object of the builder.
+func (_c *AdminActionCreate) Mutation() *AdminActionMutation {
+	return _c.mutation
+}
+
+// Save creates the AdminAction in the database.
+func (_c *AdminActionCreate) Save(ctx context.Context) (*AdminAction, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *AdminActionCreate) SaveX(ctx context.Context) *AdminAction {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AdminActionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AdminActionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *AdminActionCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := adminaction.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := adminaction.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *AdminActionCreate) check() error {
+	if _, ok := _c.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New(`models: missing required field "AdminAction.action"`)}
+	}
+	if v, ok := _c.mutation.Action(); ok {
+		if err := adminaction.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`models: validator failed for field "AdminAction.action": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Model(); !ok {
+		return &ValidationError{Name: "model", err: errors.New(`models: missing required field "AdminAction.model"`)}
+	}
+	if v, ok := _c.mutation.Model(); ok {
+		if err := adminaction.ModelValidator(v); err != nil {
+			return &ValidationError{Name: "model", err: fmt.Errorf(`models: validator failed for field "AdminAction.model": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.RecordID(); !ok {
+		return &ValidationError{Name: "record_id", err: errors.New(`models: missing required field "AdminAction.record_id"`)}
+	}
+	if _, ok := _c.mutation.RecordLabel(); !ok {
+		return &ValidationError{Name: "record_label", err: errors.New(`models: missing required field "AdminAction.record_label"`)}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`models: missing required field "AdminAction.user_id"`)}
+	}
+	if _, ok := _c.mutation.UserEmail(); !ok {
+		return &ValidationError{Name: "user_email", err: errors.New(`models: missing required field "AdminAction.user_email"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`models: missing required field "AdminAction.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *AdminActionCreate) sqlSave(ctx context.Context) (*AdminAction, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *AdminActionCreate) createSpec() (*AdminAction, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AdminAction{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(adminaction.Table, sqlgraph.NewFieldSpec(adminaction.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Action(); ok {
+		_spec.SetField(adminaction.FieldAction, field.TypeEnum, value)
+		_node.Action = value
+	}
+	if value, ok := _c.mutation.ActionName(); ok {
+		_spec.SetField(adminaction.FieldActionName, field.TypeString, value)
+		_node.ActionName = value
+	}
+	if value, ok := _c.mutation.Model(); ok {
+		_spec.SetField(adminaction.FieldModel, field.TypeString, value)
+		_node.Model = value
+	}
+	if value, ok := _c.mutation.RecordID(); ok {
+		_spec.SetField(adminaction.FieldRecordID, field.TypeString, value)
+		_node.RecordID = value
+	}
+	if value, ok := _c.mutation.RecordLabel(); ok {
+		_spec.SetField(adminaction.FieldRecordLabel, field.TypeString, value)
+		_node.RecordLabel = value
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(adminaction.FieldUserID, field.TypeUUID, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.UserEmail(); ok {
+		_spec.SetField(adminaction.FieldUserEmail, field.TypeString, value)
+		_node.UserEmail = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(adminaction.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// AdminActionCreateBulk is the builder for creating many AdminAction entities in bulk.
+type AdminActionCreateBulk struct {
+	config
+	err      error
+	builders []*AdminActionCreate
+}
+
+// Save creates the AdminAction entities in the database.
+func (_c *AdminActionCreateBulk) Save(ctx context.Context) ([]*AdminAction, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*AdminAction, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AdminActionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *AdminActionCreateBulk) SaveX(ctx context.Context) []*AdminAction {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AdminActionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AdminActionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,468 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/google/uuid"
+)
+
+// AdminActionUpdate is the builder for updating AdminAction entities.
+type AdminActionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AdminActionMutation
+}
+
+// Where appends a list predicates to the AdminActionUpdate builder.
+func (_u *AdminActionUpdate) Where(ps ...predicate.AdminAction) *AdminActionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetAction sets the "action" field.
+func (_u *AdminActionUpdate) SetAction(v adminaction.Action) *AdminActionUpdate {
+	_u.mutation.SetAction(v)
+	return _u
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (_u *AdminActionUpdate) SetNillableAction(v *adminaction.Action) *AdminActionUpdate {
+	if v != nil {
+		_u.SetAction(*v)
+	}
+	return _u
+}
+
+// SetActionName sets the "action_name" field.
+func (_u *AdminActionUpdate) SetActionName(v string) *AdminActionUpdate {
+	_u.mutation.SetActionName(v)
+	return _u
+}
+
+// SetNillableActionName sets the "action_name" field if the given value is not nil.
+func (_u *AdminActionUpdate) SetNillableActionName(v *string) *AdminActionUpdate {
+	if v != nil {
+		_u.SetActionName(*v)
+	}
+	return _u
+}
+
+// ClearActionName clears the value of the "action_name" field.
+func (_u *AdminActionUpdate) ClearActionName() *AdminActionUpdate {
+	_u.mutation.ClearActionName()
+	return _u
+}
+
+// SetModel sets the "model" field.
+func (_u *AdminActionUpdate) SetModel(v string) *AdminActionUpdate {
+	_u.mutation.SetModel(v)
+	return _u
+}
+
+// SetNillableModel sets the "model" field if the given value is not nil.
+func (_u *AdminActionUpdate) SetNillableModel(v *string) *AdminActionUpdate {
+	if v != nil {
+		_u.SetModel(*v)
+	}
+	return _u
+}
+
+// SetRecordID sets the "record_id" field.
+func (_u *AdminActionUpdate) SetRecordID(v string) *AdminActionUpdate {
+	_u.mutation.SetRecordID(v)
+	return _u
+}
+
+// SetNillableRecordID sets the "record_id" field if the given value is not nil.
+func (_u *AdminActionUpdate) SetNillableRecordID(v *string) *AdminActionUpdate {
+	if v != nil {
+		_u.SetRecordID(*v)
+	}
+	return _u
+}
+
+// SetRecordLabel sets the "record_label" field.
+func (_u *AdminActionUpdate) SetRecordLabel(v string) *AdminActionUpdate {
+	_u.mutation.SetRecordLabel(v)
+	return _u
+}
+
+// SetNillableRecordLabel sets the "record_label" field if the given value is not nil.
+func (_u *AdminActionUpdate) SetNillableRecordLabel(v *string) *AdminActionUpdate {
+	if v != nil {
+		_u.SetRecordLabel(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *AdminActionUpdate) SetUserID(v uuid.UUID) *AdminActionUpdate {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *AdminActionUpdate) SetNillableUserID(v *uuid.UUID) *AdminActionUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetUserEmail sets the "user_email" field.
+func (_u *AdminActionUpdate) SetUserEmail(v string) *AdminActionUpdate {
+	_u.mutation.SetUserEmail(v)
+	return _u
+}
+
+// SetNillableUserEmail sets the "user_email" field if the given value is not nil.
+func (_u *AdminActionUpdate) SetNillableUserEmail(v *string) *AdminActionUpdate {
+	if v != nil {
+		_u.SetUserEmail(*v)
+	}
+	return _u
+}
+
+// Mutation returns the AdminActionMutation object of the builder.
+func (_u *AdminActionUpdate) Mutation() *AdminActionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *AdminActionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AdminActionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *AdminActionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AdminActionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AdminActionUpdate) check() error {
+	if v, ok := _u.mutation.Action(); ok {
+		if err := adminaction.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`models: validator failed for field "AdminAction.action": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Model(); ok {
+		if err := adminaction.ModelValidator(v); err != nil {
+			return &ValidationError{Name: "model", err: fmt.Errorf(`models: validator failed for field "AdminAction.model": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *AdminActionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(adminaction.Table, adminaction.Columns, sqlgraph.NewFieldSpec(adminaction.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Action(); ok {
+		_spec.SetField(adminaction.FieldAction, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.ActionName(); ok {
+		_spec.SetField(adminaction.FieldActionName, field.TypeString, value)
+	}
+	if _u.mutation.ActionNameCleared() {
+		_spec.ClearField(adminaction.FieldActionName, field.TypeString)
+	}
+	if value, ok := _u.mutation.Model(); ok {
+		_spec.SetField(adminaction.FieldModel, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RecordID(); ok {
+		_spec.SetField(adminaction.FieldRecordID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RecordLabel(); ok {
+		_spec.SetField(adminaction.FieldRecordLabel, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(adminaction.FieldUserID, field.TypeUUID, value)
+	}
+	if value, ok := _u.mutation.UserEmail(); ok {
+		_spec.SetField(adminaction.FieldUserEmail, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{adminaction.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// AdminActionUpdateOne is the builder for updating a single AdminAction entity.
+type AdminActionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AdminActionMutation
+}
+
+// SetAction sets the "action" field.
+func (_u *AdminActionUpdateOne) SetAction(v adminaction.Action) *AdminActionUpdateOne {
+	_u.mutation.SetAction(v)
+	return _u
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (_u *AdminActionUpdateOne) SetNillableAction(v *adminaction.Action) *AdminActionUpdateOne {
+	if v != nil {
+		_u.SetAction(*v)
+	}
+	return _u
+}
+
+// SetActionName sets the "action_name" field.
+func (_u *AdminActionUpdateOne) SetActionName(v string) *AdminActionUpdateOne {
+	_u.mutation.SetActionName(v)
+	return _u
+}
+
+// SetNillableActionName sets the "action_name" field if the given value is not nil.
+func (_u *AdminActionUpdateOne) SetNillableActionName(v *string) *AdminActionUpdateOne {
+	if v != nil {
+		_u.SetActionName(*v)
+	}
+	return _u
+}
+
+// ClearActionName clears the value of the "action_name" field.
+func (_u *AdminActionUpdateOne) ClearActionName() *AdminActionUpdateOne {
+	_u.mutation.ClearActionName()
+	return _u
+}
+
+// SetModel sets the "model" field.
+func (_u *AdminActionUpdateOne) SetModel(v string) *AdminActionUpdateOne {
+	_u.mutation.SetModel(v)
+	return _u
+}
+
+// SetNillableModel sets the "model" field if the given value is not nil.
+func (_u *AdminActionUpdateOne) SetNillableModel(v *string) *AdminActionUpdateOne {
+	if v != nil {
+		_u.SetModel(*v)
+	}
+	return _u
+}
+
+// SetRecordID sets the "record_id" field.
+func (_u *AdminActionUpdateOne) SetRecordID(v string) *AdminActionUpdateOne {
+	_u.mutation.SetRecordID(v)
+	return _u
+}
+
+// SetNillableRecordID sets the "record_id" field if the given value is not nil.
+func (_u *AdminActionUpdateOne) SetNillableRecordID(v *string) *AdminActionUpdateOne {
+	if v != nil {
+		_u.SetRecordID(*v)
+	}
+	return _u
+}
+
+// SetRecordLabel sets the "record_label" field.
+func (_u *AdminActionUpdateOne) SetRecordLabel(v string) *AdminActionUpdateOne {
+	_u.mutation.SetRecordLabel(v)
+	return _u
+}
+
+// SetNillableRecordLabel sets the "record_label" field if the given value is not nil.
+func (_u *AdminActionUpdateOne) SetNillableRecordLabel(v *string) *AdminActionUpdateOne {
+	if v != nil {
+		_u.SetRecordLabel(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *AdminActionUpdateOne) SetUserID(v uuid.UUID) *AdminActionUpdateOne {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *AdminActionUpdateOne) SetNillableUserID(v *uuid.UUID) *AdminActionUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetUserEmail sets the "user_email" field.
+func (_u *AdminActionUpdateOne) SetUserEmail(v string) *AdminActionUpdateOne {
+	_u.mutation.SetUserEmail(v)
+	return _u
+}
+
+// SetNillableUserEmail sets the "user_email" field if the given value is not nil.
+func (_u *AdminActionUpdateOne) SetNillableUserEmail(v *string) *AdminActionUpdateOne {
+	if v != nil {
+		_u.SetUserEmail(*v)
+	}
+	return _u
+}
+
+// Mutation returns the AdminActionMutation object of the builder.
+func (_u *AdminActionUpdateOne) Mutation() *AdminActionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the AdminActionUpdate builder.
+func (_u *AdminActionUpdateOne) Where(ps ...predicate.AdminAction) *AdminActionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *AdminActionUpdateOne) Select(field string, fields ...string) *AdminActionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated AdminAction entity.
+func (_u *AdminActionUpdateOne) Save(ctx context.Context) (*AdminAction, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AdminActionUpdateOne) SaveX(ctx context.Context) *AdminAction {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *AdminActionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AdminActionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AdminActionUpdateOne) check() error {
+	if v, ok := _u.mutation.Action(); ok {
+		if err := adminaction.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`models: validator failed for field "AdminAction.action": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Model(); ok {
+		if err := adminaction.ModelValidator(v); err != nil {
+			return &ValidationError{Name: "model", err: fmt.Errorf(`models: validator failed for field "AdminAction.model": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *AdminActionUpdateOne) sqlSave(ctx context.Context) (_node *AdminAction, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(adminaction.Table, adminaction.Columns, sqlgraph.NewFieldSpec(adminaction.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`models: missing "AdminAction.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, adminaction.FieldID)
+		for _, f := range fields {
+			if !adminaction.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("models: invalid field %q for query", f)}
+			}
+			if f != adminaction.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Action(); ok {
+		_spec.SetField(adminaction.FieldAction, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.ActionName(); ok {
+		_spec.SetField(adminaction.FieldActionName, field.TypeString, value)
+	}
+	if _u.mutation.ActionNameCleared() {
+		_spec.ClearField(adminaction.FieldActionName, field.TypeString)
+	}
+	if value, ok := _u.mutation.Model(); ok {
+		_spec.SetField(adminaction.FieldModel, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RecordID(); ok {
+		_spec.SetField(adminaction.FieldRecordID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RecordLabel(); ok {
+		_spec.SetField(adminaction.FieldRecordLabel, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(adminaction.FieldUserID, field.TypeUUID, value)
+	}
+	if value, ok := _u.mutation.UserEmail(); ok {
+		_spec.SetField(adminaction.FieldUserEmail, field.TypeString, value)
+	}
+	_node = &AdminAction{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{adminaction.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
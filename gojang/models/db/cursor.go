@@ -0,0 +1,85 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned for cursors that weren't made by EncodeCursor
+var ErrInvalidCursor = errors.New("db: invalid cursor")
+
+// cursor is the encoded form of a position; clients treat it as opaque
+type cursor[T any] struct {
+	Value T         `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// EncodeCursor returns an opaque, URL-safe cursor for the row with the given
+// ordered field value and ID.
+//
+// Cursor (keyset) pagination pages through a list ordered by one field and
+// then by ID. Each page starts after the last row of the previous one, so
+// deep pages cost the same as the first, unlike OFFSET, which reads and
+// discards every skipped row. Rows inserted while a client pages don't shift
+// the pages either.
+//
+//	value, id, err := db.DecodeCursor[time.Time](r.URL.Query().Get("cursor"))
+//	q := client.Post.Query().Order(post.OrderOption(db.CursorOrder(post.FieldCreatedAt, true)))
+//	if err == nil {
+//		q.Where(predicate.Post(db.CursorAfter(post.FieldCreatedAt, value, id, true)))
+//	}
+//	posts := q.Limit(limit + 1).AllX(ctx) // One extra row tells if there's a next page
+//	if len(posts) > limit {
+//		last := posts[limit-1]
+//		next := db.EncodeCursor(last.CreatedAt, last.ID)
+//	}
+//
+// Index the ordered field (and the ID) to keep pages fast.
+func EncodeCursor[T any](value T, id uuid.UUID) string {
+	data, _ := json.Marshal(cursor[T]{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor returns the field value and ID in a cursor from EncodeCursor.
+// It returns ErrInvalidCursor for empty or malformed cursors.
+func DecodeCursor[T any](s string) (value T, id uuid.UUID, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if s == "" || err != nil {
+		return value, id, ErrInvalidCursor
+	}
+	var c cursor[T]
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == uuid.Nil {
+		return value, id, ErrInvalidCursor
+	}
+	return c.Value, c.ID, nil
+}
+
+// CursorOrder orders by field and then by ID, both descending if desc is set.
+// The ID breaks ties between rows with the same field value.
+func CursorOrder(field string, desc bool) func(*entsql.Selector) {
+	return func(s *entsql.Selector) {
+		if desc {
+			s.OrderBy(entsql.Desc(s.C(field)), entsql.Desc(s.C("id")))
+		} else {
+			s.OrderBy(entsql.Asc(s.C(field)), entsql.Asc(s.C("id")))
+		}
+	}
+}
+
+// CursorAfter matches the rows that come after (value, id) in CursorOrder
+func CursorAfter(field string, value interface{}, id uuid.UUID, desc bool) func(*entsql.Selector) {
+	return func(s *entsql.Selector) {
+		beyond := entsql.GT
+		if desc {
+			beyond = entsql.LT
+		}
+		s.Where(entsql.Or(
+			beyond(s.C(field), value),
+			entsql.And(entsql.EQ(s.C(field), value), beyond(s.C("id"), id)),
+		))
+	}
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/google/uuid"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	at := time.Date(2026, 10, 15, 12, 30, 0, 123456789, time.UTC)
+	id := uuid.New()
+
+	value, gotID, err := DecodeCursor[time.Time](EncodeCursor(at, id))
+	if err != nil || !value.Equal(at) || gotID != id {
+		t.Errorf("DecodeCursor = %v, %v, %v; want %v, %v", value, gotID, err, at, id)
+	}
+	for _, bad := range []string{"", "not base64!", "e30", EncodeCursor(at, uuid.Nil)} {
+		if _, _, err := DecodeCursor[time.Time](bad); err != ErrInvalidCursor {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+}
+
+// TestCursor_Pages tests paging through rows that share an ordered value
+func TestCursor_Pages(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := context.Background()
+
+	author := client.User.Create().SetEmail("a@example.com").SetPasswordHash("x").SaveX(ctx)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := map[uuid.UUID]bool{}
+	for i := 0; i < 7; i++ {
+		// Pairs of posts share a timestamp, so the ID has to break ties
+		p := client.Post.Create().SetSubject("s").SetBody("b").SetAuthor(author).
+			SetCreatedAt(base.Add(time.Duration(i/2) * time.Hour)).SaveX(ctx)
+		want[p.ID] = true
+	}
+
+	for _, desc := range []bool{true, false} {
+		var (
+			seen  []*models.Post
+			next  string
+			pages int
+		)
+		for {
+			q := client.Post.Query().Order(post.OrderOption(CursorOrder(post.FieldCreatedAt, desc)))
+			if next != "" {
+				at, id, err := DecodeCursor[time.Time](next)
+				if err != nil {
+					t.Fatal(err)
+				}
+				q.Where(predicate.Post(CursorAfter(post.FieldCreatedAt, at, id, desc)))
+			}
+			page := q.Limit(3).AllX(ctx)
+			pages++
+			seen = append(seen, page...)
+			if len(page) < 3 {
+				break
+			}
+			last := page[len(page)-1]
+			next = EncodeCursor(last.CreatedAt, last.ID)
+		}
+
+		if len(seen) != len(want) || pages != 3 {
+			t.Fatalf("desc=%v: expected %d posts over 3 pages, got %d over %d", desc, len(want), len(seen), pages)
+		}
+		distinct := map[uuid.UUID]bool{}
+		for i, p := range seen {
+			distinct[p.ID] = true
+			if i == 0 {
+				continue
+			}
+			prev := seen[i-1]
+			if desc && prev.CreatedAt.Before(p.CreatedAt) || !desc && prev.CreatedAt.After(p.CreatedAt) {
+				t.Errorf("desc=%v: posts out of order at %d", desc, i)
+			}
+		}
+		if len(distinct) != len(want) {
+			t.Errorf("desc=%v: expected every post once, got %d distinct", desc, len(distinct))
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/migrate"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+)
+
+// DefaultDatabase is the name of the DATABASE_URL connection
+const DefaultDatabase = "default"
+
+// readSuffix marks a route that only applies to reads (e.g., "Post:read=reporting")
+const readSuffix = ":read"
+
+// Databases holds named database connections and routes models to them, for
+// apps that keep some data (analytics, a legacy system) in a separate database.
+// Models without a route use the default database:
+//
+//	dbs := db.NewDatabases(client)
+//	dbs.Open("analytics", "postgres://...")
+//	dbs.Route("PageView", "analytics")       // Reads and writes
+//	dbs.Route("Post:read", "reporting")      // Reads only; writes stay on default
+//	views := dbs.Reader("PageView").PageView.Query().CountX(ctx)
+//
+// Every connection is a full client, but only the routed model's queries
+// should go through it. Ent can't join or use transactions across
+// databases, so keep models that reference each other together.
+type Databases struct {
+	clients map[string]*models.Client
+	drivers map[string]*entsql.Driver
+	routes  map[string]string // "PageView" or "Post:read" -> database name
+}
+
+// NewDatabases returns a router whose default database is client
+func NewDatabases(client *models.Client) *Databases {
+	return &Databases{
+		clients: map[string]*models.Client{DefaultDatabase: client},
+		drivers: map[string]*entsql.Driver{},
+		routes:  map[string]string{},
+	}
+}
+
+// Open connects the named database (a sqlite:// or postgres:// URL)
+func (d *Databases) Open(name, databaseURL string) error {
+	if _, exists := d.clients[name]; exists {
+		return fmt.Errorf("database %q is already open", name)
+	}
+	drv, err := OpenDriver(databaseURL)
+	if err != nil {
+		return fmt.Errorf("database %q: %w", name, err)
+	}
+	d.clients[name] = models.NewClient(models.Driver(drv))
+	d.drivers[name] = drv
+	return nil
+}
+
+// Route sends model's queries to the named database. A ":read" suffix
+// (e.g., "Post:read") only routes reads, see Reader.
+func (d *Databases) Route(model, name string) error {
+	if _, ok := d.clients[name]; !ok {
+		return fmt.Errorf("route %s: database %q is not open", model, name)
+	}
+	if TableOf(strings.TrimSuffix(model, readSuffix)) == nil {
+		return fmt.Errorf("route %s: unknown model", model)
+	}
+	d.routes[model] = name
+	return nil
+}
+
+// Client returns the named database's client, or nil if it isn't open
+func (d *Databases) Client(name string) *models.Client {
+	return d.clients[name]
+}
+
+// For returns the client that writes model
+func (d *Databases) For(model string) *models.Client {
+	if name, ok := d.routes[model]; ok {
+		return d.clients[name]
+	}
+	return d.clients[DefaultDatabase]
+}
+
+// Reader returns the client that reads model: its ":read" route if it has
+// one, otherwise the same client as For
+func (d *Databases) Reader(model string) *models.Client {
+	if name, ok := d.routes[model+readSuffix]; ok {
+		return d.clients[name]
+	}
+	return d.For(model)
+}
+
+// Migrate creates the tables of models routed to each non-default database
+// (the default database is migrated by AutoMigrate). Read routes point at
+// databases kept up to date elsewhere, such as replicas, so they're skipped.
+// Foreign keys to tables in other databases are left out.
+func (d *Databases) Migrate(ctx context.Context) error {
+	tables := map[string][]*schema.Table{}
+	for model, name := range d.routes {
+		if name == DefaultDatabase || strings.HasSuffix(model, readSuffix) {
+			continue
+		}
+		tables[name] = append(tables[name], TableOf(model))
+	}
+	for name, ts := range tables {
+		m, err := schema.NewMigrate(d.drivers[name], schema.WithForeignKeys(false))
+		if err != nil {
+			return fmt.Errorf("database %q: %w", name, err)
+		}
+		if err := m.Create(ctx, ts...); err != nil {
+			return fmt.Errorf("database %q: failed creating schema resources: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Close closes the databases opened with Open; the default client is left to its owner
+func (d *Databases) Close() error {
+	var firstErr error
+	for name, client := range d.clients {
+		if name == DefaultDatabase {
+			continue
+		}
+		if err := client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Configure opens the databases ("name=URL") and adds the routes
+// ("Model=name" or "Model:read=name") from DATABASES and DATABASE_ROUTES
+func (d *Databases) Configure(databases, routes []string) error {
+	urls, err := ParseNamed(databases)
+	if err != nil {
+		return fmt.Errorf("DATABASES: %w", err)
+	}
+	for name, databaseURL := range urls {
+		if err := d.Open(name, databaseURL); err != nil {
+			return err
+		}
+	}
+	bound, err := ParseNamed(routes)
+	if err != nil {
+		return fmt.Errorf("DATABASE_ROUTES: %w", err)
+	}
+	for model, name := range bound {
+		if err := d.Route(model, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseNamed splits "name=value" entries (see Configure)
+func ParseNamed(entries []string) (map[string]string, error) {
+	named := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("%q should be in \"name=value\" format", entry)
+		}
+		named[name] = value
+	}
+	return named, nil
+}
+
+// TableOf returns the table Ent generates for model, using its default naming
+// ("UserPreference" -> "user_preferences"), or nil for an unknown model
+func TableOf(model string) *schema.Table {
+	var b strings.Builder
+	for i, r := range model {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	snake := b.String()
+	candidates := []string{snake + "s", snake + "es"}
+	if strings.HasSuffix(snake, "y") {
+		candidates = append(candidates, strings.TrimSuffix(snake, "y")+"ies")
+	}
+	for _, t := range migrate.Tables {
+		for _, name := range candidates {
+			if t.Name == name {
+				return t
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+)
+
+// TestDatabases tests that routed models read and write their own database
+func TestDatabases(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := context.Background()
+
+	dbs := NewDatabases(client)
+	defer dbs.Close()
+	err := dbs.Configure(
+		[]string{"analytics=sqlite://" + filepath.Join(t.TempDir(), "analytics.db")},
+		[]string{"Setting=analytics", "UserPreference:read=analytics"},
+	)
+	if err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if err := dbs.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	analytics := dbs.Client("analytics")
+	if dbs.For("Setting") != analytics || dbs.Reader("Setting") != analytics {
+		t.Error("Expected Setting to use the analytics database")
+	}
+	if dbs.For("UserPreference") != client || dbs.Reader("UserPreference") != analytics {
+		t.Error("Expected UserPreference to write to default and read from analytics")
+	}
+	if dbs.For("Post") != client || dbs.Reader("Post") != client {
+		t.Error("Expected Post to use the default database")
+	}
+
+	dbs.For("Setting").Setting.Create().SetKey("k").SetValue("v").SaveX(ctx)
+	if n := client.Setting.Query().CountX(ctx); n != 0 {
+		t.Errorf("Expected no settings in the default database, got %d", n)
+	}
+	if n := analytics.Setting.Query().CountX(ctx); n != 1 {
+		t.Errorf("Expected 1 setting in the analytics database, got %d", n)
+	}
+	// Only routed tables are created
+	if _, err := analytics.Post.Query().Count(ctx); err == nil {
+		t.Error("Expected no posts table in the analytics database")
+	}
+}
+
+// TestDatabases_Configure tests that bad DATABASES and DATABASE_ROUTES entries are rejected
+func TestDatabases_Configure(t *testing.T) {
+	tests := []struct {
+		name      string
+		databases []string
+		routes    []string
+	}{
+		{"missing URL", []string{"analytics"}, nil},
+		{"unsupported URL", []string{"analytics=mysql://localhost/a"}, nil},
+		{"unknown database", nil, []string{"Setting=analytics"}},
+		{"unknown model", []string{"analytics=sqlite://" + filepath.Join(t.TempDir(), "a.db")}, []string{"PageView=analytics"}},
+	}
+	for _, tt := range tests {
+		dbs := NewDatabases(nil)
+		if err := dbs.Configure(tt.databases, tt.routes); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+		dbs.Close()
+	}
+}
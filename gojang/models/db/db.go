@@ -4,18 +4,33 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/utils"
 
+	atlas "ariga.io/atlas/sql/schema"
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
 	_ "github.com/lib/pq"           // Postgres driver
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
 )
 
 // NewClient creates a new Ent client from a database URL
 func NewClient(databaseURL string) (*models.Client, error) {
+	drv, err := OpenDriver(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewClient(models.Driver(drv)), nil
+}
+
+// OpenDriver opens the Ent driver for a sqlite:// or postgres:// URL. Tools that
+// need plain SQL as well as a client can use its DB().
+func OpenDriver(databaseURL string) (*entsql.Driver, error) {
 	var (
 		db         *sql.DB
 		err        error
@@ -25,8 +40,7 @@ func NewClient(databaseURL string) (*models.Client, error) {
 	// Parse database URL to determine driver
 	if strings.HasPrefix(databaseURL, "sqlite://") {
 		driverName = dialect.SQLite
-		dbPath := strings.TrimPrefix(databaseURL, "sqlite://")
-		db, err = sql.Open("sqlite3", dbPath+"?_fk=1")
+		db, err = sql.Open("sqlite3", SQLiteDSN(databaseURL))
 	} else if strings.HasPrefix(databaseURL, "postgres://") {
 		driverName = dialect.Postgres
 		db, err = sql.Open("postgres", databaseURL)
@@ -38,17 +52,110 @@ func NewClient(databaseURL string) (*models.Client, error) {
 		return nil, fmt.Errorf("failed opening database: %w", err)
 	}
 
-	// Create Ent driver
-	drv := entsql.OpenDB(driverName, db)
-	client := models.NewClient(models.Driver(drv))
-
-	return client, nil
+	return entsql.OpenDB(driverName, db), nil
 }
 
-// AutoMigrate runs automatic migrations (creates/updates tables)
+// emailIndex is the unique index on lower(email), so no two accounts have
+// emails that only differ in case, which would make logins ambiguous
+const emailIndex = "users_email_lower"
+
+// AutoMigrate runs automatic migrations (creates/updates tables and indexes)
 func AutoMigrate(ctx context.Context, client *models.Client) error {
-	if err := client.Schema.Create(ctx); err != nil {
+	if err := client.Schema.Create(ctx, schema.WithDiffHook(addEmailIndex)); err != nil {
+		if strings.Contains(err.Error(), emailIndex) {
+			return fmt.Errorf("failed creating the unique index on lower(email): some users' emails only differ in case; run `gojang normalize-emails` to list them: %w", err)
+		}
 		return fmt.Errorf("failed creating schema resources: %w", err)
 	}
 	return nil
 }
+
+// addEmailIndex adds emailIndex to the migration when the users table doesn't
+// have it yet. Ent can't declare indexes on expressions, so it's added here.
+func addEmailIndex(next schema.Differ) schema.Differ {
+	return schema.DiffFunc(func(current, desired *atlas.Schema) ([]atlas.Change, error) {
+		changes, err := next.Diff(current, desired)
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := current.Table(user.Table); ok {
+			if _, ok := t.Index(emailIndex); ok {
+				return changes, nil
+			}
+		}
+		t, ok := desired.Table(user.Table)
+		if !ok {
+			return changes, nil
+		}
+		index := atlas.NewUniqueIndex(emailIndex).AddExprs(&atlas.RawExpr{X: "lower(" + user.FieldEmail + ")"})
+		index.Table = t
+		return append(changes, &atlas.ModifyTable{T: t, Changes: []atlas.Change{&atlas.AddIndex{I: index}}}), nil
+	})
+}
+
+// EmailConflicts lists the emails NormalizeUserEmails can't normalize because
+// several accounts have them, differing only in case or surrounding spaces.
+// Merge or rename those accounts by hand, then run it again.
+type EmailConflicts map[string][]string // Normalized email -> the accounts' emails
+
+func (e EmailConflicts) Error() string {
+	emails := make([]string, 0, len(e))
+	for email := range e {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+	lines := make([]string, len(emails))
+	for i, email := range emails {
+		lines[i] = fmt.Sprintf("%s: %q", email, e[email])
+	}
+	return fmt.Sprintf("%d email(s) belong to several accounts:\n  %s", len(e), strings.Join(lines, "\n  "))
+}
+
+// NormalizeUserEmails lowercases and trims existing user emails (see
+// utils.NormalizeEmail) and returns how many rows changed. It's a one-off for
+// databases from before emails were normalized, run by `gojang
+// normalize-emails`, and safe to run again. If several accounts' emails
+// normalize to the same address, it changes nothing and returns EmailConflicts.
+func NormalizeUserEmails(ctx context.Context, client *models.Client) (int, error) {
+	users, err := client.User.Query().
+		Select(user.FieldID, user.FieldEmail).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	byEmail := make(map[string][]*models.User, len(users))
+	for _, u := range users {
+		normalized := utils.NormalizeEmail(u.Email)
+		byEmail[normalized] = append(byEmail[normalized], u)
+	}
+	conflicts := make(EmailConflicts)
+	for normalized, owners := range byEmail {
+		if len(owners) > 1 {
+			for _, u := range owners {
+				conflicts[normalized] = append(conflicts[normalized], u.Email)
+			}
+			sort.Strings(conflicts[normalized])
+		}
+	}
+	if len(conflicts) > 0 {
+		return 0, conflicts
+	}
+
+	changed := 0
+	for _, u := range users {
+		normalized := utils.NormalizeEmail(u.Email)
+		if normalized == u.Email {
+			continue
+		}
+		if err := client.User.UpdateOneID(u.ID).SetEmail(normalized).Exec(ctx); err != nil {
+			return changed, fmt.Errorf("normalizing email of user %s: %w", u.ID, err)
+		}
+		changed++
+	}
+
+	if changed > 0 {
+		utils.Infow("db.emails_normalized", "count", changed)
+	}
+	return changed, nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// TestNormalizeUserEmails tests that mixed-case emails are lowercased
+func TestNormalizeUserEmails(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := context.Background()
+
+	mixed := client.User.Create().SetEmail(" Alice@Example.com").SetPasswordHash("x").SaveX(ctx)
+	client.User.Create().SetEmail("bob@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	changed, err := NormalizeUserEmails(ctx, client)
+	if err != nil {
+		t.Fatalf("NormalizeUserEmails failed: %v", err)
+	}
+	if changed != 1 {
+		t.Errorf("Expected 1 row changed, got %d", changed)
+	}
+	if got := client.User.GetX(ctx, mixed.ID).Email; got != "alice@example.com" {
+		t.Errorf("Expected normalized email, got %q", got)
+	}
+
+	// Running again is a no-op
+	if changed, _ := NormalizeUserEmails(ctx, client); changed != 0 {
+		t.Errorf("Expected second run to change nothing, got %d", changed)
+	}
+}
+
+// TestNormalizeUserEmails_Conflicts tests that emails several accounts share
+// once normalized are reported, and nothing is changed
+func TestNormalizeUserEmails_Conflicts(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := context.Background()
+
+	mixed := client.User.Create().SetEmail("Alice@Example.com").SetPasswordHash("x").SaveX(ctx)
+	client.User.Create().SetEmail("bob@example.com").SetPasswordHash("x").SaveX(ctx)
+	client.User.Create().SetEmail("BOB@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	changed, err := NormalizeUserEmails(ctx, client)
+	var conflicts EmailConflicts
+	if !errors.As(err, &conflicts) {
+		t.Fatalf("Expected EmailConflicts, got %v", err)
+	}
+	if changed != 0 || len(conflicts) != 1 || strings.Join(conflicts["bob@example.com"], ",") != "BOB@example.com,bob@example.com" {
+		t.Errorf("Expected the bob@example.com accounts listed and nothing changed, got %d and %v", changed, conflicts)
+	}
+	if got := client.User.GetX(ctx, mixed.ID).Email; got != "Alice@Example.com" {
+		t.Errorf("Expected no email to change, got %q", got)
+	}
+}
+
+// TestAutoMigrate_EmailIndex tests that the database refuses emails that only
+// differ in case, and that migrating refuses to start while some do
+func TestAutoMigrate_EmailIndex(t *testing.T) {
+	ctx := context.Background()
+	open := func() *models.Client {
+		client, err := NewClient("sqlite://" + filepath.Join(t.TempDir(), "app.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	client := open()
+	for range 2 { // Migrating again finds the index
+		if err := AutoMigrate(ctx, client); err != nil {
+			t.Fatalf("AutoMigrate failed: %v", err)
+		}
+	}
+	client.User.Create().SetEmail("bob@example.com").SetPasswordHash("x").SaveX(ctx)
+	if _, err := client.User.Create().SetEmail("Bob@Example.com").SetPasswordHash("x").Save(ctx); !models.IsConstraintError(err) {
+		t.Errorf("Expected a constraint error for an email differing in case, got %v", err)
+	}
+
+	// A database from before the index, with such emails
+	old := open()
+	if err := old.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	old.User.Create().SetEmail("bob@example.com").SetPasswordHash("x").SaveX(ctx)
+	old.User.Create().SetEmail("BOB@example.com").SetPasswordHash("x").SaveX(ctx)
+	if err := AutoMigrate(ctx, old); err == nil || !strings.Contains(err.Error(), "gojang normalize-emails") {
+		t.Errorf("Expected migrating to point at normalize-emails, got %v", err)
+	}
+}
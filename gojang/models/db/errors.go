@@ -0,0 +1,148 @@
+package db
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/lib/pq"
+)
+
+var (
+	// SQLite: "UNIQUE constraint failed: users.email", "NOT NULL constraint failed: posts.subject"
+	sqliteColumnConstraint = regexp.MustCompile(`(UNIQUE|NOT NULL) constraint failed: \w+\.(\w+)`)
+	// Postgres unique violation detail: "Key (email)=(a@example.com) already exists."
+	postgresKeyDetail = regexp.MustCompile(`^Key \(([\w]+)`)
+)
+
+// validatorMessages rewords Ent's built-in validator errors
+var validatorMessages = map[string]string{
+	"value is less than the required length":         "is too short",
+	"value is less than the required rune length":    "is too short",
+	"value is greater than the required length":      "is too long",
+	"value is greater than the required rune length": "is too long",
+	"value does not match validation":                "has an invalid format",
+	"value out of range":                             "is out of range",
+}
+
+// FieldError converts an Ent validation or constraint error into a message fit
+// to show on a form. field is the Go field name (e.g., "Email"), or "" when the
+// error doesn't concern a single field. ok is false for any other error, which
+// handlers should keep reporting as a server error:
+//
+//	if field, msg, ok := db.FieldError(err); ok {
+//		errors[field] = msg // "Email is already taken"
+//	}
+func FieldError(err error) (field, message string, ok bool) {
+	var verr *models.ValidationError
+	if errors.As(err, &verr) {
+		field = goFieldName(verr.Name)
+		if strings.Contains(verr.Error(), "missing required field") {
+			return field, fieldLabel(field) + " is required", true
+		}
+		// Validators are wrapped as: validator failed for field "Post.subject": <cause>
+		cause := verr.Unwrap()
+		if inner := errors.Unwrap(cause); inner != nil {
+			cause = inner
+		}
+		if msg, known := validatorMessages[cause.Error()]; known {
+			return field, fieldLabel(field) + " " + msg, true
+		}
+		return field, fieldLabel(field) + " is invalid: " + cause.Error(), true
+	}
+
+	if !models.IsConstraintError(err) {
+		return "", "", false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			if m := postgresKeyDetail.FindStringSubmatch(pqErr.Detail); m != nil {
+				field = goFieldName(m[1])
+				return field, fieldLabel(field) + " is already taken", true
+			}
+			return "", "A record with these values already exists", true
+		case "not_null_violation":
+			field = goFieldName(pqErr.Column)
+			return field, fieldLabel(field) + " is required", true
+		case "foreign_key_violation":
+			return "", foreignKeyMessage, true
+		}
+		return "", invalidMessage, true
+	}
+
+	msg := err.Error()
+	if m := sqliteColumnConstraint.FindStringSubmatch(msg); m != nil {
+		field = goFieldName(m[2])
+		if m[1] == "UNIQUE" {
+			return field, fieldLabel(field) + " is already taken", true
+		}
+		return field, fieldLabel(field) + " is required", true
+	}
+	if strings.Contains(msg, "FOREIGN KEY constraint failed") {
+		return "", foreignKeyMessage, true
+	}
+	return "", invalidMessage, true
+}
+
+// FormErrors is FieldError as the errors map forms render, for handlers to
+// return after a failed save. Errors that don't concern a single field go
+// under general (e.g., "_general"); ok is false for any other error.
+//
+//	if errors, ok := db.FormErrors(err, "general"); ok {
+//		// Re-render the form with errors
+//	}
+func FormErrors(err error, general string) (map[string]string, bool) {
+	field, msg, ok := FieldError(err)
+	if !ok {
+		return nil, false
+	}
+	if field == "" {
+		field = general
+	}
+	return map[string]string{field: msg}, true
+}
+
+const (
+	foreignKeyMessage = "A related record is missing or still in use"
+	invalidMessage    = "Some values are invalid"
+)
+
+// goFieldName converts an Ent field or column name to its Go name ("password_hash" -> "PasswordHash")
+func goFieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		switch part {
+		case "":
+		case "id", "url", "ip", "uuid":
+			b.WriteString(strings.ToUpper(part))
+		default:
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// fieldLabel turns a Go field name into a label ("PasswordHash" -> "Password hash", "UserID" -> "User ID")
+func fieldLabel(field string) string {
+	var words []string
+	start := 0
+	for i := 1; i < len(field); i++ {
+		lowerBefore := field[i-1] >= 'a' && field[i-1] <= 'z'
+		upper := field[i] >= 'A' && field[i] <= 'Z'
+		if upper && lowerBefore {
+			words = append(words, field[start:i])
+			start = i
+		}
+	}
+	words = append(words, field[start:])
+	for i := 1; i < len(words); i++ {
+		if words[i] != strings.ToUpper(words[i]) || len(words[i]) == 1 {
+			words[i] = strings.ToLower(words[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
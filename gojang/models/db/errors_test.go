@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/google/uuid"
+)
+
+// TestFieldError tests that Ent validation and constraint errors become form messages
+func TestFieldError(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := context.Background()
+
+	author := client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	_, dupErr := client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").Save(ctx)
+	_, longErr := client.Post.Create().SetSubject(strings.Repeat("a", 256)).SetBody("b").SetAuthor(author).Save(ctx)
+	_, missingErr := client.Post.Create().SetSubject("s").SetAuthor(author).Save(ctx)
+
+	tests := []struct {
+		name       string
+		err        error
+		field, msg string
+		ok         bool
+	}{
+		{"unique violation", dupErr, "Email", "Email is already taken", true},
+		{"validator", longErr, "Subject", "Subject is too long", true},
+		{"missing field", missingErr, "Body", "Body is required", true},
+		{"other error", errors.New("connection refused"), "", "", false},
+	}
+	for _, tt := range tests {
+		field, msg, ok := FieldError(tt.err)
+		if field != tt.field || msg != tt.msg || ok != tt.ok {
+			t.Errorf("%s: FieldError(%v) = %q, %q, %v", tt.name, tt.err, field, msg, ok)
+		}
+	}
+}
+
+// TestFormErrors tests that errors not about one field go under the general key
+func TestFormErrors(t *testing.T) {
+	client := testdb.Open(t)
+	ctx := context.Background()
+	client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").SaveX(ctx)
+	_, dupErr := client.User.Create().SetEmail("alice@example.com").SetPasswordHash("x").Save(ctx)
+	_, fkErr := client.Post.Create().SetSubject("s").SetBody("b").SetAuthorID(uuid.New()).Save(ctx)
+
+	if got, ok := FormErrors(dupErr, "_general"); !ok || got["Email"] != "Email is already taken" || len(got) != 1 {
+		t.Errorf("Expected the email error, got %v, %v", got, ok)
+	}
+	if got, ok := FormErrors(fkErr, "_general"); !ok || got["_general"] != foreignKeyMessage || len(got) != 1 {
+		t.Errorf("Expected a general error, got %v, %v", got, ok)
+	}
+	if got, ok := FormErrors(errors.New("connection refused"), "_general"); ok || got != nil {
+		t.Errorf("Expected other errors not to be form errors, got %v", got)
+	}
+}
+
+// TestGoFieldName tests converting Ent column names to Go field names and labels
+func TestGoFieldName(t *testing.T) {
+	for column, want := range map[string]string{"email": "Email", "password_hash": "PasswordHash", "user_id": "UserID"} {
+		if got := goFieldName(column); got != want {
+			t.Errorf("goFieldName(%q) = %q, want %q", column, got, want)
+		}
+	}
+	if got := fieldLabel("PasswordHash"); got != "Password hash" {
+		t.Errorf("fieldLabel = %q", got)
+	}
+	if got := fieldLabel("UserID"); got != "User ID" {
+		t.Errorf("fieldLabel = %q", got)
+	}
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"math"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// postGIS makes distance queries use PostGIS on Postgres (see UsePostGIS)
+var postGIS bool
+
+// UsePostGIS switches WithinDistance and OrderByDistance to PostGIS geography
+// functions on Postgres, which measure on the WGS 84 ellipsoid instead of a sphere.
+// The postgis extension must be installed (CREATE EXTENSION postgis).
+func UsePostGIS(enabled bool) {
+	postGIS = enabled
+}
+
+// metersPerDegree is the length of one degree of latitude in meters
+const metersPerDegree = 6371008.8 * math.Pi / 180
+
+// WithinDistance matches records whose geo field (a utils.Point stored as JSON)
+// is within meters of center. Records without a location never match.
+//
+//	client.Store.Query().
+//		Where(predicate.Store(db.WithinDistance(store.FieldLocation, here, 5000))).
+//		Order(store.OrderOption(db.OrderByDistance(store.FieldLocation, here)))
+//
+// Postgres measures great-circle distances. SQLite has no trigonometric
+// functions, so it uses a flat-earth approximation that is accurate to within
+// a few percent for distances up to a few hundred kilometers.
+func WithinDistance(field string, center utils.Point, meters float64) func(*entsql.Selector) {
+	return func(s *entsql.Selector) {
+		lat, lng := pointColumns(s.Dialect(), s.C(field))
+		s.Where(entsql.P(func(b *entsql.Builder) {
+			switch {
+			case s.Dialect() == dialect.Postgres && postGIS:
+				b.WriteString("ST_DWithin(")
+				geography(b, lat, lng, center)
+				b.WriteString(", ").Arg(meters).WriteString(")")
+			case s.Dialect() == dialect.Postgres:
+				haversine(b, lat, lng, center)
+				b.WriteString(" <= ").Arg(meters)
+			default:
+				planarDistance(b, lat, lng, center)
+				b.WriteString(" <= ").Arg(math.Pow(meters/metersPerDegree, 2))
+			}
+		}))
+	}
+}
+
+// OrderByDistance orders records nearest to center first; records without a
+// location come last. See WithinDistance for how distances are measured.
+func OrderByDistance(field string, center utils.Point) func(*entsql.Selector) {
+	return func(s *entsql.Selector) {
+		lat, lng := pointColumns(s.Dialect(), s.C(field))
+		s.OrderExpr(entsql.ExprFunc(func(b *entsql.Builder) {
+			b.WriteString(lat + " IS NULL, ")
+			switch {
+			case s.Dialect() == dialect.Postgres && postGIS:
+				b.WriteString("ST_Distance(")
+				geography(b, lat, lng, center)
+				b.WriteString(")")
+			case s.Dialect() == dialect.Postgres:
+				haversine(b, lat, lng, center)
+			default:
+				planarDistance(b, lat, lng, center)
+			}
+		}))
+	}
+}
+
+// pointColumns returns SQL expressions for the latitude and longitude of a JSON point column
+func pointColumns(d, column string) (string, string) {
+	if d == dialect.Postgres {
+		return "((" + column + "->>'lat')::float8)", "((" + column + "->>'lng')::float8)"
+	}
+	return "json_extract(" + column + ", '$.lat')", "json_extract(" + column + ", '$.lng')"
+}
+
+// haversine writes the great-circle distance in meters between a point column and center
+func haversine(b *entsql.Builder, lat, lng string, center utils.Point) {
+	b.WriteString("2 * 6371008.8 * asin(least(1, sqrt(power(sin(radians(" + lat + " - ").Arg(center.Lat).
+		WriteString(") / 2), 2) + cos(radians(").Arg(center.Lat).
+		WriteString(")) * cos(radians(" + lat + ")) * power(sin(radians(" + lng + " - ").Arg(center.Lng).
+		WriteString(") / 2), 2))))")
+}
+
+// geography writes a point column and center as PostGIS geographies
+func geography(b *entsql.Builder, lat, lng string, center utils.Point) {
+	b.WriteString("ST_MakePoint(" + lng + ", " + lat + ")::geography, ST_MakePoint(").
+		Arg(center.Lng).WriteString(", ").Arg(center.Lat).WriteString(")::geography")
+}
+
+// planarDistance writes the squared distance in degrees of latitude between a point
+// column and center, with longitude scaled by the cosine of center's latitude
+func planarDistance(b *entsql.Builder, lat, lng string, center utils.Point) {
+	scale := math.Pow(math.Cos(center.Lat*math.Pi/180), 2)
+	b.WriteString("(" + lat + " - ").Arg(center.Lat).WriteString(") * (" + lat + " - ").Arg(center.Lat).
+		WriteString(") + (" + lng + " - ").Arg(center.Lng).WriteString(") * (" + lng + " - ").Arg(center.Lng).
+		WriteString(") * ").Arg(scale)
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// TestWithinDistance tests that distance queries find nearby points nearest first on SQLite
+func TestWithinDistance(t *testing.T) {
+	conn, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Exec(`CREATE TABLE stores (name text, location json);
+		INSERT INTO stores VALUES
+			('soho', '{"lat": 51.5136, "lng": -0.1365}'),
+			('camden', '{"lat": 51.5390, "lng": -0.1426}'),
+			('paris', '{"lat": 48.8566, "lng": 2.3522}'),
+			('nowhere', NULL)`); err != nil {
+		t.Fatal(err)
+	}
+
+	london := utils.Point{Lat: 51.5074, Lng: -0.1278}
+	selector := entsql.Dialect(dialect.SQLite).Select("name").From(entsql.Table("stores"))
+	WithinDistance("location", london, 5000)(selector)
+	OrderByDistance("location", london)(selector)
+	query, args := selector.Query()
+
+	rows, err := conn.Query(query, args...)
+	if err != nil {
+		t.Fatalf("query failed: %v\n%s", err, query)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		rows.Scan(&name)
+		names = append(names, name)
+	}
+	if strings.Join(names, ",") != "soho,camden" {
+		t.Errorf("Expected soho,camden, got %v", names)
+	}
+}
+
+// TestWithinDistance_Postgres tests that Postgres queries use great-circle distances, or PostGIS when enabled
+func TestWithinDistance_Postgres(t *testing.T) {
+	defer UsePostGIS(false)
+	center := utils.Point{Lat: 51.5074, Lng: -0.1278}
+
+	selector := entsql.Dialect(dialect.Postgres).Select("name").From(entsql.Table("stores"))
+	WithinDistance("location", center, 5000)(selector)
+	query, args := selector.Query()
+	if !strings.Contains(query, `asin(least(1, sqrt(power(sin(radians((("stores"."location"->>'lat')::float8) - $1)`) || !strings.Contains(query, "<= $4") {
+		t.Errorf("Expected a haversine distance, got %s", query)
+	}
+	if len(args) != 4 || args[3] != 5000.0 {
+		t.Errorf("Expected the radius as the last argument, got %v", args)
+	}
+
+	UsePostGIS(true)
+	selector = entsql.Dialect(dialect.Postgres).Select("name").From(entsql.Table("stores"))
+	OrderByDistance("location", center)(selector)
+	if query, _ := selector.Query(); !strings.Contains(query, "ORDER BY") || !strings.Contains(query, "ST_Distance(ST_MakePoint(") {
+		t.Errorf("Expected a PostGIS distance ordering, got %s", query)
+	}
+}
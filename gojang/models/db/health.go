@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// Monitor defaults
+const (
+	DefaultHealthInterval  = 10 * time.Second
+	DefaultHealthTimeout   = 2 * time.Second
+	DefaultHealthThreshold = 3
+)
+
+// metrics are published with expvar (see expvar.Handler) as "db":
+// pings, ping_failures, reconnects and the last ping's latency_ms
+var (
+	metrics       = expvar.NewMap("db")
+	metricLatency = new(expvar.Float)
+)
+
+func init() {
+	metrics.Set("latency_ms", metricLatency)
+}
+
+// Health is the result of the latest database ping, as served by ReadyHandler
+type Health struct {
+	OK         bool      `json:"ok"`
+	LatencyMS  float64   `json:"latency_ms"`
+	CheckedAt  time.Time `json:"checked_at"`
+	Error      string    `json:"error,omitempty"`
+	Failures   int       `json:"failures"`   // Consecutive failed pings
+	Reconnects int       `json:"reconnects"` // Connection pools replaced since startup
+}
+
+// Monitor pings the database and, after Threshold failures in a row, replaces
+// the client's connection pool with a fresh one. Queries made through the
+// client switch to the new pool without a restart; ones already running
+// finish on the old pool before it is closed.
+type Monitor struct {
+	Interval  time.Duration // Between pings (default DefaultHealthInterval)
+	Timeout   time.Duration // Per ping (default DefaultHealthTimeout)
+	Threshold int           // Failed pings before reconnecting (default DefaultHealthThreshold)
+
+	drv    *reconnectDriver
+	mu     sync.Mutex
+	health Health
+}
+
+// NewMonitoredClient is NewClient with a Monitor that can reconnect it. Each
+// wrap is applied to the driver the client queries through, e.g.
+// debugbar.Driver to record queries.
+func NewMonitoredClient(databaseURL string, wrap ...func(dialect.Driver) dialect.Driver) (*models.Client, *Monitor, error) {
+	drv, err := OpenDriver(databaseURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	rd := &reconnectDriver{url: databaseURL}
+	rd.current.Store(drv)
+	var client dialect.Driver = rd
+	for _, w := range wrap {
+		client = w(client)
+	}
+	return models.NewClient(models.Driver(client)), &Monitor{drv: rd}, nil
+}
+
+// Run pings the database every Interval until ctx is canceled
+func (m *Monitor) Run(ctx context.Context) {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = DefaultHealthInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.Check(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check pings the database now, reconnecting if it has failed Threshold times
+// in a row, and returns the result
+func (m *Monitor) Check(ctx context.Context) Health {
+	timeout, threshold := m.Timeout, m.Threshold
+	if timeout <= 0 {
+		timeout = DefaultHealthTimeout
+	}
+	if threshold <= 0 {
+		threshold = DefaultHealthThreshold
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	start := time.Now()
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	err := m.drv.driver().DB().PingContext(pingCtx)
+	cancel()
+	latency := time.Since(start)
+
+	metrics.Add("pings", 1)
+	metricLatency.Set(latency.Seconds() * 1000)
+	h := &m.health
+	h.CheckedAt, h.LatencyMS = start, latency.Seconds()*1000
+	if err == nil {
+		if h.Failures > 0 {
+			utils.Infow("db.recovered", "failures", h.Failures)
+		}
+		h.OK, h.Error, h.Failures = true, "", 0
+		return *h
+	}
+
+	metrics.Add("ping_failures", 1)
+	h.OK, h.Error = false, err.Error()
+	h.Failures++
+	utils.Warnw("db.ping_failed", "failures", h.Failures, "error", err)
+	if h.Failures >= threshold {
+		if err := m.drv.reconnect(ctx, timeout); err != nil {
+			utils.Errorw("db.reconnect_failed", "error", err)
+		} else {
+			metrics.Add("reconnects", 1)
+			h.Reconnects++
+			h.OK, h.Error, h.Failures = true, "", 0
+			utils.Warnw("db.reconnected", "reconnects", h.Reconnects)
+		}
+	}
+	return *h
+}
+
+// Health returns the latest ping's result
+func (m *Monitor) Health() Health {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.health
+}
+
+// Driver returns the connection pool in use, for plain SQL. A reconnect
+// replaces it, so call Driver for each use rather than keeping the result.
+func (m *Monitor) Driver() *entsql.Driver {
+	return m.drv.driver()
+}
+
+// ReadyHandler serves /readyz for load balancers and orchestrators: 200 when
+// the latest ping succeeded and 503 otherwise, with the Health as JSON. When
+// the result is stale because Run isn't running (e.g., serverless), it pings
+// during the request.
+func ReadyHandler(m *Monitor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		interval := m.Interval
+		if interval <= 0 {
+			interval = DefaultHealthInterval
+		}
+		h := m.Health()
+		if time.Since(h.CheckedAt) > 2*interval {
+			h = m.Check(r.Context())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if !h.OK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(h)
+	})
+}
+
+// reconnectDriver is an Ent driver whose connection pool can be swapped while
+// the client is in use
+type reconnectDriver struct {
+	url     string
+	current atomic.Pointer[entsql.Driver]
+}
+
+func (d *reconnectDriver) driver() *entsql.Driver { return d.current.Load() }
+
+func (d *reconnectDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.driver().Exec(ctx, query, args, v)
+}
+
+func (d *reconnectDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.driver().Query(ctx, query, args, v)
+}
+
+func (d *reconnectDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.driver().Tx(ctx)
+}
+
+// BeginTx is used by models.Client.BeginTx
+func (d *reconnectDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	return d.driver().BeginTx(ctx, opts)
+}
+
+func (d *reconnectDriver) Close() error { return d.driver().Close() }
+
+func (d *reconnectDriver) Dialect() string { return d.driver().Dialect() }
+
+// reconnect opens a new pool and, once it answers a ping, swaps it in and
+// closes the old one (sql.DB.Close waits for running queries)
+func (d *reconnectDriver) reconnect(ctx context.Context, timeout time.Duration) error {
+	drv, err := OpenDriver(d.url)
+	if err != nil {
+		return err
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := drv.DB().PingContext(pingCtx); err != nil {
+		drv.Close()
+		return err
+	}
+	old := d.current.Swap(drv)
+	go old.Close()
+	return nil
+}
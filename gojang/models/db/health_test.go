@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// TestMonitor tests that the monitor reports failed pings and reconnects the client
+func TestMonitor(t *testing.T) {
+	client, monitor, err := NewMonitoredClient("sqlite://" + filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if err := AutoMigrate(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	monitor.Threshold = 2
+
+	if h := monitor.Check(ctx); !h.OK || h.Failures != 0 {
+		t.Errorf("Expected a healthy database, got %+v", h)
+	}
+
+	// Simulate a lost connection pool
+	monitor.drv.driver().Close()
+	if h := monitor.Check(ctx); h.OK || h.Failures != 1 || h.Error == "" {
+		t.Errorf("Expected a failed ping, got %+v", h)
+	}
+	w := httptest.NewRecorder()
+	ReadyHandler(monitor).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body Health
+	if w.Code != http.StatusServiceUnavailable || json.Unmarshal(w.Body.Bytes(), &body) != nil || body.OK {
+		t.Errorf("Expected 503 from /readyz, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The second failure reaches the threshold and swaps in a new pool
+	if h := monitor.Check(ctx); !h.OK || h.Reconnects != 1 {
+		t.Errorf("Expected a reconnect, got %+v", h)
+	}
+	if _, err := client.User.Query().Count(ctx); err != nil {
+		t.Errorf("Expected the client to work after reconnecting: %v", err)
+	}
+	w = httptest.NewRecorder()
+	ReadyHandler(monitor).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 from /readyz, got %d", w.Code)
+	}
+}
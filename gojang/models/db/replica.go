@@ -0,0 +1,245 @@
+package db
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/storage"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// ErrNoSnapshot is returned when restoring from a replica without snapshots
+var ErrNoSnapshot = errors.New("db: replica has no snapshots")
+
+const (
+	snapshotPrefix = "snapshots/"
+	snapshotLayout = "20060102T150405.000Z" // Sorts by time
+	snapshotExt    = ".db.gz"
+)
+
+// Replica keeps gzipped snapshots of a SQLite database in a storage.Filesystem
+// (usually an S3 bucket), so the database survives losing its disk. Snapshots
+// are consistent copies taken while the app keeps running; anything written
+// after the latest one is lost on restore.
+type Replica struct {
+	Path   string             // SQLite database file
+	FS     storage.Filesystem // Where snapshots are stored
+	Retain int                // Snapshots to keep (0 keeps all)
+}
+
+// Run snapshots the database every interval while it changes, until ctx is
+// canceled. Upload failures are logged and retried on the next tick.
+func (r *Replica) Run(ctx context.Context, interval time.Duration) error {
+	var (
+		sqlDB       *sql.DB
+		lastVersion int64 = -1
+		lastSum     [sha256.Size]byte
+	)
+	defer func() {
+		if sqlDB != nil {
+			sqlDB.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// Wait for the app to create the database rather than creating an empty one
+		if sqlDB == nil {
+			if _, err := os.Stat(r.Path); err == nil {
+				db, err := sql.Open("sqlite3", SQLiteDSN("sqlite://"+r.Path))
+				if err != nil {
+					return err
+				}
+				// data_version only changes for commits made by other connections
+				db.SetMaxOpenConns(1)
+				db.SetConnMaxIdleTime(0)
+				sqlDB = db
+			}
+		}
+
+		if sqlDB != nil {
+			var version int64
+			if err := sqlDB.QueryRowContext(ctx, "PRAGMA data_version").Scan(&version); err != nil {
+				utils.Warnw("replica.check_failed", "error", err)
+			} else if version != lastVersion {
+				// Checkpoints change the version too, so compare contents before uploading
+				if _, sum, err := r.snapshot(ctx, sqlDB, lastSum); err != nil {
+					utils.Errorw("replica.snapshot_failed", "error", err)
+				} else {
+					lastVersion, lastSum = version, sum
+				}
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Snapshot uploads a copy of the database and prunes old snapshots. It returns
+// the snapshot's name.
+func (r *Replica) Snapshot(ctx context.Context, sqlDB *sql.DB) (string, error) {
+	name, _, err := r.snapshot(ctx, sqlDB, [sha256.Size]byte{})
+	return name, err
+}
+
+// snapshot uploads a copy of the database unless its checksum is unchanged,
+// in which case name is empty
+func (r *Replica) snapshot(ctx context.Context, sqlDB *sql.DB, unchanged [sha256.Size]byte) (name string, sum [sha256.Size]byte, err error) {
+	// VACUUM INTO writes a consistent, compacted copy without blocking writers
+	tmp, err := os.CreateTemp(filepath.Dir(r.Path), ".snapshot-*")
+	if err != nil {
+		return "", sum, err
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	os.Remove(tmpPath)
+	defer os.Remove(tmpPath)
+	if _, err := sqlDB.ExecContext(ctx, "VACUUM INTO ?", tmpPath); err != nil {
+		return "", sum, fmt.Errorf("copying database: %w", err)
+	}
+
+	f, err := os.Open(tmpPath)
+	if err != nil {
+		return "", sum, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", sum, err
+	}
+	copy(sum[:], h.Sum(nil))
+	if sum == unchanged {
+		return "", sum, nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", sum, err
+	}
+
+	// Compress while uploading
+	pr, pw := io.Pipe()
+	compressed := make(chan struct{})
+	go func() {
+		defer close(compressed)
+		zw := gzip.NewWriter(pw)
+		_, err := io.Copy(zw, f)
+		if err == nil {
+			err = zw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	name = snapshotPrefix + time.Now().UTC().Format(snapshotLayout) + snapshotExt
+	err = r.FS.Save(ctx, name, pr)
+	pr.CloseWithError(err) // Unblocks the compressor if Save stopped reading
+	<-compressed
+	if err != nil {
+		return "", sum, fmt.Errorf("uploading snapshot: %w", err)
+	}
+	utils.Infow("replica.snapshot", "name", name)
+
+	if err := r.prune(ctx); err != nil {
+		utils.Warnw("replica.prune_failed", "error", err)
+	}
+	return name, sum, nil
+}
+
+// Restore replaces the database with the latest snapshot and returns its name.
+// The app must not be running.
+func (r *Replica) Restore(ctx context.Context) (string, error) {
+	snapshots, err := r.snapshots(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(snapshots) == 0 {
+		return "", ErrNoSnapshot
+	}
+	name := snapshots[len(snapshots)-1].Name
+
+	rc, err := r.FS.Open(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	zr, err := gzip.NewReader(rc)
+	if err != nil {
+		return "", fmt.Errorf("reading snapshot %s: %w", name, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0o755); err != nil {
+		return "", err
+	}
+	tmpPath := r.Path + ".restore"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpPath)
+	_, err = io.Copy(f, zr)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading snapshot %s: %w", name, err)
+	}
+
+	// A leftover WAL belongs to the old database and would corrupt the new one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(r.Path + suffix); err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	if err := os.Rename(tmpPath, r.Path); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// snapshots returns the stored snapshots, oldest first
+func (r *Replica) snapshots(ctx context.Context) ([]storage.FileInfo, error) {
+	files, err := r.FS.List(ctx, snapshotPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+	snapshots := files[:0]
+	for _, f := range files {
+		if strings.HasSuffix(f.Name, snapshotExt) {
+			snapshots = append(snapshots, f)
+		}
+	}
+	return snapshots, nil
+}
+
+// prune deletes all but the newest Retain snapshots
+func (r *Replica) prune(ctx context.Context) error {
+	if r.Retain <= 0 {
+		return nil
+	}
+	snapshots, err := r.snapshots(ctx)
+	if err != nil {
+		return err
+	}
+	for len(snapshots) > r.Retain {
+		if err := r.FS.Delete(ctx, snapshots[0].Name); err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/storage"
+)
+
+// TestReplica tests taking, pruning and restoring snapshots
+func TestReplica(t *testing.T) {
+	dir := t.TempDir()
+	databaseURL := "sqlite://" + filepath.Join(dir, "app.db")
+	fs, err := storage.NewLocal(filepath.Join(dir, "replica"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	replica := &Replica{Path: filepath.Join(dir, "app.db"), FS: fs, Retain: 2}
+	ctx := context.Background()
+
+	if _, err := replica.Restore(ctx); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("Expected ErrNoSnapshot from an empty replica, got %v", err)
+	}
+
+	client, err := NewClient(databaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AutoMigrate(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := sql.Open("sqlite3", SQLiteDSN(databaseURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		latest string
+		sum    [sha256.Size]byte
+	)
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		client.User.Create().SetEmail(email).SetPasswordHash("x").SaveX(ctx)
+		if latest, sum, err = replica.snapshot(ctx, sqlDB, sum); err != nil || latest == "" {
+			t.Fatalf("snapshot = %q, %v", latest, err)
+		}
+	}
+	snapshots, _ := replica.snapshots(ctx)
+	if len(snapshots) != 2 || snapshots[1].Name != latest {
+		t.Errorf("Expected the 2 newest snapshots to be kept, got %+v", snapshots)
+	}
+
+	// The sidecar skips uploads when only a checkpoint happened
+	Checkpoint(ctx, sqlDB)
+	if name, _, err := replica.snapshot(ctx, sqlDB, sum); name != "" || err != nil {
+		t.Errorf("Expected an unchanged database to be skipped, got %q, %v", name, err)
+	}
+
+	// Lose a write made after the last snapshot, then restore
+	client.User.Create().SetEmail("d@example.com").SetPasswordHash("x").SaveX(ctx)
+	sqlDB.Close()
+	client.Close()
+	if name, err := replica.Restore(ctx); err != nil || name != latest {
+		t.Fatalf("Restore = %q, %v; want %q", name, err, latest)
+	}
+	if _, err := os.Stat(replica.Path + "-wal"); !os.IsNotExist(err) {
+		t.Errorf("Expected the old WAL to be removed, got %v", err)
+	}
+
+	client, err = NewClient(databaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if n := client.User.Query().CountX(ctx); n != 3 {
+		t.Errorf("Expected 3 users after restoring, got %d", n)
+	}
+	if client.User.Query().Where(user.EmailEQ("d@example.com")).ExistX(ctx) {
+		t.Error("Expected the write after the snapshot to be gone")
+	}
+}
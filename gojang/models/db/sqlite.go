@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// sqliteDefaults are the go-sqlite3 connection settings every SQLite database
+// gets unless its URL sets them: foreign keys, a write-ahead log so readers
+// don't block the writer, fsync only at checkpoints (safe in WAL mode), and a
+// wait instead of "database is locked" when two writers collide.
+var sqliteDefaults = [][2]string{
+	{"_fk", "1"},
+	{"_journal_mode", "WAL"},
+	{"_synchronous", "NORMAL"},
+	{"_busy_timeout", "5000"},
+}
+
+// SQLitePath returns the database file of a sqlite:// URL, without its query
+func SQLitePath(databaseURL string) (string, bool) {
+	if !strings.HasPrefix(databaseURL, "sqlite://") {
+		return "", false
+	}
+	path, _, _ := strings.Cut(strings.TrimPrefix(databaseURL, "sqlite://"), "?")
+	return path, true
+}
+
+// SQLiteDSN returns the go-sqlite3 data source name for a sqlite:// URL.
+// Query parameters in the URL (e.g., sqlite://./data/app.db?_busy_timeout=10000)
+// override the defaults.
+func SQLiteDSN(databaseURL string) string {
+	path, query, _ := strings.Cut(strings.TrimPrefix(databaseURL, "sqlite://"), "?")
+	params, _ := url.ParseQuery(query)
+	for _, kv := range sqliteDefaults {
+		if !params.Has(kv[0]) {
+			params.Set(kv[0], kv[1])
+		}
+	}
+	return path + "?" + params.Encode()
+}
+
+// CheckpointResult reports a WAL checkpoint (see Checkpoint)
+type CheckpointResult struct {
+	Busy         bool // Another connection kept it from finishing
+	Pages        int  // Pages in the WAL
+	Checkpointed int  // Pages copied into the database file
+}
+
+// Checkpoint copies the write-ahead log into the database file and truncates
+// it, so the WAL doesn't keep growing on a busy site. It waits for the busy
+// timeout if a writer is active and reports Busy if readers held it back.
+func Checkpoint(ctx context.Context, sqlDB *sql.DB) (CheckpointResult, error) {
+	var res CheckpointResult
+	var busy int
+	err := sqlDB.QueryRowContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &res.Pages, &res.Checkpointed)
+	if err != nil {
+		return res, fmt.Errorf("wal checkpoint: %w", err)
+	}
+	res.Busy = busy != 0
+	return res, nil
+}
+
+// StartCheckpointRoutine checkpoints the SQLite database at databaseURL every
+// interval until done is closed. SQLite already checkpoints after large
+// commits; this also truncates the WAL file. It does nothing for other
+// databases, and shouldn't run alongside Litestream, which checkpoints itself.
+func StartCheckpointRoutine(databaseURL string, interval time.Duration, done <-chan struct{}) {
+	if _, ok := SQLitePath(databaseURL); !ok || interval <= 0 {
+		return
+	}
+	sqlDB, err := sql.Open("sqlite3", SQLiteDSN(databaseURL))
+	if err != nil {
+		utils.Errorw("db.checkpoint_failed", "error", err)
+		return
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			res, err := Checkpoint(context.Background(), sqlDB)
+			switch {
+			case err != nil:
+				utils.Warnw("db.checkpoint_failed", "error", err)
+			case res.Busy:
+				utils.Warnw("db.checkpoint_busy", "pages", res.Pages, "checkpointed", res.Checkpointed)
+			default:
+				utils.Debugw("db.checkpoint", "pages", res.Pages)
+			}
+		case <-done:
+			return
+		}
+	}
+}
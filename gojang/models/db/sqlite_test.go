@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteDSN(t *testing.T) {
+	tests := map[string]string{
+		"sqlite://./dev.db":                          "./dev.db?_busy_timeout=5000&_fk=1&_journal_mode=WAL&_synchronous=NORMAL",
+		"sqlite://./dev.db?_busy_timeout=10000":      "./dev.db?_busy_timeout=10000&_fk=1&_journal_mode=WAL&_synchronous=NORMAL",
+		"sqlite:///data/app.db?_journal_mode=DELETE": "/data/app.db?_busy_timeout=5000&_fk=1&_journal_mode=DELETE&_synchronous=NORMAL",
+	}
+	for url, want := range tests {
+		if got := SQLiteDSN(url); got != want {
+			t.Errorf("SQLiteDSN(%q) = %q, want %q", url, got, want)
+		}
+	}
+
+	if path, ok := SQLitePath("sqlite://./data/app.db?_fk=1"); !ok || path != "./data/app.db" {
+		t.Errorf("SQLitePath = %q, %v", path, ok)
+	}
+	if _, ok := SQLitePath("postgres://localhost/app"); ok {
+		t.Error("Expected SQLitePath to reject Postgres URLs")
+	}
+}
+
+// TestCheckpoint tests that new databases use a WAL that checkpoints empty
+func TestCheckpoint(t *testing.T) {
+	databaseURL := "sqlite://" + filepath.Join(t.TempDir(), "app.db")
+	client, err := NewClient(databaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if err := AutoMigrate(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	client.User.Create().SetEmail("a@example.com").SetPasswordHash("x").SaveX(ctx)
+
+	sqlDB, err := sql.Open("sqlite3", SQLiteDSN(databaseURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	var mode string
+	if err := sqlDB.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil || mode != "wal" {
+		t.Fatalf("journal_mode = %q (%v), want wal", mode, err)
+	}
+
+	res, err := Checkpoint(ctx, sqlDB)
+	if err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+	if res.Busy {
+		t.Errorf("Expected the checkpoint to finish, got %+v", res)
+	}
+	// TRUNCATE leaves an empty log behind
+	if res, _ := Checkpoint(ctx, sqlDB); res.Pages != 0 {
+		t.Errorf("Expected an empty WAL after checkpointing, got %+v", res)
+	}
+}
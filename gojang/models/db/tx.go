@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// TxFromContext returns the transaction middleware.Transaction opened for the
+// request, or nil outside one (see ctxutil.Tx)
+func TxFromContext(ctx context.Context) *models.Tx {
+	return ctxutil.Tx(ctx)
+}
+
+// ClientFromContext returns a client whose queries run in the request's
+// transaction, or client when there is none. Handlers that may or may not be
+// wrapped by middleware.Transaction use it in place of their own client:
+//
+//	client := db.ClientFromContext(r.Context(), h.Client)
+func ClientFromContext(ctx context.Context, client *models.Client) *models.Client {
+	if tx := ctxutil.Tx(ctx); tx != nil {
+		return tx.Client()
+	}
+	return client
+}
@@ -12,9 +12,19 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/announcement"
+	"github.com/gojangframework/gojang/gojang/models/contactmessage"
+	"github.com/gojangframework/gojang/gojang/models/group"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/passkey"
+	"github.com/gojangframework/gojang/gojang/models/poll"
 	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/gojangframework/gojang/gojang/models/redirect"
 	"github.com/gojangframework/gojang/gojang/models/setting"
 	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/models/userpreference"
+	"github.com/gojangframework/gojang/gojang/models/vote"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -75,9 +85,19 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			post.Table:    post.ValidColumn,
-			setting.Table: setting.ValidColumn,
-			user.Table:    user.ValidColumn,
+			adminaction.Table:    adminaction.ValidColumn,
+			announcement.Table:   announcement.ValidColumn,
+			contactmessage.Table: contactmessage.ValidColumn,
+			group.Table:          group.ValidColumn,
+			loginevent.Table:     loginevent.ValidColumn,
+			passkey.Table:        passkey.ValidColumn,
+			poll.Table:           poll.ValidColumn,
+			post.Table:           post.ValidColumn,
+			redirect.Table:       redirect.ValidColumn,
+			setting.Table:        setting.ValidColumn,
+			user.Table:           user.ValidColumn,
+			userpreference.Table: userpreference.ValidColumn,
+			vote.Table:           vote.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -0,0 +1,224 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/google/uuid"
+)
+
+// LoginEvent is the model entity for the LoginEvent schema.
+type LoginEvent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Success holds the value of the "success" field.
+	Success bool `json:"success,omitempty"`
+	// Why the attempt failed (e.g., 'wrong password'); empty on success
+	Failure string `json:"failure,omitempty"`
+	// IP holds the value of the "ip" field.
+	IP string `json:"ip,omitempty"`
+	// UserAgent holds the value of the "user_agent" field.
+	UserAgent string `json:"user_agent,omitempty"`
+	// Random ID from a long-lived cookie, to spot logins from new devices
+	Device string `json:"device,omitempty"`
+	// A successful login from a device the user hadn't signed in from before
+	NewDevice bool `json:"new_device,omitempty"`
+	// The login's session token while it may be active, to sign out the oldest when the user has too many (SESSION_LIMIT)
+	Session string `json:"-"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the LoginEventQuery when eager-loading is set.
+	Edges             LoginEventEdges `json:"edges"`
+	user_login_events *uuid.UUID
+	selectValues      sql.SelectValues
+}
+
+// LoginEventEdges holds the relations/edges for other nodes in the graph.
+type LoginEventEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e LoginEventEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*LoginEvent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case loginevent.FieldSuccess, loginevent.FieldNewDevice:
+			values[i] = new(sql.NullBool)
+		case loginevent.FieldFailure, loginevent.FieldIP, loginevent.FieldUserAgent, loginevent.FieldDevice, loginevent.FieldSession:
+			values[i] = new(sql.NullString)
+		case loginevent.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case loginevent.FieldID:
+			values[i] = new(uuid.UUID)
+		case loginevent.ForeignKeys[0]: // user_login_events
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the LoginEvent fields.
+func (_m *LoginEvent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case loginevent.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case loginevent.FieldSuccess:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field success", values[i])
+			} else if value.Valid {
+				_m.Success = value.Bool
+			}
+		case loginevent.FieldFailure:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field failure", values[i])
+			} else if value.Valid {
+				_m.Failure = value.String
+			}
+		case loginevent.FieldIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ip", values[i])
+			} else if value.Valid {
+				_m.IP = value.String
+			}
+		case loginevent.FieldUserAgent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_agent", values[i])
+			} else if value.Valid {
+				_m.UserAgent = value.String
+			}
+		case loginevent.FieldDevice:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field device", values[i])
+			} else if value.Valid {
+				_m.Device = value.String
+			}
+		case loginevent.FieldNewDevice:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field new_device", values[i])
+			} else if value.Valid {
+				_m.NewDevice = value.Bool
+			}
+		case loginevent.FieldSession:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field session", values[i])
+			} else if value.Valid {
+				_m.Session = value.String
+			}
+		case loginevent.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case loginevent.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field user_login_events", values[i])
+			} else if value.Valid {
+				_m.user_login_events = new(uuid.UUID)
+				*_m.user_login_events = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the LoginEvent.
+// This includes values selected through modifiers, order, etc.
+func (_m *LoginEvent) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the LoginEvent entity.
+func (_m *LoginEvent) QueryUser() *UserQuery {
+	return NewLoginEventClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this LoginEvent.
+// Note that you need to call LoginEvent.Unwrap() before calling this method if this LoginEvent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *LoginEvent) Update() *LoginEventUpdateOne {
+	return NewLoginEventClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the LoginEvent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *LoginEvent) Unwrap() *LoginEvent {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("models: LoginEvent is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *LoginEvent) String() string {
+	var builder strings.Builder
+	builder.WriteString("LoginEvent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("success=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Success))
+	builder.WriteString(", ")
+	builder.WriteString("failure=")
+	builder.WriteString(_m.Failure)
+	builder.WriteString(", ")
+	builder.WriteString("ip=")
+	builder.WriteString(_m.IP)
+	builder.WriteString(", ")
+	builder.WriteString("user_agent=")
+	builder.WriteString(_m.UserAgent)
+	builder.WriteString(", ")
+	builder.WriteString("device=")
+	builder.WriteString(_m.Device)
+	builder.WriteString(", ")
+	builder.WriteString("new_device=")
+	builder.WriteString(fmt.Sprintf("%v", _m.NewDevice))
+	builder.WriteString(", ")
+	builder.WriteString("session=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// LoginEvents is a parsable slice of LoginEvent.
+type LoginEvents []*LoginEvent
@@ -0,0 +1,381 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/google/uuid"
+)
+
+// LoginEventCreate is the builder for creating a LoginEvent entity.
+type LoginEventCreate struct {
+	config
+	mutation *LoginEventMutation
+	hooks    []Hook
+}
+
+// SetSuccess sets the "success" field.
+func (_c *LoginEventCreate) SetSuccess(v bool) *LoginEventCreate {
+	_c.mutation.SetSuccess(v)
+	return _c
+}
+
+// SetFailure sets the "failure" field.
+func (_c *LoginEventCreate) SetFailure(v string) *LoginEventCreate {
+	_c.mutation.SetFailure(v)
+	return _c
+}
+
+// SetNillableFailure sets the "failure" field if the given value is not nil.
+func (_c *LoginEventCreate) SetNillableFailure(v *string) *LoginEventCreate {
+	if v != nil {
+		_c.SetFailure(*v)
+	}
+	return _c
+}
+
+// SetIP sets the "ip" field.
+func (_c *LoginEventCreate) SetIP(v string) *LoginEventCreate {
+	_c.mutation.SetIP(v)
+	return _c
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_c *LoginEventCreate) SetUserAgent(v string) *LoginEventCreate {
+	_c.mutation.SetUserAgent(v)
+	return _c
+}
+
+// SetDevice sets the "device" field.
+func (_c *LoginEventCreate) SetDevice(v string) *LoginEventCreate {
+	_c.mutation.SetDevice(v)
+	return _c
+}
+
+// SetNillableDevice sets the "device" field if the given value is not nil.
+func (_c *LoginEventCreate) SetNillableDevice(v *string) *LoginEventCreate {
+	if v != nil {
+		_c.SetDevice(*v)
+	}
+	return _c
+}
+
+// SetNewDevice sets the "new_device" field.
+func (_c *LoginEventCreate) SetNewDevice(v bool) *LoginEventCreate {
+	_c.mutation.SetNewDevice(v)
+	return _c
+}
+
+// SetNillableNewDevice sets the "new_device" field if the given value is not nil.
+func (_c *LoginEventCreate) SetNillableNewDevice(v *bool) *LoginEventCreate {
+	if v != nil {
+		_c.SetNewDevice(*v)
+	}
+	return _c
+}
+
+// SetSession sets the "session" field.
+func (_c *LoginEventCreate) SetSession(v string) *LoginEventCreate {
+	_c.mutation.SetSession(v)
+	return _c
+}
+
+// SetNillableSession sets the "session" field if the given value is not nil.
+func (_c *LoginEventCreate) SetNillableSession(v *string) *LoginEventCreate {
+	if v != nil {
+		_c.SetSession(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *LoginEventCreate) SetCreatedAt(v time.Time) *LoginEventCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *LoginEventCreate) SetNillableCreatedAt(v *time.Time) *LoginEventCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *LoginEventCreate) SetID(v uuid.UUID) *LoginEventCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *LoginEventCreate) SetNillableID(v *uuid.UUID) *LoginEventCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_c *LoginEventCreate) SetUserID(id uuid.UUID) *LoginEventCreate {
+	_c.mutation.SetUserID(id)
+	return _c
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_c *LoginEventCreate) SetUser(v *User) *LoginEventCreate {
+	return _c.SetUserID(v.ID)
+}
+
+// Mutation returns the LoginEventMutation object of the builder.
+func (_c *LoginEventCreate) Mutation() *LoginEventMutation {
+	return _c.mutation
+}
+
+// Save creates the LoginEvent in the database.
+func (_c *LoginEventCreate) Save(ctx context.Context) (*LoginEvent, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *LoginEventCreate) SaveX(ctx context.Context) *LoginEvent {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *LoginEventCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *LoginEventCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *LoginEventCreate) defaults() {
+	if _, ok := _c.mutation.NewDevice(); !ok {
+		v := loginevent.DefaultNewDevice
+		_c.mutation.SetNewDevice(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := loginevent.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := loginevent.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *LoginEventCreate) check() error {
+	if _, ok := _c.mutation.Success(); !ok {
+		return &ValidationError{Name: "success", err: errors.New(`models: missing required field "LoginEvent.success"`)}
+	}
+	if _, ok := _c.mutation.IP(); !ok {
+		return &ValidationError{Name: "ip", err: errors.New(`models: missing required field "LoginEvent.ip"`)}
+	}
+	if _, ok := _c.mutation.UserAgent(); !ok {
+		return &ValidationError{Name: "user_agent", err: errors.New(`models: missing required field "LoginEvent.user_agent"`)}
+	}
+	if v, ok := _c.mutation.UserAgent(); ok {
+		if err := loginevent.UserAgentValidator(v); err != nil {
+			return &ValidationError{Name: "user_agent", err: fmt.Errorf(`models: validator failed for field "LoginEvent.user_agent": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.NewDevice(); !ok {
+		return &ValidationError{Name: "new_device", err: errors.New(`models: missing required field "LoginEvent.new_device"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`models: missing required field "LoginEvent.created_at"`)}
+	}
+	if len(_c.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`models: missing required edge "LoginEvent.user"`)}
+	}
+	return nil
+}
+
+func (_c *LoginEventCreate) sqlSave(ctx context.Context) (*LoginEvent, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *LoginEventCreate) createSpec() (*LoginEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &LoginEvent{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(loginevent.Table, sqlgraph.NewFieldSpec(loginevent.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Success(); ok {
+		_spec.SetField(loginevent.FieldSuccess, field.TypeBool, value)
+		_node.Success = value
+	}
+	if value, ok := _c.mutation.Failure(); ok {
+		_spec.SetField(loginevent.FieldFailure, field.TypeString, value)
+		_node.Failure = value
+	}
+	if value, ok := _c.mutation.IP(); ok {
+		_spec.SetField(loginevent.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := _c.mutation.UserAgent(); ok {
+		_spec.SetField(loginevent.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := _c.mutation.Device(); ok {
+		_spec.SetField(loginevent.FieldDevice, field.TypeString, value)
+		_node.Device = value
+	}
+	if value, ok := _c.mutation.NewDevice(); ok {
+		_spec.SetField(loginevent.FieldNewDevice, field.TypeBool, value)
+		_node.NewDevice = value
+	}
+	if value, ok := _c.mutation.Session(); ok {
+		_spec.SetField(loginevent.FieldSession, field.TypeString, value)
+		_node.Session = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(loginevent.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginevent.UserTable,
+			Columns: []string{loginevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_login_events = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// LoginEventCreateBulk is the builder for creating many LoginEvent entities in bulk.
+type LoginEventCreateBulk struct {
+	config
+	err      error
+	builders []*LoginEventCreate
+}
+
+// Save creates the LoginEvent entities in the database.
+func (_c *LoginEventCreateBulk) Save(ctx context.Context) ([]*LoginEvent, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*LoginEvent, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*LoginEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *LoginEventCreateBulk) SaveX(ctx context.Context) []*LoginEvent {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *LoginEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *LoginEventCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,593 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/google/uuid"
+)
+
+// LoginEventUpdate is the builder for updating LoginEvent entities.
+type LoginEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *LoginEventMutation
+}
+
+// Where appends a list predicates to the LoginEventUpdate builder.
+func (_u *LoginEventUpdate) Where(ps ...predicate.LoginEvent) *LoginEventUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetSuccess sets the "success" field.
+func (_u *LoginEventUpdate) SetSuccess(v bool) *LoginEventUpdate {
+	_u.mutation.SetSuccess(v)
+	return _u
+}
+
+// SetNillableSuccess sets the "success" field if the given value is not nil.
+func (_u *LoginEventUpdate) SetNillableSuccess(v *bool) *LoginEventUpdate {
+	if v != nil {
+		_u.SetSuccess(*v)
+	}
+	return _u
+}
+
+// SetFailure sets the "failure" field.
+func (_u *LoginEventUpdate) SetFailure(v string) *LoginEventUpdate {
+	_u.mutation.SetFailure(v)
+	return _u
+}
+
+// SetNillableFailure sets the "failure" field if the given value is not nil.
+func (_u *LoginEventUpdate) SetNillableFailure(v *string) *LoginEventUpdate {
+	if v != nil {
+		_u.SetFailure(*v)
+	}
+	return _u
+}
+
+// ClearFailure clears the value of the "failure" field.
+func (_u *LoginEventUpdate) ClearFailure() *LoginEventUpdate {
+	_u.mutation.ClearFailure()
+	return _u
+}
+
+// SetIP sets the "ip" field.
+func (_u *LoginEventUpdate) SetIP(v string) *LoginEventUpdate {
+	_u.mutation.SetIP(v)
+	return _u
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (_u *LoginEventUpdate) SetNillableIP(v *string) *LoginEventUpdate {
+	if v != nil {
+		_u.SetIP(*v)
+	}
+	return _u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_u *LoginEventUpdate) SetUserAgent(v string) *LoginEventUpdate {
+	_u.mutation.SetUserAgent(v)
+	return _u
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_u *LoginEventUpdate) SetNillableUserAgent(v *string) *LoginEventUpdate {
+	if v != nil {
+		_u.SetUserAgent(*v)
+	}
+	return _u
+}
+
+// SetDevice sets the "device" field.
+func (_u *LoginEventUpdate) SetDevice(v string) *LoginEventUpdate {
+	_u.mutation.SetDevice(v)
+	return _u
+}
+
+// SetNillableDevice sets the "device" field if the given value is not nil.
+func (_u *LoginEventUpdate) SetNillableDevice(v *string) *LoginEventUpdate {
+	if v != nil {
+		_u.SetDevice(*v)
+	}
+	return _u
+}
+
+// ClearDevice clears the value of the "device" field.
+func (_u *LoginEventUpdate) ClearDevice() *LoginEventUpdate {
+	_u.mutation.ClearDevice()
+	return _u
+}
+
+// SetNewDevice sets the "new_device" field.
+func (_u *LoginEventUpdate) SetNewDevice(v bool) *LoginEventUpdate {
+	_u.mutation.SetNewDevice(v)
+	return _u
+}
+
+// SetNillableNewDevice sets the "new_device" field if the given value is not nil.
+func (_u *LoginEventUpdate) SetNillableNewDevice(v *bool) *LoginEventUpdate {
+	if v != nil {
+		_u.SetNewDevice(*v)
+	}
+	return _u
+}
+
+// SetSession sets the "session" field.
+func (_u *LoginEventUpdate) SetSession(v string) *LoginEventUpdate {
+	_u.mutation.SetSession(v)
+	return _u
+}
+
+// SetNillableSession sets the "session" field if the given value is not nil.
+func (_u *LoginEventUpdate) SetNillableSession(v *string) *LoginEventUpdate {
+	if v != nil {
+		_u.SetSession(*v)
+	}
+	return _u
+}
+
+// ClearSession clears the value of the "session" field.
+func (_u *LoginEventUpdate) ClearSession() *LoginEventUpdate {
+	_u.mutation.ClearSession()
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *LoginEventUpdate) SetUserID(id uuid.UUID) *LoginEventUpdate {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *LoginEventUpdate) SetUser(v *User) *LoginEventUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the LoginEventMutation object of the builder.
+func (_u *LoginEventUpdate) Mutation() *LoginEventMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *LoginEventUpdate) ClearUser() *LoginEventUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *LoginEventUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *LoginEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *LoginEventUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *LoginEventUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *LoginEventUpdate) check() error {
+	if v, ok := _u.mutation.UserAgent(); ok {
+		if err := loginevent.UserAgentValidator(v); err != nil {
+			return &ValidationError{Name: "user_agent", err: fmt.Errorf(`models: validator failed for field "LoginEvent.user_agent": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`models: clearing a required unique edge "LoginEvent.user"`)
+	}
+	return nil
+}
+
+func (_u *LoginEventUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loginevent.Table, loginevent.Columns, sqlgraph.NewFieldSpec(loginevent.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Success(); ok {
+		_spec.SetField(loginevent.FieldSuccess, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Failure(); ok {
+		_spec.SetField(loginevent.FieldFailure, field.TypeString, value)
+	}
+	if _u.mutation.FailureCleared() {
+		_spec.ClearField(loginevent.FieldFailure, field.TypeString)
+	}
+	if value, ok := _u.mutation.IP(); ok {
+		_spec.SetField(loginevent.FieldIP, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserAgent(); ok {
+		_spec.SetField(loginevent.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Device(); ok {
+		_spec.SetField(loginevent.FieldDevice, field.TypeString, value)
+	}
+	if _u.mutation.DeviceCleared() {
+		_spec.ClearField(loginevent.FieldDevice, field.TypeString)
+	}
+	if value, ok := _u.mutation.NewDevice(); ok {
+		_spec.SetField(loginevent.FieldNewDevice, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Session(); ok {
+		_spec.SetField(loginevent.FieldSession, field.TypeString, value)
+	}
+	if _u.mutation.SessionCleared() {
+		_spec.ClearField(loginevent.FieldSession, field.TypeString)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginevent.UserTable,
+			Columns: []string{loginevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginevent.UserTable,
+			Columns: []string{loginevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loginevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// LoginEventUpdateOne is the builder for updating a single LoginEvent entity.
+type LoginEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *LoginEventMutation
+}
+
+// SetSuccess sets the "success" field.
+func (_u *LoginEventUpdateOne) SetSuccess(v bool) *LoginEventUpdateOne {
+	_u.mutation.SetSuccess(v)
+	return _u
+}
+
+// SetNillableSuccess sets the "success" field if the given value is not nil.
+func (_u *LoginEventUpdateOne) SetNillableSuccess(v *bool) *LoginEventUpdateOne {
+	if v != nil {
+		_u.SetSuccess(*v)
+	}
+	return _u
+}
+
+// SetFailure sets the "failure" field.
+func (_u *LoginEventUpdateOne) SetFailure(v string) *LoginEventUpdateOne {
+	_u.mutation.SetFailure(v)
+	return _u
+}
+
+// SetNillableFailure sets the "failure" field if the given value is not nil.
+func (_u *LoginEventUpdateOne) SetNillableFailure(v *string) *LoginEventUpdateOne {
+	if v != nil {
+		_u.SetFailure(*v)
+	}
+	return _u
+}
+
+// ClearFailure clears the value of the "failure" field.
+func (_u *LoginEventUpdateOne) ClearFailure() *LoginEventUpdateOne {
+	_u.mutation.ClearFailure()
+	return _u
+}
+
+// SetIP sets the "ip" field.
+func (_u *LoginEventUpdateOne) SetIP(v string) *LoginEventUpdateOne {
+	_u.mutation.SetIP(v)
+	return _u
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (_u *LoginEventUpdateOne) SetNillableIP(v *string) *LoginEventUpdateOne {
+	if v != nil {
+		_u.SetIP(*v)
+	}
+	return _u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_u *LoginEventUpdateOne) SetUserAgent(v string) *LoginEventUpdateOne {
+	_u.mutation.SetUserAgent(v)
+	return _u
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_u *LoginEventUpdateOne) SetNillableUserAgent(v *string) *LoginEventUpdateOne {
+	if v != nil {
+		_u.SetUserAgent(*v)
+	}
+	return _u
+}
+
+// SetDevice sets the "device" field.
+func (_u *LoginEventUpdateOne) SetDevice(v string) *LoginEventUpdateOne {
+	_u.mutation.SetDevice(v)
+	return _u
+}
+
+// SetNillableDevice sets the "device" field if the given value is not nil.
+func (_u *LoginEventUpdateOne) SetNillableDevice(v *string) *LoginEventUpdateOne {
+	if v != nil {
+		_u.SetDevice(*v)
+	}
+	return _u
+}
+
+// ClearDevice clears the value of the "device" field.
+func (_u *LoginEventUpdateOne) ClearDevice() *LoginEventUpdateOne {
+	_u.mutation.ClearDevice()
+	return _u
+}
+
+// SetNewDevice sets the "new_device" field.
+func (_u *LoginEventUpdateOne) SetNewDevice(v bool) *LoginEventUpdateOne {
+	_u.mutation.SetNewDevice(v)
+	return _u
+}
+
+// SetNillableNewDevice sets the "new_device" field if the given value is not nil.
+func (_u *LoginEventUpdateOne) SetNillableNewDevice(v *bool) *LoginEventUpdateOne {
+	if v != nil {
+		_u.SetNewDevice(*v)
+	}
+	return _u
+}
+
+// SetSession sets the "session" field.
+func (_u *LoginEventUpdateOne) SetSession(v string) *LoginEventUpdateOne {
+	_u.mutation.SetSession(v)
+	return _u
+}
+
+// SetNillableSession sets the "session" field if the given value is not nil.
+func (_u *LoginEventUpdateOne) SetNillableSession(v *string) *LoginEventUpdateOne {
+	if v != nil {
+		_u.SetSession(*v)
+	}
+	return _u
+}
+
+// ClearSession clears the value of the "session" field.
+func (_u *LoginEventUpdateOne) ClearSession() *LoginEventUpdateOne {
+	_u.mutation.ClearSession()
+	return _u
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (_u *LoginEventUpdateOne) SetUserID(id uuid.UUID) *LoginEventUpdateOne {
+	_u.mutation.SetUserID(id)
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *LoginEventUpdateOne) SetUser(v *User) *LoginEventUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the LoginEventMutation object of the builder.
+func (_u *LoginEventUpdateOne) Mutation() *LoginEventMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *LoginEventUpdateOne) ClearUser() *LoginEventUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the LoginEventUpdate builder.
+func (_u *LoginEventUpdateOne) Where(ps ...predicate.LoginEvent) *LoginEventUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *LoginEventUpdateOne) Select(field string, fields ...string) *LoginEventUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated LoginEvent entity.
+func (_u *LoginEventUpdateOne) Save(ctx context.Context) (*LoginEvent, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *LoginEventUpdateOne) SaveX(ctx context.Context) *LoginEvent {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *LoginEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *LoginEventUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *LoginEventUpdateOne) check() error {
+	if v, ok := _u.mutation.UserAgent(); ok {
+		if err := loginevent.UserAgentValidator(v); err != nil {
+			return &ValidationError{Name: "user_agent", err: fmt.Errorf(`models: validator failed for field "LoginEvent.user_agent": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`models: clearing a required unique edge "LoginEvent.user"`)
+	}
+	return nil
+}
+
+func (_u *LoginEventUpdateOne) sqlSave(ctx context.Context) (_node *LoginEvent, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loginevent.Table, loginevent.Columns, sqlgraph.NewFieldSpec(loginevent.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`models: missing "LoginEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, loginevent.FieldID)
+		for _, f := range fields {
+			if !loginevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("models: invalid field %q for query", f)}
+			}
+			if f != loginevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Success(); ok {
+		_spec.SetField(loginevent.FieldSuccess, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Failure(); ok {
+		_spec.SetField(loginevent.FieldFailure, field.TypeString, value)
+	}
+	if _u.mutation.FailureCleared() {
+		_spec.ClearField(loginevent.FieldFailure, field.TypeString)
+	}
+	if value, ok := _u.mutation.IP(); ok {
+		_spec.SetField(loginevent.FieldIP, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserAgent(); ok {
+		_spec.SetField(loginevent.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Device(); ok {
+		_spec.SetField(loginevent.FieldDevice, field.TypeString, value)
+	}
+	if _u.mutation.DeviceCleared() {
+		_spec.ClearField(loginevent.FieldDevice, field.TypeString)
+	}
+	if value, ok := _u.mutation.NewDevice(); ok {
+		_spec.SetField(loginevent.FieldNewDevice, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Session(); ok {
+		_spec.SetField(loginevent.FieldSession, field.TypeString, value)
+	}
+	if _u.mutation.SessionCleared() {
+		_spec.ClearField(loginevent.FieldSession, field.TypeString)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginevent.UserTable,
+			Columns: []string{loginevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   loginevent.UserTable,
+			Columns: []string{loginevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &LoginEvent{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loginevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -8,11 +8,175 @@ import (
 )
 
 var (
+	// AdminActionsColumns holds the columns for the "admin_actions" table.
+	AdminActionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "action", Type: field.TypeEnum, Enums: []string{"create", "update", "delete", "run"}},
+		{Name: "action_name", Type: field.TypeString, Nullable: true},
+		{Name: "model", Type: field.TypeString},
+		{Name: "record_id", Type: field.TypeString},
+		{Name: "record_label", Type: field.TypeString},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "user_email", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// AdminActionsTable holds the schema information for the "admin_actions" table.
+	AdminActionsTable = &schema.Table{
+		Name:       "admin_actions",
+		Columns:    AdminActionsColumns,
+		PrimaryKey: []*schema.Column{AdminActionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "adminaction_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AdminActionsColumns[8]},
+			},
+			{
+				Name:    "adminaction_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AdminActionsColumns[6], AdminActionsColumns[8]},
+			},
+		},
+	}
+	// AnnouncementsColumns holds the columns for the "announcements" table.
+	AnnouncementsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "message", Type: field.TypeString, Size: 2147483647},
+		{Name: "level", Type: field.TypeString, Default: "info"},
+		{Name: "audience", Type: field.TypeString, Default: "all"},
+		{Name: "starts_at", Type: field.TypeTime, Nullable: true},
+		{Name: "ends_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// AnnouncementsTable holds the schema information for the "announcements" table.
+	AnnouncementsTable = &schema.Table{
+		Name:       "announcements",
+		Columns:    AnnouncementsColumns,
+		PrimaryKey: []*schema.Column{AnnouncementsColumns[0]},
+	}
+	// ContactMessagesColumns holds the columns for the "contact_messages" table.
+	ContactMessagesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Size: 100},
+		{Name: "email", Type: field.TypeString},
+		{Name: "subject", Type: field.TypeString, Size: 200, Default: ""},
+		{Name: "message", Type: field.TypeString, Size: 2147483647},
+		{Name: "ip", Type: field.TypeString, Default: ""},
+		{Name: "handled", Type: field.TypeBool, Default: false},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// ContactMessagesTable holds the schema information for the "contact_messages" table.
+	ContactMessagesTable = &schema.Table{
+		Name:       "contact_messages",
+		Columns:    ContactMessagesColumns,
+		PrimaryKey: []*schema.Column{ContactMessagesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "contactmessage_handled_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{ContactMessagesColumns[6], ContactMessagesColumns[7]},
+			},
+		},
+	}
+	// GroupsColumns holds the columns for the "groups" table.
+	GroupsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "permissions", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// GroupsTable holds the schema information for the "groups" table.
+	GroupsTable = &schema.Table{
+		Name:       "groups",
+		Columns:    GroupsColumns,
+		PrimaryKey: []*schema.Column{GroupsColumns[0]},
+	}
+	// LoginEventsColumns holds the columns for the "login_events" table.
+	LoginEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "success", Type: field.TypeBool},
+		{Name: "failure", Type: field.TypeString, Nullable: true},
+		{Name: "ip", Type: field.TypeString},
+		{Name: "user_agent", Type: field.TypeString, Size: 512},
+		{Name: "device", Type: field.TypeString, Nullable: true},
+		{Name: "new_device", Type: field.TypeBool, Default: false},
+		{Name: "session", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_login_events", Type: field.TypeUUID},
+	}
+	// LoginEventsTable holds the schema information for the "login_events" table.
+	LoginEventsTable = &schema.Table{
+		Name:       "login_events",
+		Columns:    LoginEventsColumns,
+		PrimaryKey: []*schema.Column{LoginEventsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "login_events_users_login_events",
+				Columns:    []*schema.Column{LoginEventsColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "loginevent_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{LoginEventsColumns[8]},
+			},
+			{
+				Name:    "loginevent_created_at_user_login_events",
+				Unique:  false,
+				Columns: []*schema.Column{LoginEventsColumns[8], LoginEventsColumns[9]},
+			},
+		},
+	}
+	// PasskeysColumns holds the columns for the "passkeys" table.
+	PasskeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "credential_id", Type: field.TypeBytes, Unique: true, Size: 1023},
+		{Name: "public_key", Type: field.TypeBytes},
+		{Name: "sign_count", Type: field.TypeUint32, Default: 0},
+		{Name: "name", Type: field.TypeString, Size: 100},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_passkeys", Type: field.TypeUUID},
+	}
+	// PasskeysTable holds the schema information for the "passkeys" table.
+	PasskeysTable = &schema.Table{
+		Name:       "passkeys",
+		Columns:    PasskeysColumns,
+		PrimaryKey: []*schema.Column{PasskeysColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "passkeys_users_passkeys",
+				Columns:    []*schema.Column{PasskeysColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// PollsColumns holds the columns for the "polls" table.
+	PollsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "question", Type: field.TypeString, Size: 255},
+		{Name: "options", Type: field.TypeJSON},
+		{Name: "closes_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// PollsTable holds the schema information for the "polls" table.
+	PollsTable = &schema.Table{
+		Name:       "polls",
+		Columns:    PollsColumns,
+		PrimaryKey: []*schema.Column{PollsColumns[0]},
+	}
 	// PostsColumns holds the columns for the "posts" table.
 	PostsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
 		{Name: "subject", Type: field.TypeString, Size: 255},
 		{Name: "body", Type: field.TypeString, Size: 2147483647},
+		{Name: "pending_review", Type: field.TypeBool, Default: false},
+		{Name: "publish_at", Type: field.TypeTime, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "user_posts", Type: field.TypeUUID},
@@ -25,7 +189,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "posts_users_posts",
-				Columns:    []*schema.Column{PostsColumns[5]},
+				Columns:    []*schema.Column{PostsColumns[7]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -34,10 +198,29 @@ var (
 			{
 				Name:    "post_created_at",
 				Unique:  false,
-				Columns: []*schema.Column{PostsColumns[3]},
+				Columns: []*schema.Column{PostsColumns[5]},
+			},
+			{
+				Name:    "post_publish_at",
+				Unique:  false,
+				Columns: []*schema.Column{PostsColumns[4]},
 			},
 		},
 	}
+	// RedirectsColumns holds the columns for the "redirects" table.
+	RedirectsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "from_path", Type: field.TypeString, Unique: true},
+		{Name: "to_path", Type: field.TypeString},
+		{Name: "status_code", Type: field.TypeInt, Default: 301},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// RedirectsTable holds the schema information for the "redirects" table.
+	RedirectsTable = &schema.Table{
+		Name:       "redirects",
+		Columns:    RedirectsColumns,
+		PrimaryKey: []*schema.Column{RedirectsColumns[0]},
+	}
 	// SettingsColumns holds the columns for the "settings" table.
 	SettingsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -54,6 +237,8 @@ var (
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
 		{Name: "email", Type: field.TypeString, Unique: true},
+		{Name: "username", Type: field.TypeString, Unique: true, Nullable: true},
+		{Name: "avatar", Type: field.TypeString, Nullable: true},
 		{Name: "password_hash", Type: field.TypeString},
 		{Name: "is_active", Type: field.TypeBool, Default: true},
 		{Name: "is_staff", Type: field.TypeBool, Default: false},
@@ -75,14 +260,142 @@ var (
 			},
 		},
 	}
+	// UserPreferencesColumns holds the columns for the "user_preferences" table.
+	UserPreferencesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "key", Type: field.TypeString},
+		{Name: "value", Type: field.TypeString, Size: 2147483647},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "user_preferences", Type: field.TypeUUID},
+	}
+	// UserPreferencesTable holds the schema information for the "user_preferences" table.
+	UserPreferencesTable = &schema.Table{
+		Name:       "user_preferences",
+		Columns:    UserPreferencesColumns,
+		PrimaryKey: []*schema.Column{UserPreferencesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_preferences_users_preferences",
+				Columns:    []*schema.Column{UserPreferencesColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "userpreference_key_user_preferences",
+				Unique:  true,
+				Columns: []*schema.Column{UserPreferencesColumns[1], UserPreferencesColumns[4]},
+			},
+		},
+	}
+	// VotesColumns holds the columns for the "votes" table.
+	VotesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "option", Type: field.TypeInt},
+		{Name: "voter", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "poll_votes", Type: field.TypeUUID},
+	}
+	// VotesTable holds the schema information for the "votes" table.
+	VotesTable = &schema.Table{
+		Name:       "votes",
+		Columns:    VotesColumns,
+		PrimaryKey: []*schema.Column{VotesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "votes_polls_votes",
+				Columns:    []*schema.Column{VotesColumns[4]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "vote_voter_poll_votes",
+				Unique:  true,
+				Columns: []*schema.Column{VotesColumns[2], VotesColumns[4]},
+			},
+		},
+	}
+	// UserGroupsColumns holds the columns for the "user_groups" table.
+	UserGroupsColumns = []*schema.Column{
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "group_id", Type: field.TypeUUID},
+	}
+	// UserGroupsTable holds the schema information for the "user_groups" table.
+	UserGroupsTable = &schema.Table{
+		Name:       "user_groups",
+		Columns:    UserGroupsColumns,
+		PrimaryKey: []*schema.Column{UserGroupsColumns[0], UserGroupsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_groups_user_id",
+				Columns:    []*schema.Column{UserGroupsColumns[0]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "user_groups_group_id",
+				Columns:    []*schema.Column{UserGroupsColumns[1]},
+				RefColumns: []*schema.Column{GroupsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// UserDismissedAnnouncementsColumns holds the columns for the "user_dismissed_announcements" table.
+	UserDismissedAnnouncementsColumns = []*schema.Column{
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "announcement_id", Type: field.TypeUUID},
+	}
+	// UserDismissedAnnouncementsTable holds the schema information for the "user_dismissed_announcements" table.
+	UserDismissedAnnouncementsTable = &schema.Table{
+		Name:       "user_dismissed_announcements",
+		Columns:    UserDismissedAnnouncementsColumns,
+		PrimaryKey: []*schema.Column{UserDismissedAnnouncementsColumns[0], UserDismissedAnnouncementsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_dismissed_announcements_user_id",
+				Columns:    []*schema.Column{UserDismissedAnnouncementsColumns[0]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "user_dismissed_announcements_announcement_id",
+				Columns:    []*schema.Column{UserDismissedAnnouncementsColumns[1]},
+				RefColumns: []*schema.Column{AnnouncementsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AdminActionsTable,
+		AnnouncementsTable,
+		ContactMessagesTable,
+		GroupsTable,
+		LoginEventsTable,
+		PasskeysTable,
+		PollsTable,
 		PostsTable,
+		RedirectsTable,
 		SettingsTable,
 		UsersTable,
+		UserPreferencesTable,
+		VotesTable,
+		UserGroupsTable,
+		UserDismissedAnnouncementsTable,
 	}
 )
 
 func init() {
+	LoginEventsTable.ForeignKeys[0].RefTable = UsersTable
+	PasskeysTable.ForeignKeys[0].RefTable = UsersTable
 	PostsTable.ForeignKeys[0].RefTable = UsersTable
+	UserPreferencesTable.ForeignKeys[0].RefTable = UsersTable
+	VotesTable.ForeignKeys[0].RefTable = PollsTable
+	UserGroupsTable.ForeignKeys[0].RefTable = UsersTable
+	UserGroupsTable.ForeignKeys[1].RefTable = GroupsTable
+	UserDismissedAnnouncementsTable.ForeignKeys[0].RefTable = UsersTable
+	UserDismissedAnnouncementsTable.ForeignKeys[1].RefTable = AnnouncementsTable
 }
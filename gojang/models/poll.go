@@ -0,0 +1,174 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/poll"
+	"github.com/google/uuid"
+)
+
+// Poll is the model entity for the Poll schema.
+type Poll struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Question holds the value of the "question" field.
+	Question string `json:"question,omitempty"`
+	// Answers in display order; votes refer to them by position
+	Options []string `json:"options,omitempty"`
+	// No votes from then on; open until deleted if empty
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PollQuery when eager-loading is set.
+	Edges        PollEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// PollEdges holds the relations/edges for other nodes in the graph.
+type PollEdges struct {
+	// Votes holds the value of the votes edge.
+	Votes []*Vote `json:"votes,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// VotesOrErr returns the Votes value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) VotesOrErr() ([]*Vote, error) {
+	if e.loadedTypes[0] {
+		return e.Votes, nil
+	}
+	return nil, &NotLoadedError{edge: "votes"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Poll) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case poll.FieldOptions:
+			values[i] = new([]byte)
+		case poll.FieldQuestion:
+			values[i] = new(sql.NullString)
+		case poll.FieldClosesAt, poll.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case poll.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Poll fields.
+func (_m *Poll) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case poll.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case poll.FieldQuestion:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field question", values[i])
+			} else if value.Valid {
+				_m.Question = value.String
+			}
+		case poll.FieldOptions:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field options", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Options); err != nil {
+					return fmt.Errorf("unmarshal field options: %w", err)
+				}
+			}
+		case poll.FieldClosesAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field closes_at", values[i])
+			} else if value.Valid {
+				_m.ClosesAt = new(time.Time)
+				*_m.ClosesAt = value.Time
+			}
+		case poll.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Poll.
+// This includes values selected through modifiers, order, etc.
+func (_m *Poll) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryVotes queries the "votes" edge of the Poll entity.
+func (_m *Poll) QueryVotes() *VoteQuery {
+	return NewPollClient(_m.config).QueryVotes(_m)
+}
+
+// Update returns a builder for updating this Poll.
+// Note that you need to call Poll.Unwrap() before calling this method if this Poll
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Poll) Update() *PollUpdateOne {
+	return NewPollClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Poll entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Poll) Unwrap() *Poll {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("models: Poll is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Poll) String() string {
+	var builder strings.Builder
+	builder.WriteString("Poll(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("question=")
+	builder.WriteString(_m.Question)
+	builder.WriteString(", ")
+	builder.WriteString("options=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Options))
+	builder.WriteString(", ")
+	if v := _m.ClosesAt; v != nil {
+		builder.WriteString("closes_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Polls is a parsable slice of Poll.
+type Polls []*Poll
@@ -19,6 +19,10 @@ const (
 	FieldSubject = "subject"
 	// FieldBody holds the string denoting the body field in the database.
 	FieldBody = "body"
+	// FieldPendingReview holds the string denoting the pending_review field in the database.
+	FieldPendingReview = "pending_review"
+	// FieldPublishAt holds the string denoting the publish_at field in the database.
+	FieldPublishAt = "publish_at"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
@@ -41,6 +45,8 @@ var Columns = []string{
 	FieldID,
 	FieldSubject,
 	FieldBody,
+	FieldPendingReview,
+	FieldPublishAt,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -71,6 +77,8 @@ var (
 	SubjectValidator func(string) error
 	// BodyValidator is a validator for the "body" field. It is called by the builders before save.
 	BodyValidator func(string) error
+	// DefaultPendingReview holds the default value on creation for the "pending_review" field.
+	DefaultPendingReview bool
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
@@ -99,6 +107,16 @@ func ByBody(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldBody, opts...).ToFunc()
 }
 
+// ByPendingReview orders the results by the pending_review field.
+func ByPendingReview(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPendingReview, opts...).ToFunc()
+}
+
+// ByPublishAt orders the results by the publish_at field.
+func ByPublishAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPublishAt, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -66,6 +66,16 @@ func Body(v string) predicate.Post {
 	return predicate.Post(sql.FieldEQ(FieldBody, v))
 }
 
+// PendingReview applies equality check predicate on the "pending_review" field. It's identical to PendingReviewEQ.
+func PendingReview(v bool) predicate.Post {
+	return predicate.Post(sql.FieldEQ(FieldPendingReview, v))
+}
+
+// PublishAt applies equality check predicate on the "publish_at" field. It's identical to PublishAtEQ.
+func PublishAt(v time.Time) predicate.Post {
+	return predicate.Post(sql.FieldEQ(FieldPublishAt, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Post {
 	return predicate.Post(sql.FieldEQ(FieldCreatedAt, v))
@@ -206,6 +216,66 @@ func BodyContainsFold(v string) predicate.Post {
 	return predicate.Post(sql.FieldContainsFold(FieldBody, v))
 }
 
+// PendingReviewEQ applies the EQ predicate on the "pending_review" field.
+func PendingReviewEQ(v bool) predicate.Post {
+	return predicate.Post(sql.FieldEQ(FieldPendingReview, v))
+}
+
+// PendingReviewNEQ applies the NEQ predicate on the "pending_review" field.
+func PendingReviewNEQ(v bool) predicate.Post {
+	return predicate.Post(sql.FieldNEQ(FieldPendingReview, v))
+}
+
+// PublishAtEQ applies the EQ predicate on the "publish_at" field.
+func PublishAtEQ(v time.Time) predicate.Post {
+	return predicate.Post(sql.FieldEQ(FieldPublishAt, v))
+}
+
+// PublishAtNEQ applies the NEQ predicate on the "publish_at" field.
+func PublishAtNEQ(v time.Time) predicate.Post {
+	return predicate.Post(sql.FieldNEQ(FieldPublishAt, v))
+}
+
+// PublishAtIn applies the In predicate on the "publish_at" field.
+func PublishAtIn(vs ...time.Time) predicate.Post {
+	return predicate.Post(sql.FieldIn(FieldPublishAt, vs...))
+}
+
+// PublishAtNotIn applies the NotIn predicate on the "publish_at" field.
+func PublishAtNotIn(vs ...time.Time) predicate.Post {
+	return predicate.Post(sql.FieldNotIn(FieldPublishAt, vs...))
+}
+
+// PublishAtGT applies the GT predicate on the "publish_at" field.
+func PublishAtGT(v time.Time) predicate.Post {
+	return predicate.Post(sql.FieldGT(FieldPublishAt, v))
+}
+
+// PublishAtGTE applies the GTE predicate on the "publish_at" field.
+func PublishAtGTE(v time.Time) predicate.Post {
+	return predicate.Post(sql.FieldGTE(FieldPublishAt, v))
+}
+
+// PublishAtLT applies the LT predicate on the "publish_at" field.
+func PublishAtLT(v time.Time) predicate.Post {
+	return predicate.Post(sql.FieldLT(FieldPublishAt, v))
+}
+
+// PublishAtLTE applies the LTE predicate on the "publish_at" field.
+func PublishAtLTE(v time.Time) predicate.Post {
+	return predicate.Post(sql.FieldLTE(FieldPublishAt, v))
+}
+
+// PublishAtIsNil applies the IsNil predicate on the "publish_at" field.
+func PublishAtIsNil() predicate.Post {
+	return predicate.Post(sql.FieldIsNull(FieldPublishAt))
+}
+
+// PublishAtNotNil applies the NotNil predicate on the "publish_at" field.
+func PublishAtNotNil() predicate.Post {
+	return predicate.Post(sql.FieldNotNull(FieldPublishAt))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Post {
 	return predicate.Post(sql.FieldEQ(FieldCreatedAt, v))
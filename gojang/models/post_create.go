@@ -34,6 +34,34 @@ func (_c *PostCreate) SetBody(v string) *PostCreate {
 	return _c
 }
 
+// SetPendingReview sets the "pending_review" field.
+func (_c *PostCreate) SetPendingReview(v bool) *PostCreate {
+	_c.mutation.SetPendingReview(v)
+	return _c
+}
+
+// SetNillablePendingReview sets the "pending_review" field if the given value is not nil.
+func (_c *PostCreate) SetNillablePendingReview(v *bool) *PostCreate {
+	if v != nil {
+		_c.SetPendingReview(*v)
+	}
+	return _c
+}
+
+// SetPublishAt sets the "publish_at" field.
+func (_c *PostCreate) SetPublishAt(v time.Time) *PostCreate {
+	_c.mutation.SetPublishAt(v)
+	return _c
+}
+
+// SetNillablePublishAt sets the "publish_at" field if the given value is not nil.
+func (_c *PostCreate) SetNillablePublishAt(v *time.Time) *PostCreate {
+	if v != nil {
+		_c.SetPublishAt(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *PostCreate) SetCreatedAt(v time.Time) *PostCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -122,6 +150,10 @@ func (_c *PostCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (_c *PostCreate) defaults() {
+	if _, ok := _c.mutation.PendingReview(); !ok {
+		v := post.DefaultPendingReview
+		_c.mutation.SetPendingReview(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := post.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -154,6 +186,9 @@ func (_c *PostCreate) check() error {
 			return &ValidationError{Name: "body", err: fmt.Errorf(`models: validator failed for field "Post.body": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.PendingReview(); !ok {
+		return &ValidationError{Name: "pending_review", err: errors.New(`models: missing required field "Post.pending_review"`)}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`models: missing required field "Post.created_at"`)}
 	}
@@ -206,6 +241,14 @@ func (_c *PostCreate) createSpec() (*Post, *sqlgraph.CreateSpec) {
 		_spec.SetField(post.FieldBody, field.TypeString, value)
 		_node.Body = value
 	}
+	if value, ok := _c.mutation.PendingReview(); ok {
+		_spec.SetField(post.FieldPendingReview, field.TypeBool, value)
+		_node.PendingReview = value
+	}
+	if value, ok := _c.mutation.PublishAt(); ok {
+		_spec.SetField(post.FieldPublishAt, field.TypeTime, value)
+		_node.PublishAt = &value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(post.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -58,6 +58,40 @@ func (_u *PostUpdate) SetNillableBody(v *string) *PostUpdate {
 	return _u
 }
 
+// SetPendingReview sets the "pending_review" field.
+func (_u *PostUpdate) SetPendingReview(v bool) *PostUpdate {
+	_u.mutation.SetPendingReview(v)
+	return _u
+}
+
+// SetNillablePendingReview sets the "pending_review" field if the given value is not nil.
+func (_u *PostUpdate) SetNillablePendingReview(v *bool) *PostUpdate {
+	if v != nil {
+		_u.SetPendingReview(*v)
+	}
+	return _u
+}
+
+// SetPublishAt sets the "publish_at" field.
+func (_u *PostUpdate) SetPublishAt(v time.Time) *PostUpdate {
+	_u.mutation.SetPublishAt(v)
+	return _u
+}
+
+// SetNillablePublishAt sets the "publish_at" field if the given value is not nil.
+func (_u *PostUpdate) SetNillablePublishAt(v *time.Time) *PostUpdate {
+	if v != nil {
+		_u.SetPublishAt(*v)
+	}
+	return _u
+}
+
+// ClearPublishAt clears the value of the "publish_at" field.
+func (_u *PostUpdate) ClearPublishAt() *PostUpdate {
+	_u.mutation.ClearPublishAt()
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *PostUpdate) SetUpdatedAt(v time.Time) *PostUpdate {
 	_u.mutation.SetUpdatedAt(v)
@@ -158,6 +192,15 @@ func (_u *PostUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.Body(); ok {
 		_spec.SetField(post.FieldBody, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.PendingReview(); ok {
+		_spec.SetField(post.FieldPendingReview, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PublishAt(); ok {
+		_spec.SetField(post.FieldPublishAt, field.TypeTime, value)
+	}
+	if _u.mutation.PublishAtCleared() {
+		_spec.ClearField(post.FieldPublishAt, field.TypeTime)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(post.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -238,6 +281,40 @@ func (_u *PostUpdateOne) SetNillableBody(v *string) *PostUpdateOne {
 	return _u
 }
 
+// SetPendingReview sets the "pending_review" field.
+func (_u *PostUpdateOne) SetPendingReview(v bool) *PostUpdateOne {
+	_u.mutation.SetPendingReview(v)
+	return _u
+}
+
+// SetNillablePendingReview sets the "pending_review" field if the given value is not nil.
+func (_u *PostUpdateOne) SetNillablePendingReview(v *bool) *PostUpdateOne {
+	if v != nil {
+		_u.SetPendingReview(*v)
+	}
+	return _u
+}
+
+// SetPublishAt sets the "publish_at" field.
+func (_u *PostUpdateOne) SetPublishAt(v time.Time) *PostUpdateOne {
+	_u.mutation.SetPublishAt(v)
+	return _u
+}
+
+// SetNillablePublishAt sets the "publish_at" field if the given value is not nil.
+func (_u *PostUpdateOne) SetNillablePublishAt(v *time.Time) *PostUpdateOne {
+	if v != nil {
+		_u.SetPublishAt(*v)
+	}
+	return _u
+}
+
+// ClearPublishAt clears the value of the "publish_at" field.
+func (_u *PostUpdateOne) ClearPublishAt() *PostUpdateOne {
+	_u.mutation.ClearPublishAt()
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *PostUpdateOne) SetUpdatedAt(v time.Time) *PostUpdateOne {
 	_u.mutation.SetUpdatedAt(v)
@@ -368,6 +445,15 @@ func (_u *PostUpdateOne) sqlSave(ctx context.Context) (_node *Post, err error) {
 	if value, ok := _u.mutation.Body(); ok {
 		_spec.SetField(post.FieldBody, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.PendingReview(); ok {
+		_spec.SetField(post.FieldPendingReview, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PublishAt(); ok {
+		_spec.SetField(post.FieldPublishAt, field.TypeTime, value)
+	}
+	if _u.mutation.PublishAtCleared() {
+		_spec.ClearField(post.FieldPublishAt, field.TypeTime)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(post.FieldUpdatedAt, field.TypeTime, value)
 	}
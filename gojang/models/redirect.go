@@ -0,0 +1,142 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/redirect"
+	"github.com/google/uuid"
+)
+
+// Redirect is the model entity for the Redirect schema.
+type Redirect struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Old path, e.g. '/blog/hello-world'
+	FromPath string `json:"from_path,omitempty"`
+	// New path or absolute URL
+	ToPath string `json:"to_path,omitempty"`
+	// 301 or 308 for permanent moves, 302 or 307 for temporary ones
+	StatusCode int `json:"status_code,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Redirect) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case redirect.FieldStatusCode:
+			values[i] = new(sql.NullInt64)
+		case redirect.FieldFromPath, redirect.FieldToPath:
+			values[i] = new(sql.NullString)
+		case redirect.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case redirect.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Redirect fields.
+func (_m *Redirect) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case redirect.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case redirect.FieldFromPath:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field from_path", values[i])
+			} else if value.Valid {
+				_m.FromPath = value.String
+			}
+		case redirect.FieldToPath:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field to_path", values[i])
+			} else if value.Valid {
+				_m.ToPath = value.String
+			}
+		case redirect.FieldStatusCode:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field status_code", values[i])
+			} else if value.Valid {
+				_m.StatusCode = int(value.Int64)
+			}
+		case redirect.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Redirect.
+// This includes values selected through modifiers, order, etc.
+func (_m *Redirect) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Redirect.
+// Note that you need to call Redirect.Unwrap() before calling this method if this Redirect
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Redirect) Update() *RedirectUpdateOne {
+	return NewRedirectClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Redirect entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Redirect) Unwrap() *Redirect {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("models: Redirect is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Redirect) String() string {
+	var builder strings.Builder
+	builder.WriteString("Redirect(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("from_path=")
+	builder.WriteString(_m.FromPath)
+	builder.WriteString(", ")
+	builder.WriteString("to_path=")
+	builder.WriteString(_m.ToPath)
+	builder.WriteString(", ")
+	builder.WriteString("status_code=")
+	builder.WriteString(fmt.Sprintf("%v", _m.StatusCode))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Redirects is a parsable slice of Redirect.
+type Redirects []*Redirect
@@ -0,0 +1,87 @@
+// Code generated by ent, DO NOT EDIT.
+
+package redirect
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the redirect type in the database.
+	Label = "redirect"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldFromPath holds the string denoting the from_path field in the database.
+	FieldFromPath = "from_path"
+	// FieldToPath holds the string denoting the to_path field in the database.
+	FieldToPath = "to_path"
+	// FieldStatusCode holds the string denoting the status_code field in the database.
+	FieldStatusCode = "status_code"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the redirect in the database.
+	Table = "redirects"
+)
+
+// Columns holds all SQL columns for redirect fields.
+var Columns = []string{
+	FieldID,
+	FieldFromPath,
+	FieldToPath,
+	FieldStatusCode,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// FromPathValidator is a validator for the "from_path" field. It is called by the builders before save.
+	FromPathValidator func(string) error
+	// ToPathValidator is a validator for the "to_path" field. It is called by the builders before save.
+	ToPathValidator func(string) error
+	// DefaultStatusCode holds the default value on creation for the "status_code" field.
+	DefaultStatusCode int
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the Redirect queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByFromPath orders the results by the from_path field.
+func ByFromPath(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFromPath, opts...).ToFunc()
+}
+
+// ByToPath orders the results by the to_path field.
+func ByToPath(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldToPath, opts...).ToFunc()
+}
+
+// ByStatusCode orders the results by the status_code field.
+func ByStatusCode(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatusCode, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
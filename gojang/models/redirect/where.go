@@ -0,0 +1,301 @@
+// Code generated by ent, DO NOT EDIT.
+
+package redirect
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLTE(FieldID, id))
+}
+
+// FromPath applies equality check predicate on the "from_path" field. It's identical to FromPathEQ.
+func FromPath(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldFromPath, v))
+}
+
+// ToPath applies equality check predicate on the "to_path" field. It's identical to ToPathEQ.
+func ToPath(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldToPath, v))
+}
+
+// StatusCode applies equality check predicate on the "status_code" field. It's identical to StatusCodeEQ.
+func StatusCode(v int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldStatusCode, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// FromPathEQ applies the EQ predicate on the "from_path" field.
+func FromPathEQ(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldFromPath, v))
+}
+
+// FromPathNEQ applies the NEQ predicate on the "from_path" field.
+func FromPathNEQ(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNEQ(FieldFromPath, v))
+}
+
+// FromPathIn applies the In predicate on the "from_path" field.
+func FromPathIn(vs ...string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldIn(FieldFromPath, vs...))
+}
+
+// FromPathNotIn applies the NotIn predicate on the "from_path" field.
+func FromPathNotIn(vs ...string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNotIn(FieldFromPath, vs...))
+}
+
+// FromPathGT applies the GT predicate on the "from_path" field.
+func FromPathGT(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGT(FieldFromPath, v))
+}
+
+// FromPathGTE applies the GTE predicate on the "from_path" field.
+func FromPathGTE(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGTE(FieldFromPath, v))
+}
+
+// FromPathLT applies the LT predicate on the "from_path" field.
+func FromPathLT(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLT(FieldFromPath, v))
+}
+
+// FromPathLTE applies the LTE predicate on the "from_path" field.
+func FromPathLTE(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLTE(FieldFromPath, v))
+}
+
+// FromPathContains applies the Contains predicate on the "from_path" field.
+func FromPathContains(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldContains(FieldFromPath, v))
+}
+
+// FromPathHasPrefix applies the HasPrefix predicate on the "from_path" field.
+func FromPathHasPrefix(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldHasPrefix(FieldFromPath, v))
+}
+
+// FromPathHasSuffix applies the HasSuffix predicate on the "from_path" field.
+func FromPathHasSuffix(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldHasSuffix(FieldFromPath, v))
+}
+
+// FromPathEqualFold applies the EqualFold predicate on the "from_path" field.
+func FromPathEqualFold(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEqualFold(FieldFromPath, v))
+}
+
+// FromPathContainsFold applies the ContainsFold predicate on the "from_path" field.
+func FromPathContainsFold(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldContainsFold(FieldFromPath, v))
+}
+
+// ToPathEQ applies the EQ predicate on the "to_path" field.
+func ToPathEQ(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldToPath, v))
+}
+
+// ToPathNEQ applies the NEQ predicate on the "to_path" field.
+func ToPathNEQ(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNEQ(FieldToPath, v))
+}
+
+// ToPathIn applies the In predicate on the "to_path" field.
+func ToPathIn(vs ...string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldIn(FieldToPath, vs...))
+}
+
+// ToPathNotIn applies the NotIn predicate on the "to_path" field.
+func ToPathNotIn(vs ...string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNotIn(FieldToPath, vs...))
+}
+
+// ToPathGT applies the GT predicate on the "to_path" field.
+func ToPathGT(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGT(FieldToPath, v))
+}
+
+// ToPathGTE applies the GTE predicate on the "to_path" field.
+func ToPathGTE(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGTE(FieldToPath, v))
+}
+
+// ToPathLT applies the LT predicate on the "to_path" field.
+func ToPathLT(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLT(FieldToPath, v))
+}
+
+// ToPathLTE applies the LTE predicate on the "to_path" field.
+func ToPathLTE(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLTE(FieldToPath, v))
+}
+
+// ToPathContains applies the Contains predicate on the "to_path" field.
+func ToPathContains(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldContains(FieldToPath, v))
+}
+
+// ToPathHasPrefix applies the HasPrefix predicate on the "to_path" field.
+func ToPathHasPrefix(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldHasPrefix(FieldToPath, v))
+}
+
+// ToPathHasSuffix applies the HasSuffix predicate on the "to_path" field.
+func ToPathHasSuffix(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldHasSuffix(FieldToPath, v))
+}
+
+// ToPathEqualFold applies the EqualFold predicate on the "to_path" field.
+func ToPathEqualFold(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEqualFold(FieldToPath, v))
+}
+
+// ToPathContainsFold applies the ContainsFold predicate on the "to_path" field.
+func ToPathContainsFold(v string) predicate.Redirect {
+	return predicate.Redirect(sql.FieldContainsFold(FieldToPath, v))
+}
+
+// StatusCodeEQ applies the EQ predicate on the "status_code" field.
+func StatusCodeEQ(v int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldStatusCode, v))
+}
+
+// StatusCodeNEQ applies the NEQ predicate on the "status_code" field.
+func StatusCodeNEQ(v int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNEQ(FieldStatusCode, v))
+}
+
+// StatusCodeIn applies the In predicate on the "status_code" field.
+func StatusCodeIn(vs ...int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldIn(FieldStatusCode, vs...))
+}
+
+// StatusCodeNotIn applies the NotIn predicate on the "status_code" field.
+func StatusCodeNotIn(vs ...int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNotIn(FieldStatusCode, vs...))
+}
+
+// StatusCodeGT applies the GT predicate on the "status_code" field.
+func StatusCodeGT(v int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGT(FieldStatusCode, v))
+}
+
+// StatusCodeGTE applies the GTE predicate on the "status_code" field.
+func StatusCodeGTE(v int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGTE(FieldStatusCode, v))
+}
+
+// StatusCodeLT applies the LT predicate on the "status_code" field.
+func StatusCodeLT(v int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLT(FieldStatusCode, v))
+}
+
+// StatusCodeLTE applies the LTE predicate on the "status_code" field.
+func StatusCodeLTE(v int) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLTE(FieldStatusCode, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Redirect {
+	return predicate.Redirect(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Redirect) predicate.Redirect {
+	return predicate.Redirect(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Redirect) predicate.Redirect {
+	return predicate.Redirect(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Redirect) predicate.Redirect {
+	return predicate.Redirect(sql.NotPredicates(p))
+}
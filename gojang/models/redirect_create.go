@@ -0,0 +1,287 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/redirect"
+	"github.com/google/uuid"
+)
+
+// RedirectCreate is the builder for creating a Redirect entity.
+type RedirectCreate struct {
+	config
+	mutation *RedirectMutation
+	hooks    []Hook
+}
+
+// SetFromPath sets the "from_path" field.
+func (_c *RedirectCreate) SetFromPath(v string) *RedirectCreate {
+	_c.mutation.SetFromPath(v)
+	return _c
+}
+
+// SetToPath sets the "to_path" field.
+func (_c *RedirectCreate) SetToPath(v string) *RedirectCreate {
+	_c.mutation.SetToPath(v)
+	return _c
+}
+
+// SetStatusCode sets the "status_code" field.
+func (_c *RedirectCreate) SetStatusCode(v int) *RedirectCreate {
+	_c.mutation.SetStatusCode(v)
+	return _c
+}
+
+// SetNillableStatusCode sets the "status_code" field if the given value is not nil.
+func (_c *RedirectCreate) SetNillableStatusCode(v *int) *RedirectCreate {
+	if v != nil {
+		_c.SetStatusCode(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *RedirectCreate) SetCreatedAt(v time.Time) *RedirectCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *RedirectCreate) SetNillableCreatedAt(v *time.Time) *RedirectCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *RedirectCreate) SetID(v uuid.UUID) *RedirectCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *RedirectCreate) SetNillableID(v *uuid.UUID) *RedirectCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the RedirectMutation object of the builder.
+func (_c *RedirectCreate) Mutation() *RedirectMutation {
+	return _c.mutation
+}
+
+// Save creates the Redirect in the database.
+func (_c *RedirectCreate) Save(ctx context.Context) (*Redirect, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *RedirectCreate) SaveX(ctx context.Context) *Redirect {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *RedirectCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *RedirectCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *RedirectCreate) defaults() {
+	if _, ok := _c.mutation.StatusCode(); !ok {
+		v := redirect.DefaultStatusCode
+		_c.mutation.SetStatusCode(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := redirect.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := redirect.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *RedirectCreate) check() error {
+	if _, ok := _c.mutation.FromPath(); !ok {
+		return &ValidationError{Name: "from_path", err: errors.New(`models: missing required field "Redirect.from_path"`)}
+	}
+	if v, ok := _c.mutation.FromPath(); ok {
+		if err := redirect.FromPathValidator(v); err != nil {
+			return &ValidationError{Name: "from_path", err: fmt.Errorf(`models: validator failed for field "Redirect.from_path": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ToPath(); !ok {
+		return &ValidationError{Name: "to_path", err: errors.New(`models: missing required field "Redirect.to_path"`)}
+	}
+	if v, ok := _c.mutation.ToPath(); ok {
+		if err := redirect.ToPathValidator(v); err != nil {
+			return &ValidationError{Name: "to_path", err: fmt.Errorf(`models: validator failed for field "Redirect.to_path": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.StatusCode(); !ok {
+		return &ValidationError{Name: "status_code", err: errors.New(`models: missing required field "Redirect.status_code"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`models: missing required field "Redirect.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *RedirectCreate) sqlSave(ctx context.Context) (*Redirect, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *RedirectCreate) createSpec() (*Redirect, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Redirect{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(redirect.Table, sqlgraph.NewFieldSpec(redirect.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.FromPath(); ok {
+		_spec.SetField(redirect.FieldFromPath, field.TypeString, value)
+		_node.FromPath = value
+	}
+	if value, ok := _c.mutation.ToPath(); ok {
+		_spec.SetField(redirect.FieldToPath, field.TypeString, value)
+		_node.ToPath = value
+	}
+	if value, ok := _c.mutation.StatusCode(); ok {
+		_spec.SetField(redirect.FieldStatusCode, field.TypeInt, value)
+		_node.StatusCode = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(redirect.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// RedirectCreateBulk is the builder for creating many Redirect entities in bulk.
+type RedirectCreateBulk struct {
+	config
+	err      error
+	builders []*RedirectCreate
+}
+
+// Save creates the Redirect entities in the database.
+func (_c *RedirectCreateBulk) Save(ctx context.Context) ([]*Redirect, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Redirect, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*RedirectMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *RedirectCreateBulk) SaveX(ctx context.Context) []*Redirect {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *RedirectCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *RedirectCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
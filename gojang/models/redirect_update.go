@@ -0,0 +1,333 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/gojangframework/gojang/gojang/models/redirect"
+)
+
+// RedirectUpdate is the builder for updating Redirect entities.
+type RedirectUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RedirectMutation
+}
+
+// Where appends a list predicates to the RedirectUpdate builder.
+func (_u *RedirectUpdate) Where(ps ...predicate.Redirect) *RedirectUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetFromPath sets the "from_path" field.
+func (_u *RedirectUpdate) SetFromPath(v string) *RedirectUpdate {
+	_u.mutation.SetFromPath(v)
+	return _u
+}
+
+// SetNillableFromPath sets the "from_path" field if the given value is not nil.
+func (_u *RedirectUpdate) SetNillableFromPath(v *string) *RedirectUpdate {
+	if v != nil {
+		_u.SetFromPath(*v)
+	}
+	return _u
+}
+
+// SetToPath sets the "to_path" field.
+func (_u *RedirectUpdate) SetToPath(v string) *RedirectUpdate {
+	_u.mutation.SetToPath(v)
+	return _u
+}
+
+// SetNillableToPath sets the "to_path" field if the given value is not nil.
+func (_u *RedirectUpdate) SetNillableToPath(v *string) *RedirectUpdate {
+	if v != nil {
+		_u.SetToPath(*v)
+	}
+	return _u
+}
+
+// SetStatusCode sets the "status_code" field.
+func (_u *RedirectUpdate) SetStatusCode(v int) *RedirectUpdate {
+	_u.mutation.ResetStatusCode()
+	_u.mutation.SetStatusCode(v)
+	return _u
+}
+
+// SetNillableStatusCode sets the "status_code" field if the given value is not nil.
+func (_u *RedirectUpdate) SetNillableStatusCode(v *int) *RedirectUpdate {
+	if v != nil {
+		_u.SetStatusCode(*v)
+	}
+	return _u
+}
+
+// AddStatusCode adds value to the "status_code" field.
+func (_u *RedirectUpdate) AddStatusCode(v int) *RedirectUpdate {
+	_u.mutation.AddStatusCode(v)
+	return _u
+}
+
+// Mutation returns the RedirectMutation object of the builder.
+func (_u *RedirectUpdate) Mutation() *RedirectMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *RedirectUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RedirectUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *RedirectUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RedirectUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *RedirectUpdate) check() error {
+	if v, ok := _u.mutation.FromPath(); ok {
+		if err := redirect.FromPathValidator(v); err != nil {
+			return &ValidationError{Name: "from_path", err: fmt.Errorf(`models: validator failed for field "Redirect.from_path": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ToPath(); ok {
+		if err := redirect.ToPathValidator(v); err != nil {
+			return &ValidationError{Name: "to_path", err: fmt.Errorf(`models: validator failed for field "Redirect.to_path": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *RedirectUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(redirect.Table, redirect.Columns, sqlgraph.NewFieldSpec(redirect.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.FromPath(); ok {
+		_spec.SetField(redirect.FieldFromPath, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ToPath(); ok {
+		_spec.SetField(redirect.FieldToPath, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.StatusCode(); ok {
+		_spec.SetField(redirect.FieldStatusCode, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedStatusCode(); ok {
+		_spec.AddField(redirect.FieldStatusCode, field.TypeInt, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{redirect.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// RedirectUpdateOne is the builder for updating a single Redirect entity.
+type RedirectUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RedirectMutation
+}
+
+// SetFromPath sets the "from_path" field.
+func (_u *RedirectUpdateOne) SetFromPath(v string) *RedirectUpdateOne {
+	_u.mutation.SetFromPath(v)
+	return _u
+}
+
+// SetNillableFromPath sets the "from_path" field if the given value is not nil.
+func (_u *RedirectUpdateOne) SetNillableFromPath(v *string) *RedirectUpdateOne {
+	if v != nil {
+		_u.SetFromPath(*v)
+	}
+	return _u
+}
+
+// SetToPath sets the "to_path" field.
+func (_u *RedirectUpdateOne) SetToPath(v string) *RedirectUpdateOne {
+	_u.mutation.SetToPath(v)
+	return _u
+}
+
+// SetNillableToPath sets the "to_path" field if the given value is not nil.
+func (_u *RedirectUpdateOne) SetNillableToPath(v *string) *RedirectUpdateOne {
+	if v != nil {
+		_u.SetToPath(*v)
+	}
+	return _u
+}
+
+// SetStatusCode sets the "status_code" field.
+func (_u *RedirectUpdateOne) SetStatusCode(v int) *RedirectUpdateOne {
+	_u.mutation.ResetStatusCode()
+	_u.mutation.SetStatusCode(v)
+	return _u
+}
+
+// SetNillableStatusCode sets the "status_code" field if the given value is not nil.
+func (_u *RedirectUpdateOne) SetNillableStatusCode(v *int) *RedirectUpdateOne {
+	if v != nil {
+		_u.SetStatusCode(*v)
+	}
+	return _u
+}
+
+// AddStatusCode adds value to the "status_code" field.
+func (_u *RedirectUpdateOne) AddStatusCode(v int) *RedirectUpdateOne {
+	_u.mutation.AddStatusCode(v)
+	return _u
+}
+
+// Mutation returns the RedirectMutation object of the builder.
+func (_u *RedirectUpdateOne) Mutation() *RedirectMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the RedirectUpdate builder.
+func (_u *RedirectUpdateOne) Where(ps ...predicate.Redirect) *RedirectUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *RedirectUpdateOne) Select(field string, fields ...string) *RedirectUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Redirect entity.
+func (_u *RedirectUpdateOne) Save(ctx context.Context) (*Redirect, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RedirectUpdateOne) SaveX(ctx context.Context) *Redirect {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *RedirectUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RedirectUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *RedirectUpdateOne) check() error {
+	if v, ok := _u.mutation.FromPath(); ok {
+		if err := redirect.FromPathValidator(v); err != nil {
+			return &ValidationError{Name: "from_path", err: fmt.Errorf(`models: validator failed for field "Redirect.from_path": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ToPath(); ok {
+		if err := redirect.ToPathValidator(v); err != nil {
+			return &ValidationError{Name: "to_path", err: fmt.Errorf(`models: validator failed for field "Redirect.to_path": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *RedirectUpdateOne) sqlSave(ctx context.Context) (_node *Redirect, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(redirect.Table, redirect.Columns, sqlgraph.NewFieldSpec(redirect.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`models: missing "Redirect.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, redirect.FieldID)
+		for _, f := range fields {
+			if !redirect.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("models: invalid field %q for query", f)}
+			}
+			if f != redirect.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.FromPath(); ok {
+		_spec.SetField(redirect.FieldFromPath, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ToPath(); ok {
+		_spec.SetField(redirect.FieldToPath, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.StatusCode(); ok {
+		_spec.SetField(redirect.FieldStatusCode, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedStatusCode(); ok {
+		_spec.AddField(redirect.FieldStatusCode, field.TypeInt, value)
+	}
+	_node = &Redirect{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{redirect.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
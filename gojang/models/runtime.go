@@ -5,10 +5,20 @@ package models
 import (
 	"time"
 
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/announcement"
+	"github.com/gojangframework/gojang/gojang/models/contactmessage"
+	"github.com/gojangframework/gojang/gojang/models/group"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/passkey"
+	"github.com/gojangframework/gojang/gojang/models/poll"
 	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/gojangframework/gojang/gojang/models/redirect"
 	"github.com/gojangframework/gojang/gojang/models/schema"
 	"github.com/gojangframework/gojang/gojang/models/setting"
 	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/models/userpreference"
+	"github.com/gojangframework/gojang/gojang/models/vote"
 	"github.com/google/uuid"
 )
 
@@ -16,6 +26,188 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	adminactionFields := schema.AdminAction{}.Fields()
+	_ = adminactionFields
+	// adminactionDescModel is the schema descriptor for model field.
+	adminactionDescModel := adminactionFields[3].Descriptor()
+	// adminaction.ModelValidator is a validator for the "model" field. It is called by the builders before save.
+	adminaction.ModelValidator = adminactionDescModel.Validators[0].(func(string) error)
+	// adminactionDescCreatedAt is the schema descriptor for created_at field.
+	adminactionDescCreatedAt := adminactionFields[8].Descriptor()
+	// adminaction.DefaultCreatedAt holds the default value on creation for the created_at field.
+	adminaction.DefaultCreatedAt = adminactionDescCreatedAt.Default.(func() time.Time)
+	// adminactionDescID is the schema descriptor for id field.
+	adminactionDescID := adminactionFields[0].Descriptor()
+	// adminaction.DefaultID holds the default value on creation for the id field.
+	adminaction.DefaultID = adminactionDescID.Default.(func() uuid.UUID)
+	announcementFields := schema.Announcement{}.Fields()
+	_ = announcementFields
+	// announcementDescMessage is the schema descriptor for message field.
+	announcementDescMessage := announcementFields[1].Descriptor()
+	// announcement.MessageValidator is a validator for the "message" field. It is called by the builders before save.
+	announcement.MessageValidator = announcementDescMessage.Validators[0].(func(string) error)
+	// announcementDescLevel is the schema descriptor for level field.
+	announcementDescLevel := announcementFields[2].Descriptor()
+	// announcement.DefaultLevel holds the default value on creation for the level field.
+	announcement.DefaultLevel = announcementDescLevel.Default.(string)
+	// announcementDescAudience is the schema descriptor for audience field.
+	announcementDescAudience := announcementFields[3].Descriptor()
+	// announcement.DefaultAudience holds the default value on creation for the audience field.
+	announcement.DefaultAudience = announcementDescAudience.Default.(string)
+	// announcementDescCreatedAt is the schema descriptor for created_at field.
+	announcementDescCreatedAt := announcementFields[6].Descriptor()
+	// announcement.DefaultCreatedAt holds the default value on creation for the created_at field.
+	announcement.DefaultCreatedAt = announcementDescCreatedAt.Default.(func() time.Time)
+	// announcementDescID is the schema descriptor for id field.
+	announcementDescID := announcementFields[0].Descriptor()
+	// announcement.DefaultID holds the default value on creation for the id field.
+	announcement.DefaultID = announcementDescID.Default.(func() uuid.UUID)
+	contactmessageFields := schema.ContactMessage{}.Fields()
+	_ = contactmessageFields
+	// contactmessageDescName is the schema descriptor for name field.
+	contactmessageDescName := contactmessageFields[1].Descriptor()
+	// contactmessage.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	contactmessage.NameValidator = func() func(string) error {
+		validators := contactmessageDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// contactmessageDescEmail is the schema descriptor for email field.
+	contactmessageDescEmail := contactmessageFields[2].Descriptor()
+	// contactmessage.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	contactmessage.EmailValidator = contactmessageDescEmail.Validators[0].(func(string) error)
+	// contactmessageDescSubject is the schema descriptor for subject field.
+	contactmessageDescSubject := contactmessageFields[3].Descriptor()
+	// contactmessage.DefaultSubject holds the default value on creation for the subject field.
+	contactmessage.DefaultSubject = contactmessageDescSubject.Default.(string)
+	// contactmessage.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	contactmessage.SubjectValidator = contactmessageDescSubject.Validators[0].(func(string) error)
+	// contactmessageDescMessage is the schema descriptor for message field.
+	contactmessageDescMessage := contactmessageFields[4].Descriptor()
+	// contactmessage.MessageValidator is a validator for the "message" field. It is called by the builders before save.
+	contactmessage.MessageValidator = contactmessageDescMessage.Validators[0].(func(string) error)
+	// contactmessageDescIP is the schema descriptor for ip field.
+	contactmessageDescIP := contactmessageFields[5].Descriptor()
+	// contactmessage.DefaultIP holds the default value on creation for the ip field.
+	contactmessage.DefaultIP = contactmessageDescIP.Default.(string)
+	// contactmessageDescHandled is the schema descriptor for handled field.
+	contactmessageDescHandled := contactmessageFields[6].Descriptor()
+	// contactmessage.DefaultHandled holds the default value on creation for the handled field.
+	contactmessage.DefaultHandled = contactmessageDescHandled.Default.(bool)
+	// contactmessageDescCreatedAt is the schema descriptor for created_at field.
+	contactmessageDescCreatedAt := contactmessageFields[7].Descriptor()
+	// contactmessage.DefaultCreatedAt holds the default value on creation for the created_at field.
+	contactmessage.DefaultCreatedAt = contactmessageDescCreatedAt.Default.(func() time.Time)
+	// contactmessageDescID is the schema descriptor for id field.
+	contactmessageDescID := contactmessageFields[0].Descriptor()
+	// contactmessage.DefaultID holds the default value on creation for the id field.
+	contactmessage.DefaultID = contactmessageDescID.Default.(func() uuid.UUID)
+	groupFields := schema.Group{}.Fields()
+	_ = groupFields
+	// groupDescName is the schema descriptor for name field.
+	groupDescName := groupFields[1].Descriptor()
+	// group.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	group.NameValidator = groupDescName.Validators[0].(func(string) error)
+	// groupDescCreatedAt is the schema descriptor for created_at field.
+	groupDescCreatedAt := groupFields[4].Descriptor()
+	// group.DefaultCreatedAt holds the default value on creation for the created_at field.
+	group.DefaultCreatedAt = groupDescCreatedAt.Default.(func() time.Time)
+	// groupDescID is the schema descriptor for id field.
+	groupDescID := groupFields[0].Descriptor()
+	// group.DefaultID holds the default value on creation for the id field.
+	group.DefaultID = groupDescID.Default.(func() uuid.UUID)
+	logineventFields := schema.LoginEvent{}.Fields()
+	_ = logineventFields
+	// logineventDescUserAgent is the schema descriptor for user_agent field.
+	logineventDescUserAgent := logineventFields[4].Descriptor()
+	// loginevent.UserAgentValidator is a validator for the "user_agent" field. It is called by the builders before save.
+	loginevent.UserAgentValidator = logineventDescUserAgent.Validators[0].(func(string) error)
+	// logineventDescNewDevice is the schema descriptor for new_device field.
+	logineventDescNewDevice := logineventFields[6].Descriptor()
+	// loginevent.DefaultNewDevice holds the default value on creation for the new_device field.
+	loginevent.DefaultNewDevice = logineventDescNewDevice.Default.(bool)
+	// logineventDescCreatedAt is the schema descriptor for created_at field.
+	logineventDescCreatedAt := logineventFields[8].Descriptor()
+	// loginevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	loginevent.DefaultCreatedAt = logineventDescCreatedAt.Default.(func() time.Time)
+	// logineventDescID is the schema descriptor for id field.
+	logineventDescID := logineventFields[0].Descriptor()
+	// loginevent.DefaultID holds the default value on creation for the id field.
+	loginevent.DefaultID = logineventDescID.Default.(func() uuid.UUID)
+	passkeyFields := schema.Passkey{}.Fields()
+	_ = passkeyFields
+	// passkeyDescCredentialID is the schema descriptor for credential_id field.
+	passkeyDescCredentialID := passkeyFields[1].Descriptor()
+	// passkey.CredentialIDValidator is a validator for the "credential_id" field. It is called by the builders before save.
+	passkey.CredentialIDValidator = passkeyDescCredentialID.Validators[0].(func([]byte) error)
+	// passkeyDescSignCount is the schema descriptor for sign_count field.
+	passkeyDescSignCount := passkeyFields[3].Descriptor()
+	// passkey.DefaultSignCount holds the default value on creation for the sign_count field.
+	passkey.DefaultSignCount = passkeyDescSignCount.Default.(uint32)
+	// passkeyDescName is the schema descriptor for name field.
+	passkeyDescName := passkeyFields[4].Descriptor()
+	// passkey.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	passkey.NameValidator = func() func(string) error {
+		validators := passkeyDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// passkeyDescCreatedAt is the schema descriptor for created_at field.
+	passkeyDescCreatedAt := passkeyFields[5].Descriptor()
+	// passkey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passkey.DefaultCreatedAt = passkeyDescCreatedAt.Default.(func() time.Time)
+	// passkeyDescID is the schema descriptor for id field.
+	passkeyDescID := passkeyFields[0].Descriptor()
+	// passkey.DefaultID holds the default value on creation for the id field.
+	passkey.DefaultID = passkeyDescID.Default.(func() uuid.UUID)
+	pollFields := schema.Poll{}.Fields()
+	_ = pollFields
+	// pollDescQuestion is the schema descriptor for question field.
+	pollDescQuestion := pollFields[1].Descriptor()
+	// poll.QuestionValidator is a validator for the "question" field. It is called by the builders before save.
+	poll.QuestionValidator = func() func(string) error {
+		validators := pollDescQuestion.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(question string) error {
+			for _, fn := range fns {
+				if err := fn(question); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// pollDescCreatedAt is the schema descriptor for created_at field.
+	pollDescCreatedAt := pollFields[4].Descriptor()
+	// poll.DefaultCreatedAt holds the default value on creation for the created_at field.
+	poll.DefaultCreatedAt = pollDescCreatedAt.Default.(func() time.Time)
+	// pollDescID is the schema descriptor for id field.
+	pollDescID := pollFields[0].Descriptor()
+	// poll.DefaultID holds the default value on creation for the id field.
+	poll.DefaultID = pollDescID.Default.(func() uuid.UUID)
 	postFields := schema.Post{}.Fields()
 	_ = postFields
 	// postDescSubject is the schema descriptor for subject field.
@@ -40,12 +232,16 @@ func init() {
 	postDescBody := postFields[2].Descriptor()
 	// post.BodyValidator is a validator for the "body" field. It is called by the builders before save.
 	post.BodyValidator = postDescBody.Validators[0].(func(string) error)
+	// postDescPendingReview is the schema descriptor for pending_review field.
+	postDescPendingReview := postFields[3].Descriptor()
+	// post.DefaultPendingReview holds the default value on creation for the pending_review field.
+	post.DefaultPendingReview = postDescPendingReview.Default.(bool)
 	// postDescCreatedAt is the schema descriptor for created_at field.
-	postDescCreatedAt := postFields[3].Descriptor()
+	postDescCreatedAt := postFields[5].Descriptor()
 	// post.DefaultCreatedAt holds the default value on creation for the created_at field.
 	post.DefaultCreatedAt = postDescCreatedAt.Default.(func() time.Time)
 	// postDescUpdatedAt is the schema descriptor for updated_at field.
-	postDescUpdatedAt := postFields[4].Descriptor()
+	postDescUpdatedAt := postFields[6].Descriptor()
 	// post.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	post.DefaultUpdatedAt = postDescUpdatedAt.Default.(func() time.Time)
 	// post.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -54,6 +250,28 @@ func init() {
 	postDescID := postFields[0].Descriptor()
 	// post.DefaultID holds the default value on creation for the id field.
 	post.DefaultID = postDescID.Default.(func() uuid.UUID)
+	redirectFields := schema.Redirect{}.Fields()
+	_ = redirectFields
+	// redirectDescFromPath is the schema descriptor for from_path field.
+	redirectDescFromPath := redirectFields[1].Descriptor()
+	// redirect.FromPathValidator is a validator for the "from_path" field. It is called by the builders before save.
+	redirect.FromPathValidator = redirectDescFromPath.Validators[0].(func(string) error)
+	// redirectDescToPath is the schema descriptor for to_path field.
+	redirectDescToPath := redirectFields[2].Descriptor()
+	// redirect.ToPathValidator is a validator for the "to_path" field. It is called by the builders before save.
+	redirect.ToPathValidator = redirectDescToPath.Validators[0].(func(string) error)
+	// redirectDescStatusCode is the schema descriptor for status_code field.
+	redirectDescStatusCode := redirectFields[3].Descriptor()
+	// redirect.DefaultStatusCode holds the default value on creation for the status_code field.
+	redirect.DefaultStatusCode = redirectDescStatusCode.Default.(int)
+	// redirectDescCreatedAt is the schema descriptor for created_at field.
+	redirectDescCreatedAt := redirectFields[4].Descriptor()
+	// redirect.DefaultCreatedAt holds the default value on creation for the created_at field.
+	redirect.DefaultCreatedAt = redirectDescCreatedAt.Default.(func() time.Time)
+	// redirectDescID is the schema descriptor for id field.
+	redirectDescID := redirectFields[0].Descriptor()
+	// redirect.DefaultID holds the default value on creation for the id field.
+	redirect.DefaultID = redirectDescID.Default.(func() uuid.UUID)
 	settingFields := schema.Setting{}.Fields()
 	_ = settingFields
 	// settingDescKey is the schema descriptor for key field.
@@ -71,23 +289,23 @@ func init() {
 	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
 	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
 	// userDescIsActive is the schema descriptor for is_active field.
-	userDescIsActive := userFields[3].Descriptor()
+	userDescIsActive := userFields[5].Descriptor()
 	// user.DefaultIsActive holds the default value on creation for the is_active field.
 	user.DefaultIsActive = userDescIsActive.Default.(bool)
 	// userDescIsStaff is the schema descriptor for is_staff field.
-	userDescIsStaff := userFields[4].Descriptor()
+	userDescIsStaff := userFields[6].Descriptor()
 	// user.DefaultIsStaff holds the default value on creation for the is_staff field.
 	user.DefaultIsStaff = userDescIsStaff.Default.(bool)
 	// userDescIsSuperuser is the schema descriptor for is_superuser field.
-	userDescIsSuperuser := userFields[5].Descriptor()
+	userDescIsSuperuser := userFields[7].Descriptor()
 	// user.DefaultIsSuperuser holds the default value on creation for the is_superuser field.
 	user.DefaultIsSuperuser = userDescIsSuperuser.Default.(bool)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[6].Descriptor()
+	userDescCreatedAt := userFields[8].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[7].Descriptor()
+	userDescUpdatedAt := userFields[9].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -96,4 +314,38 @@ func init() {
 	userDescID := userFields[0].Descriptor()
 	// user.DefaultID holds the default value on creation for the id field.
 	user.DefaultID = userDescID.Default.(func() uuid.UUID)
+	userpreferenceFields := schema.UserPreference{}.Fields()
+	_ = userpreferenceFields
+	// userpreferenceDescKey is the schema descriptor for key field.
+	userpreferenceDescKey := userpreferenceFields[1].Descriptor()
+	// userpreference.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	userpreference.KeyValidator = userpreferenceDescKey.Validators[0].(func(string) error)
+	// userpreferenceDescUpdatedAt is the schema descriptor for updated_at field.
+	userpreferenceDescUpdatedAt := userpreferenceFields[3].Descriptor()
+	// userpreference.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	userpreference.DefaultUpdatedAt = userpreferenceDescUpdatedAt.Default.(func() time.Time)
+	// userpreference.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	userpreference.UpdateDefaultUpdatedAt = userpreferenceDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// userpreferenceDescID is the schema descriptor for id field.
+	userpreferenceDescID := userpreferenceFields[0].Descriptor()
+	// userpreference.DefaultID holds the default value on creation for the id field.
+	userpreference.DefaultID = userpreferenceDescID.Default.(func() uuid.UUID)
+	voteFields := schema.Vote{}.Fields()
+	_ = voteFields
+	// voteDescOption is the schema descriptor for option field.
+	voteDescOption := voteFields[1].Descriptor()
+	// vote.OptionValidator is a validator for the "option" field. It is called by the builders before save.
+	vote.OptionValidator = voteDescOption.Validators[0].(func(int) error)
+	// voteDescVoter is the schema descriptor for voter field.
+	voteDescVoter := voteFields[2].Descriptor()
+	// vote.VoterValidator is a validator for the "voter" field. It is called by the builders before save.
+	vote.VoterValidator = voteDescVoter.Validators[0].(func(string) error)
+	// voteDescCreatedAt is the schema descriptor for created_at field.
+	voteDescCreatedAt := voteFields[3].Descriptor()
+	// vote.DefaultCreatedAt holds the default value on creation for the created_at field.
+	vote.DefaultCreatedAt = voteDescCreatedAt.Default.(func() time.Time)
+	// voteDescID is the schema descriptor for id field.
+	voteDescID := voteFields[0].Descriptor()
+	// vote.DefaultID holds the default value on creation for the id field.
+	vote.DefaultID = voteDescID.Default.(func() uuid.UUID)
 }
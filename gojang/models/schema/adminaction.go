@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AdminAction holds the schema definition for the AdminAction entity: one
+// record created, changed, deleted or passed to a custom action through the
+// admin panel.
+type AdminAction struct {
+	ent.Schema
+}
+
+// Fields of the AdminAction.
+func (AdminAction) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.Enum("action").
+			Values("create", "update", "delete", "run"),
+		field.String("action_name").
+			Optional().
+			Comment("Custom admin action run on the record (e.g., 'Deactivate')"),
+		field.String("model").
+			NotEmpty().
+			Comment("Admin model name (e.g., 'Post')"),
+		field.String("record_id"),
+		field.String("record_label").
+			Comment("Record label when the action ran, kept after deletes"),
+		// Plain columns rather than an edge, so the history survives deleting the user
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("user_email"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the AdminAction.
+func (AdminAction) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Fields("user_id", "created_at"),
+	}
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Announcement holds the schema definition for the Announcement entity: a
+// site-wide banner, shown to its audience between its start and end times
+// until each user dismisses it.
+type Announcement struct {
+	ent.Schema
+}
+
+// Fields of the Announcement.
+func (Announcement) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.Text("message").
+			NotEmpty().
+			Comment("Banner text (Markdown)"),
+		field.String("level").
+			Default("info").
+			Comment("Banner style: info, success, warning or error"),
+		field.String("audience").
+			Default("all").
+			Comment("Who sees it: all, guests, users or staff"),
+		field.Time("starts_at").
+			Optional().
+			Nillable().
+			Comment("Hidden until then; shown right away if empty"),
+		field.Time("ends_at").
+			Optional().
+			Nillable().
+			Comment("Hidden from then on; shown until deleted if empty"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Announcement.
+func (Announcement) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("dismissed_by", User.Type).
+			Ref("dismissed_announcements"),
+	}
+}
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ContactMessage holds the schema definition for the ContactMessage entity:
+// a message sent through the contact form, kept for the admin inbox.
+type ContactMessage struct {
+	ent.Schema
+}
+
+// Fields of the ContactMessage.
+func (ContactMessage) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("name").
+			NotEmpty().
+			MaxLen(100),
+		field.String("email").
+			NotEmpty().
+			Comment("Where replies go"),
+		field.String("subject").
+			Default("").
+			MaxLen(200),
+		field.Text("message").
+			NotEmpty(),
+		field.String("ip").
+			Default("").
+			Comment("Sender's address, for spotting abuse"),
+		field.Bool("handled").
+			Default(false).
+			Comment("Set once someone has dealt with it"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the ContactMessage.
+func (ContactMessage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("handled", "created_at"),
+	}
+}
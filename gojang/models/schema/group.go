@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Group holds the schema definition for the Group entity: a named set of
+// permissions granted to every member (like Django's auth groups).
+type Group struct {
+	ent.Schema
+}
+
+// Fields of the Group.
+func (Group) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("name").
+			Unique().
+			NotEmpty().
+			Comment("Group name (e.g., 'Editors')"),
+		field.String("description").
+			Optional(),
+		field.Strings("permissions").
+			Optional().
+			Comment("Permissions granted to members (e.g., 'manage_users'), checked by middleware.CanUser"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Group.
+func (Group) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("users", User.Type).
+			Ref("groups"),
+	}
+}
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// LoginEvent holds the schema definition for the LoginEvent entity: one
+// sign-in attempt on an existing account, shown on the user's security page.
+type LoginEvent struct {
+	ent.Schema
+}
+
+// Fields of the LoginEvent.
+func (LoginEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.Bool("success"),
+		field.String("failure").
+			Optional().
+			Comment("Why the attempt failed (e.g., 'wrong password'); empty on success"),
+		field.String("ip"),
+		field.String("user_agent").
+			MaxLen(512),
+		field.String("device").
+			Optional().
+			Comment("Random ID from a long-lived cookie, to spot logins from new devices"),
+		field.Bool("new_device").
+			Default(false).
+			Comment("A successful login from a device the user hadn't signed in from before"),
+		field.String("session").
+			Optional().
+			Sensitive().
+			Comment("The login's session token while it may be active, to sign out the oldest when the user has too many (SESSION_LIMIT)"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the LoginEvent.
+func (LoginEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("login_events").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the LoginEvent.
+func (LoginEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Edges("user").
+			Fields("created_at"),
+	}
+}
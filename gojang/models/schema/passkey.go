@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Passkey holds the schema definition for the Passkey entity: a WebAuthn
+// credential a user can sign in with instead of a password.
+type Passkey struct {
+	ent.Schema
+}
+
+// Fields of the Passkey.
+func (Passkey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.Bytes("credential_id").
+			MaxLen(1023).
+			Unique().
+			Immutable().
+			Comment("Chosen by the authenticator and sent back on every sign-in"),
+		field.Bytes("public_key").
+			Immutable().
+			Comment("COSE_Key verifying the authenticator's signatures"),
+		field.Uint32("sign_count").
+			Default(0).
+			Comment("The authenticator's signature counter (0 for synced passkeys)"),
+		field.String("name").
+			MaxLen(100).
+			NotEmpty().
+			Comment("Label the user gave it, e.g. 'Work laptop'"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Passkey.
+func (Passkey) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("passkeys").
+			Unique().
+			Required(),
+	}
+}
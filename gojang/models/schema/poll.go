@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Poll holds the schema definition for the Poll entity: a question with a
+// few options, open for votes until it closes.
+type Poll struct {
+	ent.Schema
+}
+
+// Fields of the Poll.
+func (Poll) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("question").
+			NotEmpty().
+			MaxLen(255),
+		field.Strings("options").
+			Comment("Answers in display order; votes refer to them by position"),
+		field.Time("closes_at").
+			Optional().
+			Nillable().
+			Comment("No votes from then on; open until deleted if empty"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Poll.
+func (Poll) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("votes", Vote.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
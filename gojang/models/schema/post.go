@@ -25,6 +25,14 @@ func (Post) Fields() []ent.Field {
 			MaxLen(255),
 		field.Text("body").
 			NotEmpty(),
+		// Flagged for review (e.g., reported by a reader); listed in the admin's moderation queue
+		field.Bool("pending_review").
+			Default(false),
+		// Set while the post is scheduled: it's hidden until then, and the
+		// publish task clears it once the time comes
+		field.Time("publish_at").
+			Optional().
+			Nillable(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
@@ -48,5 +56,6 @@ func (Post) Edges() []ent.Edge {
 func (Post) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Fields("created_at"),
+		index.Fields("publish_at"),
 	}
 }
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Redirect holds the schema definition for the Redirect entity: an old URL
+// path sent on to its new location, for paths no route serves anymore.
+type Redirect struct {
+	ent.Schema
+}
+
+// Fields of the Redirect.
+func (Redirect) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("from_path").
+			Unique().
+			NotEmpty().
+			Comment("Old path, e.g. '/blog/hello-world'"),
+		field.String("to_path").
+			NotEmpty().
+			Comment("New path or absolute URL"),
+		field.Int("status_code").
+			Default(301).
+			Comment("301 or 308 for permanent moves, 302 or 307 for temporary ones"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
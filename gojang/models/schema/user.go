@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
@@ -23,6 +24,14 @@ func (User) Fields() []ent.Field {
 		field.String("email").
 			Unique().
 			NotEmpty(),
+		// Optional login name, used when AUTH_IDENTIFIER is "username" or "both"
+		field.String("username").
+			Optional().
+			Nillable().
+			Unique(),
+		// Profile picture: a storage name (see gojang/storage), shown resized with gojang/images
+		field.String("avatar").
+			Optional(),
 		field.String("password_hash").
 			Sensitive(),
 		field.Bool("is_active").
@@ -47,6 +56,14 @@ func (User) Fields() []ent.Field {
 func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("posts", Post.Type),
+		edge.To("preferences", UserPreference.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("groups", Group.Type),
+		edge.To("login_events", LoginEvent.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("passkeys", Passkey.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("dismissed_announcements", Announcement.Type),
 	}
 }
 
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// UserPreference holds the schema definition for the UserPreference entity.
+type UserPreference struct {
+	ent.Schema
+}
+
+// Fields of the UserPreference.
+func (UserPreference) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("key").
+			NotEmpty().
+			Comment("Preference key (e.g., 'admin.list.post')"),
+		field.Text("value").
+			Comment("Preference value (JSON)"),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the UserPreference.
+func (UserPreference) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("preferences").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the UserPreference.
+func (UserPreference) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("key").
+			Edges("user").
+			Unique(),
+	}
+}
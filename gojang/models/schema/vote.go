@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Vote holds the schema definition for the Vote entity: one voter's answer
+// to a poll.
+type Vote struct {
+	ent.Schema
+}
+
+// Fields of the Vote.
+func (Vote) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.Int("option").
+			NonNegative().
+			Comment("Position of the chosen answer in the poll's options"),
+		field.String("voter").
+			NotEmpty().
+			Comment("user:<id> for signed-in users, guest:<session guest ID> for guests"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Vote.
+func (Vote) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("poll", Poll.Type).
+			Ref("votes").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Vote.
+func (Vote) Indexes() []ent.Index {
+	return []ent.Index{
+		// One vote per voter and poll
+		index.Fields("voter").
+			Edges("poll").
+			Unique(),
+	}
+}
@@ -20,6 +20,10 @@ type User struct {
 	ID uuid.UUID `json:"id,omitempty"`
 	// Email holds the value of the "email" field.
 	Email string `json:"email,omitempty"`
+	// Username holds the value of the "username" field.
+	Username *string `json:"username,omitempty"`
+	// Avatar holds the value of the "avatar" field.
+	Avatar string `json:"avatar,omitempty"`
 	// PasswordHash holds the value of the "password_hash" field.
 	PasswordHash string `json:"-"`
 	// IsActive holds the value of the "is_active" field.
@@ -44,9 +48,19 @@ type User struct {
 type UserEdges struct {
 	// Posts holds the value of the posts edge.
 	Posts []*Post `json:"posts,omitempty"`
+	// Preferences holds the value of the preferences edge.
+	Preferences []*UserPreference `json:"preferences,omitempty"`
+	// Groups holds the value of the groups edge.
+	Groups []*Group `json:"groups,omitempty"`
+	// LoginEvents holds the value of the login_events edge.
+	LoginEvents []*LoginEvent `json:"login_events,omitempty"`
+	// Passkeys holds the value of the passkeys edge.
+	Passkeys []*Passkey `json:"passkeys,omitempty"`
+	// DismissedAnnouncements holds the value of the dismissed_announcements edge.
+	DismissedAnnouncements []*Announcement `json:"dismissed_announcements,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [6]bool
 }
 
 // PostsOrErr returns the Posts value or an error if the edge
@@ -58,6 +72,51 @@ func (e UserEdges) PostsOrErr() ([]*Post, error) {
 	return nil, &NotLoadedError{edge: "posts"}
 }
 
+// PreferencesOrErr returns the Preferences value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PreferencesOrErr() ([]*UserPreference, error) {
+	if e.loadedTypes[1] {
+		return e.Preferences, nil
+	}
+	return nil, &NotLoadedError{edge: "preferences"}
+}
+
+// GroupsOrErr returns the Groups value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) GroupsOrErr() ([]*Group, error) {
+	if e.loadedTypes[2] {
+		return e.Groups, nil
+	}
+	return nil, &NotLoadedError{edge: "groups"}
+}
+
+// LoginEventsOrErr returns the LoginEvents value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) LoginEventsOrErr() ([]*LoginEvent, error) {
+	if e.loadedTypes[3] {
+		return e.LoginEvents, nil
+	}
+	return nil, &NotLoadedError{edge: "login_events"}
+}
+
+// PasskeysOrErr returns the Passkeys value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PasskeysOrErr() ([]*Passkey, error) {
+	if e.loadedTypes[4] {
+		return e.Passkeys, nil
+	}
+	return nil, &NotLoadedError{edge: "passkeys"}
+}
+
+// DismissedAnnouncementsOrErr returns the DismissedAnnouncements value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) DismissedAnnouncementsOrErr() ([]*Announcement, error) {
+	if e.loadedTypes[5] {
+		return e.DismissedAnnouncements, nil
+	}
+	return nil, &NotLoadedError{edge: "dismissed_announcements"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -65,7 +124,7 @@ func (*User) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case user.FieldIsActive, user.FieldIsStaff, user.FieldIsSuperuser:
 			values[i] = new(sql.NullBool)
-		case user.FieldEmail, user.FieldPasswordHash:
+		case user.FieldEmail, user.FieldUsername, user.FieldAvatar, user.FieldPasswordHash:
 			values[i] = new(sql.NullString)
 		case user.FieldCreatedAt, user.FieldUpdatedAt, user.FieldLastLogin:
 			values[i] = new(sql.NullTime)
@@ -98,6 +157,19 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Email = value.String
 			}
+		case user.FieldUsername:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field username", values[i])
+			} else if value.Valid {
+				_m.Username = new(string)
+				*_m.Username = value.String
+			}
+		case user.FieldAvatar:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field avatar", values[i])
+			} else if value.Valid {
+				_m.Avatar = value.String
+			}
 		case user.FieldPasswordHash:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field password_hash", values[i])
@@ -159,6 +231,31 @@ func (_m *User) QueryPosts() *PostQuery {
 	return NewUserClient(_m.config).QueryPosts(_m)
 }
 
+// QueryPreferences queries the "preferences" edge of the User entity.
+func (_m *User) QueryPreferences() *UserPreferenceQuery {
+	return NewUserClient(_m.config).QueryPreferences(_m)
+}
+
+// QueryGroups queries the "groups" edge of the User entity.
+func (_m *User) QueryGroups() *GroupQuery {
+	return NewUserClient(_m.config).QueryGroups(_m)
+}
+
+// QueryLoginEvents queries the "login_events" edge of the User entity.
+func (_m *User) QueryLoginEvents() *LoginEventQuery {
+	return NewUserClient(_m.config).QueryLoginEvents(_m)
+}
+
+// QueryPasskeys queries the "passkeys" edge of the User entity.
+func (_m *User) QueryPasskeys() *PasskeyQuery {
+	return NewUserClient(_m.config).QueryPasskeys(_m)
+}
+
+// QueryDismissedAnnouncements queries the "dismissed_announcements" edge of the User entity.
+func (_m *User) QueryDismissedAnnouncements() *AnnouncementQuery {
+	return NewUserClient(_m.config).QueryDismissedAnnouncements(_m)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -185,6 +282,14 @@ func (_m *User) String() string {
 	builder.WriteString("email=")
 	builder.WriteString(_m.Email)
 	builder.WriteString(", ")
+	if v := _m.Username; v != nil {
+		builder.WriteString("username=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	builder.WriteString("avatar=")
+	builder.WriteString(_m.Avatar)
+	builder.WriteString(", ")
 	builder.WriteString("password_hash=<sensitive>")
 	builder.WriteString(", ")
 	builder.WriteString("is_active=")
@@ -0,0 +1,168 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/user"
+	"github.com/gojangframework/gojang/gojang/models/userpreference"
+	"github.com/google/uuid"
+)
+
+// UserPreference is the model entity for the UserPreference schema.
+type UserPreference struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Preference key (e.g., 'admin.list.post')
+	Key string `json:"key,omitempty"`
+	// Preference value (JSON)
+	Value string `json:"value,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the UserPreferenceQuery when eager-loading is set.
+	Edges            UserPreferenceEdges `json:"edges"`
+	user_preferences *uuid.UUID
+	selectValues     sql.SelectValues
+}
+
+// UserPreferenceEdges holds the relations/edges for other nodes in the graph.
+type UserPreferenceEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e UserPreferenceEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*UserPreference) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case userpreference.FieldKey, userpreference.FieldValue:
+			values[i] = new(sql.NullString)
+		case userpreference.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case userpreference.FieldID:
+			values[i] = new(uuid.UUID)
+		case userpreference.ForeignKeys[0]: // user_preferences
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the UserPreference fields.
+func (_m *UserPreference) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case userpreference.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case userpreference.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				_m.Key = value.String
+			}
+		case userpreference.FieldValue:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field value", values[i])
+			} else if value.Valid {
+				_m.Value = value.String
+			}
+		case userpreference.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case userpreference.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field user_preferences", values[i])
+			} else if value.Valid {
+				_m.user_preferences = new(uuid.UUID)
+				*_m.user_preferences = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// GetValue returns the ent.Value that was dynamically selected and assigned to the UserPreference.
+// This includes values selected through modifiers, order, etc.
+func (_m *UserPreference) GetValue(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the UserPreference entity.
+func (_m *UserPreference) QueryUser() *UserQuery {
+	return NewUserPreferenceClient(_m.config).QueryUser(_m)
+}
+
+// Update returns a builder for updating this UserPreference.
+// Note that you need to call UserPreference.Unwrap() before calling this method if this UserPreference
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *UserPreference) Update() *UserPreferenceUpdateOne {
+	return NewUserPreferenceClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the UserPreference entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *UserPreference) Unwrap() *UserPreference {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("models: UserPreference is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *UserPreference) String() string {
+	var builder strings.Builder
+	builder.WriteString("UserPreference(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("key=")
+	builder.WriteString(_m.Key)
+	builder.WriteString(", ")
+	builder.WriteString("value=")
+	builder.WriteString(_m.Value)
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// UserPreferences is a parsable slice of UserPreference.
+type UserPreferences []*UserPreference
@@ -0,0 +1,170 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/gojangframework/gojang/gojang/models/poll"
+	"github.com/gojangframework/gojang/gojang/models/vote"
+	"github.com/google/uuid"
+)
+
+// Vote is the model entity for the Vote schema.
+type Vote struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Position of the chosen answer in the poll's options
+	Option int `json:"option,omitempty"`
+	// user:<id> for signed-in users, guest:<session guest ID> for guests
+	Voter string `json:"voter,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the VoteQuery when eager-loading is set.
+	Edges        VoteEdges `json:"edges"`
+	poll_votes   *uuid.UUID
+	selectValues sql.SelectValues
+}
+
+// VoteEdges holds the relations/edges for other nodes in the graph.
+type VoteEdges struct {
+	// Poll holds the value of the poll edge.
+	Poll *Poll `json:"poll,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// PollOrErr returns the Poll value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e VoteEdges) PollOrErr() (*Poll, error) {
+	if e.Poll != nil {
+		return e.Poll, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: poll.Label}
+	}
+	return nil, &NotLoadedError{edge: "poll"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Vote) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case vote.FieldOption:
+			values[i] = new(sql.NullInt64)
+		case vote.FieldVoter:
+			values[i] = new(sql.NullString)
+		case vote.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case vote.FieldID:
+			values[i] = new(uuid.UUID)
+		case vote.ForeignKeys[0]: // poll_votes
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Vote fields.
+func (_m *Vote) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case vote.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case vote.FieldOption:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field option", values[i])
+			} else if value.Valid {
+				_m.Option = int(value.Int64)
+			}
+		case vote.FieldVoter:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field voter", values[i])
+			} else if value.Valid {
+				_m.Voter = value.String
+			}
+		case vote.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case vote.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field poll_votes", values[i])
+			} else if value.Valid {
+				_m.poll_votes = new(uuid.UUID)
+				*_m.poll_votes = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Vote.
+// This includes values selected through modifiers, order, etc.
+func (_m *Vote) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryPoll queries the "poll" edge of the Vote entity.
+func (_m *Vote) QueryPoll() *PollQuery {
+	return NewVoteClient(_m.config).QueryPoll(_m)
+}
+
+// Update returns a builder for updating this Vote.
+// Note that you need to call Vote.Unwrap() before calling this method if this Vote
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Vote) Update() *VoteUpdateOne {
+	return NewVoteClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Vote entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Vote) Unwrap() *Vote {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("models: Vote is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Vote) String() string {
+	var builder strings.Builder
+	builder.WriteString("Vote(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("option=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Option))
+	builder.WriteString(", ")
+	builder.WriteString("voter=")
+	builder.WriteString(_m.Voter)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Votes is a parsable slice of Vote.
+type Votes []*Vote
@@ -0,0 +1,112 @@
+// Code generated by ent, DO NOT EDIT.
+
+package vote
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the vote type in the database.
+	Label = "vote"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldOption holds the string denoting the option field in the database.
+	FieldOption = "option"
+	// FieldVoter holds the string denoting the voter field in the database.
+	FieldVoter = "voter"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// EdgePoll holds the string denoting the poll edge name in mutations.
+	EdgePoll = "poll"
+	// Table holds the table name of the vote in the database.
+	Table = "votes"
+	// PollTable is the table that holds the poll relation/edge.
+	PollTable = "votes"
+	// PollInverseTable is the table name for the Poll entity.
+	// It exists in this package in order to avoid circular dependency with the "poll" package.
+	PollInverseTable = "polls"
+	// PollColumn is the table column denoting the poll relation/edge.
+	PollColumn = "poll_votes"
+)
+
+// Columns holds all SQL columns for vote fields.
+var Columns = []string{
+	FieldID,
+	FieldOption,
+	FieldVoter,
+	FieldCreatedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "votes"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"poll_votes",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// OptionValidator is a validator for the "option" field. It is called by the builders before save.
+	OptionValidator func(int) error
+	// VoterValidator is a validator for the "voter" field. It is called by the builders before save.
+	VoterValidator func(string) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the Vote queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByOption orders the results by the option field.
+func ByOption(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOption, opts...).ToFunc()
+}
+
+// ByVoter orders the results by the voter field.
+func ByVoter(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVoter, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByPollField orders the results by poll field.
+func ByPollField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newPollStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newPollStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(PollInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, PollTable, PollColumn),
+	)
+}
@@ -0,0 +1,255 @@
+// Code generated by ent, DO NOT EDIT.
+
+package vote
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldID, id))
+}
+
+// Option applies equality check predicate on the "option" field. It's identical to OptionEQ.
+func Option(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldOption, v))
+}
+
+// Voter applies equality check predicate on the "voter" field. It's identical to VoterEQ.
+func Voter(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldVoter, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// OptionEQ applies the EQ predicate on the "option" field.
+func OptionEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldOption, v))
+}
+
+// OptionNEQ applies the NEQ predicate on the "option" field.
+func OptionNEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldOption, v))
+}
+
+// OptionIn applies the In predicate on the "option" field.
+func OptionIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldOption, vs...))
+}
+
+// OptionNotIn applies the NotIn predicate on the "option" field.
+func OptionNotIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldOption, vs...))
+}
+
+// OptionGT applies the GT predicate on the "option" field.
+func OptionGT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldOption, v))
+}
+
+// OptionGTE applies the GTE predicate on the "option" field.
+func OptionGTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldOption, v))
+}
+
+// OptionLT applies the LT predicate on the "option" field.
+func OptionLT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldOption, v))
+}
+
+// OptionLTE applies the LTE predicate on the "option" field.
+func OptionLTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldOption, v))
+}
+
+// VoterEQ applies the EQ predicate on the "voter" field.
+func VoterEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldVoter, v))
+}
+
+// VoterNEQ applies the NEQ predicate on the "voter" field.
+func VoterNEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldVoter, v))
+}
+
+// VoterIn applies the In predicate on the "voter" field.
+func VoterIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldVoter, vs...))
+}
+
+// VoterNotIn applies the NotIn predicate on the "voter" field.
+func VoterNotIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldVoter, vs...))
+}
+
+// VoterGT applies the GT predicate on the "voter" field.
+func VoterGT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldVoter, v))
+}
+
+// VoterGTE applies the GTE predicate on the "voter" field.
+func VoterGTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldVoter, v))
+}
+
+// VoterLT applies the LT predicate on the "voter" field.
+func VoterLT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldVoter, v))
+}
+
+// VoterLTE applies the LTE predicate on the "voter" field.
+func VoterLTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldVoter, v))
+}
+
+// VoterContains applies the Contains predicate on the "voter" field.
+func VoterContains(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContains(FieldVoter, v))
+}
+
+// VoterHasPrefix applies the HasPrefix predicate on the "voter" field.
+func VoterHasPrefix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasPrefix(FieldVoter, v))
+}
+
+// VoterHasSuffix applies the HasSuffix predicate on the "voter" field.
+func VoterHasSuffix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasSuffix(FieldVoter, v))
+}
+
+// VoterEqualFold applies the EqualFold predicate on the "voter" field.
+func VoterEqualFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEqualFold(FieldVoter, v))
+}
+
+// VoterContainsFold applies the ContainsFold predicate on the "voter" field.
+func VoterContainsFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContainsFold(FieldVoter, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasPoll applies the HasEdge predicate on the "poll" edge.
+func HasPoll() predicate.Vote {
+	return predicate.Vote(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, PollTable, PollColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasPollWith applies the HasEdge predicate on the "poll" edge with a given conditions (other predicates).
+func HasPollWith(preds ...predicate.Poll) predicate.Vote {
+	return predicate.Vote(func(s *sql.Selector) {
+		step := newPollStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Vote) predicate.Vote {
+	return predicate.Vote(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Vote) predicate.Vote {
+	return predicate.Vote(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Vote) predicate.Vote {
+	return predicate.Vote(sql.NotPredicates(p))
+}
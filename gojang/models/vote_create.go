@@ -0,0 +1,294 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/poll"
+	"github.com/gojangframework/gojang/gojang/models/vote"
+	"github.com/google/uuid"
+)
+
+// VoteCreate is the builder for creating a Vote entity.
+type VoteCreate struct {
+	config
+	mutation *VoteMutation
+	hooks    []Hook
+}
+
+// SetOption sets the "option" field.
+func (_c *VoteCreate) SetOption(v int) *VoteCreate {
+	_c.mutation.SetOption(v)
+	return _c
+}
+
+// SetVoter sets the "voter" field.
+func (_c *VoteCreate) SetVoter(v string) *VoteCreate {
+	_c.mutation.SetVoter(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *VoteCreate) SetCreatedAt(v time.Time) *VoteCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *VoteCreate) SetNillableCreatedAt(v *time.Time) *VoteCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *VoteCreate) SetID(v uuid.UUID) *VoteCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *VoteCreate) SetNillableID(v *uuid.UUID) *VoteCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (_c *VoteCreate) SetPollID(id uuid.UUID) *VoteCreate {
+	_c.mutation.SetPollID(id)
+	return _c
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_c *VoteCreate) SetPoll(v *Poll) *VoteCreate {
+	return _c.SetPollID(v.ID)
+}
+
+// Mutation returns the VoteMutation object of the builder.
+func (_c *VoteCreate) Mutation() *VoteMutation {
+	return _c.mutation
+}
+
+// Save creates the Vote in the database.
+func (_c *VoteCreate) Save(ctx context.Context) (*Vote, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *VoteCreate) SaveX(ctx context.Context) *Vote {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *VoteCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *VoteCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *VoteCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := vote.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := vote.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *VoteCreate) check() error {
+	if _, ok := _c.mutation.Option(); !ok {
+		return &ValidationError{Name: "option", err: errors.New(`models: missing required field "Vote.option"`)}
+	}
+	if v, ok := _c.mutation.Option(); ok {
+		if err := vote.OptionValidator(v); err != nil {
+			return &ValidationError{Name: "option", err: fmt.Errorf(`models: validator failed for field "Vote.option": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Voter(); !ok {
+		return &ValidationError{Name: "voter", err: errors.New(`models: missing required field "Vote.voter"`)}
+	}
+	if v, ok := _c.mutation.Voter(); ok {
+		if err := vote.VoterValidator(v); err != nil {
+			return &ValidationError{Name: "voter", err: fmt.Errorf(`models: validator failed for field "Vote.voter": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`models: missing required field "Vote.created_at"`)}
+	}
+	if len(_c.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`models: missing required edge "Vote.poll"`)}
+	}
+	return nil
+}
+
+func (_c *VoteCreate) sqlSave(ctx context.Context) (*Vote, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Vote{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(vote.Table, sqlgraph.NewFieldSpec(vote.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Option(); ok {
+		_spec.SetField(vote.FieldOption, field.TypeInt, value)
+		_node.Option = value
+	}
+	if value, ok := _c.mutation.Voter(); ok {
+		_spec.SetField(vote.FieldVoter, field.TypeString, value)
+		_node.Voter = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.PollTable,
+			Columns: []string{vote.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.poll_votes = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// VoteCreateBulk is the builder for creating many Vote entities in bulk.
+type VoteCreateBulk struct {
+	config
+	err      error
+	builders []*VoteCreate
+}
+
+// Save creates the Vote entities in the database.
+func (_c *VoteCreateBulk) Save(ctx context.Context) ([]*Vote, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Vote, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*VoteMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *VoteCreateBulk) SaveX(ctx context.Context) []*Vote {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *VoteCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *VoteCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
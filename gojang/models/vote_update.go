@@ -0,0 +1,399 @@
+// Code generated by ent, DO NOT EDIT.
+
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/gojangframework/gojang/gojang/models/poll"
+	"github.com/gojangframework/gojang/gojang/models/predicate"
+	"github.com/gojangframework/gojang/gojang/models/vote"
+	"github.com/google/uuid"
+)
+
+// VoteUpdate is the builder for updating Vote entities.
+type VoteUpdate struct {
+	config
+	hooks    []Hook
+	mutation *VoteMutation
+}
+
+// Where appends a list predicates to the VoteUpdate builder.
+func (_u *VoteUpdate) Where(ps ...predicate.Vote) *VoteUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetOption sets the "option" field.
+func (_u *VoteUpdate) SetOption(v int) *VoteUpdate {
+	_u.mutation.ResetOption()
+	_u.mutation.SetOption(v)
+	return _u
+}
+
+// SetNillableOption sets the "option" field if the given value is not nil.
+func (_u *VoteUpdate) SetNillableOption(v *int) *VoteUpdate {
+	if v != nil {
+		_u.SetOption(*v)
+	}
+	return _u
+}
+
+// AddOption adds value to the "option" field.
+func (_u *VoteUpdate) AddOption(v int) *VoteUpdate {
+	_u.mutation.AddOption(v)
+	return _u
+}
+
+// SetVoter sets the "voter" field.
+func (_u *VoteUpdate) SetVoter(v string) *VoteUpdate {
+	_u.mutation.SetVoter(v)
+	return _u
+}
+
+// SetNillableVoter sets the "voter" field if the given value is not nil.
+func (_u *VoteUpdate) SetNillableVoter(v *string) *VoteUpdate {
+	if v != nil {
+		_u.SetVoter(*v)
+	}
+	return _u
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (_u *VoteUpdate) SetPollID(id uuid.UUID) *VoteUpdate {
+	_u.mutation.SetPollID(id)
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *VoteUpdate) SetPoll(v *Poll) *VoteUpdate {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the VoteMutation object of the builder.
+func (_u *VoteUpdate) Mutation() *VoteMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *VoteUpdate) ClearPoll() *VoteUpdate {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *VoteUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *VoteUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *VoteUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *VoteUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *VoteUpdate) check() error {
+	if v, ok := _u.mutation.Option(); ok {
+		if err := vote.OptionValidator(v); err != nil {
+			return &ValidationError{Name: "option", err: fmt.Errorf(`models: validator failed for field "Vote.option": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Voter(); ok {
+		if err := vote.VoterValidator(v); err != nil {
+			return &ValidationError{Name: "voter", err: fmt.Errorf(`models: validator failed for field "Vote.voter": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`models: clearing a required unique edge "Vote.poll"`)
+	}
+	return nil
+}
+
+func (_u *VoteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(vote.Table, vote.Columns, sqlgraph.NewFieldSpec(vote.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Option(); ok {
+		_spec.SetField(vote.FieldOption, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedOption(); ok {
+		_spec.AddField(vote.FieldOption, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Voter(); ok {
+		_spec.SetField(vote.FieldVoter, field.TypeString, value)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.PollTable,
+			Columns: []string{vote.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.PollTable,
+			Columns: []string{vote.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{vote.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// VoteUpdateOne is the builder for updating a single Vote entity.
+type VoteUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *VoteMutation
+}
+
+// SetOption sets the "option" field.
+func (_u *VoteUpdateOne) SetOption(v int) *VoteUpdateOne {
+	_u.mutation.ResetOption()
+	_u.mutation.SetOption(v)
+	return _u
+}
+
+// SetNillableOption sets the "option" field if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableOption(v *int) *VoteUpdateOne {
+	if v != nil {
+		_u.SetOption(*v)
+	}
+	return _u
+}
+
+// AddOption adds value to the "option" field.
+func (_u *VoteUpdateOne) AddOption(v int) *VoteUpdateOne {
+	_u.mutation.AddOption(v)
+	return _u
+}
+
+// SetVoter sets the "voter" field.
+func (_u *VoteUpdateOne) SetVoter(v string) *VoteUpdateOne {
+	_u.mutation.SetVoter(v)
+	return _u
+}
+
+// SetNillableVoter sets the "voter" field if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableVoter(v *string) *VoteUpdateOne {
+	if v != nil {
+		_u.SetVoter(*v)
+	}
+	return _u
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (_u *VoteUpdateOne) SetPollID(id uuid.UUID) *VoteUpdateOne {
+	_u.mutation.SetPollID(id)
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *VoteUpdateOne) SetPoll(v *Poll) *VoteUpdateOne {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the VoteMutation object of the builder.
+func (_u *VoteUpdateOne) Mutation() *VoteMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *VoteUpdateOne) ClearPoll() *VoteUpdateOne {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Where appends a list predicates to the VoteUpdate builder.
+func (_u *VoteUpdateOne) Where(ps ...predicate.Vote) *VoteUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *VoteUpdateOne) Select(field string, fields ...string) *VoteUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Vote entity.
+func (_u *VoteUpdateOne) Save(ctx context.Context) (*Vote, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *VoteUpdateOne) SaveX(ctx context.Context) *Vote {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *VoteUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *VoteUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *VoteUpdateOne) check() error {
+	if v, ok := _u.mutation.Option(); ok {
+		if err := vote.OptionValidator(v); err != nil {
+			return &ValidationError{Name: "option", err: fmt.Errorf(`models: validator failed for field "Vote.option": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Voter(); ok {
+		if err := vote.VoterValidator(v); err != nil {
+			return &ValidationError{Name: "voter", err: fmt.Errorf(`models: validator failed for field "Vote.voter": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`models: clearing a required unique edge "Vote.poll"`)
+	}
+	return nil
+}
+
+func (_u *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(vote.Table, vote.Columns, sqlgraph.NewFieldSpec(vote.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`models: missing "Vote.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, vote.FieldID)
+		for _, f := range fields {
+			if !vote.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("models: invalid field %q for query", f)}
+			}
+			if f != vote.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Option(); ok {
+		_spec.SetField(vote.FieldOption, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedOption(); ok {
+		_spec.AddField(vote.FieldOption, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Voter(); ok {
+		_spec.SetField(vote.FieldVoter, field.TypeString, value)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.PollTable,
+			Columns: []string{vote.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.PollTable,
+			Columns: []string{vote.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Vote{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{vote.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,323 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// Routes serves the provider's endpoints (mounted at /oidc). Discovery is
+// served separately at /.well-known/openid-configuration.
+func Routes(p *Provider, sm *scs.SessionManager) chi.Router {
+	r := chi.NewRouter()
+
+	// Users sign in here first, then come back to the authorize URL
+	r.With(middleware.RequireAuth(sm, p.Client)).Get("/authorize", p.Authorize)
+
+	// Called by relying parties' servers, so there's no session or CSRF token
+	r.Post("/token", p.Token)
+	r.Get("/userinfo", p.UserInfo)
+	r.Post("/userinfo", p.UserInfo)
+	r.Get("/jwks", p.JWKS)
+
+	return r
+}
+
+// grant is what an authorization code stands for until it's redeemed
+type grant struct {
+	ClientID    string    `json:"client_id"`
+	RedirectURI string    `json:"redirect_uri"` // Empty when the request left it out
+	UserID      uuid.UUID `json:"user_id"`
+	Scope       string    `json:"scope"`
+	Nonce       string    `json:"nonce,omitempty"`
+	Challenge   string    `json:"code_challenge,omitempty"`
+	AuthTime    int64     `json:"auth_time"`
+}
+
+// Discovery serves the provider metadata relying parties configure themselves from
+func (p *Provider) Discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.Issuer,
+		"authorization_endpoint":                p.Issuer + "/oidc/authorize",
+		"token_endpoint":                        p.Issuer + "/oidc/token",
+		"userinfo_endpoint":                     p.Issuer + "/oidc/userinfo",
+		"jwks_uri":                              p.Issuer + "/oidc/jwks",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{ScopeOpenID, ScopeEmail, ScopeProfile},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported":                      []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "email", "preferred_username"},
+	})
+}
+
+// JWKS serves the public key that tokens are signed with
+func (p *Provider) JWKS(w http.ResponseWriter, r *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": p.keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// Authorize issues an authorization code for the signed-in user and sends
+// them back to the relying party
+func (p *Provider) Authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	// Without a known client and redirect URI there's nowhere safe to send errors
+	rp, ok := p.parties[q.Get("client_id")]
+	if !ok {
+		http.Error(w, "Unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirectURI := q.Get("redirect_uri")
+	target := redirectURI
+	if target == "" && len(rp.RedirectURIs) == 1 {
+		target = rp.RedirectURIs[0]
+	}
+	if !slices.Contains(rp.RedirectURIs, target) {
+		http.Error(w, "redirect_uri is not registered for this client", http.StatusBadRequest)
+		return
+	}
+
+	state := q.Get("state")
+	if q.Get("response_type") != "code" {
+		redirectError(w, r, target, state, "unsupported_response_type", "only the authorization code flow is supported")
+		return
+	}
+	var scopes []string
+	for _, s := range strings.Fields(q.Get("scope")) {
+		if (s == ScopeOpenID || s == ScopeEmail || s == ScopeProfile) && !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	if !slices.Contains(scopes, ScopeOpenID) {
+		redirectError(w, r, target, state, "invalid_scope", "the openid scope is required")
+		return
+	}
+	challenge := q.Get("code_challenge")
+	if challenge != "" && q.Get("code_challenge_method") != "S256" {
+		redirectError(w, r, target, state, "invalid_request", "code_challenge_method must be S256")
+		return
+	}
+	if challenge == "" && rp.Secret == "" {
+		redirectError(w, r, target, state, "invalid_request", "public clients must use PKCE")
+		return
+	}
+
+	user := middleware.GetUser(r.Context())
+	authTime := p.now()
+	if user.LastLogin != nil {
+		authTime = *user.LastLogin
+	}
+	code := randomToken()
+	data, _ := json.Marshal(grant{
+		ClientID:    rp.ID,
+		RedirectURI: redirectURI,
+		UserID:      user.ID,
+		Scope:       strings.Join(scopes, " "),
+		Nonce:       q.Get("nonce"),
+		Challenge:   challenge,
+		AuthTime:    authTime.Unix(),
+	})
+	p.Codes.Set(codeKey(code), data, CodeTTL)
+
+	utils.Infow("oidc.authorized", "client_id", rp.ID, "user_id", user.ID)
+	redirectWith(w, r, target, url.Values{"code": {code}, "state": {state}})
+}
+
+// Token exchanges an authorization code for an ID token and an access token
+func (p *Provider) Token(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	rp, ok := p.authenticate(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oidc"`)
+		tokenError(w, http.StatusUnauthorized, "invalid_client", "unknown client or wrong secret")
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+
+	// Codes are single use, so they're taken out before anything else is
+	// checked; of two requests redeeming one code, only one gets it
+	data, found := p.Codes.Take(codeKey(r.PostFormValue("code")))
+	var g grant
+	if !found || json.Unmarshal(data, &g) != nil || g.ClientID != rp.ID || g.RedirectURI != r.PostFormValue("redirect_uri") {
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "the code is invalid, expired or was issued to another client")
+		return
+	}
+	if g.Challenge != "" {
+		sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != g.Challenge {
+			tokenError(w, http.StatusBadRequest, "invalid_grant", "code_verifier doesn't match the code_challenge")
+			return
+		}
+	}
+
+	user, err := p.Client.User.Get(r.Context(), g.UserID)
+	if err != nil || !user.IsActive {
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "the user can no longer sign in")
+		return
+	}
+
+	now := p.now()
+	exp := now.Add(TokenTTL).Unix()
+	idClaims := userClaims(user, g.Scope)
+	idClaims["iss"] = p.Issuer
+	idClaims["aud"] = rp.ID
+	idClaims["iat"] = now.Unix()
+	idClaims["exp"] = exp
+	idClaims["auth_time"] = g.AuthTime
+	if g.Nonce != "" {
+		idClaims["nonce"] = g.Nonce
+	}
+	idToken, err := p.sign(typeIDToken, idClaims)
+	if err != nil {
+		utils.Errorw("oidc.sign_failed", "client_id", rp.ID, "error", err)
+		tokenError(w, http.StatusInternalServerError, "server_error", "")
+		return
+	}
+	accessToken, err := p.sign(typeAccessToken, map[string]interface{}{
+		"iss":       p.Issuer,
+		"sub":       user.ID.String(),
+		"aud":       p.Issuer,
+		"client_id": rp.ID,
+		"scope":     g.Scope,
+		"iat":       now.Unix(),
+		"exp":       exp,
+		"jti":       randomToken(),
+	})
+	if err != nil {
+		utils.Errorw("oidc.sign_failed", "client_id", rp.ID, "error", err)
+		tokenError(w, http.StatusInternalServerError, "server_error", "")
+		return
+	}
+
+	utils.Infow("oidc.token_issued", "client_id", rp.ID, "user_id", user.ID)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int64(TokenTTL.Seconds()),
+		"id_token":     idToken,
+		"scope":        g.Scope,
+	})
+}
+
+// UserInfo returns the claims of the user an access token was issued for
+func (p *Provider) UserInfo(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	claims, err := p.verify(strings.TrimSpace(token), typeAccessToken)
+	if !ok || err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	sub, _ := claims["sub"].(string)
+	scope, _ := claims["scope"].(string)
+	var user *models.User
+	if id, err := uuid.Parse(sub); err == nil {
+		user, _ = p.Client.User.Get(r.Context(), id)
+	}
+	if user == nil || !user.IsActive {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, userClaims(user, scope))
+}
+
+// authenticate returns the relying party making a token request, checking
+// its secret from HTTP Basic auth or the form. Public clients send no secret.
+func (p *Provider) authenticate(r *http.Request) (RelyingParty, bool) {
+	id, secret, basic := r.BasicAuth()
+	if basic {
+		// Basic credentials are form-encoded first (RFC 6749, section 2.3.1)
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		id, secret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+
+	rp, ok := p.parties[id]
+	if !ok || subtle.ConstantTimeCompare([]byte(secret), []byte(rp.Secret)) != 1 {
+		return RelyingParty{}, false
+	}
+	return rp, true
+}
+
+// userClaims returns the user's claims allowed by scope
+func userClaims(user *models.User, scope string) map[string]interface{} {
+	claims := map[string]interface{}{"sub": user.ID.String()}
+	scopes := strings.Fields(scope)
+	if slices.Contains(scopes, ScopeEmail) {
+		claims["email"] = user.Email
+	}
+	if slices.Contains(scopes, ScopeProfile) && user.Username != nil {
+		claims["preferred_username"] = *user.Username
+	}
+	return claims
+}
+
+func codeKey(code string) string {
+	return "oidc:code:" + code
+}
+
+// redirectWith sends the browser to target with params added to its query
+func redirectWith(w http.ResponseWriter, r *http.Request, target string, params url.Values) {
+	u, _ := url.Parse(target) // Registered URIs are checked at startup
+	q := u.Query()
+	for k, v := range params {
+		if v[0] != "" {
+			q[k] = v
+		}
+	}
+	u.RawQuery = q.Encode()
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+// redirectError reports an authorization error to the relying party
+func redirectError(w http.ResponseWriter, r *http.Request, target, state, code, description string) {
+	redirectWith(w, r, target, url.Values{"error": {code}, "error_description": {description}, "state": {state}})
+}
+
+// tokenError writes a token endpoint error (RFC 6749, section 5.2)
+func tokenError(w http.ResponseWriter, status int, code, description string) {
+	body := map[string]string{"error": code}
+	if description != "" {
+		body["error_description"] = description
+	}
+	writeJSON(w, status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+func newTestProvider(t *testing.T) (*Provider, *models.User) {
+	t.Helper()
+	client := testdb.Open(t)
+	user := client.User.Create().
+		SetEmail("jane@example.com").SetUsername("jane").SetPasswordHash("x").
+		SaveX(context.Background())
+
+	p := New("https://accounts.example.com/", testKey(t), client,
+		RelyingParty{ID: "wiki", Secret: "s3cret", RedirectURIs: []string{"https://wiki.example.com/cb"}},
+		RelyingParty{ID: "cli", RedirectURIs: []string{"http://127.0.0.1:8400/cb"}},
+	)
+	return p, user
+}
+
+// authorize runs the authorize endpoint for user and returns the redirect
+func authorize(p *Provider, user *models.User, query string) *url.URL {
+	r := httptest.NewRequest(http.MethodGet, "/oidc/authorize?"+query, nil)
+	r = r.WithContext(middleware.WithUser(r.Context(), user))
+	w := httptest.NewRecorder()
+	p.Authorize(w, r)
+	if w.Code != http.StatusFound {
+		return nil
+	}
+	u, _ := url.Parse(w.Header().Get("Location"))
+	return u
+}
+
+// token posts form to the token endpoint
+func token(p *Provider, form url.Values, user, pass string) (int, map[string]interface{}) {
+	r := httptest.NewRequest(http.MethodPost, "/oidc/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if user != "" {
+		r.SetBasicAuth(user, pass)
+	}
+	w := httptest.NewRecorder()
+	p.Token(w, r)
+	var body map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&body)
+	return w.Code, body
+}
+
+// TestFlow tests the authorization code flow from authorize to userinfo
+func TestFlow(t *testing.T) {
+	p, user := newTestProvider(t)
+
+	loc := authorize(p, user, "response_type=code&client_id=wiki&redirect_uri=https://wiki.example.com/cb&scope=openid+email+profile+admin&state=xyz&nonce=n1")
+	if loc == nil || loc.Host != "wiki.example.com" || loc.Query().Get("state") != "xyz" {
+		t.Fatalf("Unexpected redirect %v", loc)
+	}
+	code := loc.Query().Get("code")
+
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {"https://wiki.example.com/cb"}}
+	if status, body := token(p, form, "wiki", "wrong"); status != http.StatusUnauthorized || body["error"] != "invalid_client" {
+		t.Errorf("Wrong secret: %d %v", status, body)
+	}
+	status, body := token(p, form, "wiki", "s3cret")
+	if status != http.StatusOK || body["token_type"] != "Bearer" || body["scope"] != "openid email profile" {
+		t.Fatalf("Token: %d %v", status, body)
+	}
+
+	// The ID token is for the wiki, with the nonce and the scoped claims
+	claims, err := p.verify(body["id_token"].(string), typeIDToken)
+	if err != nil {
+		t.Fatalf("ID token: %v", err)
+	}
+	if claims["aud"] != "wiki" || claims["sub"] != user.ID.String() || claims["nonce"] != "n1" ||
+		claims["email"] != "jane@example.com" || claims["preferred_username"] != "jane" || claims["iss"] != "https://accounts.example.com" {
+		t.Errorf("Unexpected ID token claims %v", claims)
+	}
+
+	// Codes can only be redeemed once
+	if status, body := token(p, form, "wiki", "s3cret"); status != http.StatusBadRequest || body["error"] != "invalid_grant" {
+		t.Errorf("Reused code: %d %v", status, body)
+	}
+
+	userinfo := func(tok string) (int, map[string]interface{}) {
+		r := httptest.NewRequest(http.MethodGet, "/oidc/userinfo", nil)
+		r.Header.Set("Authorization", "Bearer "+tok)
+		w := httptest.NewRecorder()
+		p.UserInfo(w, r)
+		var body map[string]interface{}
+		json.NewDecoder(w.Body).Decode(&body)
+		return w.Code, body
+	}
+	if status, info := userinfo(body["access_token"].(string)); status != http.StatusOK || info["email"] != "jane@example.com" {
+		t.Errorf("UserInfo: %d %v", status, info)
+	}
+	if status, _ := userinfo(body["id_token"].(string)); status != http.StatusUnauthorized {
+		t.Errorf("Expected an ID token to be refused as an access token, got %d", status)
+	}
+
+	// Tokens expire, and stop working for deactivated users
+	p.now = func() time.Time { return time.Now().Add(TokenTTL + time.Minute) }
+	if status, _ := userinfo(body["access_token"].(string)); status != http.StatusUnauthorized {
+		t.Errorf("Expected an expired token to be refused, got %d", status)
+	}
+	p.now = time.Now
+	user.Update().SetIsActive(false).ExecX(context.Background())
+	if status, _ := userinfo(body["access_token"].(string)); status != http.StatusUnauthorized {
+		t.Errorf("Expected a deactivated user's token to be refused, got %d", status)
+	}
+}
+
+// TestFlow_PKCE tests that public clients must prove they started the flow
+func TestFlow_PKCE(t *testing.T) {
+	p, user := newTestProvider(t)
+
+	if loc := authorize(p, user, "response_type=code&client_id=cli&scope=openid"); loc == nil || loc.Query().Get("error") != "invalid_request" {
+		t.Errorf("Expected public clients without PKCE to be refused, got %v", loc)
+	}
+
+	verifier := "a-long-random-verifier-from-the-client-0123456789"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	loc := authorize(p, user, "response_type=code&client_id=cli&scope=openid&code_challenge_method=S256&code_challenge="+challenge)
+	if loc == nil || loc.Query().Get("code") == "" {
+		t.Fatalf("Unexpected redirect %v", loc)
+	}
+
+	form := url.Values{"grant_type": {"authorization_code"}, "client_id": {"cli"}, "code": {loc.Query().Get("code")}, "code_verifier": {"wrong"}}
+	if status, _ := token(p, form, "", ""); status != http.StatusBadRequest {
+		t.Errorf("Expected a wrong verifier to be refused, got %d", status)
+	}
+
+	loc = authorize(p, user, "response_type=code&client_id=cli&scope=openid&code_challenge_method=S256&code_challenge="+challenge)
+	form.Set("code", loc.Query().Get("code"))
+	form.Set("code_verifier", verifier)
+	if status, body := token(p, form, "", ""); status != http.StatusOK || body["id_token"] == nil {
+		t.Errorf("Token: %d %v", status, body)
+	}
+}
+
+// TestAuthorize_Errors tests that unknown clients and redirect URIs get an error page, not a redirect
+func TestAuthorize_Errors(t *testing.T) {
+	p, user := newTestProvider(t)
+	for _, query := range []string{
+		"response_type=code&client_id=nope&scope=openid",
+		"response_type=code&client_id=wiki&scope=openid&redirect_uri=https://evil.example.com/cb",
+	} {
+		if loc := authorize(p, user, query); loc != nil {
+			t.Errorf("%s: redirected to %v", query, loc)
+		}
+	}
+	if loc := authorize(p, user, "response_type=code&client_id=wiki&scope=email"); loc == nil || loc.Query().Get("error") != "invalid_scope" {
+		t.Errorf("Expected invalid_scope without openid, got %v", loc)
+	}
+}
+
+func TestDiscoveryAndJWKS(t *testing.T) {
+	p, _ := newTestProvider(t)
+
+	w := httptest.NewRecorder()
+	p.Discovery(w, httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil))
+	var meta map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&meta)
+	if meta["issuer"] != "https://accounts.example.com" || meta["jwks_uri"] != "https://accounts.example.com/oidc/jwks" {
+		t.Errorf("Unexpected metadata %v", meta)
+	}
+
+	w = httptest.NewRecorder()
+	p.JWKS(w, httptest.NewRequest(http.MethodGet, "/oidc/jwks", nil))
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	json.NewDecoder(w.Body).Decode(&set)
+	if len(set.Keys) != 1 || set.Keys[0]["kid"] != p.keyID || set.Keys[0]["e"] != "AQAB" {
+		t.Errorf("Unexpected key set %v", set)
+	}
+}
+
+// TestToken_ConcurrentRedeem tests that of several requests redeeming one
+// code at once, only one gets tokens
+func TestToken_ConcurrentRedeem(t *testing.T) {
+	p, user := newTestProvider(t)
+	loc := authorize(p, user, "response_type=code&client_id=wiki&redirect_uri=https://wiki.example.com/cb&scope=openid")
+	if loc == nil {
+		t.Fatal("Expected a redirect with a code")
+	}
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {loc.Query().Get("code")}, "redirect_uri": {"https://wiki.example.com/cb"}}
+
+	const requests = 10
+	statuses := make([]int, requests)
+	var wg sync.WaitGroup
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i], _ = token(p, form, "wiki", "s3cret")
+		}()
+	}
+	wg.Wait()
+
+	redeemed := 0
+	for _, status := range statuses {
+		if status == http.StatusOK {
+			redeemed++
+		} else if status != http.StatusBadRequest {
+			t.Errorf("Unexpected status %d", status)
+		}
+	}
+	if redeemed != 1 {
+		t.Errorf("Expected the code to be redeemed once, got %d", redeemed)
+	}
+}
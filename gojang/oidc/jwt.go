@@ -0,0 +1,86 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Token types, set as "typ" in the JWT header so an ID token can't be
+// presented as an access token
+const (
+	typeIDToken     = "JWT"
+	typeAccessToken = "at+jwt"
+)
+
+var errInvalidToken = errors.New("oidc: invalid token")
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// sign returns claims as a compact RS256 JWT of the given type
+func (p *Provider) sign(typ string, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: "RS256", Typ: typ, Kid: p.keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// verify checks a JWT signed by p and returns its claims, if it has the given
+// type, was issued here and hasn't expired
+func (p *Provider) verify(token, typ string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidToken
+	}
+	var header jwtHeader
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(raw, &header) != nil {
+		return nil, errInvalidToken
+	}
+	if header.Alg != "RS256" || header.Typ != typ || header.Kid != p.keyID {
+		return nil, errInvalidToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(&p.key.PublicKey, crypto.SHA256, sum[:], sig) != nil {
+		return nil, errInvalidToken
+	}
+
+	var claims map[string]interface{}
+	raw, err = base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(raw, &claims) != nil {
+		return nil, errInvalidToken
+	}
+	if iss, _ := claims["iss"].(string); iss != p.Issuer {
+		return nil, errInvalidToken
+	}
+	if exp, _ := claims["exp"].(float64); int64(exp) <= p.now().Unix() {
+		return nil, errInvalidToken
+	}
+	return claims, nil
+}
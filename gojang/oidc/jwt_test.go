@@ -0,0 +1,37 @@
+package oidc
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestVerify tests that tokens are refused when tampered with, of the wrong type or from another issuer
+func TestVerify(t *testing.T) {
+	p := New("https://accounts.example.com", testKey(t), nil)
+	tok, err := p.sign(typeAccessToken, map[string]interface{}{"iss": p.Issuer, "sub": "1", "exp": time.Now().Add(time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims, err := p.verify(tok, typeAccessToken); err != nil || claims["sub"] != "1" {
+		t.Fatalf("verify = %v, %v", claims, err)
+	}
+
+	parts := strings.Split(tok, ".")
+	forged, _ := p.sign(typeAccessToken, map[string]interface{}{"iss": p.Issuer, "sub": "2", "exp": time.Now().Add(time.Minute).Unix()})
+	other := New("https://other.example.com", testKey(t), nil)
+	foreign, _ := other.sign(typeAccessToken, map[string]interface{}{"iss": other.Issuer, "sub": "1", "exp": time.Now().Add(time.Minute).Unix()})
+	for name, bad := range map[string]string{
+		"swapped payload": parts[0] + "." + strings.Split(forged, ".")[1] + "." + parts[2],
+		"no signature":    parts[0] + "." + parts[1] + ".",
+		"malformed":       "abc",
+		"other issuer":    foreign,
+	} {
+		if _, err := p.verify(bad, typeAccessToken); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := p.verify(tok, typeIDToken); err == nil {
+		t.Error("Expected an access token to be refused as an ID token")
+	}
+}
@@ -0,0 +1,150 @@
+// Package oidc makes the app an OpenID Connect provider, so other internal apps
+// can sign users in with their accounts here (single sign-on).
+//
+// Only the authorization code flow is supported, with PKCE (S256) for public
+// clients. ID and access tokens are RS256 JWTs signed with one RSA key, published
+// at the JWKS endpoint. Relying parties are trusted first-party apps, so users
+// who are signed in aren't asked for consent.
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/cache"
+	"github.com/gojangframework/gojang/gojang/models"
+)
+
+// Scopes the provider understands; others are dropped from grants
+const (
+	ScopeOpenID  = "openid"
+	ScopeEmail   = "email"
+	ScopeProfile = "profile"
+)
+
+// Lifetimes of authorization codes and of the ID and access tokens
+const (
+	CodeTTL  = time.Minute
+	TokenTTL = time.Hour
+)
+
+// RelyingParty is an app allowed to sign users in here
+type RelyingParty struct {
+	ID           string
+	Secret       string // Empty for public clients (SPAs, CLIs), which must use PKCE
+	RedirectURIs []string
+}
+
+// ParseRelyingParties parses OIDC_CLIENTS entries of the form
+// id:secret:redirect_uri, with several redirect URIs separated by "|".
+// The secret is left empty for public clients ("cli::http://127.0.0.1:8400/callback").
+func ParseRelyingParties(specs []string) ([]RelyingParty, error) {
+	var parties []RelyingParty
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		id, rest, ok1 := strings.Cut(strings.TrimSpace(spec), ":")
+		secret, uris, ok2 := strings.Cut(rest, ":")
+		if !ok1 || !ok2 || id == "" || uris == "" {
+			return nil, fmt.Errorf("oidc: client %q must be id:secret:redirect_uri", spec)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("oidc: client %q is listed twice", id)
+		}
+		seen[id] = true
+
+		rp := RelyingParty{ID: id, Secret: secret}
+		for _, uri := range strings.Split(uris, "|") {
+			u, err := url.Parse(uri)
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.Fragment != "" {
+				return nil, fmt.Errorf("oidc: client %q has an invalid redirect URI %q", id, uri)
+			}
+			rp.RedirectURIs = append(rp.RedirectURIs, uri)
+		}
+		parties = append(parties, rp)
+	}
+	return parties, nil
+}
+
+// LoadKey reads an RSA private key from a PEM file (PKCS #1 or PKCS #8), as
+// written by `openssl genrsa -out oidc.pem 2048`
+func LoadKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("oidc: %s is not a PEM file", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: parsing %s: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("oidc: the signing key must be an RSA key")
+	}
+	return key, nil
+}
+
+// GenerateKey returns a new 2048-bit signing key. Tokens it signs stop
+// verifying when the process restarts, so use LoadKey in production.
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 2048)
+}
+
+// Provider serves the OpenID Connect endpoints for a set of relying parties
+type Provider struct {
+	Issuer string         // The site's base URL, e.g. https://accounts.example.com
+	Client *models.Client // Where users are loaded from
+
+	// Pending authorization codes. The default in-memory cache only works
+	// when the authorize and token requests reach the same process.
+	Codes cache.Cache
+
+	parties map[string]RelyingParty
+	key     *rsa.PrivateKey
+	keyID   string
+	now     func() time.Time
+}
+
+// New creates a provider issuing tokens as issuer, signed with key
+func New(issuer string, key *rsa.PrivateKey, client *models.Client, parties ...RelyingParty) *Provider {
+	p := &Provider{
+		Issuer:  strings.TrimSuffix(issuer, "/"),
+		Client:  client,
+		Codes:   cache.NewMemoryCache(),
+		parties: make(map[string]RelyingParty, len(parties)),
+		key:     key,
+		now:     time.Now,
+	}
+	for _, rp := range parties {
+		p.parties[rp.ID] = rp
+	}
+
+	// The key ID is derived from the public key, so it changes when the key does
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	sum := sha256.Sum256(der)
+	p.keyID = base64.RawURLEncoding.EncodeToString(sum[:12])
+	return p
+}
+
+// randomToken returns 32 random bytes, base64url encoded
+func randomToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,85 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+var (
+	testKeyOnce sync.Once
+	testKeyVal  *rsa.PrivateKey
+)
+
+// testKey returns a signing key shared by the tests, since generating one is slow
+func testKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	testKeyOnce.Do(func() {
+		key, err := GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		testKeyVal = key
+	})
+	return testKeyVal
+}
+
+func TestParseRelyingParties(t *testing.T) {
+	parties, err := ParseRelyingParties([]string{
+		"wiki:s3cret:https://wiki.example.com/callback",
+		" cli::http://127.0.0.1:8400/cb|http://localhost:8400/cb",
+	})
+	if err != nil {
+		t.Fatalf("ParseRelyingParties: %v", err)
+	}
+	if len(parties) != 2 || parties[0].ID != "wiki" || parties[0].Secret != "s3cret" || parties[0].RedirectURIs[0] != "https://wiki.example.com/callback" {
+		t.Errorf("Unexpected first client %+v", parties[0])
+	}
+	if parties[1].ID != "cli" || parties[1].Secret != "" || len(parties[1].RedirectURIs) != 2 {
+		t.Errorf("Unexpected public client %+v", parties[1])
+	}
+
+	for _, spec := range []string{
+		"wiki",
+		"wiki:secret",
+		":secret:https://wiki.example.com/cb",
+		"wiki:secret:wiki.example.com/cb",
+		"wiki:secret:https://wiki.example.com/cb#frag",
+		"wiki:secret:javascript:alert(1)",
+	} {
+		if _, err := ParseRelyingParties([]string{spec}); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+	if _, err := ParseRelyingParties([]string{"a:x:https://a.example.com", "a:y:https://b.example.com"}); err == nil {
+		t.Error("Expected an error for a duplicate client ID")
+	}
+}
+
+// TestLoadKey tests PKCS #1 and PKCS #8 keys, as written by different versions of openssl
+func TestLoadKey(t *testing.T) {
+	key := testKey(t)
+	dir := t.TempDir()
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	files := map[string]*pem.Block{
+		"pkcs1.pem": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		"pkcs8.pem": {Type: "PRIVATE KEY", Bytes: pkcs8},
+	}
+	for name, block := range files {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, pem.EncodeToMemory(block), 0o600)
+		loaded, err := LoadKey(path)
+		if err != nil || !loaded.Equal(key) {
+			t.Errorf("LoadKey(%s) = %v", name, err)
+		}
+	}
+
+	os.WriteFile(filepath.Join(dir, "bad.pem"), []byte("not a key"), 0o600)
+	if _, err := LoadKey(filepath.Join(dir, "bad.pem")); err == nil {
+		t.Error("Expected an error for a file that isn't PEM")
+	}
+}
@@ -0,0 +1,131 @@
+// Package polls records votes on polls (Poll records, managed in the admin)
+// and tallies their results. Each voter gets one vote per poll: signed-in
+// users by account and guests by session (see Voter).
+package polls
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/poll"
+	"github.com/gojangframework/gojang/gojang/models/vote"
+)
+
+// Errors for votes that aren't counted
+var (
+	ErrClosed        = errors.New("polls: poll is closed")
+	ErrAlreadyVoted  = errors.New("polls: already voted")
+	ErrInvalidOption = errors.New("polls: no such option")
+)
+
+// MinOptions is the fewest answers a poll can offer
+const MinOptions = 2
+
+// Voter returns the key a vote is counted under: u's account when signed in
+// (u is non-nil), or else guest, an ID the server gave the visitor's session.
+// Not their IP address, which proxy headers let anyone change.
+func Voter(u *models.User, guest string) string {
+	if u != nil {
+		return "user:" + u.ID.String()
+	}
+	return "guest:" + guest
+}
+
+// Open reports whether p takes votes at now
+func Open(p *models.Poll, now time.Time) bool {
+	return p.ClosesAt == nil || now.Before(*p.ClosesAt)
+}
+
+// Cast records voter's choice of the option at position option in p
+func Cast(ctx context.Context, client *models.Client, p *models.Poll, voter string, option int) error {
+	if !Open(p, time.Now()) {
+		return ErrClosed
+	}
+	if option < 0 || option >= len(p.Options) {
+		return ErrInvalidOption
+	}
+	err := client.Vote.Create().
+		SetPoll(p).
+		SetVoter(voter).
+		SetOption(option).
+		Exec(ctx)
+	if models.IsConstraintError(err) {
+		return ErrAlreadyVoted
+	}
+	return err
+}
+
+// Choice returns the option voter chose in p, or -1 if they haven't voted
+func Choice(ctx context.Context, client *models.Client, p *models.Poll, voter string) (int, error) {
+	v, err := client.Vote.Query().
+		Where(vote.Voter(voter), vote.HasPollWith(poll.ID(p.ID))).
+		Only(ctx)
+	if models.IsNotFound(err) {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return v.Option, nil
+}
+
+// Result is the votes for one of a poll's options
+type Result struct {
+	Option  string
+	Votes   int
+	Percent int // Share of all votes, rounded down
+}
+
+// Results are a poll's votes per option, in the poll's order
+type Results struct {
+	Options []Result
+	Total   int
+}
+
+// Tally counts the votes on p
+func Tally(ctx context.Context, client *models.Client, p *models.Poll) (*Results, error) {
+	var rows []struct {
+		Option int `json:"option"`
+		Count  int `json:"count"`
+	}
+	err := client.Vote.Query().
+		Where(vote.HasPollWith(poll.ID(p.ID))).
+		GroupBy(vote.FieldOption).
+		Aggregate(models.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.Option] = row.Count
+	}
+	return tally(p.Options, counts), nil
+}
+
+// Count counts the votes loaded with p (see PollQuery.WithVotes)
+func Count(p *models.Poll) *Results {
+	counts := make(map[int]int, len(p.Options))
+	for _, v := range p.Edges.Votes {
+		counts[v.Option]++
+	}
+	return tally(p.Options, counts)
+}
+
+// tally turns vote counts by option position into results. Votes for
+// options that were removed since aren't counted.
+func tally(options []string, counts map[int]int) *Results {
+	res := &Results{Options: make([]Result, len(options))}
+	for i, option := range options {
+		res.Options[i] = Result{Option: option, Votes: counts[i]}
+		res.Total += counts[i]
+	}
+	if res.Total > 0 {
+		for i := range res.Options {
+			res.Options[i].Percent = res.Options[i].Votes * 100 / res.Total
+		}
+	}
+	return res
+}
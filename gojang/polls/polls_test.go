@@ -0,0 +1,76 @@
+package polls
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+func TestVoter(t *testing.T) {
+	id := uuid.New()
+	if got := Voter(&models.User{ID: id}, "g1"); got != "user:"+id.String() {
+		t.Errorf("Voter(user) = %q", got)
+	}
+	if got := Voter(nil, "g1"); got != "guest:g1" {
+		t.Errorf("Voter(guest) = %q", got)
+	}
+}
+
+// TestCast tests that each voter gets one vote, on an open poll and a listed option
+func TestCast(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	p := client.Poll.Create().SetQuestion("Tabs or spaces?").SetOptions([]string{"Tabs", "Spaces", "Both"}).SaveX(ctx)
+
+	if err := Cast(ctx, client, p, "ip:192.0.2.1", 1); err != nil {
+		t.Fatalf("Cast: %v", err)
+	}
+	if err := Cast(ctx, client, p, "ip:192.0.2.1", 0); !errors.Is(err, ErrAlreadyVoted) {
+		t.Errorf("Expected ErrAlreadyVoted, got %v", err)
+	}
+	if err := Cast(ctx, client, p, "ip:192.0.2.2", 3); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+	if choice, err := Choice(ctx, client, p, "ip:192.0.2.1"); err != nil || choice != 1 {
+		t.Errorf("Choice = %d, %v; want 1", choice, err)
+	}
+	if choice, err := Choice(ctx, client, p, "ip:192.0.2.2"); err != nil || choice != -1 {
+		t.Errorf("Choice without a vote = %d, %v; want -1", choice, err)
+	}
+
+	closed := client.Poll.Create().SetQuestion("Closed?").SetOptions([]string{"Yes", "No"}).SetClosesAt(time.Now()).SaveX(ctx)
+	if err := Cast(ctx, client, closed, "ip:192.0.2.1", 0); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestTally(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	p := client.Poll.Create().SetQuestion("Best day?").SetOptions([]string{"Mon", "Fri", "Sun"}).SaveX(ctx)
+	for i, option := range []int{1, 1, 2, 1} {
+		if err := Cast(ctx, client, p, Voter(nil, string(rune('a'+i))), option); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := Tally(ctx, client, p)
+	if err != nil {
+		t.Fatalf("Tally: %v", err)
+	}
+	want := []Result{{"Mon", 0, 0}, {"Fri", 3, 75}, {"Sun", 1, 25}}
+	if res.Total != 4 || len(res.Options) != 3 || res.Options[0] != want[0] || res.Options[1] != want[1] || res.Options[2] != want[2] {
+		t.Errorf("Tally = %+v, want %+v", res, want)
+	}
+
+	// Counting loaded votes gives the same results
+	loaded := client.Poll.Query().WithVotes().OnlyX(ctx)
+	if got := Count(loaded); got.Total != 4 || got.Options[1] != want[1] {
+		t.Errorf("Count = %+v", got)
+	}
+}
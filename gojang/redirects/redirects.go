@@ -0,0 +1,137 @@
+// Package redirects sends requests for old URLs on to their new location,
+// using the Redirect records managed in the admin. Its middleware wraps the
+// 404 handler, so a redirect never shadows a path a route still serves:
+//
+//	r.NotFound(redirectMap.NotFound(http.HandlerFunc(pageHandler.NotFound)).ServeHTTP)
+package redirects
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// TTL is how long the redirects are cached. Changes saved through Ent reload
+// them right away (see Hook); other instances pick them up within TTL.
+const TTL = time.Minute
+
+// StatusCodes are the statuses a redirect may use
+var StatusCodes = []int{
+	http.StatusMovedPermanently,  // 301
+	http.StatusFound,             // 302
+	http.StatusTemporaryRedirect, // 307
+	http.StatusPermanentRedirect, // 308
+}
+
+// target is where one path redirects to
+type target struct {
+	to     string
+	status int
+}
+
+// Map is the cached redirects by old path
+type Map struct {
+	client *models.Client
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	targets map[string]target
+	expires time.Time
+}
+
+// New returns a map of client's redirects, loaded on first use
+func New(client *models.Client) *Map {
+	return &Map{client: client, now: time.Now}
+}
+
+// Invalidate makes the next lookup reload the redirects
+func (m *Map) Invalidate() {
+	m.mu.Lock()
+	m.expires = time.Time{}
+	m.mu.Unlock()
+}
+
+// Hook returns an Ent hook that reloads the map when redirects change:
+//
+//	client.Redirect.Use(redirectMap.Hook())
+func (m *Map) Hook() models.Hook {
+	return func(next models.Mutator) models.Mutator {
+		return models.MutateFunc(func(ctx context.Context, mu models.Mutation) (models.Value, error) {
+			v, err := next.Mutate(ctx, mu)
+			if err == nil {
+				m.Invalidate()
+			}
+			return v, err
+		})
+	}
+}
+
+// Lookup returns where path redirects to and with which status. A path
+// with or without a trailing slash matches a redirect saved the other way.
+func (m *Map) Lookup(ctx context.Context, path string) (string, int, bool) {
+	targets := m.load(ctx)
+	t, ok := targets[path]
+	if !ok && path != "/" {
+		if strings.HasSuffix(path, "/") {
+			t, ok = targets[strings.TrimSuffix(path, "/")]
+		} else {
+			t, ok = targets[path+"/"]
+		}
+	}
+	return t.to, t.status, ok
+}
+
+// load returns the cached redirects, reloading them once they expired. If
+// reloading fails the old ones are kept until the next try.
+func (m *Map) load(ctx context.Context) map[string]target {
+	m.mu.RLock()
+	targets, fresh := m.targets, m.now().Before(m.expires)
+	m.mu.RUnlock()
+	if fresh {
+		return targets
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.now().Before(m.expires) {
+		return m.targets // Another request reloaded them meanwhile
+	}
+	m.expires = m.now().Add(TTL)
+
+	list, err := m.client.Redirect.Query().All(ctx)
+	if err != nil {
+		utils.Errorw("redirects.load_failed", "error", err)
+		return m.targets
+	}
+	m.targets = make(map[string]target, len(list))
+	for _, r := range list {
+		m.targets[r.FromPath] = target{to: r.ToPath, status: r.StatusCode}
+	}
+	return m.targets
+}
+
+// NotFound wraps the 404 handler: GET and HEAD requests for a redirected
+// path are sent on, keeping their query string unless the target has its
+// own, and everything else gets notFound
+func (m *Map) NotFound(notFound http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			notFound.ServeHTTP(w, r)
+			return
+		}
+		to, status, ok := m.Lookup(r.Context(), r.URL.Path)
+		if !ok {
+			notFound.ServeHTTP(w, r)
+			return
+		}
+		if r.URL.RawQuery != "" && !strings.Contains(to, "?") {
+			to += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, to, status)
+	})
+}
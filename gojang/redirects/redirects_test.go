@@ -0,0 +1,68 @@
+package redirects
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+)
+
+func TestNotFound(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	client.Redirect.Create().SetFromPath("/blog/hello").SetToPath("/posts/hello").SaveX(ctx)
+	client.Redirect.Create().SetFromPath("/old-docs/").SetToPath("https://docs.example.com/?v=2").SetStatusCode(http.StatusFound).SaveX(ctx)
+
+	handler := New(client).NotFound(http.NotFoundHandler())
+	tests := []struct {
+		method, url  string
+		status       int
+		wantLocation string
+	}{
+		{http.MethodGet, "/blog/hello", http.StatusMovedPermanently, "/posts/hello"},
+		{http.MethodHead, "/blog/hello/?utm=x", http.StatusMovedPermanently, "/posts/hello?utm=x"},
+		{http.MethodGet, "/old-docs?page=3", http.StatusFound, "https://docs.example.com/?v=2"},
+		{http.MethodPost, "/blog/hello", http.StatusNotFound, ""},
+		{http.MethodGet, "/blog/other", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, nil))
+		if rec.Code != tt.status || rec.Header().Get("Location") != tt.wantLocation {
+			t.Errorf("%s %s = %d %q, want %d %q", tt.method, tt.url, rec.Code, rec.Header().Get("Location"), tt.status, tt.wantLocation)
+		}
+	}
+}
+
+// TestReload tests that changes show up through the hook, and otherwise once the cache expires
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	m := New(client)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	if _, _, ok := m.Lookup(ctx, "/a"); ok {
+		t.Fatal("Expected no redirect yet")
+	}
+
+	// Saved without the hook (e.g., by another instance): cached until TTL
+	client.Redirect.Create().SetFromPath("/a").SetToPath("/b").SaveX(ctx)
+	if _, _, ok := m.Lookup(ctx, "/a"); ok {
+		t.Error("Expected the cached map to be used within TTL")
+	}
+	now = now.Add(TTL)
+	if to, _, ok := m.Lookup(ctx, "/a"); !ok || to != "/b" {
+		t.Errorf("Lookup after TTL = %q, %v", to, ok)
+	}
+
+	// Saved with the hook: reloaded right away
+	client.Redirect.Use(m.Hook())
+	client.Redirect.Create().SetFromPath("/c").SetToPath("/d").SaveX(ctx)
+	if to, _, ok := m.Lookup(ctx, "/c"); !ok || to != "/d" {
+		t.Errorf("Lookup after save = %q, %v", to, ok)
+	}
+}
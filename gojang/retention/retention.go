@@ -0,0 +1,246 @@
+// Package retention deletes old rows on a schedule, with one policy per table
+// registered in code:
+//
+//	policies := retention.New(client)
+//	policies.Register(retention.AdminActions(client, 365))
+//	go jobs.Every(ctx, time.Hour, "retention", policies.Run)
+//
+// Superusers can change how long each table is kept, without a deploy, on the admin
+// settings page (/admin/settings). Their choices are stored as
+// "retention.<name>" settings and take precedence over Policy.Days.
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/adminaction"
+	"github.com/gojangframework/gojang/gojang/models/loginevent"
+	"github.com/gojangframework/gojang/gojang/models/setting"
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// settingPrefix namespaces the days overrides in the settings table
+const settingPrefix = "retention."
+
+// MaxDays is the longest retention the admin accepts (about 100 years)
+const MaxDays = 36500
+
+// PruneFunc deletes the rows created before the cutoff and returns how many it deleted
+type PruneFunc func(ctx context.Context, before time.Time) (int, error)
+
+// Policy is how long one table keeps its rows
+type Policy struct {
+	Name  string    // Unique key, e.g., "admin_actions"
+	Label string    // Shown on the admin settings page, e.g., "Admin activity"
+	Days  int       // Rows older than this are deleted; 0 keeps them forever
+	Prune PruneFunc // Deletes the old rows
+}
+
+// Status is a policy with the days in effect and its last run on this instance
+type Status struct {
+	Policy
+	Default int       // Days registered in code
+	LastRun time.Time // Zero until the policy runs
+	Deleted int       // Rows deleted by the last run
+	Error   string    // Why the last run failed
+}
+
+// run is the outcome of a policy's last run
+type run struct {
+	at      time.Time
+	deleted int
+	err     error
+}
+
+// Registry holds the policies and runs them
+type Registry struct {
+	client *models.Client
+
+	mu       sync.Mutex
+	policies []Policy
+	runs     map[string]run
+	now      func() time.Time
+}
+
+// New creates a registry that reads days overrides from client's settings
+func New(client *models.Client) *Registry {
+	return &Registry{
+		client: client,
+		runs:   make(map[string]run),
+		now:    time.Now,
+	}
+}
+
+// Register adds a policy. It panics if the name is taken, like registering a
+// route twice.
+func (r *Registry) Register(p Policy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.policies {
+		if existing.Name == p.Name {
+			panic(fmt.Sprintf("retention: policy %q registered twice", p.Name))
+		}
+	}
+	r.policies = append(r.policies, p)
+}
+
+// Policies returns every policy with the days in effect, in registration order
+func (r *Registry) Policies(ctx context.Context) ([]Status, error) {
+	overrides, err := r.overrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	statuses := make([]Status, len(r.policies))
+	for i, p := range r.policies {
+		s := Status{Policy: p, Default: p.Days}
+		if days, ok := overrides[p.Name]; ok {
+			s.Days = days
+		}
+		if last, ok := r.runs[p.Name]; ok {
+			s.LastRun, s.Deleted = last.at, last.deleted
+			if last.err != nil {
+				s.Error = last.err.Error()
+			}
+		}
+		statuses[i] = s
+	}
+	return statuses, nil
+}
+
+// SetDays overrides how many days a policy keeps rows
+func (r *Registry) SetDays(ctx context.Context, name string, days int) error {
+	if days < 0 || days > MaxDays {
+		return fmt.Errorf("retention: days must be between 0 and %d", MaxDays)
+	}
+	if !r.registered(name) {
+		return fmt.Errorf("retention: unknown policy %q", name)
+	}
+
+	key, value := settingPrefix+name, strconv.Itoa(days)
+	n, err := r.client.Setting.Update().Where(setting.KeyEQ(key)).SetValue(value).Save(ctx)
+	if err != nil || n > 0 {
+		return err
+	}
+	return r.client.Setting.Create().SetKey(key).SetValue(value).Exec(ctx)
+}
+
+// Run prunes every policy, continuing past failures, and returns their errors
+func (r *Registry) Run(ctx context.Context) error {
+	policies, err := r.Policies(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range policies {
+		if p.Days == 0 {
+			continue
+		}
+		before := r.now().AddDate(0, 0, -p.Days)
+		deleted, err := p.Prune(ctx, before)
+
+		r.mu.Lock()
+		r.runs[p.Name] = run{at: r.now(), deleted: deleted, err: err}
+		r.mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+			continue
+		}
+		if deleted > 0 {
+			utils.Infow("retention.pruned", "policy", p.Name, "days", p.Days, "count", deleted)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// overrides returns the days set on the admin settings page, by policy name
+func (r *Registry) overrides(ctx context.Context) (map[string]int, error) {
+	settings, err := r.client.Setting.Query().Where(setting.KeyHasPrefix(settingPrefix)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string]int, len(settings))
+	for _, s := range settings {
+		days, err := strconv.Atoi(s.Value)
+		if err != nil || days < 0 {
+			utils.Warnw("retention.invalid_setting", "key", s.Key, "value", s.Value)
+			continue
+		}
+		overrides[strings.TrimPrefix(s.Key, settingPrefix)] = days
+	}
+	return overrides, nil
+}
+
+func (r *Registry) registered(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.policies {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminActions keeps the admin activity log (AdminAction rows) for days
+func AdminActions(client *models.Client, days int) Policy {
+	return Policy{
+		Name:  "admin_actions",
+		Label: "Admin activity log",
+		Days:  days,
+		Prune: func(ctx context.Context, before time.Time) (int, error) {
+			return client.AdminAction.Delete().Where(adminaction.CreatedAtLT(before)).Exec(ctx)
+		},
+	}
+}
+
+// LoginEvents keeps users' sign-in history (LoginEvent rows) for days
+func LoginEvents(client *models.Client, days int) Policy {
+	return Policy{
+		Name:  "login_events",
+		Label: "Sign-in history",
+		Days:  days,
+		Prune: func(ctx context.Context, before time.Time) (int, error) {
+			return client.LoginEvent.Delete().Where(loginevent.CreatedAtLT(before)).Exec(ctx)
+		},
+	}
+}
+
+// Sessions signs out sessions that began more than days ago, however recently
+// they were used. A session began its manager's Lifetime before its deadline.
+// The store must list its sessions (scs.IterableStore), as memstore and
+// postgresstore do; expired sessions are left to the store's own cleanup.
+func Sessions(sm *scs.SessionManager, days int) Policy {
+	return Policy{
+		Name:  "sessions",
+		Label: "Signed-in sessions",
+		Days:  days,
+		Prune: func(ctx context.Context, before time.Time) (int, error) {
+			switch sm.Store.(type) {
+			case scs.IterableStore, scs.IterableCtxStore:
+			default:
+				return 0, fmt.Errorf("session store %T can't list its sessions", sm.Store)
+			}
+			deleted := 0
+			err := sm.Iterate(ctx, func(ctx context.Context) error {
+				if !sm.Deadline(ctx).Add(-sm.Lifetime).Before(before) {
+					return nil
+				}
+				deleted++
+				return sm.Destroy(ctx)
+			})
+			return deleted, err
+		},
+	}
+}
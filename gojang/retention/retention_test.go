@@ -0,0 +1,157 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/alexedwards/scs/v2/memstore"
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
+)
+
+// addAction records an admin action created age ago
+func addAction(t *testing.T, client *models.Client, age time.Duration) {
+	t.Helper()
+	client.AdminAction.Create().
+		SetAction("update").SetModel("Post").SetRecordID("1").SetRecordLabel("Hello").
+		SetUserID(uuid.New()).SetUserEmail("root@example.com").
+		SetCreatedAt(time.Now().Add(-age)).
+		SaveX(context.Background())
+}
+
+// TestRegistry tests pruning with the days registered in code and set in the admin
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	reg := New(client)
+	reg.Register(AdminActions(client, 30))
+
+	addAction(t, client, 40*24*time.Hour)
+	addAction(t, client, 20*24*time.Hour)
+	addAction(t, client, time.Hour)
+
+	if err := reg.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if n := client.AdminAction.Query().CountX(ctx); n != 2 {
+		t.Errorf("Expected rows older than 30 days to be deleted, %d left", n)
+	}
+	statuses, _ := reg.Policies(ctx)
+	if s := statuses[0]; s.Deleted != 1 || s.LastRun.IsZero() || s.Days != 30 || s.Default != 30 {
+		t.Errorf("Unexpected status %+v", s)
+	}
+
+	// Days set in the admin take precedence, and 0 keeps everything
+	if err := reg.SetDays(ctx, "admin_actions", 0); err != nil {
+		t.Fatalf("SetDays failed: %v", err)
+	}
+	if err := reg.SetDays(ctx, "admin_actions", 10); err != nil {
+		t.Fatalf("SetDays failed: %v", err)
+	}
+	statuses, _ = reg.Policies(ctx)
+	if s := statuses[0]; s.Days != 10 || s.Default != 30 {
+		t.Errorf("Expected 10 days in effect, got %+v", s)
+	}
+	reg.Run(ctx)
+	if n := client.AdminAction.Query().CountX(ctx); n != 1 {
+		t.Errorf("Expected rows older than 10 days to be deleted, %d left", n)
+	}
+	reg.SetDays(ctx, "admin_actions", 0)
+	reg.now = func() time.Time { return time.Now().AddDate(1, 0, 0) }
+	reg.Run(ctx)
+	if n := client.AdminAction.Query().CountX(ctx); n != 1 {
+		t.Errorf("Expected 0 days to keep every row, %d left", n)
+	}
+
+	if err := reg.SetDays(ctx, "missing", 5); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+	if err := reg.SetDays(ctx, "admin_actions", -1); err == nil {
+		t.Error("Expected an error for negative days")
+	}
+}
+
+// TestRegistry_Errors tests that a failing policy doesn't stop the others
+func TestRegistry_Errors(t *testing.T) {
+	client := testdb.Open(t)
+	reg := New(client)
+	ran := false
+	reg.Register(Policy{Name: "broken", Days: 1, Prune: func(ctx context.Context, before time.Time) (int, error) {
+		return 0, errors.New("table locked")
+	}})
+	reg.Register(Policy{Name: "ok", Days: 1, Prune: func(ctx context.Context, before time.Time) (int, error) {
+		ran = true
+		return 0, nil
+	}})
+
+	err := reg.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "broken: table locked") || !ran {
+		t.Errorf("Expected broken's error after running ok, got %v (ran %v)", err, ran)
+	}
+	statuses, _ := reg.Policies(context.Background())
+	if statuses[0].Error != "table locked" {
+		t.Errorf("Expected the error in the status, got %+v", statuses[0])
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a name twice to panic")
+		}
+	}()
+	reg.Register(Policy{Name: "ok"})
+}
+
+// TestSessions tests that sessions which began before the cutoff are signed out
+func TestSessions(t *testing.T) {
+	ctx := context.Background()
+	sm := scs.New()
+	sm.Lifetime = 90 * 24 * time.Hour
+	store := memstore.NewWithCleanupInterval(0)
+	sm.Store = store
+
+	// Sessions that began 40 days and 1 day ago
+	for token, age := range map[string]time.Duration{"old": 40 * 24 * time.Hour, "new": 24 * time.Hour} {
+		deadline := time.Now().Add(sm.Lifetime - age)
+		b, err := sm.Codec.Encode(deadline, map[string]interface{}{"user_id": token})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Commit(token, b, deadline); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := Sessions(sm, 30).Prune(ctx, time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 session signed out, got %d", deleted)
+	}
+	if _, found, _ := store.Find("old"); found {
+		t.Error("Expected the session from 40 days ago to be deleted")
+	}
+	if _, found, _ := store.Find("new"); !found {
+		t.Error("Expected the session from yesterday to be kept")
+	}
+
+	// Stores that can't list their sessions fail instead of panicking
+	sm.Store = unlistedStore{store}
+	if _, err := Sessions(sm, 30).Prune(ctx, time.Now()); err == nil {
+		t.Error("Expected an error for a store that can't list its sessions")
+	}
+}
+
+// unlistedStore hides memstore's All method
+type unlistedStore struct{ store *memstore.MemStore }
+
+func (s unlistedStore) Delete(token string) error               { return s.store.Delete(token) }
+func (s unlistedStore) Find(token string) ([]byte, bool, error) { return s.store.Find(token) }
+func (s unlistedStore) Commit(token string, b []byte, expiry time.Time) error {
+	return s.store.Commit(token, b, expiry)
+}
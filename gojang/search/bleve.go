@@ -0,0 +1,131 @@
+//go:build bleve
+
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/mapping"
+	blevequery "github.com/blevesearch/bleve/v2/search/query"
+)
+
+// Bleve is an Indexer backed by an embedded Bleve index
+type Bleve struct {
+	index bleve.Index
+}
+
+// NewBleve opens the Bleve index in dir, creating it if needed. An empty dir
+// keeps the index in memory.
+func NewBleve(dir string) (*Bleve, error) {
+	if dir == "" {
+		index, err := bleve.NewMemOnly(bleveMapping())
+		if err != nil {
+			return nil, err
+		}
+		return &Bleve{index: index}, nil
+	}
+
+	index, err := bleve.Open(dir)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+			return nil, err
+		}
+		index, err = bleve.New(dir, bleveMapping())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Bleve{index: index}, nil
+}
+
+// bleveMapping indexes type as an exact keyword and title/body as analyzed text
+func bleveMapping() mapping.IndexMapping {
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+
+	textField := bleve.NewTextFieldMapping()
+
+	storedField := bleve.NewTextFieldMapping()
+	storedField.Index = false
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("id", storedField)
+	doc.AddFieldMappingsAt("type", keywordField)
+	doc.AddFieldMappingsAt("title", textField)
+	doc.AddFieldMappingsAt("body", textField)
+	doc.AddFieldMappingsAt("url", storedField)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	return m
+}
+
+func (b *Bleve) Index(ctx context.Context, docs ...Document) error {
+	batch := b.index.NewBatch()
+	for _, doc := range docs {
+		if err := batch.Index(doc.key(), doc); err != nil {
+			return err
+		}
+	}
+	return b.index.Batch(batch)
+}
+
+func (b *Bleve) Delete(ctx context.Context, docType string, ids ...string) error {
+	batch := b.index.NewBatch()
+	for _, id := range ids {
+		batch.Delete(Document{Type: docType, ID: id}.key())
+	}
+	return b.index.Batch(batch)
+}
+
+func (b *Bleve) Search(ctx context.Context, q Query) (*Results, error) {
+	title := bleve.NewMatchQuery(q.Text)
+	title.SetField("title")
+	title.SetBoost(2)
+	body := bleve.NewMatchQuery(q.Text)
+	body.SetField("body")
+
+	var query blevequery.Query = bleve.NewDisjunctionQuery(title, body)
+	if len(q.Types) > 0 {
+		types := make([]blevequery.Query, len(q.Types))
+		for i, t := range q.Types {
+			term := bleve.NewTermQuery(t)
+			term.SetField("type")
+			types[i] = term
+		}
+		query = bleve.NewConjunctionQuery(query, bleve.NewDisjunctionQuery(types...))
+	}
+
+	req := bleve.NewSearchRequestOptions(query, q.limit(), q.Offset, false)
+	req.Fields = []string{"id", "type", "title", "body", "url"}
+	res, err := b.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &Results{Total: int(res.Total), Hits: make([]Hit, len(res.Hits))}
+	for i, hit := range res.Hits {
+		results.Hits[i] = Hit{Document: Document{
+			ID:    fieldString(hit.Fields, "id"),
+			Type:  fieldString(hit.Fields, "type"),
+			Title: fieldString(hit.Fields, "title"),
+			Body:  fieldString(hit.Fields, "body"),
+			URL:   fieldString(hit.Fields, "url"),
+		}, Score: hit.Score}
+	}
+	return results, nil
+}
+
+func (b *Bleve) Close() error {
+	return b.index.Close()
+}
+
+func fieldString(fields map[string]interface{}, name string) string {
+	s, _ := fields[name].(string)
+	return s
+}
@@ -0,0 +1,14 @@
+//go:build !bleve
+
+package search
+
+import "errors"
+
+// ErrBleveUnavailable is returned by NewBleve in builds without the bleve tag
+var ErrBleveUnavailable = errors.New("search: bleve backend not compiled in (build with -tags bleve, or set SEARCH_URL to a Meilisearch server)")
+
+// NewBleve opens the embedded Bleve index. This build doesn't include Bleve;
+// build with -tags bleve (after go get github.com/blevesearch/bleve/v2) to use it.
+func NewBleve(dir string) (Indexer, error) {
+	return nil, ErrBleveUnavailable
+}
@@ -0,0 +1,48 @@
+//go:build bleve
+
+package search
+
+import (
+	"context"
+	"testing"
+)
+
+// TestBleve tests indexing, type filtering and deletion with an in-memory index
+func TestBleve(t *testing.T) {
+	idx, err := NewBleve("")
+	if err != nil {
+		t.Fatalf("NewBleve: %v", err)
+	}
+	defer idx.Close()
+
+	ctx := context.Background()
+	err = idx.Index(ctx,
+		Document{ID: "1", Type: "Post", Title: "Gophers", Body: "All about gophers", URL: "/posts#post-1"},
+		Document{ID: "2", Type: "Post", Title: "Cats", Body: "Nothing to see"},
+		Document{ID: "1", Type: "Page", Title: "About", Body: "A page about gophers"},
+	)
+	if err != nil {
+		t.Fatalf("Index: %v", err)
+	}
+
+	res, err := idx.Search(ctx, Query{Text: "gophers"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if res.Total != 2 || res.Hits[0].Title != "Gophers" || res.Hits[0].URL != "/posts#post-1" {
+		t.Errorf("unexpected results: %+v", res)
+	}
+
+	res, _ = idx.Search(ctx, Query{Text: "gophers", Types: []string{"Page"}})
+	if res.Total != 1 || res.Hits[0].Type != "Page" {
+		t.Errorf("type filter: unexpected results: %+v", res)
+	}
+
+	if err := idx.Delete(ctx, "Post", "1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	res, _ = idx.Search(ctx, Query{Text: "gophers"})
+	if res.Total != 1 {
+		t.Errorf("after delete Total = %d, want 1", res.Total)
+	}
+}
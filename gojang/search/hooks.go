@@ -0,0 +1,125 @@
+package search
+
+import (
+	"context"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// Source describes how the records of one model are indexed
+type Source struct {
+	Type     string                                                            // Document type (e.g., "Post")
+	Document func(record interface{}) Document                                 // Builds a record's document (receives e.g. *models.Post)
+	Load     func(ctx context.Context, ids []uuid.UUID) ([]interface{}, error) // Reloads records changed by bulk updates (nil skips them)
+	All      func(ctx context.Context) ([]interface{}, error)                  // Every record, for Reindex
+	Hidden   func(record interface{}) bool                                     // Keeps records out of the index (e.g., scheduled posts); optional
+}
+
+// hidden reports whether record is kept out of the index
+func (s Source) hidden(record interface{}) bool {
+	return s.Hidden != nil && s.Hidden(record)
+}
+
+// idsMutation is implemented by every Ent mutation (e.g., *models.PostMutation)
+type idsMutation interface {
+	IDs(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// Hook returns an Ent hook that indexes the source's records as they are created
+// or updated and removes them when they are deleted:
+//
+//	client.Post.Use(search.Hook(index, search.Posts(client)))
+//
+// Index errors are logged instead of failing the save, since the database stays
+// the source of truth; Reindex catches an index up that fell behind. Changes made
+// in a transaction are indexed before it commits.
+func Hook(idx Indexer, src Source) models.Hook {
+	return func(next models.Mutator) models.Mutator {
+		return models.MutateFunc(func(ctx context.Context, m models.Mutation) (models.Value, error) {
+			// Find the affected rows first; deleted ones can't be looked up afterwards
+			var ids []uuid.UUID
+			if m.Op().Is(models.OpDelete | models.OpDeleteOne | models.OpUpdate) {
+				if im, ok := m.(idsMutation); ok {
+					var err error
+					if ids, err = im.IDs(ctx); err != nil {
+						utils.Errorw("search.index_failed", "type", src.Type, "op", m.Op().String(), "error", err)
+					}
+				}
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return v, err
+			}
+
+			switch {
+			case m.Op().Is(models.OpCreate|models.OpUpdateOne) && src.hidden(v):
+				err = idx.Delete(ctx, src.Type, src.Document(v).ID)
+			case m.Op().Is(models.OpCreate | models.OpUpdateOne):
+				err = idx.Index(ctx, src.Document(v))
+			case m.Op().Is(models.OpUpdate) && src.Load != nil && len(ids) > 0:
+				err = indexLoaded(ctx, idx, src, ids)
+			case m.Op().Is(models.OpDelete|models.OpDeleteOne) && len(ids) > 0:
+				err = idx.Delete(ctx, src.Type, idStrings(ids)...)
+			}
+			if err != nil {
+				utils.Errorw("search.index_failed", "type", src.Type, "op", m.Op().String(), "error", err)
+			}
+			return v, nil
+		})
+	}
+}
+
+// Reindex indexes every record of the source, e.g. at startup or after importing
+// data without going through Ent
+func Reindex(ctx context.Context, idx Indexer, src Source) (int, error) {
+	records, err := src.All(ctx)
+	if err != nil {
+		return 0, err
+	}
+	docs, _ := documents(src, records)
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	return len(docs), idx.Index(ctx, docs...)
+}
+
+func indexLoaded(ctx context.Context, idx Indexer, src Source, ids []uuid.UUID) error {
+	records, err := src.Load(ctx, ids)
+	if err != nil {
+		return err
+	}
+	docs, hidden := documents(src, records)
+	if len(hidden) > 0 {
+		if err := idx.Delete(ctx, src.Type, hidden...); err != nil {
+			return err
+		}
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	return idx.Index(ctx, docs...)
+}
+
+// documents builds the documents of records, and lists the IDs of the hidden ones
+func documents(src Source, records []interface{}) (docs []Document, hidden []string) {
+	for _, record := range records {
+		doc := src.Document(record)
+		if src.hidden(record) {
+			hidden = append(hidden, doc.ID)
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs, hidden
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
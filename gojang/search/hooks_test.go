@@ -0,0 +1,136 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/internal/testdb"
+	"github.com/gojangframework/gojang/gojang/models/post"
+)
+
+// memIndex is an Indexer that keeps documents in a map and matches by substring
+type memIndex struct {
+	docs map[string]Document
+}
+
+func newMemIndex() *memIndex {
+	return &memIndex{docs: make(map[string]Document)}
+}
+
+func (m *memIndex) Index(ctx context.Context, docs ...Document) error {
+	for _, doc := range docs {
+		m.docs[doc.key()] = doc
+	}
+	return nil
+}
+
+func (m *memIndex) Delete(ctx context.Context, docType string, ids ...string) error {
+	for _, id := range ids {
+		delete(m.docs, Document{Type: docType, ID: id}.key())
+	}
+	return nil
+}
+
+func (m *memIndex) Search(ctx context.Context, q Query) (*Results, error) {
+	results := &Results{}
+	for _, doc := range m.docs {
+		if strings.Contains(doc.Title+" "+doc.Body, q.Text) {
+			results.Hits = append(results.Hits, Hit{Document: doc})
+		}
+	}
+	results.Total = len(results.Hits)
+	return results, nil
+}
+
+func (m *memIndex) Close() error { return nil }
+
+// TestHook tests that creating, updating and deleting posts keeps the index in sync
+func TestHook(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	idx := newMemIndex()
+	client.Post.Use(Hook(idx, Posts(client)))
+
+	u := client.User.Create().SetEmail("author@example.com").SetPasswordHash("x").SaveX(ctx)
+	p := client.Post.Create().SetSubject("Hello").SetBody("First post").SetAuthor(u).SaveX(ctx)
+
+	doc, ok := idx.docs["Post-"+p.ID.String()]
+	if !ok {
+		t.Fatal("created post was not indexed")
+	}
+	if doc.Title != "Hello" || doc.URL != "/posts#post-"+p.ID.String() {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+
+	p.Update().SetSubject("Updated").SaveX(ctx)
+	if got := idx.docs["Post-"+p.ID.String()].Title; got != "Updated" {
+		t.Errorf("after update-one title = %q, want %q", got, "Updated")
+	}
+
+	client.Post.Update().Where(post.IDEQ(p.ID)).SetBody("Bulk edit").ExecX(ctx)
+	if got := idx.docs["Post-"+p.ID.String()].Body; got != "Bulk edit" {
+		t.Errorf("after bulk update body = %q, want %q", got, "Bulk edit")
+	}
+
+	client.Post.DeleteOneID(p.ID).ExecX(ctx)
+	if len(idx.docs) != 0 {
+		t.Errorf("expected deleted post to be removed, index has %d documents", len(idx.docs))
+	}
+}
+
+// TestHook_Hidden tests that scheduled posts stay out of the index until published
+func TestHook_Hidden(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	idx := newMemIndex()
+	client.Post.Use(Hook(idx, Posts(client)))
+
+	u := client.User.Create().SetEmail("author@example.com").SetPasswordHash("x").SaveX(ctx)
+	p := client.Post.Create().SetSubject("Soon").SetBody("b").SetAuthor(u).SetPublishAt(time.Now().Add(time.Hour)).SaveX(ctx)
+	if len(idx.docs) != 0 {
+		t.Fatal("Expected the scheduled post not to be indexed")
+	}
+
+	client.Post.Update().Where(post.IDEQ(p.ID)).ClearPublishAt().ExecX(ctx)
+	if _, ok := idx.docs["Post-"+p.ID.String()]; !ok {
+		t.Fatal("Expected the published post to be indexed")
+	}
+
+	p.Update().SetPublishAt(time.Now().Add(time.Hour)).SaveX(ctx)
+	if len(idx.docs) != 0 {
+		t.Error("Expected the rescheduled post to be removed")
+	}
+}
+
+// TestReindex tests that Reindex indexes existing records
+func TestReindex(t *testing.T) {
+	ctx := context.Background()
+	client := testdb.Open(t)
+	u := client.User.Create().SetEmail("author@example.com").SetPasswordHash("x").SaveX(ctx)
+	for i := 0; i < 3; i++ {
+		client.Post.Create().SetSubject("s").SetBody("b").SetAuthor(u).SaveX(ctx)
+	}
+	client.Post.Create().SetSubject("s").SetBody("b").SetAuthor(u).SetPublishAt(time.Now().Add(time.Hour)).SaveX(ctx)
+
+	idx := newMemIndex()
+	n, err := Reindex(ctx, idx, Posts(client))
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if n != 3 || len(idx.docs) != 3 {
+		t.Errorf("Reindex indexed %d (index has %d), want 3", n, len(idx.docs))
+	}
+}
+
+// TestDocumentExcerpt tests cutting bodies at a word boundary
+func TestDocumentExcerpt(t *testing.T) {
+	doc := Document{Body: "one two\nthree four"}
+	if got := doc.Excerpt(100); got != "one two three four" {
+		t.Errorf("Excerpt(100) = %q", got)
+	}
+	if got := doc.Excerpt(10); got != "one two…" {
+		t.Errorf("Excerpt(10) = %q", got)
+	}
+}
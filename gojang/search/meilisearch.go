@@ -0,0 +1,137 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Meilisearch is an Indexer backed by a Meilisearch server
+// (https://www.meilisearch.com). Writes are queued by Meilisearch and become
+// searchable shortly after they return.
+type Meilisearch struct {
+	host   string
+	apiKey string
+	index  string
+	client *http.Client
+}
+
+// meiliDocument is a Document keyed across types, since Meilisearch needs one primary key
+type meiliDocument struct {
+	Key string `json:"key"`
+	Document
+}
+
+// NewMeilisearch connects to the index on a Meilisearch server, configuring which
+// attributes are searchable and filterable
+func NewMeilisearch(host, apiKey, index string) (*Meilisearch, error) {
+	m := &Meilisearch{
+		host:   strings.TrimRight(host, "/"),
+		apiKey: apiKey,
+		index:  index,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	settings := map[string]interface{}{
+		"searchableAttributes": []string{"title", "body"},
+		"filterableAttributes": []string{"type"},
+	}
+	if err := m.do(context.Background(), http.MethodPatch, "/settings", settings, nil); err != nil {
+		return nil, fmt.Errorf("failed to configure meilisearch index: %w", err)
+	}
+	return m, nil
+}
+
+func (m *Meilisearch) Index(ctx context.Context, docs ...Document) error {
+	body := make([]meiliDocument, len(docs))
+	for i, doc := range docs {
+		body[i] = meiliDocument{Key: doc.key(), Document: doc}
+	}
+	return m.do(ctx, http.MethodPost, "/documents?primaryKey=key", body, nil)
+}
+
+func (m *Meilisearch) Delete(ctx context.Context, docType string, ids ...string) error {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = Document{Type: docType, ID: id}.key()
+	}
+	return m.do(ctx, http.MethodPost, "/documents/delete-batch", keys, nil)
+}
+
+func (m *Meilisearch) Search(ctx context.Context, q Query) (*Results, error) {
+	body := map[string]interface{}{
+		"q":                q.Text,
+		"limit":            q.limit(),
+		"offset":           q.Offset,
+		"showRankingScore": true,
+	}
+	if len(q.Types) > 0 {
+		quoted := make([]string, len(q.Types))
+		for i, t := range q.Types {
+			quoted[i] = strconv.Quote(t)
+		}
+		body["filter"] = "type IN [" + strings.Join(quoted, ", ") + "]"
+	}
+
+	var res struct {
+		Hits []struct {
+			Document
+			RankingScore float64 `json:"_rankingScore"`
+		} `json:"hits"`
+		EstimatedTotalHits int `json:"estimatedTotalHits"`
+	}
+	if err := m.do(ctx, http.MethodPost, "/search", body, &res); err != nil {
+		return nil, err
+	}
+
+	results := &Results{Total: res.EstimatedTotalHits, Hits: make([]Hit, len(res.Hits))}
+	for i, hit := range res.Hits {
+		results.Hits[i] = Hit{Document: hit.Document, Score: hit.RankingScore}
+	}
+	return results, nil
+}
+
+func (m *Meilisearch) Close() error {
+	m.client.CloseIdleConnections()
+	return nil
+}
+
+// do sends a JSON request to a path under the index and decodes the response into out
+func (m *Meilisearch) do(ctx context.Context, method, path string, in, out interface{}) error {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	endpoint := m.host + "/indexes/" + url.PathEscape(m.index) + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("meilisearch %s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
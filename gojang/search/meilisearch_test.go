@@ -0,0 +1,92 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestMeilisearch tests the requests sent to Meilisearch and decoding of search results
+func TestMeilisearch(t *testing.T) {
+	var requests []string
+	var bodies []interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		var body interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+
+		if r.URL.Path == "/indexes/site/search" {
+			w.Write([]byte(`{"hits":[{"id":"1","type":"Post","title":"Hello","url":"/posts#post-1","_rankingScore":0.9}],"estimatedTotalHits":7}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"taskUid":1}`))
+	}))
+	defer srv.Close()
+
+	idx, err := Open(srv.URL+"?index=site", "secret")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	ctx := context.Background()
+	if err := idx.Index(ctx, Document{ID: "1", Type: "Post", Title: "Hello"}); err != nil {
+		t.Fatalf("Index: %v", err)
+	}
+	if err := idx.Delete(ctx, "Post", "1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	res, err := idx.Search(ctx, Query{Text: "hello", Types: []string{"Post"}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	want := []string{
+		"PATCH /indexes/site/settings",
+		"POST /indexes/site/documents?primaryKey=key",
+		"POST /indexes/site/documents/delete-batch",
+		"POST /indexes/site/search",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, requests[i], want[i])
+		}
+	}
+
+	if key := bodies[1].([]interface{})[0].(map[string]interface{})["key"]; key != "Post-1" {
+		t.Errorf("indexed key = %v, want Post-1", key)
+	}
+	if filter := bodies[3].(map[string]interface{})["filter"]; filter != `type IN ["Post"]` {
+		t.Errorf("filter = %v", filter)
+	}
+	if res.Total != 7 || len(res.Hits) != 1 || res.Hits[0].Title != "Hello" || res.Hits[0].Score != 0.9 {
+		t.Errorf("unexpected results: %+v", res)
+	}
+}
+
+// TestMeilisearch_Error tests that error responses are returned
+func TestMeilisearch_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"invalid api key"}`, http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	if _, err := Open(srv.URL, "wrong"); err == nil {
+		t.Error("expected an error for a rejected API key")
+	}
+}
+
+// TestOpen_UnsupportedScheme tests that unknown SEARCH_URL schemes are rejected
+func TestOpen_UnsupportedScheme(t *testing.T) {
+	if _, err := Open("elastic://localhost", ""); err == nil {
+		t.Error("expected an error for an unsupported scheme")
+	}
+}
@@ -0,0 +1,114 @@
+// Package search indexes records for full-text search. An Indexer is either the
+// embedded Bleve index (the default) or a Meilisearch server; Hook keeps it up to
+// date as records are created, updated and deleted through Ent.
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Document is what gets indexed for one record
+type Document struct {
+	ID    string `json:"id"`    // Record ID
+	Type  string `json:"type"`  // Model name (e.g., "Post"); results can be narrowed by type
+	Title string `json:"title"` // Weighted above the body when ranking
+	Body  string `json:"body"`
+	URL   string `json:"url"` // Where a result links to
+}
+
+// key identifies a document across types (record IDs are only unique per model)
+func (d Document) key() string {
+	return d.Type + "-" + d.ID
+}
+
+// Excerpt returns the start of the body, cut at a word boundary after at most n runes
+func (d Document) Excerpt(n int) string {
+	body := []rune(strings.Join(strings.Fields(d.Body), " "))
+	if len(body) <= n {
+		return string(body)
+	}
+	cut := string(body[:n])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// Query is a search request
+type Query struct {
+	Text   string
+	Types  []string // Only match these document types (empty = all)
+	Limit  int      // Defaults to 20
+	Offset int
+}
+
+// Hit is a matching document
+type Hit struct {
+	Document
+	Score float64
+}
+
+// Results is one page of hits, best first
+type Results struct {
+	Hits  []Hit
+	Total int // Matches across all pages
+}
+
+// Indexer stores documents and searches them
+type Indexer interface {
+	// Index adds or replaces documents
+	Index(ctx context.Context, docs ...Document) error
+	// Delete removes documents of a type by record ID
+	Delete(ctx context.Context, docType string, ids ...string) error
+	// Search returns documents matching q.Text
+	Search(ctx context.Context, q Query) (*Results, error)
+	Close() error
+}
+
+// defaultLimit is the page size when Query.Limit is unset
+const defaultLimit = 20
+
+func (q Query) limit() int {
+	if q.Limit <= 0 {
+		return defaultLimit
+	}
+	return q.Limit
+}
+
+// Open returns the Indexer for a SEARCH_URL:
+//
+//	bleve://./data/search.bleve    embedded Bleve index in that directory
+//	bleve://                       in-memory Bleve index (lost on restart)
+//	http://localhost:7700          Meilisearch; add ?index=name to pick the index (default "gojang")
+//
+// apiKey is only used for Meilisearch.
+func Open(searchURL, apiKey string) (Indexer, error) {
+	switch {
+	case strings.HasPrefix(searchURL, "bleve://"):
+		idx, err := NewBleve(strings.TrimPrefix(searchURL, "bleve://"))
+		if err != nil {
+			return nil, err
+		}
+		return idx, nil
+	case strings.HasPrefix(searchURL, "http://"), strings.HasPrefix(searchURL, "https://"):
+		u, err := url.Parse(searchURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search URL: %w", err)
+		}
+		index := u.Query().Get("index")
+		if index == "" {
+			index = "gojang"
+		}
+		u.RawQuery = ""
+		idx, err := NewMeilisearch(u.String(), apiKey, index)
+		if err != nil {
+			return nil, err
+		}
+		return idx, nil
+	default:
+		return nil, fmt.Errorf("unsupported search URL scheme: %s", searchURL)
+	}
+}
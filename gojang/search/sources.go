@@ -0,0 +1,47 @@
+package search
+
+import (
+	"context"
+
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/gojangframework/gojang/gojang/models/post"
+	"github.com/google/uuid"
+)
+
+// Posts indexes posts by subject and body, linking results to the post on /posts
+func Posts(client *models.Client) Source {
+	return Source{
+		Type: "Post",
+		Document: func(record interface{}) Document {
+			p := record.(*models.Post)
+			return Document{
+				ID:    p.ID.String(),
+				Type:  "Post",
+				Title: p.Subject,
+				Body:  p.Body,
+				URL:   "/posts#post-" + p.ID.String(),
+			}
+		},
+		Load: func(ctx context.Context, ids []uuid.UUID) ([]interface{}, error) {
+			posts, err := client.Post.Query().Where(post.IDIn(ids...)).All(ctx)
+			return records(posts), err
+		},
+		All: func(ctx context.Context) ([]interface{}, error) {
+			posts, err := client.Post.Query().All(ctx)
+			return records(posts), err
+		},
+		// Scheduled posts are indexed when the publish task clears PublishAt
+		Hidden: func(record interface{}) bool {
+			return record.(*models.Post).PublishAt != nil
+		},
+	}
+}
+
+// records converts a slice of Ent entities for Source.Load and Source.All
+func records[T any](entities []T) []interface{} {
+	out := make([]interface{}, len(entities))
+	for i, e := range entities {
+		out[i] = e
+	}
+	return out
+}
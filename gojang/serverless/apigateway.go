@@ -0,0 +1,222 @@
+// Package serverless runs an http.Handler on function platforms. On AWS
+// Lambda, Start polls the runtime API and translates API Gateway and function
+// URL events to requests; platforms that already speak HTTP, like Google Cloud
+// Functions, can call the handler's ServeHTTP directly.
+//
+// Build the handler once, before the first event (see app.Options.Serverless),
+// so a cold start parses templates and opens the database a single time.
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIGatewayRequest is an API Gateway proxy event. REST APIs send payload
+// format 1.0; HTTP APIs and Lambda function URLs send 2.0, which has a version
+// field and raw path and query string.
+type APIGatewayRequest struct {
+	Version string `json:"version"`
+
+	// Format 1.0
+	HTTPMethod                      string              `json:"httpMethod"`
+	Path                            string              `json:"path"`
+	MultiValueHeaders               map[string][]string `json:"multiValueHeaders"`
+	MultiValueQueryStringParameters map[string][]string `json:"multiValueQueryStringParameters"`
+
+	// Format 2.0
+	RawPath        string   `json:"rawPath"`
+	RawQueryString string   `json:"rawQueryString"`
+	Cookies        []string `json:"cookies"`
+
+	// Both formats (2.0 joins repeated headers with commas)
+	Headers               map[string]string `json:"headers"`
+	QueryStringParameters map[string]string `json:"queryStringParameters"`
+	RequestContext        struct {
+		Identity struct {
+			SourceIP string `json:"sourceIp"`
+		} `json:"identity"` // 1.0
+		HTTP struct {
+			Method   string `json:"method"`
+			SourceIP string `json:"sourceIp"`
+		} `json:"http"` // 2.0
+	} `json:"requestContext"`
+	Body            string `json:"body"`
+	IsBase64Encoded bool   `json:"isBase64Encoded"`
+}
+
+// APIGatewayResponse answers an API Gateway proxy event in the request's format
+type APIGatewayResponse struct {
+	StatusCode        int                 `json:"statusCode"`
+	Headers           map[string]string   `json:"headers,omitempty"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders,omitempty"`
+	Cookies           []string            `json:"cookies,omitempty"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded"`
+}
+
+// v2 reports whether the event uses payload format 2.0
+func (e *APIGatewayRequest) v2() bool {
+	return e.Version == "2.0"
+}
+
+// HTTPRequest converts the event to a request for an http.Handler
+func (e *APIGatewayRequest) HTTPRequest(ctx context.Context) (*http.Request, error) {
+	method, path, query, remoteIP := e.HTTPMethod, e.Path, "", e.RequestContext.Identity.SourceIP
+	if e.v2() {
+		method, path, query, remoteIP = e.RequestContext.HTTP.Method, e.RawPath, e.RawQueryString, e.RequestContext.HTTP.SourceIP
+	} else {
+		values := url.Values{}
+		for key, vals := range e.MultiValueQueryStringParameters {
+			values[key] = vals
+		}
+		// Events from tests and older integrations may only have single values
+		for key, val := range e.QueryStringParameters {
+			if _, ok := values[key]; !ok {
+				values.Set(key, val)
+			}
+		}
+		query = values.Encode()
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	body := []byte(e.Body)
+	if e.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(e.Body)
+		if err != nil {
+			return nil, fmt.Errorf("serverless: decode body: %w", err)
+		}
+		body = decoded
+	}
+
+	target := path
+	if query != "" {
+		target += "?" + query
+	}
+	r, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("serverless: %w", err)
+	}
+
+	for key, vals := range e.MultiValueHeaders {
+		for _, val := range vals {
+			r.Header.Add(key, val)
+		}
+	}
+	for key, val := range e.Headers {
+		if r.Header.Get(key) == "" {
+			r.Header.Set(key, val)
+		}
+	}
+	if len(e.Cookies) > 0 {
+		r.Header.Set("Cookie", strings.Join(e.Cookies, "; "))
+	}
+
+	r.Host = r.Header.Get("Host")
+	r.RemoteAddr = remoteIP
+	r.ContentLength = int64(len(body))
+	r.RequestURI = target
+	return r, nil
+}
+
+// Serve runs an event through h and returns its response
+func (e *APIGatewayRequest) Serve(ctx context.Context, h http.Handler) (*APIGatewayResponse, error) {
+	r, err := e.HTTPRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w := newResponseWriter()
+	h.ServeHTTP(w, r)
+	return w.apiGatewayResponse(e.v2()), nil
+}
+
+// responseWriter buffers a response, since API Gateway needs it whole
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{header: http.Header{}}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.header.Get("Content-Type") == "" {
+		w.header.Set("Content-Type", http.DetectContentType(b))
+	}
+	return w.body.Write(b)
+}
+
+// Flush is a no-op; the response is sent once the handler returns
+func (w *responseWriter) Flush() {}
+
+func (w *responseWriter) apiGatewayResponse(v2 bool) *APIGatewayResponse {
+	res := &APIGatewayResponse{StatusCode: w.status}
+	if res.StatusCode == 0 {
+		res.StatusCode = http.StatusOK
+	}
+
+	if isText(w.header) {
+		res.Body = w.body.String()
+	} else {
+		res.Body = base64.StdEncoding.EncodeToString(w.body.Bytes())
+		res.IsBase64Encoded = true
+	}
+
+	if !v2 {
+		res.MultiValueHeaders = map[string][]string(w.header)
+		return res
+	}
+	// Format 2.0 has a single value per header; cookies are listed separately
+	res.Headers = map[string]string{}
+	for key, vals := range w.header {
+		if key == "Set-Cookie" {
+			res.Cookies = vals
+			continue
+		}
+		res.Headers[key] = strings.Join(vals, ",")
+	}
+	return res
+}
+
+// isText reports whether a response body can be sent as a string. Anything
+// else (images, compressed responses) is base64-encoded.
+func isText(header http.Header) bool {
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch {
+	case mediaType == "", strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "json"), strings.HasSuffix(mediaType, "xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/javascript", "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package serverless
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// echoHandler reports what it received and sets a cookie
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	cookie, _ := r.Cookie("session_id")
+	http.SetCookie(w, &http.Cookie{Name: "seen", Value: "1"})
+	http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark"})
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "Cookie")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	io.WriteString(w, strings.Join([]string{
+		r.Method, r.URL.Path, r.URL.Query().Get("tag"), strings.Join(r.URL.Query()["tag"], ","),
+		r.Host, r.RemoteAddr, r.Header.Get("X-Forwarded-Proto"), cookie.Value, string(body),
+	}, "|"))
+})
+
+func decodeEvent(t *testing.T, event string) *APIGatewayRequest {
+	var e APIGatewayRequest
+	if err := json.Unmarshal([]byte(event), &e); err != nil {
+		t.Fatal(err)
+	}
+	return &e
+}
+
+// TestServe_V1 tests REST API (payload format 1.0) events
+func TestServe_V1(t *testing.T) {
+	e := decodeEvent(t, `{
+		"httpMethod": "POST",
+		"path": "/posts/new",
+		"headers": {"Host": "example.com", "Cookie": "session_id=abc"},
+		"multiValueHeaders": {"X-Forwarded-Proto": ["https"]},
+		"queryStringParameters": {"tag": "b"},
+		"multiValueQueryStringParameters": {"tag": ["a", "b"]},
+		"requestContext": {"identity": {"sourceIp": "203.0.113.7"}},
+		"body": "dGl0bGU9SGk=",
+		"isBase64Encoded": true
+	}`)
+
+	res, err := e.Serve(context.Background(), echoHandler)
+	if err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		t.Errorf("StatusCode = %d, want 201", res.StatusCode)
+	}
+	if want := "POST|/posts/new|a|a,b|example.com|203.0.113.7|https|abc|title=Hi"; res.Body != want || res.IsBase64Encoded {
+		t.Errorf("Body = %q, want %q as text", res.Body, want)
+	}
+	if got := res.MultiValueHeaders["Set-Cookie"]; len(got) != 2 {
+		t.Errorf("Expected both cookies in multi-value headers, got %v", got)
+	}
+	if res.Headers != nil || res.Cookies != nil {
+		t.Errorf("Expected only format 1.0 fields, got %+v", res)
+	}
+}
+
+// TestServe_V2 tests HTTP API and function URL (payload format 2.0) events
+func TestServe_V2(t *testing.T) {
+	e := decodeEvent(t, `{
+		"version": "2.0",
+		"rawPath": "/search",
+		"rawQueryString": "tag=a&tag=b",
+		"cookies": ["session_id=xyz", "other=1"],
+		"headers": {"host": "example.com", "x-forwarded-proto": "https"},
+		"requestContext": {"http": {"method": "GET", "sourceIp": "198.51.100.2"}}
+	}`)
+
+	res, err := e.Serve(context.Background(), echoHandler)
+	if err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if want := "GET|/search|a|a,b|example.com|198.51.100.2|https|xyz|"; res.Body != want {
+		t.Errorf("Body = %q, want %q", res.Body, want)
+	}
+	if strings.Join(res.Cookies, ";") != "seen=1;theme=dark" {
+		t.Errorf("Expected cookies in their own field, got %v", res.Cookies)
+	}
+	if res.Headers["Vary"] != "Accept,Cookie" || res.Headers["Set-Cookie"] != "" || res.MultiValueHeaders != nil {
+		t.Errorf("Expected comma-joined headers without Set-Cookie, got %v", res.Headers)
+	}
+}
+
+func TestServe_BinaryBody(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00")
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(png)
+	})
+
+	res, err := (&APIGatewayRequest{HTTPMethod: "GET", Path: "/media/a.png"}).Serve(context.Background(), h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.IsBase64Encoded || res.Body != base64.StdEncoding.EncodeToString(png) {
+		t.Errorf("Expected the image to be base64-encoded, got %+v", res)
+	}
+	if res.StatusCode != http.StatusOK || res.MultiValueHeaders["Content-Type"][0] != "image/png" {
+		t.Errorf("Expected a sniffed 200 image/png response, got %+v", res)
+	}
+}
+
+func TestIsText(t *testing.T) {
+	tests := map[string]bool{
+		"text/html; charset=utf-8": true,
+		"application/json":         true,
+		"application/ld+json":      true,
+		"image/svg+xml":            true,
+		"application/javascript":   true,
+		"image/png":                false,
+		"application/pdf":          false,
+	}
+	for contentType, want := range tests {
+		if got := isText(http.Header{"Content-Type": {contentType}}); got != want {
+			t.Errorf("isText(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+	if isText(http.Header{"Content-Type": {"text/html"}, "Content-Encoding": {"gzip"}}) {
+		t.Error("Expected compressed responses to be binary")
+	}
+}
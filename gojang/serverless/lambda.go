@@ -0,0 +1,142 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// runtimeAPIVersion is the Lambda runtime API path prefix
+const runtimeAPIVersion = "/2018-06-01/runtime"
+
+// Start serves Lambda invocations with h until the process is stopped. It
+// talks to the runtime API directly (provided.al2023 runtime, binary named
+// bootstrap), so no AWS SDK is needed. It returns an error outside Lambda.
+func Start(h http.Handler) error {
+	api := os.Getenv("AWS_LAMBDA_RUNTIME_API")
+	if api == "" {
+		return errors.New("serverless: AWS_LAMBDA_RUNTIME_API is not set; run this binary on AWS Lambda")
+	}
+	rt := &lambdaRuntime{
+		baseURL: "http://" + api + runtimeAPIVersion,
+		// Waiting for the next event blocks until one arrives, so no timeout
+		client: &http.Client{},
+	}
+	return rt.serve(context.Background(), h)
+}
+
+// lambdaRuntime is a client for the Lambda runtime API
+type lambdaRuntime struct {
+	baseURL string
+	client  *http.Client
+}
+
+// invocation is an event waiting for a response
+type invocation struct {
+	id       string
+	deadline time.Time
+	payload  []byte
+}
+
+// serve handles invocations one at a time, as Lambda sends them, until ctx is
+// canceled or the runtime API fails
+func (rt *lambdaRuntime) serve(ctx context.Context, h http.Handler) error {
+	for {
+		if err := rt.handleNext(ctx, h); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// handleNext waits for an invocation and replies with h's response
+func (rt *lambdaRuntime) handleNext(ctx context.Context, h http.Handler) error {
+	inv, err := rt.next(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := rt.invoke(ctx, h, inv)
+	if err != nil {
+		return rt.post(ctx, inv.id, "error", lambdaError{Type: "InvalidEvent", Message: err.Error()})
+	}
+	return rt.post(ctx, inv.id, "response", res)
+}
+
+// invoke runs one event through h before the invocation's deadline
+func (rt *lambdaRuntime) invoke(ctx context.Context, h http.Handler, inv *invocation) (*APIGatewayResponse, error) {
+	if !inv.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, inv.deadline)
+		defer cancel()
+	}
+
+	var event APIGatewayRequest
+	if err := json.Unmarshal(inv.payload, &event); err != nil {
+		return nil, fmt.Errorf("serverless: decode event: %w", err)
+	}
+	return event.Serve(ctx, h)
+}
+
+// next waits for the next invocation
+func (rt *lambdaRuntime) next(ctx context.Context) (*invocation, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rt.baseURL+"/invocation/next", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rt.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("serverless: next invocation: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("serverless: next invocation: %s", resp.Status)
+	}
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("serverless: next invocation: %w", err)
+	}
+	inv := &invocation{id: resp.Header.Get("Lambda-Runtime-Aws-Request-Id"), payload: payload}
+	if ms, err := strconv.ParseInt(resp.Header.Get("Lambda-Runtime-Deadline-Ms"), 10, 64); err == nil {
+		inv.deadline = time.UnixMilli(ms)
+	}
+	return inv, nil
+}
+
+// lambdaError reports a failed invocation
+type lambdaError struct {
+	Type    string `json:"errorType"`
+	Message string `json:"errorMessage"`
+}
+
+// post sends an invocation's response or error
+func (rt *lambdaRuntime) post(ctx context.Context, id, kind string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	url := rt.baseURL + "/invocation/" + id + "/" + kind
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := rt.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("serverless: send %s: %w", kind, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("serverless: send %s: %s", kind, resp.Status)
+	}
+	return nil
+}
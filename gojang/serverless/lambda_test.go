@@ -0,0 +1,78 @@
+package serverless
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeRuntime is a Lambda runtime API that hands out events and records replies
+type fakeRuntime struct {
+	events  chan string
+	replies chan string // "<id>/<kind> <body>"
+}
+
+func (f *fakeRuntime) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == runtimeAPIVersion+"/invocation/next" {
+		select {
+		case event := <-f.events:
+			w.Header().Set("Lambda-Runtime-Aws-Request-Id", "req-"+strconv.Itoa(len(event)))
+			w.Header().Set("Lambda-Runtime-Deadline-Ms", strconv.FormatInt(time.Now().Add(time.Minute).UnixMilli(), 10))
+			io.WriteString(w, event)
+		case <-r.Context().Done():
+		}
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	w.WriteHeader(http.StatusAccepted)
+	f.replies <- r.URL.Path[len(runtimeAPIVersion+"/invocation/"):] + " " + string(body)
+}
+
+// TestLambdaRuntime tests serving invocations and reporting bad events
+func TestLambdaRuntime(t *testing.T) {
+	fake := &fakeRuntime{events: make(chan string, 2), replies: make(chan string, 2)}
+	api := httptest.NewServer(fake)
+	defer api.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rt := &lambdaRuntime{baseURL: api.URL + runtimeAPIVersion, client: api.Client()}
+	done := make(chan error)
+	go func() { done <- rt.serve(ctx, echoHandler) }()
+
+	event := `{"httpMethod":"GET","path":"/","headers":{"Cookie":"session_id=s"}}`
+	fake.events <- event
+	reply := <-fake.replies
+	id := "req-" + strconv.Itoa(len(event))
+	if reply[:len(id)+9] != id+"/response" {
+		t.Fatalf("Expected a response for %s, got %s", id, reply)
+	}
+	var res APIGatewayResponse
+	if err := json.Unmarshal([]byte(reply[len(id)+10:]), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusCreated || res.Body == "" {
+		t.Errorf("Expected the handler's response, got %+v", res)
+	}
+
+	fake.events <- `not json`
+	if reply := <-fake.replies; reply[:len("req-8/error")] != "req-8/error" {
+		t.Errorf("Expected an error for an invalid event, got %s", reply)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected serve to stop cleanly, got %v", err)
+	}
+}
+
+func TestStart_OutsideLambda(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_RUNTIME_API", "")
+	if err := Start(echoHandler); err == nil {
+		t.Error("Expected an error without AWS_LAMBDA_RUNTIME_API")
+	}
+}
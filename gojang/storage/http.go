@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Handler serves files from fs, mounted under its public URL prefix:
+//
+//	r.Handle("/media/*", http.StripPrefix("/media", storage.Handler(fs)))
+//
+// Directories are never listed and uploaded HTML is served as a download, so
+// user files can't run scripts on the site's origin.
+func Handler(fs Filesystem) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		f, err := fs.Open(r.Context(), name)
+		if errors.Is(err, ErrNotExist) || errors.Is(err, ErrInvalidName) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to open file", http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+
+		contentType := ContentType(name)
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if strings.HasPrefix(contentType, "text/html") || strings.HasPrefix(contentType, "image/svg") {
+			w.Header().Set("Content-Disposition", "attachment")
+		}
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+
+		// Local files support range requests (seeking in video and audio) and
+		// conditional requests; other stores stream the whole file
+		if rs, ok := f.(io.ReadSeeker); ok {
+			var modified time.Time
+			if st, ok := f.(interface{ Stat() (os.FileInfo, error) }); ok {
+				if info, err := st.Stat(); err == nil {
+					modified = info.ModTime()
+				}
+			}
+			http.ServeContent(w, r, name, modified, rs)
+			return
+		}
+		w.Header().Set("Accept-Ranges", "none")
+		if r.Method == http.MethodHead {
+			return
+		}
+		io.Copy(w, f)
+	})
+}
+
+// mediaTypes covers common upload formats that the system's MIME table, which
+// is often missing in containers, may not know
+var mediaTypes = map[string]string{
+	".avif":  "image/avif",
+	".csv":   "text/csv; charset=utf-8",
+	".gif":   "image/gif",
+	".ico":   "image/x-icon",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".m4a":   "audio/mp4",
+	".mov":   "video/quicktime",
+	".mp3":   "audio/mpeg",
+	".mp4":   "video/mp4",
+	".ogg":   "audio/ogg",
+	".pdf":   "application/pdf",
+	".png":   "image/png",
+	".svg":   "image/svg+xml",
+	".txt":   "text/plain; charset=utf-8",
+	".wav":   "audio/wav",
+	".webm":  "video/webm",
+	".webp":  "image/webp",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".zip":   "application/zip",
+}
+
+// ContentType returns the MIME type to serve the named file with, falling back
+// to application/octet-stream so browsers don't guess
+func ContentType(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	if t, ok := mediaTypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// SaveUpload stores an uploaded file under dir with a random name that keeps
+// its extension (e.g., "uploads/2024/05/9f1c...e2.jpg") and returns that name.
+// The upload must pass rules and the Scanner (see SetScanner); otherwise it
+// returns a *RejectedError.
+func SaveUpload(r *http.Request, fs Filesystem, fh *multipart.FileHeader, dir string, rules Rules) (string, error) {
+	if _, err := CheckUpload(fh, rules); err != nil {
+		return "", err
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := scanUpload(r.Context(), f, fh); err != nil {
+		return "", err
+	}
+
+	name := path.Join(dir, time.Now().UTC().Format("2006/01"), uuid.NewString()+uploadExt(fh.Filename))
+	if err := fs.Save(r.Context(), name, f); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// uploadExt returns a file name's extension if it's short and alphanumeric, lowercased
+func uploadExt(filename string) string {
+	ext := strings.ToLower(path.Ext(strings.ReplaceAll(filename, "\\", "/")))
+	if len(ext) < 2 || len(ext) > 10 {
+		return ""
+	}
+	for _, c := range ext[1:] {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return ""
+		}
+	}
+	return ext
+}
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestLocal tests saving, opening, linking and deleting files on disk
+func TestLocal(t *testing.T) {
+	fs, err := NewLocal(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := fs.Save(ctx, "docs/a b.txt", strings.NewReader("hello")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	f, err := fs.Open(ctx, "docs/a b.txt")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	got, _ := io.ReadAll(f)
+	f.Close()
+	if string(got) != "hello" {
+		t.Errorf("Open returned %q, want %q", got, "hello")
+	}
+
+	if url := fs.URL("docs/a b.txt"); url != "/media/docs/a%20b.txt" {
+		t.Errorf("URL = %q", url)
+	}
+
+	if err := fs.Delete(ctx, "docs/a b.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := fs.Open(ctx, "docs/a b.txt"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Open after Delete: got %v, want ErrNotExist", err)
+	}
+	if _, err := fs.Open(ctx, "docs"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Open on a directory: got %v, want ErrNotExist", err)
+	}
+}
+
+// TestLocal_List tests listing files by prefix
+func TestLocal_List(t *testing.T) {
+	fs, _ := NewLocal(t.TempDir(), "")
+	ctx := context.Background()
+	for _, name := range []string{"uploads/2024/05/b.jpg", "uploads/a.txt", "uploads-old/c.txt", "cache/d.png"} {
+		fs.Save(ctx, name, strings.NewReader("data"))
+	}
+
+	files, err := fs.List(ctx, "uploads/")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+		if f.Size != 4 || f.ModTime.IsZero() {
+			t.Errorf("%s: size %d, modified %v", f.Name, f.Size, f.ModTime)
+		}
+	}
+	if got := strings.Join(names, ","); got != "uploads/2024/05/b.jpg,uploads/a.txt" {
+		t.Errorf("List(uploads/) = %s", got)
+	}
+
+	if files, err := fs.List(ctx, ""); err != nil || len(files) != 4 {
+		t.Errorf("List(\"\") returned %d files, %v", len(files), err)
+	}
+	if files, err := fs.List(ctx, "missing/"); err != nil || len(files) != 0 {
+		t.Errorf("List(missing/) = %v, %v; want no files", files, err)
+	}
+}
+
+// TestLocal_InvalidNames tests that names can't escape the storage root
+func TestLocal_InvalidNames(t *testing.T) {
+	fs, _ := NewLocal(t.TempDir(), "")
+	for _, name := range []string{"", "/etc/passwd", "../secret", "a/../../secret", "..\\secret"} {
+		if err := fs.Save(context.Background(), name, strings.NewReader("x")); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("Save(%q): got %v, want ErrInvalidName", name, err)
+		}
+	}
+}
+
+// TestHandler tests serving stored files
+func TestHandler(t *testing.T) {
+	fs, _ := NewLocal(t.TempDir(), "")
+	ctx := context.Background()
+	fs.Save(ctx, "img/logo.png", strings.NewReader("png"))
+	fs.Save(ctx, "page.html", strings.NewReader("<script>"))
+	h := http.StripPrefix("/media", Handler(fs))
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+		attachment  bool
+	}{
+		{"/media/img/logo.png", http.StatusOK, "image/png", false},
+		{"/media/page.html", http.StatusOK, "text/html; charset=utf-8", true},
+		{"/media/img/", http.StatusNotFound, "", false},
+		{"/media/missing.png", http.StatusNotFound, "", false},
+		{"/media/../go.mod", http.StatusNotFound, "", false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.path, rec.Code, tt.status)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != tt.contentType {
+			t.Errorf("%s: Content-Type = %q, want %q", tt.path, ct, tt.contentType)
+		}
+		if got := rec.Header().Get("Content-Disposition") == "attachment"; got != tt.attachment {
+			t.Errorf("%s: attachment = %v, want %v", tt.path, got, tt.attachment)
+		}
+	}
+}
+
+// TestHandler_Range tests that local files answer range and conditional requests
+func TestHandler_Range(t *testing.T) {
+	fs, _ := NewLocal(t.TempDir(), "")
+	fs.Save(context.Background(), "clip.mp4", strings.NewReader("0123456789"))
+	h := http.StripPrefix("/media", Handler(fs))
+
+	req := httptest.NewRequest(http.MethodGet, "/media/clip.mp4", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "2345" {
+		t.Fatalf("Expected 206 with bytes 2-5, got %d %q", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "video/mp4" {
+		t.Errorf("Content-Type = %q, want video/mp4", ct)
+	}
+
+	modified := rec.Header().Get("Last-Modified")
+	if modified == "" {
+		t.Fatal("Expected a Last-Modified header")
+	}
+	req = httptest.NewRequest(http.MethodGet, "/media/clip.mp4", nil)
+	req.Header.Set("If-Modified-Since", modified)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for an unchanged file, got %d", rec.Code)
+	}
+}
+
+func TestContentType(t *testing.T) {
+	tests := map[string]string{
+		"a/video.MP4":  "video/mp4",
+		"font.woff2":   "font/woff2",
+		"photo.jpg":    "image/jpeg",
+		"style.css":    "text/css; charset=utf-8",
+		"archive.xyz1": "application/octet-stream",
+		"noext":        "application/octet-stream",
+	}
+	for name, want := range tests {
+		if got := ContentType(name); got != want {
+			t.Errorf("ContentType(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// TestSaveUpload tests that uploads get random names that keep safe extensions
+func TestSaveUpload(t *testing.T) {
+	fs, _ := NewLocal(t.TempDir(), "")
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("photo", `C:\Users\me\Holiday Photo.JPG`)
+	part.Write([]byte("jpeg"))
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := SaveUpload(req, fs, req.MultipartForm.File["photo"][0], "uploads", Rules{})
+	if err != nil {
+		t.Fatalf("SaveUpload: %v", err)
+	}
+	if !regexp.MustCompile(`^uploads/\d{4}/\d{2}/[0-9a-f-]{36}\.jpg$`).MatchString(name) {
+		t.Errorf("unexpected name %q", name)
+	}
+	if _, err := fs.Open(context.Background(), name); err != nil {
+		t.Errorf("uploaded file not stored: %v", err)
+	}
+
+	if ext := uploadExt("evil.php%00.png "); ext != "" {
+		t.Errorf("uploadExt kept unsafe extension %q", ext)
+	}
+}
@@ -0,0 +1,297 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// S3Config configures an S3-compatible bucket
+type S3Config struct {
+	Bucket    string
+	Prefix    string // Stored names are placed under this key prefix (optional)
+	Region    string // Defaults to "us-east-1" ("auto" for GCS and R2)
+	Endpoint  string // Defaults to AWS (https://s3.<region>.amazonaws.com)
+	PathStyle bool   // Address the bucket as endpoint/bucket instead of bucket.endpoint (MinIO)
+	AccessKey string
+	SecretKey string
+	PublicURL string // URL prefix for links, e.g. a CDN (defaults to the bucket URL)
+}
+
+// S3 stores files in an S3-compatible bucket. Requests are signed with AWS
+// Signature Version 4, which Google Cloud Storage also accepts with HMAC keys.
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3 returns a Filesystem for the bucket described by cfg
+func NewS3(cfg S3Config) (*S3, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("storage: S3 bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("storage: invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+
+	return &S3{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+func (s *S3) Save(ctx context.Context, name string, r io.Reader) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		header.Set("Content-Type", ct)
+	}
+	resp, err := s.do(ctx, http.MethodPut, name, body, header)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the named file. S3 reports success for missing files, so
+// ErrNotExist is never returned.
+func (s *S3) Delete(ctx context.Context, name string) error {
+	resp, err := s.do(ctx, http.MethodDelete, name, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// listResult is the response of ListObjectsV2
+type listResult struct {
+	Contents []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+func (s *S3) List(ctx context.Context, prefix string) ([]FileInfo, error) {
+	keyPrefix := prefix
+	if s.cfg.Prefix != "" {
+		keyPrefix = s.cfg.Prefix + "/" + prefix
+	}
+
+	files := []FileInfo{}
+	token := ""
+	for {
+		// The query is built by hand because it must be sorted and escaped
+		// exactly as in the signed canonical request
+		u := s.objectURL("")
+		if s.cfg.PathStyle {
+			u.Path = strings.TrimSuffix(u.Path, "/")
+			u.RawPath = ""
+		}
+		u.RawQuery = "list-type=2&prefix=" + awsEscapeQuery(keyPrefix)
+		if token != "" {
+			u.RawQuery = "continuation-token=" + awsEscapeQuery(token) + "&" + u.RawQuery
+		}
+
+		resp, err := s.send(ctx, http.MethodGet, u, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result listResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("storage: decoding S3 listing: %w", err)
+		}
+
+		for _, obj := range result.Contents {
+			name := obj.Key
+			if s.cfg.Prefix != "" {
+				name = strings.TrimPrefix(name, s.cfg.Prefix+"/")
+			}
+			files = append(files, FileInfo{Name: name, Size: obj.Size, ModTime: obj.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return files, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *S3) URL(name string) string {
+	key, err := s.key(name)
+	if err != nil {
+		return ""
+	}
+	if s.cfg.PublicURL != "" {
+		return s.cfg.PublicURL + "/" + EscapePath(key)
+	}
+	return s.objectURL(key).String()
+}
+
+// key returns the object key for a file name
+func (s *S3) key(name string) (string, error) {
+	cleaned, err := cleanName(name)
+	if err != nil {
+		return "", err
+	}
+	if s.cfg.Prefix != "" {
+		return s.cfg.Prefix + "/" + cleaned, nil
+	}
+	return cleaned, nil
+}
+
+// objectURL addresses an object virtual-hosted style (bucket.host/key) or path style (host/bucket/key)
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	objectPath := "/" + key
+	if s.cfg.PathStyle {
+		objectPath = "/" + s.cfg.Bucket + objectPath
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+	}
+	u.Path = u.Path + objectPath
+	u.RawPath = awsEscapePath(u.Path)
+	return &u
+}
+
+// do sends a signed request for an object and returns the response if it succeeded
+func (s *S3) do(ctx context.Context, method, name string, body []byte, header http.Header) (*http.Response, error) {
+	key, err := s.key(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.send(ctx, method, s.objectURL(key), body, header)
+}
+
+// send signs and sends a request to the bucket
+func (s *S3) send(ctx context.Context, method string, u *url.URL, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if s.cfg.AccessKey != "" {
+		s.sign(req, body, time.Now().UTC())
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotExist
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("storage: S3 %s %s: %s: %s", method, u.Path, resp.Status, bytes.TrimSpace(msg))
+	}
+	return resp, nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to req
+func (s *S3) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	signingKey = hmacSHA256(signingKey, s.cfg.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsEscapePath percent-encodes everything but unreserved characters and slashes,
+// as Signature Version 4 requires
+func awsEscapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// awsEscapeQuery percent-encodes a query value for Signature Version 4
+func awsEscapeQuery(v string) string {
+	return strings.ReplaceAll(awsEscapePath(v), "/", "%2F")
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestS3 tests the requests sent to an S3-compatible server
+func TestS3(t *testing.T) {
+	objects := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=key/") || !strings.Contains(auth, "/auto/s3/aws4_request") {
+			t.Errorf("unexpected Authorization %q", auth)
+		}
+		if r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") == "" {
+			t.Error("missing signed X-Amz headers")
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			if ct := r.Header.Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.EscapedPath()] = string(body)
+		case http.MethodGet:
+			body, ok := objects[r.URL.EscapedPath()]
+			if !ok {
+				http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, body)
+		case http.MethodDelete:
+			delete(objects, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	fs, err := Open("s3://media/site?region=auto&path_style=true&endpoint="+srv.URL, Options{AccessKey: "key", SecretKey: "secret"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := fs.Save(ctx, "docs/a b!.txt", strings.NewReader("hello")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, ok := objects["/media/site/docs/a%20b%21.txt"]; !ok {
+		t.Fatalf("object stored at unexpected path: %v", objects)
+	}
+
+	f, err := fs.Open(ctx, "docs/a b!.txt")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	got, _ := io.ReadAll(f)
+	f.Close()
+	if string(got) != "hello" {
+		t.Errorf("Open returned %q", got)
+	}
+
+	if err := fs.Delete(ctx, "docs/a b!.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := fs.Open(ctx, "docs/a b!.txt"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Open after Delete: got %v, want ErrNotExist", err)
+	}
+
+	if url := fs.URL("docs/a.txt"); url != srv.URL+"/media/site/docs/a.txt" {
+		t.Errorf("URL = %q", url)
+	}
+}
+
+// TestS3_List tests paginated ListObjectsV2 requests
+func TestS3_List(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/media" || r.URL.Query().Get("list-type") != "2" || r.URL.Query().Get("prefix") != "site/uploads/" {
+			t.Errorf("unexpected list request %s", r.URL)
+		}
+		if r.URL.Query().Get("continuation-token") == "" {
+			io.WriteString(w, `<ListBucketResult><Contents><Key>site/uploads/a.jpg</Key><Size>10</Size>`+
+				`<LastModified>2024-05-01T10:00:00.000Z</LastModified></Contents>`+
+				`<IsTruncated>true</IsTruncated><NextContinuationToken>next/page</NextContinuationToken></ListBucketResult>`)
+			return
+		}
+		io.WriteString(w, `<ListBucketResult><Contents><Key>site/uploads/b.jpg</Key><Size>20</Size>`+
+			`<LastModified>2024-05-02T10:00:00.000Z</LastModified></Contents><IsTruncated>false</IsTruncated></ListBucketResult>`)
+	}))
+	defer srv.Close()
+
+	fs, _ := Open("s3://media/site?region=auto&path_style=true&endpoint="+srv.URL, Options{AccessKey: "key", SecretKey: "secret"})
+	files, err := fs.List(context.Background(), "uploads/")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(files) != 2 || files[0].Name != "uploads/a.jpg" || files[1].Name != "uploads/b.jpg" || files[1].Size != 20 {
+		t.Errorf("List = %+v", files)
+	}
+	if files[0].ModTime.Day() != 1 {
+		t.Errorf("ModTime = %v", files[0].ModTime)
+	}
+}
+
+// TestS3_URL tests virtual-hosted and public URLs
+func TestS3_URL(t *testing.T) {
+	fs, _ := NewS3(S3Config{Bucket: "media", Region: "eu-west-1"})
+	if url := fs.URL("a.jpg"); url != "https://media.s3.eu-west-1.amazonaws.com/a.jpg" {
+		t.Errorf("URL = %q", url)
+	}
+
+	fs, _ = NewS3(S3Config{Bucket: "media", PublicURL: "https://cdn.example.com/"})
+	if url := fs.URL("a b.jpg"); url != "https://cdn.example.com/a%20b.jpg" {
+		t.Errorf("URL with PublicURL = %q", url)
+	}
+}
+
+// TestOpen_UnsupportedScheme tests that unknown STORAGE_URL schemes are rejected
+func TestOpen_UnsupportedScheme(t *testing.T) {
+	if _, err := Open("ftp://example.com", Options{}); err == nil {
+		t.Error("expected an error for an unsupported scheme")
+	}
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
+)
+
+// Rules limits what an upload may be. Types are matched against the content
+// type sniffed from the file's first bytes, never its name or the type the
+// browser claimed.
+type Rules struct {
+	MaxSize int64    // Largest size in bytes; 0 for no limit
+	Types   []string // Allowed types, e.g., "application/pdf" or "image/*"; empty allows any
+}
+
+// RejectedError is returned for uploads that break their Rules or that the
+// Scanner refuses. Reason is safe to show to the uploader.
+type RejectedError struct {
+	Reason string
+}
+
+func (e *RejectedError) Error() string {
+	return "storage: upload rejected: " + e.Reason
+}
+
+// Scanner checks an upload's contents before it's saved, e.g., with an
+// antivirus. It returns a *RejectedError for files it refuses and other errors
+// when it can't scan, which refuse the upload too.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) error
+}
+
+// scanner and quarantine are set with SetScanner and SetQuarantine
+var (
+	scanner    Scanner
+	quarantine Filesystem
+)
+
+// SetScanner sets the Scanner SaveUpload runs on every upload; nil turns
+// scanning off
+func SetScanner(s Scanner) {
+	scanner = s
+}
+
+// SetQuarantine sets where uploads the Scanner refuses are kept for review.
+// It must not be publicly served. With none, refused uploads are discarded.
+func SetQuarantine(fs Filesystem) {
+	quarantine = fs
+}
+
+// CheckUpload checks an upload's size and sniffed content type against rules
+// and returns the content type. It doesn't run the Scanner.
+func CheckUpload(fh *multipart.FileHeader, rules Rules) (string, error) {
+	if rules.MaxSize > 0 && fh.Size > rules.MaxSize {
+		return "", &RejectedError{Reason: fmt.Sprintf("must be at most %s", formatSize(rules.MaxSize))}
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+
+	if len(rules.Types) == 0 {
+		return contentType, nil
+	}
+	for _, allowed := range rules.Types {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(contentType, prefix+"/") || allowed == contentType {
+			return contentType, nil
+		}
+	}
+	return "", &RejectedError{Reason: fmt.Sprintf("must be %s, not %s", strings.Join(rules.Types, ", "), contentType)}
+}
+
+// scanUpload runs the Scanner on f, quarantining the upload if it's refused
+func scanUpload(ctx context.Context, f multipart.File, fh *multipart.FileHeader) error {
+	if scanner == nil {
+		return nil
+	}
+	err := scanner.Scan(ctx, f)
+	var rejected *RejectedError
+	if errors.As(err, &rejected) {
+		quarantineUpload(ctx, f, fh, rejected.Reason)
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("storage: scanning upload: %w", err)
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return err
+}
+
+// quarantineUpload keeps a refused upload in the quarantine, if one is set
+func quarantineUpload(ctx context.Context, f multipart.File, fh *multipart.FileHeader, reason string) {
+	if quarantine == nil {
+		utils.Warnw("storage.upload_rejected", "file", fh.Filename, "size", fh.Size, "reason", reason)
+		return
+	}
+
+	name := path.Join(time.Now().UTC().Format("2006/01/02"), uuid.NewString()+uploadExt(fh.Filename))
+	_, err := f.Seek(0, io.SeekStart)
+	if err == nil {
+		err = quarantine.Save(ctx, name, f)
+	}
+	if err != nil {
+		utils.Errorw("storage.quarantine_failed", "file", fh.Filename, "reason", reason, "error", err)
+		return
+	}
+	utils.Warnw("storage.upload_quarantined", "file", fh.Filename, "size", fh.Size, "reason", reason, "name", name)
+}
+
+// formatSize formats a byte count for rejection messages, e.g., "5 MB"
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20 && n%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", n>>20)
+	case n >= 1<<10 && n%(1<<10) == 0:
+		return fmt.Sprintf("%d KB", n>>10)
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
+
+// ClamAV scans uploads with a clamd daemon, over TCP or a Unix socket
+type ClamAV struct {
+	Network string        // "tcp" or "unix"
+	Addr    string        // e.g., "localhost:3310" or "/var/run/clamav/clamd.ctl"
+	Timeout time.Duration // For the whole scan; 0 means 30 seconds
+}
+
+// OpenScanner returns the Scanner for an UPLOAD_SCANNER URL, or nil for "":
+//
+//	clamav://localhost:3310                  clamd over TCP
+//	clamav:///var/run/clamav/clamd.ctl       clamd over a Unix socket
+func OpenScanner(scannerURL string) (Scanner, error) {
+	if scannerURL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(scannerURL)
+	if err != nil || u.Scheme != "clamav" {
+		return nil, fmt.Errorf("unsupported upload scanner %q (use clamav://host:port or clamav:///path/to/socket)", scannerURL)
+	}
+	if u.Host != "" {
+		return &ClamAV{Network: "tcp", Addr: u.Host}, nil
+	}
+	return &ClamAV{Network: "unix", Addr: u.Path}, nil
+}
+
+// Scan streams r to clamd with its INSTREAM command
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) error {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, c.Network, c.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// Chunks are prefixed with their length; an empty chunk ends the stream
+	w := bufio.NewWriter(conn)
+	w.WriteString("zINSTREAM\x00")
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			binary.Write(w, binary.BigEndian, uint32(n))
+			w.Write(buf[:n])
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	binary.Write(w, binary.BigEndian, uint32(0))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	reply = strings.TrimSuffix(reply, "\x00")
+	switch {
+	case strings.HasSuffix(reply, " OK"):
+		return nil
+	case strings.HasSuffix(reply, " FOUND"):
+		virus := strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND")
+		return &RejectedError{Reason: "contains malware (" + virus + ")"}
+	}
+	return fmt.Errorf("clamd: %s", reply)
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// pngHeader is enough of a PNG for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// uploadRequest returns a parsed multipart request with one "file" upload
+func uploadRequest(t *testing.T, filename string, content []byte) (*http.Request, *multipart.FileHeader) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", filename)
+	part.Write(content)
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+	return req, req.MultipartForm.File["file"][0]
+}
+
+func TestCheckUpload(t *testing.T) {
+	images := Rules{Types: []string{"image/*"}}
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		rules    Rules
+		want     string // Content type, or the rejection reason
+		rejected bool
+	}{
+		{"any type", "notes.txt", []byte("hello"), Rules{}, "text/plain", false},
+		{"wildcard", "photo.png", pngHeader, images, "image/png", false},
+		{"exact type", "photo.png", pngHeader, Rules{Types: []string{"image/jpeg", "image/png"}}, "image/png", false},
+		{"spoofed extension", "photo.png", []byte("<html><script>alert(1)</script>"), images, "must be image/*, not text/html", true},
+		{"too large", "photo.png", pngHeader, Rules{MaxSize: 8}, "must be at most 8 bytes", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, fh := uploadRequest(t, tt.filename, tt.content)
+			contentType, err := CheckUpload(fh, tt.rules)
+			var rejected *RejectedError
+			if tt.rejected {
+				if !errors.As(err, &rejected) || rejected.Reason != tt.want {
+					t.Errorf("Expected rejection %q, got %v", tt.want, err)
+				}
+				return
+			}
+			if err != nil || contentType != tt.want {
+				t.Errorf("Expected %q, got %q (%v)", tt.want, contentType, err)
+			}
+		})
+	}
+
+	if got := formatSize(5 << 20); got != "5 MB" {
+		t.Errorf("formatSize(5 MB) = %q", got)
+	}
+}
+
+// scannerFunc adapts a function to Scanner
+type scannerFunc func(ctx context.Context, r io.Reader) error
+
+func (f scannerFunc) Scan(ctx context.Context, r io.Reader) error { return f(ctx, r) }
+
+func TestSaveUpload_Scanner(t *testing.T) {
+	media, _ := NewLocal(t.TempDir(), "")
+	held, _ := NewLocal(t.TempDir(), "")
+	t.Cleanup(func() { SetScanner(nil); SetQuarantine(nil) })
+	ctx := context.Background()
+
+	// The scanner reads the whole file, and the saved copy is still complete
+	SetScanner(scannerFunc(func(ctx context.Context, r io.Reader) error {
+		data, _ := io.ReadAll(r)
+		if bytes.Contains(data, []byte("EICAR")) {
+			return &RejectedError{Reason: "contains malware (Eicar-Test-Signature)"}
+		}
+		return nil
+	}))
+	SetQuarantine(held)
+
+	req, fh := uploadRequest(t, "clean.txt", []byte("clean file"))
+	name, err := SaveUpload(req, media, fh, "uploads", Rules{})
+	if err != nil {
+		t.Fatalf("SaveUpload: %v", err)
+	}
+	f, _ := media.Open(ctx, name)
+	if data, _ := io.ReadAll(f); string(data) != "clean file" {
+		t.Errorf("Expected the whole file to be saved after scanning, got %q", data)
+	}
+	f.Close()
+
+	req, fh = uploadRequest(t, "evil.txt", []byte("X5O!P%@AP EICAR test"))
+	_, err = SaveUpload(req, media, fh, "uploads", Rules{})
+	var rejected *RejectedError
+	if !errors.As(err, &rejected) || !strings.Contains(rejected.Reason, "malware") {
+		t.Fatalf("Expected the upload to be rejected, got %v", err)
+	}
+	if files, _ := media.List(ctx, "uploads/"); len(files) != 1 {
+		t.Errorf("Expected only the clean file in storage, got %v", files)
+	}
+	if files, _ := held.List(ctx, ""); len(files) != 1 || !strings.HasSuffix(files[0].Name, ".txt") {
+		t.Errorf("Expected the rejected file in quarantine, got %v", files)
+	}
+
+	// A scanner that can't scan refuses the upload without quarantining it
+	SetScanner(scannerFunc(func(ctx context.Context, r io.Reader) error { return errors.New("connection refused") }))
+	req, fh = uploadRequest(t, "clean.txt", []byte("clean file"))
+	if _, err := SaveUpload(req, media, fh, "uploads", Rules{}); err == nil || errors.As(err, &rejected) {
+		t.Errorf("Expected a scan error, got %v", err)
+	}
+}
+
+// fakeClamd answers one INSTREAM command with reply, after reading the stream
+func fakeClamd(t *testing.T, reply string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		if cmd, _ := r.ReadString(0); cmd != "zINSTREAM\x00" {
+			return
+		}
+		for {
+			var size uint32
+			if binary.Read(r, binary.BigEndian, &size) != nil || size == 0 {
+				break
+			}
+			io.CopyN(io.Discard, r, int64(size))
+		}
+		conn.Write([]byte(reply + "\x00"))
+	}()
+	return ln.Addr().String()
+}
+
+func TestClamAV(t *testing.T) {
+	ctx := context.Background()
+
+	clean := &ClamAV{Network: "tcp", Addr: fakeClamd(t, "stream: OK")}
+	if err := clean.Scan(ctx, strings.NewReader("hello")); err != nil {
+		t.Errorf("Expected a clean scan, got %v", err)
+	}
+
+	infected := &ClamAV{Network: "tcp", Addr: fakeClamd(t, "stream: Eicar-Test-Signature FOUND")}
+	var rejected *RejectedError
+	if err := infected.Scan(ctx, strings.NewReader("X5O!P%@AP")); !errors.As(err, &rejected) || rejected.Reason != "contains malware (Eicar-Test-Signature)" {
+		t.Errorf("Expected a malware rejection, got %v", err)
+	}
+
+	failing := &ClamAV{Network: "tcp", Addr: fakeClamd(t, "INSTREAM size limit exceeded. ERROR")}
+	if err := failing.Scan(ctx, strings.NewReader("big")); err == nil || errors.As(err, &rejected) {
+		t.Errorf("Expected a clamd error, got %v", err)
+	}
+}
+
+func TestOpenScanner(t *testing.T) {
+	if s, err := OpenScanner(""); s != nil || err != nil {
+		t.Errorf("Expected no scanner for an empty URL, got %v, %v", s, err)
+	}
+	if s, _ := OpenScanner("clamav://localhost:3310"); *s.(*ClamAV) != (ClamAV{Network: "tcp", Addr: "localhost:3310"}) {
+		t.Errorf("Unexpected TCP scanner %+v", s)
+	}
+	if s, _ := OpenScanner("clamav:///var/run/clamav/clamd.ctl"); *s.(*ClamAV) != (ClamAV{Network: "unix", Addr: "/var/run/clamav/clamd.ctl"}) {
+		t.Errorf("Unexpected Unix scanner %+v", s)
+	}
+	if _, err := OpenScanner("icap://scanner"); err == nil {
+		t.Error("Expected an error for an unsupported scanner")
+	}
+}
@@ -0,0 +1,132 @@
+// Package storage saves files (uploads, backups, exports) to local disk or an
+// S3-compatible bucket (AWS S3, Google Cloud Storage, MinIO, Cloudflare R2, ...)
+// behind one Filesystem interface.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotExist is returned when opening or deleting a file that isn't stored
+var ErrNotExist = errors.New("storage: file does not exist")
+
+// ErrInvalidName is returned for names that are empty, absolute or leave the root
+var ErrInvalidName = errors.New("storage: invalid file name")
+
+// Filesystem stores files by name. Names are slash-separated paths relative to
+// the storage root (e.g., "uploads/2024/05/photo.jpg").
+type Filesystem interface {
+	// Save writes r to the named file, replacing any existing file
+	Save(ctx context.Context, name string, r io.Reader) error
+	// Open returns the named file's contents; callers must close it
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	// Delete removes the named file
+	Delete(ctx context.Context, name string) error
+	// URL returns where browsers can download the named file
+	URL(name string) string
+	// List returns the files whose names start with prefix, sorted by name
+	List(ctx context.Context, prefix string) ([]FileInfo, error)
+}
+
+// FileInfo describes a stored file
+type FileInfo struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Options configures the Filesystem returned by Open
+type Options struct {
+	PublicURL string // URL prefix files are served from (local default "/media"; S3 default is the bucket URL)
+	AccessKey string // S3 access key ID (or GCS HMAC key)
+	SecretKey string // S3 secret access key
+}
+
+// Open returns the Filesystem for a STORAGE_URL:
+//
+//	file://./data/media                                   local directory
+//	s3://bucket?region=us-east-1                          AWS S3
+//	s3://bucket?endpoint=https://storage.googleapis.com   Google Cloud Storage (HMAC keys)
+//	s3://bucket?endpoint=http://localhost:9000&path_style=true   MinIO and other S3-compatible stores
+func Open(storageURL string, opts Options) (Filesystem, error) {
+	switch {
+	case strings.HasPrefix(storageURL, "file://"):
+		fs, err := NewLocal(strings.TrimPrefix(storageURL, "file://"), opts.PublicURL)
+		if err != nil {
+			return nil, err
+		}
+		return fs, nil
+	case strings.HasPrefix(storageURL, "s3://"):
+		u, err := url.Parse(storageURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid storage URL: %w", err)
+		}
+		q := u.Query()
+		fs, err := NewS3(S3Config{
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+			Region:    q.Get("region"),
+			Endpoint:  q.Get("endpoint"),
+			PathStyle: q.Get("path_style") == "true",
+			AccessKey: opts.AccessKey,
+			SecretKey: opts.SecretKey,
+			PublicURL: opts.PublicURL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return fs, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage URL scheme: %s", storageURL)
+	}
+}
+
+// defaultFS is the Filesystem used by upload fields; see SetDefault
+var defaultFS Filesystem
+
+// SetDefault sets the Filesystem upload fields save to and link from
+func SetDefault(fs Filesystem) {
+	defaultFS = fs
+}
+
+// Default returns the Filesystem set with SetDefault, or nil
+func Default() Filesystem {
+	return defaultFS
+}
+
+// URL returns the download URL of a file in the default Filesystem ("" when
+// name is empty or no default is set)
+func URL(name string) string {
+	if name == "" || defaultFS == nil {
+		return ""
+	}
+	return defaultFS.URL(name)
+}
+
+// cleanName validates a file name and returns it in canonical form
+func cleanName(name string) (string, error) {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return "", ErrInvalidName
+	}
+	cleaned := path.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidName
+	}
+	return cleaned, nil
+}
+
+// EscapePath escapes each segment of a slash-separated name for use in a URL
+func EscapePath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,26 @@
+package utils
+
+import "html"
+
+// IsHexColor reports whether s is a color in the "#rrggbb" form used by
+// <input type="color">
+func IsHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// ColorSwatch returns HTML showing a color as a swatch next to its code.
+// Values that aren't "#rrggbb" colors are shown as plain text.
+func ColorSwatch(color string) string {
+	if !IsHexColor(color) {
+		return html.EscapeString(color)
+	}
+	return `<span class="color-value"><span class="color-swatch" style="background-color: ` + color + `"></span>` + color + `</span>`
+}
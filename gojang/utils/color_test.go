@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestIsHexColor(t *testing.T) {
+	tests := map[string]bool{
+		"#1a2B3c":                 true,
+		"#000000":                 true,
+		"#fff":                    false,
+		"1a2b3c":                  false,
+		"#1a2b3g":                 false,
+		"red":                     false,
+		"#000000; background:url": false,
+	}
+	for input, expected := range tests {
+		if got := IsHexColor(input); got != expected {
+			t.Errorf("IsHexColor(%q) = %v, expected %v", input, got, expected)
+		}
+	}
+}
+
+func TestColorSwatch(t *testing.T) {
+	expected := `<span class="color-value"><span class="color-swatch" style="background-color: #1a2b3c"></span>#1a2b3c</span>`
+	if got := ColorSwatch("#1a2b3c"); got != expected {
+		t.Errorf("ColorSwatch = %q, expected %q", got, expected)
+	}
+	if got := ColorSwatch(`"><script>`); got != "&#34;&gt;&lt;script&gt;" {
+		t.Errorf("Expected an invalid color to be escaped text, got %q", got)
+	}
+}
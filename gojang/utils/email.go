@@ -0,0 +1,9 @@
+package utils
+
+import "strings"
+
+// NormalizeEmail trims surrounding whitespace and lowercases an email address so
+// "Foo@Example.com " and "foo@example.com" are stored and looked up as one account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
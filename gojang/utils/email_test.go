@@ -0,0 +1,18 @@
+package utils
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		"foo@example.com":       "foo@example.com",
+		"  Foo@Example.COM\t":   "foo@example.com",
+		"":                      "",
+		"MiXeD.Case+tag@X.org ": "mixed.case+tag@x.org",
+	}
+
+	for input, expected := range tests {
+		if got := NormalizeEmail(input); got != expected {
+			t.Errorf("NormalizeEmail(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Point is a location in decimal degrees (WGS 84). Geo fields store it as JSON
+// ({"lat": 37.7749, "lng": -122.4194}).
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// earthRadius is the mean radius of the Earth in meters
+const earthRadius = 6371008.8
+
+// mapTileURL is the tile server used by StaticMap and the map picker; set it
+// at startup with SetMapTileURL
+var mapTileURL = "https://tile.openstreetmap.org/{z}/{x}/{y}.png"
+
+// ErrInvalidPoint is returned by ParsePoint for input that isn't a location
+var ErrInvalidPoint = errors.New("invalid location")
+
+// ParsePoint reads a location entered as "lat,lng" (e.g., "37.7749, -122.4194")
+func ParsePoint(s string) (Point, error) {
+	latStr, lngStr, ok := strings.Cut(s, ",")
+	if !ok {
+		return Point{}, ErrInvalidPoint
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return Point{}, ErrInvalidPoint
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+	if err != nil {
+		return Point{}, ErrInvalidPoint
+	}
+	p := Point{Lat: lat, Lng: lng}
+	if !p.Valid() {
+		return Point{}, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// Valid reports whether the latitude and longitude are in range
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// String formats the point as "lat,lng", which ParsePoint reads back
+func (p Point) String() string {
+	return strconv.FormatFloat(p.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lng, 'f', -1, 64)
+}
+
+// Distance returns the great-circle distance between two points in meters
+func Distance(a, b Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// SetMapTileURL sets the tile server used for maps. The URL must contain
+// {z}, {x} and {y} (e.g., "https://tile.openstreetmap.org/{z}/{x}/{y}.png").
+func SetMapTileURL(url string) error {
+	for _, part := range []string{"{z}", "{x}", "{y}"} {
+		if !strings.Contains(url, part) {
+			return fmt.Errorf("map tile URL %q is missing %s", url, part)
+		}
+	}
+	mapTileURL = url
+	return nil
+}
+
+// MapTileURL returns the tile server URL set with SetMapTileURL
+func MapTileURL() string {
+	return mapTileURL
+}
+
+// Static map size and zoom level
+const (
+	staticMapWidth  = 160
+	staticMapHeight = 100
+	staticMapZoom   = 14
+	mapTileSize     = 256
+)
+
+// StaticMap returns HTML for a small map centered on p, built from the map
+// tiles that cover it, linking to OpenStreetMap. A nil point renders as "".
+func StaticMap(p *Point) string {
+	if p == nil || !p.Valid() {
+		return ""
+	}
+
+	// Web Mercator pixel position of the point at the zoom level
+	scale := float64(mapTileSize) * math.Exp2(staticMapZoom)
+	lat := math.Max(-85.0511, math.Min(85.0511, p.Lat)) * math.Pi / 180
+	x := (p.Lng + 180) / 360 * scale
+	y := (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * scale
+	left := int(x) - staticMapWidth/2
+	top := int(y) - staticMapHeight/2
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<a class="static-map" href="https://www.openstreetmap.org/?mlat=%[1]f&amp;mlon=%[2]f#map=%[3]d/%[1]f/%[2]f" target="_blank" rel="noopener" title="%[4]s (map data © OpenStreetMap contributors)" style="width:%[5]dpx;height:%[6]dpx">`,
+		p.Lat, p.Lng, staticMapZoom, html.EscapeString(p.String()), staticMapWidth, staticMapHeight)
+	tiles := 1 << staticMapZoom
+	for ty := floorDiv(top, mapTileSize); ty <= floorDiv(top+staticMapHeight-1, mapTileSize); ty++ {
+		for tx := floorDiv(left, mapTileSize); tx <= floorDiv(left+staticMapWidth-1, mapTileSize); tx++ {
+			if ty < 0 || ty >= tiles {
+				continue
+			}
+			url := strings.NewReplacer(
+				"{z}", strconv.Itoa(staticMapZoom),
+				"{x}", strconv.Itoa(((tx%tiles)+tiles)%tiles),
+				"{y}", strconv.Itoa(ty),
+			).Replace(mapTileURL)
+			fmt.Fprintf(&b, `<img src="%s" alt="" loading="lazy" style="left:%dpx;top:%dpx">`,
+				html.EscapeString(url), tx*mapTileSize-left, ty*mapTileSize-top)
+		}
+	}
+	b.WriteString(`<span class="static-map-marker"></span></a>`)
+	return b.String()
+}
+
+// floorDiv divides rounding toward negative infinity
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
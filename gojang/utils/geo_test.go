@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParsePoint(t *testing.T) {
+	tests := map[string]Point{
+		"37.7749,-122.4194":   {37.7749, -122.4194},
+		" 51.5 , -0.12 ":      {51.5, -0.12},
+		"-90,180":             {-90, 180},
+		"0,0":                 {0, 0},
+		"35.6762,139.6503000": {35.6762, 139.6503},
+	}
+	for input, expected := range tests {
+		got, err := ParsePoint(input)
+		if err != nil || got != expected {
+			t.Errorf("ParsePoint(%q) = %v, %v, expected %v", input, got, err, expected)
+		}
+		if back, err := ParsePoint(got.String()); err != nil || back != got {
+			t.Errorf("ParsePoint(%q) did not round-trip: %v, %v", got.String(), back, err)
+		}
+	}
+
+	for _, input := range []string{"", "37.7749", "abc,def", "91,0", "0,181", "1,2,3"} {
+		if _, err := ParsePoint(input); err == nil {
+			t.Errorf("ParsePoint(%q) should fail", input)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	london := Point{51.5074, -0.1278}
+	paris := Point{48.8566, 2.3522}
+	if got := Distance(london, paris); math.Abs(got-343_500) > 1_000 {
+		t.Errorf("Expected London to Paris to be about 343.5 km, got %.0f m", got)
+	}
+	if got := Distance(paris, paris); got != 0 {
+		t.Errorf("Expected a distance of 0, got %f", got)
+	}
+}
+
+func TestStaticMap(t *testing.T) {
+	if got := StaticMap(nil); got != "" {
+		t.Errorf("Expected a nil point to render empty, got %q", got)
+	}
+
+	got := StaticMap(&Point{51.5074, -0.1278})
+	for _, expected := range []string{
+		`class="static-map"`,
+		"mlat=51.507400&amp;mlon=-0.127800",
+		`src="https://tile.openstreetmap.org/14/8186/`,
+		`class="static-map-marker"`,
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("StaticMap missing %q in %s", expected, got)
+		}
+	}
+
+	// Points near the antimeridian wrap around to tiles on the other side
+	if got := StaticMap(&Point{0, 179.9999}); !strings.Contains(got, "/14/0/") {
+		t.Errorf("Expected the map to wrap around the antimeridian, got %s", got)
+	}
+}
+
+func TestSetMapTileURL(t *testing.T) {
+	defer SetMapTileURL("https://tile.openstreetmap.org/{z}/{x}/{y}.png")
+
+	if err := SetMapTileURL("https://tiles.example.com/{z}/{x}/{y}.png"); err != nil {
+		t.Fatalf("SetMapTileURL failed: %v", err)
+	}
+	if !strings.Contains(StaticMap(&Point{0, 0}), "https://tiles.example.com/14/") {
+		t.Error("Expected StaticMap to use the configured tile server")
+	}
+	if err := SetMapTileURL("https://tiles.example.com/tile.png"); err == nil {
+		t.Error("Expected an error for a URL without {z}, {x} and {y}")
+	}
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// richTextTags are the elements SanitizeHTML keeps, with their allowed attributes.
+// It covers what the bundled rich text editor (static/js/richtext.js) and
+// RenderMarkdown produce.
+var richTextTags = map[string][]string{
+	"p": nil, "div": nil, "br": nil, "hr": nil,
+	"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"ul": nil, "ol": nil, "li": nil,
+	"blockquote": nil, "pre": nil, "code": nil,
+	"a": {"href", "title"},
+}
+
+// droppedContentTags are removed together with everything inside them
+var droppedContentTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"template": true, "noscript": true, "textarea": true, "title": true, "svg": true, "math": true,
+}
+
+// allowedURLSchemes are the link schemes SanitizeHTML keeps; relative links are always kept
+var allowedURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// SanitizeHTML cleans user-submitted rich text so it is safe to render unescaped.
+// Tags outside the allowlist are removed (keeping their text), scripts and styles
+// are removed entirely, event handler and style attributes are dropped, and links
+// are limited to http, https, mailto and relative URLs.
+func SanitizeHTML(s string) string {
+	var out strings.Builder
+	var open []string
+	skipDepth := 0
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				Warnw("html.sanitize_failed", "error", z.Err())
+			}
+			break
+		}
+		token := z.Token()
+
+		if droppedContentTags[token.Data] {
+			switch tt {
+			case html.StartTagToken:
+				skipDepth++
+			case html.EndTagToken:
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			}
+			continue
+		}
+		if skipDepth > 0 {
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			out.WriteString(html.EscapeString(token.Data))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			attrs, ok := richTextTags[token.Data]
+			if !ok {
+				continue
+			}
+			out.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if !containsString(attrs, attr.Key) {
+					continue
+				}
+				if attr.Key == "href" && !isSafeURL(attr.Val) {
+					continue
+				}
+				out.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			if token.Data == "a" {
+				out.WriteString(` rel="nofollow noopener noreferrer"`)
+			}
+			out.WriteString(">")
+			if tt == html.StartTagToken && !isVoidTag(token.Data) {
+				open = append(open, token.Data)
+			}
+		case html.EndTagToken:
+			// Only close tags we opened, closing any left open inside them
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						out.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return out.String()
+}
+
+// StripHTML returns the text content of an HTML fragment (e.g., for list
+// previews and search), dropping scripts and styles
+func StripHTML(s string) string {
+	var out strings.Builder
+	skipDepth := 0
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		token := z.Token()
+		switch {
+		case droppedContentTags[token.Data] && tt == html.StartTagToken:
+			skipDepth++
+		case droppedContentTags[token.Data] && tt == html.EndTagToken:
+			if skipDepth > 0 {
+				skipDepth--
+			}
+		case tt == html.TextToken && skipDepth == 0:
+			out.WriteString(token.Data)
+		case tt == html.StartTagToken || tt == html.SelfClosingTagToken:
+			if token.Data == "br" || token.Data == "p" || token.Data == "li" || token.Data == "div" {
+				out.WriteString(" ")
+			}
+		}
+	}
+	return strings.Join(strings.Fields(out.String()), " ")
+}
+
+// isSafeURL reports whether a link target is relative or uses an allowed scheme
+func isSafeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || allowedURLSchemes[strings.ToLower(u.Scheme)]
+}
+
+func isVoidTag(tag string) bool {
+	return tag == "br" || tag == "hr"
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package utils
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := map[string]string{
+		"<p>Hello <strong>world</strong></p>":                     "<p>Hello <strong>world</strong></p>",
+		"<p onclick=\"alert(1)\" style=\"color:red\">Hi</p>":      "<p>Hi</p>",
+		"<script>alert(1)</script><p>ok</p>":                      "<p>ok</p>",
+		"<style>p{}</style><iframe src=x>in</iframe>text":         "text",
+		"<span>kept text</span>":                                  "kept text",
+		"<img src=x onerror=alert(1)>":                            "",
+		"<a href=\"https://example.com\" target=\"_blank\">x</a>": `<a href="https://example.com" rel="nofollow noopener noreferrer">x</a>`,
+		"<a href=\"javascript:alert(1)\">x</a>":                   `<a rel="nofollow noopener noreferrer">x</a>`,
+		"<a href=\"JaVaScRiPt:alert(1)\">x</a>":                   `<a rel="nofollow noopener noreferrer">x</a>`,
+		"<a href=\"java&#x09;script:alert(1)\">x</a>":             `<a rel="nofollow noopener noreferrer">x</a>`,
+		"<a href=\"/posts\">x</a>":                                `<a href="/posts" rel="nofollow noopener noreferrer">x</a>`,
+		"<ul><li>one<li>two</ul>":                                 "<ul><li>one<li>two</li></li></ul>",
+		"<em>unclosed":                                            "<em>unclosed</em>",
+		"</div>stray":                                             "stray",
+		"a<br/>b<hr>":                                             "a<br>b<hr>",
+		"1 &lt; 2 &amp; <b>x</b>":                                 "1 &lt; 2 &amp; <b>x</b>",
+	}
+
+	for input, expected := range tests {
+		if got := SanitizeHTML(input); got != expected {
+			t.Errorf("SanitizeHTML(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestStripHTML(t *testing.T) {
+	tests := map[string]string{
+		"<p>Hello <strong>world</strong></p><p>Again</p>": "Hello world Again",
+		"<script>alert(1)</script>text":                   "text",
+		"1 &lt; 2":                                        "1 < 2",
+		"":                                                "",
+	}
+
+	for input, expected := range tests {
+		if got := StripHTML(input); got != expected {
+			t.Errorf("StripHTML(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PrettyJSON formats a value as indented JSON for display. JSON documents
+// (json.RawMessage, []byte) are re-indented; other values are marshaled. Values
+// that can't be formatted are returned as-is, and null renders as "".
+func PrettyJSON(v interface{}) string {
+	var raw []byte
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case json.RawMessage:
+		raw = value
+	case []byte:
+		raw = value
+	case string:
+		raw = []byte(value)
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		raw = encoded
+	}
+
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || string(trimmed) == "null" {
+		return ""
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, trimmed, "", "  "); err != nil {
+		return string(raw)
+	}
+	return out.String()
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPrettyJSON(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected string
+	}{
+		{json.RawMessage(`{"a":1,"b":[true]}`), "{\n  \"a\": 1,\n  \"b\": [\n    true\n  ]\n}"},
+		{map[string]interface{}{"tags": []string{"x"}}, "{\n  \"tags\": [\n    \"x\"\n  ]\n}"},
+		{[]string{}, "[]"},
+		{json.RawMessage("null"), ""},
+		{nil, ""},
+		{"not json", "not json"},
+	}
+
+	for _, tt := range tests {
+		if got := PrettyJSON(tt.input); got != tt.expected {
+			t.Errorf("PrettyJSON(%v) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// maxLinkText is how much of a URL URLLink shows before truncating it
+const maxLinkText = 40
+
+// IsHTTPURL reports whether s is an absolute http or https URL with a host
+func IsHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// URLLink returns HTML for a URL as a link that opens in a new tab, shown
+// without its scheme and truncated (e.g., "example.com/docs/…"). Values that
+// aren't http(s) URLs are shown as plain text so they can't run scripts.
+func URLLink(s string) string {
+	if !IsHTTPURL(s) {
+		return html.EscapeString(s)
+	}
+	text := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(s, "https://"), "http://"), "/")
+	if runes := []rune(text); len(runes) > maxLinkText {
+		text = string(runes[:maxLinkText-1]) + "…"
+	}
+	return `<a href="` + html.EscapeString(s) + `" target="_blank" rel="noopener nofollow">` + html.EscapeString(text) + `</a>`
+}
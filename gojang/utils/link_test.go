@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestIsHTTPURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://example.com":         true,
+		"http://example.com/docs?q=1": true,
+		"example.com":                 false,
+		"javascript:alert(1)":         false,
+		"ftp://example.com/file":      false,
+		"https://":                    false,
+		"/relative/path":              false,
+	}
+	for input, expected := range tests {
+		if got := IsHTTPURL(input); got != expected {
+			t.Errorf("IsHTTPURL(%q) = %v, expected %v", input, got, expected)
+		}
+	}
+}
+
+func TestURLLink(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/":                                     `<a href="https://example.com/" target="_blank" rel="noopener nofollow">example.com</a>`,
+		"http://example.com/a?b=1&c=2":                             `<a href="http://example.com/a?b=1&amp;c=2" target="_blank" rel="noopener nofollow">example.com/a?b=1&amp;c=2</a>`,
+		"https://example.com/a/very/long/path/that/goes/on/and/on": `<a href="https://example.com/a/very/long/path/that/goes/on/and/on" target="_blank" rel="noopener nofollow">example.com/a/very/long/path/that/goes/…</a>`,
+		"javascript:alert(1)":                                      "javascript:alert(1)",
+		"":                                                         "",
+	}
+	for input, expected := range tests {
+		if got := URLLink(input); got != expected {
+			t.Errorf("URLLink(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultLogBufferSize is how many entries RecentLogs keeps
+const DefaultLogBufferSize = 1000
+
+// RecentLogs keeps the latest log entries in memory for the admin log
+// viewer. Init adds it to the logger alongside the usual output.
+var RecentLogs = NewLogBuffer(DefaultLogBufferSize)
+
+// LogEntry is a log entry kept by a LogBuffer
+type LogEntry struct {
+	Time    time.Time
+	Level   zapcore.Level
+	Message string
+	Fields  []LogField
+}
+
+// LogField is a key/value pair of a LogEntry, formatted for display
+type LogField struct {
+	Key   string
+	Value string
+}
+
+// LogFilter selects entries from a LogBuffer
+type LogFilter struct {
+	Level zapcore.Level // Minimum level
+	Query string        // Case-insensitive text in the message or a field
+	Limit int           // Most entries returned; 0 for all
+}
+
+// matches reports whether e passes the filter. query is already lowercased.
+func (f LogFilter) matches(e *LogEntry, query string) bool {
+	if e.Level < f.Level {
+		return false
+	}
+	if query == "" || strings.Contains(strings.ToLower(e.Message), query) {
+		return true
+	}
+	for _, field := range e.Fields {
+		if strings.Contains(strings.ToLower(field.Key+"="+field.Value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// LogBuffer is a fixed-size ring of log entries. The oldest entry is dropped
+// when a new one doesn't fit. It's safe for concurrent use.
+type LogBuffer struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int  // Where the next entry goes
+	full    bool // entries has wrapped around
+}
+
+// NewLogBuffer returns a buffer keeping the latest size entries
+func NewLogBuffer(size int) *LogBuffer {
+	if size <= 0 {
+		size = DefaultLogBufferSize
+	}
+	return &LogBuffer{entries: make([]LogEntry, size)}
+}
+
+// Add appends an entry, dropping the oldest if the buffer is full
+func (b *LogBuffer) Add(e LogEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[b.next] = e
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Entries returns the entries matching filter, newest first
+func (b *LogBuffer) Entries(filter LogFilter) []LogEntry {
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := b.next
+	if b.full {
+		count = len(b.entries)
+	}
+	var entries []LogEntry
+	for i := 1; i <= count; i++ {
+		e := &b.entries[(b.next-i+len(b.entries))%len(b.entries)]
+		if !filter.matches(e, query) {
+			continue
+		}
+		entries = append(entries, *e)
+		if filter.Limit > 0 && len(entries) == filter.Limit {
+			break
+		}
+	}
+	return entries
+}
+
+// Core returns a zapcore.Core that adds the entries enabled by level to b
+func (b *LogBuffer) Core(level zapcore.LevelEnabler) zapcore.Core {
+	return &logBufferCore{LevelEnabler: level, buf: b}
+}
+
+// logBufferCore is the zapcore.Core behind LogBuffer.Core
+type logBufferCore struct {
+	zapcore.LevelEnabler
+	buf    *LogBuffer
+	fields []zapcore.Field // From With
+}
+
+func (c *logBufferCore) With(fields []zapcore.Field) zapcore.Core {
+	return &logBufferCore{
+		LevelEnabler: c.LevelEnabler,
+		buf:          c.buf,
+		fields:       append(append([]zapcore.Field{}, c.fields...), fields...),
+	}
+}
+
+func (c *logBufferCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *logBufferCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	e := LogEntry{Time: ent.Time, Level: ent.Level, Message: ent.Message}
+	for key, value := range enc.Fields {
+		e.Fields = append(e.Fields, LogField{Key: key, Value: fmt.Sprint(value)})
+	}
+	sort.Slice(e.Fields, func(i, j int) bool { return e.Fields[i].Key < e.Fields[j].Key })
+	c.buf.Add(e)
+	return nil
+}
+
+func (c *logBufferCore) Sync() error { return nil }
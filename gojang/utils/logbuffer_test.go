@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogBuffer(t *testing.T) {
+	buf := NewLogBuffer(3)
+	logger := zap.New(buf.Core(zapcore.InfoLevel)).Sugar().With("request_id", "r1")
+
+	logger.Debugw("ignored")
+	for i := 1; i <= 4; i++ {
+		logger.Infow(fmt.Sprintf("entry %d", i), "n", i)
+	}
+	logger.Errorw("db.failed", "error", errors.New("connection refused"))
+
+	entries := buf.Entries(LogFilter{})
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].Message != "db.failed" || entries[2].Message != "entry 3" {
+		t.Errorf("entries = %q, %q, %q; want newest first", entries[0].Message, entries[1].Message, entries[2].Message)
+	}
+	want := []LogField{{"error", "connection refused"}, {"request_id", "r1"}}
+	if fmt.Sprint(entries[0].Fields) != fmt.Sprint(want) {
+		t.Errorf("Fields = %v, want %v", entries[0].Fields, want)
+	}
+
+	tests := map[string]struct {
+		filter LogFilter
+		want   int
+	}{
+		"level":   {LogFilter{Level: zapcore.ErrorLevel}, 1},
+		"message": {LogFilter{Query: "ENTRY"}, 2},
+		"field":   {LogFilter{Query: "n=4"}, 1},
+		"value":   {LogFilter{Query: "refused"}, 1},
+		"limit":   {LogFilter{Limit: 2}, 2},
+		"none":    {LogFilter{Query: "missing"}, 0},
+	}
+	for name, tt := range tests {
+		if got := buf.Entries(tt.filter); len(got) != tt.want {
+			t.Errorf("%s: got %d entries, want %d", name, len(got), tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdRule      = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	mdBullet    = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	mdNumbered  = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	mdCodeSpan  = regexp.MustCompile("`([^`]+)`")
+	mdLink      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdStrong    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdEmphasis  = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+	mdStrike    = regexp.MustCompile(`~~(.+?)~~`)
+	mdLineBreak = regexp.MustCompile(` {2,}\n`)
+)
+
+// RenderMarkdown converts Markdown to sanitized HTML. It supports headings,
+// paragraphs, emphasis, strikethrough, inline and fenced code, links, block
+// quotes, flat lists and horizontal rules. Raw HTML in the source is escaped.
+func RenderMarkdown(src string) string {
+	return SanitizeHTML(renderMarkdownBlocks(strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")))
+}
+
+// renderMarkdownBlocks renders lines as block elements (block quotes recurse)
+func renderMarkdownBlocks(lines []string) string {
+	var out strings.Builder
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			text := renderMarkdownInline(strings.Join(paragraph, "\n"))
+			out.WriteString("<p>" + mdLineBreak.ReplaceAllString(text, "<br>") + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case mdHeading.MatchString(trimmed):
+			flush()
+			m := mdHeading.FindStringSubmatch(trimmed)
+			tag := "h" + strconv.Itoa(len(m[1]))
+			out.WriteString("<" + tag + ">" + renderMarkdownInline(m[2]) + "</" + tag + ">\n")
+
+		case mdRule.MatchString(trimmed):
+			flush()
+			out.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quoted := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, strings.TrimPrefix(quoted, " "))
+			}
+			i--
+			out.WriteString("<blockquote>\n" + renderMarkdownBlocks(quote) + "</blockquote>\n")
+
+		case mdBullet.MatchString(trimmed) || mdNumbered.MatchString(trimmed):
+			flush()
+			item, tag := mdBullet, "ul"
+			if !mdBullet.MatchString(trimmed) {
+				item, tag = mdNumbered, "ol"
+			}
+			var items []string
+			for ; i < len(lines); i++ {
+				next := strings.TrimSpace(lines[i])
+				if m := item.FindStringSubmatch(next); m != nil {
+					items = append(items, m[1])
+				} else if next != "" && len(items) > 0 && strings.HasPrefix(lines[i], " ") {
+					// Indented lines continue the previous item
+					items[len(items)-1] += "\n" + next
+				} else {
+					break
+				}
+			}
+			i--
+			out.WriteString("<" + tag + ">\n")
+			for _, text := range items {
+				out.WriteString("<li>" + renderMarkdownInline(text) + "</li>\n")
+			}
+			out.WriteString("</" + tag + ">\n")
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return out.String()
+}
+
+// renderMarkdownInline escapes text and applies inline formatting; code spans
+// are left unformatted
+func renderMarkdownInline(text string) string {
+	var out strings.Builder
+	last := 0
+	for _, m := range mdCodeSpan.FindAllStringSubmatchIndex(text, -1) {
+		out.WriteString(formatMarkdownText(text[last:m[0]]))
+		out.WriteString("<code>" + html.EscapeString(text[m[2]:m[3]]) + "</code>")
+		last = m[1]
+	}
+	out.WriteString(formatMarkdownText(text[last:]))
+	return out.String()
+}
+
+func formatMarkdownText(text string) string {
+	text = html.EscapeString(text)
+	text = mdLink.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = mdStrong.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = mdEmphasis.ReplaceAllString(text, "<em>$1$2</em>")
+	return mdStrike.ReplaceAllString(text, "<del>$1</del>")
+}
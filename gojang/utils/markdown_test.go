@@ -0,0 +1,28 @@
+package utils
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"# Title":                          "<h1>Title</h1>\n",
+		"### Sub ###":                      "<h3>Sub</h3>\n",
+		"Hello **bold** and *em* ~~x~~":    "<p>Hello <strong>bold</strong> and <em>em</em> <del>x</del></p>\n",
+		"snake_case_name stays":            "<p>snake_case_name stays</p>\n",
+		"line one  \nline two":             "<p>line one<br>line two</p>\n",
+		"Use `a *b*` here":                 "<p>Use <code>a *b*</code> here</p>\n",
+		"```\n<b>x</b>\n```":               "<pre><code>&lt;b&gt;x&lt;/b&gt;</code></pre>\n",
+		"- one\n- two\n  more\n\n1. first": "<ul>\n<li>one</li>\n<li>two\nmore</li>\n</ul>\n<ol>\n<li>first</li>\n</ol>\n",
+		"> quoted\n> **text**":             "<blockquote>\n<p>quoted\n<strong>text</strong></p>\n</blockquote>\n",
+		"---":                              "<hr>\n",
+		"[site](https://example.com)":      "<p><a href=\"https://example.com\" rel=\"nofollow noopener noreferrer\">site</a></p>\n",
+		"[x](javascript:alert(1))":         "<p><a rel=\"nofollow noopener noreferrer\">x</a>)</p>\n",
+		"<script>alert(1)</script>":        "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+		"":                                 "",
+	}
+
+	for input, expected := range tests {
+		if got := RenderMarkdown(input); got != expected {
+			t.Errorf("RenderMarkdown(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Currency describes how amounts in a currency are entered and displayed.
+// Amounts are stored as integer minor units (e.g., cents) so they never pick
+// up floating point rounding errors.
+type Currency struct {
+	Code     string // ISO 4217 code (e.g., "USD")
+	Symbol   string // Shown before amounts (e.g., "$")
+	Decimals int    // Digits of minor units (2 for cents, 0 for yen)
+}
+
+// currencies are the currencies accepted by CURRENCY
+var currencies = map[string]Currency{
+	"USD": {"USD", "$", 2},
+	"EUR": {"EUR", "€", 2},
+	"GBP": {"GBP", "£", 2},
+	"JPY": {"JPY", "¥", 0},
+	"CNY": {"CNY", "¥", 2},
+	"KRW": {"KRW", "₩", 0},
+	"INR": {"INR", "₹", 2},
+	"CAD": {"CAD", "CA$", 2},
+	"AUD": {"AUD", "A$", 2},
+	"CHF": {"CHF", "CHF ", 2},
+	"BRL": {"BRL", "R$", 2},
+	"MXN": {"MXN", "MX$", 2},
+}
+
+// defaultCurrency is used by ParseMoney, FormatMoney and MoneyInput; set it at
+// startup with SetDefaultCurrency
+var defaultCurrency = currencies["USD"]
+
+// maxMoneyDigits keeps parsed amounts well inside int64
+const maxMoneyDigits = 15
+
+// ErrInvalidMoney is returned by ParseMoney for input that isn't an amount
+var ErrInvalidMoney = errors.New("invalid amount")
+
+// LookupCurrency returns a supported currency by its ISO 4217 code
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// SetDefaultCurrency sets the currency used to parse and format amounts
+func SetDefaultCurrency(code string) error {
+	c, ok := LookupCurrency(code)
+	if !ok {
+		return fmt.Errorf("unsupported currency %q", code)
+	}
+	defaultCurrency = c
+	return nil
+}
+
+// DefaultCurrency returns the currency set with SetDefaultCurrency (USD by default)
+func DefaultCurrency() Currency {
+	return defaultCurrency
+}
+
+// ParseMoney converts an amount entered in a form (e.g., "1,234.50", "$12",
+// "-3.5") to minor units of the default currency. The currency symbol or code,
+// spaces and thousands separators are ignored; more decimal places than the
+// currency has are rejected rather than rounded.
+func ParseMoney(s string) (int64, error) {
+	c := defaultCurrency
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	s = strings.TrimPrefix(s, strings.TrimSpace(c.Symbol))
+	s = strings.TrimPrefix(strings.TrimSpace(s), c.Code)
+	s = strings.NewReplacer(",", "", " ", "").Replace(s)
+
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if (whole == "" && frac == "") || (hasPoint && frac == "") || len(frac) > c.Decimals {
+		return 0, ErrInvalidMoney
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	digits := whole + frac + strings.Repeat("0", c.Decimals-len(frac))
+	if len(digits) > maxMoneyDigits || strings.Trim(digits, "0123456789") != "" {
+		return 0, ErrInvalidMoney
+	}
+
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidMoney
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// FormatMoney formats minor units of the default currency for display
+// (e.g., 123450 -> "$1,234.50")
+func FormatMoney(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	whole, frac := splitMinorUnits(amount)
+
+	// Group the whole part in thousands
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if frac != "" {
+		return sign + defaultCurrency.Symbol + grouped.String() + "." + frac
+	}
+	return sign + defaultCurrency.Symbol + grouped.String()
+}
+
+// MoneyInput formats minor units as a plain number for form inputs
+// (e.g., 123450 -> "1234.50"), which ParseMoney reads back unchanged
+func MoneyInput(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	whole, frac := splitMinorUnits(amount)
+	if frac != "" {
+		return sign + whole + "." + frac
+	}
+	return sign + whole
+}
+
+// splitMinorUnits returns the unsigned whole and fractional digits of an amount
+func splitMinorUnits(amount int64) (string, string) {
+	digits := strconv.FormatUint(absInt64(amount), 10)
+	decimals := defaultCurrency.Decimals
+	if decimals == 0 {
+		return digits, ""
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+}
+
+func absInt64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
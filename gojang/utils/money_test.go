@@ -0,0 +1,67 @@
+package utils
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := map[string]int64{
+		"12":        1200,
+		"12.5":      1250,
+		"12.05":     1205,
+		"$1,234.56": 123456,
+		" USD 7 ":   700,
+		"-3.50":     -350,
+		"-$3.50":    -350,
+		".99":       99,
+		"0":         0,
+	}
+	for input, expected := range tests {
+		got, err := ParseMoney(input)
+		if err != nil || got != expected {
+			t.Errorf("ParseMoney(%q) = %d, %v, expected %d", input, got, err, expected)
+		}
+	}
+
+	for _, input := range []string{"", "abc", "1.234", "12.", "1e5", "€5", "9999999999999999"} {
+		if _, err := ParseMoney(input); err == nil {
+			t.Errorf("ParseMoney(%q) should fail", input)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[int64][2]string{
+		0:         {"$0.00", "0.00"},
+		5:         {"$0.05", "0.05"},
+		123456:    {"$1,234.56", "1234.56"},
+		-350:      {"-$3.50", "-3.50"},
+		100000000: {"$1,000,000.00", "1000000.00"},
+	}
+	for amount, expected := range tests {
+		if got := FormatMoney(amount); got != expected[0] {
+			t.Errorf("FormatMoney(%d) = %q, expected %q", amount, got, expected[0])
+		}
+		if got := MoneyInput(amount); got != expected[1] {
+			t.Errorf("MoneyInput(%d) = %q, expected %q", amount, got, expected[1])
+		}
+	}
+}
+
+func TestSetDefaultCurrency(t *testing.T) {
+	defer SetDefaultCurrency("USD")
+
+	if err := SetDefaultCurrency("xyz"); err == nil {
+		t.Error("Expected an error for an unsupported currency")
+	}
+	if err := SetDefaultCurrency("jpy"); err != nil {
+		t.Fatalf("SetDefaultCurrency failed: %v", err)
+	}
+	if got, err := ParseMoney("¥1,500"); err != nil || got != 1500 {
+		t.Errorf("ParseMoney(¥1,500) = %d, %v, expected 1500", got, err)
+	}
+	if _, err := ParseMoney("15.5"); err == nil {
+		t.Error("Expected yen amounts to reject decimals")
+	}
+	if got := FormatMoney(1500); got != "¥1,500" {
+		t.Errorf("FormatMoney(1500) = %q, expected ¥1,500", got)
+	}
+}
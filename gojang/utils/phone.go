@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"html"
+	"strings"
+)
+
+// ErrInvalidPhone is returned by NormalizePhone for input that isn't an
+// international phone number
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// NormalizePhone converts a phone number entered with its country code (e.g.,
+// "+1 (415) 555-2671" or "0044 20 7946 0958") to E.164 ("+14155552671").
+// Spaces, dots, dashes and parentheses are ignored.
+func NormalizePhone(s string) (string, error) {
+	s = strings.NewReplacer(" ", "", ".", "", "-", "", "(", "", ")", "").Replace(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "00") {
+		s = "+" + s[2:]
+	}
+	digits := strings.TrimPrefix(s, "+")
+	if len(digits) == len(s) || len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return "", ErrInvalidPhone
+		}
+	}
+	return "+" + digits, nil
+}
+
+// FormatPhone formats an E.164 number for display. North American numbers are
+// grouped ("+1 (415) 555-2671"); others are shown as stored.
+func FormatPhone(phone string) string {
+	if len(phone) == 12 && strings.HasPrefix(phone, "+1") {
+		return "+1 (" + phone[2:5] + ") " + phone[5:8] + "-" + phone[8:]
+	}
+	return phone
+}
+
+// PhoneLink returns HTML for a phone number as a tel: link ("" when empty).
+// Values that aren't E.164 numbers are shown as plain text.
+func PhoneLink(phone string) string {
+	normalized, err := NormalizePhone(phone)
+	if err != nil {
+		return html.EscapeString(phone)
+	}
+	return `<a href="tel:` + normalized + `">` + html.EscapeString(FormatPhone(normalized)) + `</a>`
+}
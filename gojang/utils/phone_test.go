@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := map[string]string{
+		"+14155552671":       "+14155552671",
+		"+1 (415) 555-2671":  "+14155552671",
+		"0044 20 7946 0958":  "+442079460958",
+		" +49.30.1234.5678 ": "+493012345678",
+	}
+	for input, expected := range tests {
+		got, err := NormalizePhone(input)
+		if err != nil || got != expected {
+			t.Errorf("NormalizePhone(%q) = %q, %v, expected %q", input, got, err, expected)
+		}
+	}
+
+	for _, input := range []string{"", "4155552671", "+1415", "+0123456789", "+1 415 555 2671 ext 5", "+1234567890123456"} {
+		if _, err := NormalizePhone(input); err == nil {
+			t.Errorf("NormalizePhone(%q) should fail", input)
+		}
+	}
+}
+
+func TestPhoneLink(t *testing.T) {
+	tests := map[string]string{
+		"+14155552671":   `<a href="tel:+14155552671">+1 (415) 555-2671</a>`,
+		"+442079460958":  `<a href="tel:+442079460958">+442079460958</a>`,
+		"":               "",
+		"<b>call me</b>": "&lt;b&gt;call me&lt;/b&gt;",
+	}
+	for input, expected := range tests {
+		if got := PhoneLink(input); got != expected {
+			t.Errorf("PhoneLink(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
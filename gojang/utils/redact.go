@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive log fields
+const Redacted = "[REDACTED]"
+
+// redactKeys are matched against log field names, ignoring case; a field
+// whose name contains one (e.g., "new_password", "csrf_token") is redacted
+var redactKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey", "card_number"}
+
+// redactEmails masks email addresses in string values; see SetLogRedaction
+var redactEmails bool
+
+// emailPattern finds email addresses inside log values
+var emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)`)
+
+// SetLogRedaction adds field names to redact from structured logs (on top of
+// passwords, tokens, secrets, cookies and the like) and, with emails, masks
+// email addresses in every string value ("jane@example.com" is logged as
+// "j***@example.com"). Call it once at startup.
+func SetLogRedaction(keys []string, emails bool) {
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			redactKeys = append(redactKeys, key)
+		}
+	}
+	redactEmails = emails
+}
+
+// RedactFields returns structured log key-value pairs with sensitive values
+// replaced. The Infow family calls it on every entry, so fields are redacted
+// however they're logged; keysAndValues itself is never modified.
+func RedactFields(keysAndValues []interface{}) []interface{} {
+	var out []interface{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			continue
+		}
+		value, changed := redactValue(key, keysAndValues[i+1])
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = append([]interface{}(nil), keysAndValues...)
+		}
+		out[i+1] = value
+	}
+	if out == nil {
+		return keysAndValues
+	}
+	return out
+}
+
+// redactValue returns the value to log for a field, and whether it differs
+// from value. Values can't be compared directly, since maps aren't comparable.
+func redactValue(key string, value interface{}) (interface{}, bool) {
+	if sensitiveKey(key) {
+		return Redacted, true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			redacted[k], _ = redactValue(k, val)
+		}
+		return redacted, true
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for k, val := range v {
+			masked, _ := redactValue(k, val)
+			redacted[k] = masked.(string)
+		}
+		return redacted, true
+	case string:
+		if redactEmails && strings.Contains(v, "@") {
+			return maskEmails(v), true
+		}
+	case error:
+		if redactEmails && strings.Contains(v.Error(), "@") {
+			return maskEmails(v.Error()), true
+		}
+	}
+	return value, false
+}
+
+// sensitiveKey reports whether a field name contains one of redactKeys
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+	for _, k := range redactKeys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskEmails keeps the first letter and domain of each email address in s
+func maskEmails(s string) string {
+	return emailPattern.ReplaceAllString(s, "$1***@$2")
+}
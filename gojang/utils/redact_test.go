@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+// withLogRedaction sets the redaction settings for one test
+func withLogRedaction(t *testing.T, keys []string, emails bool) {
+	saved := append([]string(nil), redactKeys...)
+	t.Cleanup(func() { redactKeys, redactEmails = saved, false })
+	SetLogRedaction(keys, emails)
+}
+
+func TestRedactFields(t *testing.T) {
+	withLogRedaction(t, []string{"SSN"}, false)
+
+	fields := []interface{}{
+		"user_id", 42,
+		"password", "hunter2",
+		"csrf-Token", "abc",
+		"customer_ssn", "078-05-1120",
+		"email", "jane@example.com",
+		"form", map[string]interface{}{"new_password": "hunter2", "name": "Jane"},
+	}
+	got := RedactFields(fields)
+
+	want := map[string]interface{}{
+		"user_id":      42,
+		"password":     Redacted,
+		"csrf-Token":   Redacted,
+		"customer_ssn": Redacted,
+		"email":        "jane@example.com", // Emails are only masked when asked for
+	}
+	for i := 0; i < len(want)*2; i += 2 {
+		if got[i+1] != want[got[i].(string)] {
+			t.Errorf("%s = %v, want %v", got[i], got[i+1], want[got[i].(string)])
+		}
+	}
+	if form := got[11].(map[string]interface{}); form["new_password"] != Redacted || form["name"] != "Jane" {
+		t.Errorf("Expected nested passwords to be redacted, got %v", form)
+	}
+	if fields[3] != "hunter2" {
+		t.Error("Expected the caller's fields to be left alone")
+	}
+
+	clean := []interface{}{"model", "Post", "count", 3}
+	if got := RedactFields(clean); &got[0] != &clean[0] {
+		t.Error("Expected fields with nothing to redact not to be copied")
+	}
+}
+
+func TestRedactFields_Emails(t *testing.T) {
+	withLogRedaction(t, nil, true)
+
+	got := RedactFields([]interface{}{
+		"user", "jane.doe@example.com",
+		"details", "Created user: bob@mail.example.org",
+		"error", errors.New(`user "al@example.com" already exists`),
+		"count", 3,
+	})
+	want := []interface{}{
+		"user", "j***@example.com",
+		"details", "Created user: b***@mail.example.org",
+		"error", `user "a***@example.com" already exists`,
+		"count", 3,
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("field %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
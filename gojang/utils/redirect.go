@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// IsSafeRedirect reports whether target is a same-host relative path such as
+// "/posts?page=2". Absolute URLs, scheme-relative URLs ("//evil.com"), backslash
+// tricks ("/\evil.com") and control characters are rejected.
+func IsSafeRedirect(target string) bool {
+	if target == "" || target[0] != '/' {
+		return false
+	}
+	// Browsers treat "\" like "/" and strip tabs/newlines, so "/\evil.com" and
+	// "/\t/evil.com" would both become "//evil.com"
+	if strings.ContainsAny(target, "\\") {
+		return false
+	}
+	for _, c := range target {
+		if c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+	if strings.HasPrefix(target, "//") {
+		return false
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" && u.Host == "" && u.User == nil
+}
+
+// SafeRedirect returns target if IsSafeRedirect allows it, otherwise fallback.
+// Use it for any redirect destination taken from user input (e.g., ?next=).
+func SafeRedirect(target, fallback string) string {
+	if IsSafeRedirect(target) {
+		return target
+	}
+	return fallback
+}
@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func TestIsSafeRedirect(t *testing.T) {
+	tests := []struct {
+		target string
+		safe   bool
+	}{
+		{"/dashboard", true},
+		{"/posts?page=2#top", true},
+		{"/", true},
+		{"", false},
+		{"dashboard", false},
+		{"https://evil.com", false},
+		{"//evil.com", false},
+		{"/\\evil.com", false},
+		{"/\t/evil.com", false},
+		{"/\r\nSet-Cookie: x=y", false},
+		{"javascript:alert(1)", false},
+		{"///evil.com", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsSafeRedirect(tt.target); got != tt.safe {
+			t.Errorf("IsSafeRedirect(%q) = %v, expected %v", tt.target, got, tt.safe)
+		}
+	}
+}
+
+func TestSafeRedirect_Fallback(t *testing.T) {
+	if got := SafeRedirect("https://evil.com/phish", "/dashboard"); got != "/dashboard" {
+		t.Errorf("Expected fallback for external URL, got %q", got)
+	}
+	if got := SafeRedirect("/posts", "/dashboard"); got != "/posts" {
+		t.Errorf("Expected relative path to be kept, got %q", got)
+	}
+}
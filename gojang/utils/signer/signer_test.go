@@ -0,0 +1,93 @@
+package signer
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestSigner_Verify(t *testing.T) {
+	s := New("key")
+	signed := s.Sign("/download/report.pdf?user=42", time.Hour)
+	if !strings.Contains(signed, "sig=") || !strings.Contains(signed, "expires=") {
+		t.Fatalf("Expected a signature and expiry, got %q", signed)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want error
+	}{
+		{"valid", signed, nil},
+		{"changed parameter", strings.Replace(signed, "user=42", "user=43", 1), ErrInvalidSignature},
+		{"added parameter", signed + "&admin=1", ErrInvalidSignature},
+		{"changed path", strings.Replace(signed, "report", "payroll", 1), ErrInvalidSignature},
+		{"no signature", "/download/report.pdf?user=42", ErrInvalidSignature},
+		{"no expiry", s.Sign("/download/report.pdf", -time.Hour), nil}, // ttl <= 0 never expires
+		{"other key", New("other").Sign("/download/report.pdf?user=42", time.Hour), ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Verify(mustParse(t, tt.url)); !errors.Is(err, tt.want) {
+				t.Errorf("Verify(%q) = %v, want %v", tt.url, err, tt.want)
+			}
+		})
+	}
+
+	// Absolute URLs keep their host, which isn't signed
+	abs := s.Sign("https://example.com/unsubscribe?list=news", 0)
+	if !strings.HasPrefix(abs, "https://example.com/unsubscribe?") || strings.Contains(abs, "expires=") {
+		t.Errorf("Unexpected absolute URL %q", abs)
+	}
+	if err := s.Verify(mustParse(t, strings.Replace(abs, "example.com", "example.org", 1))); err != nil {
+		t.Errorf("Expected a moved host to verify, got %v", err)
+	}
+}
+
+func TestSigner_Expired(t *testing.T) {
+	s := New("key")
+	u := mustParse(t, s.Sign("/verify-email?user=42", time.Hour))
+	q := u.Query()
+	q.Set(ExpiresParam, "1") // Rewinding the expiry breaks the signature...
+	u.RawQuery = q.Encode()
+	if err := s.Verify(u); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected a tampered expiry to be invalid, got %v", err)
+	}
+
+	// ...so only a link that really expired reports ErrExpired
+	q.Del(SignatureParam)
+	q.Set(SignatureParam, signature(s.keys[0], u.Path, q))
+	u.RawQuery = q.Encode()
+	if err := s.Verify(u); !errors.Is(err, ErrExpired) {
+		t.Errorf("Expected ErrExpired, got %v", err)
+	}
+}
+
+func TestSigner_Rotation(t *testing.T) {
+	old := New("old-key").Sign("/media/file.pdf", 0)
+	rotated := New("new-key", "old-key")
+
+	if err := rotated.Verify(mustParse(t, old)); err != nil {
+		t.Errorf("Expected a link signed with the old key to verify, got %v", err)
+	}
+	if err := New("old-key").Verify(mustParse(t, rotated.Sign("/media/file.pdf", 0))); err == nil {
+		t.Error("Expected new links to be signed with the new key")
+	}
+	if err := New("new-key").Verify(mustParse(t, old)); err == nil {
+		t.Error("Expected the old key to stop working once it's removed")
+	}
+	if got := New("", "").Sign("/media/file.pdf", 0); got != "" {
+		t.Errorf("Expected no URL without keys, got %q", got)
+	}
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Form fields added by SpamTrapFields. Bots tend to fill in every input they
+// find, so a filled-in honeypot (hidden from people with CSS) gives them away.
+const (
+	HoneypotField = "hp_website"
+	TimeTrapField = "hp_issued"
+)
+
+// spamTrapMaxAge is how long a rendered form stays valid, so tokens scraped once
+// can't be replayed forever
+const spamTrapMaxAge = 24 * time.Hour
+
+// Reasons CheckSpamTrap rejects a submission
+var (
+	ErrHoneypotFilled   = errors.New("honeypot field filled in")
+	ErrSubmittedTooFast = errors.New("form submitted too quickly")
+	ErrTimeTrapInvalid  = errors.New("missing, forged or expired form timestamp")
+)
+
+var (
+	spamTrapKey      = []byte("gojang-spam-trap")
+	spamTrapMinDelay = 2 * time.Second
+)
+
+// SetSpamTrap sets the key that signs form timestamps (use SESSION_KEY) and how
+// long after a form was rendered it may be submitted; 0 only checks the honeypot
+func SetSpamTrap(key string, minDelay time.Duration) {
+	spamTrapKey = []byte(key)
+	spamTrapMinDelay = minDelay
+}
+
+// SpamTrapFields returns the honeypot input and a signed timestamp of when the
+// form was rendered, to place inside a <form> checked by middleware.SpamTrap
+func SpamTrapFields() string {
+	return fmt.Sprintf(`<div class="hp-field" aria-hidden="true">`+
+		`<label for="%[1]s">Leave this field empty</label>`+
+		`<input type="text" id="%[1]s" name="%[1]s" tabindex="-1" autocomplete="off">`+
+		`</div>`+
+		`<input type="hidden" name="%[2]s" value="%[3]s">`,
+		HoneypotField, TimeTrapField, html.EscapeString(timeTrapToken(time.Now())))
+}
+
+// CheckSpamTrap reports why a submitted form looks automated, or nil if it doesn't
+func CheckSpamTrap(form url.Values) error {
+	if form.Get(HoneypotField) != "" {
+		return ErrHoneypotFilled
+	}
+
+	issued, ok := parseTimeTrapToken(form.Get(TimeTrapField))
+	elapsed := time.Since(issued)
+	if !ok || elapsed < 0 || elapsed > spamTrapMaxAge {
+		return ErrTimeTrapInvalid
+	}
+	if elapsed < spamTrapMinDelay {
+		return ErrSubmittedTooFast
+	}
+	return nil
+}
+
+// timeTrapToken returns "<unix millis>.<signature>" for t
+func timeTrapToken(t time.Time) string {
+	stamp := strconv.FormatInt(t.UnixMilli(), 10)
+	return stamp + "." + timeTrapSignature(stamp)
+}
+
+// parseTimeTrapToken returns the time in a token made by timeTrapToken, if its signature matches
+func parseTimeTrapToken(token string) (time.Time, bool) {
+	stamp, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(timeTrapSignature(stamp))) {
+		return time.Time{}, false
+	}
+	millis, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
+
+func timeTrapSignature(stamp string) string {
+	mac := hmac.New(sha256.New, spamTrapKey)
+	mac.Write([]byte(stamp))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
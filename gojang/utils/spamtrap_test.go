@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckSpamTrap(t *testing.T) {
+	defer SetSpamTrap("gojang-spam-trap", 2*time.Second)
+	SetSpamTrap("test-key", 2*time.Second)
+
+	form := func(issued time.Time, honeypot string) url.Values {
+		return url.Values{TimeTrapField: {timeTrapToken(issued)}, HoneypotField: {honeypot}}
+	}
+	forged := form(time.Now().Add(-time.Minute), "")
+	forged.Set(TimeTrapField, strings.Replace(forged.Get(TimeTrapField), "1", "2", 1))
+
+	tests := map[string]struct {
+		form url.Values
+		want error
+	}{
+		"human":         {form(time.Now().Add(-time.Minute), ""), nil},
+		"honeypot":      {form(time.Now().Add(-time.Minute), "http://spam.example"), ErrHoneypotFilled},
+		"too fast":      {form(time.Now().Add(-time.Second), ""), ErrSubmittedTooFast},
+		"expired":       {form(time.Now().Add(-25*time.Hour), ""), ErrTimeTrapInvalid},
+		"from future":   {form(time.Now().Add(time.Hour), ""), ErrTimeTrapInvalid},
+		"forged":        {forged, ErrTimeTrapInvalid},
+		"missing token": {url.Values{}, ErrTimeTrapInvalid},
+	}
+	for name, tt := range tests {
+		if got := CheckSpamTrap(tt.form); got != tt.want {
+			t.Errorf("%s: CheckSpamTrap() = %v, expected %v", name, got, tt.want)
+		}
+	}
+
+	// Tokens signed with another key are rejected
+	token := timeTrapToken(time.Now().Add(-time.Minute))
+	SetSpamTrap("other-key", 0)
+	if err := CheckSpamTrap(url.Values{TimeTrapField: {token}}); err != ErrTimeTrapInvalid {
+		t.Errorf("Expected a token from another key to be rejected, got %v", err)
+	}
+}
+
+func TestSpamTrapFields(t *testing.T) {
+	got := SpamTrapFields()
+	for _, expected := range []string{`class="hp-field"`, `name="hp_website"`, `tabindex="-1"`, `name="hp_issued"`} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("SpamTrapFields missing %q in %s", expected, got)
+		}
+	}
+
+	token := regexp.MustCompile(`name="hp_issued" value="([^"]+)"`).FindStringSubmatch(got)
+	if token == nil {
+		t.Fatalf("No timestamp in %s", got)
+	}
+	if issued, ok := parseTimeTrapToken(token[1]); !ok || time.Since(issued) > time.Minute {
+		t.Errorf("Expected a valid token for now, got %v, %v", issued, ok)
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"time"
+)
+
+// TimeAgo describes t relative to now, e.g., "3 hours ago" or "in 2 days".
+// It returns "" for the zero time.
+func TimeAgo(t time.Time) string {
+	return timeAgo(t, time.Now())
+}
+
+func timeAgo(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Minute {
+		return "just now"
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int(d/(365*24*time.Hour)), "year"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// TimeTag returns HTML showing t in layout followed by how long ago it was,
+// e.g., <time datetime="...">2026-10-15 09:30</time> (3 hours ago).
+// The zero time is shown as "-".
+func TimeTag(t time.Time, layout string) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return `<time datetime="` + t.Format(time.RFC3339) + `">` + html.EscapeString(t.Format(layout)) + `</time>` +
+		` <span class="time-ago">` + timeAgo(t, time.Now()) + `</span>`
+}
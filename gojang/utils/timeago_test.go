@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{time.Time{}, ""},
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-45 * time.Minute), "45 minutes ago"},
+		{now.Add(-3 * time.Hour), "3 hours ago"},
+		{now.Add(-26 * time.Hour), "1 day ago"},
+		{now.Add(-65 * 24 * time.Hour), "2 months ago"},
+		{now.Add(-800 * 24 * time.Hour), "2 years ago"},
+		{now.Add(2*time.Hour + time.Minute), "in 2 hours"},
+	}
+	for _, tt := range tests {
+		if got := timeAgo(tt.t, now); got != tt.expected {
+			t.Errorf("timeAgo(%v) = %q, expected %q", tt.t, got, tt.expected)
+		}
+	}
+}
+
+func TestTimeTag(t *testing.T) {
+	ts := time.Now().Add(-3 * time.Hour).UTC()
+	got := TimeTag(ts, "2006-01-02 15:04")
+	if !strings.Contains(got, `datetime="`+ts.Format(time.RFC3339)+`"`) ||
+		!strings.Contains(got, ">"+ts.Format("2006-01-02 15:04")+"</time>") ||
+		!strings.Contains(got, "3 hours ago") {
+		t.Errorf("TimeTag = %q", got)
+	}
+	if got := TimeTag(time.Time{}, time.RFC3339); got != "-" {
+		t.Errorf("Expected the zero time to be shown as -, got %q", got)
+	}
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// Username length limits
+const (
+	MinUsernameLength = 3
+	MaxUsernameLength = 30
+)
+
+// NormalizeUsername trims surrounding whitespace and lowercases a username so
+// "Alice" and "alice" are one account, like NormalizeEmail does for emails.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// ValidateUsername checks that a normalized username is 3-30 characters of
+// lowercase letters, digits, dots, dashes or underscores. Usernames can't
+// contain "@", so a login value is never both a username and an email.
+func ValidateUsername(username string) error {
+	if len(username) < MinUsernameLength || len(username) > MaxUsernameLength {
+		return errors.New("username must be 3-30 characters long")
+	}
+	for _, char := range username {
+		switch {
+		case char >= 'a' && char <= 'z', char >= '0' && char <= '9':
+		case char == '.' || char == '-' || char == '_':
+		default:
+			return errors.New("username may only contain letters, numbers, dots, dashes and underscores")
+		}
+	}
+	return nil
+}
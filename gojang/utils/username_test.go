@@ -0,0 +1,33 @@
+package utils
+
+import "testing"
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := map[string]string{
+		"alice":      "alice",
+		"  Alice_1 ": "alice_1",
+		"":           "",
+	}
+
+	for input, expected := range tests {
+		if got := NormalizeUsername(input); got != expected {
+			t.Errorf("NormalizeUsername(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	valid := []string{"abc", "alice.smith", "bob-2", "carol_x", "a23456789012345678901234567890"}
+	for _, username := range valid {
+		if err := ValidateUsername(username); err != nil {
+			t.Errorf("ValidateUsername(%q) unexpected error: %v", username, err)
+		}
+	}
+
+	invalid := []string{"", "ab", "a234567890123456789012345678901", "alice@example.com", "Alice", "al ice"}
+	for _, username := range invalid {
+		if err := ValidateUsername(username); err == nil {
+			t.Errorf("ValidateUsername(%q) expected an error", username)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/gojangframework/gojang/gojang/version"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -33,6 +34,12 @@ func Init(level string) error {
 		cfg = zap.NewProductionConfig()
 		// Production: use JSON by default
 		cfg.Encoding = "json"
+		// Tag every entry with the build, so aggregated logs show which deploy wrote them
+		build := version.Get()
+		cfg.InitialFields = map[string]interface{}{"version": build.Version}
+		if build.Commit != "" {
+			cfg.InitialFields["commit"] = build.ShortCommit()
+		}
 	}
 
 	// Map level strings to zapcore.Level
@@ -49,7 +56,10 @@ func Init(level string) error {
 		cfg.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	}
 
-	logger, err := cfg.Build()
+	// Keep recent entries for the admin log viewer too
+	logger, err := cfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, RecentLogs.Core(cfg.Level))
+	}))
 	if err != nil {
 		return err
 	}
@@ -98,8 +108,10 @@ func Errorf(format string, args ...interface{}) {
 	Logger.Errorf(format, args...)
 }
 
-// Structured helpers that accept key/value pairs (fall back if Logger is nil)
+// Structured helpers that accept key/value pairs (fall back if Logger is nil).
+// Sensitive fields are redacted first; see RedactFields.
 func Infow(msg string, keysAndValues ...interface{}) {
+	keysAndValues = RedactFields(keysAndValues)
 	if Logger == nil {
 		// fallback to simple print
 		fmt.Printf("[INFO] %s %v\n", msg, keysAndValues)
@@ -109,6 +121,7 @@ func Infow(msg string, keysAndValues ...interface{}) {
 }
 
 func Debugw(msg string, keysAndValues ...interface{}) {
+	keysAndValues = RedactFields(keysAndValues)
 	if Logger == nil {
 		fmt.Printf("[DEBUG] %s %v\n", msg, keysAndValues)
 		return
@@ -117,6 +130,7 @@ func Debugw(msg string, keysAndValues ...interface{}) {
 }
 
 func Warnw(msg string, keysAndValues ...interface{}) {
+	keysAndValues = RedactFields(keysAndValues)
 	if Logger == nil {
 		fmt.Printf("[WARN] %s %v\n", msg, keysAndValues)
 		return
@@ -125,6 +139,7 @@ func Warnw(msg string, keysAndValues ...interface{}) {
 }
 
 func Errorw(msg string, keysAndValues ...interface{}) {
+	keysAndValues = RedactFields(keysAndValues)
 	if Logger == nil {
 		fmt.Printf("[ERROR] %s %v\n", msg, keysAndValues)
 		return
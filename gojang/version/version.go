@@ -0,0 +1,113 @@
+// Package version reports which build of the app is running. Release builds
+// set the variables below with -ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/gojangframework/gojang/gojang/version.Version=v1.4.0 \
+//	  -X github.com/gojangframework/gojang/gojang/version.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/gojangframework/gojang/gojang/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./gojang/cmd/web
+//
+// Without them, the commit and time Go stamps into binaries built from a git
+// checkout are used.
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Set with -ldflags -X
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = "" // RFC 3339
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit,omitempty"`
+	BuildTime time.Time `json:"build_time,omitzero"` // Or the commit's time, from Go's stamp
+	Modified  bool      `json:"modified,omitempty"`  // Built with uncommitted changes
+	GoVersion string    `json:"go_version"`
+}
+
+// ShortCommit is the commit's first 7 characters
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 7 {
+		return i.Commit[:7]
+	}
+	return i.Commit
+}
+
+// String is a one-line description, e.g. "v1.4.0 (3f2a9c1, 2025-06-01 12:00 UTC)"
+func (i Info) String() string {
+	var details []byte
+	if i.Commit != "" {
+		details = append(details, i.ShortCommit()...)
+		if i.Modified {
+			details = append(details, "-dirty"...)
+		}
+	}
+	if !i.BuildTime.IsZero() {
+		if len(details) > 0 {
+			details = append(details, ", "...)
+		}
+		details = i.BuildTime.UTC().AppendFormat(details, "2006-01-02 15:04 MST")
+	}
+	if len(details) == 0 {
+		return i.Version
+	}
+	return i.Version + " (" + string(details) + ")"
+}
+
+var (
+	info     Info
+	infoOnce sync.Once
+)
+
+// Get returns the running build's Info
+func Get() Info {
+	infoOnce.Do(func() {
+		info = read(Version, Commit, BuildTime)
+	})
+	return info
+}
+
+// read fills in Info from the ldflags values, falling back to the build's
+// VCS stamp
+func read(version, commit, buildTime string) Info {
+	i := Info{Version: version, Commit: commit, GoVersion: runtime.Version()}
+	if t, err := time.Parse(time.RFC3339, buildTime); err == nil {
+		i.BuildTime = t
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return i
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if i.Commit == "" {
+				i.Commit = s.Value
+			}
+		case "vcs.time":
+			if t, err := time.Parse(time.RFC3339, s.Value); err == nil && i.BuildTime.IsZero() {
+				i.BuildTime = t
+			}
+		case "vcs.modified":
+			// Only meaningful for the commit Go stamped
+			i.Modified = s.Value == "true" && commit == ""
+		}
+	}
+	return i
+}
+
+// Handler serves Get as JSON (e.g., at /version)
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(Get())
+}
@@ -0,0 +1,56 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	i := read("v1.4.0", "3f2a9c1d8e7b6a5f", "2025-06-01T12:00:00Z")
+	if i.Version != "v1.4.0" || i.Commit != "3f2a9c1d8e7b6a5f" || i.Modified {
+		t.Errorf("read = %+v", i)
+	}
+	if !i.BuildTime.Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("BuildTime = %v", i.BuildTime)
+	}
+	if got, want := i.String(), "v1.4.0 (3f2a9c1, 2025-06-01 12:00 UTC)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if i.GoVersion == "" {
+		t.Error("GoVersion is empty")
+	}
+}
+
+func TestInfoString(t *testing.T) {
+	tests := []struct {
+		info Info
+		want string
+	}{
+		{Info{Version: "dev"}, "dev"},
+		{Info{Version: "dev", Commit: "abcdef0123", Modified: true}, "dev (abcdef0-dirty)"},
+		{Info{Version: "v2", BuildTime: time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)}, "v2 (2025-01-02 03:04 UTC)"},
+	}
+	for _, tt := range tests {
+		if got := tt.info.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler(w, httptest.NewRequest("GET", "/version", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var got Info
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != Version || got.GoVersion == "" {
+		t.Errorf("served %+v", got)
+	}
+}
@@ -0,0 +1,155 @@
+// Package components holds the template partials shared by the page and admin
+// renderers: pagination, sortable table headers, the modal shell, form fields
+// and alerts/toasts. Each is a {{define}} called with a dict of parameters:
+//
+//	{{template "field" (dict "Name" "email" "Label" "Email" "Type" "email" "Required" true)}}
+//
+// See "Components" in docs/html-renderer-guide.md.
+package components
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+)
+
+// Dir holds the component files, relative to the repository root
+const Dir = "./gojang/views/components"
+
+// Funcs returns the template functions the components use, for the renderers'
+// FuncMaps. The components also call add, sub and mapTileURL, which both
+// renderers define.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"dict":       Dict,
+		"formValue":  FormValue,
+		"pageURL":    PageURL,
+		"pageWindow": PageWindow,
+		"sortURL":    SortURL,
+	}
+}
+
+// Parse adds the components in Dir to t. Each file is parsed as
+// "components/<file>", so only its {{define}}s are meant to be called.
+func Parse(t *template.Template) error {
+	files, err := filepath.Glob(filepath.Join(Dir, "*.html"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("reading component %s: %w", file, err)
+		}
+		if _, err := t.New("components/" + filepath.Base(file)).Parse(string(content)); err != nil {
+			return fmt.Errorf("parsing component %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// Dict builds a component's parameters from key/value pairs
+func Dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict: odd number of arguments")
+	}
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+// FormValue returns field from a submitted form (a struct or map) so it can be
+// shown again after a validation error. Without a form, e.g. when an edit
+// form is first opened, it returns fallback (typically the record's value).
+// Nil pointers become "".
+func FormValue(form interface{}, field string, fallback ...interface{}) interface{} {
+	v := reflect.ValueOf(form)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			v = reflect.Value{}
+			break
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if f := v.FieldByName(field); f.IsValid() {
+			return f.Interface()
+		}
+		return ""
+	case reflect.Map:
+		if f := v.MapIndex(reflect.ValueOf(field)); f.IsValid() {
+			return f.Interface()
+		}
+		return ""
+	}
+	if len(fallback) == 0 || fallback[0] == nil {
+		return ""
+	}
+	if fv := reflect.ValueOf(fallback[0]); fv.Kind() == reflect.Pointer && fv.IsNil() {
+		return ""
+	}
+	return fallback[0]
+}
+
+// PageURL returns rawURL with its page query parameter set to page
+func PageURL(rawURL string, page int) string {
+	return withQuery(rawURL, func(q url.Values) {
+		q.Set("page", strconv.Itoa(page))
+	})
+}
+
+// SortURL returns rawURL sorted by field, descending ("-field") when it's
+// already sorted ascending by field. The page is reset to the first.
+func SortURL(rawURL, field, current string) string {
+	return withQuery(rawURL, func(q url.Values) {
+		if current == field {
+			field = "-" + field
+		}
+		q.Set("sort", field)
+		q.Del("page")
+	})
+}
+
+// PageWindow returns the page numbers to link to: the first and last pages and
+// two on each side of page, with 0 where pages are skipped. A gap of a single
+// page shows the page instead.
+func PageWindow(page, pages int) []int {
+	lo, hi := page-2, page+2
+	if lo <= 3 {
+		lo = 1
+	}
+	if hi >= pages-2 {
+		hi = pages
+	}
+	var window []int
+	for p := 1; p <= pages; p++ {
+		if p == 1 || p == pages || (p >= lo && p <= hi) {
+			window = append(window, p)
+		} else if window[len(window)-1] != 0 {
+			window = append(window, 0)
+		}
+	}
+	return window
+}
+
+func withQuery(rawURL string, set func(url.Values)) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	set(q)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
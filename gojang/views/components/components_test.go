@@ -0,0 +1,192 @@
+package components
+
+import (
+	"html/template"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// render executes a component with the same helpers the renderers provide
+func render(t *testing.T, src string, data interface{}) string {
+	t.Helper()
+	funcs := Funcs()
+	funcs["add"] = func(a, b int) int { return a + b }
+	funcs["sub"] = func(a, b int) int { return a - b }
+	funcs["mapTileURL"] = func() string { return "https://tiles.example.com/{z}/{x}/{y}.png" }
+
+	tmpl := template.Must(template.New("page").Funcs(funcs).Parse(src))
+	if err := Parse(tmpl); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	return out.String()
+}
+
+// TestComponents tests rendering each component from a page
+func TestComponents(t *testing.T) {
+	t.Chdir("../../..") // Components are loaded relative to the repository root
+
+	tests := []struct {
+		name string
+		src  string
+		data interface{}
+		want []string
+		not  []string
+	}{
+		{
+			name: "text field",
+			src:  `{{template "field" (dict "Name" "email" "Label" "Email" "Type" "email" "Value" .Email "Error" .Error "Required" true)}}`,
+			data: map[string]string{"Email": "a@example.com", "Error": "Email is already taken"},
+			want: []string{`<label for="email">Email</label>`, `type="email"`, `value="a@example.com"`, ` required`, `<span class="error">Email is already taken</span>`},
+		},
+		{
+			name: "missing value",
+			src:  `{{template "field" (dict "Name" "name" "Label" "Name")}}`,
+			want: []string{`type="text"`, `value=""`},
+			not:  []string{"no value", "required", `class="error"`},
+		},
+		{
+			name: "checkbox",
+			src:  `{{template "field" (dict "Name" "active" "Label" "Active" "Type" "checkbox" "Value" true)}}`,
+			want: []string{`<input type="checkbox" id="active" name="active" checked>`},
+		},
+		{
+			name: "markdown",
+			src:  `{{template "field" (dict "Name" "notes" "Label" "Notes" "Type" "markdown")}}`,
+			want: []string{`hx-post="/markdown/preview?field=notes"`, `hx-target="#notes-preview"`, `rows="8"`, `<div id="notes-preview" class="markdown-preview richtext-content"></div>`},
+		},
+		{
+			name: "geo",
+			src:  `{{template "field" (dict "Name" "location" "Label" "Location" "Geo" true)}}`,
+			want: []string{`data-geo="https://tiles.example.com/{z}/{x}/{y}.png"`},
+		},
+		{
+			name: "modal",
+			src:  `{{template "modal" (dict "Title" "New Post")}}<p>Body</p>{{template "modal_footer" (dict "Submit" "Create Post")}}{{template "modal_end"}}`,
+			want: []string{`<div class="modal-backdrop"`, `<h3>New Post</h3>`, `<p>Body</p>`, `>Create Post</button>`},
+		},
+		{
+			name: "alert messages",
+			src:  `{{template "alert" (dict "Type" "error" "Messages" .)}}`,
+			data: map[string]string{"Name": "Name is required"},
+			want: []string{`class="alert alert-error"`, `<p>Name is required</p>`},
+		},
+		{
+			name: "empty alert",
+			src:  `{{template "alert" (dict "Message" "")}}`,
+			not:  []string{"alert"},
+		},
+		{
+			name: "toast",
+			src:  `{{template "toast" (dict "Type" "success" "Message" "Saved")}}`,
+			want: []string{`class="toast toast-success"`, `>Saved</div>`},
+		},
+		{
+			name: "sort header",
+			src:  `{{template "sort_header" (dict "Field" "name" "Label" "Name" "Sort" "name" "URL" "/posts?page=3" "Target" "#list")}}`,
+			want: []string{`aria-sort="ascending"`, `href="/posts?sort=-name"`, `hx-target="#list"`, `Name ▲`},
+		},
+		{
+			name: "pagination",
+			src:  `{{template "pagination" (dict "Page" 5 "Pages" 10 "URL" "/posts?q=go")}}`,
+			want: []string{`href="/posts?page=4&amp;q=go" rel="prev"`, `aria-current="page">5</span>`, `href="/posts?page=1&amp;q=go"`, `…`, `rel="next"`},
+			not:  []string{"hx-get"},
+		},
+		{
+			name: "single page",
+			src:  `{{template "pagination" (dict "Page" 1 "Pages" 1 "URL" "/posts")}}`,
+			not:  []string{"nav"},
+		},
+	}
+	for _, tt := range tests {
+		out := render(t, tt.src, tt.data)
+		for _, want := range tt.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s: missing %q in:\n%s", tt.name, want, out)
+			}
+		}
+		for _, not := range tt.not {
+			if strings.Contains(out, not) {
+				t.Errorf("%s: unexpected %q in:\n%s", tt.name, not, out)
+			}
+		}
+	}
+}
+
+// TestFormValue tests refilling forms from the submitted form or the record
+func TestFormValue(t *testing.T) {
+	type form struct{ Name string }
+	var nilID *int
+
+	tests := []struct {
+		name     string
+		form     interface{}
+		fallback []interface{}
+		want     interface{}
+	}{
+		{"submitted struct", form{Name: "new"}, []interface{}{"old"}, "new"},
+		{"submitted pointer", &form{Name: ""}, []interface{}{"old"}, ""},
+		{"submitted map", map[string]string{"Name": "new"}, nil, "new"},
+		{"no form", nil, []interface{}{"old"}, "old"},
+		{"nil form pointer", (*form)(nil), []interface{}{42}, 42},
+		{"no fallback", nil, nil, ""},
+		{"nil fallback pointer", nil, []interface{}{nilID}, ""},
+	}
+	for _, tt := range tests {
+		if got := FormValue(tt.form, "Name", tt.fallback...); got != tt.want {
+			t.Errorf("%s: FormValue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestPageWindow tests which page links are shown
+func TestPageWindow(t *testing.T) {
+	tests := []struct {
+		page, pages int
+		want        []int
+	}{
+		{1, 1, []int{1}},
+		{1, 5, []int{1, 2, 3, 4, 5}},
+		{1, 10, []int{1, 2, 3, 0, 10}},
+		{5, 10, []int{1, 2, 3, 4, 5, 6, 7, 0, 10}},
+		{6, 10, []int{1, 0, 4, 5, 6, 7, 8, 9, 10}},
+		{10, 10, []int{1, 0, 8, 9, 10}},
+	}
+	for _, tt := range tests {
+		if got := PageWindow(tt.page, tt.pages); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PageWindow(%d, %d) = %v, want %v", tt.page, tt.pages, got, tt.want)
+		}
+	}
+}
+
+// TestSortURL tests toggling the sort direction
+func TestSortURL(t *testing.T) {
+	tests := []struct{ current, want string }{
+		{"", "/posts?q=go&sort=name"},
+		{"name", "/posts?q=go&sort=-name"},
+		{"-name", "/posts?q=go&sort=name"},
+		{"created_at", "/posts?q=go&sort=name"},
+	}
+	for _, tt := range tests {
+		if got := SortURL("/posts?q=go&page=2", "name", tt.current); got != tt.want {
+			t.Errorf("SortURL(current %q) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+}
+
+// TestDict tests building component parameters
+func TestDict(t *testing.T) {
+	if m, err := Dict("Name", "email", "Required", true); err != nil || m["Name"] != "email" || m["Required"] != true {
+		t.Errorf("Dict = %v, %v", m, err)
+	}
+	if _, err := Dict("Name"); err == nil {
+		t.Error("Dict with an odd number of arguments should fail")
+	}
+	if _, err := Dict(1, "email"); err == nil {
+		t.Error("Dict with a non-string key should fail")
+	}
+}
@@ -1,21 +1,52 @@
 package forms
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/google/uuid"
 )
 
 var validate = validator.New()
 
-// Keep time import referenced until forms/models start using it explicitly
-// This avoids "imported and not used" compile errors while keeping the import ready.
-var _ time.Time
+func init() {
+	// "money" accepts amounts utils.ParseMoney understands (e.g., "1,234.50")
+	validate.RegisterValidation("money", func(fl validator.FieldLevel) bool {
+		_, err := utils.ParseMoney(fl.Field().String())
+		return err == nil
+	})
+	// "geo" accepts locations utils.ParsePoint understands (e.g., "37.7749,-122.4194")
+	validate.RegisterValidation("geo", func(fl validator.FieldLevel) bool {
+		_, err := utils.ParsePoint(fl.Field().String())
+		return err == nil
+	})
+	// "color" accepts "#rrggbb" colors from <input type="color">
+	validate.RegisterValidation("color", func(fl validator.FieldLevel) bool {
+		return utils.IsHexColor(fl.Field().String())
+	})
+	// "phone" accepts numbers with a country code that utils.NormalizePhone understands
+	validate.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		_, err := utils.NormalizePhone(fl.Field().String())
+		return err == nil
+	})
+	// "future" accepts <input type="datetime-local"> values after the current time
+	validate.RegisterValidation("future", func(fl validator.FieldLevel) bool {
+		t := OptionalTime(fl.Field().String())
+		return t != nil && t.After(time.Now())
+	})
+}
+
+// DateTimeLayout is the format of <input type="datetime-local"> values
+const DateTimeLayout = "2006-01-02T15:04"
 
-// LoginForm represents login form data
+// LoginForm represents login form data. Login is an email or username,
+// depending on AUTH_IDENTIFIER.
 type LoginForm struct {
-	Email    string `form:"email" validate:"required,email"`
+	Login    string `form:"login" validate:"required"`
 	Password string `form:"password" validate:"required"`
 	Next     string `form:"next"`
 }
@@ -23,6 +54,7 @@ type LoginForm struct {
 // RegisterForm represents registration form data
 type RegisterForm struct {
 	Email           string `form:"email" validate:"required,email"`
+	Username        string `form:"username"` // Checked with utils.ValidateUsername when set
 	Password        string `form:"password" validate:"required"`
 	PasswordConfirm string `form:"password_confirm" validate:"required,eqfield=Password"`
 }
@@ -38,8 +70,22 @@ type UserForm struct {
 
 // PostForm represents post create/update form
 type PostForm struct {
-	Subject string `form:"subject" validate:"required,max=255"`
-	Body    string `form:"body" validate:"required"`
+	Subject   string `form:"subject" validate:"required,max=255"`
+	Body      string `form:"body" validate:"required"`
+	PublishAt string `form:"publish_at" validate:"omitempty,future"` // Empty publishes the post now
+}
+
+// ScheduleForm represents the post reschedule form
+type ScheduleForm struct {
+	PublishAt string `form:"publish_at" validate:"omitempty,future"` // Empty publishes the post now
+}
+
+// ContactForm represents the contact form
+type ContactForm struct {
+	Name    string `form:"name" validate:"required,max=100"`
+	Email   string `form:"email" validate:"required,email"`
+	Subject string `form:"subject" validate:"max=200"`
+	Message string `form:"message" validate:"required,max=5000"`
 }
 
 // ProductForm represents product create/update form
@@ -69,6 +115,30 @@ func Validate(form interface{}) map[string]string {
 				errors[field] = "Minimum length is " + err.Param()
 			case "eqfield":
 				errors[field] = "Must match " + err.Param()
+			case "json":
+				errors[field] = "Must be valid JSON"
+			case "uuid":
+				errors[field] = "Must be a valid ID"
+			case "money":
+				errors[field] = "Must be an amount like " + utils.MoneyInput(1250)
+			case "geo":
+				errors[field] = "Must be a location like 37.7749,-122.4194"
+			case "color":
+				errors[field] = "Must be a color like #1a2b3c"
+			case "http_url":
+				errors[field] = "Must be a URL starting with http:// or https://"
+			case "phone":
+				errors[field] = "Must be a phone number with country code, like +1 415 555 2671"
+			case "future":
+				errors[field] = "Must be a date and time in the future"
+			case "gtfield", "gtefield", "ltfield", "ltefield":
+				errors[field] = compareFieldMessage(err)
+			case "required_if":
+				// Param is "OtherField value"
+				other, value, _ := strings.Cut(err.Param(), " ")
+				errors[field] = "Required when " + other + " is " + value
+			case "required_with":
+				errors[field] = "Required when " + err.Param() + " is set"
 			default:
 				errors[field] = "Invalid value"
 			}
@@ -78,6 +148,11 @@ func Validate(form interface{}) map[string]string {
 	// Additional password complexity validation for forms with Password field
 	switch f := form.(type) {
 	case RegisterForm:
+		if f.Username != "" {
+			if err := utils.ValidateUsername(f.Username); err != nil {
+				errors["Username"] = err.Error()
+			}
+		}
 		if f.Password != "" {
 			if err := utils.ValidatePasswordComplexity(f.Password); err != nil {
 				errors["Password"] = err.Error()
@@ -92,5 +167,98 @@ func Validate(form interface{}) map[string]string {
 		}
 	}
 
+	// Cross-field rules only report fields whose own tags passed
+	if v, ok := form.(FormValidator); ok {
+		for field, message := range v.ValidateForm() {
+			if _, exists := errors[field]; !exists {
+				errors[field] = message
+			}
+		}
+	}
+
 	return errors
 }
+
+// FormValidator is implemented by forms with rules that span several fields
+// (e.g., "EndsAt must be after StartsAt", "CouponCode is required for coupon
+// discounts"). Validate calls ValidateForm after the tag checks and merges the
+// returned messages, keyed by struct field name, into its errors. Define it on
+// the value receiver so it runs whether a form or a pointer to it is validated.
+//
+// Simple comparisons and conditional requirements can be declared as tags
+// instead: gtfield, gtefield, ltfield, ltefield, required_if and required_with.
+type FormValidator interface {
+	ValidateForm() map[string]string
+}
+
+// compareFieldMessage describes a failed gtfield/gtefield/ltfield/ltefield rule,
+// in terms of dates for time fields and amounts otherwise
+func compareFieldMessage(err validator.FieldError) string {
+	words := map[string][2]string{
+		"gtfield":  {"after", "greater than"},
+		"gtefield": {"on or after", "at least"},
+		"ltfield":  {"before", "less than"},
+		"ltefield": {"on or before", "at most"},
+	}[err.Tag()]
+	if err.Type() == reflect.TypeOf(time.Time{}) {
+		return "Must be " + words[0] + " " + err.Param()
+	}
+	return "Must be " + words[1] + " " + err.Param()
+}
+
+// RawJSON converts a JSON form value (validated with the "json" tag) for an Ent
+// JSON field; an empty value is stored as null
+func RawJSON(value string) json.RawMessage {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
+
+// Money converts an amount form value (validated with the "money" tag) to minor
+// units (e.g., cents) for an Ent Int64 field; an empty value is 0
+func Money(value string) int64 {
+	amount, _ := utils.ParseMoney(value)
+	return amount
+}
+
+// OptionalUUID converts a UUID form value (validated with the "uuid" tag) for an
+// optional Ent UUID field; an empty value gives nil
+func OptionalUUID(value string) *uuid.UUID {
+	id, err := uuid.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// Point converts a location form value (validated with the "geo" tag) for an Ent
+// geo field; an empty value gives nil
+func Point(value string) *utils.Point {
+	p, err := utils.ParsePoint(value)
+	if err != nil {
+		return nil
+	}
+	return &p
+}
+
+// OptionalTime converts a datetime-local form value (see DateTimeLayout) in the
+// server's time zone for an optional Ent time field; an empty or invalid value
+// gives nil
+func OptionalTime(value string) *time.Time {
+	t, err := time.ParseInLocation(DateTimeLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// Phone converts a phone form value (validated with the "phone" tag) to E.164
+// (e.g., "+14155552671"); an empty value stays empty
+func Phone(value string) string {
+	phone, err := utils.NormalizePhone(value)
+	if err != nil {
+		return value
+	}
+	return phone
+}
@@ -2,6 +2,7 @@ package forms
 
 import (
 	"testing"
+	"time"
 )
 
 func TestValidate_RegisterForm_ValidPassword(t *testing.T) {
@@ -84,6 +85,25 @@ func TestValidate_RegisterForm_PasswordMismatch(t *testing.T) {
 	}
 }
 
+func TestValidate_RegisterForm_Username(t *testing.T) {
+	form := RegisterForm{
+		Email:           "test@example.com",
+		Username:        "bad name",
+		Password:        "Password123!",
+		PasswordConfirm: "Password123!",
+	}
+
+	errors := Validate(form)
+	if _, exists := errors["Username"]; !exists {
+		t.Errorf("Expected Username error, got errors: %v", errors)
+	}
+
+	form.Username = "good_name"
+	if errors := Validate(form); len(errors) > 0 {
+		t.Errorf("Expected no errors for valid username, got: %v", errors)
+	}
+}
+
 func TestValidate_UserForm_ValidPassword(t *testing.T) {
 	form := UserForm{
 		Email:    "test@example.com",
@@ -129,7 +149,7 @@ func TestValidate_UserForm_WeakPasswordWhenProvided(t *testing.T) {
 func TestValidate_LoginForm_NoComplexityCheck(t *testing.T) {
 	// LoginForm should not validate password complexity (only during registration)
 	form := LoginForm{
-		Email:    "test@example.com",
+		Login:    "test@example.com",
 		Password: "anypassword",
 	}
 
@@ -138,3 +158,193 @@ func TestValidate_LoginForm_NoComplexityCheck(t *testing.T) {
 		t.Errorf("Expected no errors for LoginForm, got: %v", errors)
 	}
 }
+
+func TestValidate_JSONField(t *testing.T) {
+	type settingsForm struct {
+		Options string `form:"options" validate:"omitempty,json"`
+	}
+
+	if errors := Validate(settingsForm{Options: `{"a": 1}`}); len(errors) > 0 {
+		t.Errorf("Expected valid JSON to pass, got %v", errors)
+	}
+	if errors := Validate(settingsForm{Options: `{"a": }`}); errors["Options"] != "Must be valid JSON" {
+		t.Errorf("Expected a JSON error, got %v", errors)
+	}
+	if RawJSON("  ") != nil || string(RawJSON(`[1]`)) != "[1]" {
+		t.Error("Expected RawJSON to map empty input to null")
+	}
+}
+
+func TestValidate_UUIDField(t *testing.T) {
+	type refForm struct {
+		OwnerID string `form:"owner_id" validate:"omitempty,uuid"`
+	}
+
+	id := "0b6f6a3e-2f7c-4d55-9d8c-6a1f7e5b2c10"
+	if errors := Validate(refForm{OwnerID: id}); len(errors) > 0 {
+		t.Errorf("Expected a valid UUID to pass, got %v", errors)
+	}
+	if errors := Validate(refForm{OwnerID: "not-an-id"}); errors["OwnerID"] != "Must be a valid ID" {
+		t.Errorf("Expected a UUID error, got %v", errors)
+	}
+	if OptionalUUID("") != nil {
+		t.Error("Expected OptionalUUID to map empty input to nil")
+	}
+	if got := OptionalUUID(id); got == nil || got.String() != id {
+		t.Errorf("OptionalUUID(%q) = %v", id, got)
+	}
+}
+
+func TestValidate_MoneyField(t *testing.T) {
+	type priceForm struct {
+		Price string `form:"price" validate:"required,money"`
+	}
+
+	if errors := Validate(priceForm{Price: "$1,234.50"}); len(errors) > 0 {
+		t.Errorf("Expected a valid amount to pass, got %v", errors)
+	}
+	if errors := Validate(priceForm{Price: "12.345"}); errors["Price"] != "Must be an amount like 12.50" {
+		t.Errorf("Expected an amount error, got %v", errors)
+	}
+	if got := Money("$1,234.50"); got != 123450 {
+		t.Errorf("Money($1,234.50) = %d, expected 123450", got)
+	}
+}
+
+func TestValidate_GeoField(t *testing.T) {
+	type storeForm struct {
+		Location string `form:"location" validate:"omitempty,geo"`
+	}
+
+	if errors := Validate(storeForm{Location: "37.7749, -122.4194"}); len(errors) > 0 {
+		t.Errorf("Expected a valid location to pass, got %v", errors)
+	}
+	if errors := Validate(storeForm{Location: "somewhere"}); errors["Location"] != "Must be a location like 37.7749,-122.4194" {
+		t.Errorf("Expected a location error, got %v", errors)
+	}
+	if p := Point("37.7749,-122.4194"); p == nil || p.Lat != 37.7749 || p.Lng != -122.4194 {
+		t.Errorf("Point returned %v", p)
+	}
+	if p := Point(""); p != nil {
+		t.Errorf("Expected nil for an empty location, got %v", p)
+	}
+}
+
+func TestValidate_ColorURLPhoneFields(t *testing.T) {
+	type contactForm struct {
+		Color   string `form:"color" validate:"omitempty,color"`
+		Website string `form:"website" validate:"omitempty,http_url"`
+		Phone   string `form:"phone" validate:"omitempty,phone"`
+	}
+
+	valid := contactForm{Color: "#1a2b3c", Website: "https://example.com", Phone: "+1 (415) 555-2671"}
+	if errors := Validate(valid); len(errors) > 0 {
+		t.Errorf("Expected valid values to pass, got %v", errors)
+	}
+	if errors := Validate(contactForm{}); len(errors) > 0 {
+		t.Errorf("Expected empty optional values to pass, got %v", errors)
+	}
+
+	errors := Validate(contactForm{Color: "red", Website: "javascript:alert(1)", Phone: "555-2671"})
+	expected := map[string]string{
+		"Color":   "Must be a color like #1a2b3c",
+		"Website": "Must be a URL starting with http:// or https://",
+		"Phone":   "Must be a phone number with country code, like +1 415 555 2671",
+	}
+	for field, message := range expected {
+		if errors[field] != message {
+			t.Errorf("Expected %s error %q, got %q", field, message, errors[field])
+		}
+	}
+
+	if got := Phone("+1 (415) 555-2671"); got != "+14155552671" {
+		t.Errorf("Phone() = %q, expected +14155552671", got)
+	}
+}
+
+func TestValidate_FutureField(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour).Format(DateTimeLayout)
+	yesterday := time.Now().Add(-24 * time.Hour).Format(DateTimeLayout)
+
+	if errors := Validate(PostForm{Subject: "s", Body: "b", PublishAt: tomorrow}); len(errors) > 0 {
+		t.Errorf("Expected a future time to pass, got %v", errors)
+	}
+	if errors := Validate(PostForm{Subject: "s", Body: "b"}); len(errors) > 0 {
+		t.Errorf("Expected an empty time to pass, got %v", errors)
+	}
+	for _, value := range []string{yesterday, "tomorrow"} {
+		if errors := Validate(PostForm{Subject: "s", Body: "b", PublishAt: value}); errors["PublishAt"] != "Must be a date and time in the future" {
+			t.Errorf("Expected a PublishAt error for %q, got %v", value, errors)
+		}
+	}
+
+	if got := OptionalTime(tomorrow); got == nil || got.Format(DateTimeLayout) != tomorrow {
+		t.Errorf("OptionalTime(%q) = %v", tomorrow, got)
+	}
+	if got := OptionalTime(""); got != nil {
+		t.Errorf("Expected nil for an empty time, got %v", got)
+	}
+}
+
+func TestValidate_CrossFieldTags(t *testing.T) {
+	type eventForm struct {
+		StartsAt     time.Time `form:"starts_at" validate:"omitempty"`
+		EndsAt       time.Time `form:"ends_at" validate:"omitempty,gtfield=StartsAt"`
+		MinGuests    int       `form:"min_guests" validate:"gte=0"`
+		MaxGuests    int       `form:"max_guests" validate:"gte=0,gtefield=MinGuests"`
+		DiscountType string    `form:"discount_type" validate:"omitempty,max=255"`
+		CouponCode   string    `form:"coupon_code" validate:"required_if=DiscountType coupon,omitempty,max=255"`
+	}
+
+	now := time.Now()
+	valid := eventForm{StartsAt: now, EndsAt: now.Add(time.Hour), MinGuests: 2, MaxGuests: 2, DiscountType: "coupon", CouponCode: "SPRING"}
+	if errors := Validate(valid); len(errors) > 0 {
+		t.Errorf("Expected a valid form to pass, got %v", errors)
+	}
+	if errors := Validate(eventForm{StartsAt: now}); len(errors) > 0 {
+		t.Errorf("Expected empty optional fields to pass, got %v", errors)
+	}
+
+	errors := Validate(eventForm{StartsAt: now, EndsAt: now.Add(-time.Hour), MinGuests: 5, MaxGuests: 3, DiscountType: "coupon"})
+	expected := map[string]string{
+		"EndsAt":     "Must be after StartsAt",
+		"MaxGuests":  "Must be at least MinGuests",
+		"CouponCode": "Required when DiscountType is coupon",
+	}
+	for field, message := range expected {
+		if errors[field] != message {
+			t.Errorf("Expected %s error %q, got %q", field, message, errors[field])
+		}
+	}
+}
+
+type discountForm struct {
+	Percent int    `form:"percent" validate:"gte=0,lte=100"`
+	Coupon  string `form:"coupon" validate:"omitempty,max=20"`
+}
+
+func (f discountForm) ValidateForm() map[string]string {
+	errors := make(map[string]string)
+	if f.Percent > 50 && f.Coupon == "" {
+		errors["Coupon"] = "Discounts over 50% need a coupon"
+	}
+	if f.Percent > 50 {
+		errors["Percent"] = "Discounts over 50% need approval"
+	}
+	return errors
+}
+
+func TestValidate_FormValidator(t *testing.T) {
+	if errors := Validate(discountForm{Percent: 10}); len(errors) > 0 {
+		t.Errorf("Expected a valid form to pass, got %v", errors)
+	}
+
+	// Runs for pointers too, and tag errors take precedence
+	errors := Validate(&discountForm{Percent: 120})
+	if errors["Coupon"] != "Discounts over 50% need a coupon" {
+		t.Errorf("Expected a cross-field error, got %v", errors)
+	}
+	if errors["Percent"] != "Invalid value" {
+		t.Errorf("Expected the tag error to be kept, got %v", errors)
+	}
+}
@@ -0,0 +1,297 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+// wizardKeyPrefix namespaces wizard state in the session (e.g., "wizard:product_new")
+const wizardKeyPrefix = "wizard:"
+
+// WizardTimeLayout is the format of time values collected by a wizard (datetime-local inputs)
+const WizardTimeLayout = "2006-01-02T15:04"
+
+// WizardStep is one page of a multi-step form
+type WizardStep struct {
+	Title    string
+	Template string   // Template rendering the step's inputs (e.g., "products/new_step1.partial.html")
+	Fields   []string // Form field names collected on this step
+
+	// Validate adds checks beyond the form struct's tags (nil adds none).
+	// values holds everything collected so far, including this step.
+	Validate func(values map[string]string) map[string]string
+}
+
+// Wizard collects a long form over several steps, keeping the values entered so
+// far in the session until the last step is submitted
+type Wizard struct {
+	Name     string      // Session key for this wizard's state
+	Form     interface{} // Form struct (e.g., ProductForm{}) whose tags validate each step; nil skips
+	Steps    []WizardStep
+	Sessions *scs.SessionManager
+}
+
+// WizardView is what step templates get as .Data.Wizard
+type WizardView struct {
+	Step   int // 1-based
+	Total  int
+	Title  string
+	Titles []string
+	First  bool
+	Last   bool
+}
+
+// wizardState is stored as JSON in the session
+type wizardState struct {
+	Step   int               `json:"step"`
+	Values map[string]string `json:"values"`
+}
+
+// NewWizard creates a wizard; name must be unique per session (e.g., "product_new")
+func NewWizard(name string, sessions *scs.SessionManager, form interface{}, steps ...WizardStep) *Wizard {
+	return &Wizard{
+		Name:     name,
+		Form:     form,
+		Steps:    steps,
+		Sessions: sessions,
+	}
+}
+
+func (w *Wizard) load(ctx context.Context) wizardState {
+	state := wizardState{Values: make(map[string]string)}
+	if encoded := w.Sessions.GetString(ctx, wizardKeyPrefix+w.Name); encoded != "" {
+		_ = json.Unmarshal([]byte(encoded), &state)
+	}
+	if state.Values == nil {
+		state.Values = make(map[string]string)
+	}
+	if state.Step < 0 || state.Step >= len(w.Steps) {
+		state.Step = 0
+	}
+	return state
+}
+
+func (w *Wizard) save(ctx context.Context, state wizardState) {
+	encoded, _ := json.Marshal(state)
+	w.Sessions.Put(ctx, wizardKeyPrefix+w.Name, string(encoded))
+}
+
+// Step returns the 0-based index of the current step
+func (w *Wizard) Step(ctx context.Context) int {
+	return w.load(ctx).Step
+}
+
+// Current returns the current step
+func (w *Wizard) Current(ctx context.Context) WizardStep {
+	return w.Steps[w.Step(ctx)]
+}
+
+// Values returns everything collected so far, keyed by form field name
+func (w *Wizard) Values(ctx context.Context) map[string]string {
+	return w.load(ctx).Values
+}
+
+// View describes the current step for templates
+func (w *Wizard) View(ctx context.Context) WizardView {
+	step := w.Step(ctx)
+	titles := make([]string, len(w.Steps))
+	for i, s := range w.Steps {
+		titles[i] = s.Title
+	}
+	return WizardView{
+		Step:   step + 1,
+		Total:  len(w.Steps),
+		Title:  w.Steps[step].Title,
+		Titles: titles,
+		First:  step == 0,
+		Last:   step == len(w.Steps)-1,
+	}
+}
+
+// Handle processes a step submission according to its "wizard" button value:
+// "back" goes to the previous step (keeping what was typed), "reset" starts
+// over, and anything else submits the step (see Submit)
+func (w *Wizard) Handle(ctx context.Context, form url.Values) (errors map[string]string, done bool) {
+	switch form.Get("wizard") {
+	case "back":
+		state := w.load(ctx)
+		w.collect(state, form)
+		w.save(ctx, state)
+		w.Back(ctx)
+		return nil, false
+	case "reset":
+		w.Reset(ctx)
+		return nil, false
+	default:
+		return w.Submit(ctx, form)
+	}
+}
+
+// Submit stores the current step's fields from form and validates them. Without
+// errors it moves to the next step, or reports done after the last one; the
+// values stay in the session until Reset so the handler can Decode them.
+func (w *Wizard) Submit(ctx context.Context, form url.Values) (errors map[string]string, done bool) {
+	state := w.load(ctx)
+	step := w.collect(state, form)
+
+	errors = w.validateStep(step, state.Values)
+	if len(errors) == 0 {
+		if state.Step == len(w.Steps)-1 {
+			done = true
+		} else {
+			state.Step++
+		}
+	}
+	w.save(ctx, state)
+	return errors, done
+}
+
+// collect copies the current step's fields from form into state and returns the step.
+// Unchecked checkboxes aren't sent, so every step field is overwritten.
+func (w *Wizard) collect(state wizardState, form url.Values) WizardStep {
+	step := w.Steps[state.Step]
+	for _, name := range step.Fields {
+		state.Values[name] = strings.TrimSpace(form.Get(name))
+	}
+	return step
+}
+
+// Back returns to the previous step, keeping the values entered so far
+func (w *Wizard) Back(ctx context.Context) {
+	state := w.load(ctx)
+	if state.Step > 0 {
+		state.Step--
+	}
+	w.save(ctx, state)
+}
+
+// Reset discards the wizard's state (after saving, or to start over)
+func (w *Wizard) Reset(ctx context.Context) {
+	w.Sessions.Remove(ctx, wizardKeyPrefix+w.Name)
+}
+
+// Decode copies the collected values into dst, a pointer to a form struct,
+// matching them by `form` tag
+func (w *Wizard) Decode(ctx context.Context, dst interface{}) error {
+	invalid, err := decodeValues(w.Values(ctx), dst)
+	if err != nil {
+		return err
+	}
+	for field, msg := range invalid {
+		return fmt.Errorf("%s: %s", field, msg)
+	}
+	return nil
+}
+
+// validateStep returns the form struct's errors for the step's fields plus the
+// step's own checks. Later steps' fields aren't reported yet.
+func (w *Wizard) validateStep(step WizardStep, values map[string]string) map[string]string {
+	errors := make(map[string]string)
+
+	if w.Form != nil {
+		formType := reflect.TypeOf(w.Form)
+		if formType.Kind() == reflect.Ptr {
+			formType = formType.Elem()
+		}
+		form := reflect.New(formType)
+		invalid, err := decodeValues(values, form.Interface())
+		if err != nil {
+			errors["general"] = err.Error()
+			return errors
+		}
+
+		onStep := make(map[string]bool)
+		for i := 0; i < formType.NumField(); i++ {
+			field := formType.Field(i)
+			for _, name := range step.Fields {
+				if field.Tag.Get("form") == name {
+					onStep[field.Name] = true
+				}
+			}
+		}
+		for field, msg := range Validate(form.Elem().Interface()) {
+			if onStep[field] {
+				errors[field] = msg
+			}
+		}
+		for field, msg := range invalid {
+			if onStep[field] {
+				errors[field] = msg
+			}
+		}
+	}
+
+	if step.Validate != nil {
+		for field, msg := range step.Validate(values) {
+			errors[field] = msg
+		}
+	}
+	return errors
+}
+
+// decodeValues sets dst's fields from values by `form` tag. Empty values leave
+// the zero value; checkboxes accept "on", "true" and "1". Values that don't
+// parse are skipped and returned as errors keyed by struct field name.
+func decodeValues(values map[string]string, dst interface{}) (map[string]string, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("decode target must be a pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	invalid := make(map[string]string)
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("form")
+		value, ok := values[name]
+		if name == "" || !ok {
+			continue
+		}
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Bool {
+			field.SetBool(value == "on" || value == "true" || value == "1")
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				invalid[t.Field(i).Name] = "Must be a whole number"
+				continue
+			}
+			field.SetInt(n)
+		case reflect.Float32, reflect.Float64:
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				invalid[t.Field(i).Name] = "Must be a number"
+				continue
+			}
+			field.SetFloat(f)
+		default:
+			if field.Type() == reflect.TypeOf(time.Time{}) {
+				ts, err := time.Parse(WizardTimeLayout, value)
+				if err != nil {
+					invalid[t.Field(i).Name] = "Invalid date"
+					continue
+				}
+				field.Set(reflect.ValueOf(ts))
+			}
+		}
+	}
+	return invalid, nil
+}
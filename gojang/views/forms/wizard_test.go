@@ -0,0 +1,99 @@
+package forms
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+)
+
+type wizardTestForm struct {
+	Name  string  `form:"name" validate:"required,max=255"`
+	Price float64 `form:"price" validate:"gt=0"`
+	Stock int     `form:"stock" validate:"gte=0"`
+	Live  bool    `form:"live"`
+}
+
+// newTestWizard returns a two-step wizard and a context carrying a fresh session
+func newTestWizard(t *testing.T) (*Wizard, context.Context) {
+	t.Helper()
+	sm := scs.New()
+	ctx, err := sm.Load(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	wizard := NewWizard("test", sm, wizardTestForm{},
+		WizardStep{Title: "Basics", Fields: []string{"name"}},
+		WizardStep{Title: "Inventory", Fields: []string{"price", "stock", "live"}},
+	)
+	return wizard, ctx
+}
+
+// TestWizard_StepValidationAndNavigation tests that each step only reports its own errors and Back keeps values
+func TestWizard_StepValidationAndNavigation(t *testing.T) {
+	wizard, ctx := newTestWizard(t)
+
+	// Later steps' errors (price must be > 0) aren't reported on step 1
+	errors, done := wizard.Submit(ctx, url.Values{})
+	if done || len(errors) != 1 || errors["Name"] == "" {
+		t.Fatalf("Expected only a Name error, got %v (done=%v)", errors, done)
+	}
+
+	if errors, _ := wizard.Submit(ctx, url.Values{"name": {"Widget"}}); len(errors) > 0 {
+		t.Fatalf("Expected step 1 to pass, got %v", errors)
+	}
+	if view := wizard.View(ctx); view.Step != 2 || !view.Last || view.First {
+		t.Errorf("Expected to be on the last step, got %+v", view)
+	}
+
+	errors, done = wizard.Submit(ctx, url.Values{"price": {"abc"}})
+	if done || errors["Price"] != "Must be a number" {
+		t.Errorf("Expected a Price parse error, got %v", errors)
+	}
+
+	// Back keeps what was typed on the current step
+	wizard.Handle(ctx, url.Values{"wizard": {"back"}, "price": {"9.5"}})
+	if wizard.Step(ctx) != 0 || wizard.Values(ctx)["price"] != "9.5" {
+		t.Errorf("Expected step 1 with price kept, got step %d values %v", wizard.Step(ctx), wizard.Values(ctx))
+	}
+}
+
+// TestWizard_DecodeAfterLastStep tests that the collected values decode into the form once the wizard finishes
+func TestWizard_DecodeAfterLastStep(t *testing.T) {
+	wizard, ctx := newTestWizard(t)
+
+	wizard.Handle(ctx, url.Values{"wizard": {"next"}, "name": {" Widget "}})
+	errors, done := wizard.Handle(ctx, url.Values{"wizard": {"next"}, "price": {"9.5"}, "stock": {"3"}, "live": {"on"}})
+	if !done || len(errors) > 0 {
+		t.Fatalf("Expected the wizard to finish, got %v (done=%v)", errors, done)
+	}
+
+	var form wizardTestForm
+	if err := wizard.Decode(ctx, &form); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if form != (wizardTestForm{Name: "Widget", Price: 9.5, Stock: 3, Live: true}) {
+		t.Errorf("Unexpected decoded form: %+v", form)
+	}
+
+	wizard.Reset(ctx)
+	if len(wizard.Values(ctx)) != 0 || wizard.Step(ctx) != 0 {
+		t.Error("Expected Reset to clear the wizard")
+	}
+}
+
+// TestWizard_StepValidateHook tests that a step's Validate hook adds its errors
+func TestWizard_StepValidateHook(t *testing.T) {
+	wizard, ctx := newTestWizard(t)
+	wizard.Steps[0].Validate = func(values map[string]string) map[string]string {
+		if values["name"] == "taken" {
+			return map[string]string{"Name": "Name already used"}
+		}
+		return nil
+	}
+
+	if errors, _ := wizard.Submit(ctx, url.Values{"name": {"taken"}}); errors["Name"] != "Name already used" {
+		t.Errorf("Expected the step hook's error, got %v", errors)
+	}
+}
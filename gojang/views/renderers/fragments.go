@@ -0,0 +1,86 @@
+package renderers
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/cache"
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+)
+
+// UseCache enables fragment caching backed by the given cache
+func (r *Renderer) UseCache(c cache.Cache) {
+	r.cache = c
+}
+
+// RenderFragment renders a partial template to HTML, serving it from the fragment
+// cache when possible. load is only called on a cache miss, so expensive queries
+// can live inside it. Keys must include anything the output depends on (e.g., the
+// viewing user); use FragmentKey for the common per-viewer case.
+//
+// Without a cache configured the fragment is rendered on every call.
+func (r *Renderer) RenderFragment(req *http.Request, key string, ttl time.Duration, name string, load func() (*TemplateData, error)) (template.HTML, error) {
+	if r.cache != nil {
+		if cached, ok := r.cache.Get(key); ok {
+			return template.HTML(cached), nil
+		}
+	}
+
+	data, err := load()
+	if err != nil {
+		return "", err
+	}
+
+	html, err := r.RenderPartial(req, name, data)
+	if err != nil {
+		return "", err
+	}
+
+	if r.cache != nil {
+		r.cache.Set(key, []byte(html), ttl)
+	}
+	return html, nil
+}
+
+// RenderPartial renders a partial template to HTML for embedding in another page.
+// Unlike RenderFragment the output is never cached.
+func (r *Renderer) RenderPartial(req *http.Request, name string, data *TemplateData) (template.HTML, error) {
+	if data == nil {
+		data = &TemplateData{}
+	}
+	data.User = middleware.GetUser(req.Context())
+	data.CurrentPath = req.URL.Path
+
+	r.mu.RLock()
+	tmpl, ok := r.templates[name]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("template %s not found", name)
+	}
+
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("rendering fragment %s: %w", name, err)
+	}
+	// String copies out of the pooled buffer before it is reused
+	return template.HTML(buf.String()), nil
+}
+
+// InvalidateFragments removes every cached fragment whose key starts with prefix
+func (r *Renderer) InvalidateFragments(prefix string) {
+	if r.cache != nil {
+		r.cache.DeletePrefix(prefix)
+	}
+}
+
+// FragmentKey builds a cache key scoped to the viewing user, so fragments that
+// show per-user controls (edit buttons, etc.) are never shared between users
+func FragmentKey(req *http.Request, name string) string {
+	if user := middleware.GetUser(req.Context()); user != nil {
+		return name + ":user:" + user.ID.String()
+	}
+	return name + ":anon"
+}
@@ -0,0 +1,127 @@
+package renderers
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// TemplateErrors lists every problem found loading a renderer's templates.
+// Renderers refuse to start with any, so broken pages show up at startup
+// rather than as 500s on their first request.
+type TemplateErrors []string
+
+func (e TemplateErrors) Error() string {
+	return fmt.Sprintf("%d template problem(s):\n  %s", len(e), strings.Join(e, "\n  "))
+}
+
+// BlockIndex records which blocks ({{define}} and {{block}}) each template
+// set defines, and which file each comes from
+type BlockIndex struct {
+	sets map[string]map[string]string // Set -> block -> file
+}
+
+// NewBlockIndex indexes the blocks of sets, which are keyed by name like
+// Renderer.Templates
+func NewBlockIndex(sets map[string]*template.Template) *BlockIndex {
+	x := &BlockIndex{sets: make(map[string]map[string]string, len(sets))}
+	for name, set := range sets {
+		blocks := make(map[string]string)
+		for _, t := range set.Templates() {
+			// Each file is a template too, named after the file
+			if t.Tree != nil && t.Name() != t.Tree.ParseName {
+				blocks[t.Name()] = t.Tree.ParseName
+			}
+		}
+		x.sets[name] = blocks
+	}
+	return x
+}
+
+// Defines reports whether set defines block
+func (x *BlockIndex) Defines(set, block string) bool {
+	_, ok := x.sets[set][block]
+	return ok
+}
+
+// DefinedIn returns the file set's block comes from, or "" if set doesn't define it
+func (x *BlockIndex) DefinedIn(set, block string) string {
+	return x.sets[set][block]
+}
+
+// Blocks returns the blocks set defines, sorted
+func (x *BlockIndex) Blocks(set string) []string {
+	blocks := make([]string, 0, len(x.sets[set]))
+	for block := range x.sets[set] {
+		blocks = append(blocks, block)
+	}
+	sort.Strings(blocks)
+	return blocks
+}
+
+// Validate reports {{template}} calls to templates their set doesn't define,
+// and pages that leave the "content" block to their layout, which renders
+// nothing there. Pages are parsed once per layout, so each problem names the
+// page it was found rendering and is reported once.
+func Validate(sets map[string]*template.Template, index *BlockIndex) []string {
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]bool)
+	var problems []string
+	report := func(problem string) {
+		if !seen[problem] {
+			seen[problem] = true
+			problems = append(problems, problem)
+		}
+	}
+	for _, name := range names {
+		set := sets[name]
+		page, _, _ := strings.Cut(name, "@")
+		if !strings.Contains(name, ".partial.html") && index.DefinedIn(name, "content") == set.Name() {
+			report(fmt.Sprintf("%s: doesn't define a \"content\" block, so it renders empty", page))
+		}
+		for _, t := range set.Templates() {
+			if t.Tree == nil {
+				continue
+			}
+			walkTemplateCalls(t.Tree.Root, func(call *parse.TemplateNode) {
+				if defined := set.Lookup(call.Name); defined != nil && defined.Tree != nil {
+					return
+				}
+				loc, _ := t.Tree.ErrorContext(call)
+				report(fmt.Sprintf("%s: {{template %q}} is not defined (rendering %s)", loc, call.Name, page))
+			})
+		}
+	}
+	return problems
+}
+
+// walkTemplateCalls calls visit for every {{template}} call under node
+func walkTemplateCalls(node parse.Node, visit func(*parse.TemplateNode)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkTemplateCalls(child, visit)
+		}
+	case *parse.TemplateNode:
+		visit(n)
+	case *parse.IfNode:
+		walkTemplateCalls(n.List, visit)
+		walkTemplateCalls(n.ElseList, visit)
+	case *parse.RangeNode:
+		walkTemplateCalls(n.List, visit)
+		walkTemplateCalls(n.ElseList, visit)
+	case *parse.WithNode:
+		walkTemplateCalls(n.List, visit)
+		walkTemplateCalls(n.ElseList, visit)
+	}
+}
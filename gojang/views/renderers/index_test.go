@@ -0,0 +1,81 @@
+package renderers
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBlockIndex tests indexing the blocks of a set and the files they come from
+func TestBlockIndex(t *testing.T) {
+	set := template.Must(template.New("base.html").Parse(`{{block "title" .}}Site{{end}}{{block "content" .}}{{end}}`))
+	template.Must(set.New("page.html").Parse(`{{define "content"}}Hello{{end}}`))
+	x := NewBlockIndex(map[string]*template.Template{"page.html": set})
+
+	if got := strings.Join(x.Blocks("page.html"), ","); got != "content,title" {
+		t.Errorf("Expected blocks content,title, got %s", got)
+	}
+	if x.DefinedIn("page.html", "content") != "page.html" || x.DefinedIn("page.html", "title") != "base.html" {
+		t.Errorf("Expected content from the page and title from the layout, got %q and %q",
+			x.DefinedIn("page.html", "content"), x.DefinedIn("page.html", "title"))
+	}
+	if x.Defines("page.html", "base.html") || x.Defines("page.html", "missing") || x.Defines("other.html", "content") {
+		t.Error("Expected files, unknown blocks and unknown sets not to be defined")
+	}
+}
+
+// TestValidate tests reporting calls to undefined templates and pages without content
+func TestValidate(t *testing.T) {
+	page := template.Must(template.New("base.html").Parse(`{{template "title" .}}{{if .}}{{template "missing" .}}{{end}}{{block "content" .}}{{end}}`))
+	template.Must(page.New("page.html").Parse(`{{define "title"}}Home{{end}}`))
+	fragment := template.Must(template.New("list.partial.html").Parse(`<ul></ul>`))
+	sets := map[string]*template.Template{"page.html": page, "page.html@print": page, "list.partial.html": fragment}
+
+	problems := Validate(sets, NewBlockIndex(sets))
+	if len(problems) != 2 {
+		t.Fatalf("Expected two problems, got %q", problems)
+	}
+	if problems[0] != `page.html: doesn't define a "content" block, so it renders empty` {
+		t.Errorf("Unexpected problem %q", problems[0])
+	}
+	if !strings.Contains(problems[1], `{{template "missing"}} is not defined (rendering page.html)`) {
+		t.Errorf("Unexpected problem %q", problems[1])
+	}
+}
+
+// TestNewRenderer_ReportsEveryProblem tests that startup fails with every
+// broken template listed, not just the first
+func TestNewRenderer_ReportsEveryProblem(t *testing.T) {
+	t.Chdir(t.TempDir())
+	files := map[string]string{
+		"gojang/views/templates/base.html":            `<main>{{block "content" .}}{{end}}</main>`,
+		"gojang/views/templates/ok.html":              `{{define "content"}}OK{{end}}`,
+		"gojang/views/templates/unknown_func.html":    `{{define "content"}}{{nope}}{{end}}`,
+		"gojang/views/templates/empty.html":           `<p>Outside any block</p>`,
+		"gojang/views/templates/broken.partial.html":  `{{if}}`,
+		"gojang/views/templates/missing.partial.html": `{{template "gone" .}}`,
+	}
+	for name, content := range files {
+		os.MkdirAll(filepath.Dir(name), 0o755)
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := NewRenderer(false)
+	var problems TemplateErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("Expected TemplateErrors, got %v", err)
+	}
+	if len(problems) != 4 {
+		t.Fatalf("Expected four problems, got %d:\n%v", len(problems), err)
+	}
+	for _, want := range []string{`"nope" not defined`, "parsing fragment broken.partial.html", `empty.html: doesn't define a "content" block`, `{{template "gone"}} is not defined`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected a problem mentioning %q in:\n%v", want, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package renderers
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"regexp"
+
+	"github.com/gojangframework/gojang/gojang/utils"
+)
+
+// Fragment is one partial in a RenderOOB response
+type Fragment struct {
+	Name string // Template, e.g. "posts/list.partial.html"
+	Data *TemplateData
+	// OOB is the fragment's hx-swap-oob value: "true" replaces the element with
+	// the same id as the fragment's root element, "innerHTML:#post-count" swaps
+	// into the elements matching a selector. Leave it empty for the main
+	// content, which htmx swaps into the request's target as usual.
+	OOB string
+}
+
+// firstTag finds the name of the first element in rendered HTML
+var firstTag = regexp.MustCompile(`<[a-zA-Z][a-zA-Z0-9-]*`)
+
+// RenderOOB renders several fragments into one response, so a single htmx
+// request can update the page in more than one place:
+//
+//	h.Renderer.RenderOOB(w, r, []renderers.Fragment{
+//		{Name: "posts/list.partial.html", Data: listData},
+//		{Name: "posts/count.partial.html", Data: countData, OOB: "true"},
+//	})
+//
+// Fragments render as they would for an htmx request (pages render their
+// content block). Out-of-band fragments get hx-swap-oob added to their first
+// element, or are wrapped in a <div> if they have none. Like RenderStatus,
+// nothing is written unless every fragment renders.
+func (r *Renderer) RenderOOB(w http.ResponseWriter, req *http.Request, fragments []Fragment) error {
+	r.reloadIfDebug()
+
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	part := GetBuffer()
+	defer PutBuffer(part)
+
+	for _, f := range fragments {
+		data := prepare(req, f.Data)
+		data.IsHX = true
+
+		part.Reset()
+		if err := r.execute(part, f.Name, data); err != nil {
+			utils.Errorf("Template execution failed: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return err
+		}
+		if f.OOB == "" {
+			buf.Write(part.Bytes())
+		} else {
+			buf.Write(markOOB(part.Bytes(), f.OOB))
+		}
+		buf.WriteByte('\n')
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// markOOB adds hx-swap-oob to the first element of html
+func markOOB(html []byte, oob string) []byte {
+	attr := ` hx-swap-oob="` + template.HTMLEscapeString(oob) + `"`
+	loc := firstTag.FindIndex(html)
+	if loc == nil {
+		return []byte(`<div` + attr + `>` + string(html) + `</div>`)
+	}
+	var out bytes.Buffer
+	out.Grow(len(html) + len(attr))
+	out.Write(html[:loc[1]])
+	out.WriteString(attr)
+	out.Write(html[loc[1]:])
+	return out.Bytes()
+}
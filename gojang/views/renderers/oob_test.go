@@ -0,0 +1,62 @@
+package renderers
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRenderOOB tests rendering the main content and out-of-band fragments together
+func TestRenderOOB(t *testing.T) {
+	r := &Renderer{templates: map[string]*template.Template{
+		"posts/list.partial.html":  template.Must(template.New("list").Parse(`<ul id="posts"><li>{{.Title}}</li></ul>`)),
+		"posts/count.partial.html": template.Must(template.New("count").Parse(`<span id="post-count">{{.Data.Count}}</span>`)),
+		"flash.partial.html":       template.Must(template.New("flash").Parse(`Saved {{.Title}}`)),
+	}}
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", nil)
+	rec := httptest.NewRecorder()
+	err := r.RenderOOB(rec, req, []Fragment{
+		{Name: "posts/list.partial.html", Data: &TemplateData{Title: "Hello"}},
+		{Name: "posts/count.partial.html", Data: &TemplateData{Data: map[string]interface{}{"Count": 3}}, OOB: "true"},
+		{Name: "flash.partial.html", Data: &TemplateData{Title: "post"}, OOB: "innerHTML:#flash"},
+	})
+	if err != nil {
+		t.Fatalf("RenderOOB failed: %v", err)
+	}
+
+	body := rec.Body.String()
+	for _, expected := range []string{
+		`<ul id="posts"><li>Hello</li></ul>`,
+		`<span hx-swap-oob="true" id="post-count">3</span>`,
+		`<div hx-swap-oob="innerHTML:#flash">Saved post</div>`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Response missing %q in:\n%s", expected, body)
+		}
+	}
+	if strings.Contains(body, `<ul hx-swap-oob`) {
+		t.Error("The main content should not be marked out-of-band")
+	}
+}
+
+// TestRenderOOB_FailedFragmentWritesNothing tests that one broken fragment fails the whole response
+func TestRenderOOB_FailedFragmentWritesNothing(t *testing.T) {
+	r := &Renderer{templates: map[string]*template.Template{
+		"ok.partial.html": template.Must(template.New("ok").Parse(`<p>ok</p>`)),
+	}}
+
+	rec := httptest.NewRecorder()
+	err := r.RenderOOB(rec, httptest.NewRequest(http.MethodGet, "/", nil), []Fragment{
+		{Name: "ok.partial.html"},
+		{Name: "missing.partial.html", OOB: "true"},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for a missing template")
+	}
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "<p>ok</p>") {
+		t.Errorf("Expected a clean 500, got %d %q", rec.Code, rec.Body.String())
+	}
+}
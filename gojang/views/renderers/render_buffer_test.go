@@ -0,0 +1,148 @@
+package renderers
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRenderStatus_FailedTemplateWritesNothingPartial tests that a template error
+// results in a clean 500 instead of a half-written page with status 200
+func TestRenderStatus_FailedTemplateWritesNothingPartial(t *testing.T) {
+	tmpl := template.Must(template.New("base.html").Parse(`<h1>start</h1>{{.Data.Missing.Field}}`))
+	r := &Renderer{templates: map[string]*template.Template{"broken.html": tmpl}}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	if err := r.RenderStatus(rec, req, http.StatusOK, "broken.html", &TemplateData{
+		Data: map[string]interface{}{"Missing": 42},
+	}); err == nil {
+		t.Fatal("Expected template execution error")
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "start") {
+		t.Error("Expected no partial template output in response body")
+	}
+}
+
+// TestRenderStatus_WritesStatusAfterSuccess tests that the requested status is written with the body
+func TestRenderStatus_WritesStatusAfterSuccess(t *testing.T) {
+	tmpl := template.Must(template.New("base.html").Parse(`<p>{{.Title}}</p>`))
+	r := &Renderer{templates: map[string]*template.Template{"404.html": tmpl}}
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	rec := httptest.NewRecorder()
+
+	if err := r.RenderStatus(rec, req, http.StatusNotFound, "404.html", &TemplateData{Title: "Not Found"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+	if rec.Body.String() != "<p>Not Found</p>" {
+		t.Errorf("Unexpected body %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Unexpected Content-Type %q", ct)
+	}
+}
+
+// brokenData returns template data broken.html fails to render
+func brokenData() *TemplateData {
+	return &TemplateData{Data: map[string]interface{}{"Missing": 42}}
+}
+
+// newFallbackRenderer returns a renderer with a broken page and error templates to fall back to
+func newFallbackRenderer() *Renderer {
+	broken := template.Must(template.New("base.html").Parse(`{{block "content" .}}<h1>start</h1>{{.Data.Missing.Field}}{{end}}`))
+	page := template.Must(template.New("base.html").Parse(`<main>{{.Data.Status}}: {{.Data.Message}}</main>`))
+	partial := template.Must(template.New("error.partial.html").Parse(`<div class="alert">{{.Data.Message}}</div>`))
+	return &Renderer{
+		templates: map[string]*template.Template{
+			"broken.html":        broken,
+			"error.html":         page,
+			"error.partial.html": partial,
+		},
+		errorPage:    DefaultErrorPage,
+		errorPartial: DefaultErrorPartial,
+	}
+}
+
+// TestRenderStatus_FallsBackToErrorTemplates tests that failing and missing
+// templates render the error page, or the error partial for htmx requests
+func TestRenderStatus_FallsBackToErrorTemplates(t *testing.T) {
+	r := newFallbackRenderer()
+
+	for _, name := range []string{"broken.html", "missing.html"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		if err := r.RenderStatus(rec, req, http.StatusOK, name, brokenData()); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("%s: expected status 500, got %d", name, rec.Code)
+		}
+		if body := rec.Body.String(); body != "<main>500: Something went wrong showing this page.</main>" {
+			t.Errorf("%s: expected the error page, got %q", name, body)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	r.RenderStatus(rec, req, 0, "broken.html", brokenData())
+	if rec.Code != http.StatusInternalServerError || rec.Header().Get("HX-Reswap") != "innerHTML" {
+		t.Errorf("Expected a 500 htmx swaps, got %d with HX-Reswap %q", rec.Code, rec.Header().Get("HX-Reswap"))
+	}
+	if body := rec.Body.String(); body != `<div class="alert">Something went wrong showing this page.</div>` {
+		t.Errorf("Expected the error partial, got %q", body)
+	}
+}
+
+// TestRenderStatus_FailingFallback tests that a plain-text 500 is the last resort
+func TestRenderStatus_FailingFallback(t *testing.T) {
+	r := newFallbackRenderer()
+	r.templates["error.html"] = template.Must(template.New("base.html").Parse(`<h1>start</h1>{{template "gone" .}}`))
+
+	for _, name := range []string{"broken.html", "error.html"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		r.RenderStatus(rec, req, 0, name, brokenData())
+		if rec.Code != http.StatusInternalServerError || strings.TrimSpace(rec.Body.String()) != "Internal Server Error" {
+			t.Errorf("%s: expected a plain-text 500, got %d %q", name, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+// TestUseErrorTemplates tests that error templates must exist, and that empty names turn fallbacks off
+func TestUseErrorTemplates(t *testing.T) {
+	r := newFallbackRenderer()
+	if err := r.UseErrorTemplates("missing.html", ""); err == nil {
+		t.Error("Expected an unknown error page to be rejected")
+	}
+	if r.errorPage != DefaultErrorPage {
+		t.Errorf("Expected a rejected page to leave the fallback alone, got %q", r.errorPage)
+	}
+
+	if err := r.UseErrorTemplates("", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	r.RenderStatus(rec, httptest.NewRequest(http.MethodGet, "/", nil), 0, "broken.html", brokenData())
+	if strings.TrimSpace(rec.Body.String()) != "Internal Server Error" {
+		t.Errorf("Expected a plain-text 500 without error templates, got %q", rec.Body.String())
+	}
+}
+
+// TestDataKeys tests that render errors log the sorted keys of .Data
+func TestDataKeys(t *testing.T) {
+	got := dataKeys(&TemplateData{Data: map[string]interface{}{"Posts": nil, "Form": nil, "Count": 3}})
+	if strings.Join(got, ",") != "Count,Form,Posts" {
+		t.Errorf("Expected sorted keys, got %q", got)
+	}
+}
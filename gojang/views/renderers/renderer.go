@@ -1,15 +1,28 @@
 package renderers
 
 import (
+	"bytes"
 	"fmt"
 	"html/template"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
-
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/cache"
+	"github.com/gojangframework/gojang/gojang/ctxutil"
+	"github.com/gojangframework/gojang/gojang/debugbar"
+	"github.com/gojangframework/gojang/gojang/htmx"
+	"github.com/gojangframework/gojang/gojang/images"
+	"github.com/gojangframework/gojang/gojang/livereload"
+	"github.com/gojangframework/gojang/gojang/storage"
 	"github.com/gojangframework/gojang/gojang/utils"
+	"github.com/gojangframework/gojang/gojang/version"
+	"github.com/gojangframework/gojang/gojang/views/components"
 
 	"github.com/gojangframework/gojang/gojang/http/middleware"
 	"github.com/gojangframework/gojang/gojang/models"
@@ -19,8 +32,22 @@ import (
 
 type Renderer struct {
 	templates map[string]*template.Template
-	mu        sync.RWMutex // Protects templates map
+	blocks    *BlockIndex
+	mu        sync.RWMutex // Protects templates and blocks
 	debug     bool
+	cache     cache.Cache // Optional fragment cache (see RenderFragment)
+
+	// Optional source of the banners on full pages (see UseAnnouncements)
+	announcements func(*http.Request) []*models.Announcement
+
+	// Optional source of the session expiry warning (see UseSessionExpiry)
+	sessionExpiry func(*http.Request) *middleware.SessionExpiry
+
+	// Optional development toolbar added to full pages (see UseDebugToolbar)
+	debugToolbar *debugbar.Toolbar
+
+	// Rendered instead of a template that's missing or fails (see UseErrorTemplates)
+	errorPage, errorPartial string
 }
 
 // TemplateData holds data for template rendering
@@ -34,22 +61,67 @@ type TemplateData struct {
 	CurrentPath string
 	Flash       string
 	FlashType   string
+	Layout      string // Overrides the page's layout for this render (e.g., "print")
+	Locale      string // Language picked by middleware.Locale, e.g., for {{.Title.Get .Locale}}
+
+	// Banners shown above the content of full pages (see UseAnnouncements)
+	Announcements []*models.Announcement
+
+	// When a signed-in user's session ends, for the warning before it does (see UseSessionExpiry)
+	SessionExpiry *middleware.SessionExpiry
 }
 
-// NewRenderer creates a new template renderer for public site
+// DefaultLayout is base.html, used by pages without a layout directive.
+// Other layouts are templates/layouts/<name>.html; files there starting with
+// "_" hold templates shared by every layout (e.g., "head").
+const DefaultLayout = "base"
+
+// The templates NewRenderer falls back to when a page can't be rendered: the
+// error page, or for htmx requests a fragment that fits wherever they swap
+const (
+	DefaultErrorPage    = "error.html"
+	DefaultErrorPartial = "error.partial.html"
+)
+
+// layoutDirective picks a page's layout: {{/* layout: minimal */}}
+var layoutDirective = regexp.MustCompile(`\{\{-?\s*/\*\s*layout:\s*([\w-]+)\s*\*/\s*-?\}\}`)
+
+// NewRenderer creates a new template renderer for public site. Every template
+// is parsed and checked up front, in debug mode too; if any has problems it
+// returns them all as TemplateErrors.
 func NewRenderer(debug bool) (*Renderer, error) {
-	tmpl, err := parseTemplates()
+	tmpl, blocks, err := loadTemplates()
 	if err != nil {
 		return nil, err
 	}
 
 	return &Renderer{
-		templates: tmpl,
-		debug:     debug,
+		templates:    tmpl,
+		blocks:       blocks,
+		debug:        debug,
+		errorPage:    DefaultErrorPage,
+		errorPartial: DefaultErrorPartial,
 	}, nil
 }
 
-func parseTemplates() (map[string]*template.Template, error) {
+// loadTemplates parses the templates, indexes their blocks and checks them
+// with Validate
+func loadTemplates() (map[string]*template.Template, *BlockIndex, error) {
+	tmpl, problems, err := parseTemplates()
+	if err != nil {
+		return nil, nil, err
+	}
+	blocks := NewBlockIndex(tmpl)
+	problems = append(problems, Validate(tmpl, blocks)...)
+	if len(problems) > 0 {
+		return nil, nil, TemplateErrors(problems)
+	}
+	return tmpl, blocks, nil
+}
+
+// parseTemplates parses every template, carrying on past templates that fail
+// so their problems can be reported together
+func parseTemplates() (map[string]*template.Template, []string, error) {
 	funcMap := template.FuncMap{
 		"add": func(a, b int) int { return a + b },
 		"sub": func(a, b int) int { return a - b },
@@ -71,14 +143,81 @@ func parseTemplates() (map[string]*template.Template, error) {
 			}
 			return false
 		},
+		// richtext renders stored rich text HTML, sanitizing it again in case it
+		// was saved without going through utils.SanitizeHTML
+		"richtext": func(s string) template.HTML {
+			return template.HTML(utils.SanitizeHTML(s))
+		},
+		"markdown": func(s string) template.HTML {
+			return template.HTML(utils.RenderMarkdown(s))
+		},
+		"prettyJSON": utils.PrettyJSON,
+		// Relative times, e.g., {{timeago .Post.CreatedAt}} shows "3 hours ago"
+		"timeago": utils.TimeAgo,
+		// Money fields hold minor units (e.g., cents) of the CURRENCY setting
+		"money":      utils.FormatMoney,
+		"moneyInput": utils.MoneyInput,
+		// Geo fields hold a *utils.Point; the map picker (static/js/geo.js) reads the tile server
+		"staticMap": func(p *utils.Point) template.HTML {
+			return template.HTML(utils.StaticMap(p))
+		},
+		"mapTileURL": utils.MapTileURL,
+		// Color, URL and phone fields; values that don't validate are shown as text
+		"colorSwatch": func(s string) template.HTML {
+			return template.HTML(utils.ColorSwatch(s))
+		},
+		"urlLink": func(s string) template.HTML {
+			return template.HTML(utils.URLLink(s))
+		},
+		"phoneLink": func(s string) template.HTML {
+			return template.HTML(utils.PhoneLink(s))
+		},
+		// Download URL of a stored upload (see storage.SetDefault)
+		"mediaURL": storage.URL,
+		// Resized image variants, e.g. {{image .User.Avatar "96x96c" "Avatar" "avatar"}}
+		"image": func(name, size, alt, class string) template.HTML {
+			return template.HTML(images.Picture(name, size, alt, class))
+		},
+		"imageURL": func(name, size string) string {
+			return images.URL(name, size, false)
+		},
+		// Honeypot and signed timestamp checked by middleware.SpamTrap
+		"spamTrap": func() template.HTML {
+			return template.HTML(utils.SpamTrapFields())
+		},
+		// Browser auto-refresh script in debug mode (see livereload.SetEnabled)
+		"liveReload": func() template.HTML {
+			return template.HTML(livereload.Snippet())
+		},
+		// Running build, e.g. "v1.4.0 (3f2a9c1, 2025-06-01 12:00 UTC)"; shown to staff
+		"buildVersion": func() string {
+			return version.Get().String()
+		},
+	}
+
+	// Shared partials: {{template "pagination" (dict ...)}}, "field", "modal", ...
+	for name, fn := range components.Funcs() {
+		funcMap[name] = fn
 	}
 
 	templates := make(map[string]*template.Template)
+	var problems []string
+	seen := make(map[string]bool)
+	fail := func(what string, err error) {
+		// A broken layout or component fails every page; report it once
+		if !seen[err.Error()] {
+			seen[err.Error()] = true
+			problems = append(problems, fmt.Sprintf("%s: %v", what, err))
+		}
+	}
 	templateDir := "./gojang/views/templates"
-	basePath := filepath.Join(templateDir, "base.html")
+	layouts, shared, err := findLayouts(templateDir)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Walk the template directory to find all .html files
-	err := filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -97,8 +236,8 @@ func parseTemplates() (map[string]*template.Template, error) {
 		// Normalize path separators to forward slashes for cross-platform compatibility
 		relPath = filepath.ToSlash(relPath)
 
-		// Skip base.html itself
-		if relPath == "base.html" {
+		// Skip the layouts themselves
+		if relPath == "base.html" || strings.HasPrefix(relPath, "layouts/") {
 			return nil
 		}
 
@@ -113,14 +252,44 @@ func parseTemplates() (map[string]*template.Template, error) {
 				return fmt.Errorf("reading fragment %s: %w", relPath, err)
 			}
 			tmpl, err = template.New(relPath).Funcs(funcMap).Parse(string(content))
+			if err == nil {
+				err = components.Parse(tmpl)
+			}
 			if err != nil {
-				return fmt.Errorf("parsing fragment %s: %w", relPath, err)
+				fail("parsing fragment "+relPath, err)
+				return nil
 			}
 		} else {
-			// Parse with base.html
-			tmpl, err = template.New(filepath.Base(basePath)).Funcs(funcMap).ParseFiles(basePath, path)
+			// Parse with every layout, so TemplateData.Layout can switch at render
+			// time; the page's own layout is stored under its plain name
+			content, err := os.ReadFile(path)
 			if err != nil {
-				return fmt.Errorf("parsing %s: %w", relPath, err)
+				return fmt.Errorf("reading %s: %w", relPath, err)
+			}
+			layout := DefaultLayout
+			if m := layoutDirective.FindSubmatch(content); m != nil {
+				layout = string(m[1])
+			}
+			if _, ok := layouts[layout]; !ok {
+				problems = append(problems, fmt.Sprintf("parsing %s: layout %q not found", relPath, layout))
+				return nil
+			}
+			for name, layoutPath := range layouts {
+				t, err := template.New(filepath.Base(layoutPath)).Funcs(funcMap).ParseFiles(append([]string{layoutPath}, shared...)...)
+				if err == nil {
+					_, err = t.New(filepath.Base(path)).Parse(string(content))
+				}
+				if err == nil {
+					err = components.Parse(t)
+				}
+				if err != nil {
+					fail(fmt.Sprintf("parsing %s with layout %s", relPath, name), err)
+					continue
+				}
+				templates[relPath+"@"+name] = t
+			}
+			if tmpl = templates[relPath+"@"+layout]; tmpl == nil {
+				return nil
 			}
 		}
 
@@ -129,14 +298,208 @@ func parseTemplates() (map[string]*template.Template, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("walking template directory: %w", err)
+		return nil, nil, fmt.Errorf("walking template directory: %w", err)
 	}
 
-	return templates, nil
+	return templates, problems, nil
 }
 
-// Render renders a template
+// findLayouts returns the layout files by name and the shared "_" files parsed with each
+func findLayouts(templateDir string) (map[string]string, []string, error) {
+	layouts := map[string]string{DefaultLayout: filepath.Join(templateDir, "base.html")}
+	files, err := filepath.Glob(filepath.Join(templateDir, "layouts", "*.html"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var shared []string
+	for _, f := range files {
+		name := strings.TrimSuffix(filepath.Base(f), ".html")
+		if strings.HasPrefix(name, "_") {
+			shared = append(shared, f)
+		} else {
+			layouts[name] = f
+		}
+	}
+	return layouts, shared, nil
+}
+
+// bufferPool recycles render buffers across requests to reduce allocations
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// GetBuffer returns an empty buffer from the pool, for rendering a response
+// before writing it. Return it with PutBuffer.
+func GetBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// PutBuffer returns buf to the pool, unless it grew over 1 MB
+func PutBuffer(buf *bytes.Buffer) {
+	// Don't keep unusually large buffers around
+	if buf.Cap() > 1<<20 {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// Render renders a template with status 200 (or whatever status the handler already wrote)
 func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, name string, data *TemplateData) error {
+	return r.RenderStatus(w, req, 0, name, data)
+}
+
+// RenderStatus renders a template into a pooled buffer and only writes the response
+// once execution succeeded, so template errors never produce half-written pages.
+// A status of 0 leaves the status code untouched (implicit 200).
+func (r *Renderer) RenderStatus(w http.ResponseWriter, req *http.Request, status int, name string, data *TemplateData) error {
+	data = prepare(req, data)
+	if r.announcements != nil && !data.IsHX {
+		data.Announcements = r.announcements(req)
+	}
+	if r.sessionExpiry != nil && !data.IsHX {
+		data.SessionExpiry = r.sessionExpiry(req)
+	}
+	r.reloadIfDebug()
+
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+
+	start := time.Now()
+	if err := r.execute(buf, name, data); err != nil {
+		r.renderFailed(w, req, name, data, err)
+		return err
+	}
+	if r.debugToolbar != nil {
+		debugbar.FromContext(req.Context()).AddRender(name, time.Since(start))
+		if !data.IsHX {
+			if err := r.debugToolbar.Inject(buf, req); err != nil {
+				utils.Warnw("debugbar.inject_failed", "template", name, "error", err)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if status != 0 {
+		w.WriteHeader(status)
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// renderFailed logs why name couldn't be rendered and answers with the error
+// partial (htmx) or error page instead. If that fails too, or is what failed,
+// the answer is a plain-text 500.
+func (r *Renderer) renderFailed(w http.ResponseWriter, req *http.Request, name string, data *TemplateData, err error) {
+	utils.Errorw("render.failed", "template", name, "data_keys", dataKeys(data), "path", req.URL.Path, "error", err)
+
+	fallback := r.errorPage
+	if data.IsHX {
+		fallback = r.errorPartial
+	}
+	if fallback == "" || fallback == name {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	message := "Something went wrong showing this page."
+	if r.debug {
+		message = err.Error()
+	}
+	fallbackData := prepare(req, &TemplateData{
+		Title: fmt.Sprintf("Error %d", http.StatusInternalServerError),
+		Data: map[string]interface{}{
+			"Status":  http.StatusInternalServerError,
+			"Message": message,
+		},
+	})
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if ferr := r.execute(buf, fallback, fallbackData); ferr != nil {
+		utils.Errorw("render.fallback_failed", "template", fallback, "error", ferr)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Error responses are only swapped when they set HX-Reswap (see layouts/_head.html)
+	if data.IsHX {
+		htmx.Reswap(w, "innerHTML")
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	buf.WriteTo(w)
+}
+
+// dataKeys returns the keys of data.Data, sorted, to log alongside render
+// errors without logging the values
+func dataKeys(data *TemplateData) []string {
+	keys := make([]string, 0, len(data.Data))
+	for key := range data.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UseErrorTemplates sets what's rendered, with status 500, when a template is
+// missing or fails: page for full page loads and partial for htmx requests.
+// Both get Status and Message in .Data, like RenderError's page. An empty
+// name answers those requests with a plain-text 500 instead.
+func (r *Renderer) UseErrorTemplates(page, partial string) error {
+	for _, name := range []string{page, partial} {
+		r.mu.RLock()
+		_, ok := r.templates[name]
+		r.mu.RUnlock()
+		if name != "" && !ok {
+			return fmt.Errorf("error template %s not found", name)
+		}
+	}
+	r.errorPage, r.errorPartial = page, partial
+	return nil
+}
+
+// UseAnnouncements sets where full pages get their banners from, typically
+// AnnouncementHandler.Banners. htmx requests skip it, since they don't
+// render the layout.
+func (r *Renderer) UseAnnouncements(fn func(*http.Request) []*models.Announcement) {
+	r.announcements = fn
+}
+
+// UseSessionExpiry sets where full pages learn when the signed-in user's
+// session ends, typically middleware.SessionExpiryFunc, so they can warn
+// before it does
+func (r *Renderer) UseSessionExpiry(fn func(*http.Request) *middleware.SessionExpiry) {
+	r.sessionExpiry = fn
+}
+
+// UseDebugToolbar adds tb to full pages, with the time each template took.
+// Only call it in debug mode (see debugbar.Toolbar).
+func (r *Renderer) UseDebugToolbar(tb *debugbar.Toolbar) {
+	r.debugToolbar = tb
+}
+
+// Blocks returns the index of the blocks each template set defines
+func (r *Renderer) Blocks() *BlockIndex {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.blocks
+}
+
+// Templates returns the parsed template sets by name ("posts/index.html",
+// "posts/index.html@print" for each layout), for tooling such as `gojang check`
+func (r *Renderer) Templates() map[string]*template.Template {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sets := make(map[string]*template.Template, len(r.templates))
+	for name, t := range r.templates {
+		sets[name] = t
+	}
+	return sets
+}
+
+// prepare fills in the fields every template can rely on
+func prepare(req *http.Request, data *TemplateData) *TemplateData {
 	if data == nil {
 		data = &TemplateData{}
 	}
@@ -148,21 +511,31 @@ func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, name string,
 	data.User = middleware.GetUser(req.Context())
 
 	// Check if htmx request
-	data.IsHX = req.Header.Get("HX-Request") == "true"
+	data.IsHX = htmx.IsRequest(req)
 	data.CurrentPath = req.URL.Path
+	data.Locale = ctxutil.Locale(req.Context())
+	return data
+}
 
-	// Reload templates in debug mode
-	if r.debug {
-		tmpl, err := parseTemplates()
-		if err == nil {
-			r.mu.Lock()
-			r.templates = tmpl
-			r.mu.Unlock()
-		}
+// reloadIfDebug re-parses the templates in debug mode, so edits show up
+// without a restart. While an edit has problems they're logged and the last
+// good templates are kept.
+func (r *Renderer) reloadIfDebug() {
+	if !r.debug {
+		return
 	}
+	tmpl, blocks, err := loadTemplates()
+	if err != nil {
+		utils.Warnw("templates.reload_failed", "error", err)
+		return
+	}
+	r.mu.Lock()
+	r.templates, r.blocks = tmpl, blocks
+	r.mu.Unlock()
+}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-
+// execute picks the right template (partial, content block, or full page) and executes it into buf
+func (r *Renderer) execute(buf *bytes.Buffer, name string, data *TemplateData) error {
 	// Check if htmx request for partial
 	partialName := name + ".partial.html"
 	if data.IsHX {
@@ -170,16 +543,22 @@ func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, name string,
 		tmpl, ok := r.templates[partialName]
 		r.mu.RUnlock()
 		if ok {
-			return tmpl.ExecuteTemplate(w, partialName, data)
+			return tmpl.ExecuteTemplate(buf, partialName, data)
 		}
 	}
 
-	// Get the template for this page
+	// Get the template for this page, in the requested layout if any
+	key := name
+	if data.Layout != "" && !data.IsHX {
+		key = name + "@" + data.Layout
+	}
 	r.mu.RLock()
-	tmpl, ok := r.templates[name]
+	tmpl, ok := r.templates[key]
 	r.mu.RUnlock()
+	if !ok && key != name {
+		return fmt.Errorf("template %s not found in layout %s", name, data.Layout)
+	}
 	if !ok {
-		utils.Errorf("Template '%s' not found", name)
 		return fmt.Errorf("template %s not found", name)
 	}
 
@@ -188,26 +567,21 @@ func (r *Renderer) Render(w http.ResponseWriter, req *http.Request, name string,
 
 	if isFragment {
 		// Execute the fragment template directly (no base.html wrapper)
-		return tmpl.Execute(w, data)
+		return tmpl.Execute(buf, data)
 	}
 
 	// For htmx requests to full pages, render only the content block
 	if data.IsHX {
 		// Execute just the "content" block without base.html wrapper
-		return tmpl.ExecuteTemplate(w, "content", data)
+		return tmpl.ExecuteTemplate(buf, "content", data)
 	}
 
-	// Execute base.html which will use the blocks defined in the specific template
-	err := tmpl.ExecuteTemplate(w, "base.html", data)
-	if err != nil {
-		utils.Errorf("Template execution failed: %v", err)
-	}
-	return err
+	// Execute the layout, which uses the blocks defined in the specific template
+	return tmpl.Execute(buf, data)
 }
 
 // RenderError renders an error page
 func (r *Renderer) RenderError(w http.ResponseWriter, req *http.Request, status int, message string) {
-	w.WriteHeader(status)
 	data := &TemplateData{
 		Title: fmt.Sprintf("Error %d", status),
 		Data: map[string]interface{}{
@@ -215,5 +589,5 @@ func (r *Renderer) RenderError(w http.ResponseWriter, req *http.Request, status
 			"Message": message,
 		},
 	}
-	_ = r.Render(w, req, "error.html", data)
+	_ = r.RenderStatus(w, req, status, "error.html", data)
 }
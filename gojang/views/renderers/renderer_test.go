@@ -1,7 +1,16 @@
 package renderers
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/gojangframework/gojang/gojang/http/middleware"
+	"github.com/gojangframework/gojang/gojang/models"
+	"github.com/google/uuid"
 )
 
 // Test template function: add
@@ -164,3 +173,118 @@ func TestTemplateFuncContains(t *testing.T) {
 		})
 	}
 }
+
+// TestRender_Layouts tests that pages use their layout directive and TemplateData.Layout overrides it
+func TestRender_Layouts(t *testing.T) {
+	t.Chdir("../../..") // Templates are loaded relative to the repository root
+	r, err := NewRenderer(false)
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	render := func(name string, data *TemplateData, hx bool) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if hx {
+			req.Header.Set("HX-Request", "true")
+		}
+		w := httptest.NewRecorder()
+		if err := r.Render(w, req, name, data); err != nil {
+			t.Fatalf("Render(%s) failed: %v", name, err)
+		}
+		return w.Body.String()
+	}
+
+	if body := render("home.html", nil, false); !strings.Contains(body, `class="nav"`) || !strings.Contains(body, "csrf-token") {
+		t.Error("Expected home.html in the base layout")
+	}
+	if body := render("auth/login.html", nil, false); !strings.Contains(body, "layout-minimal") || strings.Contains(body, `class="nav"`) {
+		t.Error("Expected auth/login.html in the minimal layout")
+	}
+	if body := render("home.html", &TemplateData{Layout: "print"}, false); !strings.Contains(body, "layout-print") || strings.Contains(body, `class="nav"`) {
+		t.Error("Expected the print layout override")
+	}
+	if body := render("home.html", &TemplateData{Layout: "print"}, true); strings.Contains(body, "<html") {
+		t.Error("Expected htmx requests to get only the content block")
+	}
+
+	w := httptest.NewRecorder()
+	if err := r.Render(w, httptest.NewRequest(http.MethodGet, "/", nil), "home.html", &TemplateData{Layout: "missing"}); err == nil {
+		t.Error("Expected an error for an unknown layout")
+	}
+}
+
+// TestRender_Components tests that pages and fragments can call the shared components
+func TestRender_Components(t *testing.T) {
+	t.Chdir("../../..") // Templates are loaded relative to the repository root
+	r, err := NewRenderer(false)
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/posts/new", nil)
+	data := &TemplateData{Errors: map[string]string{"Subject": "Subject is required"}}
+	if err := r.Render(w, req, "posts/new.partial.html", data); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	body := w.Body.String()
+	for _, expected := range []string{
+		`<h3>Create New Post</h3>`,
+		`<input type="text" id="subject" name="subject" value="" required maxlength="255"`,
+		`<textarea id="body" name="body" rows="15" required`,
+		`<span class="error">Subject is required</span>`,
+		`>Create Post</button>`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("posts/new.partial.html missing %q in:\n%s", expected, body)
+		}
+	}
+}
+
+// BenchmarkRender measures rendering a full page in its layout and an htmx
+// partial listing 50 posts, with the app's templates
+func BenchmarkRender(b *testing.B) {
+	b.Chdir("../../..") // Templates are loaded relative to the repository root
+	r, err := NewRenderer(false)
+	if err != nil {
+		b.Fatalf("NewRenderer failed: %v", err)
+	}
+
+	author := &models.User{ID: uuid.New(), Email: "author@example.com"}
+	posts := make([]*models.Post, 50)
+	for i := range posts {
+		posts[i] = &models.Post{
+			ID:        uuid.New(),
+			Subject:   fmt.Sprintf("Post %d", i),
+			Body:      strings.Repeat("Lorem ipsum dolor sit amet. ", 20),
+			CreatedAt: time.Now(),
+			Edges:     models.PostEdges{Author: author},
+		}
+	}
+
+	for _, bm := range []struct {
+		name, template string
+		data           *TemplateData
+		user           *models.User
+		hx             bool
+	}{
+		{"Page", "home.html", nil, nil, false},
+		{"Partial", "posts/list.partial.html", &TemplateData{Data: map[string]interface{}{"Posts": posts}}, author, true},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if bm.user != nil {
+				req = req.WithContext(middleware.WithUser(req.Context(), bm.user))
+			}
+			if bm.hx {
+				req.Header.Set("HX-Request", "true")
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := r.Render(httptest.NewRecorder(), req, bm.template, bm.data); err != nil {
+					b.Fatalf("Render(%s) failed: %v", bm.template, err)
+				}
+			}
+		})
+	}
+}
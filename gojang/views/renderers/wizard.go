@@ -0,0 +1,38 @@
+package renderers
+
+import (
+	"net/http"
+
+	"github.com/gojangframework/gojang/gojang/views/forms"
+)
+
+// RenderWizardStep renders the wizard's current step template. Besides data, the
+// step gets .Data.Wizard (a forms.WizardView), .Data.Values (what was entered so
+// far, by form field name), and the pre-rendered .Data.WizardProgress and
+// .Data.WizardNav (Back/Next buttons to place inside the step's form).
+func (r *Renderer) RenderWizardStep(w http.ResponseWriter, req *http.Request, wizard *forms.Wizard, data *TemplateData) error {
+	if data == nil {
+		data = &TemplateData{}
+	}
+	if data.Data == nil {
+		data.Data = make(map[string]interface{})
+	}
+
+	view := wizard.View(req.Context())
+	data.Data["Wizard"] = view
+	data.Data["Values"] = wizard.Values(req.Context())
+
+	partial := &TemplateData{Data: map[string]interface{}{"Wizard": view}}
+	progress, err := r.RenderPartial(req, "wizard/progress.partial.html", partial)
+	if err != nil {
+		return err
+	}
+	nav, err := r.RenderPartial(req, "wizard/nav.partial.html", partial)
+	if err != nil {
+		return err
+	}
+	data.Data["WizardProgress"] = progress
+	data.Data["WizardNav"] = nav
+
+	return r.Render(w, req, wizard.Current(req.Context()).Template, data)
+}
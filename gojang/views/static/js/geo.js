@@ -0,0 +1,194 @@
+// Map picker for <input data-geo="{tile URL}"> location fields ("lat,lng").
+// The input stays editable; clicking the map sets it, dragging pans and the
+// buttons zoom or use the browser's location. utils.ParsePoint validates it.
+(function () {
+    var TILE = 256;
+
+    function project(lat, lng, zoom) {
+        var size = TILE * Math.pow(2, zoom);
+        var sin = Math.min(Math.max(Math.sin(lat * Math.PI / 180), -0.9999), 0.9999);
+        return {
+            x: (lng + 180) / 360 * size,
+            y: (0.5 - Math.log((1 + sin) / (1 - sin)) / (4 * Math.PI)) * size
+        };
+    }
+
+    function unproject(x, y, zoom) {
+        var size = TILE * Math.pow(2, zoom);
+        var n = Math.PI - 2 * Math.PI * y / size;
+        var lng = x / size * 360 - 180;
+        lng = ((lng + 540) % 360) - 180;
+        return { lat: 180 / Math.PI * Math.atan(Math.sinh(n)), lng: lng };
+    }
+
+    function parse(value) {
+        var parts = (value || '').split(',');
+        if (parts.length !== 2) {
+            return null;
+        }
+        var lat = parseFloat(parts[0]), lng = parseFloat(parts[1]);
+        if (isNaN(lat) || isNaN(lng) || Math.abs(lat) > 90 || Math.abs(lng) > 180) {
+            return null;
+        }
+        return { lat: lat, lng: lng };
+    }
+
+    function init(input) {
+        if (input.dataset.geoReady) {
+            return;
+        }
+        input.dataset.geoReady = 'true';
+
+        var tiles = input.dataset.geo || 'https://tile.openstreetmap.org/{z}/{x}/{y}.png';
+        var point = parse(input.value);
+        var center = point || { lat: 20, lng: 0 };
+        var zoom = point ? 13 : 2;
+
+        var map = document.createElement('div');
+        map.className = 'geo-picker';
+        var layer = document.createElement('div');
+        var marker = document.createElement('span');
+        marker.className = 'geo-picker-marker';
+        var controls = document.createElement('div');
+        controls.className = 'geo-picker-controls';
+        var attribution = document.createElement('a');
+        attribution.className = 'geo-picker-attribution';
+        attribution.href = 'https://www.openstreetmap.org/copyright';
+        attribution.target = '_blank';
+        attribution.rel = 'noopener';
+        attribution.textContent = '© OpenStreetMap';
+
+        function button(label, title, onClick) {
+            var b = document.createElement('button');
+            b.type = 'button';
+            b.textContent = label;
+            b.title = title;
+            b.addEventListener('click', function (e) {
+                e.stopPropagation();
+                onClick();
+            });
+            b.addEventListener('pointerdown', function (e) { e.stopPropagation(); });
+            controls.appendChild(b);
+        }
+        button('+', 'Zoom in', function () { setZoom(zoom + 1); });
+        button('−', 'Zoom out', function () { setZoom(zoom - 1); });
+        if (navigator.geolocation) {
+            button('📍', 'Use my location', function () {
+                navigator.geolocation.getCurrentPosition(function (pos) {
+                    zoom = Math.max(zoom, 15);
+                    select({ lat: pos.coords.latitude, lng: pos.coords.longitude });
+                    center = point;
+                    render();
+                });
+            });
+        }
+
+        map.appendChild(layer);
+        map.appendChild(marker);
+        map.appendChild(controls);
+        map.appendChild(attribution);
+        input.parentNode.insertBefore(map, input.nextSibling);
+
+        function size() {
+            return { w: map.clientWidth || 400, h: map.clientHeight || 256 };
+        }
+
+        function render() {
+            var s = size();
+            var c = project(center.lat, center.lng, zoom);
+            var left = c.x - s.w / 2, top = c.y - s.h / 2;
+            var count = Math.pow(2, zoom);
+            layer.innerHTML = '';
+            for (var ty = Math.floor(top / TILE); ty <= Math.floor((top + s.h - 1) / TILE); ty++) {
+                for (var tx = Math.floor(left / TILE); tx <= Math.floor((left + s.w - 1) / TILE); tx++) {
+                    if (ty < 0 || ty >= count) {
+                        continue;
+                    }
+                    var img = document.createElement('img');
+                    img.alt = '';
+                    img.src = tiles.replace('{z}', zoom)
+                        .replace('{x}', ((tx % count) + count) % count)
+                        .replace('{y}', ty);
+                    img.style.left = Math.round(tx * TILE - left) + 'px';
+                    img.style.top = Math.round(ty * TILE - top) + 'px';
+                    layer.appendChild(img);
+                }
+            }
+            if (point) {
+                var p = project(point.lat, point.lng, zoom);
+                marker.style.display = '';
+                marker.style.left = Math.round(p.x - left) + 'px';
+                marker.style.top = Math.round(p.y - top) + 'px';
+            } else {
+                marker.style.display = 'none';
+            }
+        }
+
+        function setZoom(z) {
+            zoom = Math.min(Math.max(z, 1), 18);
+            render();
+        }
+
+        function select(p) {
+            input.value = p.lat.toFixed(6) + ',' + p.lng.toFixed(6);
+            point = parse(input.value);
+            input.dispatchEvent(new Event('change', { bubbles: true }));
+            render();
+        }
+
+        // Drag to pan; a press that barely moves picks the point under it
+        var drag = null;
+        map.addEventListener('pointerdown', function (e) {
+            drag = { x: e.clientX, y: e.clientY, start: project(center.lat, center.lng, zoom), moved: false };
+            map.setPointerCapture(e.pointerId);
+        });
+        map.addEventListener('pointermove', function (e) {
+            if (!drag) {
+                return;
+            }
+            var dx = e.clientX - drag.x, dy = e.clientY - drag.y;
+            if (Math.abs(dx) + Math.abs(dy) > 4) {
+                drag.moved = true;
+            }
+            if (drag.moved) {
+                center = unproject(drag.start.x - dx, drag.start.y - dy, zoom);
+                render();
+            }
+        });
+        map.addEventListener('pointerup', function (e) {
+            if (drag && !drag.moved) {
+                var rect = map.getBoundingClientRect();
+                var s = size();
+                var c = project(center.lat, center.lng, zoom);
+                select(unproject(c.x - s.w / 2 + e.clientX - rect.left, c.y - s.h / 2 + e.clientY - rect.top, zoom));
+            }
+            drag = null;
+        });
+
+        // Typing coordinates moves the map to them
+        input.addEventListener('change', function () {
+            var p = parse(input.value);
+            if (p && (!point || p.lat !== point.lat || p.lng !== point.lng)) {
+                point = p;
+                center = p;
+                render();
+            }
+        });
+
+        render();
+    }
+
+    function initAll(root) {
+        var fields = (root || document).querySelectorAll('input[data-geo]');
+        for (var i = 0; i < fields.length; i++) {
+            init(fields[i]);
+        }
+    }
+
+    // Pickers in forms loaded by htmx (e.g., modals) are set up when swapped in
+    if (window.htmx) {
+        htmx.onLoad(initAll);
+    } else {
+        document.addEventListener('DOMContentLoaded', function () { initAll(document); });
+    }
+})();
@@ -0,0 +1,110 @@
+// Passkeys (WebAuthn) for <button data-passkey="register"> on the security
+// page and <button data-passkey="login"> on the login page. The server sends
+// binary fields as base64url; they're converted to and from ArrayBuffers here.
+(function () {
+    function decode(value) {
+        var s = value.replace(/-/g, '+').replace(/_/g, '/');
+        var bin = atob(s + '==='.slice((s.length + 3) % 4));
+        var bytes = new Uint8Array(bin.length);
+        for (var i = 0; i < bin.length; i++) {
+            bytes[i] = bin.charCodeAt(i);
+        }
+        return bytes.buffer;
+    }
+
+    function encode(buffer) {
+        if (!buffer) {
+            return null;
+        }
+        var bytes = new Uint8Array(buffer), bin = '';
+        for (var i = 0; i < bytes.length; i++) {
+            bin += String.fromCharCode(bytes[i]);
+        }
+        return btoa(bin).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+    }
+
+    function post(url, body) {
+        var token = document.querySelector('meta[name="csrf-token"]');
+        return fetch(url, {
+            method: 'POST',
+            credentials: 'same-origin',
+            headers: { 'Content-Type': 'application/json', 'X-CSRF-Token': token ? token.content : '' },
+            body: body === undefined ? '{}' : JSON.stringify(body)
+        }).then(function (res) {
+            return res.json().then(function (data) {
+                if (!res.ok) {
+                    throw new Error(data.error || 'Request failed');
+                }
+                return data;
+            });
+        });
+    }
+
+    function register(button) {
+        var input = document.getElementById(button.dataset.passkeyName || '');
+        return post('/account/passkeys/options').then(function (data) {
+            var options = data.publicKey;
+            options.challenge = decode(options.challenge);
+            options.user.id = decode(options.user.id);
+            options.excludeCredentials.forEach(function (c) { c.id = decode(c.id); });
+            return navigator.credentials.create({ publicKey: options });
+        }).then(function (cred) {
+            return post('/account/passkeys', {
+                name: input ? input.value : '',
+                credential: {
+                    rawId: encode(cred.rawId),
+                    response: {
+                        clientDataJSON: encode(cred.response.clientDataJSON),
+                        attestationObject: encode(cred.response.attestationObject)
+                    }
+                }
+            });
+        });
+    }
+
+    function login(button) {
+        var next = button.dataset.next ? '?next=' + encodeURIComponent(button.dataset.next) : '';
+        return post('/login/passkey/options').then(function (data) {
+            var options = data.publicKey;
+            options.challenge = decode(options.challenge);
+            return navigator.credentials.get({ publicKey: options });
+        }).then(function (cred) {
+            return post('/login/passkey' + next, {
+                rawId: encode(cred.rawId),
+                response: {
+                    clientDataJSON: encode(cred.response.clientDataJSON),
+                    authenticatorData: encode(cred.response.authenticatorData),
+                    signature: encode(cred.response.signature),
+                    userHandle: encode(cred.response.userHandle)
+                }
+            });
+        });
+    }
+
+    document.addEventListener('click', function (evt) {
+        var button = evt.target.closest('[data-passkey]');
+        if (!button) {
+            return;
+        }
+        evt.preventDefault();
+        var error = document.getElementById(button.dataset.passkeyError || '');
+        if (!window.PublicKeyCredential) {
+            if (error) {
+                error.textContent = "This browser doesn't support passkeys.";
+            }
+            return;
+        }
+
+        button.disabled = true;
+        var ceremony = button.dataset.passkey === 'register' ? register : login;
+        ceremony(button).then(function (data) {
+            window.location.href = data.redirect;
+        }).catch(function (err) {
+            button.disabled = false;
+            // Cancelling the browser's prompt isn't worth an error message
+            if (error && err.name !== 'NotAllowedError') {
+                error.textContent = err.message;
+            }
+        });
+    });
+})();
@@ -0,0 +1,97 @@
+// Lightweight rich text editor for <textarea data-richtext> fields.
+// The textarea stays the form field (hidden) and is kept in sync with an
+// editable area; the server sanitizes the HTML (utils.SanitizeHTML).
+(function () {
+    var buttons = [
+        { cmd: 'bold', label: 'B', title: 'Bold' },
+        { cmd: 'italic', label: 'I', title: 'Italic' },
+        { cmd: 'underline', label: 'U', title: 'Underline' },
+        { cmd: 'formatBlock', arg: 'h2', label: 'H2', title: 'Heading' },
+        { cmd: 'formatBlock', arg: 'h3', label: 'H3', title: 'Subheading' },
+        { cmd: 'formatBlock', arg: 'p', label: '¶', title: 'Paragraph' },
+        { cmd: 'insertUnorderedList', label: '•', title: 'Bulleted list' },
+        { cmd: 'insertOrderedList', label: '1.', title: 'Numbered list' },
+        { cmd: 'formatBlock', arg: 'blockquote', label: '❝', title: 'Quote' },
+        { cmd: 'createLink', label: '🔗', title: 'Link' },
+        { cmd: 'removeFormat', label: '⨯', title: 'Clear formatting' }
+    ];
+
+    function init(textarea) {
+        if (textarea.dataset.richtextReady) {
+            return;
+        }
+        textarea.dataset.richtextReady = 'true';
+
+        var wrapper = document.createElement('div');
+        wrapper.className = 'richtext';
+
+        var toolbar = document.createElement('div');
+        toolbar.className = 'richtext-toolbar';
+
+        var editor = document.createElement('div');
+        editor.className = 'richtext-editor';
+        editor.contentEditable = 'true';
+        editor.setAttribute('role', 'textbox');
+        editor.setAttribute('aria-multiline', 'true');
+        var label = textarea.id && document.querySelector('label[for="' + textarea.id + '"]');
+        if (label) {
+            editor.setAttribute('aria-label', label.textContent.trim());
+            label.addEventListener('click', function () { editor.focus(); });
+        }
+        editor.innerHTML = textarea.value;
+
+        buttons.forEach(function (b) {
+            var button = document.createElement('button');
+            button.type = 'button';
+            button.textContent = b.label;
+            button.title = b.title;
+            button.addEventListener('mousedown', function (e) {
+                e.preventDefault(); // Keep the selection in the editor
+            });
+            button.addEventListener('click', function () {
+                var arg = b.arg || null;
+                if (b.cmd === 'createLink') {
+                    arg = window.prompt('Link URL (https://...)');
+                    if (!arg) {
+                        return;
+                    }
+                }
+                document.execCommand(b.cmd, false, arg);
+                sync();
+                editor.focus();
+            });
+            toolbar.appendChild(button);
+        });
+
+        function sync() {
+            textarea.value = editor.innerHTML;
+        }
+        editor.addEventListener('input', sync);
+        editor.addEventListener('blur', sync);
+        if (textarea.form) {
+            textarea.form.addEventListener('submit', sync);
+        }
+
+        // A hidden required field would block submission; the server validates it
+        textarea.required = false;
+        textarea.style.display = 'none';
+        textarea.parentNode.insertBefore(wrapper, textarea);
+        wrapper.appendChild(toolbar);
+        wrapper.appendChild(editor);
+        wrapper.appendChild(textarea);
+    }
+
+    function initAll(root) {
+        var fields = (root || document).querySelectorAll('textarea[data-richtext]');
+        for (var i = 0; i < fields.length; i++) {
+            init(fields[i]);
+        }
+    }
+
+    // Editors in forms loaded by htmx (e.g., modals) are set up when swapped in
+    if (window.htmx) {
+        htmx.onLoad(initAll);
+    } else {
+        document.addEventListener('DOMContentLoaded', function () { initAll(document); });
+    }
+})();
@@ -0,0 +1,86 @@
+// Session expiry warning for the "session_expiry" component. The server ends
+// a session data-idle seconds after its last request, and data-remaining
+// seconds from page load however active the user is. data-warn seconds before
+// either, the dialog counts down; once it's over the page goes to the login.
+// "Stay signed in" posts to /session/keepalive with htmx, and every htmx
+// request (in any tab, shared through localStorage) counts as activity.
+(function () {
+    var root = document.getElementById('session-expiry');
+    if (!root) {
+        return;
+    }
+    var idle = Number(root.dataset.idle) * 1000;
+    var warn = Number(root.dataset.warn) * 1000;
+    var deadline = Date.now() + Number(root.dataset.remaining) * 1000;
+    var activeKey = 'session.lastActive';
+    var lastActive = Date.now();
+
+    function touch() {
+        lastActive = Date.now();
+        try {
+            localStorage.setItem(activeKey, String(lastActive));
+        } catch (e) {
+            // Private browsing may refuse storage; this tab still counts
+        }
+    }
+
+    function idleEnd() {
+        var shared = 0;
+        try {
+            shared = Number(localStorage.getItem(activeKey)) || 0;
+        } catch (e) {}
+        return Math.max(lastActive, shared) + idle;
+    }
+
+    function format(ms) {
+        var s = Math.max(0, Math.ceil(ms / 1000));
+        var m = Math.floor(s / 60);
+        s = s % 60;
+        return m + ':' + (s < 10 ? '0' : '') + s;
+    }
+
+    function show(selector, visible) {
+        root.querySelectorAll(selector).forEach(function (el) {
+            el.hidden = !visible;
+        });
+    }
+
+    function tick() {
+        var now = Date.now();
+        var byIdle = idle > 0 && idleEnd() < deadline;
+        var end = byIdle ? idleEnd() : deadline;
+        if (end <= now) {
+            var next = window.location.pathname + window.location.search;
+            window.location.href = '/login?expired=1&next=' + encodeURIComponent(next);
+            return;
+        }
+        root.hidden = end - now > warn;
+        if (!root.hidden) {
+            // Staying active only helps against the idle timeout
+            show('[data-session-idle]', byIdle);
+            show('[data-session-lifetime]', !byIdle);
+            root.querySelectorAll('[data-session-countdown]').forEach(function (el) {
+                el.textContent = format(end - now);
+            });
+        }
+    }
+
+    document.addEventListener('htmx:afterRequest', function (evt) {
+        var xhr = evt.detail.xhr;
+        if (evt.detail.requestConfig && evt.detail.requestConfig.path === '/session/keepalive') {
+            if (xhr.status === 401) {
+                deadline = 0; // Already over; tick() goes to the login
+            } else if (evt.detail.successful) {
+                deadline = Date.now() + JSON.parse(xhr.responseText).remaining * 1000;
+            }
+        }
+        if (evt.detail.successful) {
+            touch();
+        }
+        tick();
+    });
+
+    touch();
+    tick();
+    setInterval(tick, 1000);
+})();
@@ -0,0 +1,105 @@
+package webauthn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"testing"
+)
+
+// encodeCBOR encodes the values decodeCBOR returns, for building test
+// credentials. Map keys are sorted so the output is stable.
+func encodeCBOR(v interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 1<<8:
+			return []byte{major<<5 | 24, byte(n)}
+		case n < 1<<16:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+		default:
+			return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+		}
+	}
+	switch v := v.(type) {
+	case int:
+		return encodeCBOR(int64(v))
+	case int64:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case bool:
+		if v {
+			return []byte{0xf5}
+		}
+		return []byte{0xf4}
+	case nil:
+		return []byte{0xf6}
+	case []interface{}:
+		out := head(4, uint64(len(v)))
+		for _, item := range v {
+			out = append(out, encodeCBOR(item)...)
+		}
+		return out
+	case map[interface{}]interface{}:
+		var pairs [][]byte
+		for k, item := range v {
+			pairs = append(pairs, append(encodeCBOR(k), encodeCBOR(item)...))
+		}
+		sort.Slice(pairs, func(i, j int) bool { return bytes.Compare(pairs[i], pairs[j]) < 0 })
+		out := head(5, uint64(len(v)))
+		for _, p := range pairs {
+			out = append(out, p...)
+		}
+		return out
+	}
+	panic("encodeCBOR: unsupported type")
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	in := map[interface{}]interface{}{
+		"fmt":     "none",
+		int64(1):  int64(2),
+		int64(-2): []byte{1, 2, 3},
+		"list":    []interface{}{int64(500), int64(-70000), true, nil},
+	}
+	data := append(encodeCBOR(in), 0xaa)
+	out, rest, err := decodeCBOR(data)
+	if err != nil {
+		t.Fatalf("decodeCBOR: %v", err)
+	}
+	if !bytes.Equal(rest, []byte{0xaa}) {
+		t.Errorf("rest = %x, want aa", rest)
+	}
+	m := out.(map[interface{}]interface{})
+	list := m["list"].([]interface{})
+	if m["fmt"] != "none" || m[int64(1)] != int64(2) || !bytes.Equal(m[int64(-2)].([]byte), []byte{1, 2, 3}) ||
+		list[0] != int64(500) || list[1] != int64(-70000) || list[2] != true || list[3] != nil {
+		t.Errorf("Unexpected result %#v", out)
+	}
+}
+
+// TestDecodeCBOR_Malformed tests that truncated or hostile input is refused without panicking
+func TestDecodeCBOR_Malformed(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"empty":             {},
+		"truncated string":  {0x45, 1, 2},
+		"truncated length":  {0x59, 0x01},
+		"huge array":        {0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"float":             {0xf9, 0x3c, 0x00},
+		"indefinite string": {0x5f, 0x41, 0x00, 0xff},
+		"array key":         {0xa1, 0x80, 0x01},
+		"tag":               {0xc1, 0x01},
+		"deep nesting":      bytes.Repeat([]byte{0x81}, 100),
+	} {
+		if _, _, err := decodeCBOR(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
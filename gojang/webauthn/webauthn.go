@@ -0,0 +1,332 @@
+// Package webauthn implements passkeys: registering a public key credential
+// with navigator.credentials.create and signing in with
+// navigator.credentials.get (WebAuthn Level 2).
+//
+// Attestation isn't requested, so any authenticator is accepted and nothing
+// about its make is trusted. Credentials are ES256 (P-256) or RS256 keys,
+// which every platform authenticator and security key supports.
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalid is wrapped by every verification failure
+var ErrInvalid = errors.New("webauthn: verification failed")
+
+// COSE algorithm identifiers of the supported keys
+const (
+	AlgES256 = -7
+	AlgRS256 = -257
+)
+
+// Authenticator data flags
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+// RelyingParty is the site credentials are registered with
+type RelyingParty struct {
+	ID      string        // The site's domain, e.g. example.com
+	Name    string        // Shown by the browser while creating a passkey
+	Origin  string        // Where ceremonies run, e.g. https://example.com
+	Timeout time.Duration // How long the browser waits for the user
+}
+
+// New returns the relying party for a site served at siteURL
+func New(siteURL, name string) (*RelyingParty, error) {
+	u, err := url.Parse(siteURL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("webauthn: %q isn't a site URL", siteURL)
+	}
+	return &RelyingParty{
+		ID:      u.Hostname(),
+		Name:    name,
+		Origin:  u.Scheme + "://" + u.Host,
+		Timeout: 5 * time.Minute,
+	}, nil
+}
+
+// Credential is a registered passkey, as stored for a user
+type Credential struct {
+	ID        []byte
+	PublicKey []byte // COSE_Key, as sent by the authenticator
+	SignCount uint32
+}
+
+// Bytes is binary data sent as base64url in JSON, as browsers' toJSON() does
+type Bytes []byte
+
+// MarshalJSON encodes b as unpadded base64url
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// UnmarshalJSON decodes base64url, padded or not
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// CreationResponse is the credential from navigator.credentials.create
+type CreationResponse struct {
+	RawID    Bytes `json:"rawId"`
+	Response struct {
+		ClientDataJSON    Bytes `json:"clientDataJSON"`
+		AttestationObject Bytes `json:"attestationObject"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the credential from navigator.credentials.get
+type AssertionResponse struct {
+	RawID    Bytes `json:"rawId"`
+	Response struct {
+		ClientDataJSON    Bytes `json:"clientDataJSON"`
+		AuthenticatorData Bytes `json:"authenticatorData"`
+		Signature         Bytes `json:"signature"`
+		UserHandle        Bytes `json:"userHandle"`
+	} `json:"response"`
+}
+
+// NewChallenge returns a random challenge for one ceremony. Keep it on the
+// server (e.g. in the session) and use it only once.
+func NewChallenge() []byte {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return b
+}
+
+// CreationOptions returns the options for navigator.credentials.create, for
+// a user identified by userID (not their email, which can change). Credentials
+// in exclude are already registered, so the same authenticator isn't added twice.
+func (rp *RelyingParty) CreationOptions(challenge, userID []byte, name, displayName string, exclude [][]byte) map[string]interface{} {
+	excluded := []map[string]interface{}{}
+	for _, id := range exclude {
+		excluded = append(excluded, map[string]interface{}{"type": "public-key", "id": Bytes(id)})
+	}
+	return map[string]interface{}{
+		"challenge": Bytes(challenge),
+		"rp":        map[string]string{"id": rp.ID, "name": rp.Name},
+		"user":      map[string]interface{}{"id": Bytes(userID), "name": name, "displayName": displayName},
+		"pubKeyCredParams": []map[string]interface{}{
+			{"type": "public-key", "alg": AlgES256},
+			{"type": "public-key", "alg": AlgRS256},
+		},
+		"timeout":            rp.Timeout.Milliseconds(),
+		"attestation":        "none",
+		"excludeCredentials": excluded,
+		"authenticatorSelection": map[string]string{
+			"residentKey":      "required", // Passkeys sign in without a username
+			"userVerification": "preferred",
+		},
+	}
+}
+
+// RequestOptions returns the options for navigator.credentials.get. No
+// credentials are listed, so the browser offers every passkey for the site.
+func (rp *RelyingParty) RequestOptions(challenge []byte) map[string]interface{} {
+	return map[string]interface{}{
+		"challenge":        Bytes(challenge),
+		"rpId":             rp.ID,
+		"timeout":          rp.Timeout.Milliseconds(),
+		"userVerification": "required", // A passkey is the only factor, so it needs a PIN or biometric
+	}
+}
+
+// VerifyRegistration checks a new credential against the challenge it was
+// created for and returns it for storing
+func (rp *RelyingParty) VerifyRegistration(challenge []byte, res *CreationResponse) (*Credential, error) {
+	if err := rp.verifyClientData(res.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := decodeCBOR(res.Response.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	obj, _ := decoded.(map[interface{}]interface{})
+	raw, _ := obj["authData"].([]byte)
+	data, err := rp.parseAuthData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if data.flags&flagAttested == 0 {
+		return nil, fmt.Errorf("%w: no credential in authenticator data", ErrInvalid)
+	}
+	if !bytes.Equal(data.credentialID, res.RawID) {
+		return nil, fmt.Errorf("%w: credential ID mismatch", ErrInvalid)
+	}
+	if _, _, err := parsePublicKey(data.publicKey); err != nil {
+		return nil, err
+	}
+	return &Credential{ID: data.credentialID, PublicKey: data.publicKey, SignCount: data.signCount}, nil
+}
+
+// VerifyLogin checks a sign-in with cred against the challenge it was made
+// for, and returns the authenticator's new signature counter to store. The
+// authenticator must have verified the user (PIN or biometric), not just
+// seen them present, since the passkey signs in without a password.
+func (rp *RelyingParty) VerifyLogin(challenge []byte, res *AssertionResponse, cred *Credential) (uint32, error) {
+	if !bytes.Equal(res.RawID, cred.ID) {
+		return 0, fmt.Errorf("%w: credential ID mismatch", ErrInvalid)
+	}
+	if err := rp.verifyClientData(res.Response.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+	data, err := rp.parseAuthData(res.Response.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+	if data.flags&flagUserVerified == 0 {
+		return 0, fmt.Errorf("%w: user not verified", ErrInvalid)
+	}
+
+	pub, alg, err := parsePublicKey(cred.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientHash := sha256.Sum256(res.Response.ClientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, res.Response.AuthenticatorData...), clientHash[:]...))
+	var valid bool
+	switch alg {
+	case AlgES256:
+		valid = ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), digest[:], res.Response.Signature)
+	case AlgRS256:
+		valid = rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA256, digest[:], res.Response.Signature) == nil
+	}
+	if !valid {
+		return 0, fmt.Errorf("%w: bad signature", ErrInvalid)
+	}
+
+	// Counters only go up; one that doesn't suggests a cloned authenticator.
+	// Synced passkeys always send 0.
+	if (data.signCount != 0 || cred.SignCount != 0) && data.signCount <= cred.SignCount {
+		return 0, fmt.Errorf("%w: signature counter went backwards", ErrInvalid)
+	}
+	return data.signCount, nil
+}
+
+// verifyClientData checks the ceremony type, challenge and origin the browser signed
+func (rp *RelyingParty) verifyClientData(raw []byte, typ string, challenge []byte) error {
+	var client struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	if err := json.Unmarshal(raw, &client); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	switch {
+	case client.Type != typ:
+		return fmt.Errorf("%w: expected %s, got %q", ErrInvalid, typ, client.Type)
+	case len(challenge) == 0 || client.Challenge != base64.RawURLEncoding.EncodeToString(challenge):
+		return fmt.Errorf("%w: challenge mismatch", ErrInvalid)
+	case client.Origin != rp.Origin:
+		return fmt.Errorf("%w: unexpected origin %q", ErrInvalid, client.Origin)
+	}
+	return nil
+}
+
+type authData struct {
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	publicKey    []byte // Only when flagAttested is set
+}
+
+// parseAuthData parses authenticator data, checking it's for this site and
+// that the user was present
+func (rp *RelyingParty) parseAuthData(b []byte) (*authData, error) {
+	if len(b) < 37 {
+		return nil, fmt.Errorf("%w: authenticator data too short", ErrInvalid)
+	}
+	rpHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(b[:32], rpHash[:]) {
+		return nil, fmt.Errorf("%w: credential is for another site", ErrInvalid)
+	}
+	data := &authData{flags: b[32], signCount: binary.BigEndian.Uint32(b[33:37])}
+	if data.flags&flagUserPresent == 0 {
+		return nil, fmt.Errorf("%w: user not present", ErrInvalid)
+	}
+
+	if data.flags&flagAttested != 0 {
+		// AAGUID (16 bytes), credential ID length (2) and ID, then the COSE key
+		rest := b[37:]
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("%w: attested credential data too short", ErrInvalid)
+		}
+		n := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if n == 0 || len(rest) < n {
+			return nil, fmt.Errorf("%w: bad credential ID", ErrInvalid)
+		}
+		data.credentialID, rest = rest[:n], rest[n:]
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad public key: %v", ErrInvalid, err)
+		}
+		data.publicKey = rest[:len(rest)-len(after)]
+	}
+	return data, nil
+}
+
+// parsePublicKey returns the key and algorithm in a COSE_Key (RFC 9053)
+func parsePublicKey(cose []byte) (crypto.PublicKey, int64, error) {
+	decoded, _, err := decodeCBOR(cose)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: bad public key: %v", ErrInvalid, err)
+	}
+	key, _ := decoded.(map[interface{}]interface{})
+	kty, _ := key[int64(1)].(int64)
+	alg, _ := key[int64(3)].(int64)
+
+	switch {
+	case kty == 2 && alg == AlgES256:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, fmt.Errorf("%w: unsupported EC key", ErrInvalid)
+		}
+		// ecdh checks the point is on the curve
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid EC key", ErrInvalid)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, alg, nil
+	case kty == 3 && alg == AlgRS256:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, fmt.Errorf("%w: unsupported RSA key", ErrInvalid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	}
+	return nil, 0, fmt.Errorf("%w: unsupported key type %d, algorithm %d", ErrInvalid, kty, alg)
+}
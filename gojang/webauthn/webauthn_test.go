@@ -0,0 +1,178 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// authenticator is a software passkey for one site
+type authenticator struct {
+	key       *ecdsa.PrivateKey
+	id        []byte
+	rpID      string
+	origin    string
+	signCount uint32
+	skipUV    bool // Only test the user's presence, like a security key without a PIN
+}
+
+func newAuthenticator(t *testing.T, rpID, origin string) *authenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &authenticator{key: key, id: []byte("credential-1"), rpID: rpID, origin: origin}
+}
+
+func (a *authenticator) clientData(typ string, challenge []byte) []byte {
+	data, _ := json.Marshal(map[string]string{
+		"type":      typ,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    a.origin,
+	})
+	return data
+}
+
+func (a *authenticator) authData(flags byte, attested []byte) []byte {
+	hash := sha256.Sum256([]byte(a.rpID))
+	data := append(hash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+// create answers navigator.credentials.create
+func (a *authenticator) create(challenge []byte) *CreationResponse {
+	x, y := make([]byte, 32), make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	cose := encodeCBOR(map[interface{}]interface{}{int64(1): int64(2), int64(3): int64(AlgES256), int64(-1): int64(1), int64(-2): x, int64(-3): y})
+
+	attested := make([]byte, 16) // AAGUID
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.id)))
+	attested = append(append(attested, a.id...), cose...)
+
+	res := &CreationResponse{RawID: a.id}
+	res.Response.ClientDataJSON = a.clientData("webauthn.create", challenge)
+	res.Response.AttestationObject = encodeCBOR(map[interface{}]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[interface{}]interface{}{},
+		"authData": a.authData(flagUserPresent|flagUserVerified|flagAttested, attested),
+	})
+	return res
+}
+
+// get answers navigator.credentials.get
+func (a *authenticator) get(challenge []byte) *AssertionResponse {
+	a.signCount++
+	res := &AssertionResponse{RawID: a.id}
+	res.Response.ClientDataJSON = a.clientData("webauthn.get", challenge)
+	flags := byte(flagUserPresent | flagUserVerified)
+	if a.skipUV {
+		flags = flagUserPresent
+	}
+	res.Response.AuthenticatorData = a.authData(flags, nil)
+	clientHash := sha256.Sum256(res.Response.ClientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, res.Response.AuthenticatorData...), clientHash[:]...))
+	res.Response.Signature, _ = ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	return res
+}
+
+func TestNew(t *testing.T) {
+	rp, err := New("https://example.com:8443/", "Example")
+	if err != nil || rp.ID != "example.com" || rp.Origin != "https://example.com:8443" {
+		t.Errorf("New = %+v, %v", rp, err)
+	}
+	if _, err := New("example.com", "Example"); err == nil {
+		t.Error("Expected an error for a URL without a scheme")
+	}
+}
+
+// TestRegisterAndLogin tests both ceremonies with a software authenticator
+func TestRegisterAndLogin(t *testing.T) {
+	rp, _ := New("https://example.com", "Example")
+	auth := newAuthenticator(t, "example.com", "https://example.com")
+
+	challenge := NewChallenge()
+	cred, err := rp.VerifyRegistration(challenge, auth.create(challenge))
+	if err != nil {
+		t.Fatalf("VerifyRegistration: %v", err)
+	}
+	if string(cred.ID) != "credential-1" || cred.SignCount != 0 {
+		t.Errorf("Unexpected credential %+v", cred)
+	}
+
+	challenge = NewChallenge()
+	count, err := rp.VerifyLogin(challenge, auth.get(challenge), cred)
+	if err != nil || count != 1 {
+		t.Fatalf("VerifyLogin = %d, %v", count, err)
+	}
+	cred.SignCount = count
+
+	// A replayed response fails on the challenge, and a stale counter on the counter
+	res := auth.get(challenge)
+	if _, err := rp.VerifyLogin(NewChallenge(), res, cred); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected a challenge mismatch, got %v", err)
+	}
+	cred.SignCount = 10
+	if _, err := rp.VerifyLogin(challenge, res, cred); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected a counter error, got %v", err)
+	}
+	cred.SignCount = 0
+
+	// Tampered authenticator data no longer matches the signature
+	res = auth.get(challenge)
+	res.Response.AuthenticatorData[33] ^= 0xff
+	if _, err := rp.VerifyLogin(challenge, res, cred); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected a bad signature, got %v", err)
+	}
+
+	// Holding the authenticator isn't enough; it has to verify the user
+	auth.skipUV = true
+	if _, err := rp.VerifyLogin(challenge, auth.get(challenge), cred); err == nil || !strings.Contains(err.Error(), "not verified") {
+		t.Errorf("Expected an assertion without user verification to be refused, got %v", err)
+	}
+	if uv := rp.RequestOptions(challenge)["userVerification"]; uv != "required" {
+		t.Errorf("Expected sign-ins to require user verification, got %v", uv)
+	}
+}
+
+// TestVerify_WrongSite tests that credentials made on another origin or for another site are refused
+func TestVerify_WrongSite(t *testing.T) {
+	rp, _ := New("https://example.com", "Example")
+	challenge := NewChallenge()
+
+	phishing := newAuthenticator(t, "example.com", "https://examp1e.com")
+	if _, err := rp.VerifyRegistration(challenge, phishing.create(challenge)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected an origin error, got %v", err)
+	}
+	other := newAuthenticator(t, "other.com", "https://example.com")
+	if _, err := rp.VerifyRegistration(challenge, other.create(challenge)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected an RP ID error, got %v", err)
+	}
+
+	// A login response isn't accepted as a registration
+	auth := newAuthenticator(t, "example.com", "https://example.com")
+	res := auth.create(challenge)
+	res.Response.ClientDataJSON = auth.clientData("webauthn.get", challenge)
+	if _, err := rp.VerifyRegistration(challenge, res); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected a type error, got %v", err)
+	}
+}
+
+func TestBytesJSON(t *testing.T) {
+	var b Bytes
+	if err := json.Unmarshal([]byte(`"aGk="`), &b); err != nil || string(b) != "hi" {
+		t.Errorf("Unmarshal = %q, %v", b, err)
+	}
+	if out, _ := json.Marshal(Bytes("hi")); string(out) != `"aGk"` {
+		t.Errorf("Marshal = %s", out)
+	}
+}